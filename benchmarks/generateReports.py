@@ -13,8 +13,8 @@ BENCHMARKS = [
     "CreateRun",
     # "LogMetricSingle",
     "LogMetricBatch10",
-    # "LogMetricBatch1000",
     "LogMetricBatch500",
+    "LogMetricBatch1000",
     "LogParamBatch10",
     "LogParamBatch100",
 ]
@@ -44,6 +44,7 @@ export default function () {
   let metrics10 = []
   let metrics100 = []
   let metrics500 = []
+  let metrics1000 = []
 
 
   for (let id = 1; id <= 500; id++) {
@@ -87,6 +88,16 @@ export default function () {
       value: `${id * Math.random()}`,
     })
 
+    // add metrics
+    for (let step = 1; step < 3; step++) {
+      metrics1000.push({
+        key: `metric1000-${id}`,
+        value: id * step * Math.random(),
+        timestamp: Date.now(),
+        step: step
+      })
+    }
+
     // add metrics
     for (let step = 1; step < 3; step++) {
       metrics500.push({
@@ -171,6 +182,23 @@ export default function () {
     }
   );
 
+  // test logging metric only batch of 1000
+  http.post(
+    base_url + 'runs/log-batch',
+    JSON.stringify({
+      run_id: runId,
+      metrics: metrics1000,
+    }),
+    {
+      headers: {
+        'Content-Type': 'application/json'
+      },
+      tags: {
+        name: 'LogMetricBatch1000',
+      },
+    }
+  );
+
   //test logging params only batch 10
   http.post(
     base_url + 'runs/log-batch',
@@ -188,6 +216,23 @@ export default function () {
     }
   );
 
+  // test logging metric only batch of 1000
+  http.post(
+    base_url + 'runs/log-batch',
+    JSON.stringify({
+      run_id: runId,
+      metrics: metrics1000,
+    }),
+    {
+      headers: {
+        'Content-Type': 'application/json'
+      },
+      tags: {
+        name: 'LogMetricBatch1000',
+      },
+    }
+  );
+
   //test logging params only batch 100
   http.post(
     base_url + 'runs/log-batch',
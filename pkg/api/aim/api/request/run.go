@@ -31,6 +31,12 @@ type GetRunImagesRequest []struct {
 	Context map[string]string `json:"context"`
 }
 
+// GetRunArtifactsRequest is a request object for `POST /runs/:id/:sequence/get-batch` endpoint.
+type GetRunArtifactsRequest []struct {
+	Name    string            `json:"name"`
+	Context map[string]string `json:"context"`
+}
+
 // GetRunImagesBatchRequest is a request object for `POST /runs/images/get-batch` endpoint.
 type GetRunImagesBatchRequest []string
 
@@ -39,6 +45,11 @@ type GetRunsActiveRequest struct {
 	BaseSearchRequest
 }
 
+// GetRunsLiveRequest is a request object for `GET /runs/live` endpoint.
+type GetRunsLiveRequest struct {
+	RunIDs []string `query:"run_id"`
+}
+
 // UpdateRunRequest is a request struct for `PUT /runs/:id` endpoint.
 type UpdateRunRequest struct {
 	ID          string  `params:"id"`
@@ -83,6 +94,7 @@ type SearchMetricsRequest struct {
 	Steps      int           `json:"steps"`
 	XAxis      string        `json:"x_axis"`
 	SkipSystem bool          `json:"skip_system"`
+	Sampling   string        `json:"sampling"`
 }
 
 // SearchAlignedMetricsRequest is a request struct for `GET /runs/search/metric/align` endpoint.
@@ -98,9 +110,10 @@ type SearchAlignedMetricsRequest struct {
 	AlignBy string `json:"align_by"`
 }
 
-// SearchArtifactsRequest is a request struct for `POST /runs/search/image` endpoint.
+// SearchArtifactsRequest is a request struct for `POST /runs/search/:sequence` endpoint.
 type SearchArtifactsRequest struct {
 	BaseSearchRequest
+	Sequence      string `params:"sequence" json:"-"`
 	Query         string `json:"q"`
 	SkipSystem    bool   `json:"skip_system"`
 	RecordDensity any    `json:"record_density"`
@@ -101,10 +101,20 @@ func NewProjectParamsResponse(projectParams *models.ProjectParams,
 		}
 	}
 
-	// process images
-	images := make(fiber.Map, len(projectParams.Images))
-	for _, imageName := range projectParams.Images {
-		images[imageName] = []fiber.Map{}
+	// process artifacts
+	artifacts := make(map[string]fiber.Map, len(projectParams.Artifacts))
+	for artifactType, names := range projectParams.Artifacts {
+		artifacts[artifactType] = make(fiber.Map, len(names))
+		for _, name := range names {
+			artifacts[artifactType][name] = []fiber.Map{}
+		}
+	}
+	getArtifacts := func(artifactType string) *fiber.Map {
+		names, ok := artifacts[artifactType]
+		if !ok {
+			names = fiber.Map{}
+		}
+		return &names
 	}
 
 	rsp := ProjectParamsResponse{}
@@ -124,15 +134,15 @@ func NewProjectParamsResponse(projectParams *models.ProjectParams,
 	for _, s := range sequences {
 		switch s {
 		case "images":
-			rsp.Images = &images
+			rsp.Images = getArtifacts(s)
 		case "texts":
-			rsp.Texts = &fiber.Map{}
+			rsp.Texts = getArtifacts(s)
 		case "figures":
-			rsp.Figures = &fiber.Map{}
+			rsp.Figures = getArtifacts(s)
 		case "distributions":
-			rsp.Distributions = &fiber.Map{}
+			rsp.Distributions = getArtifacts(s)
 		case "audios":
-			rsp.Audios = &fiber.Map{}
+			rsp.Audios = getArtifacts(s)
 		case "metric":
 			rsp.Metric = &metrics
 		}
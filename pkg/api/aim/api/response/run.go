@@ -15,15 +15,17 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/rotisserie/eris"
 	log "github.com/sirupsen/logrus"
-	"gorm.io/datatypes"
 
 	"github.com/G-Research/fasttrackml/pkg/api/aim/api/request"
 	"github.com/G-Research/fasttrackml/pkg/api/aim/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/aim/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/api/aim/encoding"
+	"github.com/G-Research/fasttrackml/pkg/api/aim/sampling"
 	mlflowCommon "github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
 	"github.com/G-Research/fasttrackml/pkg/common"
+	"github.com/G-Research/fasttrackml/pkg/common/metricstore"
 	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/common/services/live"
 	"github.com/G-Research/fasttrackml/pkg/database"
 )
 
@@ -215,13 +217,11 @@ type SearchAlignedMetricsResponse struct {
 }
 
 // NewSearchAlignedMetricsResponse creates a new response object for `GET /runs/search/metric/align` endpoint.
-func NewSearchAlignedMetricsResponse(
-	ctx *fiber.Ctx, rows *sql.Rows, next func(*sql.Rows) (*models.AlignedMetric, error), capacity int,
-) {
+func NewSearchAlignedMetricsResponse(ctx *fiber.Ctx, cursor metricstore.Cursor, capacity int) {
 	ctx.Set("Content-Type", "application/octet-stream")
 	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
 		//nolint:errcheck
-		defer rows.Close()
+		defer cursor.Close()
 
 		flushMetrics := func(id string, metrics []SearchAlignedMetricsResponse) error {
 			if len(metrics) == 0 {
@@ -255,11 +255,8 @@ func NewSearchAlignedMetricsResponse(
 				}
 			}
 
-			for rows.Next() {
-				metric, err := next(rows)
-				if err != nil {
-					return eris.Wrap(err, "error getting next result")
-				}
+			for cursor.Next() {
+				metric := cursor.Metric()
 
 				// New series of metrics
 				if metric.Key != key || metric.RunID != id || metric.ContextID != contextID {
@@ -287,6 +284,9 @@ func NewSearchAlignedMetricsResponse(
 					contextID = metric.ContextID
 				}
 			}
+			if err := cursor.Err(); err != nil {
+				return eris.Wrap(err, "error getting next result")
+			}
 
 			addMetrics()
 			if err := flushMetrics(id, metrics); err != nil {
@@ -353,15 +353,15 @@ func NewDeleteBatchResponse(status string) *DeleteBatchResponse {
 	}
 }
 
-// NewStreamMetricsResponse streams the provided sql.Rows to the fiber context.
+// NewStreamMetricsResponse streams the metrics of provided cursor to the fiber context.
 //
 //nolint:gocyclo
-func NewStreamMetricsResponse(ctx *fiber.Ctx, rows *sql.Rows, totalRuns int64,
+func NewStreamMetricsResponse(ctx *fiber.Ctx, cursor metricstore.Cursor, totalRuns int64,
 	result repositories.SearchResultMap, req request.SearchMetricsRequest,
 ) {
 	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
 		//nolint:errcheck
-		defer rows.Close()
+		defer cursor.Close()
 
 		start := time.Now()
 
@@ -372,17 +372,13 @@ func NewStreamMetricsResponse(ctx *fiber.Ctx, rows *sql.Rows, totalRuns int64,
 
 		if err := func() error {
 			var (
-				id          string
-				key         string
-				context     fiber.Map
-				contextID   uint
-				metrics     []fiber.Map
-				values      []float64
-				iters       []float64
-				epochs      []float64
-				timestamps  []float64
-				xAxisValues []float64
-				progress    int
+				id        string
+				key       string
+				context   fiber.Map
+				contextID uint
+				metrics   []fiber.Map
+				points    []sampling.Point
+				progress  int
 			)
 			reportProgress := func(cur int64) error {
 				if !req.ReportProgress {
@@ -399,6 +395,19 @@ func NewStreamMetricsResponse(ctx *fiber.Ctx, rows *sql.Rows, totalRuns int64,
 			}
 			addMetrics := func() {
 				if key != "" {
+					points = sampling.Downsample(req.Sampling, points, req.Steps)
+					values := make([]float64, len(points))
+					iters := make([]float64, len(points))
+					epochs := make([]float64, len(points))
+					timestamps := make([]float64, len(points))
+					xAxisValues := make([]float64, len(points))
+					for i, point := range points {
+						values[i] = point.Value
+						iters[i] = point.Iter
+						epochs[i] = point.Epoch
+						timestamps[i] = point.Timestamp
+						xAxisValues[i] = point.XAxisValue
+					}
 					metric := fiber.Map{
 						"name":          key,
 						"context":       context,
@@ -433,16 +442,8 @@ func NewStreamMetricsResponse(ctx *fiber.Ctx, rows *sql.Rows, totalRuns int64,
 				}
 				return w.Flush()
 			}
-			for rows.Next() {
-				var metric struct {
-					database.Metric
-					Context    datatypes.JSON `gorm:"column:context_json"`
-					XAxisValue float64        `gorm:"column:x_axis_value"`
-					XAxisIsNaN bool           `gorm:"column:x_axis_is_nan"`
-				}
-				if err := database.DB.ScanRows(rows, &metric); err != nil {
-					return err
-				}
+			for cursor.Next() {
+				metric := cursor.Metric()
 
 				if metric.Key != key || metric.RunID != id || metric.ContextID != contextID {
 					addMetrics()
@@ -463,38 +464,36 @@ func NewStreamMetricsResponse(ctx *fiber.Ctx, rows *sql.Rows, totalRuns int64,
 						id = metric.RunID
 					}
 
-					values = make([]float64, 0, req.Steps)
-					iters = make([]float64, 0, req.Steps)
-					epochs = make([]float64, 0, req.Steps)
+					points = make([]sampling.Point, 0, req.Steps)
 					context = fiber.Map{}
-					timestamps = make([]float64, 0, req.Steps)
-					if xAxis {
-						xAxisValues = make([]float64, 0, req.Steps)
-					}
 					key = metric.Key
 				}
 
-				v := metric.Value
+				point := sampling.Point{
+					Value:     metric.Value,
+					Iter:      float64(metric.Iter),
+					Epoch:     float64(metric.Step),
+					Timestamp: float64(metric.Timestamp) / 1000,
+				}
 				if metric.IsNan {
-					v = math.NaN()
+					point.Value = math.NaN()
 				}
-				values = append(values, v)
-				iters = append(iters, float64(metric.Iter))
-				epochs = append(epochs, float64(metric.Step))
-				timestamps = append(timestamps, float64(metric.Timestamp)/1000)
 				if xAxis {
-					x := metric.XAxisValue
+					point.XAxisValue = metric.XAxisValue
 					if metric.XAxisIsNaN {
-						x = math.NaN()
+						point.XAxisValue = math.NaN()
 					}
-					xAxisValues = append(xAxisValues, x)
 				}
+				points = append(points, point)
 				// to be properly decoded by AIM UI, json should be represented as a key:value object.
 				if err := json.Unmarshal(metric.Context, &context); err != nil {
 					return eris.Wrap(err, "error unmarshalling `context` json to `fiber.Map` object")
 				}
 				contextID = metric.ContextID
 			}
+			if err := cursor.Err(); err != nil {
+				return err
+			}
 
 			addMetrics()
 			if err := flushMetrics(); err != nil {
@@ -546,7 +545,7 @@ func NewStreamArtifactsResponse(ctx *fiber.Ctx, rows *sql.Rows, runs map[string]
 				cur++
 				return w.Flush()
 			}
-			addImage := func(img models.Artifact, run models.Run) {
+			addImage := func(img models.Artifact, run models.Run) error {
 				maxIndex := summary.MaxIndex(img.RunID, img.Name)
 				maxStep := summary.MaxStep(img.RunID, img.Name)
 				if runData == nil {
@@ -558,7 +557,7 @@ func NewStreamArtifactsResponse(ctx *fiber.Ctx, rows *sql.Rows, runs map[string]
 							"index_range_used":   []int{req.IndexRangeMin(), req.IndexRangeMax(maxIndex)},
 						},
 						"params": fiber.Map{
-							"images_per_step": maxIndex,
+							fmt.Sprintf("%s_per_step", req.Sequence): maxIndex,
 						},
 						"props": renderProps(run),
 					}
@@ -583,15 +582,9 @@ func NewStreamArtifactsResponse(ctx *fiber.Ctx, rows *sql.Rows, runs map[string]
 				if !ok {
 					iters = make([]int64, maxStep+1)
 				}
-				value := fiber.Map{
-					"blob_uri": img.BlobURI,
-					"caption":  img.Caption,
-					"height":   img.Height,
-					"width":    img.Width,
-					"format":   img.Format,
-					"iter":     img.Iter,
-					"index":    img.Index,
-					"step":     img.Step,
+				value, err := newArtifactValue(img)
+				if err != nil {
+					return err
 				}
 
 				stepImages := traceValues[img.Step]
@@ -604,6 +597,7 @@ func NewStreamArtifactsResponse(ctx *fiber.Ctx, rows *sql.Rows, runs map[string]
 				trace["values"] = traceValues
 				trace["iters"] = iters
 				tracesMap[img.Name] = trace
+				return nil
 			}
 			selectTraces := func() {
 				// collect the traces for this run, limiting to RecordDensity and IndexDensity.
@@ -683,7 +677,9 @@ func NewStreamArtifactsResponse(ctx *fiber.Ctx, rows *sql.Rows, runs map[string]
 					runID = image.RunID
 					runData = nil
 				}
-				addImage(image, runs[image.RunID])
+				if err := addImage(image, runs[image.RunID]); err != nil {
+					return err
+				}
 				hasRows = true
 			}
 
@@ -698,11 +694,109 @@ func NewStreamArtifactsResponse(ctx *fiber.Ctx, rows *sql.Rows, runs map[string]
 
 			return nil
 		}(); err != nil {
-			log.Errorf("Error encountered in %s %s: error streaming images: %s", ctx.Method(), ctx.Path(), err)
+			log.Errorf("Error encountered in %s %s: error streaming artifacts: %s", ctx.Method(), ctx.Path(), err)
+		}
+
+		log.Infof("body - %s %s %s", time.Since(start), ctx.Method(), ctx.Path())
+	})
+}
+
+// newArtifactValue creates the trace value of the artifact depending on the artifact type.
+func newArtifactValue(artifact models.Artifact) (fiber.Map, error) {
+	value := fiber.Map{
+		"iter":  artifact.Iter,
+		"index": artifact.Index,
+		"step":  artifact.Step,
+	}
+	switch artifact.Type {
+	case models.ArtifactTypeTexts:
+		var data models.ArtifactTextData
+		if err := json.Unmarshal(artifact.Data, &data); err != nil {
+			return nil, eris.Wrap(err, "error unmarshaling text data")
 		}
+		value["data"] = data.Text
+	case models.ArtifactTypeDistributions:
+		var data models.ArtifactDistributionData
+		if err := json.Unmarshal(artifact.Data, &data); err != nil {
+			return nil, eris.Wrap(err, "error unmarshaling distribution data")
+		}
+		value["data"] = toNumpy(data.Weights)
+		value["bin_count"] = len(data.Weights)
+		value["range"] = data.BinRange[:]
+	case models.ArtifactTypeFigures:
+		data := fiber.Map{}
+		if err := json.Unmarshal(artifact.Data, &data); err != nil {
+			return nil, eris.Wrap(err, "error unmarshaling figure data")
+		}
+		value["data"] = data
+	case models.ArtifactTypeAudios:
+		value["blob_uri"] = artifact.BlobURI
+		value["caption"] = artifact.Caption
+		value["format"] = artifact.Format
+	default:
+		value["blob_uri"] = artifact.BlobURI
+		value["caption"] = artifact.Caption
+		value["height"] = artifact.Height
+		value["width"] = artifact.Width
+		value["format"] = artifact.Format
+	}
+	return value, nil
+}
+
+// NewRunArtifactsStreamResponse streams artifacts of the run to the fiber context,
+// one trace per artifact name. Artifacts are expected to be ordered by name and step.
+func NewRunArtifactsStreamResponse(ctx *fiber.Ctx, sequence string, artifacts []models.Artifact) error {
+	ctx.Set("Content-Type", "application/octet-stream")
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		start := time.Now()
+		if err := func() error {
+			for len(artifacts) > 0 {
+				// collect artifacts of the same name.
+				end := 1
+				for end < len(artifacts) && artifacts[end].Name == artifacts[0].Name {
+					end++
+				}
+				trace := artifacts[:end]
+				artifacts = artifacts[end:]
 
+				maxStep, maxIndex := int64(0), int64(0)
+				for _, artifact := range trace {
+					maxStep = max(maxStep, artifact.Step)
+					maxIndex = max(maxIndex, artifact.Index)
+				}
+				values, iters := make([][]fiber.Map, 0, maxStep+1), make([]int64, 0, maxStep+1)
+				for _, artifact := range trace {
+					value, err := newArtifactValue(artifact)
+					if err != nil {
+						return err
+					}
+					if len(iters) == 0 || iters[len(iters)-1] != artifact.Step {
+						values = append(values, []fiber.Map{})
+						iters = append(iters, artifact.Step)
+					}
+					values[len(values)-1] = append(values[len(values)-1], value)
+				}
+
+				if err := encoding.EncodeTree(w, fiber.Map{
+					"name":         trace[0].Name,
+					"context":      fiber.Map{},
+					"values":       values,
+					"iters":        iters,
+					"record_range": []int64{0, maxStep + 1},
+					"index_range":  []int64{0, maxIndex + 1},
+				}); err != nil {
+					return err
+				}
+			}
+			return w.Flush()
+		}(); err != nil {
+			log.Errorf(
+				"error encountered in %s %s: error streaming run %s: %s", ctx.Method(), ctx.Path(), sequence, err,
+			)
+		}
 		log.Infof("body - %s %s %s", time.Since(start), ctx.Method(), ctx.Path())
 	})
+	return nil
 }
 
 // NewRunsSearchCSVResponse formats and sends Runs search response as a CSV file.
@@ -1014,6 +1108,57 @@ func NewActiveRunsStreamResponse(ctx *fiber.Ctx, runs []models.Run, reportProgre
 	return nil
 }
 
+// liveUpdatesKeepAliveInterval is an interval of keep-alive comments sent to the live updates clients.
+// Failed keep-alive write also detects disconnected clients when there are no run updates.
+const liveUpdatesKeepAliveInterval = 15 * time.Second
+
+// NewRunsLiveStreamResponse streams run events of the subscription as Server-Sent Events
+// until the client disconnects. unsubscribe is called when streaming is finished.
+func NewRunsLiveStreamResponse(ctx *fiber.Ctx, subscription *live.Subscription, unsubscribe func()) error {
+	ctx.Set("Content-Type", "text/event-stream")
+	ctx.Set("Cache-Control", "no-cache")
+	ctx.Set("X-Accel-Buffering", "no")
+	// stream outlives the request handler, so keep the copies of values used for logging.
+	method, path := strings.Clone(ctx.Method()), strings.Clone(ctx.Path())
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+		ticker := time.NewTicker(liveUpdatesKeepAliveInterval)
+		defer ticker.Stop()
+
+		start := time.Now()
+		if err := func() error {
+			// let the client know how fast to reconnect, it also sends the headers to the client.
+			if _, err := fmt.Fprintf(w, "retry: %d\n\n", liveUpdatesKeepAliveInterval.Milliseconds()); err != nil {
+				return err
+			}
+			for {
+				if err := w.Flush(); err != nil {
+					return err
+				}
+				select {
+				case event := <-subscription.Events():
+					data, err := json.Marshal(event)
+					if err != nil {
+						return eris.Wrap(err, "error marshaling run event")
+					}
+					if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+						return err
+					}
+				case <-ticker.C:
+					if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+						return err
+					}
+				}
+			}
+		}(); err != nil {
+			log.Debugf("live updates stream finished in %s %s: %s", method, path, err)
+		}
+
+		log.Infof("body - %s %s %s", time.Since(start), method, path)
+	})
+	return nil
+}
+
 // renderProps makes the "props" map for a run.
 func renderProps(r models.Run) fiber.Map {
 	m := fiber.Map{
@@ -93,6 +93,28 @@ func (c Controller) GetRunImages(ctx *fiber.Ctx) error {
 	return response.NewRunImagesStreamResponse(ctx, images)
 }
 
+// GetRunArtifacts handles `POST /runs/:id/:sequence/get-batch` endpoint.
+func (c Controller) GetRunArtifacts(ctx *fiber.Ctx) error {
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("getRunArtifacts namespace: %s", ns.Code)
+
+	req := request.GetRunArtifactsRequest{}
+	if err := ctx.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+	}
+
+	sequence := ctx.Params("sequence")
+	artifacts, err := c.runService.GetRunArtifacts(ctx.Context(), ns.ID, ctx.Params("id"), sequence, &req)
+	if err != nil {
+		return convertError(err)
+	}
+
+	return response.NewRunArtifactsStreamResponse(ctx, sequence, artifacts)
+}
+
 // GetRunImagesBatch handles `POST /runs/images/get-batch` endpoint.
 func (c Controller) GetRunImagesBatch(ctx *fiber.Ctx) error {
 	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
@@ -106,7 +128,7 @@ func (c Controller) GetRunImagesBatch(ctx *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
 	}
 
-	images, err := c.runService.GetRunImagesBatch(ctx.Context(), &req)
+	images, err := c.runService.GetRunImagesBatch(ctx.Context(), ns.Code, &req)
 	if err != nil {
 		return err
 	}
@@ -143,6 +165,29 @@ func (c Controller) GetRunsActive(ctx *fiber.Ctx) error {
 	return response.NewActiveRunsStreamResponse(ctx, runs, req.ReportProgress)
 }
 
+// GetRunsLive handles `GET /runs/live` endpoint.
+func (c Controller) GetRunsLive(ctx *fiber.Ctx) error {
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("getRunsLive namespace: %s", ns.Code)
+
+	req := request.GetRunsLiveRequest{}
+	if err := ctx.QueryParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+	}
+
+	subscription, err := c.runService.SubscribeRunsLive(ns.ID, &req)
+	if err != nil {
+		return err
+	}
+
+	return response.NewRunsLiveStreamResponse(ctx, subscription, func() {
+		c.runService.UnsubscribeRunsLive(subscription)
+	})
+}
+
 // SearchRuns handles `GET /runs/search` endpoint.
 func (c Controller) SearchRuns(ctx *fiber.Ctx) error {
 	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
@@ -168,7 +213,7 @@ func (c Controller) SearchRuns(ctx *fiber.Ctx) error {
 	// Search runs
 	runs, total, err := c.runService.SearchRuns(ctx.Context(), ns.ID, tzOffset, req)
 	if err != nil {
-		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		return err
 	}
 	log.Debugf("found %d runs", len(runs))
 
@@ -208,13 +253,12 @@ func (c Controller) SearchMetrics(ctx *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusUnprocessableEntity, "x-timezone-offset header is not a valid integer")
 	}
 
-	//nolint:rowserrcheck
-	rows, totalRuns, result, err := c.runService.SearchMetrics(ctx.Context(), ns.ID, tzOffset, req)
+	cursor, totalRuns, result, err := c.runService.SearchMetrics(ctx.Context(), ns.ID, tzOffset, req)
 	if err != nil {
-		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		return err
 	}
 
-	response.NewStreamMetricsResponse(ctx, rows, totalRuns, result, req)
+	response.NewStreamMetricsResponse(ctx, cursor, totalRuns, result, req)
 	return nil
 }
 
@@ -231,28 +275,30 @@ func (c Controller) SearchAlignedMetrics(ctx *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
 	}
 
-	//nolint:rowserrcheck
-	rows, next, capacity, err := c.runService.SearchAlignedMetrics(ctx.Context(), ns.ID, &req)
+	cursor, capacity, err := c.runService.SearchAlignedMetrics(ctx.Context(), ns.ID, &req)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	response.NewSearchAlignedMetricsResponse(ctx, rows, next, capacity)
+	response.NewSearchAlignedMetricsResponse(ctx, cursor, capacity)
 	return nil
 }
 
-// SearchMetrics handles `POST /runs/search/image` endpoint.
-func (c Controller) SearchImages(ctx *fiber.Ctx) error {
+// SearchArtifacts handles `POST /runs/search/:sequence` endpoint.
+func (c Controller) SearchArtifacts(ctx *fiber.Ctx) error {
 	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
 	if err != nil {
 		return api.NewInternalError("error getting namespace from context")
 	}
-	log.Debugf("searchMetrics namespace: %s", ns.Code)
+	log.Debugf("searchArtifacts namespace: %s", ns.Code)
 
 	req := request.SearchArtifactsRequest{}
 	if err = ctx.BodyParser(&req); err != nil {
 		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
 	}
+	if err = ctx.ParamsParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+	}
 	if ctx.Query("report_progress") == "" {
 		req.ReportProgress = true
 	}
@@ -265,7 +311,7 @@ func (c Controller) SearchImages(ctx *fiber.Ctx) error {
 	//nolint:rowserrcheck
 	rows, runs, result, err := c.runService.SearchArtifacts(ctx.Context(), ns.ID, tzOffset, req)
 	if err != nil {
-		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	response.NewStreamArtifactsResponse(ctx, rows, runs, result, req)
@@ -4,8 +4,39 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+)
+
+// Supported artifact types, named after the Aim sequence types.
+const (
+	ArtifactTypeImages        = "images"
+	ArtifactTypeTexts         = "texts"
+	ArtifactTypeAudios        = "audios"
+	ArtifactTypeDistributions = "distributions"
+	ArtifactTypeFigures       = "figures"
 )
 
+// ArtifactTypes list of supported artifact types.
+var ArtifactTypes = []string{
+	ArtifactTypeImages,
+	ArtifactTypeTexts,
+	ArtifactTypeAudios,
+	ArtifactTypeDistributions,
+	ArtifactTypeFigures,
+}
+
+// ArtifactTextData represents the data of `texts` artifact.
+type ArtifactTextData struct {
+	Text string `json:"text"`
+}
+
+// ArtifactDistributionData represents the data of `distributions` artifact.
+type ArtifactDistributionData struct {
+	Weights  []float64  `json:"weights"`
+	BinRange [2]float64 `json:"bin_range"`
+}
+
 // Artifact represents the artifact model.
 type Artifact struct {
 	RowNum    int64 // RowNum is calculated, not persistent
@@ -21,6 +52,8 @@ type Artifact struct {
 	Format    string
 	Caption   string
 	BlobURI   string
+	Type      string
+	Data      types.JSONB
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -4,8 +4,6 @@ import (
 	"crypto/sha256"
 	"fmt"
 
-	"gorm.io/datatypes"
-
 	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
 )
 
@@ -27,17 +25,6 @@ func (m Metric) UniqueKey() string {
 	return fmt.Sprintf("%v-%v-%v", m.RunID, m.Key, m.ContextID)
 }
 
-// AlignedMetric represents model to work with `metrics` table.
-type AlignedMetric struct {
-	Metric
-	Context datatypes.JSON `gorm:"column:context_json"`
-}
-
-// TableName returns current table name.
-func (m AlignedMetric) TableName() string {
-	return "metrics"
-}
-
 // LatestMetric represents model to work with `last_metrics` table.
 type LatestMetric struct {
 	Key       string  `gorm:"type:varchar(250);not null;primaryKey"`
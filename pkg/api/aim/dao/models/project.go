@@ -14,5 +14,6 @@ type ProjectParams struct {
 	Metrics   []LatestMetric
 	TagKeys   []string
 	ParamKeys []string
-	Images    []string
+	// Artifacts contains artifact names by the artifact type.
+	Artifacts map[string][]string
 }
@@ -21,6 +21,8 @@ const (
 )
 
 // Run represents model to work with `runs` table.
+// DeletedAt is set when the run has been deleted and waits to be purged. Unlike DeletedTime of the archived run,
+// it hides the run from all the queries of the model.
 //
 //nolint:lll
 type Run struct {
@@ -39,6 +41,7 @@ type Run struct {
 	ExperimentID   int32
 	Experiment     Experiment
 	DeletedTime    sql.NullInt64  `gorm:"type:bigint"`
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
 	RowNum         RowNum         `gorm:"<-:create;index"`
 	Params         []Param        `gorm:"constraint:OnDelete:CASCADE"`
 	Tags           []Tag          `gorm:"constraint:OnDelete:CASCADE"`
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+// RunPurgeStatus represents the status of the run purge.
+type RunPurgeStatus string
+
+// Supported list of run purge statuses.
+const (
+	RunPurgeStatusPending RunPurgeStatus = "pending"
+	RunPurgeStatusFailed  RunPurgeStatus = "failed"
+)
+
+// RunPurge represents model to work with `run_purges` table. The purge is created together with the deletion of
+// the run and tracks the progress of removing its metrics, artifacts and the run itself. The purge is removed once
+// the run has been purged, failed purges are retried.
+type RunPurge struct {
+	RunID          string         `gorm:"column:run_uuid;type:varchar(32);primaryKey"`
+	NamespaceID    uint           `gorm:"not null;index"`
+	Status         RunPurgeStatus `gorm:"type:varchar(20);not null"`
+	DeletedMetrics int64          `gorm:"not null;default:0"`
+	Attempts       int            `gorm:"not null;default:0"`
+	Error          string         `gorm:"type:varchar(1000)"`
+	RequestedAt    time.Time      `gorm:"not null;index"`
+	UpdatedAt      time.Time
+}
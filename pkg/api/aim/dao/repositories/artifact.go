@@ -69,9 +69,14 @@ type ArtifactRepositoryProvider interface {
 		timeZoneOffset int,
 		req request.SearchArtifactsRequest,
 	) (*sql.Rows, map[string]models.Run, ArtifactSearchSummary, error)
+	// GetArtifactNamesByExperiments will find artifact names of the type in the selected experiments.
 	GetArtifactNamesByExperiments(
-		ctx context.Context, namespaceID uint, experiments []int,
+		ctx context.Context, namespaceID uint, experiments []int, artifactType string,
 	) ([]string, error)
+	// GetByRunIDAndNames returns artifacts of the type and names belonging to the run.
+	GetByRunIDAndNames(
+		ctx context.Context, runID string, artifactType string, names []string,
+	) ([]models.Artifact, error)
 }
 
 // ArtifactRepository repository to work with `artifact` entity.
@@ -139,14 +144,15 @@ func (r ArtifactRepository) Search(
 		Raw(`SELECT run_uuid, name, step, count(id) as img_count, max("index") as max_index
 			FROM artifacts
 			WHERE run_uuid IN (?)
+			AND type = ?
 			GROUP BY run_uuid, name, step;`,
-			runIDs).
+			runIDs, req.Sequence).
 		Find(&stepInfo); tx.Error != nil {
 		return nil, nil, nil, eris.Wrap(err, "error find result summary for artifact search")
 	}
 
-	imageNames := []string{}
-	imageNameQueryTemplate := `images.name == "%s"`
+	artifactNames := []string{}
+	artifactNameQueryTemplate := `%s.name == "%s"`
 	resultSummary := make(ArtifactSearchSummary, len(runIDs))
 	for _, rslt := range stepInfo {
 		traceMap, ok := resultSummary[rslt.RunUUID]
@@ -155,9 +161,9 @@ func (r ArtifactRepository) Search(
 		}
 		traceMap[rslt.Name] = append(traceMap[rslt.Name], rslt)
 		resultSummary[rslt.RunUUID] = traceMap
-		qImage := fmt.Sprintf(imageNameQueryTemplate, rslt.Name)
-		if strings.Contains(req.Query, qImage) {
-			imageNames = append(imageNames, rslt.Name)
+		qArtifact := fmt.Sprintf(artifactNameQueryTemplate, req.Sequence, rslt.Name)
+		if strings.Contains(req.Query, qArtifact) {
+			artifactNames = append(artifactNames, rslt.Name)
 		}
 	}
 
@@ -171,17 +177,19 @@ func (r ArtifactRepository) Search(
                        FROM artifacts
                     ) rows USING (id)
                     WHERE run_uuid IN ?
+                    AND type = ?
                     AND step BETWEEN ? AND ?
                     AND "index" BETWEEN ? AND ?
                     AND name IN ?
                     ORDER BY run_uuid, name, step
                 `,
 			runIDs,
+			req.Sequence,
 			req.RecordRangeMin(),
 			req.RecordRangeMax(math.MaxInt16),
 			req.IndexRangeMin(),
 			req.IndexRangeMax(math.MaxInt16),
-			imageNames)
+			artifactNames)
 
 	rows, err := tx.Rows()
 	if err != nil {
@@ -194,9 +202,9 @@ func (r ArtifactRepository) Search(
 	return rows, runMap, resultSummary, nil
 }
 
-// GetArtifactNamesByExperiments will find image names in the selected experiments.
+// GetArtifactNamesByExperiments will find artifact names of the type in the selected experiments.
 func (r ArtifactRepository) GetArtifactNamesByExperiments(
-	ctx context.Context, namespaceID uint, experiments []int,
+	ctx context.Context, namespaceID uint, experiments []int, artifactType string,
 ) ([]string, error) {
 	runIDs := []string{}
 	if err := r.GetDB().WithContext(ctx).
@@ -208,17 +216,37 @@ func (r ArtifactRepository) GetArtifactNamesByExperiments(
 		        AND experiments.experiment_id IN ?`,
 			namespaceID, experiments,
 		).
+		Where("runs.deleted_at IS NULL").
 		Find(&runIDs).Error; err != nil {
 		return nil, eris.Wrap(err, "error finding runs for artifacts")
 	}
 
-	imageNames := []string{}
+	artifactNames := []string{}
 	if err := r.GetDB().WithContext(ctx).
 		Distinct("name").
 		Table("artifacts").
 		Where("run_uuid IN ?", runIDs).
-		Find(&imageNames).Error; err != nil {
+		Where("type = ?", artifactType).
+		Find(&artifactNames).Error; err != nil {
 		return nil, eris.Wrap(err, "error finding runs for artifact search")
 	}
-	return imageNames, nil
+	return artifactNames, nil
+}
+
+// GetByRunIDAndNames returns artifacts of the type and names belonging to the run.
+func (r ArtifactRepository) GetByRunIDAndNames(
+	ctx context.Context, runID string, artifactType string, names []string,
+) ([]models.Artifact, error) {
+	var artifacts []models.Artifact
+	if err := r.GetDB().WithContext(ctx).
+		Where("run_uuid = ?", runID).
+		Where("type = ?", artifactType).
+		Where("name IN ?", names).
+		Order("name").
+		Order("step").
+		Order(`"index"`).
+		Find(&artifacts).Error; err != nil {
+		return nil, eris.Wrapf(err, "error getting %s of run: %s", artifactType, runID)
+	}
+	return artifacts, nil
 }
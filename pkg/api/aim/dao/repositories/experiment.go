@@ -13,6 +13,7 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/aim/api/request"
 	"github.com/G-Research/fasttrackml/pkg/api/aim/common"
 	"github.com/G-Research/fasttrackml/pkg/api/aim/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/metricstore"
 	"github.com/G-Research/fasttrackml/pkg/database"
 )
 
@@ -45,6 +46,8 @@ type ExperimentRepositoryProvider interface {
 // ExperimentRepository repository to work with `experiment` entity.
 type ExperimentRepository struct {
 	db *gorm.DB
+	// metricStore is the store of the metric history. Metrics are kept by the database when it is not set.
+	metricStore metricstore.Store
 }
 
 // NewExperimentRepository creates a repository to work with `experiment` entity.
@@ -54,6 +57,13 @@ func NewExperimentRepository(db *gorm.DB) *ExperimentRepository {
 	}
 }
 
+// WithMetricStore sets the store of the metric history, so the metrics of the runs are removed
+// from the store together with the experiment.
+func (r *ExperimentRepository) WithMetricStore(metricStore metricstore.Store) *ExperimentRepository {
+	r.metricStore = metricStore
+	return r
+}
+
 // Update updates existing experiment.
 func (r ExperimentRepository) Update(ctx context.Context, experiment *models.Experiment) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
@@ -82,8 +92,20 @@ func (r ExperimentRepository) Update(ctx context.Context, experiment *models.Exp
 
 // Delete deletes existing experiment.
 func (r ExperimentRepository) Delete(ctx context.Context, experiment *models.Experiment) error {
+	var runIDs []string
 	if err := r.db.Transaction(func(tx *gorm.DB) error {
-		// finding all the related runs
+		// finding all the related runs, including the deleted ones, as they are removed by cascade too.
+		if r.metricStore != nil {
+			if err := tx.Unscoped().Model(
+				&models.Run{},
+			).Where(
+				"experiment_id = ?", *experiment.ID,
+			).Pluck(
+				"run_uuid", &runIDs,
+			).Error; err != nil {
+				return err
+			}
+		}
 		var minRowNum sql.NullInt64
 		if err := tx.Model(
 			&models.Run{},
@@ -95,6 +117,27 @@ func (r ExperimentRepository) Delete(ctx context.Context, experiment *models.Exp
 			return err
 		}
 
+		// delete the dataset inputs of the related runs and the datasets, they aren't removed by cascade.
+		if err := tx.Exec(
+			`DELETE FROM input_tags WHERE input_uuid IN (
+				SELECT input_uuid FROM inputs
+				WHERE destination_type = 'RUN' AND destination_id IN (SELECT run_uuid FROM runs WHERE experiment_id = ?)
+			)`,
+			*experiment.ID,
+		).Error; err != nil {
+			return eris.Wrapf(err, "error deleting input tags of experiment with id: %d", *experiment.ID)
+		}
+		if err := tx.Exec(
+			`DELETE FROM inputs
+			WHERE destination_type = 'RUN' AND destination_id IN (SELECT run_uuid FROM runs WHERE experiment_id = ?)`,
+			*experiment.ID,
+		).Error; err != nil {
+			return eris.Wrapf(err, "error deleting inputs of experiment with id: %d", *experiment.ID)
+		}
+		if err := tx.Exec("DELETE FROM datasets WHERE experiment_id = ?", *experiment.ID).Error; err != nil {
+			return eris.Wrapf(err, "error deleting datasets of experiment with id: %d", *experiment.ID)
+		}
+
 		// delete current experiment
 		if err := tx.Clauses(
 			clause.Returning{Columns: []clause.Column{{Name: "experiment_id"}}},
@@ -139,6 +182,11 @@ func (r ExperimentRepository) Delete(ctx context.Context, experiment *models.Exp
 		return eris.Wrapf(err, "error deleting experiment with id: %d", *experiment.ID)
 	}
 
+	if r.metricStore != nil && len(runIDs) > 0 {
+		if err := r.metricStore.Delete(ctx, runIDs); err != nil {
+			return eris.Wrapf(err, "error deleting metrics of experiment with id: %d from metric store", *experiment.ID)
+		}
+	}
 	return nil
 }
 
@@ -161,7 +209,7 @@ func (r ExperimentRepository) GetExperiments(
 	).Where(
 		"experiments.lifecycle_stage = ?", database.LifecycleStageActive,
 	).Joins(
-		"LEFT JOIN runs USING(experiment_id)",
+		"LEFT JOIN runs ON runs.experiment_id = experiments.experiment_id AND runs.deleted_at IS NULL",
 	).Joins(
 		"LEFT JOIN experiment_tags ON experiments.experiment_id = experiment_tags.experiment_id AND"+
 			" experiment_tags.key = ?", common.DescriptionTagKey,
@@ -297,7 +345,7 @@ func (r ExperimentRepository) GetExtendedExperimentByNamespaceIDAndExperimentID(
 		"COUNT(runs.run_uuid) AS run_count",
 		"COALESCE(MAX(experiment_tags.value), '') AS description",
 	).Joins(
-		"LEFT JOIN runs USING(experiment_id)",
+		"LEFT JOIN runs ON runs.experiment_id = experiments.experiment_id AND runs.deleted_at IS NULL",
 	).Joins(
 		"LEFT JOIN experiment_tags ON experiments.experiment_id = experiment_tags.experiment_id AND"+
 			" experiment_tags.key = ?", common.DescriptionTagKey,
@@ -2,7 +2,6 @@ package repositories
 
 import (
 	"context"
-	"database/sql"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -15,8 +14,10 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/aim/common"
 	"github.com/G-Research/fasttrackml/pkg/api/aim/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/aim/query"
+	"github.com/G-Research/fasttrackml/pkg/api/aim/sampling"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+	"github.com/G-Research/fasttrackml/pkg/common/metricstore"
 )
 
 // SearchResult is a helper for reporting result progress.
@@ -35,10 +36,10 @@ type MetricRepositoryProvider interface {
 	GetMetricKeysAndContextsByExperiments(
 		ctx context.Context, namespaceID uint, experiments []int,
 	) ([]models.LatestMetric, error)
-	// SearchMetrics returns a sql.Rows cursor for streaming the metrics matching the request.
+	// SearchMetrics returns a cursor for streaming the metrics matching the request.
 	SearchMetrics(
 		ctx context.Context, namespaceID uint, timeZoneOffset int, req request.SearchMetricsRequest,
-	) (*sql.Rows, int64, SearchResultMap, error)
+	) (metricstore.Cursor, int64, SearchResultMap, error)
 	// GetContextListByContextObjects returns list of context by provided map of contexts.
 	GetContextListByContextObjects(
 		ctx context.Context, contextsMap map[string]types.JSONB,
@@ -48,15 +49,23 @@ type MetricRepositoryProvider interface {
 // MetricRepository repository to work with models.Metric entity.
 type MetricRepository struct {
 	repositories.BaseRepositoryProvider
+	// metricStore is the store of the metric history. Metrics are kept by the database when it is not set.
+	metricStore metricstore.Store
 }
 
 // NewMetricRepository creates repository to work with models.Metric entity.
 func NewMetricRepository(db *gorm.DB) *MetricRepository {
 	return &MetricRepository{
-		repositories.NewBaseRepository(db),
+		BaseRepositoryProvider: repositories.NewBaseRepository(db),
 	}
 }
 
+// WithMetricStore sets the store of the metric history.
+func (r *MetricRepository) WithMetricStore(metricStore metricstore.Store) *MetricRepository {
+	r.metricStore = metricStore
+	return r
+}
+
 // GetMetricKeysAndContextsByExperiments returns metric keys and contexts by provided experiments.
 func (r MetricRepository) GetMetricKeysAndContextsByExperiments(
 	ctx context.Context, namespaceID uint, experiments []int,
@@ -74,6 +83,8 @@ func (r MetricRepository) GetMetricKeysAndContextsByExperiments(
 		"Context",
 	).Where(
 		"runs.lifecycle_stage = ?", models.LifecycleStageActive,
+	).Where(
+		"runs.deleted_at IS NULL",
 	)
 	if len(experiments) != 0 {
 		query = query.Where("experiments.experiment_id IN ?", experiments)
@@ -88,7 +99,7 @@ func (r MetricRepository) GetMetricKeysAndContextsByExperiments(
 // SearchMetrics returns a metrics cursor according to the SearchMetricsRequest.
 func (r MetricRepository) SearchMetrics(
 	ctx context.Context, namespaceID uint, timeZoneOffset int, req request.SearchMetricsRequest,
-) (*sql.Rows, int64, SearchResultMap, error) {
+) (metricstore.Cursor, int64, SearchResultMap, error) {
 	qp := query.QueryParser{
 		Default: query.DefaultExpression{
 			Contains:   "run.archived",
@@ -185,14 +196,20 @@ func (r MetricRepository) SearchMetrics(
 	}
 	metricKeyContextCondition := strings.Join(metricKeyContextConditionSlice, " OR ")
 
-	subQuery := r.GetDB().WithContext(ctx).
+	var latestMetrics []struct {
+		RunID       string `gorm:"column:run_uuid"`
+		Key         string
+		ContextID   uint
+		ContextJson types.JSONB
+		LastIter    int64
+	}
+	if err := pq.Filter(r.GetDB().WithContext(ctx).
 		Select(
 			"runs.run_uuid",
-			"runs.row_num",
 			"latest_metrics.key",
 			"latest_metrics.context_id",
 			"contexts.json AS context_json",
-			fmt.Sprintf("(latest_metrics.last_iter + 1)/ %f AS interval", float32(req.Steps)),
+			"latest_metrics.last_iter",
 		).
 		Table("runs").
 		Joins(
@@ -201,42 +218,50 @@ func (r MetricRepository) SearchMetrics(
 		).
 		Joins("LEFT JOIN latest_metrics USING(run_uuid)").
 		Joins("LEFT JOIN contexts ON latest_metrics.context_id = contexts.id").
-		Where(metricKeyContextCondition)
-
-	tx := r.GetDB().WithContext(ctx).
-		Select(`
-        metrics.*,
-        runmetrics.context_json`,
-		).
-		Table("metrics").
-		Joins(
-			"INNER JOIN (?) runmetrics USING(run_uuid, key, context_id)",
-			pq.Filter(subQuery),
-		).
-		Where("MOD(metrics.iter + 1 + runmetrics.interval / 2, runmetrics.interval) < 1").
-		Order("runmetrics.row_num DESC").
-		Order("metrics.key").
-		Order("metrics.context_id").
-		Order("metrics.iter")
+		Where("runs.deleted_at IS NULL").
+		Where(metricKeyContextCondition),
+	).
+		Order("runs.row_num DESC").
+		Order("latest_metrics.key").
+		Order("latest_metrics.context_id").
+		Scan(&latestMetrics).Error; err != nil {
+		return nil, 0, nil, eris.Wrap(err, "error searching metric series")
+	}
 
-	if req.XAxis != "" {
-		tx.
-			Select("metrics.*", "runmetrics.context_json", "x_axis.value as x_axis_value", "x_axis.is_nan as x_axis_is_nan").
-			Joins(
-				"LEFT JOIN metrics x_axis ON metrics.run_uuid = x_axis.run_uuid AND "+
-					"metrics.iter = x_axis.iter AND x_axis.context_id = metrics.context_id AND x_axis.key = ?",
-				req.XAxis,
-			)
+	series := make([]metricstore.Series, len(latestMetrics))
+	for i, latestMetric := range latestMetrics {
+		series[i] = metricstore.Series{
+			RunID:     latestMetric.RunID,
+			Key:       latestMetric.Key,
+			ContextID: latestMetric.ContextID,
+			Context:   latestMetric.ContextJson,
+		}
+		// other sampling methods need all the points and are applied while streaming the response.
+		if sampling.IsDefault(req.Sampling) && req.Steps > 0 {
+			series[i].Interval = float64(latestMetric.LastIter+1) / float64(req.Steps)
+		}
 	}
-	rows, err := tx.Rows()
+
+	cursor, err := r.getMetricStore().Read(ctx, &metricstore.Query{
+		Series: series,
+		OrderBy: []metricstore.Column{
+			metricstore.ColumnKey, metricstore.ColumnContextID, metricstore.ColumnIter,
+		},
+		XAxisKey: req.XAxis,
+	})
 	if err != nil {
 		return nil, 0, nil, eris.Wrap(err, "error searching metrics")
 	}
-	if err := rows.Err(); err != nil {
-		return nil, 0, nil, eris.Wrap(err, "error getting metrics rows cursor")
-	}
 
-	return rows, totalRuns, result, nil
+	return cursor, totalRuns, result, nil
+}
+
+// getMetricStore returns the store of the metric history.
+func (r MetricRepository) getMetricStore() metricstore.Store {
+	if r.metricStore != nil {
+		return r.metricStore
+	}
+	return metricstore.NewSQLStore(r.GetDB(), nil)
 }
 
 // GetContextListByContextObjects returns list of context by provided map of contexts.
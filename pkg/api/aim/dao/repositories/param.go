@@ -41,6 +41,8 @@ func (r ParamRepository) GetParamKeysByParameters(
 		namespaceID,
 	).Where(
 		"runs.lifecycle_stage = ?", models.LifecycleStageActive,
+	).Where(
+		"runs.deleted_at IS NULL",
 	)
 	if len(experiments) != 0 {
 		query = query.Where("experiments.experiment_id IN ?", experiments)
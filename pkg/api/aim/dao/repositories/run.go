@@ -18,6 +18,7 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/aim/query"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+	"github.com/G-Research/fasttrackml/pkg/common/metricstore"
 	"github.com/G-Research/fasttrackml/pkg/database"
 )
 
@@ -31,7 +32,7 @@ type RunRepositoryProvider interface {
 	// GetAlignedMetrics returns aligned metrics.
 	GetAlignedMetrics(
 		ctx context.Context, namespaceID uint, values []any, alignBy string,
-	) (*sql.Rows, func(*sql.Rows) (*models.AlignedMetric, error), error)
+	) (metricstore.Cursor, error)
 	// GetRunByNamespaceIDAndRunID returns experiment by Namespace ID and Run ID.
 	GetRunByNamespaceIDAndRunID(ctx context.Context, namespaceID uint, runID string) (*models.Run, error)
 	// GetByNamespaceID returns list of models.Run by requested namespace ID.
@@ -42,7 +43,7 @@ type RunRepositoryProvider interface {
 	Update(ctx context.Context, run *models.Run) error
 	// ArchiveBatch marks existing models.Run entities as archived.
 	ArchiveBatch(ctx context.Context, namespaceID uint, ids []string) error
-	// DeleteBatch removes the existing models.Run from the db.
+	// DeleteBatch marks existing models.Run entities as deleted and queues them to be purged.
 	DeleteBatch(ctx context.Context, namespaceID uint, ids []string) error
 	// RestoreBatch marks existing models.Run entities as active.
 	RestoreBatch(ctx context.Context, namespaceID uint, ids []string) error
@@ -55,15 +56,23 @@ type RunRepositoryProvider interface {
 // RunRepository repository to work with models.Run entity.
 type RunRepository struct {
 	repositories.BaseRepositoryProvider
+	// metricStore is the store of the metric history. Metrics are kept by the database when it is not set.
+	metricStore metricstore.Store
 }
 
 // NewRunRepository creates a repository to work with models.Run entity.
 func NewRunRepository(db *gorm.DB) *RunRepository {
 	return &RunRepository{
-		repositories.NewBaseRepository(db),
+		BaseRepositoryProvider: repositories.NewBaseRepository(db),
 	}
 }
 
+// WithMetricStore sets the store of the metric history.
+func (r *RunRepository) WithMetricStore(metricStore metricstore.Store) *RunRepository {
+	r.metricStore = metricStore
+	return r
+}
+
 // GetRunInfo returns run info.
 func (r RunRepository) GetRunInfo(
 	ctx context.Context, namespaceID uint, req *request.GetRunInfoRequest,
@@ -109,29 +118,78 @@ func (r RunRepository) GetRunMetrics(
 ) ([]models.Metric, error) {
 	subQuery := r.GetDB().WithContext(ctx)
 	for metricKey := range metricKeysMap {
-		subQuery = subQuery.Or("key = ? AND json = ?", metricKey.Name, types.JSONB(metricKey.Context))
+		subQuery = subQuery.Or(
+			"series.key = ? AND contexts.json = ?", metricKey.Name, types.JSONB(metricKey.Context),
+		)
 	}
 
-	// fetch run metrics based on provided criteria.
-	var metrics []models.Metric
-	if err := r.GetDB().InnerJoins(
-		"Context",
-	).Order(
-		"iter",
+	// find the series of the run based on provided criteria.
+	var rows []struct {
+		RunID       string `gorm:"column:run_uuid"`
+		Key         string
+		ContextID   uint
+		ContextJson types.JSONB
+	}
+	if err := metricstore.SeriesTable(
+		r.GetDB().WithContext(ctx), r.metricStore, []string{runID},
+	).Select(
+		"series.run_uuid", "series.key", "series.context_id", "contexts.json AS context_json",
+	).Joins(
+		"INNER JOIN contexts ON contexts.id = series.context_id",
 	).Where(
-		"run_uuid = ?", runID,
+		"series.run_uuid = ?", runID,
 	).Where(
 		subQuery,
-	).Find(&metrics).Error; err != nil {
+	).Order(
+		"series.key",
+	).Order(
+		"series.context_id",
+	).Find(&rows).Error; err != nil {
+		return nil, eris.Wrapf(err, "error getting run metric series")
+	}
+	series := make([]metricstore.Series, len(rows))
+	for i, row := range rows {
+		series[i] = metricstore.Series{
+			RunID:     row.RunID,
+			Key:       row.Key,
+			ContextID: row.ContextID,
+			Context:   row.ContextJson,
+		}
+	}
+
+	// fetch run metrics of the series.
+	points, err := metricstore.ReadAll(ctx, r.getMetricStore(), &metricstore.Query{
+		Series:  series,
+		OrderBy: []metricstore.Column{metricstore.ColumnIter},
+	})
+	if err != nil {
 		return nil, eris.Wrapf(err, "error getting run metrics")
 	}
+	metrics := make([]models.Metric, len(points))
+	for i, point := range points {
+		metrics[i] = models.Metric{
+			Key:       point.Key,
+			Value:     point.Value,
+			Timestamp: point.Timestamp,
+			RunID:     point.RunID,
+			Step:      point.Step,
+			IsNan:     point.IsNan,
+			Iter:      point.Iter,
+			ContextID: point.ContextID,
+			Context: models.Context{
+				ID:   point.ContextID,
+				Json: point.Context,
+			},
+		}
+	}
 	return metrics, nil
 }
 
-// GetAlignedMetrics returns aligned metrics.
+// GetAlignedMetrics returns aligned metrics. Values are the tuples of run id, metric key, context id and
+// number of steps of the metrics, which are aligned by the metric with alignBy key of the same run and context.
 func (r RunRepository) GetAlignedMetrics(
 	ctx context.Context, namespaceID uint, values []any, alignBy string,
-) (*sql.Rows, func(rows *sql.Rows) (*models.AlignedMetric, error), error) {
+) (metricstore.Cursor, error) {
 	var valuesStmt strings.Builder
 	length := len(values) / 4
 	for i := 0; i < length; i++ {
@@ -140,49 +198,69 @@ func (r RunRepository) GetAlignedMetrics(
 			valuesStmt.WriteString(",")
 		}
 	}
-	values = append(values, namespaceID, alignBy)
-	rows, err := r.GetDB().Raw(
+	values = append(values, namespaceID)
+
+	var alignedSeries []struct {
+		RunID       string `gorm:"column:run_uuid"`
+		Key         string
+		ContextID   uint
+		ContextJson types.JSONB
+		LastIter    int64
+		Steps       float64
+	}
+	if err := r.GetDB().WithContext(ctx).Raw(
 		fmt.Sprintf("WITH params(run_uuid, key, context_id, steps) AS (VALUES %s)", &valuesStmt)+
-			"        SELECT m.run_uuid, "+
-			"				rm.key, "+
-			"				m.iter, "+
-			"				m.value, "+
-			"				m.is_nan, "+
-			"				rm.context_id, "+
-			"				rm.context_json"+
-			"		 FROM metrics AS m"+
-			"        RIGHT JOIN ("+
-			"          SELECT p.run_uuid, "+
-			"				  p.key, "+
-			"				  p.context_id, "+
-			"				  lm.last_iter AS max, "+
-			"				  (lm.last_iter + 1) / p.steps AS interval, "+
-			"				  contexts.json AS context_json"+
-			"          FROM params AS p"+
-			"          LEFT JOIN latest_metrics AS lm USING(run_uuid, key, context_id)"+
-			"          INNER JOIN contexts ON contexts.id = lm.context_id"+
-			"        ) rm USING(run_uuid, context_id)"+
-			"		 INNER JOIN runs AS r ON m.run_uuid = r.run_uuid"+
+			"        SELECT p.run_uuid, "+
+			"				p.key, "+
+			"				lm.context_id, "+
+			"				contexts.json AS context_json, "+
+			"				lm.last_iter, "+
+			"				p.steps"+
+			"        FROM params AS p"+
+			"        INNER JOIN latest_metrics AS lm USING(run_uuid, key, context_id)"+
+			"        INNER JOIN contexts ON contexts.id = lm.context_id"+
+			"		 INNER JOIN runs AS r ON p.run_uuid = r.run_uuid AND r.deleted_at IS NULL"+
 			"		 INNER JOIN experiments AS e ON r.experiment_id = e.experiment_id AND e.namespace_id = ?"+
-			"        WHERE m.key = ?"+
-			"          AND m.iter <= rm.max"+
-			"          AND MOD(m.iter + 1 + rm.interval / 2, rm.interval) < 1"+
-			"        ORDER BY r.row_num DESC, rm.key, rm.context_id, m.iter",
+			"        ORDER BY r.row_num DESC, p.key, lm.context_id",
 		values...,
-	).Rows()
+	).Scan(&alignedSeries).Error; err != nil {
+		return nil, eris.Wrap(err, "error searching aligned run metric series")
+	}
+
+	// metrics with alignBy key are returned instead of the metrics of the series, sampled the same way.
+	series := make([]metricstore.Series, len(alignedSeries))
+	for i, s := range alignedSeries {
+		series[i] = metricstore.Series{
+			RunID:     s.RunID,
+			Key:       alignBy,
+			ContextID: s.ContextID,
+			Context:   s.ContextJson,
+			Name:      s.Key,
+			MaxIter:   s.LastIter,
+		}
+		if s.Steps > 0 {
+			series[i].Interval = float64(s.LastIter+1) / s.Steps
+		}
+	}
+
+	cursor, err := r.getMetricStore().Read(ctx, &metricstore.Query{
+		Series: series,
+		OrderBy: []metricstore.Column{
+			metricstore.ColumnKey, metricstore.ColumnContextID, metricstore.ColumnIter,
+		},
+	})
 	if err != nil {
-		return nil, nil, eris.Wrap(err, "error searching aligned run metrics")
+		return nil, eris.Wrap(err, "error searching aligned run metrics")
 	}
-	if err := rows.Err(); err != nil {
-		return nil, nil, eris.Wrap(err, "error getting query result")
+	return cursor, nil
+}
+
+// getMetricStore returns the store of the metric history.
+func (r RunRepository) getMetricStore() metricstore.Store {
+	if r.metricStore != nil {
+		return r.metricStore
 	}
-	return rows, func(rows *sql.Rows) (*models.AlignedMetric, error) {
-		var metric models.AlignedMetric
-		if err := r.GetDB().ScanRows(rows, &metric); err != nil {
-			return nil, eris.Wrap(err, "error getting aligned metric")
-		}
-		return &metric, nil
-	}, nil
+	return metricstore.NewSQLStore(r.GetDB(), nil)
 }
 
 // GetRunByNamespaceIDAndRunID returns experiment by Namespace ID and Run ID.
@@ -287,50 +365,55 @@ func (r RunRepository) ArchiveBatch(ctx context.Context, namespaceID uint, ids [
 	return nil
 }
 
-// Delete removes the existing models.Run from the db.
+// Delete marks the existing models.Run as deleted and queues it to be purged.
 func (r RunRepository) Delete(ctx context.Context, namespaceID uint, run *models.Run) error {
 	return r.DeleteBatch(ctx, namespaceID, []string{run.ID})
 }
 
-// DeleteBatch removes existing models.Run from the db.
+// DeleteBatch marks existing models.Run entities as deleted and queues them to be purged. Deleted runs are hidden
+// from all the queries, their metrics, artifacts and the runs themselves are removed by the purge worker.
 func (r RunRepository) DeleteBatch(ctx context.Context, namespaceID uint, ids []string) error {
-	if err := r.GetDB().Transaction(func(tx *gorm.DB) error {
-		runs := make([]models.Run, 0, len(ids))
-		if err := tx.Clauses(
-			clause.Returning{Columns: []clause.Column{{Name: "row_num"}}},
+	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var runIDs []string
+		if err := tx.Model(
+			&models.Run{},
+		).Joins(
+			"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
+			namespaceID,
 		).Where(
-			"run_uuid IN (?)",
-			r.GetDB().Model(
-				models.Run{},
-			).Select(
-				"run_uuid",
-			).Joins(
-				"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
-				namespaceID,
-			).Where(
-				"run_uuid IN (?)", ids,
-			),
-		).Delete(
-			&runs,
+			"runs.run_uuid IN (?)", ids,
+		).Pluck(
+			"runs.run_uuid", &runIDs,
 		).Error; err != nil {
-			return eris.Wrapf(err, "error deleting existing runs with ids: %s", ids)
+			return eris.Wrapf(err, "error getting existing runs with ids: %s", ids)
 		}
 
 		// verify deletion
-		// NOTE: tx.RowsAffected does not provide correct number of deleted, using the returning slice instead
-		if len(runs) != len(ids) {
+		if len(runIDs) != len(ids) {
 			return eris.New("count of deleted runs does not match length of ids input (invalid run ID?)")
 		}
 
-		// renumber the remainder
-		if err := r.renumberRows(tx, getMinRowNum(runs)); err != nil {
-			return eris.Wrapf(err, "error renumbering runs.row_num")
+		if err := tx.Where("run_uuid IN (?)", runIDs).Delete(&models.Run{}).Error; err != nil {
+			return eris.Wrapf(err, "error deleting existing runs with ids: %s", ids)
+		}
+
+		now := time.Now().UTC()
+		purges := make([]models.RunPurge, len(runIDs))
+		for i, runID := range runIDs {
+			purges[i] = models.RunPurge{
+				RunID:       runID,
+				NamespaceID: namespaceID,
+				Status:      models.RunPurgeStatusPending,
+				RequestedAt: now,
+			}
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&purges).Error; err != nil {
+			return eris.Wrapf(err, "error creating purges of runs with ids: %s", ids)
 		}
 		return nil
 	}); err != nil {
 		return eris.Wrapf(err, "error deleting runs")
 	}
-
 	return nil
 }
 
@@ -409,7 +492,8 @@ func (r RunRepository) SearchRuns(
 				`"Experiment"."name" IN ?`, req.ExperimentNames,
 			),
 		).
-		Order("row_num DESC")
+		Order("row_num DESC").
+		Order("runs.run_uuid DESC")
 
 	if !req.ExcludeParams {
 		tx.Preload("Params")
@@ -425,17 +509,25 @@ func (r RunRepository) SearchRuns(
 		tx.Limit(req.Limit)
 	}
 	if req.Offset != "" {
-		run := &database.Run{
-			ID: req.Offset,
-		}
-		if err := database.DB.Select(
-			"row_num",
-		).First(
-			&run,
-		).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		// offset is the id of the last run of the previous page, so the next page starts right after it.
+		var run models.Run
+		if err := r.GetDB().WithContext(ctx).Select(
+			"ID", "RowNum",
+		).InnerJoins(
+			"Experiment",
+			database.DB.Select(
+				"ID",
+			).Where(
+				&models.Experiment{NamespaceID: namespaceID},
+			),
+		).Where(
+			"run_uuid = ?", req.Offset,
+		).First(&run).Error; err != nil {
 			return nil, 0, eris.Wrapf(err, "unable to find search runs offset %q", req.Offset)
 		}
-		tx.Where("row_num < ?", run.RowNum)
+		tx.Where(
+			"(runs.row_num < ? OR (runs.row_num = ? AND runs.run_uuid < ?))", run.RowNum, run.RowNum, run.ID,
+		)
 	}
 	var runs []models.Run
 	if err := pq.Filter(tx).Find(&runs).Error; err != nil {
@@ -445,18 +537,6 @@ func (r RunRepository) SearchRuns(
 	return runs, total, nil
 }
 
-// getMinRowNum will find the lowest row_num for the slice of runs
-// or 0 for an empty slice
-func getMinRowNum(runs []models.Run) models.RowNum {
-	var minRowNum models.RowNum
-	for _, run := range runs {
-		if minRowNum == models.RowNum(0) || run.RowNum < minRowNum {
-			minRowNum = run.RowNum
-		}
-	}
-	return minRowNum
-}
-
 // renumberRows will update the runs.row_num field with the correct ordinal
 func (r RunRepository) renumberRows(tx *gorm.DB, startWith models.RowNum) error {
 	if startWith < models.RowNum(0) {
@@ -10,11 +10,6 @@ import (
 	"github.com/rotisserie/eris"
 )
 
-type (
-	arrayFlag  struct{}
-	objectFlag struct{}
-)
-
 var pathSentinel = []byte{0xfe}
 
 func EncodeTree(w io.Writer, tree map[string]any) error {
@@ -40,7 +35,7 @@ func encodeTree(w io.Writer, v any, p []any) error {
 			return encodePathValue(w, v, p)
 		}
 
-		if err := encodePathValue(w, arrayFlag{}, p); err != nil {
+		if err := encodePathValue(w, ArrayFlag{}, p); err != nil {
 			return err
 		}
 		for i := 0; i < rv.Len(); i++ {
@@ -50,7 +45,7 @@ func encodeTree(w io.Writer, v any, p []any) error {
 		}
 	case reflect.Map:
 		if rv.Len() == 0 {
-			return encodePathValue(w, objectFlag{}, p)
+			return encodePathValue(w, ObjectFlag{}, p)
 		}
 
 		iter := rv.MapRange()
@@ -129,9 +124,9 @@ func encodeValue(w io.Writer, v any) error {
 		case []byte:
 			kind = 0x05
 			buf.Write(t)
-		case arrayFlag:
+		case ArrayFlag:
 			kind = 0x06
-		case objectFlag:
+		case ObjectFlag:
 			kind = 0x07
 		default:
 			return fmt.Errorf("unsupported value %#v", v)
@@ -0,0 +1,97 @@
+package encoding
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+
+	"github.com/rotisserie/eris"
+)
+
+// TypeCustomObject is a type of Aim custom objects (images, texts, etc.) in Aim storage.
+// The payload is the name of the object type.
+const TypeCustomObject = 8
+
+type (
+	// ArrayFlag marks the beginning of the array in Aim storage. Array items are stored under the index keys.
+	ArrayFlag struct{}
+	// ObjectFlag marks the beginning of the object in Aim storage. Object fields are stored under the field keys.
+	ObjectFlag struct{}
+	// CustomObjectFlag marks the beginning of the custom object in Aim storage.
+	CustomObjectFlag struct {
+		Type string
+	}
+)
+
+// DecodePath decodes the key of Aim storage into path components.
+// String components are returned as string and index components as int64.
+func DecodePath(key []byte) ([]any, error) {
+	var path []any
+	for len(key) > 0 {
+		if key[0] == pathSentinel[0] {
+			if len(key) < 10 || key[9] != pathSentinel[0] {
+				return nil, eris.New("malformed index path component")
+			}
+			path = append(path, int64(binary.BigEndian.Uint64(key[1:9])))
+			key = key[10:]
+			continue
+		}
+		i := bytes.IndexByte(key, pathSentinel[0])
+		if i < 0 {
+			return nil, eris.New("unterminated path component")
+		}
+		path = append(path, string(key[:i]))
+		key = key[i+1:]
+	}
+	return path, nil
+}
+
+// DecodeValue decodes the value of Aim storage. Unlike the stream values, storage values
+// are not length prefixed and binary data is returned as []byte.
+func DecodeValue(data []byte) (any, error) {
+	if len(data) == 0 {
+		return nil, eris.New("empty value")
+	}
+	payload := data[1:]
+	switch data[0] {
+	case TypeNil:
+		return nil, nil
+	case TypeBool:
+		if len(payload) != 1 {
+			return nil, eris.New("malformed bool value")
+		}
+		return payload[0] != 0, nil
+	case TypeInt:
+		switch len(payload) {
+		case 2:
+			return int64(int16(binary.LittleEndian.Uint16(payload))), nil
+		case 4:
+			return int64(int32(binary.LittleEndian.Uint32(payload))), nil
+		case 8:
+			return int64(binary.LittleEndian.Uint64(payload)), nil
+		default:
+			return nil, eris.Errorf("unsupported int length %d", len(payload))
+		}
+	case TypeFloat:
+		switch len(payload) {
+		case 4:
+			return float64(math.Float32frombits(binary.LittleEndian.Uint32(payload))), nil
+		case 8:
+			return math.Float64frombits(binary.LittleEndian.Uint64(payload)), nil
+		default:
+			return nil, eris.Errorf("unsupported float length %d", len(payload))
+		}
+	case TypeString:
+		return string(payload), nil
+	case TypeSlice:
+		return payload, nil
+	case TypeArray:
+		return ArrayFlag{}, nil
+	case TypeObject:
+		return ObjectFlag{}, nil
+	case TypeCustomObject:
+		return CustomObjectFlag{Type: string(payload)}, nil
+	default:
+		return nil, eris.Errorf("unsupported type %x", data[0])
+	}
+}
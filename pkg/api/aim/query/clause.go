@@ -111,7 +111,7 @@ func (json Json) jsonPathForDialect() string {
 	switch json.Dialector {
 	case postgres.Dialector{}.Name():
 		jsonPath := removePrefix(json.JsonPath)
-		return "{" + strings.ReplaceAll(jsonPath, ",", ".") + "}"
+		return "{" + strings.ReplaceAll(jsonPath, ".", ",") + "}"
 	default:
 		return addPrefix(json.JsonPath)
 	}
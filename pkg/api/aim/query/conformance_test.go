@@ -0,0 +1,265 @@
+package query
+
+import (
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim/dao/models"
+)
+
+// TestConformance_Ok checks SQL generated for the documented Aim QL expressions in both dialects.
+// Empty sqlite expectations mean that the sqlite dialect generates the same SQL as postgres.
+func (s *QueryTestSuite) TestConformance_Ok() {
+	tests := []struct {
+		name                 string
+		query                string
+		expectedPostgresSQL  string
+		expectedPostgresVars []interface{}
+		expectedSqliteSQL    string
+		expectedSqliteVars   []interface{}
+	}{
+		{
+			name:  "ParamAttributeGreater",
+			query: `run.hparams.lr > 0.01`,
+			expectedPostgresSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`LEFT JOIN params params_0 ON runs.run_uuid = params_0.run_uuid AND params_0.key = $1 ` +
+				`WHERE (("params_0"."value_int" > $2 OR "params_0"."value_float" > $3) ` +
+				`AND "runs"."lifecycle_stage" <> $4) AND "runs"."deleted_at" IS NULL`,
+			expectedPostgresVars: []interface{}{"hparams.lr", 0.01, 0.01, models.LifecycleStageDeleted},
+		},
+		{
+			name:  "ParamAttributeReversed",
+			query: `0.01 < run.hparams.lr`,
+			expectedPostgresSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`LEFT JOIN params params_0 ON runs.run_uuid = params_0.run_uuid AND params_0.key = $1 ` +
+				`WHERE (("params_0"."value_int" > $2 OR "params_0"."value_float" > $3) ` +
+				`AND "runs"."lifecycle_stage" <> $4) AND "runs"."deleted_at" IS NULL`,
+			expectedPostgresVars: []interface{}{"hparams.lr", 0.01, 0.01, models.LifecycleStageDeleted},
+		},
+		{
+			name:  "ParamSubscript",
+			query: `run["hparams"]["lr"] == 0.01`,
+			expectedPostgresSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`LEFT JOIN params params_0 ON runs.run_uuid = params_0.run_uuid AND params_0.key = $1 ` +
+				`WHERE (("params_0"."value_int" = $2 OR "params_0"."value_float" = $3) ` +
+				`AND "runs"."lifecycle_stage" <> $4) AND "runs"."deleted_at" IS NULL`,
+			expectedPostgresVars: []interface{}{"hparams.lr", 0.01, 0.01, models.LifecycleStageDeleted},
+		},
+		{
+			name:  "ParamMixedAttributeAndSubscript",
+			query: `run.hparams["optimizer"] == "adam"`,
+			expectedPostgresSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`LEFT JOIN params params_0 ON runs.run_uuid = params_0.run_uuid AND params_0.key = $1 ` +
+				`WHERE ("params_0"."value_str" = $2 AND "runs"."lifecycle_stage" <> $3) AND "runs"."deleted_at" IS NULL`,
+			expectedPostgresVars: []interface{}{"hparams.optimizer", "adam", models.LifecycleStageDeleted},
+		},
+		{
+			name:  "ParamInList",
+			query: `run.optimizer in ["adam", "sgd"]`,
+			expectedPostgresSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`LEFT JOIN params params_0 ON runs.run_uuid = params_0.run_uuid AND params_0.key = $1 ` +
+				`WHERE (("params_0"."value_str" = $2 OR "params_0"."value_str" = $3) ` +
+				`AND "runs"."lifecycle_stage" <> $4) AND "runs"."deleted_at" IS NULL`,
+			expectedPostgresVars: []interface{}{"optimizer", "adam", "sgd", models.LifecycleStageDeleted},
+		},
+		{
+			name:  "ParamNotInMixedList",
+			query: `run.optimizer not in ["adam", 1]`,
+			expectedPostgresSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`LEFT JOIN params params_0 ON runs.run_uuid = params_0.run_uuid AND params_0.key = $1 ` +
+				`WHERE (NOT ("params_0"."value_str" = $2 OR ("params_0"."value_int" = $3 OR "params_0"."value_float" = $4)) ` +
+				`AND "runs"."lifecycle_stage" <> $5) AND "runs"."deleted_at" IS NULL`,
+			expectedPostgresVars: []interface{}{"optimizer", "adam", 1, 1, models.LifecycleStageDeleted},
+		},
+		{
+			name:  "ParamContains",
+			query: `"ad" in run.optimizer`,
+			expectedPostgresSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`LEFT JOIN params params_0 ON runs.run_uuid = params_0.run_uuid AND params_0.key = $1 ` +
+				`WHERE ("params_0"."value_str" LIKE $2 AND "runs"."lifecycle_stage" <> $3) AND "runs"."deleted_at" IS NULL`,
+			expectedPostgresVars: []interface{}{"optimizer", "%ad%", models.LifecycleStageDeleted},
+		},
+		{
+			name:  "ParamStartsWith",
+			query: `run.optimizer.startswith("ad")`,
+			expectedPostgresSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`LEFT JOIN params params_0 ON runs.run_uuid = params_0.run_uuid AND params_0.key = $1 ` +
+				`WHERE ("params_0"."value_str" LIKE $2 AND "runs"."lifecycle_stage" <> $3) AND "runs"."deleted_at" IS NULL`,
+			expectedPostgresVars: []interface{}{"optimizer", "ad%", models.LifecycleStageDeleted},
+		},
+		{
+			name:  "ParamRegexpMatch",
+			query: `re.match("ad", run.optimizer)`,
+			expectedPostgresSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`LEFT JOIN params params_0 ON runs.run_uuid = params_0.run_uuid AND params_0.key = $1 ` +
+				`WHERE ("params_0"."value_str" ~ $2 AND "runs"."lifecycle_stage" <> $3) AND "runs"."deleted_at" IS NULL`,
+			expectedPostgresVars: []interface{}{"optimizer", "^ad", models.LifecycleStageDeleted},
+			expectedSqliteSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`LEFT JOIN params params_0 ON runs.run_uuid = params_0.run_uuid AND params_0.key = $1 ` +
+				`WHERE (IFNULL("params_0"."value_str", '') REGEXP $2 ` +
+				`AND "runs"."lifecycle_stage" <> $3) AND "runs"."deleted_at" IS NULL`,
+			expectedSqliteVars: []interface{}{"optimizer", "^ad", models.LifecycleStageDeleted},
+		},
+		{
+			name:  "ParamBoolean",
+			query: `run.augment == True`,
+			expectedPostgresSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`LEFT JOIN params params_0 ON runs.run_uuid = params_0.run_uuid AND params_0.key = $1 ` +
+				`WHERE ("params_0"."value_str" IN ($2,$3) AND "runs"."lifecycle_stage" <> $4) AND "runs"."deleted_at" IS NULL`,
+			expectedPostgresVars: []interface{}{"augment", "True", "true", models.LifecycleStageDeleted},
+		},
+		{
+			name:  "ParamIsNone",
+			query: `run.lr is None`,
+			expectedPostgresSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`LEFT JOIN params params_0 ON runs.run_uuid = params_0.run_uuid AND params_0.key = $1 ` +
+				`WHERE ("params_0"."key" IS NULL AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
+			expectedPostgresVars: []interface{}{"lr", models.LifecycleStageDeleted},
+		},
+		{
+			name:  "ParamIsNotNone",
+			query: `run.lr is not None`,
+			expectedPostgresSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`LEFT JOIN params params_0 ON runs.run_uuid = params_0.run_uuid AND params_0.key = $1 ` +
+				`WHERE ("params_0"."key" IS NOT NULL AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
+			expectedPostgresVars: []interface{}{"lr", models.LifecycleStageDeleted},
+		},
+		{
+			name:  "RunDescription",
+			query: `run.description == "text"`,
+			expectedPostgresSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`LEFT JOIN tags tags_0 ON runs.run_uuid = tags_0.run_uuid AND tags_0.key = $1 ` +
+				`WHERE ("tags_0"."value" = $2 AND "runs"."lifecycle_stage" <> $3) AND "runs"."deleted_at" IS NULL`,
+			expectedPostgresVars: []interface{}{"mlflow.note.content", "text", models.LifecycleStageDeleted},
+		},
+		{
+			name:  "MetricName",
+			query: `metric.name == "loss"`,
+			expectedPostgresSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`WHERE ("latest_metrics"."key" = $1 AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
+			expectedPostgresVars: []interface{}{"loss", models.LifecycleStageDeleted},
+		},
+		{
+			name:  "MetricNameInList",
+			query: `metric.name in ["loss", "accuracy"]`,
+			expectedPostgresSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`WHERE ("latest_metrics"."key" IN ($1,$2) AND "runs"."lifecycle_stage" <> $3) AND "runs"."deleted_at" IS NULL`,
+			expectedPostgresVars: []interface{}{"loss", "accuracy", models.LifecycleStageDeleted},
+		},
+		{
+			name:  "MetricContextAttribute",
+			query: `metric.context.subset == "val"`,
+			expectedPostgresSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`WHERE ("contexts"."json"#>>$1 = $2 AND "runs"."lifecycle_stage" <> $3) AND "runs"."deleted_at" IS NULL`,
+			expectedPostgresVars: []interface{}{"{subset}", "val", models.LifecycleStageDeleted},
+			expectedSqliteSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`WHERE (IFNULL("contexts"."json", JSON('{}'))->>$1 = $2 ` +
+				`AND "runs"."lifecycle_stage" <> $3) AND "runs"."deleted_at" IS NULL`,
+			expectedSqliteVars: []interface{}{"$.subset", "val", models.LifecycleStageDeleted},
+		},
+		{
+			name:  "MetricContextSubscript",
+			query: `metric.context["subset"] != "val"`,
+			expectedPostgresSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`WHERE ("contexts"."json"#>>$1 <> $2 AND "runs"."lifecycle_stage" <> $3) AND "runs"."deleted_at" IS NULL`,
+			expectedPostgresVars: []interface{}{"{subset}", "val", models.LifecycleStageDeleted},
+			expectedSqliteSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`WHERE (IFNULL("contexts"."json", JSON('{}'))->>$1 <> $2 ` +
+				`AND "runs"."lifecycle_stage" <> $3) AND "runs"."deleted_at" IS NULL`,
+			expectedSqliteVars: []interface{}{"$.subset", "val", models.LifecycleStageDeleted},
+		},
+		{
+			name:  "MetricContextNested",
+			query: `metric.context.a.b == "val"`,
+			expectedPostgresSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`WHERE ("contexts"."json"#>>$1 = $2 AND "runs"."lifecycle_stage" <> $3) AND "runs"."deleted_at" IS NULL`,
+			expectedPostgresVars: []interface{}{"{a,b}", "val", models.LifecycleStageDeleted},
+			expectedSqliteSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`WHERE (IFNULL("contexts"."json", JSON('{}'))->>$1 = $2 ` +
+				`AND "runs"."lifecycle_stage" <> $3) AND "runs"."deleted_at" IS NULL`,
+			expectedSqliteVars: []interface{}{"$.a.b", "val", models.LifecycleStageDeleted},
+		},
+		{
+			name:  "MetricContextInList",
+			query: `metric.context.subset in ["train", "val"]`,
+			expectedPostgresSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`WHERE (("contexts"."json"#>>$1 = $2 OR "contexts"."json"#>>$3 = $4) ` +
+				`AND "runs"."lifecycle_stage" <> $5) AND "runs"."deleted_at" IS NULL`,
+			expectedPostgresVars: []interface{}{
+				"{subset}", "train", "{subset}", "val", models.LifecycleStageDeleted,
+			},
+			expectedSqliteSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`WHERE ((IFNULL("contexts"."json", JSON('{}'))->>$1 = $2 ` +
+				`OR IFNULL("contexts"."json", JSON('{}'))->>$3 = $4) ` +
+				`AND "runs"."lifecycle_stage" <> $5) AND "runs"."deleted_at" IS NULL`,
+			expectedSqliteVars: []interface{}{
+				"$.subset", "train", "$.subset", "val", models.LifecycleStageDeleted,
+			},
+		},
+		{
+			name:  "NotMetricContext",
+			query: `not metric.context.subset == "val"`,
+			expectedPostgresSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`WHERE ("contexts"."json"#>>$1 <> $2 AND "runs"."lifecycle_stage" <> $3) AND "runs"."deleted_at" IS NULL`,
+			expectedPostgresVars: []interface{}{"{subset}", "val", models.LifecycleStageDeleted},
+			expectedSqliteSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`WHERE (IFNULL("contexts"."json", JSON('{}'))->>$1 <> $2 ` +
+				`AND "runs"."lifecycle_stage" <> $3) AND "runs"."deleted_at" IS NULL`,
+			expectedSqliteVars: []interface{}{"$.subset", "val", models.LifecycleStageDeleted},
+		},
+		{
+			name:  "MetricLastStep",
+			query: `metric.last_step > 100`,
+			expectedPostgresSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`WHERE ("latest_metrics"."last_iter" > $1 AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
+			expectedPostgresVars: []interface{}{100, models.LifecycleStageDeleted},
+		},
+		{
+			name:  "MetricLastValue",
+			query: `metric.last < 0.5`,
+			expectedPostgresSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`WHERE ("latest_metrics"."value" < $1 AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
+			expectedPostgresVars: []interface{}{0.5, models.LifecycleStageDeleted},
+		},
+		{
+			name:  "TextsName",
+			query: `texts.name == "prompt"`,
+			expectedPostgresSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`INNER JOIN artifacts artifacts_0 ON runs.run_uuid = artifacts_0.run_uuid AND artifacts_0.type = $1 ` +
+				`WHERE ("artifacts_0"."name" = $2 AND "runs"."lifecycle_stage" <> $3) AND "runs"."deleted_at" IS NULL`,
+			expectedPostgresVars: []interface{}{"texts", "prompt", models.LifecycleStageDeleted},
+		},
+	}
+
+	for _, tt := range tests {
+		for _, dialector := range []string{postgres.Dialector{}.Name(), sqlite.Dialector{}.Name()} {
+			s.Run(tt.name+"/"+dialector, func() {
+				expectedSQL, expectedVars := tt.expectedPostgresSQL, tt.expectedPostgresVars
+				if dialector == (sqlite.Dialector{}).Name() && tt.expectedSqliteSQL != "" {
+					expectedSQL, expectedVars = tt.expectedSqliteSQL, tt.expectedSqliteVars
+				}
+				pq := QueryParser{
+					Default: DefaultExpression{
+						Contains:   "run.archived",
+						Expression: "not run.archived",
+					},
+					Tables: map[string]string{
+						"runs":        "runs",
+						"experiments": "Experiment",
+						"metrics":     "latest_metrics",
+					},
+					Dialector: dialector,
+				}
+				parsedQuery, err := pq.Parse(tt.query)
+				s.Require().Nil(err)
+				tx := parsedQuery.Filter(
+					s.db.Session(&gorm.Session{DryRun: true}).Model(models.Run{}),
+				).Select("ID").Find(&models.Run{})
+				s.Require().Nil(tx.Error)
+				s.Equal(expectedSQL, tx.Statement.SQL.String())
+				s.Equal(expectedVars, tx.Statement.Vars)
+			})
+		}
+	}
+}
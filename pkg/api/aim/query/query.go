@@ -14,6 +14,7 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/G-Research/fasttrackml/pkg/api/aim/common"
 	"github.com/G-Research/fasttrackml/pkg/api/aim/dao/models"
 )
 
@@ -53,6 +54,12 @@ type subscriptSlicer func(index ast.Slicer) (any, error)
 
 type attributeOrSubscript func(v any) (any, error)
 
+// paramRef references the run param. Keys of nested params are joined with dots.
+type paramRef struct {
+	key   string
+	table string
+}
+
 type join struct {
 	key   string
 	alias string
@@ -252,7 +259,7 @@ func (pq *parsedQuery) parseAttribute(node *ast.Attribute) (any, error) {
 					return nil, errors.New("unsupported argument type. has to be `string` only")
 				}
 				value := fmt.Sprintf("%%%s", arg.S)
-				switch c := parsedNode.(type) {
+				switch c := pq.resolveParam(parsedNode).(type) {
 				case clause.Column:
 					return clause.Like{
 						Value: value,
@@ -280,7 +287,7 @@ func (pq *parsedQuery) parseAttribute(node *ast.Attribute) (any, error) {
 					return nil, errors.New("unsupported argument type. has to be `string` only")
 				}
 				value := fmt.Sprintf("%s%%", arg.S)
-				switch c := parsedNode.(type) {
+				switch c := pq.resolveParam(parsedNode).(type) {
 				case clause.Column:
 					return clause.Like{
 						Value: value,
@@ -305,6 +312,12 @@ func (pq *parsedQuery) parseAttribute(node *ast.Attribute) (any, error) {
 			return value(attribute)
 		case attributeOrSubscript:
 			return value(attribute)
+		case paramRef:
+			value.key = fmt.Sprintf("%s.%s", value.key, attribute)
+			return value, nil
+		case Json:
+			value.JsonPath = fmt.Sprintf("%s.%s", value.JsonPath, attribute)
+			return value, nil
 		default:
 			return nil, fmt.Errorf("unsupported attribute value %#v", value)
 		}
@@ -369,6 +382,11 @@ func (pq *parsedQuery) parseCompare(node *ast.Compare) (any, error) {
 		}
 
 		switch left := left.(type) {
+		case paramRef:
+			exprs[i], err = pq.newSqlParamComparison(op, left, right)
+			if err != nil {
+				return nil, err
+			}
 		case clause.Column:
 			exprs[i], err = newSqlComparison(op, left, right)
 			if err != nil {
@@ -390,6 +408,21 @@ func (pq *parsedQuery) parseCompare(node *ast.Compare) (any, error) {
 				return nil, err
 			}
 		default:
+			// literal is compared with the param, e.g. `0.01 < run.hparams.lr` or `"adam" in run.optimizer`.
+			if ref, ok := right.(paramRef); ok {
+				if op != ast.In && op != ast.NotIn {
+					o, err := reverseOperator(op)
+					if err != nil {
+						return nil, err
+					}
+					exprs[i], err = pq.newSqlParamComparison(o, ref, left)
+					if err != nil {
+						return nil, err
+					}
+					continue
+				}
+				right = pq.resolveParam(ref)
+			}
 			switch right := right.(type) {
 			case clause.Column:
 				switch op {
@@ -602,24 +635,13 @@ func (pq *parsedQuery) parseName(node *ast.Name) (any, error) {
 								return nil, fmt.Errorf("unsupported slicer or attribute %v", v)
 							}
 						}), nil
+					case "description":
+						return pq.tagJoin(common.DescriptionTagKey, table)
 					default:
-						joinKey := fmt.Sprintf("params:%s", attr)
-						j, ok := pq.joins[joinKey]
-						if !ok {
-							alias := fmt.Sprintf("params_%d", len(pq.joins))
-							j = join{
-								alias: alias,
-								query: fmt.Sprintf(
-									"LEFT JOIN params %s ON %s.run_uuid = %s.run_uuid AND %s.key = ?",
-									alias, table, alias, alias,
-								),
-								args: []any{attr},
-							}
-							pq.AddJoin(joinKey, j)
-						}
-						return clause.Column{
-							Table: j.alias,
-							Name:  "value",
+						// params are resolved once the full key of the nested param is known.
+						return paramRef{
+							key:   attr,
+							table: table,
 						}, nil
 					}
 				},
@@ -650,7 +672,7 @@ func (pq *parsedQuery) parseName(node *ast.Name) (any, error) {
 								if err != nil {
 									return nil, err
 								}
-								column, ok := parsedNode.(clause.Column)
+								column, ok := pq.resolveParam(parsedNode).(clause.Column)
 								if !ok {
 									return nil, errors.New(
 										"second argument type for re.match function has to be clause.Column",
@@ -706,24 +728,71 @@ func (pq *parsedQuery) parseName(node *ast.Name) (any, error) {
 					).UnixMilli(), nil
 				},
 			), nil
-		case "images":
+		case "metric":
+			table, ok := pq.qp.Tables["metrics"]
+			if !ok {
+				return nil, errors.New("unsupported name identifier 'metric'")
+			}
+			return attributeGetter(
+				func(attr string) (any, error) {
+					switch attr {
+					case "name":
+						return clause.Column{
+							Table: table,
+							Name:  "key",
+						}, nil
+					case "context":
+						// context values are referenced as `metric.context.subset` or `metric.context["subset"]`.
+						return attributeOrSubscript(func(v any) (any, error) {
+							var key string
+							switch v := v.(type) {
+							case string:
+								key = v
+							case ast.Slicer:
+								k, err := pq.parseStringIndex(v)
+								if err != nil {
+									return nil, err
+								}
+								key = k
+							default:
+								return nil, fmt.Errorf("unsupported slicer or attribute %v", v)
+							}
+							return Json{
+								Column: clause.Column{
+									Table: TableContexts,
+									Name:  "json",
+								},
+								JsonPath:  key,
+								Dialector: pq.qp.Dialector,
+							}, nil
+						}), nil
+					default:
+						getter, err := metricAttributeGetter(table)
+						if err != nil {
+							return nil, err
+						}
+						return getter.(attributeGetter)(attr)
+					}
+				},
+			), nil
+		case "images", "texts", "audios", "distributions", "figures":
 			table, ok := pq.qp.Tables["runs"]
 			if !ok {
 				return nil, errors.New("unsupported name identifier 'runs'")
 			}
 			return attributeGetter(
 				func(attr string) (any, error) {
-					joinKey := fmt.Sprintf("artifacts:%s", attr)
+					joinKey := fmt.Sprintf("artifacts:%s:%s", node.Id, attr)
 					j, ok := pq.joins[joinKey]
 					alias := fmt.Sprintf("artifacts_%d", len(pq.joins))
 					if !ok {
 						j = join{
 							alias: alias,
 							query: fmt.Sprintf(
-								"INNER JOIN artifacts %s ON %s.run_uuid = %s.run_uuid",
-								alias, table, alias,
+								"INNER JOIN artifacts %s ON %s.run_uuid = %s.run_uuid AND %s.type = ?",
+								alias, table, alias, alias,
 							),
-							args: []any{attr},
+							args: []any{string(node.Id)},
 						}
 						pq.AddJoin(joinKey, j)
 					}
@@ -810,6 +879,38 @@ func (pq *parsedQuery) tagJoin(key string, table string) (any, error) {
 	}, nil
 }
 
+// paramJoin joins the params table using the param key.
+func (pq *parsedQuery) paramJoin(ref paramRef) join {
+	joinKey := fmt.Sprintf("params:%s", ref.key)
+	j, ok := pq.joins[joinKey]
+	if !ok {
+		alias := fmt.Sprintf("params_%d", len(pq.joins))
+		j = join{
+			alias: alias,
+			query: fmt.Sprintf(
+				"LEFT JOIN params %s ON %s.run_uuid = %s.run_uuid AND %s.key = ?",
+				alias, ref.table, alias, alias,
+			),
+			args: []any{ref.key},
+			key:  joinKey,
+		}
+		pq.AddJoin(joinKey, j)
+	}
+	return j
+}
+
+// resolveParam converts the param reference into the string value column, other values are returned as is.
+func (pq *parsedQuery) resolveParam(v any) any {
+	ref, ok := v.(paramRef)
+	if !ok {
+		return v
+	}
+	return clause.Column{
+		Table: pq.paramJoin(ref).alias,
+		Name:  "value_str",
+	}
+}
+
 // latestMetricsKeyJoin joins the latest_metrics table by run_uuid and metric key, returning the join struct.
 func (pq *parsedQuery) latestMetricsKeyJoin(key, table string) join {
 	joinsKey := fmt.Sprintf("metrics:%s", key)
@@ -933,6 +1034,23 @@ func (pq *parsedQuery) parseSubscript(node *ast.Subscript) (any, error) {
 			return v(node.Slice)
 		case attributeOrSubscript:
 			return v(node.Slice)
+		case attributeGetter, paramRef, Json:
+			// `run["hparams"]["lr"]` is the same as `run.hparams.lr`.
+			key, err := pq.parseStringIndex(node.Slice)
+			if err != nil {
+				return nil, err
+			}
+			switch v := v.(type) {
+			case attributeGetter:
+				return v(key)
+			case paramRef:
+				v.key = fmt.Sprintf("%s.%s", v.key, key)
+				return v, nil
+			case Json:
+				v.JsonPath = fmt.Sprintf("%s.%s", v.JsonPath, key)
+				return v, nil
+			}
+			return nil, fmt.Errorf("unsupported attribute value %#v", v)
 		default:
 			return nil, fmt.Errorf("unsupported attribute value %#v", v)
 		}
@@ -941,6 +1059,23 @@ func (pq *parsedQuery) parseSubscript(node *ast.Subscript) (any, error) {
 	}
 }
 
+// parseStringIndex returns the string index of the subscript.
+func (pq *parsedQuery) parseStringIndex(slicer ast.Slicer) (string, error) {
+	index, ok := slicer.(*ast.Index)
+	if !ok {
+		return "", fmt.Errorf("unsupported slicer %q", ast.Dump(slicer))
+	}
+	v, err := pq.parseNode(index.Value)
+	if err != nil {
+		return "", err
+	}
+	key, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("unsupported index value type %T (should be string)", v)
+	}
+	return key, nil
+}
+
 func (pq *parsedQuery) parseUnaryOp(node *ast.UnaryOp) (any, error) {
 	e, err := pq.parseNode(node.Operand)
 	if err != nil {
@@ -1054,25 +1189,108 @@ func (pq *parsedQuery) newSqlJsonPathComparison(op ast.CmpOp, left Json, right a
 			Value:     right,
 			Dialector: pq.qp.Dialector,
 		}, nil
+	case ast.In, ast.NotIn:
+		values, ok := right.([]any)
+		if !ok {
+			return nil, fmt.Errorf("right value in %q comparison is not a list: %#v", op, right)
+		}
+		exprs := make([]clause.Expression, len(values))
+		for i, value := range values {
+			exprs[i] = JsonEq{
+				Left:      left,
+				Value:     value,
+				Dialector: pq.qp.Dialector,
+			}
+		}
+		if op == ast.NotIn {
+			return negativeClause(clause.Or(exprs...)), nil
+		}
+		return clause.Or(exprs...), nil
 	default:
 		return nil, fmt.Errorf("unsupported comparison operation %q", op)
 	}
 }
 
+// newSqlParamComparison compares the param with the value. Params are stored in typed columns,
+// so the column is selected by the value type: numbers are compared with both int and float values,
+// booleans with string values logged by Python clients and None checks that the param is missing.
+func (pq *parsedQuery) newSqlParamComparison(op ast.CmpOp, left paramRef, right any) (clause.Expression, error) {
+	j := pq.paramJoin(left)
+	column := func(name string) clause.Column {
+		return clause.Column{
+			Table: j.alias,
+			Name:  name,
+		}
+	}
+	switch right := right.(type) {
+	case nil:
+		return newSqlComparison(op, column("key"), nil)
+	case string:
+		return newSqlComparison(op, column("value_str"), right)
+	case int, float64:
+		intComparison, err := newSqlComparison(op, column("value_int"), right)
+		if err != nil {
+			return nil, err
+		}
+		floatComparison, err := newSqlComparison(op, column("value_float"), right)
+		if err != nil {
+			return nil, err
+		}
+		return clause.Or(intComparison, floatComparison), nil
+	case bool:
+		values := []any{"False", "false"}
+		if right {
+			values = []any{"True", "true"}
+		}
+		switch op {
+		case ast.Eq, ast.Is:
+			return clause.IN{Column: column("value_str"), Values: values}, nil
+		case ast.NotEq, ast.IsNot:
+			return negativeClause(clause.IN{Column: column("value_str"), Values: values}), nil
+		default:
+			return nil, fmt.Errorf("comparison operation incompatible with bool %q", op)
+		}
+	case []any:
+		if op != ast.In && op != ast.NotIn {
+			return nil, fmt.Errorf("unsupported comparison operation %q with list", op)
+		}
+		exprs := make([]clause.Expression, len(right))
+		for i, value := range right {
+			expr, err := pq.newSqlParamComparison(ast.Eq, left, value)
+			if err != nil {
+				return nil, err
+			}
+			exprs[i] = expr
+		}
+		if op == ast.NotIn {
+			return negativeClause(clause.Or(exprs...)), nil
+		}
+		return clause.Or(exprs...), nil
+	default:
+		return nil, fmt.Errorf("unsupported param comparison with %#v", right)
+	}
+}
+
 func reverseComparison(op ast.CmpOp, left any, right clause.Column) (ast.CmpOp, clause.Column, any, error) {
+	o, err := reverseOperator(op)
+	return o, right, left, err
+}
+
+// reverseOperator returns the operator to be used when the operands are swapped.
+func reverseOperator(op ast.CmpOp) (ast.CmpOp, error) {
 	switch op {
 	case ast.Lt:
-		return ast.Gt, right, left, nil
+		return ast.Gt, nil
 	case ast.LtE:
-		return ast.GtE, right, left, nil
+		return ast.GtE, nil
 	case ast.Gt:
-		return ast.Lt, right, left, nil
+		return ast.Lt, nil
 	case ast.GtE:
-		return ast.LtE, right, left, nil
+		return ast.LtE, nil
 	case ast.Eq, ast.Is, ast.NotEq, ast.IsNot:
-		return op, right, left, nil
+		return op, nil
 	default:
-		return op, right, left, fmt.Errorf("unable to reverse comparison operator %q", op)
+		return op, fmt.Errorf("unable to reverse comparison operator %q", op)
 	}
 }
 
@@ -47,63 +47,63 @@ func (s *QueryTestSuite) TestPostgresDialector_Ok() {
 			name:  "TestRunNameWithoutFunction",
 			query: `(run.name == 'run')`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
-				`WHERE "runs"."name" = $1 AND "runs"."lifecycle_stage" <> $2`,
+				`WHERE ("runs"."name" = $1 AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"run", models.LifecycleStageDeleted},
 		},
 		{
 			name:  "TestRunNameWithInFunction",
 			query: `('run' in run.name)`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
-				`WHERE "runs"."name" LIKE $1 AND "runs"."lifecycle_stage" <> $2`,
+				`WHERE ("runs"."name" LIKE $1 AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"%run%", models.LifecycleStageDeleted},
 		},
 		{
 			name:  "TestRunNameWithNotInFunction",
 			query: `('run' not in run.name)`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
-				`WHERE "runs"."name" NOT LIKE $1 AND "runs"."lifecycle_stage" <> $2`,
+				`WHERE ("runs"."name" NOT LIKE $1 AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"%run%", models.LifecycleStageDeleted},
 		},
 		{
 			name:  "TestRunNameWithStartWithFunction",
 			query: `(run.name.startswith('run'))`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
-				`WHERE "runs"."name" LIKE $1 AND "runs"."lifecycle_stage" <> $2`,
+				`WHERE ("runs"."name" LIKE $1 AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"run%", models.LifecycleStageDeleted},
 		},
 		{
 			name:  "TestRunNameWithEndWithFunction",
 			query: `(run.name.endswith('run'))`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
-				`WHERE "runs"."name" LIKE $1 AND "runs"."lifecycle_stage" <> $2`,
+				`WHERE ("runs"."name" LIKE $1 AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"%run", models.LifecycleStageDeleted},
 		},
 		{
 			name:  "TestRunNameWithRegexpMatchFunction",
 			query: `(re.match('run', run.name))`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
-				`WHERE "runs"."name" ~ $1 AND "runs"."lifecycle_stage" <> $2`,
+				`WHERE ("runs"."name" ~ $1 AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"^run", models.LifecycleStageDeleted},
 		},
 		{
 			name:  "TestRunNameWithRegexpSearchFunction",
 			query: `(re.search('run', run.name))`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
-				`WHERE "runs"."name" ~ $1 AND "runs"."lifecycle_stage" <> $2`,
+				`WHERE ("runs"."name" ~ $1 AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"run", models.LifecycleStageDeleted},
 		},
 		{
 			name:  "TestRunNameWithNegatedRegexpMatchFunction",
 			query: `not (re.match('run', run.name))`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
-				`WHERE "runs"."name" !~ $1 AND "runs"."lifecycle_stage" <> $2`,
+				`WHERE ("runs"."name" !~ $1 AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"^run", models.LifecycleStageDeleted},
 		},
 		{
 			name:  "TestRunNameWithNegatedRegexpSearchFunction",
 			query: `not (re.search('run', run.name))`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
-				`WHERE "runs"."name" !~ $1 AND "runs"."lifecycle_stage" <> $2`,
+				`WHERE ("runs"."name" !~ $1 AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"run", models.LifecycleStageDeleted},
 		},
 		{
@@ -111,7 +111,7 @@ func (s *QueryTestSuite) TestPostgresDialector_Ok() {
 			query: `run.metrics['my_metric'].last < -1`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
 				`LEFT JOIN latest_metrics metrics_0 ON runs.run_uuid = metrics_0.run_uuid AND metrics_0.key = $1 ` +
-				`WHERE "metrics_0"."value" < $2 AND "runs"."lifecycle_stage" <> $3`,
+				`WHERE ("metrics_0"."value" < $2 AND "runs"."lifecycle_stage" <> $3) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"my_metric", -1, models.LifecycleStageDeleted},
 		},
 		{
@@ -119,7 +119,7 @@ func (s *QueryTestSuite) TestPostgresDialector_Ok() {
 			query: `run.metrics['my_metric'].last < -1.0`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
 				`LEFT JOIN latest_metrics metrics_0 ON runs.run_uuid = metrics_0.run_uuid AND metrics_0.key = $1 ` +
-				`WHERE "metrics_0"."value" < $2 AND "runs"."lifecycle_stage" <> $3`,
+				`WHERE ("metrics_0"."value" < $2 AND "runs"."lifecycle_stage" <> $3) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"my_metric", -1.0, models.LifecycleStageDeleted},
 		},
 		{
@@ -128,8 +128,8 @@ func (s *QueryTestSuite) TestPostgresDialector_Ok() {
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
 				`LEFT JOIN latest_metrics metrics_0 ON runs.run_uuid = metrics_0.run_uuid AND metrics_0.key = $1 ` +
 				`LEFT JOIN contexts contexts_1 ON metrics_0.context_id = contexts_1.id ` +
-				`WHERE "contexts_1"."json"#>>$2 = $3 ` +
-				`AND ("metrics_0"."value" < $4 AND "runs"."lifecycle_stage" <> $5)`,
+				`WHERE ("contexts_1"."json"#>>$2 = $3 ` +
+				`AND ("metrics_0"."value" < $4 AND "runs"."lifecycle_stage" <> $5)) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"my_metric", "{key1}", "value1", -1, models.LifecycleStageDeleted},
 		},
 		{
@@ -138,89 +138,100 @@ func (s *QueryTestSuite) TestPostgresDialector_Ok() {
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
 				`LEFT JOIN latest_metrics metrics_0 ON runs.run_uuid = metrics_0.run_uuid AND metrics_0.key = $1 ` +
 				`LEFT JOIN contexts contexts_1 ON metrics_0.context_id = contexts_1.id ` +
-				`WHERE "contexts_1"."json"#>>$2 = $3 ` +
-				`AND ("metrics_0"."value" < $4 AND "runs"."lifecycle_stage" <> $5)`,
+				`WHERE ("contexts_1"."json"#>>$2 = $3 ` +
+				`AND ("metrics_0"."value" < $4 AND "runs"."lifecycle_stage" <> $5)) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"my_metric", "{key1}", "value1", -1, models.LifecycleStageDeleted},
 		},
 		{
 			name:  "TestTagsSubscript",
 			query: `(run.tags["foo"] == "bar")`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" LEFT JOIN tags tags_0 ON runs.run_uuid = tags_0.run_uuid ` +
-				`AND tags_0.key = $1 WHERE "tags_0"."value" = $2 AND "runs"."lifecycle_stage" <> $3`,
+				`AND tags_0.key = $1 WHERE ("tags_0"."value" = $2 ` +
+				`AND "runs"."lifecycle_stage" <> $3) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"foo", "bar", models.LifecycleStageDeleted},
 		},
 		{
 			name:  "TestTagsAttribute",
 			query: `(run.tags.foo == "bar")`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" LEFT JOIN tags tags_0 ON runs.run_uuid = tags_0.run_uuid ` +
-				`AND tags_0.key = $1 WHERE "tags_0"."value" = $2 AND "runs"."lifecycle_stage" <> $3`,
+				`AND tags_0.key = $1 WHERE ("tags_0"."value" = $2 ` +
+				`AND "runs"."lifecycle_stage" <> $3) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"foo", "bar", models.LifecycleStageDeleted},
 		},
 		{
-			name:         "TestCreationTimeAttribute",
-			query:        `run.creation_time == 12345678`,
-			expectedSQL:  `SELECT "run_uuid" FROM "runs" WHERE "runs"."start_time" = $1 AND "runs"."lifecycle_stage" <> $2`,
+			name:  "TestCreationTimeAttribute",
+			query: `run.creation_time == 12345678`,
+			expectedSQL: `SELECT "run_uuid" FROM "runs" WHERE ("runs"."start_time" = $1 ` +
+				`AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{12345678, models.LifecycleStageDeleted},
 		},
 		{
-			name:         "TestCreatedAtAttribute",
-			query:        `run.created_at == 12345678`,
-			expectedSQL:  `SELECT "run_uuid" FROM "runs" WHERE "runs"."start_time" = $1 AND "runs"."lifecycle_stage" <> $2`,
+			name:  "TestCreatedAtAttribute",
+			query: `run.created_at == 12345678`,
+			expectedSQL: `SELECT "run_uuid" FROM "runs" WHERE ("runs"."start_time" = $1 ` +
+				`AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{12345678, models.LifecycleStageDeleted},
 		},
 		{
-			name:         "TestEndTimeAttribute",
-			query:        `run.end_time == 12345678`,
-			expectedSQL:  `SELECT "run_uuid" FROM "runs" WHERE "runs"."end_time" = $1 AND "runs"."lifecycle_stage" <> $2`,
+			name:  "TestEndTimeAttribute",
+			query: `run.end_time == 12345678`,
+			expectedSQL: `SELECT "run_uuid" FROM "runs" WHERE ("runs"."end_time" = $1 ` +
+				`AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{12345678, models.LifecycleStageDeleted},
 		},
 		{
-			name:         "TestFinalizedAtAttribute",
-			query:        `run.finalized_at == 12345678`,
-			expectedSQL:  `SELECT "run_uuid" FROM "runs" WHERE "runs"."end_time" = $1 AND "runs"."lifecycle_stage" <> $2`,
+			name:  "TestFinalizedAtAttribute",
+			query: `run.finalized_at == 12345678`,
+			expectedSQL: `SELECT "run_uuid" FROM "runs" WHERE ("runs"."end_time" = $1 ` +
+				`AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{12345678, models.LifecycleStageDeleted},
 		},
 		{
-			name:         "TestHashAttribute",
-			query:        `run.hash == "hash"`,
-			expectedSQL:  `SELECT "run_uuid" FROM "runs" WHERE "runs"."run_uuid" = $1 AND "runs"."lifecycle_stage" <> $2`,
+			name:  "TestHashAttribute",
+			query: `run.hash == "hash"`,
+			expectedSQL: `SELECT "run_uuid" FROM "runs" WHERE ("runs"."run_uuid" = $1 ` +
+				`AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"hash", models.LifecycleStageDeleted},
 		},
 		{
-			name:         "TestNameAttribute",
-			query:        `run.name == "run name"`,
-			expectedSQL:  `SELECT "run_uuid" FROM "runs" WHERE "runs"."name" = $1 AND "runs"."lifecycle_stage" <> $2`,
+			name:  "TestNameAttribute",
+			query: `run.name == "run name"`,
+			expectedSQL: `SELECT "run_uuid" FROM "runs" WHERE ("runs"."name" = $1 ` +
+				`AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"run name", models.LifecycleStageDeleted},
 		},
 		{
-			name:         "TestExperimentAttribute",
-			query:        `run.experiment == "experiment name"`,
-			expectedSQL:  `SELECT "run_uuid" FROM "runs" WHERE "Experiment"."name" = $1 AND "runs"."lifecycle_stage" <> $2`,
+			name:  "TestExperimentAttribute",
+			query: `run.experiment == "experiment name"`,
+			expectedSQL: `SELECT "run_uuid" FROM "runs" WHERE ("Experiment"."name" = $1 ` +
+				`AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"experiment name", models.LifecycleStageDeleted},
 		},
 		{
 			name:         "TestArchivedAttribute",
 			query:        `run.archived`,
-			expectedSQL:  `SELECT "run_uuid" FROM "runs" WHERE "runs"."lifecycle_stage" = $1`,
+			expectedSQL:  `SELECT "run_uuid" FROM "runs" WHERE "runs"."lifecycle_stage" = $1 AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{models.LifecycleStageDeleted},
 		},
 		{
-			name:         "TestActiveAttribute",
-			query:        `run.active`,
-			expectedSQL:  `SELECT "run_uuid" FROM "runs" WHERE "runs"."status" = $1 AND "runs"."lifecycle_stage" <> $2`,
+			name:  "TestActiveAttribute",
+			query: `run.active`,
+			expectedSQL: `SELECT "run_uuid" FROM "runs" WHERE ("runs"."status" = $1 ` +
+				`AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{models.StatusRunning, models.LifecycleStageDeleted},
 		},
 		{
 			name:  "TestDurationAttribute",
 			query: `run.duration == 123456789`,
-			expectedSQL: `SELECT "run_uuid" FROM "runs" WHERE (runs.end_time - runs.start_time) / 1000 = $1 AND ` +
-				`"runs"."lifecycle_stage" <> $2`,
+			expectedSQL: `SELECT "run_uuid" FROM "runs" WHERE ((runs.end_time - runs.start_time) / 1000 = $1 AND ` +
+				`"runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{123456789, models.LifecycleStageDeleted},
 		},
 		{
-			name:         "TestDatetimeFunction",
-			query:        `run.creation_time > datetime(2022, 2, 2)`,
-			expectedSQL:  `SELECT "run_uuid" FROM "runs" WHERE "runs"."start_time" > $1 AND "runs"."lifecycle_stage" <> $2`,
+			name:  "TestDatetimeFunction",
+			query: `run.creation_time > datetime(2022, 2, 2)`,
+			expectedSQL: `SELECT "run_uuid" FROM "runs" WHERE ("runs"."start_time" > $1 ` +
+				`AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{int64(1643760000000), models.LifecycleStageDeleted},
 		},
 	}
@@ -271,63 +282,65 @@ func (s *QueryTestSuite) TestSqliteDialector_Ok() {
 			name:  "TestRunNameWithoutFunction",
 			query: `(run.name == 'run')`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
-				`WHERE "runs"."name" = $1 AND "runs"."lifecycle_stage" <> $2`,
+				`WHERE ("runs"."name" = $1 AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"run", models.LifecycleStageDeleted},
 		},
 		{
 			name:  "TestRunNameWithInFunction",
 			query: `('run' in run.name)`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
-				`WHERE "runs"."name" LIKE $1 AND "runs"."lifecycle_stage" <> $2`,
+				`WHERE ("runs"."name" LIKE $1 AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"%run%", models.LifecycleStageDeleted},
 		},
 		{
 			name:  "TestRunNameWithNotInFunction",
 			query: `('run' not in run.name)`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
-				`WHERE "runs"."name" NOT LIKE $1 AND "runs"."lifecycle_stage" <> $2`,
+				`WHERE ("runs"."name" NOT LIKE $1 AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"%run%", models.LifecycleStageDeleted},
 		},
 		{
 			name:  "TestRunNameWithStartWithFunction",
 			query: `(run.name.startswith('run'))`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
-				`WHERE "runs"."name" LIKE $1 AND "runs"."lifecycle_stage" <> $2`,
+				`WHERE ("runs"."name" LIKE $1 AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"run%", models.LifecycleStageDeleted},
 		},
 		{
 			name:  "TestRunNameWithEndWithFunction",
 			query: `(run.name.endswith('run'))`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
-				`WHERE "runs"."name" LIKE $1 AND "runs"."lifecycle_stage" <> $2`,
+				`WHERE ("runs"."name" LIKE $1 AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"%run", models.LifecycleStageDeleted},
 		},
 		{
 			name:  "TestRunNameWithRegexpMatchFunction",
 			query: `(re.match('run', run.name))`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
-				`WHERE IFNULL("runs"."name", '') REGEXP $1 AND "runs"."lifecycle_stage" <> $2`,
+				`WHERE (IFNULL("runs"."name", '') REGEXP $1 AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"^run", models.LifecycleStageDeleted},
 		},
 		{
 			name:  "TestRunNameWithRegexpSearchFunction",
 			query: `(re.search('run', run.name))`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
-				`WHERE IFNULL("runs"."name", '') REGEXP $1 AND "runs"."lifecycle_stage" <> $2`,
+				`WHERE (IFNULL("runs"."name", '') REGEXP $1 AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"run", models.LifecycleStageDeleted},
 		},
 		{
 			name:  "TestRunNameWithNegatedRegexpMatchFunction",
 			query: `not (re.match('run', run.name))`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
-				`WHERE IFNULL("runs"."name", '') NOT REGEXP $1 AND "runs"."lifecycle_stage" <> $2`,
+				`WHERE (IFNULL("runs"."name", '') NOT REGEXP $1 ` +
+				`AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"^run", models.LifecycleStageDeleted},
 		},
 		{
 			name:  "TestRunNameWithNegatedRegexpSearchFunction",
 			query: `not (re.search('run', run.name))`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
-				`WHERE IFNULL("runs"."name", '') NOT REGEXP $1 AND "runs"."lifecycle_stage" <> $2`,
+				`WHERE (IFNULL("runs"."name", '') NOT REGEXP $1 ` +
+				`AND "runs"."lifecycle_stage" <> $2) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"run", models.LifecycleStageDeleted},
 		},
 		{
@@ -335,7 +348,7 @@ func (s *QueryTestSuite) TestSqliteDialector_Ok() {
 			query: `run.metrics['my_metric'].last < -1`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
 				`LEFT JOIN latest_metrics metrics_0 ON runs.run_uuid = metrics_0.run_uuid AND metrics_0.key = $1 ` +
-				`WHERE "metrics_0"."value" < $2 AND "runs"."lifecycle_stage" <> $3`,
+				`WHERE ("metrics_0"."value" < $2 AND "runs"."lifecycle_stage" <> $3) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"my_metric", -1, models.LifecycleStageDeleted},
 		},
 		{
@@ -343,7 +356,7 @@ func (s *QueryTestSuite) TestSqliteDialector_Ok() {
 			query: `run.metrics['my_metric'].last < -1.0`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
 				`LEFT JOIN latest_metrics metrics_0 ON runs.run_uuid = metrics_0.run_uuid AND metrics_0.key = $1 ` +
-				`WHERE "metrics_0"."value" < $2 AND "runs"."lifecycle_stage" <> $3`,
+				`WHERE ("metrics_0"."value" < $2 AND "runs"."lifecycle_stage" <> $3) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"my_metric", -1.0, models.LifecycleStageDeleted},
 		},
 		{
@@ -351,7 +364,7 @@ func (s *QueryTestSuite) TestSqliteDialector_Ok() {
 			query: `run.metrics["key1"].last < -1`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
 				`LEFT JOIN latest_metrics metrics_0 ON runs.run_uuid = metrics_0.run_uuid AND metrics_0.key = $1 ` +
-				`WHERE "metrics_0"."value" < $2 AND "runs"."lifecycle_stage" <> $3`,
+				`WHERE ("metrics_0"."value" < $2 AND "runs"."lifecycle_stage" <> $3) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"key1", -1, models.LifecycleStageDeleted},
 		},
 		{
@@ -360,8 +373,8 @@ func (s *QueryTestSuite) TestSqliteDialector_Ok() {
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
 				`LEFT JOIN latest_metrics metrics_0 ON runs.run_uuid = metrics_0.run_uuid AND metrics_0.key = $1 ` +
 				`LEFT JOIN contexts contexts_1 ON metrics_0.context_id = contexts_1.id ` +
-				`WHERE IFNULL("contexts_1"."json", JSON('{}'))->>$2 = $3 ` +
-				`AND ("metrics_0"."value" < $4 AND "runs"."lifecycle_stage" <> $5)`,
+				`WHERE (IFNULL("contexts_1"."json", JSON('{}'))->>$2 = $3 ` +
+				`AND ("metrics_0"."value" < $4 AND "runs"."lifecycle_stage" <> $5)) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"my_metric", "$.key1", "value1", -1, models.LifecycleStageDeleted},
 		},
 		{
@@ -370,17 +383,17 @@ func (s *QueryTestSuite) TestSqliteDialector_Ok() {
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
 				`LEFT JOIN latest_metrics metrics_0 ON runs.run_uuid = metrics_0.run_uuid AND metrics_0.key = $1 ` +
 				`LEFT JOIN contexts contexts_1 ON metrics_0.context_id = contexts_1.id ` +
-				`WHERE IFNULL("contexts_1"."json", JSON('{}'))->>$2 = $3 ` +
-				`AND ("metrics_0"."value" < $4 AND "runs"."lifecycle_stage" <> $5)`,
+				`WHERE (IFNULL("contexts_1"."json", JSON('{}'))->>$2 = $3 ` +
+				`AND ("metrics_0"."value" < $4 AND "runs"."lifecycle_stage" <> $5)) AND "runs"."deleted_at" IS NULL`,
 			expectedVars: []interface{}{"my_metric", "$.key1", "value1", -1, models.LifecycleStageDeleted},
 		},
 		{
 			name:  "TestImagesName",
 			query: `(images.name == 'my-image')`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
-				`INNER JOIN artifacts artifacts_0 ON runs.run_uuid = artifacts_0.run_uuid ` +
-				`WHERE "artifacts_0"."name" = $1 AND "runs"."lifecycle_stage" <> $2`,
-			expectedVars: []interface{}{"my-image", models.LifecycleStageDeleted},
+				`INNER JOIN artifacts artifacts_0 ON runs.run_uuid = artifacts_0.run_uuid AND artifacts_0.type = $1 ` +
+				`WHERE ("artifacts_0"."name" = $2 AND "runs"."lifecycle_stage" <> $3) AND "runs"."deleted_at" IS NULL`,
+			expectedVars: []interface{}{"images", "my-image", models.LifecycleStageDeleted},
 		},
 	}
 
@@ -435,6 +448,11 @@ func (s *QueryTestSuite) Test_Error() {
 			query:         `run.metrics[{"key1": "value1"}].last < -1`,
 			expectedError: SyntaxError{},
 		},
+		{
+			name:          "TestParamSubscriptNonStringIndex",
+			query:         `run["hparams"][0] > 1`,
+			expectedError: SyntaxError{},
+		},
 	}
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
@@ -61,16 +61,19 @@ func (r *Router) Init(server fiber.Router) {
 
 	runs := mainGroup.Group("/runs")
 	runs.Get("/active/", r.controller.GetRunsActive)
+	runs.Get("/live/", r.controller.GetRunsLive)
 	runs.Get("/search/run/", r.controller.SearchRuns)
 	runs.Post("/search/metric/", r.controller.SearchMetrics)
 	runs.Post("/search/metric/align/", r.controller.SearchAlignedMetrics)
-	runs.Post("/search/images/", r.controller.SearchImages)
+	runs.Post("/search/:sequence/", r.controller.SearchArtifacts)
 	runs.Get("/:id/info/", r.controller.GetRunInfo)
 	runs.Post("/:id/tags/new", r.controller.AddRunTag)
 	runs.Delete("/:id/tags/:tagID", r.controller.DeleteRunTag)
 	runs.Post("/:id/metric/get-batch/", r.controller.GetRunMetrics)
 	runs.Post("/:id/images/get-batch/", r.controller.GetRunImages)
+	runs.Post("/:id/:sequence/get-batch/", r.controller.GetRunArtifacts)
 	runs.Post("/images/get-batch/", r.controller.GetRunImagesBatch)
+	runs.Post("/audios/get-batch/", r.controller.GetRunImagesBatch)
 	runs.Put("/:id/", r.controller.UpdateRun)
 	runs.Get("/:id/logs", r.controller.GetRunLogs)
 	runs.Delete("/:id/", r.controller.DeleteRun)
@@ -0,0 +1,180 @@
+// Package sampling implements downsampling of metric sequences for the Aim UI charts.
+package sampling
+
+import (
+	"math"
+	"slices"
+)
+
+// Supported downsampling methods.
+const (
+	// MethodModulo keeps every n-th point of the sequence. It is the default method and,
+	// unlike the others, is applied by the database query itself.
+	MethodModulo = "modulo"
+	// MethodLTTB uses Largest-Triangle-Three-Buckets algorithm which keeps the visual shape of the sequence.
+	MethodLTTB = "lttb"
+	// MethodMinMax keeps the minimum and the maximum point of each bucket.
+	MethodMinMax = "minmax"
+	// MethodMean replaces each bucket with the mean value of its points.
+	MethodMean = "mean"
+)
+
+// SupportedMethods list of supported downsampling methods.
+var SupportedMethods = []string{
+	MethodModulo,
+	MethodLTTB,
+	MethodMinMax,
+	MethodMean,
+}
+
+// Point represents a point of the metric sequence.
+type Point struct {
+	Value      float64
+	Iter       float64
+	Epoch      float64
+	Timestamp  float64
+	XAxisValue float64
+}
+
+// IsSupported checks that the method is supported. Empty method means default one.
+func IsSupported(method string) bool {
+	return method == "" || slices.Contains(SupportedMethods, method)
+}
+
+// IsDefault checks that the method is the default one which is applied by the database query.
+func IsDefault(method string) bool {
+	return method == "" || method == MethodModulo
+}
+
+// Downsample reduces the number of points to the threshold using the method. Points are expected
+// to be ordered by iteration. NaN values are never dropped in favor of regular values,
+// so the divergence of the sequence stays visible.
+func Downsample(method string, points []Point, threshold int) []Point {
+	if threshold <= 0 || len(points) <= threshold {
+		return points
+	}
+	switch method {
+	case MethodLTTB:
+		return lttb(points, threshold)
+	case MethodMinMax:
+		return minMax(points, threshold)
+	case MethodMean:
+		return mean(points, threshold)
+	default:
+		return points
+	}
+}
+
+// bucketBounds returns bounds of the i-th of n equal buckets of the size points.
+func bucketBounds(i, n, size int) (int, int) {
+	return i * size / n, (i + 1) * size / n
+}
+
+// lttb implements Largest-Triangle-Three-Buckets algorithm. First and last points are always kept, other
+// points are split into buckets and the point forming the largest triangle with the previously selected
+// point and the average of the next bucket is selected from each bucket.
+func lttb(points []Point, threshold int) []Point {
+	if threshold < 3 {
+		return []Point{points[0], points[len(points)-1]}
+	}
+
+	inner := points[1 : len(points)-1]
+	buckets := threshold - 2
+	sampled := make([]Point, 0, threshold)
+	sampled = append(sampled, points[0])
+	selected := points[0]
+	for i := 0; i < buckets; i++ {
+		start, end := bucketBounds(i, buckets, len(inner))
+
+		// average point of the next bucket, the last point is used after the last bucket.
+		var avgX, avgY float64
+		if i+1 < buckets {
+			nextStart, nextEnd := bucketBounds(i+1, buckets, len(inner))
+			count := 0
+			for _, p := range inner[nextStart:nextEnd] {
+				avgX += p.Iter
+				if !math.IsNaN(p.Value) {
+					avgY += p.Value
+					count++
+				}
+			}
+			avgX /= float64(nextEnd - nextStart)
+			if count > 0 {
+				avgY /= float64(count)
+			} else {
+				avgY = selected.Value
+			}
+		} else {
+			last := points[len(points)-1]
+			avgX, avgY = last.Iter, last.Value
+		}
+
+		index, maxArea := start, -1.0
+		for j := start; j < end; j++ {
+			p := inner[j]
+			if math.IsNaN(p.Value) {
+				index = j
+				break
+			}
+			area := math.Abs((selected.Iter-avgX)*(p.Value-selected.Value) - (selected.Iter-p.Iter)*(avgY-selected.Value))
+			if area > maxArea {
+				index, maxArea = j, area
+			}
+		}
+		selected = inner[index]
+		sampled = append(sampled, selected)
+	}
+	return append(sampled, points[len(points)-1])
+}
+
+// minMax splits the points into threshold/2 buckets and keeps the minimum and the maximum point of each
+// bucket in their original order. The first NaN point of the bucket is kept as well.
+func minMax(points []Point, threshold int) []Point {
+	buckets := max(threshold/2, 1)
+	sampled := make([]Point, 0, threshold)
+	for i := 0; i < buckets; i++ {
+		start, end := bucketBounds(i, buckets, len(points))
+		minIndex, maxIndex, nanIndex := -1, -1, -1
+		for j := start; j < end; j++ {
+			switch v := points[j].Value; {
+			case math.IsNaN(v):
+				if nanIndex == -1 {
+					nanIndex = j
+				}
+			default:
+				if minIndex == -1 || v < points[minIndex].Value {
+					minIndex = j
+				}
+				if maxIndex == -1 || v > points[maxIndex].Value {
+					maxIndex = j
+				}
+			}
+		}
+		indexes := []int{minIndex, maxIndex, nanIndex}
+		slices.Sort(indexes)
+		for _, index := range slices.Compact(indexes) {
+			if index != -1 {
+				sampled = append(sampled, points[index])
+			}
+		}
+	}
+	return sampled
+}
+
+// mean splits the points into threshold buckets and replaces each bucket with one point having the mean
+// value of the bucket. Bucket containing NaN value gets NaN value. Other fields are taken from
+// the last point of the bucket.
+func mean(points []Point, threshold int) []Point {
+	sampled := make([]Point, 0, threshold)
+	for i := 0; i < threshold; i++ {
+		start, end := bucketBounds(i, threshold, len(points))
+		sum := 0.0
+		for _, p := range points[start:end] {
+			sum += p.Value
+		}
+		point := points[end-1]
+		point.Value = sum / float64(end-start)
+		sampled = append(sampled, point)
+	}
+	return sampled
+}
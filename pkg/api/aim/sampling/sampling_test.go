@@ -0,0 +1,122 @@
+package sampling
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newPoints creates points with consecutive iterations for the values.
+func newPoints(values ...float64) []Point {
+	points := make([]Point, len(values))
+	for i, v := range values {
+		points[i] = Point{Value: v, Iter: float64(i + 1), Epoch: float64(i), Timestamp: float64(100 + i)}
+	}
+	return points
+}
+
+// values returns values of the points, NaN values are replaced with -1 to make them comparable.
+func values(points []Point) []float64 {
+	result := make([]float64, len(points))
+	for i, p := range points {
+		result[i] = p.Value
+		if math.IsNaN(p.Value) {
+			result[i] = -1
+		}
+	}
+	return result
+}
+
+func TestDownsample_Ok(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		points    []Point
+		threshold int
+		values    []float64
+		iters     []float64
+	}{
+		{
+			name:      "BelowThreshold",
+			method:    MethodLTTB,
+			points:    newPoints(1, 2, 3),
+			threshold: 5,
+			values:    []float64{1, 2, 3},
+			iters:     []float64{1, 2, 3},
+		},
+		{
+			name:      "DefaultMethod",
+			method:    "",
+			points:    newPoints(1, 2, 3, 4),
+			threshold: 2,
+			values:    []float64{1, 2, 3, 4},
+			iters:     []float64{1, 2, 3, 4},
+		},
+		{
+			name:      "LTTBKeepsSpike",
+			method:    MethodLTTB,
+			points:    newPoints(1, 1, 1, 1, 1, 100, 1, 1, 1, 1, 1, 1),
+			threshold: 4,
+			values:    []float64{1, 100, 1, 1},
+			iters:     []float64{1, 6, 7, 12},
+		},
+		{
+			name:      "LTTBKeepsNaN",
+			method:    MethodLTTB,
+			points:    newPoints(1, 2, 3, 4, 5, 6, 7, math.NaN(), 9, 10),
+			threshold: 4,
+			values:    []float64{1, 5, -1, 10},
+			iters:     []float64{1, 5, 8, 10},
+		},
+		{
+			name:      "MinMax",
+			method:    MethodMinMax,
+			points:    newPoints(5, 1, 9, 3, 4, 8, 2, 6),
+			threshold: 4,
+			values:    []float64{1, 9, 8, 2},
+			iters:     []float64{2, 3, 6, 7},
+		},
+		{
+			name:      "MinMaxKeepsNaN",
+			method:    MethodMinMax,
+			points:    newPoints(1, math.NaN(), 3, 4, 5, 6),
+			threshold: 2,
+			values:    []float64{1, -1, 6},
+			iters:     []float64{1, 2, 6},
+		},
+		{
+			name:      "Mean",
+			method:    MethodMean,
+			points:    newPoints(1, 3, 5, 7, 9, 11),
+			threshold: 3,
+			values:    []float64{2, 6, 10},
+			iters:     []float64{2, 4, 6},
+		},
+		{
+			name:      "MeanKeepsNaN",
+			method:    MethodMean,
+			points:    newPoints(1, 3, math.NaN(), 7),
+			threshold: 2,
+			values:    []float64{2, -1},
+			iters:     []float64{2, 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			points := Downsample(tt.method, tt.points, tt.threshold)
+			assert.Equal(t, tt.values, values(points))
+			iters := make([]float64, len(points))
+			for i, p := range points {
+				iters[i] = p.Iter
+			}
+			assert.Equal(t, tt.iters, iters)
+		})
+	}
+}
+
+func TestIsSupported(t *testing.T) {
+	assert.True(t, IsSupported(""))
+	assert.True(t, IsSupported(MethodLTTB))
+	assert.False(t, IsSupported("median"))
+}
@@ -116,15 +116,19 @@ func (s Service) GetProjectParams(
 		}
 		projectParams.Metrics = metrics
 	}
-	if slices.Contains(req.Sequences, "images") {
-		// fetch images available for requested Experiments.
-		images, err := s.artifactRepository.GetArtifactNamesByExperiments(
-			ctx, namespaceID, req.Experiments,
+	projectParams.Artifacts = make(map[string][]string)
+	for _, artifactType := range models.ArtifactTypes {
+		if !slices.Contains(req.Sequences, artifactType) {
+			continue
+		}
+		// fetch artifacts of the type available for requested Experiments.
+		names, err := s.artifactRepository.GetArtifactNamesByExperiments(
+			ctx, namespaceID, req.Experiments, artifactType,
 		)
 		if err != nil {
-			return nil, api.NewInternalError("error getting images: %s", err)
+			return nil, api.NewInternalError("error getting %s: %s", artifactType, err)
 		}
-		projectParams.Images = images
+		projectParams.Artifacts[artifactType] = names
 	}
 	return &projectParams, nil
 }
@@ -18,7 +18,9 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/aim/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+	"github.com/G-Research/fasttrackml/pkg/common/metricstore"
 	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/common/services/live"
 )
 
 // allowed batch actions.
@@ -37,6 +39,7 @@ type Service struct {
 	sharedTagRepository    repositories.SharedTagRepositoryProvider
 	artifactStorageFactory storage.ArtifactStorageFactoryProvider
 	artifactRepository     repositories.ArtifactRepositoryProvider
+	liveUpdatesHub         live.HubProvider
 }
 
 // NewService creates new Service instance.
@@ -60,6 +63,12 @@ func NewService(
 	}
 }
 
+// WithLiveUpdates enables subscriptions to the live updates of runs.
+func (s *Service) WithLiveUpdates(hub live.HubProvider) *Service {
+	s.liveUpdatesHub = hub
+	return s
+}
+
 // GetRunInfo returns run info.
 func (s Service) GetRunInfo(
 	ctx context.Context, namespaceID uint, req *request.GetRunInfoRequest,
@@ -172,10 +181,37 @@ func (s Service) GetRunImages(
 	return images, nil
 }
 
-// GetRunImagesBatch returns run images.
+// GetRunArtifacts returns run artifacts of the sequence type.
+func (s Service) GetRunArtifacts(
+	ctx context.Context, namespaceID uint, runID, sequence string, req *request.GetRunArtifactsRequest,
+) ([]models.Artifact, error) {
+	if err := validateArtifactSequence(sequence); err != nil {
+		return nil, err
+	}
+	run, err := s.runRepository.GetRunByNamespaceIDAndRunID(ctx, namespaceID, runID)
+	if err != nil {
+		return nil, api.NewInternalError("error getting run by id %s: %s", runID, err)
+	}
+	if run == nil {
+		return nil, api.NewResourceDoesNotExistError("run '%s' not found", runID)
+	}
+
+	names := make([]string, len(*req))
+	for i, trace := range *req {
+		names[i] = trace.Name
+	}
+	artifacts, err := s.artifactRepository.GetByRunIDAndNames(ctx, runID, sequence, names)
+	if err != nil {
+		return nil, api.NewInternalError("error getting run %s by id %s: %s", sequence, runID, err)
+	}
+	return artifacts, nil
+}
+
+// GetRunImagesBatch returns run images of the namespace.
 func (s Service) GetRunImagesBatch(
-	ctx context.Context, req *request.GetRunImagesBatchRequest,
+	ctx context.Context, namespaceCode string, req *request.GetRunImagesBatchRequest,
 ) ([]io.ReadCloser, error) {
+	ctx = storage.WithNamespace(ctx, namespaceCode)
 	readers := make([]io.ReadCloser, len(*req))
 	for i, image := range *req {
 		artifactStorage, err := s.artifactStorageFactory.GetStorage(ctx, image)
@@ -208,10 +244,32 @@ func (s Service) GetRunsActive(
 	return runs, nil
 }
 
+// SubscribeRunsLive subscribes to the live updates of the requested runs.
+func (s Service) SubscribeRunsLive(namespaceID uint, req *request.GetRunsLiveRequest) (*live.Subscription, error) {
+	if s.liveUpdatesHub == nil {
+		return nil, api.NewBadRequestError("live updates are disabled")
+	}
+	return s.liveUpdatesHub.Subscribe(namespaceID, req.RunIDs), nil
+}
+
+// UnsubscribeRunsLive removes subscription to the live updates of runs.
+func (s Service) UnsubscribeRunsLive(subscription *live.Subscription) {
+	s.liveUpdatesHub.Unsubscribe(subscription)
+}
+
 // SearchRuns returns the list of runs by provided search criteria.
 func (s Service) SearchRuns(
 	ctx context.Context, namespaceID uint, tzOffset int, req request.SearchRunsRequest,
 ) ([]models.Run, int64, error) {
+	if req.Offset != "" {
+		run, err := s.runRepository.GetRunByNamespaceIDAndRunID(ctx, namespaceID, req.Offset)
+		if err != nil {
+			return nil, 0, api.NewInternalError("unable to find run '%s': %s", req.Offset, err)
+		}
+		if run == nil {
+			return nil, 0, api.NewResourceDoesNotExistError("run '%s' not found", req.Offset)
+		}
+	}
 	runs, total, err := s.runRepository.SearchRuns(ctx, namespaceID, tzOffset, req)
 	if err != nil {
 		return nil, 0, api.NewInternalError("error searching runs: %s", err)
@@ -222,18 +280,24 @@ func (s Service) SearchRuns(
 // SearchMetrics returns the list of metrics by provided search criteria.
 func (s Service) SearchMetrics(
 	ctx context.Context, namespaceID uint, timeZoneOffset int, req request.SearchMetricsRequest,
-) (*sql.Rows, int64, repositories.SearchResultMap, error) {
-	rows, total, searchResult, err := s.metricRepository.SearchMetrics(ctx, namespaceID, timeZoneOffset, req)
+) (metricstore.Cursor, int64, repositories.SearchResultMap, error) {
+	if err := ValidateSearchMetricsRequest(&req); err != nil {
+		return nil, 0, nil, err
+	}
+	cursor, total, searchResult, err := s.metricRepository.SearchMetrics(ctx, namespaceID, timeZoneOffset, req)
 	if err != nil {
 		return nil, 0, nil, api.NewInternalError("error searching runs: %s", err)
 	}
-	return rows, total, searchResult, nil
+	return cursor, total, searchResult, nil
 }
 
-// SearchArtifacts returns the list of artifacts (images) by provided search criteria.
+// SearchArtifacts returns the list of artifacts (images, texts, etc.) by provided search criteria.
 func (s Service) SearchArtifacts(
 	ctx context.Context, namespaceID uint, timeZoneOffset int, req request.SearchArtifactsRequest,
 ) (*sql.Rows, map[string]models.Run, repositories.ArtifactSearchSummary, error) {
+	if err := ValidateSearchArtifactsRequest(&req); err != nil {
+		return nil, nil, nil, err
+	}
 	rows, runs, result, err := s.artifactRepository.Search(ctx, namespaceID, timeZoneOffset, req)
 	if err != nil {
 		return nil, nil, nil, api.NewInternalError("error searching artifacts: %s", err)
@@ -244,7 +308,7 @@ func (s Service) SearchArtifacts(
 // SearchAlignedMetrics returns the list of aligned metrics.
 func (s Service) SearchAlignedMetrics(
 	ctx context.Context, namespaceID uint, req *request.SearchAlignedMetricsRequest,
-) (*sql.Rows, func(*sql.Rows) (*models.AlignedMetric, error), int, error) {
+) (metricstore.Cursor, int, error) {
 	// collect map of unique contexts, collect values.
 	values, capacity, contextsMap := []any{}, 0, map[string]types.JSONB{}
 	for _, r := range req.Runs {
@@ -255,7 +319,7 @@ func (s Service) SearchAlignedMetrics(
 			}
 			data, err := json.Marshal(t.Context)
 			if err != nil {
-				return nil, nil, 0, api.NewInternalError("error serializing context: %s", err)
+				return nil, 0, api.NewInternalError("error serializing context: %s", err)
 			}
 			values, contextsMap[string(data)] = append(values, r.ID, t.Name, data, float32(l)), data
 		}
@@ -263,7 +327,7 @@ func (s Service) SearchAlignedMetrics(
 
 	contexts, err := s.metricRepository.GetContextListByContextObjects(ctx, contextsMap)
 	if err != nil {
-		return nil, nil, 0, api.NewInternalError("error getting context list: %s", err)
+		return nil, 0, api.NewInternalError("error getting context list: %s", err)
 	}
 
 	// add context ids to `values` array.
@@ -275,12 +339,12 @@ func (s Service) SearchAlignedMetrics(
 		}
 	}
 
-	rows, next, err := s.runRepository.GetAlignedMetrics(ctx, namespaceID, values, req.AlignBy)
+	cursor, err := s.runRepository.GetAlignedMetrics(ctx, namespaceID, values, req.AlignBy)
 	if err != nil {
-		return nil, nil, 0, api.NewInternalError("error searching aligned run metrics: %s", err)
+		return nil, 0, api.NewInternalError("error searching aligned run metrics: %s", err)
 	}
 
-	return rows, next, capacity, nil
+	return cursor, capacity, nil
 }
 
 // DeleteRun deletes requested run.
@@ -4,6 +4,8 @@ import (
 	"slices"
 
 	"github.com/G-Research/fasttrackml/pkg/api/aim/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/aim/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/aim/sampling"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 )
 
@@ -28,3 +30,28 @@ func ValidateGetRunInfoRequest(req *request.GetRunInfoRequest) error {
 	}
 	return nil
 }
+
+// ValidateSearchMetricsRequest validates `POST /runs/search/metric` request.
+func ValidateSearchMetricsRequest(req *request.SearchMetricsRequest) error {
+	if !sampling.IsSupported(req.Sampling) {
+		return api.NewInvalidParameterValueError(
+			"%q is not a valid sampling method, supported methods are: %v", req.Sampling, sampling.SupportedMethods,
+		)
+	}
+	return nil
+}
+
+// ValidateSearchArtifactsRequest validates `POST /runs/search/:sequence` request.
+func ValidateSearchArtifactsRequest(req *request.SearchArtifactsRequest) error {
+	return validateArtifactSequence(req.Sequence)
+}
+
+// validateArtifactSequence validates that the sequence is one of the artifact types.
+func validateArtifactSequence(sequence string) error {
+	if !slices.Contains(models.ArtifactTypes, sequence) {
+		return api.NewInvalidParameterValueError(
+			"%q is not a valid Sequence, supported sequences are: %v", sequence, models.ArtifactTypes,
+		)
+	}
+	return nil
+}
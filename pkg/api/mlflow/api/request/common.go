@@ -8,6 +8,27 @@ const (
 	ViewTypeDeletedOnly ViewType = "DELETED_ONLY"
 )
 
+// ExportFormat is the format of the exported runs and metrics.
+type ExportFormat string
+
+const (
+	ExportFormatArrow   ExportFormat = "arrow"
+	ExportFormatCSV     ExportFormat = "csv"
+	ExportFormatParquet ExportFormat = "parquet"
+)
+
+// PageToken is the decoded `page_token` value. Search runs and search experiments tokens are keyset cursors,
+// which hold the values of the effective `order_by` columns of the last returned row. Offset is still used by
+// the models search and accepted from the tokens issued by the older versions.
 type PageToken struct {
-	Offset int32 `json:"offset"`
+	Offset int32          `json:"offset,omitempty"`
+	Keys   []PageTokenKey `json:"keys,omitempty"`
+}
+
+// PageTokenKey is the value of single order column. All the fields are empty for NULL value.
+type PageTokenKey struct {
+	Int *int64 `json:"i,omitempty"`
+	// Float is formatted as string to keep NaN and infinite values.
+	Float  *string `json:"f,omitempty"`
+	String *string `json:"s,omitempty"`
 }
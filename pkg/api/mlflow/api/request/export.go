@@ -0,0 +1,27 @@
+package request
+
+// ExportTable is the table written by the export.
+type ExportTable string
+
+const (
+	// ExportTableRuns is the table of the runs with their attributes, latest metrics, params and tags.
+	ExportTableRuns ExportTable = "runs"
+	// ExportTableMetrics is the long-format table of the metric histories.
+	ExportTableMetrics ExportTable = "metrics"
+	// ExportTableLatestMetrics is the wide-format table of the latest metrics, single column per metric key.
+	ExportTableLatestMetrics ExportTable = "latest_metrics"
+)
+
+// CreateExportRequest is a request object for `POST /mlflow/exports/create` endpoint.
+type CreateExportRequest struct {
+	ExportRunsRequest
+	Table ExportTable `json:"table"`
+	// Destination is the directory relative to the exports directory of the namespace, where the exported
+	// file is written.
+	Destination string `json:"destination"`
+}
+
+// GetExportRequest is a request object for `GET /mlflow/exports/get` endpoint.
+type GetExportRequest struct {
+	ID string `query:"export_id"`
+}
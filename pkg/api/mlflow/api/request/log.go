@@ -1,5 +1,7 @@
 package request
 
+import "encoding/json"
+
 // ParamPartialRequest is a partial request object for different requests.
 type ParamPartialRequest struct {
 	Key        string   `json:"key"`
@@ -87,3 +89,40 @@ type LogArtifactRequest struct {
 	Format  string `json:"format"`
 	BlobURI string `json:"blob_uri"`
 }
+
+// LogTextRequest is a request object for `POST mlflow/runs/log-text` endpoint.
+type LogTextRequest struct {
+	RunID string `json:"run_id"`
+	Name  string `json:"name"`
+	Step  int64  `json:"step"`
+	Index int64  `json:"index"`
+	Text  string `json:"text"`
+}
+
+// LogAudioRequest is a request object for `POST mlflow/runs/log-audio` endpoint.
+type LogAudioRequest struct {
+	RunID   string `json:"run_id"`
+	Name    string `json:"name"`
+	Step    int64  `json:"step"`
+	Index   int64  `json:"index"`
+	Caption string `json:"caption"`
+	Format  string `json:"format"`
+	BlobURI string `json:"blob_uri"`
+}
+
+// LogDistributionRequest is a request object for `POST mlflow/runs/log-distribution` endpoint.
+type LogDistributionRequest struct {
+	RunID    string     `json:"run_id"`
+	Name     string     `json:"name"`
+	Step     int64      `json:"step"`
+	Weights  []float64  `json:"weights"`
+	BinRange [2]float64 `json:"bin_range"`
+}
+
+// LogFigureRequest is a request object for `POST mlflow/runs/log-figure` endpoint.
+type LogFigureRequest struct {
+	RunID  string          `json:"run_id"`
+	Name   string          `json:"name"`
+	Step   int64           `json:"step"`
+	Figure json.RawMessage `json:"figure"`
+}
@@ -56,6 +56,22 @@ type SearchRunsRequest struct {
 	PageToken     string   `json:"page_token"`
 }
 
+// ExportRunsRequest is a request object for `POST /mlflow/runs/export` and
+// `POST /mlflow/runs/export-metrics` endpoints.
+type ExportRunsRequest struct {
+	ExperimentIDs []string `json:"experiment_ids"`
+	Filter        string   `json:"filter"`
+	// Query is Aim QL query, which selects the runs instead of the filter, e.g. `run.metrics['loss'].last < 1`.
+	Query    string   `json:"query"`
+	ViewType ViewType `json:"run_view_type"`
+	// Columns is the list of the exported columns. All the columns are exported when it is empty.
+	Columns []string `json:"columns"`
+	// MetricKeys limits the metric histories exported by `POST /mlflow/runs/export-metrics` endpoint.
+	MetricKeys []string `json:"metric_keys"`
+	// Format is the format of the exported data. Arrow IPC stream is used by default.
+	Format ExportFormat `json:"format"`
+}
+
 // RestoreRunRequest is a request object for `POST /mlflow/runs/restore` endpoint.
 type RestoreRunRequest struct {
 	RunID string `json:"run_id"`
@@ -87,3 +103,31 @@ type DeleteRunTagRequest struct {
 	RunID string `json:"run_id"`
 	Key   string `json:"key"`
 }
+
+// DatasetPartialRequest is a partial request object for `POST /mlflow/runs/log-inputs` endpoint.
+type DatasetPartialRequest struct {
+	Name       string `json:"name"`
+	Digest     string `json:"digest"`
+	SourceType string `json:"source_type"`
+	Source     string `json:"source"`
+	Schema     string `json:"schema"`
+	Profile    string `json:"profile"`
+}
+
+// DatasetInputPartialRequest is a partial request object for `POST /mlflow/runs/log-inputs` endpoint.
+type DatasetInputPartialRequest struct {
+	Tags    []InputTagPartialRequest `json:"tags"`
+	Dataset DatasetPartialRequest    `json:"dataset"`
+}
+
+// InputTagPartialRequest is a partial request object for `POST /mlflow/runs/log-inputs` endpoint.
+type InputTagPartialRequest struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// LogInputsRequest is a request object for `POST /mlflow/runs/log-inputs` endpoint.
+type LogInputsRequest struct {
+	RunID    string                       `json:"run_id"`
+	Datasets []DatasetInputPartialRequest `json:"datasets"`
+}
@@ -61,17 +61,17 @@ type SearchExperimentsResponse struct {
 
 // NewSearchExperimentsResponse  creates new SearchExperimentsResponse object.
 func NewSearchExperimentsResponse(
-	experiments []models.Experiment, limit, offset int,
+	experiments []models.Experiment, nextPageToken *request.PageToken,
 ) (*SearchExperimentsResponse, error) {
 	// encode `nextPageToken` value.
 	var token strings.Builder
-	if len(experiments) > limit {
-		experiments = experiments[:limit]
-		if err := json.NewEncoder(
-			base64.NewEncoder(base64.StdEncoding, &token),
-		).Encode(request.PageToken{
-			Offset: int32(offset + limit),
-		}); err != nil {
+	if nextPageToken != nil {
+		encoder := base64.NewEncoder(base64.StdEncoding, &token)
+		if err := json.NewEncoder(encoder).Encode(nextPageToken); err != nil {
+			return nil, eris.Wrap(err, "error encoding 'nextPageToken' value")
+		}
+		// the last partial block of the encoded value is written on close.
+		if err := encoder.Close(); err != nil {
 			return nil, eris.Wrap(err, "error encoding 'nextPageToken' value")
 		}
 	}
@@ -109,3 +109,32 @@ func NewExperimentPartialResponse(experiment *models.Experiment) *ExperimentPart
 		Tags:             tags,
 	}
 }
+
+// DatasetSummaryPartialResponse is a partial response object for `POST /mlflow/experiments/search-datasets` endpoint.
+type DatasetSummaryPartialResponse struct {
+	ExperimentID string `json:"experiment_id"`
+	Name         string `json:"name"`
+	Digest       string `json:"digest"`
+	Context      string `json:"context,omitempty"`
+}
+
+// SearchDatasetsResponse is a response object for `POST /mlflow/experiments/search-datasets` endpoint.
+type SearchDatasetsResponse struct {
+	DatasetSummaries []DatasetSummaryPartialResponse `json:"dataset_summaries"`
+}
+
+// NewSearchDatasetsResponse creates new SearchDatasetsResponse object.
+func NewSearchDatasetsResponse(summaries []models.DatasetSummary) *SearchDatasetsResponse {
+	resp := SearchDatasetsResponse{
+		DatasetSummaries: make([]DatasetSummaryPartialResponse, len(summaries)),
+	}
+	for n, summary := range summaries {
+		resp.DatasetSummaries[n] = DatasetSummaryPartialResponse{
+			ExperimentID: fmt.Sprint(summary.ExperimentID),
+			Name:         summary.Name,
+			Digest:       summary.Digest,
+			Context:      summary.Context,
+		}
+	}
+	return &resp
+}
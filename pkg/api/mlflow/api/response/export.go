@@ -0,0 +1,57 @@
+package response
+
+import (
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+)
+
+// ExportPartialResponse is a partial response object for different responses.
+type ExportPartialResponse struct {
+	ID           string `json:"export_id"`
+	Table        string `json:"table"`
+	Format       string `json:"format"`
+	URI          string `json:"uri"`
+	Status       string `json:"status"`
+	Rows         int    `json:"rows"`
+	ErrorMessage string `json:"error_message,omitempty"`
+	CreationTime int64  `json:"creation_time"`
+	FinishTime   int64  `json:"finish_time,omitempty"`
+}
+
+// NewExportPartialResponse creates new ExportPartialResponse object.
+func NewExportPartialResponse(export *models.Export) ExportPartialResponse {
+	return ExportPartialResponse{
+		ID:           export.ID,
+		Table:        export.Table,
+		Format:       export.Format,
+		URI:          export.URI,
+		Status:       string(export.Status),
+		Rows:         export.Rows,
+		ErrorMessage: export.Error,
+		CreationTime: export.CreationTime,
+		FinishTime:   export.FinishTime,
+	}
+}
+
+// CreateExportResponse is a response object for `POST /mlflow/exports/create` endpoint.
+type CreateExportResponse struct {
+	Export ExportPartialResponse `json:"export"`
+}
+
+// NewCreateExportResponse creates new CreateExportResponse object.
+func NewCreateExportResponse(export *models.Export) *CreateExportResponse {
+	return &CreateExportResponse{
+		Export: NewExportPartialResponse(export),
+	}
+}
+
+// GetExportResponse is a response object for `GET /mlflow/exports/get` endpoint.
+type GetExportResponse struct {
+	Export ExportPartialResponse `json:"export"`
+}
+
+// NewGetExportResponse creates new GetExportResponse object.
+func NewGetExportResponse(export *models.Export) *GetExportResponse {
+	return &GetExportResponse{
+		Export: NewExportPartialResponse(export),
+	}
+}
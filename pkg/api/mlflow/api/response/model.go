@@ -0,0 +1,261 @@
+package response
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+)
+
+// RegisteredModelTagPartialResponse is a partial response object for different responses.
+type RegisteredModelTagPartialResponse struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// RegisteredModelAliasPartialResponse is a partial response object for different responses.
+type RegisteredModelAliasPartialResponse struct {
+	Alias   string `json:"alias"`
+	Version string `json:"version"`
+}
+
+// ModelVersionTagPartialResponse is a partial response object for different responses.
+type ModelVersionTagPartialResponse struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// RegisteredModelPartialResponse is a partial response object for different responses.
+type RegisteredModelPartialResponse struct {
+	Name                 string                                `json:"name"`
+	CreationTimestamp    int64                                 `json:"creation_timestamp"`
+	LastUpdatedTimestamp int64                                 `json:"last_updated_timestamp"`
+	UserID               string                                `json:"user_id,omitempty"`
+	Description          string                                `json:"description,omitempty"`
+	LatestVersions       []*ModelVersionPartialResponse        `json:"latest_versions,omitempty"`
+	Tags                 []RegisteredModelTagPartialResponse   `json:"tags,omitempty"`
+	Aliases              []RegisteredModelAliasPartialResponse `json:"aliases,omitempty"`
+}
+
+// ModelVersionPartialResponse is a partial response object for different responses.
+type ModelVersionPartialResponse struct {
+	Name                 string                           `json:"name"`
+	Version              string                           `json:"version"`
+	CreationTimestamp    int64                            `json:"creation_timestamp"`
+	LastUpdatedTimestamp int64                            `json:"last_updated_timestamp"`
+	UserID               string                           `json:"user_id,omitempty"`
+	CurrentStage         string                           `json:"current_stage"`
+	Description          string                           `json:"description,omitempty"`
+	Source               string                           `json:"source,omitempty"`
+	RunID                string                           `json:"run_id,omitempty"`
+	Status               string                           `json:"status"`
+	StatusMessage        string                           `json:"status_message,omitempty"`
+	Tags                 []ModelVersionTagPartialResponse `json:"tags,omitempty"`
+	RunLink              string                           `json:"run_link,omitempty"`
+	Aliases              []string                         `json:"aliases,omitempty"`
+}
+
+// RegisteredModelResponse is a response object for `registered-models` endpoints,
+// which return single registered model.
+type RegisteredModelResponse struct {
+	RegisteredModel *RegisteredModelPartialResponse `json:"registered_model"`
+}
+
+// NewRegisteredModelResponse creates new RegisteredModelResponse object.
+func NewRegisteredModelResponse(registeredModel *models.RegisteredModel) *RegisteredModelResponse {
+	return &RegisteredModelResponse{
+		RegisteredModel: NewRegisteredModelPartialResponse(registeredModel),
+	}
+}
+
+// SearchRegisteredModelsResponse is a response object for `GET /mlflow/registered-models/search` endpoint.
+type SearchRegisteredModelsResponse struct {
+	RegisteredModels []*RegisteredModelPartialResponse `json:"registered_models"`
+	NextPageToken    string                            `json:"next_page_token,omitempty"`
+}
+
+// NewSearchRegisteredModelsResponse creates new SearchRegisteredModelsResponse object.
+func NewSearchRegisteredModelsResponse(
+	registeredModels []models.RegisteredModel, limit, offset int,
+) (*SearchRegisteredModelsResponse, error) {
+	token, err := encodeNextPageToken(len(registeredModels) > limit, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if len(registeredModels) > limit {
+		registeredModels = registeredModels[:limit]
+	}
+
+	resp := SearchRegisteredModelsResponse{
+		RegisteredModels: make([]*RegisteredModelPartialResponse, 0, len(registeredModels)),
+		NextPageToken:    token,
+	}
+	for _, registeredModel := range registeredModels {
+		//nolint:gosec
+		resp.RegisteredModels = append(resp.RegisteredModels, NewRegisteredModelPartialResponse(&registeredModel))
+	}
+	return &resp, nil
+}
+
+// GetLatestVersionsResponse is a response object for `POST /mlflow/registered-models/get-latest-versions` endpoint.
+type GetLatestVersionsResponse struct {
+	ModelVersions []*ModelVersionPartialResponse `json:"model_versions"`
+}
+
+// NewGetLatestVersionsResponse creates new GetLatestVersionsResponse object.
+func NewGetLatestVersionsResponse(
+	registeredModel *models.RegisteredModel, stages ...models.ModelVersionStage,
+) *GetLatestVersionsResponse {
+	return &GetLatestVersionsResponse{
+		ModelVersions: newLatestVersionsPartialResponse(registeredModel, stages...),
+	}
+}
+
+// ModelVersionResponse is a response object for `model-versions` endpoints, which return single model version.
+type ModelVersionResponse struct {
+	ModelVersion *ModelVersionPartialResponse `json:"model_version"`
+}
+
+// NewModelVersionResponse creates new ModelVersionResponse object.
+func NewModelVersionResponse(modelVersion *models.ModelVersion) *ModelVersionResponse {
+	return &ModelVersionResponse{
+		ModelVersion: NewModelVersionPartialResponse(modelVersion),
+	}
+}
+
+// SearchModelVersionsResponse is a response object for `GET /mlflow/model-versions/search` endpoint.
+type SearchModelVersionsResponse struct {
+	ModelVersions []*ModelVersionPartialResponse `json:"model_versions"`
+	NextPageToken string                         `json:"next_page_token,omitempty"`
+}
+
+// NewSearchModelVersionsResponse creates new SearchModelVersionsResponse object.
+func NewSearchModelVersionsResponse(
+	modelVersions []models.ModelVersion, limit, offset int,
+) (*SearchModelVersionsResponse, error) {
+	token, err := encodeNextPageToken(len(modelVersions) > limit, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if len(modelVersions) > limit {
+		modelVersions = modelVersions[:limit]
+	}
+
+	resp := SearchModelVersionsResponse{
+		ModelVersions: make([]*ModelVersionPartialResponse, 0, len(modelVersions)),
+		NextPageToken: token,
+	}
+	for _, modelVersion := range modelVersions {
+		//nolint:gosec
+		resp.ModelVersions = append(resp.ModelVersions, NewModelVersionPartialResponse(&modelVersion))
+	}
+	return &resp, nil
+}
+
+// GetModelVersionDownloadURIResponse is a response object for
+// `GET /mlflow/model-versions/get-download-uri` endpoint.
+type GetModelVersionDownloadURIResponse struct {
+	ArtifactURI string `json:"artifact_uri"`
+}
+
+// NewGetModelVersionDownloadURIResponse creates new GetModelVersionDownloadURIResponse object.
+func NewGetModelVersionDownloadURIResponse(modelVersion *models.ModelVersion) *GetModelVersionDownloadURIResponse {
+	return &GetModelVersionDownloadURIResponse{
+		ArtifactURI: modelVersion.Source,
+	}
+}
+
+// NewRegisteredModelPartialResponse is a helper function for different `registered-models` responses.
+func NewRegisteredModelPartialResponse(registeredModel *models.RegisteredModel) *RegisteredModelPartialResponse {
+	tags := make([]RegisteredModelTagPartialResponse, len(registeredModel.Tags))
+	for n, t := range registeredModel.Tags {
+		tags[n] = RegisteredModelTagPartialResponse{
+			Key:   t.Key,
+			Value: t.Value,
+		}
+	}
+
+	aliases := make([]RegisteredModelAliasPartialResponse, len(registeredModel.Aliases))
+	for n, a := range registeredModel.Aliases {
+		aliases[n] = RegisteredModelAliasPartialResponse{
+			Alias:   a.Alias,
+			Version: fmt.Sprint(a.Version),
+		}
+	}
+
+	return &RegisteredModelPartialResponse{
+		Name:                 registeredModel.Name,
+		CreationTimestamp:    registeredModel.CreatedAt.UnixMilli(),
+		LastUpdatedTimestamp: registeredModel.UpdatedAt.UnixMilli(),
+		UserID:               registeredModel.UserID,
+		Description:          registeredModel.Description,
+		LatestVersions:       newLatestVersionsPartialResponse(registeredModel),
+		Tags:                 tags,
+		Aliases:              aliases,
+	}
+}
+
+// NewModelVersionPartialResponse is a helper function for different `model-versions` responses.
+// Name and aliases are taken from the preloaded models.RegisteredModel entity.
+func NewModelVersionPartialResponse(modelVersion *models.ModelVersion) *ModelVersionPartialResponse {
+	tags := make([]ModelVersionTagPartialResponse, len(modelVersion.Tags))
+	for n, t := range modelVersion.Tags {
+		tags[n] = ModelVersionTagPartialResponse{
+			Key:   t.Key,
+			Value: t.Value,
+		}
+	}
+
+	return &ModelVersionPartialResponse{
+		Name:                 modelVersion.RegisteredModel.Name,
+		Version:              fmt.Sprint(modelVersion.Version),
+		CreationTimestamp:    modelVersion.CreatedAt.UnixMilli(),
+		LastUpdatedTimestamp: modelVersion.UpdatedAt.UnixMilli(),
+		UserID:               modelVersion.UserID,
+		CurrentStage:         string(modelVersion.CurrentStage),
+		Description:          modelVersion.Description,
+		Source:               modelVersion.Source,
+		RunID:                modelVersion.RunID,
+		Status:               string(modelVersion.Status),
+		StatusMessage:        modelVersion.StatusMessage,
+		Tags:                 tags,
+		RunLink:              modelVersion.RunLink,
+		Aliases:              modelVersion.RegisteredModel.GetAliasesForVersion(modelVersion.Version),
+	}
+}
+
+// newLatestVersionsPartialResponse converts the latest versions of the registered model.
+func newLatestVersionsPartialResponse(
+	registeredModel *models.RegisteredModel, stages ...models.ModelVersionStage,
+) []*ModelVersionPartialResponse {
+	latestVersions := registeredModel.LatestVersions(stages...)
+	resp := make([]*ModelVersionPartialResponse, len(latestVersions))
+	for n, version := range latestVersions {
+		version.RegisteredModel = models.RegisteredModel{
+			Name:    registeredModel.Name,
+			Aliases: registeredModel.Aliases,
+		}
+		resp[n] = NewModelVersionPartialResponse(&version)
+	}
+	return resp
+}
+
+// encodeNextPageToken encodes `nextPageToken` value when there are more results.
+func encodeNextPageToken(hasMore bool, limit, offset int) (string, error) {
+	var token strings.Builder
+	if hasMore {
+		if err := json.NewEncoder(
+			base64.NewEncoder(base64.StdEncoding, &token),
+		).Encode(request.PageToken{
+			Offset: int32(offset + limit),
+		}); err != nil {
+			return "", eris.Wrap(err, "error encoding 'nextPageToken' value")
+		}
+	}
+	return token.String(), nil
+}
@@ -54,10 +54,38 @@ type RunInfoPartialResponse struct {
 	LifecycleStage string `json:"lifecycle_stage"`
 }
 
+// InputTagPartialResponse is a partial response object for different responses.
+type InputTagPartialResponse struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// DatasetPartialResponse is a partial response object for different responses.
+type DatasetPartialResponse struct {
+	Name       string `json:"name"`
+	Digest     string `json:"digest"`
+	SourceType string `json:"source_type"`
+	Source     string `json:"source"`
+	Schema     string `json:"schema,omitempty"`
+	Profile    string `json:"profile,omitempty"`
+}
+
+// DatasetInputPartialResponse is a partial response object for different responses.
+type DatasetInputPartialResponse struct {
+	Tags    []InputTagPartialResponse `json:"tags,omitempty"`
+	Dataset DatasetPartialResponse    `json:"dataset"`
+}
+
+// RunInputsPartialResponse is a partial response object for different responses.
+type RunInputsPartialResponse struct {
+	DatasetInputs []DatasetInputPartialResponse `json:"dataset_inputs,omitempty"`
+}
+
 // RunPartialResponse is a partial response object for different responses.
 type RunPartialResponse struct {
-	Info RunInfoPartialResponse `json:"info"`
-	Data RunDataPartialResponse `json:"data"`
+	Info   RunInfoPartialResponse   `json:"info"`
+	Data   RunDataPartialResponse   `json:"data"`
+	Inputs RunInputsPartialResponse `json:"inputs"`
 }
 
 // CreateRunResponse is a response object for `POST mlflow/runs/create` endpoint.
@@ -137,7 +165,7 @@ type SearchRunsResponse struct {
 }
 
 // NewSearchRunsResponse creates a new SearchRunsResponse object.
-func NewSearchRunsResponse(runs []models.Run, limit, offset int) (*SearchRunsResponse, error) {
+func NewSearchRunsResponse(runs []models.Run, nextPageToken *request.PageToken) (*SearchRunsResponse, error) {
 	resp := SearchRunsResponse{
 		Runs: make([]*RunPartialResponse, len(runs)),
 	}
@@ -149,13 +177,14 @@ func NewSearchRunsResponse(runs []models.Run, limit, offset int) (*SearchRunsRes
 	}
 
 	// encode `nextPageToken` value.
-	if len(runs) == limit {
+	if nextPageToken != nil {
 		var token strings.Builder
-		if err := json.NewEncoder(
-			base64.NewEncoder(base64.StdEncoding, &token),
-		).Encode(request.PageToken{
-			Offset: int32(offset + limit),
-		}); err != nil {
+		encoder := base64.NewEncoder(base64.StdEncoding, &token)
+		if err := json.NewEncoder(encoder).Encode(nextPageToken); err != nil {
+			return nil, eris.Wrap(err, "error encoding 'nextPageToken' value")
+		}
+		// the last partial block of the encoded value is written on close.
+		if err := encoder.Close(); err != nil {
 			return nil, eris.Wrap(err, "error encoding 'nextPageToken' value")
 		}
 		resp.NextPageToken = token.String()
@@ -220,5 +249,31 @@ func NewRunPartialResponse(run *models.Run) *RunPartialResponse {
 			Params:  params,
 			Tags:    tags,
 		},
+		Inputs: NewRunInputsPartialResponse(run.Inputs),
+	}
+}
+
+// NewRunInputsPartialResponse creates a new RunInputsPartialResponse object from the dataset inputs of the run.
+func NewRunInputsPartialResponse(inputs []models.Input) RunInputsPartialResponse {
+	var resp RunInputsPartialResponse
+	for _, input := range inputs {
+		datasetInput := DatasetInputPartialResponse{
+			Dataset: DatasetPartialResponse{
+				Name:       input.Dataset.Name,
+				Digest:     input.Dataset.Digest,
+				SourceType: input.Dataset.SourceType,
+				Source:     input.Dataset.Source,
+				Schema:     input.Dataset.Schema,
+				Profile:    input.Dataset.Profile,
+			},
+		}
+		for _, tag := range input.Tags {
+			datasetInput.Tags = append(datasetInput.Tags, InputTagPartialResponse{
+				Key:   tag.Key,
+				Value: tag.Value,
+			})
+		}
+		resp.DatasetInputs = append(resp.DatasetInputs, datasetInput)
 	}
+	return resp
 }
@@ -245,3 +245,45 @@ func TestNewRunPartialResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestNewRunInputsPartialResponse(t *testing.T) {
+	actualResponse := NewRunInputsPartialResponse([]models.Input{
+		{
+			Tags: []models.InputTag{
+				{
+					Key:   "mlflow.data.context",
+					Value: "training",
+				},
+			},
+			Dataset: models.Dataset{
+				Name:       "Name",
+				Digest:     "Digest",
+				SourceType: "SourceType",
+				Source:     "Source",
+				Schema:     "Schema",
+				Profile:    "Profile",
+			},
+		},
+	})
+	assert.Equal(t, RunInputsPartialResponse{
+		DatasetInputs: []DatasetInputPartialResponse{
+			{
+				Tags: []InputTagPartialResponse{
+					{
+						Key:   "mlflow.data.context",
+						Value: "training",
+					},
+				},
+				Dataset: DatasetPartialResponse{
+					Name:       "Name",
+					Digest:     "Digest",
+					SourceType: "SourceType",
+					Source:     "Source",
+					Schema:     "Schema",
+					Profile:    "Profile",
+				},
+			},
+		},
+	}, actualResponse)
+	assert.Equal(t, RunInputsPartialResponse{}, NewRunInputsPartialResponse(nil))
+}
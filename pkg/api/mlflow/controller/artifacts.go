@@ -2,8 +2,10 @@ package controller
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"io"
+	"net/url"
 	"path/filepath"
 	"time"
 
@@ -61,7 +63,197 @@ func (c Controller) GetArtifact(ctx *fiber.Ctx) error {
 		return err
 	}
 
-	filename := filepath.Base(req.Path)
+	streamArtifact(ctx, req.Path, artifact)
+	return nil
+}
+
+// DownloadProxiedArtifact handles `GET /mlflow-artifacts/artifacts/*` endpoint.
+func (c Controller) DownloadProxiedArtifact(ctx *fiber.Ctx) error {
+	path, err := getArtifactPathFromParams(ctx)
+	if err != nil {
+		return err
+	}
+	req := request.DownloadArtifactRequest{Path: path}
+	log.Debugf("downloadProxiedArtifact request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("downloadProxiedArtifact namespace: %s", ns.Code)
+
+	artifact, err := c.artifactService.DownloadArtifact(ctx.Context(), ns, &req)
+	if err != nil {
+		return err
+	}
+
+	streamArtifact(ctx, req.Path, artifact)
+	return nil
+}
+
+// UploadProxiedArtifact handles `PUT /mlflow-artifacts/artifacts/*` endpoint.
+func (c Controller) UploadProxiedArtifact(ctx *fiber.Ctx) error {
+	path, err := getArtifactPathFromParams(ctx)
+	if err != nil {
+		return err
+	}
+	req := request.UploadArtifactRequest{Path: path}
+	log.Debugf("uploadProxiedArtifact request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("uploadProxiedArtifact namespace: %s", ns.Code)
+
+	if err := c.artifactService.UploadArtifact(
+		ctx.Context(), ns, &req, bytes.NewReader(ctx.Body()),
+	); err != nil {
+		return err
+	}
+
+	return ctx.JSON(fiber.Map{})
+}
+
+// DeleteProxiedArtifact handles `DELETE /mlflow-artifacts/artifacts/*` endpoint.
+func (c Controller) DeleteProxiedArtifact(ctx *fiber.Ctx) error {
+	path, err := getArtifactPathFromParams(ctx)
+	if err != nil {
+		return err
+	}
+	req := request.DeleteArtifactRequest{Path: path}
+	log.Debugf("deleteProxiedArtifact request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("deleteProxiedArtifact namespace: %s", ns.Code)
+
+	if err := c.artifactService.DeleteArtifact(ctx.Context(), ns, &req); err != nil {
+		return err
+	}
+
+	return ctx.JSON(fiber.Map{})
+}
+
+// ListProxiedArtifacts handles `GET /mlflow-artifacts/artifacts` endpoint.
+func (c Controller) ListProxiedArtifacts(ctx *fiber.Ctx) error {
+	req := request.ListProxiedArtifactsRequest{}
+	if err := ctx.QueryParser(&req); err != nil {
+		return api.NewBadRequestError(err.Error())
+	}
+	log.Debugf("listProxiedArtifacts request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("listProxiedArtifacts namespace: %s", ns.Code)
+
+	artifacts, err := c.artifactService.ListProxiedArtifacts(ctx.Context(), ns, &req)
+	if err != nil {
+		return err
+	}
+
+	resp := response.NewListProxiedArtifactsResponse(artifacts)
+	log.Debugf("listProxiedArtifacts response: %#v", resp)
+	return ctx.JSON(resp)
+}
+
+// CreateMultipartUpload handles `POST /mlflow-artifacts/mpu/create/*` endpoint.
+func (c Controller) CreateMultipartUpload(ctx *fiber.Ctx) error {
+	req := request.CreateMultipartUploadRequest{}
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	path, err := getArtifactPathFromParams(ctx)
+	if err != nil {
+		return err
+	}
+	req.ArtifactPath = path
+	log.Debugf("createMultipartUpload request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("createMultipartUpload namespace: %s", ns.Code)
+
+	upload, err := c.artifactService.CreateMultipartUpload(ctx.Context(), ns, &req)
+	if err != nil {
+		return err
+	}
+
+	resp := response.NewCreateMultipartUploadResponse(upload)
+	log.Debugf("createMultipartUpload response: %#v", resp)
+	return ctx.JSON(resp)
+}
+
+// CompleteMultipartUpload handles `POST /mlflow-artifacts/mpu/complete/*` endpoint.
+func (c Controller) CompleteMultipartUpload(ctx *fiber.Ctx) error {
+	req := request.CompleteMultipartUploadRequest{}
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	path, err := getArtifactPathFromParams(ctx)
+	if err != nil {
+		return err
+	}
+	req.ArtifactPath = path
+	log.Debugf("completeMultipartUpload request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("completeMultipartUpload namespace: %s", ns.Code)
+
+	if err := c.artifactService.CompleteMultipartUpload(ctx.Context(), ns, &req); err != nil {
+		return err
+	}
+
+	return ctx.JSON(fiber.Map{})
+}
+
+// AbortMultipartUpload handles `POST /mlflow-artifacts/mpu/abort/*` endpoint.
+func (c Controller) AbortMultipartUpload(ctx *fiber.Ctx) error {
+	req := request.AbortMultipartUploadRequest{}
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	path, err := getArtifactPathFromParams(ctx)
+	if err != nil {
+		return err
+	}
+	req.ArtifactPath = path
+	log.Debugf("abortMultipartUpload request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("abortMultipartUpload namespace: %s", ns.Code)
+
+	if err := c.artifactService.AbortMultipartUpload(ctx.Context(), ns, &req); err != nil {
+		return err
+	}
+
+	return ctx.JSON(fiber.Map{})
+}
+
+// getArtifactPathFromParams returns unescaped artifact path from the wildcard route parameter.
+func getArtifactPathFromParams(ctx *fiber.Ctx) (string, error) {
+	path, err := url.PathUnescape(ctx.Params("*"))
+	if err != nil {
+		return "", api.NewBadRequestError("Unable to decode artifact path: %s", err)
+	}
+	return path, nil
+}
+
+// streamArtifact streams artifact content into the response body.
+func streamArtifact(ctx *fiber.Ctx, path string, artifact io.ReadCloser) {
+	filename := filepath.Base(path)
 	ctx.Set("Content-Type", common.GetContentType(filename))
 	ctx.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
 	ctx.Set("X-Content-Type-Options", "nosniff")
@@ -90,5 +282,4 @@ func (c Controller) GetArtifact(ctx *fiber.Ctx) error {
 		}
 		log.Infof("body - %s %s %s", time.Since(start), ctx.Method(), ctx.Path())
 	})
-	return nil
 }
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/experiment"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/export"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/metric"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/model"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/run"
@@ -15,6 +16,7 @@ type Controller struct {
 	metricService     *metric.Service
 	artifactService   *artifact.Service
 	experimentService *experiment.Service
+	exportService     *export.Service
 }
 
 // NewController creates new Controller instance.
@@ -24,6 +26,7 @@ func NewController(
 	metricService *metric.Service,
 	artifactService *artifact.Service,
 	experimentService *experiment.Service,
+	exportService *export.Service,
 ) *Controller {
 	return &Controller{
 		runService:        runService,
@@ -31,5 +34,6 @@ func NewController(
 		metricService:     metricService,
 		artifactService:   artifactService,
 		experimentService: experimentService,
+		exportService:     exportService,
 	}
 }
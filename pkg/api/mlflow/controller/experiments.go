@@ -179,15 +179,37 @@ func (c Controller) SearchExperiments(ctx *fiber.Ctx) error {
 		return api.NewInternalError("error getting namespace from context")
 	}
 	log.Debugf("searchExperiments namespace: %s", ns.Code)
-	experiments, limit, offset, err := c.experimentService.SearchExperiments(ctx.Context(), ns, &req)
+	experiments, nextPageToken, err := c.experimentService.SearchExperiments(ctx.Context(), ns, &req)
 	if err != nil {
 		return err
 	}
 
-	resp, err := response.NewSearchExperimentsResponse(experiments, limit, offset)
+	resp, err := response.NewSearchExperimentsResponse(experiments, nextPageToken)
 	if err != nil {
 		return api.NewInternalError("unable to build next_page_token: %s", err)
 	}
 	log.Debugf("searchExperiments response: %#v", resp)
 	return ctx.JSON(resp)
 }
+
+// SearchDatasets handles `POST /experiments/search-datasets` endpoint.
+func (c Controller) SearchDatasets(ctx *fiber.Ctx) error {
+	var req request.SearchDatasetsRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("searchDatasets request: %#v", req)
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("searchDatasets namespace: %s", ns.Code)
+	summaries, err := c.experimentService.SearchDatasets(ctx.Context(), ns, &req)
+	if err != nil {
+		return err
+	}
+
+	resp := response.NewSearchDatasetsResponse(summaries)
+	log.Debugf("searchDatasets response: %#v", resp)
+	return ctx.JSON(resp)
+}
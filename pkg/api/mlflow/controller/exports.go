@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/middleware"
+)
+
+// CreateExport handles `POST /exports/create` endpoint.
+func (c Controller) CreateExport(ctx *fiber.Ctx) error {
+	var req request.CreateExportRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("createExport request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("createExport namespace: %s", ns.Code)
+
+	export, err := c.exportService.CreateExport(ctx.Context(), ns, &req)
+	if err != nil {
+		return err
+	}
+
+	resp := response.NewCreateExportResponse(export)
+	log.Debugf("createExport response: %#v", resp)
+
+	return ctx.JSON(resp)
+}
+
+// GetExport handles `GET /exports/get` endpoint.
+func (c Controller) GetExport(ctx *fiber.Ctx) error {
+	req := request.GetExportRequest{}
+	if err := ctx.QueryParser(&req); err != nil {
+		return api.NewBadRequestError(err.Error())
+	}
+	log.Debugf("getExport request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("getExport namespace: %s", ns.Code)
+
+	export, err := c.exportService.GetExport(ctx.Context(), ns, &req)
+	if err != nil {
+		return err
+	}
+
+	resp := response.NewGetExportResponse(export)
+	log.Debugf("getExport response: %#v", resp)
+
+	return ctx.JSON(resp)
+}
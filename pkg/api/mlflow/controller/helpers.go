@@ -1,8 +1,18 @@
 package controller
 
 import (
+	"bufio"
+	"fmt"
+	"io"
+	"time"
+
 	"github.com/apache/arrow/go/v14/arrow"
 	"github.com/apache/arrow/go/v14/arrow/ipc"
+	"github.com/gofiber/fiber/v2"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/export"
 )
 
 // WriteStreamingRecord writes record into stream.
@@ -10,3 +20,21 @@ func WriteStreamingRecord(w *ipc.Writer, r arrow.Record) error {
 	defer r.Release()
 	return w.Write(r)
 }
+
+// streamExport streams the data written by provided function in provided format. CSV and Parquet data
+// is sent as the attachment named after provided name.
+func streamExport(ctx *fiber.Ctx, format request.ExportFormat, name string, write func(w io.Writer) (int, error)) {
+	ctx.Set("Content-Type", export.ContentType(format))
+	if format == request.ExportFormatCSV || format == request.ExportFormatParquet {
+		ctx.Set("Content-Disposition", fmt.Sprintf(
+			`attachment; filename="%s-%d%s"`, name, time.Now().Unix(), export.FileExtension(format),
+		))
+	}
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		start := time.Now()
+		if _, err := write(w); err != nil {
+			log.Errorf("error encountered in %s %s: error streaming records: %s", ctx.Method(), ctx.Path(), err)
+		}
+		log.Infof("body - %s %s %s", time.Since(start), ctx.Method(), ctx.Path())
+	})
+}
@@ -1,21 +1,470 @@
 package controller
 
-import "github.com/gofiber/fiber/v2"
+import (
+	"github.com/gofiber/fiber/v2"
+	log "github.com/sirupsen/logrus"
 
-// SearchModelVersions handles `GET /model-versions/search` endpoint.
-func (c Controller) SearchModelVersions(ctx *fiber.Ctx) error {
-	models, err := c.modelService.SearchModelVersions(ctx.Context())
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/middleware"
+)
+
+// CreateRegisteredModel handles `POST /registered-models/create` endpoint.
+func (c Controller) CreateRegisteredModel(ctx *fiber.Ctx) error {
+	var req request.CreateRegisteredModelRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("createRegisteredModel request: %#v", req)
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("createRegisteredModel namespace: %s", ns.Code)
+	registeredModel, err := c.modelService.CreateRegisteredModel(ctx.Context(), ns, &req)
+	if err != nil {
+		return err
+	}
+
+	resp := response.NewRegisteredModelResponse(registeredModel)
+	log.Debugf("createRegisteredModel response: %#v", resp)
+	return ctx.JSON(resp)
+}
+
+// GetRegisteredModel handles `GET /registered-models/get` endpoint.
+func (c Controller) GetRegisteredModel(ctx *fiber.Ctx) error {
+	var req request.GetRegisteredModelRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		return api.NewBadRequestError(err.Error())
+	}
+	log.Debugf("getRegisteredModel request: %#v", req)
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("getRegisteredModel namespace: %s", ns.Code)
+	registeredModel, err := c.modelService.GetRegisteredModel(ctx.Context(), ns, &req)
+	if err != nil {
+		return err
+	}
+
+	resp := response.NewRegisteredModelResponse(registeredModel)
+	log.Debugf("getRegisteredModel response: %#v", resp)
+	return ctx.JSON(resp)
+}
+
+// RenameRegisteredModel handles `POST /registered-models/rename` endpoint.
+func (c Controller) RenameRegisteredModel(ctx *fiber.Ctx) error {
+	var req request.RenameRegisteredModelRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("renameRegisteredModel request: %#v", req)
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("renameRegisteredModel namespace: %s", ns.Code)
+	registeredModel, err := c.modelService.RenameRegisteredModel(ctx.Context(), ns, &req)
 	if err != nil {
 		return err
 	}
-	return ctx.JSON(models)
+
+	resp := response.NewRegisteredModelResponse(registeredModel)
+	log.Debugf("renameRegisteredModel response: %#v", resp)
+	return ctx.JSON(resp)
+}
+
+// UpdateRegisteredModel handles `PATCH /registered-models/update` endpoint.
+func (c Controller) UpdateRegisteredModel(ctx *fiber.Ctx) error {
+	var req request.UpdateRegisteredModelRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("updateRegisteredModel request: %#v", req)
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("updateRegisteredModel namespace: %s", ns.Code)
+	registeredModel, err := c.modelService.UpdateRegisteredModel(ctx.Context(), ns, &req)
+	if err != nil {
+		return err
+	}
+
+	resp := response.NewRegisteredModelResponse(registeredModel)
+	log.Debugf("updateRegisteredModel response: %#v", resp)
+	return ctx.JSON(resp)
+}
+
+// DeleteRegisteredModel handles `DELETE /registered-models/delete` endpoint.
+func (c Controller) DeleteRegisteredModel(ctx *fiber.Ctx) error {
+	var req request.DeleteRegisteredModelRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("deleteRegisteredModel request: %#v", req)
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("deleteRegisteredModel namespace: %s", ns.Code)
+	if err := c.modelService.DeleteRegisteredModel(ctx.Context(), ns, &req); err != nil {
+		return err
+	}
+
+	return ctx.JSON(fiber.Map{})
 }
 
 // SearchRegisteredModels handles `GET /registered-models/search` endpoint.
 func (c Controller) SearchRegisteredModels(ctx *fiber.Ctx) error {
-	models, err := c.modelService.SearchRegisteredModels(ctx.Context())
+	var req request.SearchRegisteredModelsRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		return api.NewBadRequestError(err.Error())
+	}
+	log.Debugf("searchRegisteredModels request: %#v", req)
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("searchRegisteredModels namespace: %s", ns.Code)
+	registeredModels, limit, offset, err := c.modelService.SearchRegisteredModels(ctx.Context(), ns, &req)
 	if err != nil {
 		return err
 	}
-	return ctx.JSON(models)
+
+	resp, err := response.NewSearchRegisteredModelsResponse(registeredModels, limit, offset)
+	if err != nil {
+		return api.NewInternalError("unable to build next_page_token: %s", err)
+	}
+	log.Debugf("searchRegisteredModels response: %#v", resp)
+	return ctx.JSON(resp)
+}
+
+// GetLatestVersions handles `POST /registered-models/get-latest-versions` endpoint.
+func (c Controller) GetLatestVersions(ctx *fiber.Ctx) error {
+	var req request.GetLatestVersionsRequest
+	if err := parseBodyOrQuery(ctx, &req); err != nil {
+		return err
+	}
+	log.Debugf("getLatestVersions request: %#v", req)
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("getLatestVersions namespace: %s", ns.Code)
+	registeredModel, stages, err := c.modelService.GetLatestVersions(ctx.Context(), ns, &req)
+	if err != nil {
+		return err
+	}
+
+	resp := response.NewGetLatestVersionsResponse(registeredModel, stages...)
+	log.Debugf("getLatestVersions response: %#v", resp)
+	return ctx.JSON(resp)
+}
+
+// SetRegisteredModelTag handles `POST /registered-models/set-tag` endpoint.
+func (c Controller) SetRegisteredModelTag(ctx *fiber.Ctx) error {
+	var req request.SetRegisteredModelTagRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("setRegisteredModelTag request: %#v", req)
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("setRegisteredModelTag namespace: %s", ns.Code)
+	if err := c.modelService.SetRegisteredModelTag(ctx.Context(), ns, &req); err != nil {
+		return err
+	}
+
+	return ctx.JSON(fiber.Map{})
+}
+
+// DeleteRegisteredModelTag handles `DELETE /registered-models/delete-tag` endpoint.
+func (c Controller) DeleteRegisteredModelTag(ctx *fiber.Ctx) error {
+	var req request.DeleteRegisteredModelTagRequest
+	if err := parseBodyOrQuery(ctx, &req); err != nil {
+		return err
+	}
+	log.Debugf("deleteRegisteredModelTag request: %#v", req)
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("deleteRegisteredModelTag namespace: %s", ns.Code)
+	if err := c.modelService.DeleteRegisteredModelTag(ctx.Context(), ns, &req); err != nil {
+		return err
+	}
+
+	return ctx.JSON(fiber.Map{})
+}
+
+// SetRegisteredModelAlias handles `POST /registered-models/alias` endpoint.
+func (c Controller) SetRegisteredModelAlias(ctx *fiber.Ctx) error {
+	var req request.SetRegisteredModelAliasRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("setRegisteredModelAlias request: %#v", req)
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("setRegisteredModelAlias namespace: %s", ns.Code)
+	if err := c.modelService.SetRegisteredModelAlias(ctx.Context(), ns, &req); err != nil {
+		return err
+	}
+
+	return ctx.JSON(fiber.Map{})
+}
+
+// DeleteRegisteredModelAlias handles `DELETE /registered-models/alias` endpoint.
+func (c Controller) DeleteRegisteredModelAlias(ctx *fiber.Ctx) error {
+	var req request.DeleteRegisteredModelAliasRequest
+	if err := parseBodyOrQuery(ctx, &req); err != nil {
+		return err
+	}
+	log.Debugf("deleteRegisteredModelAlias request: %#v", req)
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("deleteRegisteredModelAlias namespace: %s", ns.Code)
+	if err := c.modelService.DeleteRegisteredModelAlias(ctx.Context(), ns, &req); err != nil {
+		return err
+	}
+
+	return ctx.JSON(fiber.Map{})
+}
+
+// GetModelVersionByAlias handles `GET /registered-models/alias` endpoint.
+func (c Controller) GetModelVersionByAlias(ctx *fiber.Ctx) error {
+	var req request.GetModelVersionByAliasRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		return api.NewBadRequestError(err.Error())
+	}
+	log.Debugf("getModelVersionByAlias request: %#v", req)
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("getModelVersionByAlias namespace: %s", ns.Code)
+	modelVersion, err := c.modelService.GetModelVersionByAlias(ctx.Context(), ns, &req)
+	if err != nil {
+		return err
+	}
+
+	resp := response.NewModelVersionResponse(modelVersion)
+	log.Debugf("getModelVersionByAlias response: %#v", resp)
+	return ctx.JSON(resp)
+}
+
+// CreateModelVersion handles `POST /model-versions/create` endpoint.
+func (c Controller) CreateModelVersion(ctx *fiber.Ctx) error {
+	var req request.CreateModelVersionRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("createModelVersion request: %#v", req)
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("createModelVersion namespace: %s", ns.Code)
+	modelVersion, err := c.modelService.CreateModelVersion(ctx.Context(), ns, &req)
+	if err != nil {
+		return err
+	}
+
+	resp := response.NewModelVersionResponse(modelVersion)
+	log.Debugf("createModelVersion response: %#v", resp)
+	return ctx.JSON(resp)
+}
+
+// GetModelVersion handles `GET /model-versions/get` endpoint.
+func (c Controller) GetModelVersion(ctx *fiber.Ctx) error {
+	var req request.GetModelVersionRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		return api.NewBadRequestError(err.Error())
+	}
+	log.Debugf("getModelVersion request: %#v", req)
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("getModelVersion namespace: %s", ns.Code)
+	modelVersion, err := c.modelService.GetModelVersion(ctx.Context(), ns, &req)
+	if err != nil {
+		return err
+	}
+
+	resp := response.NewModelVersionResponse(modelVersion)
+	log.Debugf("getModelVersion response: %#v", resp)
+	return ctx.JSON(resp)
+}
+
+// UpdateModelVersion handles `PATCH /model-versions/update` endpoint.
+func (c Controller) UpdateModelVersion(ctx *fiber.Ctx) error {
+	var req request.UpdateModelVersionRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("updateModelVersion request: %#v", req)
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("updateModelVersion namespace: %s", ns.Code)
+	modelVersion, err := c.modelService.UpdateModelVersion(ctx.Context(), ns, &req)
+	if err != nil {
+		return err
+	}
+
+	resp := response.NewModelVersionResponse(modelVersion)
+	log.Debugf("updateModelVersion response: %#v", resp)
+	return ctx.JSON(resp)
+}
+
+// DeleteModelVersion handles `DELETE /model-versions/delete` endpoint.
+func (c Controller) DeleteModelVersion(ctx *fiber.Ctx) error {
+	var req request.DeleteModelVersionRequest
+	if err := parseBodyOrQuery(ctx, &req); err != nil {
+		return err
+	}
+	log.Debugf("deleteModelVersion request: %#v", req)
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("deleteModelVersion namespace: %s", ns.Code)
+	if err := c.modelService.DeleteModelVersion(ctx.Context(), ns, &req); err != nil {
+		return err
+	}
+
+	return ctx.JSON(fiber.Map{})
+}
+
+// SearchModelVersions handles `GET /model-versions/search` endpoint.
+func (c Controller) SearchModelVersions(ctx *fiber.Ctx) error {
+	var req request.SearchModelVersionsRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		return api.NewBadRequestError(err.Error())
+	}
+	log.Debugf("searchModelVersions request: %#v", req)
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("searchModelVersions namespace: %s", ns.Code)
+	modelVersions, limit, offset, err := c.modelService.SearchModelVersions(ctx.Context(), ns, &req)
+	if err != nil {
+		return err
+	}
+
+	resp, err := response.NewSearchModelVersionsResponse(modelVersions, limit, offset)
+	if err != nil {
+		return api.NewInternalError("unable to build next_page_token: %s", err)
+	}
+	log.Debugf("searchModelVersions response: %#v", resp)
+	return ctx.JSON(resp)
+}
+
+// GetModelVersionDownloadURI handles `GET /model-versions/get-download-uri` endpoint.
+func (c Controller) GetModelVersionDownloadURI(ctx *fiber.Ctx) error {
+	var req request.GetModelVersionDownloadURIRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		return api.NewBadRequestError(err.Error())
+	}
+	log.Debugf("getModelVersionDownloadURI request: %#v", req)
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("getModelVersionDownloadURI namespace: %s", ns.Code)
+	modelVersion, err := c.modelService.GetModelVersionDownloadURI(ctx.Context(), ns, &req)
+	if err != nil {
+		return err
+	}
+
+	resp := response.NewGetModelVersionDownloadURIResponse(modelVersion)
+	log.Debugf("getModelVersionDownloadURI response: %#v", resp)
+	return ctx.JSON(resp)
+}
+
+// TransitionModelVersionStage handles `POST /model-versions/transition-stage` endpoint.
+func (c Controller) TransitionModelVersionStage(ctx *fiber.Ctx) error {
+	var req request.TransitionModelVersionStageRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("transitionModelVersionStage request: %#v", req)
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("transitionModelVersionStage namespace: %s", ns.Code)
+	modelVersion, err := c.modelService.TransitionModelVersionStage(ctx.Context(), ns, &req)
+	if err != nil {
+		return err
+	}
+
+	resp := response.NewModelVersionResponse(modelVersion)
+	log.Debugf("transitionModelVersionStage response: %#v", resp)
+	return ctx.JSON(resp)
+}
+
+// SetModelVersionTag handles `POST /model-versions/set-tag` endpoint.
+func (c Controller) SetModelVersionTag(ctx *fiber.Ctx) error {
+	var req request.SetModelVersionTagRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("setModelVersionTag request: %#v", req)
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("setModelVersionTag namespace: %s", ns.Code)
+	if err := c.modelService.SetModelVersionTag(ctx.Context(), ns, &req); err != nil {
+		return err
+	}
+
+	return ctx.JSON(fiber.Map{})
+}
+
+// DeleteModelVersionTag handles `DELETE /model-versions/delete-tag` endpoint.
+func (c Controller) DeleteModelVersionTag(ctx *fiber.Ctx) error {
+	var req request.DeleteModelVersionTagRequest
+	if err := parseBodyOrQuery(ctx, &req); err != nil {
+		return err
+	}
+	log.Debugf("deleteModelVersionTag request: %#v", req)
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("deleteModelVersionTag namespace: %s", ns.Code)
+	if err := c.modelService.DeleteModelVersionTag(ctx.Context(), ns, &req); err != nil {
+		return err
+	}
+
+	return ctx.JSON(fiber.Map{})
+}
+
+// parseBodyOrQuery parses request parameters either from the body, or from the query string, because
+// MLflow clients send parameters of `GET` and `DELETE` requests differently.
+func parseBodyOrQuery(ctx *fiber.Ctx, req any) error {
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(req); err != nil {
+			return api.NewBadRequestError("Unable to decode request body: %s", err)
+		}
+		return nil
+	}
+	if err := ctx.QueryParser(req); err != nil {
+		return api.NewBadRequestError(err.Error())
+	}
+	return nil
 }
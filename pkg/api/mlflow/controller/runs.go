@@ -2,6 +2,7 @@ package controller
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 
 	"github.com/gofiber/fiber/v2"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/export"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/pkg/common/middleware"
 )
@@ -100,12 +102,12 @@ func (c Controller) SearchRuns(ctx *fiber.Ctx) error {
 	}
 	log.Debugf("searchRuns namespace: %s", ns.Code)
 
-	runs, limit, offset, err := c.runService.SearchRuns(ctx.Context(), ns, &req)
+	runs, nextPageToken, err := c.runService.SearchRuns(ctx.Context(), ns, &req)
 	if err != nil {
 		return err
 	}
 
-	resp, err := response.NewSearchRunsResponse(runs, limit, offset)
+	resp, err := response.NewSearchRunsResponse(runs, nextPageToken)
 	if err != nil {
 		return api.NewInternalError("Unable to build next_page_token: %s", err)
 	}
@@ -316,6 +318,34 @@ func (c Controller) LogOutput(ctx *fiber.Ctx) error {
 	return ctx.JSON(fiber.Map{})
 }
 
+// LogInputs handles `POST /runs/log-inputs` endpoint.
+func (c Controller) LogInputs(ctx *fiber.Ctx) error {
+	var req request.LogInputsRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		if err, ok := err.(*json.UnmarshalTypeError); ok {
+			return api.NewInvalidParameterValueError(
+				`Invalid value for dataset input field '%s'. Hint: Value was of type '%s'. `+
+					`See the API docs for more information about request parameters.`,
+				err.Field, err.Value,
+			)
+		}
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("LogInputs request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("LogInputs namespace: %s", ns.Code)
+
+	if err := c.runService.LogInputs(ctx.Context(), ns, &req); err != nil {
+		return err
+	}
+
+	return ctx.JSON(fiber.Map{})
+}
+
 // LogArtifact handles `POST /runs/log-artifact` endpoint.
 func (c Controller) LogArtifact(ctx *fiber.Ctx) error {
 	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
@@ -335,3 +365,133 @@ func (c Controller) LogArtifact(ctx *fiber.Ctx) error {
 
 	return ctx.SendStatus(http.StatusCreated)
 }
+
+// LogText handles `POST /runs/log-text` endpoint.
+func (c Controller) LogText(ctx *fiber.Ctx) error {
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("LogText namespace: %s", ns.Code)
+
+	req := request.LogTextRequest{}
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+
+	if err := c.runService.LogText(ctx.Context(), ns.ID, &req); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(http.StatusCreated)
+}
+
+// LogAudio handles `POST /runs/log-audio` endpoint.
+func (c Controller) LogAudio(ctx *fiber.Ctx) error {
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("LogAudio namespace: %s", ns.Code)
+
+	req := request.LogAudioRequest{}
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+
+	if err := c.runService.LogAudio(ctx.Context(), ns.ID, &req); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(http.StatusCreated)
+}
+
+// LogDistribution handles `POST /runs/log-distribution` endpoint.
+func (c Controller) LogDistribution(ctx *fiber.Ctx) error {
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("LogDistribution namespace: %s", ns.Code)
+
+	req := request.LogDistributionRequest{}
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+
+	if err := c.runService.LogDistribution(ctx.Context(), ns.ID, &req); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(http.StatusCreated)
+}
+
+// LogFigure handles `POST /runs/log-figure` endpoint.
+func (c Controller) LogFigure(ctx *fiber.Ctx) error {
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("LogFigure namespace: %s", ns.Code)
+
+	req := request.LogFigureRequest{}
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+
+	if err := c.runService.LogFigure(ctx.Context(), ns.ID, &req); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(http.StatusCreated)
+}
+
+// ExportRuns handles `POST /runs/export` endpoint.
+func (c Controller) ExportRuns(ctx *fiber.Ctx) error {
+	var req request.ExportRunsRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("exportRuns request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("exportRuns namespace: %s", ns.Code)
+
+	exported, err := c.runService.ExportRuns(ctx.Context(), ns, &req)
+	if err != nil {
+		return err
+	}
+
+	streamExport(ctx, req.Format, "runs", func(w io.Writer) (int, error) {
+		return export.WriteRuns(w, req.Format, exported)
+	})
+	return nil
+}
+
+// ExportRunMetrics handles `POST /runs/export-metrics` endpoint.
+func (c Controller) ExportRunMetrics(ctx *fiber.Ctx) error {
+	var req request.ExportRunsRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("exportRunMetrics request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("exportRunMetrics namespace: %s", ns.Code)
+
+	cursor, columns, err := c.runService.ExportRunMetrics(ctx.Context(), ns, &req)
+	if err != nil {
+		return err
+	}
+
+	streamExport(ctx, req.Format, "metrics", func(w io.Writer) (int, error) {
+		return export.WriteMetrics(w, req.Format, cursor, columns)
+	})
+	return nil
+}
@@ -0,0 +1,41 @@
+package convertors
+
+import (
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/database"
+)
+
+// ConvertLogInputsRequestToDBModel converts request.LogInputsRequest into actual []models.Input models.
+func ConvertLogInputsRequestToDBModel(run *models.Run, req *request.LogInputsRequest) []models.Input {
+	inputs := make([]models.Input, len(req.Datasets))
+	for n, datasetInput := range req.Datasets {
+		inputID := database.NewUUID()
+		tags := make([]models.InputTag, len(datasetInput.Tags))
+		for i, tag := range datasetInput.Tags {
+			tags[i] = models.InputTag{
+				InputID: inputID,
+				Key:     tag.Key,
+				Value:   tag.Value,
+			}
+		}
+		inputs[n] = models.Input{
+			ID:              inputID,
+			SourceType:      models.InputSourceTypeDataset,
+			DestinationType: models.InputDestinationTypeRun,
+			DestinationID:   run.ID,
+			Tags:            tags,
+			Dataset: models.Dataset{
+				ID:           database.NewUUID(),
+				ExperimentID: run.ExperimentID,
+				Name:         datasetInput.Dataset.Name,
+				Digest:       datasetInput.Dataset.Digest,
+				SourceType:   datasetInput.Dataset.SourceType,
+				Source:       datasetInput.Dataset.Source,
+				Schema:       datasetInput.Dataset.Schema,
+				Profile:      datasetInput.Dataset.Profile,
+			},
+		}
+	}
+	return inputs
+}
@@ -0,0 +1,55 @@
+package convertors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+)
+
+func TestConvertLogInputsRequestToDBModel_Ok(t *testing.T) {
+	req := request.LogInputsRequest{
+		RunID: "run_id",
+		Datasets: []request.DatasetInputPartialRequest{
+			{
+				Tags: []request.InputTagPartialRequest{
+					{
+						Key:   "mlflow.data.context",
+						Value: "training",
+					},
+				},
+				Dataset: request.DatasetPartialRequest{
+					Name:       "name",
+					Digest:     "digest",
+					SourceType: "local",
+					Source:     "source",
+					Schema:     "schema",
+					Profile:    "profile",
+				},
+			},
+		},
+	}
+	result := ConvertLogInputsRequestToDBModel(&models.Run{ID: "run_id", ExperimentID: 1}, &req)
+	assert.Equal(t, 1, len(result))
+	assert.NotEmpty(t, result[0].ID)
+	assert.Equal(t, models.InputSourceTypeDataset, result[0].SourceType)
+	assert.Equal(t, models.InputDestinationTypeRun, result[0].DestinationType)
+	assert.Equal(t, "run_id", result[0].DestinationID)
+	assert.Equal(t, []models.InputTag{
+		{
+			InputID: result[0].ID,
+			Key:     "mlflow.data.context",
+			Value:   "training",
+		},
+	}, result[0].Tags)
+	assert.NotEmpty(t, result[0].Dataset.ID)
+	assert.Equal(t, int32(1), result[0].Dataset.ExperimentID)
+	assert.Equal(t, "name", result[0].Dataset.Name)
+	assert.Equal(t, "digest", result[0].Dataset.Digest)
+	assert.Equal(t, "local", result[0].Dataset.SourceType)
+	assert.Equal(t, "source", result[0].Dataset.Source)
+	assert.Equal(t, "schema", result[0].Dataset.Schema)
+	assert.Equal(t, "profile", result[0].Dataset.Profile)
+}
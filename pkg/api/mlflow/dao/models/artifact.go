@@ -6,9 +6,33 @@ import (
 	"github.com/google/uuid"
 	"github.com/rotisserie/eris"
 	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+)
+
+// Supported artifact types, named after the Aim sequence types.
+const (
+	ArtifactTypeImages        = "images"
+	ArtifactTypeTexts         = "texts"
+	ArtifactTypeAudios        = "audios"
+	ArtifactTypeDistributions = "distributions"
+	ArtifactTypeFigures       = "figures"
 )
 
-// Artifact represents the artifact model.
+// ArtifactTextData represents the data of `texts` artifact.
+type ArtifactTextData struct {
+	Text string `json:"text"`
+}
+
+// ArtifactDistributionData represents the data of `distributions` artifact. Weights are the bin values
+// of the histogram and BinRange is the range of values covered by the bins.
+type ArtifactDistributionData struct {
+	Weights  []float64  `json:"weights"`
+	BinRange [2]float64 `json:"bin_range"`
+}
+
+// Artifact represents the artifact model. Images and audios are stored in the artifact storage and referenced
+// by BlobURI, data of other types is stored in Data.
 type Artifact struct {
 	ID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
 	Name      string    `gorm:"not null;index"`
@@ -22,6 +46,8 @@ type Artifact struct {
 	Format    string
 	Caption   string
 	BlobURI   string
+	Type      string `gorm:"not null;default:'images';index"`
+	Data      types.JSONB
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -37,9 +63,10 @@ func (u *Artifact) AfterSave(tx *gorm.DB) error {
                    WHERE run_uuid = ?
                    AND name = ?
                    AND step = ?
+                   AND type = ?
                  ) as rows
 	         WHERE artifacts.id = rows.id`,
-		u.RunID, u.Name, u.Step,
+		u.RunID, u.Name, u.Step, u.Type,
 	).Error; err != nil {
 		return eris.Wrap(err, "error updating artifacts iter")
 	}
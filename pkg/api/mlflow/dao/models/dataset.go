@@ -0,0 +1,22 @@
+package models
+
+// Dataset represents model to work with `datasets` table. The dataset is identified by its name and digest
+// within the experiment, so the same dataset logged by several runs is kept only once.
+type Dataset struct {
+	ID           string `gorm:"column:dataset_uuid;type:varchar(36);not null;index"`
+	ExperimentID int32  `gorm:"not null;primaryKey"`
+	Name         string `gorm:"type:varchar(500);not null;primaryKey"`
+	Digest       string `gorm:"type:varchar(36);not null;primaryKey"`
+	SourceType   string `gorm:"column:dataset_source_type;type:varchar(36);not null"`
+	Source       string `gorm:"column:dataset_source;type:text;not null"`
+	Schema       string `gorm:"column:dataset_schema;type:text"`
+	Profile      string `gorm:"column:dataset_profile;type:text"`
+}
+
+// DatasetSummary represents the dataset used by the runs of the experiment in the given context.
+type DatasetSummary struct {
+	ExperimentID int32
+	Name         string
+	Digest       string
+	Context      string
+}
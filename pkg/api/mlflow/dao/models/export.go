@@ -0,0 +1,32 @@
+package models
+
+// ExportStatus represents the status of the export.
+type ExportStatus string
+
+// Supported list of export statuses.
+const (
+	ExportStatusRunning   ExportStatus = "RUNNING"
+	ExportStatusCompleted ExportStatus = "COMPLETED"
+	ExportStatusFailed    ExportStatus = "FAILED"
+)
+
+// ExportErrorMaxLength is the max length of the error kept by the failed export.
+const ExportErrorMaxLength = 1000
+
+// Export represents model to work with `exports` table. The export writes the exported runs or metrics
+// to the artifact storage in background, and the table keeps its status, so it could be requested
+// from any instance of the server.
+type Export struct {
+	ID          string `gorm:"column:export_uuid;type:varchar(32);primaryKey"`
+	NamespaceID uint   `gorm:"not null;index"`
+	Table       string `gorm:"column:export_table;type:varchar(20);not null"`
+	Format      string `gorm:"type:varchar(20);not null"`
+	// URI is the artifact uri of the exported file.
+	URI    string       `gorm:"type:varchar(1000);not null"`
+	Status ExportStatus `gorm:"type:varchar(20);not null"`
+	// Rows is the number of the exported rows.
+	Rows         int    `gorm:"not null;default:0"`
+	Error        string `gorm:"type:varchar(1000)"`
+	CreationTime int64  `gorm:"not null"`
+	FinishTime   int64  `gorm:"index"`
+}
@@ -0,0 +1,27 @@
+package models
+
+// Supported source and destination types of the input.
+const (
+	InputSourceTypeDataset  = "DATASET"
+	InputDestinationTypeRun = "RUN"
+	InputTagDatasetContext  = "mlflow.data.context"
+)
+
+// Input represents model to work with `inputs` table. The input connects the source, the dataset, with
+// the destination, the run, which has used it.
+type Input struct {
+	ID              string     `gorm:"column:input_uuid;type:varchar(36);not null;index"`
+	SourceType      string     `gorm:"type:varchar(36);not null;primaryKey"`
+	SourceID        string     `gorm:"type:varchar(36);not null;primaryKey"`
+	DestinationType string     `gorm:"type:varchar(36);not null;primaryKey"`
+	DestinationID   string     `gorm:"type:varchar(36);not null;primaryKey"`
+	Tags            []InputTag `gorm:"foreignKey:InputID;references:ID"`
+	Dataset         Dataset    `gorm:"foreignKey:SourceID;references:ID"`
+}
+
+// InputTag represents model to work with `input_tags` table.
+type InputTag struct {
+	InputID string `gorm:"column:input_uuid;type:varchar(36);not null;primaryKey"`
+	Key     string `gorm:"column:name;type:varchar(255);not null;primaryKey"`
+	Value   string `gorm:"type:varchar(500);not null"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MetricRetentionPolicy represents model to work with `metric_retention_policies` table.
+// The policy keeps metrics of the namespace or the experiment in full resolution for FullResolutionDays,
+// after that only every KeepEvery-th iteration of each metric is kept, optionally together with minimal and
+// maximal values of each bucket of KeepEvery iterations. Policy of the experiment overrides policy of its namespace.
+type MetricRetentionPolicy struct {
+	ID                 uuid.UUID   `gorm:"type:uuid;primaryKey"`
+	NamespaceID        uint        `gorm:"not null;index"`
+	Namespace          Namespace   `gorm:"constraint:OnDelete:CASCADE"`
+	ExperimentID       *int32      `gorm:"index"`
+	Experiment         *Experiment `gorm:"constraint:OnDelete:CASCADE"`
+	FullResolutionDays int         `gorm:"not null"`
+	KeepEvery          int         `gorm:"not null"`
+	KeepMinMax         bool        `gorm:"not null;default:false"`
+	LastAppliedAt      *time.Time
+	RemovedMetrics     int64 `gorm:"not null;default:0"`
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// IsExperimentPolicy makes check that policy is applied to the experiment rather than to the whole namespace.
+func (p MetricRetentionPolicy) IsExperimentPolicy() bool {
+	return p.ExperimentID != nil
+}
+
+// GetCutoffTimestamp returns the timestamp in milliseconds, metrics logged before which are compacted.
+func (p MetricRetentionPolicy) GetCutoffTimestamp(now time.Time) int64 {
+	return now.AddDate(0, 0, -p.FullResolutionDays).UnixMilli()
+}
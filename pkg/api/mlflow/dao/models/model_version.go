@@ -0,0 +1,72 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ModelVersionStage represents stage of the model version.
+type ModelVersionStage string
+
+// Supported list of model version stages.
+const (
+	ModelVersionStageNone       ModelVersionStage = "None"
+	ModelVersionStageStaging    ModelVersionStage = "Staging"
+	ModelVersionStageProduction ModelVersionStage = "Production"
+	ModelVersionStageArchived   ModelVersionStage = "Archived"
+)
+
+// ModelVersionStages is the ordered list of all supported stages.
+var ModelVersionStages = []ModelVersionStage{
+	ModelVersionStageNone,
+	ModelVersionStageStaging,
+	ModelVersionStageProduction,
+	ModelVersionStageArchived,
+}
+
+// ParseModelVersionStage returns canonical stage value for the case-insensitive input.
+func ParseModelVersionStage(stage string) (ModelVersionStage, bool) {
+	for _, s := range ModelVersionStages {
+		if strings.EqualFold(string(s), stage) {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+// ModelVersionStatus represents status of the model version.
+type ModelVersionStatus string
+
+// Supported list of model version statuses.
+const (
+	ModelVersionStatusPendingRegistration ModelVersionStatus = "PENDING_REGISTRATION"
+	ModelVersionStatusFailedRegistration  ModelVersionStatus = "FAILED_REGISTRATION"
+	ModelVersionStatusReady               ModelVersionStatus = "READY"
+)
+
+// ModelVersion represents model to work with `model_versions` table.
+//
+//nolint:lll
+type ModelVersion struct {
+	Base
+	Version           int64              `gorm:"not null;index:,unique,composite:version"`
+	RegisteredModelID uuid.UUID          `gorm:"type:uuid;not null;index:,unique,composite:version"`
+	RegisteredModel   RegisteredModel    `gorm:"constraint:OnDelete:CASCADE"`
+	Description       string             `gorm:"type:varchar(5000)"`
+	UserID            string             `gorm:"type:varchar(256)"`
+	CurrentStage      ModelVersionStage  `gorm:"type:varchar(20);check:current_stage IN ('None', 'Staging', 'Production', 'Archived')"`
+	Source            string             `gorm:"type:varchar(500)"`
+	RunID             string             `gorm:"column:run_uuid;type:varchar(32);index"`
+	RunLink           string             `gorm:"type:varchar(500)"`
+	Status            ModelVersionStatus `gorm:"type:varchar(20);check:status IN ('PENDING_REGISTRATION', 'FAILED_REGISTRATION', 'READY')"`
+	StatusMessage     string             `gorm:"type:varchar(500)"`
+	Tags              []ModelVersionTag  `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// ModelVersionTag represents model to work with `model_version_tags` table.
+type ModelVersionTag struct {
+	Key            string    `gorm:"type:varchar(250);not null;primaryKey"`
+	Value          string    `gorm:"type:varchar(5000)"`
+	ModelVersionID uuid.UUID `gorm:"type:uuid;not null;primaryKey"`
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// RegisteredModel represents model to work with `registered_models` table.
+type RegisteredModel struct {
+	Base
+	Name        string                 `gorm:"type:varchar(256);not null;index:,unique,composite:name"`
+	Description string                 `gorm:"type:varchar(5000)"`
+	UserID      string                 `gorm:"type:varchar(256)"`
+	NamespaceID uint                   `gorm:"not null;index:,unique,composite:name"`
+	Namespace   Namespace              `gorm:"constraint:OnDelete:CASCADE"`
+	Tags        []RegisteredModelTag   `gorm:"constraint:OnDelete:CASCADE"`
+	Aliases     []RegisteredModelAlias `gorm:"constraint:OnDelete:CASCADE"`
+	Versions    []ModelVersion         `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// LatestVersions returns the latest models.ModelVersion for each stage the RegisteredModel has versions in.
+// If the list of stages is provided, then only these stages will be taken into account.
+func (m RegisteredModel) LatestVersions(stages ...ModelVersionStage) []ModelVersion {
+	latest := map[ModelVersionStage]ModelVersion{}
+	for _, version := range m.Versions {
+		if current, ok := latest[version.CurrentStage]; !ok || version.Version > current.Version {
+			latest[version.CurrentStage] = version
+		}
+	}
+
+	if len(stages) == 0 {
+		stages = ModelVersionStages
+	}
+	versions := make([]ModelVersion, 0, len(latest))
+	for _, stage := range stages {
+		if version, ok := latest[stage]; ok {
+			versions = append(versions, version)
+		}
+	}
+	return versions
+}
+
+// GetAliasesForVersion returns the list of aliases pointing to the requested version.
+func (m RegisteredModel) GetAliasesForVersion(version int64) []string {
+	var aliases []string
+	for _, alias := range m.Aliases {
+		if alias.Version == version {
+			aliases = append(aliases, alias.Alias)
+		}
+	}
+	return aliases
+}
+
+// RegisteredModelTag represents model to work with `registered_model_tags` table.
+type RegisteredModelTag struct {
+	Key               string    `gorm:"type:varchar(250);not null;primaryKey"`
+	Value             string    `gorm:"type:varchar(5000)"`
+	RegisteredModelID uuid.UUID `gorm:"type:uuid;not null;primaryKey"`
+}
+
+// RegisteredModelAlias represents model to work with `registered_model_aliases` table.
+type RegisteredModelAlias struct {
+	Alias             string    `gorm:"type:varchar(256);not null;primaryKey"`
+	Version           int64     `gorm:"not null"`
+	RegisteredModelID uuid.UUID `gorm:"type:uuid;not null;primaryKey"`
+}
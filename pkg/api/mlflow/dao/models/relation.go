@@ -2,14 +2,17 @@ package models
 
 import (
 	"github.com/google/uuid"
+
+	commonModels "github.com/G-Research/fasttrackml/pkg/common/dao/models"
 )
 
 // RoleNamespace represents a model to work with `role_relations` table.
-// Model holds relations between Role and Namespace models.
+// Model holds relations between Role and Namespace models and the permission granted by Role.
 type RoleNamespace struct {
 	Base
-	Role        Role      `gorm:"constraint:OnDelete:CASCADE"`
-	RoleID      uuid.UUID `gorm:"not null;index:,unique,composite:relation"`
-	Namespace   Namespace `gorm:"constraint:OnDelete:CASCADE"`
-	NamespaceID uint      `gorm:"not null;index:,unique,composite:relation"`
+	Role        Role                    `gorm:"constraint:OnDelete:CASCADE"`
+	RoleID      uuid.UUID               `gorm:"not null;index:,unique,composite:relation"`
+	Namespace   Namespace               `gorm:"constraint:OnDelete:CASCADE"`
+	NamespaceID uint                    `gorm:"not null;index:,unique,composite:relation"`
+	Permission  commonModels.Permission `gorm:"not null;default:'owner'"`
 }
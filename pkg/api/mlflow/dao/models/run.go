@@ -21,6 +21,8 @@ const (
 )
 
 // Run represents a model to work with `runs` table.
+// DeletedAt is set when the run has been deleted and waits to be purged. Unlike DeletedTime of the archived run,
+// it hides the run from all the queries of the model.
 //
 //nolint:lll
 type Run struct {
@@ -39,12 +41,14 @@ type Run struct {
 	ExperimentID   int32
 	Experiment     Experiment
 	DeletedTime    sql.NullInt64  `gorm:"type:bigint"`
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
 	RowNum         RowNum         `gorm:"<-:create;index"`
 	Params         []Param        `gorm:"constraint:OnDelete:CASCADE"`
 	Tags           []Tag          `gorm:"constraint:OnDelete:CASCADE"`
 	Logs           []Log          `gorm:"constraint:OnDelete:CASCADE"`
 	Metrics        []Metric       `gorm:"constraint:OnDelete:CASCADE"`
 	LatestMetrics  []LatestMetric `gorm:"constraint:OnDelete:CASCADE"`
+	Inputs         []Input        `gorm:"polymorphic:Destination;polymorphicValue:RUN"`
 }
 
 // RowNum represents custom data type.
@@ -11,6 +11,7 @@ import (
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/metricstore"
 )
 
 // ExperimentRepositoryProvider provides an interface to work with `experiment` entity.
@@ -36,15 +37,24 @@ type ExperimentRepositoryProvider interface {
 // ExperimentRepository repository to work with `experiment` entity.
 type ExperimentRepository struct {
 	repositories.BaseRepositoryProvider
+	// metricStore is the store of the metric history. Metrics are kept by the database when it is not set.
+	metricStore metricstore.Store
 }
 
 // NewExperimentRepository creates repository to work with `experiment` entity.
 func NewExperimentRepository(db *gorm.DB) *ExperimentRepository {
 	return &ExperimentRepository{
-		repositories.NewBaseRepository(db),
+		BaseRepositoryProvider: repositories.NewBaseRepository(db),
 	}
 }
 
+// WithMetricStore sets the store of the metric history, so the metrics of the runs are removed
+// from the store together with the experiments.
+func (r *ExperimentRepository) WithMetricStore(metricStore metricstore.Store) *ExperimentRepository {
+	r.metricStore = metricStore
+	return r
+}
+
 // Create creates new models.Experiment entity.
 func (r ExperimentRepository) Create(ctx context.Context, experiment *models.Experiment) error {
 	if err := r.GetDB().WithContext(ctx).Create(&experiment).Error; err != nil {
@@ -138,8 +148,18 @@ func (r ExperimentRepository) Delete(ctx context.Context, experiment *models.Exp
 
 // DeleteBatch removes existing []models.Experiment in batch from the db.
 func (r ExperimentRepository) DeleteBatch(ctx context.Context, ids []*int32) error {
+	var runIDs []string
 	if err := r.GetDB().Transaction(func(tx *gorm.DB) error {
-		// finding all the runs
+		// finding all the runs, including the deleted ones, as they are removed by cascade too.
+		if r.metricStore != nil {
+			if err := tx.Unscoped().Model(
+				&models.Run{},
+			).Where(
+				"experiment_id IN (?)", ids,
+			).Pluck("run_uuid", &runIDs).Error; err != nil {
+				return err
+			}
+		}
 		var minRowNum sql.NullInt64
 		if err := tx.Model(
 			&models.Run{},
@@ -149,6 +169,22 @@ func (r ExperimentRepository) DeleteBatch(ctx context.Context, ids []*int32) err
 			return err
 		}
 
+		// removing the dataset inputs of the runs and the datasets, they aren't removed by cascade.
+		if err := deleteRunInputs(
+			tx, tx.Session(&gorm.Session{NewDB: true}).Unscoped().Model(
+				&models.Run{},
+			).Select(
+				"run_uuid",
+			).Where(
+				"experiment_id IN ?", ids,
+			),
+		); err != nil {
+			return eris.Wrapf(err, "error deleting inputs of experiments with ids: %d", ids)
+		}
+		if err := tx.Where("experiment_id IN ?", ids).Delete(&models.Dataset{}).Error; err != nil {
+			return eris.Wrapf(err, "error deleting datasets of experiments with ids: %d", ids)
+		}
+
 		experiments := make([]models.Experiment, 0, len(ids))
 		if err := tx.Clauses(
 			clause.Returning{Columns: []clause.Column{{Name: "experiment_id"}}},
@@ -176,6 +212,11 @@ func (r ExperimentRepository) DeleteBatch(ctx context.Context, ids []*int32) err
 		return eris.Wrapf(err, "error deleting experiments")
 	}
 
+	if r.metricStore != nil && len(runIDs) > 0 {
+		if err := r.metricStore.Delete(ctx, runIDs); err != nil {
+			return eris.Wrapf(err, "error deleting metrics of experiments with ids: %d from metric store", ids)
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// ExportRepositoryProvider provides an interface to work with models.Export entity.
+type ExportRepositoryProvider interface {
+	repositories.BaseRepositoryProvider
+	// Create creates new models.Export entity.
+	Create(ctx context.Context, export *models.Export) error
+	// Finish keeps the number of the exported rows, the status and the error of the finished export.
+	Finish(ctx context.Context, export *models.Export) error
+	// GetByNamespaceIDAndID returns the export by Namespace ID and its ID or nil when it doesn't exist.
+	GetByNamespaceIDAndID(ctx context.Context, namespaceID uint, id string) (*models.Export, error)
+	// DeleteFinishedBefore removes the exports finished before the time in milliseconds.
+	DeleteFinishedBefore(ctx context.Context, finishTime int64) error
+}
+
+// ExportRepository repository to work with models.Export entity.
+type ExportRepository struct {
+	repositories.BaseRepositoryProvider
+}
+
+// NewExportRepository creates repository to work with models.Export entity.
+func NewExportRepository(db *gorm.DB) *ExportRepository {
+	return &ExportRepository{
+		repositories.NewBaseRepository(db),
+	}
+}
+
+// Create creates new models.Export entity.
+func (r ExportRepository) Create(ctx context.Context, export *models.Export) error {
+	if err := r.GetDB().WithContext(ctx).Create(export).Error; err != nil {
+		return eris.Wrap(err, "error creating export entity")
+	}
+	return nil
+}
+
+// Finish keeps the number of the exported rows, the status and the error of the finished export.
+func (r ExportRepository) Finish(ctx context.Context, export *models.Export) error {
+	if len(export.Error) > models.ExportErrorMaxLength {
+		export.Error = export.Error[:models.ExportErrorMaxLength]
+	}
+	if err := r.GetDB().WithContext(ctx).Model(
+		export,
+	).Select(
+		"Rows", "Status", "Error", "FinishTime",
+	).Updates(export).Error; err != nil {
+		return eris.Wrapf(err, "error finishing export with id: %s", export.ID)
+	}
+	return nil
+}
+
+// GetByNamespaceIDAndID returns the export by Namespace ID and its ID or nil when it doesn't exist.
+func (r ExportRepository) GetByNamespaceIDAndID(
+	ctx context.Context, namespaceID uint, id string,
+) (*models.Export, error) {
+	var export models.Export
+	if err := r.GetDB().WithContext(ctx).Where(
+		"namespace_id = ? AND export_uuid = ?", namespaceID, id,
+	).First(&export).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, eris.Wrapf(err, "error getting export by id: %s", id)
+	}
+	return &export, nil
+}
+
+// DeleteFinishedBefore removes the exports finished before the time in milliseconds.
+func (r ExportRepository) DeleteFinishedBefore(ctx context.Context, finishTime int64) error {
+	if err := r.GetDB().WithContext(ctx).Where(
+		"status <> ? AND finish_time < ?", models.ExportStatusRunning, finishTime,
+	).Delete(&models.Export{}).Error; err != nil {
+		return eris.Wrap(err, "error deleting finished exports")
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// MaxDatasetSummaries is the max number of the dataset summaries returned by SearchDatasets.
+const MaxDatasetSummaries = 1000
+
+// InputRepositoryProvider provides an interface to work with models.Input entity.
+type InputRepositoryProvider interface {
+	repositories.BaseRepositoryProvider
+	// CreateBatch creates new models.Input entities together with their models.Dataset and models.InputTag.
+	CreateBatch(ctx context.Context, inputs []models.Input) error
+	// SearchDatasets returns the summaries of the datasets used by the runs of the requested experiments.
+	SearchDatasets(ctx context.Context, namespaceID uint, experimentIDs []int32) ([]models.DatasetSummary, error)
+}
+
+// InputRepository repository to work with models.Input entity.
+type InputRepository struct {
+	repositories.BaseRepositoryProvider
+}
+
+// NewInputRepository creates repository to work with models.Input entity.
+func NewInputRepository(db *gorm.DB) *InputRepository {
+	return &InputRepository{
+		repositories.NewBaseRepository(db),
+	}
+}
+
+// CreateBatch creates new models.Input entities together with their models.Dataset and models.InputTag.
+// The dataset already logged in the experiment and the input already logged for the destination are reused,
+// tags of the existing input are kept as they are.
+func (r InputRepository) CreateBatch(ctx context.Context, inputs []models.Input) error {
+	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, input := range inputs {
+			dataset := input.Dataset
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&dataset).Error; err != nil {
+				return eris.Wrapf(err, "error creating dataset with name: %s", dataset.Name)
+			}
+			if err := tx.Where(
+				"experiment_id = ? AND name = ? AND digest = ?", dataset.ExperimentID, dataset.Name, dataset.Digest,
+			).First(&dataset).Error; err != nil {
+				return eris.Wrapf(err, "error getting dataset with name: %s", dataset.Name)
+			}
+
+			input.SourceID = dataset.ID
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Omit(clause.Associations).Create(&input)
+			if result.Error != nil {
+				return eris.Wrapf(result.Error, "error creating input of dataset with name: %s", dataset.Name)
+			}
+			if result.RowsAffected == 0 || len(input.Tags) == 0 {
+				continue
+			}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&input.Tags).Error; err != nil {
+				return eris.Wrapf(err, "error creating tags of input of dataset with name: %s", dataset.Name)
+			}
+		}
+		return nil
+	}); err != nil {
+		return eris.Wrap(err, "error creating inputs")
+	}
+	return nil
+}
+
+// SearchDatasets returns the summaries of the datasets used by the runs of the requested experiments.
+func (r InputRepository) SearchDatasets(
+	ctx context.Context, namespaceID uint, experimentIDs []int32,
+) ([]models.DatasetSummary, error) {
+	var summaries []models.DatasetSummary
+	if err := r.GetDB().WithContext(ctx).Model(
+		&models.Dataset{},
+	).Distinct(
+		"datasets.experiment_id", "datasets.name", "datasets.digest", "input_tags.value AS context",
+	).Joins(
+		"INNER JOIN experiments ON experiments.experiment_id = datasets.experiment_id AND experiments.namespace_id = ?",
+		namespaceID,
+	).Joins(
+		"INNER JOIN inputs ON inputs.source_type = ? AND inputs.source_id = datasets.dataset_uuid",
+		models.InputSourceTypeDataset,
+	).Joins(
+		"LEFT JOIN input_tags ON input_tags.input_uuid = inputs.input_uuid AND input_tags.name = ?",
+		models.InputTagDatasetContext,
+	).Where(
+		"datasets.experiment_id IN ?", experimentIDs,
+	).Order(
+		"datasets.experiment_id",
+	).Order(
+		"datasets.name",
+	).Order(
+		"datasets.digest",
+	).Order(
+		"context",
+	).Limit(
+		MaxDatasetSummaries,
+	).Scan(&summaries).Error; err != nil {
+		return nil, eris.Wrap(err, "error searching datasets")
+	}
+	return summaries, nil
+}
+
+// deleteRunInputs removes the inputs of the runs together with their tags. The inputs aren't connected with
+// the runs by the foreign key, so they have to be removed explicitly. runIDs could be either the list of
+// the run ids or the query selecting them.
+func deleteRunInputs(tx *gorm.DB, runIDs any) error {
+	inputIDs := tx.Session(&gorm.Session{NewDB: true}).Model(
+		&models.Input{},
+	).Select(
+		"input_uuid",
+	).Where(
+		"destination_type = ? AND destination_id IN (?)", models.InputDestinationTypeRun, runIDs,
+	)
+	if err := tx.Where("input_uuid IN (?)", inputIDs).Delete(&models.InputTag{}).Error; err != nil {
+		return eris.Wrap(err, "error deleting input tags")
+	}
+	if err := tx.Where(
+		"destination_type = ? AND destination_id IN (?)", models.InputDestinationTypeRun, runIDs,
+	).Delete(&models.Input{}).Error; err != nil {
+		return eris.Wrap(err, "error deleting inputs")
+	}
+	return nil
+}
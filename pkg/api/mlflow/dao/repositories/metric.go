@@ -5,12 +5,15 @@ import (
 	"database/sql"
 
 	"github.com/rotisserie/eris"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+	"github.com/G-Research/fasttrackml/pkg/common/metricstore"
 	"github.com/G-Research/fasttrackml/pkg/database"
 )
 
@@ -19,11 +22,19 @@ const (
 	MetricHistoryBulkDefaultLimit = 25000
 )
 
+// RunMetrics represents metrics of the run to be created in batch.
+type RunMetrics struct {
+	Run     *models.Run
+	Metrics []models.Metric
+}
+
 // MetricRepositoryProvider provides an interface to work with models.Metric entity.
 type MetricRepositoryProvider interface {
 	repositories.BaseRepositoryProvider
 	// CreateBatch creates []models.Metric entities in batch.
 	CreateBatch(ctx context.Context, run *models.Run, batchSize int, params []models.Metric) error
+	// CreateRunsBatch creates metrics of the several runs in one transaction.
+	CreateRunsBatch(ctx context.Context, batchSize int, runsMetrics []RunMetrics) error
 	// GetMetricHistories returns metric histories by request parameters.
 	GetMetricHistories(
 		ctx context.Context,
@@ -32,7 +43,7 @@ type MetricRepositoryProvider interface {
 		viewType request.ViewType,
 		limit int32,
 		jsonPathValueMap map[string]string,
-	) (*sql.Rows, func(*sql.Rows, interface{}) error, error)
+	) (metricstore.Cursor, error)
 	// GetMetricHistoryBulk returns metrics history bulk.
 	GetMetricHistoryBulk(
 		ctx context.Context, namespaceID uint, runIDs []string, key string, limit int,
@@ -44,13 +55,31 @@ type MetricRepositoryProvider interface {
 // MetricRepository repository to work with models.Metric entity.
 type MetricRepository struct {
 	repositories.BaseRepositoryProvider
+	// conn is the connection pinned by the transaction, which is used to insert metrics by Postgres `COPY`.
+	conn *sql.Conn
+	// metricStore is the store of the metric history. Metrics are kept by the database when it is not set.
+	metricStore metricstore.Store
 }
 
 // NewMetricRepository creates repository to work with models.Metric entity.
 func NewMetricRepository(db *gorm.DB) *MetricRepository {
 	return &MetricRepository{
-		repositories.NewBaseRepository(db),
+		BaseRepositoryProvider: repositories.NewBaseRepository(db),
+	}
+}
+
+// WithMetricStore sets the store of the metric history.
+func (r *MetricRepository) WithMetricStore(metricStore metricstore.Store) *MetricRepository {
+	r.metricStore = metricStore
+	return r
+}
+
+// getMetricStore returns the store of the metric history.
+func (r MetricRepository) getMetricStore() metricstore.Store {
+	if r.metricStore != nil {
+		return r.metricStore
 	}
+	return metricstore.NewSQLStore(r.GetDB(), r.conn)
 }
 
 // CreateBatch creates []models.Metric entities in batch.
@@ -132,9 +161,24 @@ func (r MetricRepository) CreateBatch(
 		}
 	}
 
-	if err := r.GetDB().WithContext(ctx).Clauses(
-		clause.OnConflict{DoNothing: true},
-	).CreateInBatches(&metrics, batchSize).Error; err != nil {
+	points := make([]metricstore.Metric, len(metrics))
+	for n, metric := range metrics {
+		points[n] = metricstore.Metric{
+			RunID:     metric.RunID,
+			Key:       metric.Key,
+			ContextID: metric.ContextID,
+			Iter:      metric.Iter,
+			Step:      metric.Step,
+			Timestamp: metric.Timestamp,
+			Value:     metric.Value,
+			IsNan:     metric.IsNan,
+		}
+	}
+	metricStore := r.metricStore
+	if metricStore == nil {
+		metricStore = metricstore.NewSQLStore(r.GetDB(), r.conn).WithBatchSize(batchSize)
+	}
+	if err := metricStore.Write(ctx, points); err != nil {
 		return eris.Wrapf(err, "error creating metrics for run: %s", run.ID)
 	}
 
@@ -169,8 +213,32 @@ func (r MetricRepository) CreateBatch(
 	return nil
 }
 
+// CreateRunsBatch creates metrics of the several runs in one transaction.
+func (r MetricRepository) CreateRunsBatch(ctx context.Context, batchSize int, runsMetrics []RunMetrics) error {
+	createRunsBatch := func(repository *MetricRepository) error {
+		for _, runMetrics := range runsMetrics {
+			if err := repository.CreateBatch(ctx, runMetrics.Run, batchSize, runMetrics.Metrics); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var err error
+	if r.GetDB().Dialector.Name() == (postgres.Dialector{}).Name() {
+		err = r.runInPinnedTransaction(ctx, createRunsBatch)
+	} else {
+		err = r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return createRunsBatch(NewMetricRepository(tx).WithMetricStore(r.metricStore))
+		})
+	}
+	if err != nil {
+		return eris.Wrapf(err, "error creating metrics of %d runs", len(runsMetrics))
+	}
+	return nil
+}
+
 // GetMetricHistories returns metric histories by request parameters.
-// TODO think about to use interface instead of underlying type for -> func(*sql.Rows, interface{})
 func (r MetricRepository) GetMetricHistories(
 	ctx context.Context,
 	namespaceID uint,
@@ -178,7 +246,7 @@ func (r MetricRepository) GetMetricHistories(
 	viewType request.ViewType,
 	limit int32,
 	jsonPathValueMap map[string]string,
-) (*sql.Rows, func(*sql.Rows, interface{}) error, error) {
+) (metricstore.Cursor, error) {
 	// if experimentIDs has been provided then firstly get the runs by provided experimentIDs.
 	if len(experimentIDs) > 0 {
 		query := r.GetDB().WithContext(ctx).Model(
@@ -206,7 +274,7 @@ func (r MetricRepository) GetMetricHistories(
 			})
 		}
 		if err := query.Pluck("run_uuid", &runIDs).Error; err != nil {
-			return nil, nil, eris.Wrapf(
+			return nil, eris.Wrapf(
 				err, "error getting runs by experimentIDs: %v, viewType: %s", experimentIDs, viewType,
 			)
 		}
@@ -214,49 +282,51 @@ func (r MetricRepository) GetMetricHistories(
 
 	// if experimentIDs has been provided then runIDs contains values from previous step,
 	// otherwise runIDs may or may not contain values.
-	query := r.GetDB().WithContext(ctx).Model(
-		&database.Metric{},
-	).Where(
-		"metrics.run_uuid IN ?", runIDs,
+	query := r.GetDB().WithContext(ctx).Where(
+		"series.run_uuid IN ?", runIDs,
 	).Joins(
-		"JOIN runs on runs.run_uuid = metrics.run_uuid",
+		"INNER JOIN runs ON runs.run_uuid = series.run_uuid AND runs.deleted_at IS NULL",
 	).Joins(
 		"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
 		namespaceID,
-	).Joins(
-		"Context",
 	).Order(
 		"runs.start_time DESC",
 	).Order(
-		"metrics.run_uuid",
-	).Order(
-		"metrics.key",
-	).Order(
-		"metrics.step",
-	).Order(
-		"metrics.timestamp",
-	).Order(
-		"metrics.value",
+		"series.run_uuid",
 	)
 
-	if limit == 0 {
-		limit = MetricHistoriesDefaultLimit
-	}
-	query.Limit(int(limit))
-
 	if len(metricKeys) > 0 {
-		query.Where("metrics.key IN ?", metricKeys)
+		query.Where("series.key IN ?", metricKeys)
 	}
 
 	if len(jsonPathValueMap) > 0 {
-		query.Joins("LEFT JOIN contexts on metrics.context_id = contexts.id")
 		sql, args := BuildJsonCondition(query.Dialector.Name(), "contexts.json", jsonPathValueMap)
 		query.Where(sql, args...)
 	}
 
-	rows, err := query.Rows()
+	series, err := r.getSeries(query, runIDs)
 	if err != nil {
-		return nil, nil, eris.Wrapf(
+		return nil, eris.Wrapf(
+			err, "error getting metric series by experimentIDs: %v, runIDs: %v, metricKeys: %v, viewType: %s",
+			experimentIDs,
+			runIDs,
+			metricKeys,
+			viewType,
+		)
+	}
+
+	if limit == 0 {
+		limit = MetricHistoriesDefaultLimit
+	}
+	cursor, err := r.getMetricStore().Read(ctx, &metricstore.Query{
+		Series: series,
+		OrderBy: []metricstore.Column{
+			metricstore.ColumnKey, metricstore.ColumnStep, metricstore.ColumnTimestamp, metricstore.ColumnValue,
+		},
+		Limit: int(limit),
+	})
+	if err != nil {
+		return nil, eris.Wrapf(
 			err, "error getting metrics by experimentIDs: %v, runIDs: %v, metricKeys: %v, viewType: %s",
 			experimentIDs,
 			runIDs,
@@ -264,7 +334,66 @@ func (r MetricRepository) GetMetricHistories(
 			viewType,
 		)
 	}
-	return rows, r.GetDB().ScanRows, nil
+	return cursor, nil
+}
+
+// getSeries returns the metric series of the runs selected by provided query of `series` table. The order of
+// the query defines the order of the runs.
+func (r MetricRepository) getSeries(query *gorm.DB, runIDs []string) ([]metricstore.Series, error) {
+	var rows []struct {
+		RunID       string `gorm:"column:run_uuid"`
+		Key         string
+		ContextID   uint
+		ContextJson types.JSONB
+	}
+	if err := metricstore.SeriesTable(query, r.metricStore, runIDs).Select(
+		"series.run_uuid", "series.key", "series.context_id", "contexts.json AS context_json",
+	).Joins(
+		"INNER JOIN contexts ON contexts.id = series.context_id",
+	).Order(
+		"series.key",
+	).Order(
+		"series.context_id",
+	).Find(&rows).Error; err != nil {
+		return nil, eris.Wrap(err, "error getting metric series")
+	}
+
+	series := make([]metricstore.Series, len(rows))
+	for i, row := range rows {
+		series[i] = metricstore.Series{
+			RunID:     row.RunID,
+			Key:       row.Key,
+			ContextID: row.ContextID,
+			Context:   row.ContextJson,
+		}
+	}
+	return series, nil
+}
+
+// readMetrics reads the metrics of the series and converts them to models.Metric entities.
+func (r MetricRepository) readMetrics(ctx context.Context, query *metricstore.Query) ([]models.Metric, error) {
+	points, err := metricstore.ReadAll(ctx, r.getMetricStore(), query)
+	if err != nil {
+		return nil, err
+	}
+	metrics := make([]models.Metric, len(points))
+	for i, point := range points {
+		metrics[i] = models.Metric{
+			Key:       point.Key,
+			Value:     point.Value,
+			Timestamp: point.Timestamp,
+			RunID:     point.RunID,
+			Step:      point.Step,
+			IsNan:     point.IsNan,
+			Iter:      point.Iter,
+			ContextID: point.ContextID,
+			Context: models.Context{
+				ID:   point.ContextID,
+				Json: point.Context,
+			},
+		}
+	}
+	return metrics, nil
 }
 
 // getLatestMetricsByRunIDAndKeys returns the latest metrics by requested Run ID and keys.
@@ -286,16 +415,19 @@ func (r MetricRepository) getLatestMetricsByRunIDAndKeys(
 func (r MetricRepository) GetMetricHistoryByRunIDAndKey(
 	ctx context.Context, runID, key string,
 ) ([]models.Metric, error) {
-	var metrics []models.Metric
-	if err := r.GetDB().WithContext(
-		ctx,
-	).Joins(
-		"Context",
-	).Where(
-		"run_uuid = ?", runID,
+	series, err := r.getSeries(r.GetDB().WithContext(ctx).Where(
+		"series.run_uuid = ?", runID,
 	).Where(
-		"key = ?", key,
-	).Find(&metrics).Error; err != nil {
+		"series.key = ?", key,
+	), []string{runID})
+	if err != nil {
+		return nil, eris.Wrapf(err, "error getting metric series by run id: %s and key: %s", runID, key)
+	}
+	metrics, err := r.readMetrics(ctx, &metricstore.Query{
+		Series:  series,
+		OrderBy: []metricstore.Column{metricstore.ColumnIter},
+	})
+	if err != nil {
 		return nil, eris.Wrapf(err, "error getting metric history by run id: %s and key: %s", runID, key)
 	}
 	return metrics, nil
@@ -305,34 +437,33 @@ func (r MetricRepository) GetMetricHistoryByRunIDAndKey(
 func (r MetricRepository) GetMetricHistoryBulk(
 	ctx context.Context, namespaceID uint, runIDs []string, key string, limit int,
 ) ([]models.Metric, error) {
-	var metrics []models.Metric
-	query := r.GetDB().WithContext(ctx).Where(
-		"runs.run_uuid IN ?", runIDs,
+	series, err := r.getSeries(r.GetDB().WithContext(ctx).Where(
+		"series.run_uuid IN ?", runIDs,
 	).Joins(
-		"LEFT JOIN runs ON runs.run_uuid = metrics.run_uuid",
+		"INNER JOIN runs ON runs.run_uuid = series.run_uuid AND runs.deleted_at IS NULL",
 	).Joins(
 		"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
 		namespaceID,
 	).Where(
-		"key = ?", key,
+		"series.key = ?", key,
 	).Order(
-		"metrics.run_uuid",
-	).Order(
-		"metrics.timestamp",
-	).Order(
-		"metrics.step",
-	).Order(
-		"metrics.value",
-	)
+		"series.run_uuid",
+	), runIDs)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error getting metric series by run ids: %v and key: %s", runIDs, key)
+	}
 
 	if limit == 0 {
 		limit = MetricHistoryBulkDefaultLimit
 	}
-	query.Limit(limit)
-
-	if err := query.Find(
-		&metrics,
-	).Error; err != nil {
+	metrics, err := r.readMetrics(ctx, &metricstore.Query{
+		Series: series,
+		OrderBy: []metricstore.Column{
+			metricstore.ColumnTimestamp, metricstore.ColumnStep, metricstore.ColumnValue,
+		},
+		Limit: limit,
+	})
+	if err != nil {
 		return nil, eris.Wrapf(err, "error getting metric history by run ids: %v and key: %s", runIDs, key)
 	}
 	return metrics, nil
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+)
+
+// runInPinnedTransaction runs fn inside the transaction on the pinned connection, so `COPY` is available
+// inside the transaction too.
+func (r MetricRepository) runInPinnedTransaction(ctx context.Context, fn func(*MetricRepository) error) error {
+	return r.GetDB().WithContext(ctx).Connection(func(db *gorm.DB) error {
+		conn, ok := db.Statement.ConnPool.(*sql.Conn)
+		if !ok {
+			return eris.New("error getting pinned database connection")
+		}
+		return db.Transaction(func(tx *gorm.DB) error {
+			repository := NewMetricRepository(tx).WithMetricStore(r.metricStore)
+			repository.conn = conn
+			return fn(repository)
+		})
+	})
+}
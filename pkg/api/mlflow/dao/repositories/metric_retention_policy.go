@@ -0,0 +1,260 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// MetricRetentionPreview represents the number of metrics affected by the retention policy.
+type MetricRetentionPreview struct {
+	Total   int64
+	Removed int64
+}
+
+// MetricRetentionPolicyRepositoryProvider provides an interface to work with `metric_retention_policy` entity.
+type MetricRetentionPolicyRepositoryProvider interface {
+	repositories.BaseRepositoryProvider
+	// Create creates new models.MetricRetentionPolicy entity.
+	Create(ctx context.Context, policy *models.MetricRetentionPolicy) error
+	// Update modifies the existing models.MetricRetentionPolicy entity.
+	Update(ctx context.Context, policy *models.MetricRetentionPolicy) error
+	// Delete removes the existing models.MetricRetentionPolicy entity.
+	Delete(ctx context.Context, policy *models.MetricRetentionPolicy) error
+	// GetByID returns policy by its ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*models.MetricRetentionPolicy, error)
+	// GetByScope returns policy of the namespace, or of the experiment when experimentID is not nil.
+	GetByScope(ctx context.Context, namespaceID uint, experimentID *int32) (*models.MetricRetentionPolicy, error)
+	// List returns all policies.
+	List(ctx context.Context) ([]models.MetricRetentionPolicy, error)
+	// Preview returns the number of metrics in the scope of the policy and the number of metrics it would remove.
+	Preview(ctx context.Context, policy *models.MetricRetentionPolicy, now time.Time) (*MetricRetentionPreview, error)
+	// Apply removes the metrics which are not kept by the policy and returns the number of removed metrics.
+	Apply(ctx context.Context, policy *models.MetricRetentionPolicy, now time.Time) (int64, error)
+}
+
+// MetricRetentionPolicyRepository repository to work with `metric_retention_policy` entity.
+type MetricRetentionPolicyRepository struct {
+	repositories.BaseRepositoryProvider
+}
+
+// NewMetricRetentionPolicyRepository creates repository to work with `metric_retention_policy` entity.
+func NewMetricRetentionPolicyRepository(db *gorm.DB) *MetricRetentionPolicyRepository {
+	return &MetricRetentionPolicyRepository{
+		repositories.NewBaseRepository(db),
+	}
+}
+
+// Create creates new models.MetricRetentionPolicy entity.
+func (r MetricRetentionPolicyRepository) Create(ctx context.Context, policy *models.MetricRetentionPolicy) error {
+	if err := r.GetDB().WithContext(ctx).Omit("Namespace", "Experiment").Create(policy).Error; err != nil {
+		return eris.Wrap(err, "error creating metric retention policy entity")
+	}
+	return nil
+}
+
+// Update modifies the existing models.MetricRetentionPolicy entity.
+func (r MetricRetentionPolicyRepository) Update(ctx context.Context, policy *models.MetricRetentionPolicy) error {
+	if err := r.GetDB().WithContext(ctx).Omit("Namespace", "Experiment").Save(policy).Error; err != nil {
+		return eris.Wrapf(err, "error updating metric retention policy with id: %s", policy.ID)
+	}
+	return nil
+}
+
+// Delete removes the existing models.MetricRetentionPolicy entity.
+func (r MetricRetentionPolicyRepository) Delete(ctx context.Context, policy *models.MetricRetentionPolicy) error {
+	if err := r.GetDB().WithContext(ctx).Delete(policy).Error; err != nil {
+		return eris.Wrapf(err, "error deleting metric retention policy with id: %s", policy.ID)
+	}
+	return nil
+}
+
+// GetByID returns policy by its ID.
+func (r MetricRetentionPolicyRepository) GetByID(
+	ctx context.Context, id uuid.UUID,
+) (*models.MetricRetentionPolicy, error) {
+	var policy models.MetricRetentionPolicy
+	if err := r.GetDB().WithContext(ctx).Preload(
+		"Namespace",
+	).Preload(
+		"Experiment",
+	).Where(
+		"id = ?", id,
+	).First(&policy).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, eris.Wrapf(err, "error getting metric retention policy by id: %s", id)
+	}
+	return &policy, nil
+}
+
+// GetByScope returns policy of the namespace, or of the experiment when experimentID is not nil.
+func (r MetricRetentionPolicyRepository) GetByScope(
+	ctx context.Context, namespaceID uint, experimentID *int32,
+) (*models.MetricRetentionPolicy, error) {
+	var policy models.MetricRetentionPolicy
+	query := r.GetDB().WithContext(ctx).Where("namespace_id = ?", namespaceID)
+	if experimentID != nil {
+		query = query.Where("experiment_id = ?", *experimentID)
+	} else {
+		query = query.Where("experiment_id IS NULL")
+	}
+	if err := query.First(&policy).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, eris.Wrapf(err, "error getting metric retention policy of namespace: %d", namespaceID)
+	}
+	return &policy, nil
+}
+
+// List returns all policies.
+func (r MetricRetentionPolicyRepository) List(ctx context.Context) ([]models.MetricRetentionPolicy, error) {
+	var policies []models.MetricRetentionPolicy
+	if err := r.GetDB().WithContext(ctx).Preload(
+		"Namespace",
+	).Preload(
+		"Experiment",
+	).Order(
+		"namespace_id",
+	).Order(
+		"experiment_id",
+	).Find(&policies).Error; err != nil {
+		return nil, eris.Wrap(err, "error listing metric retention policies")
+	}
+	return policies, nil
+}
+
+// Preview returns the number of metrics in the scope of the policy and the number of metrics it would remove.
+func (r MetricRetentionPolicyRepository) Preview(
+	ctx context.Context, policy *models.MetricRetentionPolicy, now time.Time,
+) (*MetricRetentionPreview, error) {
+	var preview MetricRetentionPreview
+	db := r.GetDB().WithContext(ctx)
+	if err := db.Model(
+		&models.Metric{},
+	).Where(
+		"metrics.run_uuid IN (?)", r.getRunIDsQuery(db, policy),
+	).Count(&preview.Total).Error; err != nil {
+		return nil, eris.Wrap(err, "error counting metrics in the scope of metric retention policy")
+	}
+	if err := r.applyRemovableConditions(
+		db.Model(&models.Metric{}).Where("metrics.run_uuid IN (?)", r.getRunIDsQuery(db, policy)), policy, now,
+	).Count(&preview.Removed).Error; err != nil {
+		return nil, eris.Wrap(err, "error counting metrics removed by metric retention policy")
+	}
+	return &preview, nil
+}
+
+// Apply removes the metrics which are not kept by the policy and returns the number of removed metrics.
+// Metrics are removed run by run, so each statement is limited to the metrics of one run.
+func (r MetricRetentionPolicyRepository) Apply(
+	ctx context.Context, policy *models.MetricRetentionPolicy, now time.Time,
+) (int64, error) {
+	db := r.GetDB().WithContext(ctx)
+	var runIDs []string
+	if err := r.getRunIDsQuery(db, policy).Pluck("run_uuid", &runIDs).Error; err != nil {
+		return 0, eris.Wrap(err, "error getting runs in the scope of metric retention policy")
+	}
+
+	var removed int64
+	for _, runID := range runIDs {
+		result := r.applyRemovableConditions(
+			db.Where("metrics.run_uuid = ?", runID), policy, now,
+		).Delete(&models.Metric{})
+		if result.Error != nil {
+			return removed, eris.Wrapf(result.Error, "error removing metrics of run: %s", runID)
+		}
+		removed += result.RowsAffected
+	}
+
+	policy.LastAppliedAt, policy.RemovedMetrics = &now, policy.RemovedMetrics+removed
+	if err := db.Model(policy).UpdateColumns(map[string]any{
+		"last_applied_at": policy.LastAppliedAt,
+		"removed_metrics": policy.RemovedMetrics,
+	}).Error; err != nil {
+		return removed, eris.Wrapf(err, "error updating metric retention policy with id: %s", policy.ID)
+	}
+	return removed, nil
+}
+
+// getRunIDsQuery returns query of IDs of the runs in the scope of the policy. Namespace policy doesn't apply to
+// experiments which have their own policy.
+func (r MetricRetentionPolicyRepository) getRunIDsQuery(db *gorm.DB, policy *models.MetricRetentionPolicy) *gorm.DB {
+	query := db.Session(&gorm.Session{NewDB: true}).Model(&models.Run{}).Select("runs.run_uuid")
+	if policy.ExperimentID != nil {
+		return query.Where("runs.experiment_id = ?", *policy.ExperimentID)
+	}
+	return query.Where(
+		"runs.experiment_id IN (?)",
+		db.Session(&gorm.Session{NewDB: true}).Model(
+			&models.Experiment{},
+		).Select(
+			"experiment_id",
+		).Where(
+			"namespace_id = ?", policy.NamespaceID,
+		),
+	).Where(
+		"runs.experiment_id NOT IN (?)",
+		db.Session(&gorm.Session{NewDB: true}).Model(
+			&models.MetricRetentionPolicy{},
+		).Select(
+			"experiment_id",
+		).Where(
+			"namespace_id = ? AND experiment_id IS NOT NULL", policy.NamespaceID,
+		),
+	)
+}
+
+// applyRemovableConditions adds conditions matching the metrics which are not kept by the policy:
+// metrics logged before the cutoff, except every KeepEvery-th iteration and, when requested, minimal and
+// maximal values of each bucket of KeepEvery iterations. Metrics referenced by `latest_metrics` are always
+// kept, so `latest_metrics` and `last_iter` stay consistent with `metrics`.
+func (r MetricRetentionPolicyRepository) applyRemovableConditions(
+	query *gorm.DB, policy *models.MetricRetentionPolicy, now time.Time,
+) *gorm.DB {
+	query = query.Where(
+		"metrics.timestamp < ?", policy.GetCutoffTimestamp(now),
+	).Where(
+		"metrics.iter % ? <> 0", policy.KeepEvery,
+	).Where(
+		`NOT EXISTS (
+			SELECT 1 FROM latest_metrics
+			WHERE latest_metrics.run_uuid = metrics.run_uuid
+			AND latest_metrics.key = metrics.key
+			AND latest_metrics.context_id = metrics.context_id
+			AND (
+				latest_metrics.last_iter = metrics.iter OR (
+					latest_metrics.step = metrics.step AND
+					latest_metrics.timestamp = metrics.timestamp AND
+					latest_metrics.value = metrics.value
+				)
+			)
+		)`,
+	)
+	if policy.KeepMinMax {
+		for _, condition := range []string{
+			"metrics.value > (SELECT MIN(bucket.value) %s)",
+			"metrics.value < (SELECT MAX(bucket.value) %s)",
+		} {
+			query = query.Where(
+				fmt.Sprintf(condition, `FROM metrics bucket
+					WHERE bucket.run_uuid = metrics.run_uuid
+					AND bucket.key = metrics.key
+					AND bucket.context_id = metrics.context_id
+					AND bucket.iter / ? = metrics.iter / ?`),
+				policy.KeepEvery, policy.KeepEvery,
+			)
+		}
+	}
+	return query
+}
@@ -13,7 +13,7 @@ import (
 
 	request "github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
 
-	sql "database/sql"
+	metricstore "github.com/G-Research/fasttrackml/pkg/common/metricstore"
 )
 
 // MockMetricRepositoryProvider is an autogenerated mock type for the MetricRepositoryProvider type
@@ -35,6 +35,20 @@ func (_m *MockMetricRepositoryProvider) CreateBatch(ctx context.Context, run *mo
 	return r0
 }
 
+// CreateRunsBatch provides a mock function with given fields: ctx, batchSize, runsMetrics
+func (_m *MockMetricRepositoryProvider) CreateRunsBatch(ctx context.Context, batchSize int, runsMetrics []RunMetrics) error {
+	ret := _m.Called(ctx, batchSize, runsMetrics)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []RunMetrics) error); ok {
+		r0 = rf(ctx, batchSize, runsMetrics)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetDB provides a mock function with given fields:
 func (_m *MockMetricRepositoryProvider) GetDB() *gorm.DB {
 	ret := _m.Called()
@@ -52,38 +66,29 @@ func (_m *MockMetricRepositoryProvider) GetDB() *gorm.DB {
 }
 
 // GetMetricHistories provides a mock function with given fields: ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, jsonPathValueMap
-func (_m *MockMetricRepositoryProvider) GetMetricHistories(ctx context.Context, namespaceID uint, experimentIDs []string, runIDs []string, metricKeys []string, viewType request.ViewType, limit int32, jsonPathValueMap map[string]string) (*sql.Rows, func(*sql.Rows, interface{}) error, error) {
+func (_m *MockMetricRepositoryProvider) GetMetricHistories(ctx context.Context, namespaceID uint, experimentIDs []string, runIDs []string, metricKeys []string, viewType request.ViewType, limit int32, jsonPathValueMap map[string]string) (metricstore.Cursor, error) {
 	ret := _m.Called(ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, jsonPathValueMap)
 
-	var r0 *sql.Rows
-	var r1 func(*sql.Rows, interface{}) error
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint, []string, []string, []string, request.ViewType, int32, map[string]string) (*sql.Rows, func(*sql.Rows, interface{}) error, error)); ok {
+	var r0 metricstore.Cursor
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string, []string, []string, request.ViewType, int32, map[string]string) (metricstore.Cursor, error)); ok {
 		return rf(ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, jsonPathValueMap)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint, []string, []string, []string, request.ViewType, int32, map[string]string) *sql.Rows); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string, []string, []string, request.ViewType, int32, map[string]string) metricstore.Cursor); ok {
 		r0 = rf(ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, jsonPathValueMap)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*sql.Rows)
+			r0 = ret.Get(0).(metricstore.Cursor)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint, []string, []string, []string, request.ViewType, int32, map[string]string) func(*sql.Rows, interface{}) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, uint, []string, []string, []string, request.ViewType, int32, map[string]string) error); ok {
 		r1 = rf(ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, jsonPathValueMap)
 	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(func(*sql.Rows, interface{}) error)
-		}
-	}
-
-	if rf, ok := ret.Get(2).(func(context.Context, uint, []string, []string, []string, request.ViewType, int32, map[string]string) error); ok {
-		r2 = rf(ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, jsonPathValueMap)
-	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
 // GetMetricHistoryBulk provides a mock function with given fields: ctx, namespaceID, runIDs, key, limit
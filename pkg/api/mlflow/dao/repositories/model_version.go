@@ -0,0 +1,243 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// ModelVersionRepositoryProvider provides an interface to work with models.ModelVersion entity.
+type ModelVersionRepositoryProvider interface {
+	repositories.BaseRepositoryProvider
+	// Create creates new models.ModelVersion entity with the next available version number.
+	Create(ctx context.Context, modelVersion *models.ModelVersion) error
+	// Update updates existing models.ModelVersion entity.
+	Update(ctx context.Context, modelVersion *models.ModelVersion) error
+	// Delete removes existing models.ModelVersion entity.
+	Delete(ctx context.Context, modelVersion *models.ModelVersion) error
+	// GetByRegisteredModelIDAndVersion returns models.ModelVersion entity by Registered Model ID and version.
+	GetByRegisteredModelIDAndVersion(
+		ctx context.Context, registeredModelID uuid.UUID, version int64,
+	) (*models.ModelVersion, error)
+	// Search returns the list of models.ModelVersion entities which satisfy provided conditions.
+	Search(
+		ctx context.Context,
+		namespaceID uint,
+		filters []ModelSearchFilter,
+		order []ModelSearchOrder,
+		limit, offset int,
+	) ([]models.ModelVersion, error)
+	// TransitionStage moves models.ModelVersion entity to the requested stage.
+	// If `archiveExisting` is set, then other versions in the same stage will be moved to the archived stage.
+	TransitionStage(
+		ctx context.Context, modelVersion *models.ModelVersion, stage models.ModelVersionStage, archiveExisting bool,
+	) error
+	// SetTag creates or updates models.ModelVersionTag entity.
+	SetTag(ctx context.Context, tag *models.ModelVersionTag) error
+	// DeleteTag removes existing models.ModelVersionTag entity.
+	DeleteTag(ctx context.Context, tag *models.ModelVersionTag) error
+}
+
+// ModelVersionRepository repository to work with models.ModelVersion entity.
+type ModelVersionRepository struct {
+	repositories.BaseRepositoryProvider
+}
+
+// NewModelVersionRepository creates repository to work with models.ModelVersion entity.
+func NewModelVersionRepository(db *gorm.DB) *ModelVersionRepository {
+	return &ModelVersionRepository{
+		repositories.NewBaseRepository(db),
+	}
+}
+
+// Create creates new models.ModelVersion entity with the next available version number.
+func (r ModelVersionRepository) Create(ctx context.Context, modelVersion *models.ModelVersion) error {
+	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// lock parent registered model, so concurrent requests won't get the same version number.
+		query := tx.Model(&models.RegisteredModel{}).Where("id = ?", modelVersion.RegisteredModelID)
+		if tx.Dialector.Name() == "postgres" {
+			query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+		}
+		if err := query.Update("updated_at", time.Now()).Error; err != nil {
+			return eris.Wrap(err, "error locking registered model")
+		}
+
+		var maxVersion sql.NullInt64
+		if err := tx.Model(
+			&models.ModelVersion{},
+		).Where(
+			"registered_model_id = ?", modelVersion.RegisteredModelID,
+		).Pluck("MAX(version)", &maxVersion).Error; err != nil {
+			return eris.Wrap(err, "error getting max model version")
+		}
+		modelVersion.Version = maxVersion.Int64 + 1
+
+		return tx.Omit("RegisteredModel").Create(modelVersion).Error
+	}); err != nil {
+		return eris.Wrapf(
+			err, "error creating model version for registered model with id: %s", modelVersion.RegisteredModelID,
+		)
+	}
+	return nil
+}
+
+// Update updates existing models.ModelVersion entity.
+func (r ModelVersionRepository) Update(ctx context.Context, modelVersion *models.ModelVersion) error {
+	if err := r.GetDB().WithContext(ctx).Model(
+		modelVersion,
+	).Omit(
+		clause.Associations,
+	).Select(
+		"Description", "CurrentStage", "Status", "StatusMessage", "UpdatedAt",
+	).Updates(modelVersion).Error; err != nil {
+		return eris.Wrapf(err, "error updating model version with id: %s", modelVersion.ID)
+	}
+	return nil
+}
+
+// Delete removes existing models.ModelVersion entity.
+func (r ModelVersionRepository) Delete(ctx context.Context, modelVersion *models.ModelVersion) error {
+	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// aliases, which point to the deleted version, have to be removed too.
+		if err := tx.Where(
+			"registered_model_id = ? AND version = ?", modelVersion.RegisteredModelID, modelVersion.Version,
+		).Delete(&models.RegisteredModelAlias{}).Error; err != nil {
+			return eris.Wrap(err, "error deleting model version aliases")
+		}
+		return tx.Select("Tags").Delete(modelVersion).Error
+	}); err != nil {
+		return eris.Wrapf(err, "error deleting model version with id: %s", modelVersion.ID)
+	}
+	return nil
+}
+
+// GetByRegisteredModelIDAndVersion returns models.ModelVersion entity by Registered Model ID and version.
+func (r ModelVersionRepository) GetByRegisteredModelIDAndVersion(
+	ctx context.Context, registeredModelID uuid.UUID, version int64,
+) (*models.ModelVersion, error) {
+	var modelVersion models.ModelVersion
+	if err := r.GetDB().WithContext(ctx).Preload(
+		"Tags",
+	).Preload(
+		"RegisteredModel.Aliases",
+	).Where(
+		"registered_model_id = ?", registeredModelID,
+	).Where(
+		"version = ?", version,
+	).First(&modelVersion).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, eris.Wrapf(
+			err, "error getting model version %d for registered model with id: %s", version, registeredModelID,
+		)
+	}
+	return &modelVersion, nil
+}
+
+// Search returns the list of models.ModelVersion entities which satisfy provided conditions.
+func (r ModelVersionRepository) Search(
+	ctx context.Context,
+	namespaceID uint,
+	filters []ModelSearchFilter,
+	order []ModelSearchOrder,
+	limit, offset int,
+) ([]models.ModelVersion, error) {
+	query := r.GetDB().WithContext(ctx).Model(
+		&models.ModelVersion{},
+	).Joins(
+		"INNER JOIN registered_models ON registered_models.id = model_versions.registered_model_id",
+	).Where(
+		"registered_models.namespace_id = ?", namespaceID,
+	)
+	applyModelSearchFilters(query, "model_versions", "model_version_tags", "model_version_id", filters)
+	applyModelSearchOrder(query, "model_versions", order)
+
+	var modelVersions []models.ModelVersion
+	if err := query.Preload(
+		"Tags",
+	).Preload(
+		"RegisteredModel.Aliases",
+	).Limit(
+		limit,
+	).Offset(
+		offset,
+	).Find(&modelVersions).Error; err != nil {
+		return nil, eris.Wrap(err, "error searching model versions")
+	}
+	return modelVersions, nil
+}
+
+// TransitionStage moves models.ModelVersion entity to the requested stage.
+// If `archiveExisting` is set, then other versions in the same stage will be moved to the archived stage.
+func (r ModelVersionRepository) TransitionStage(
+	ctx context.Context, modelVersion *models.ModelVersion, stage models.ModelVersionStage, archiveExisting bool,
+) error {
+	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if archiveExisting &&
+			(stage == models.ModelVersionStageStaging || stage == models.ModelVersionStageProduction) {
+			if err := tx.Model(
+				&models.ModelVersion{},
+			).Where(
+				"registered_model_id = ?", modelVersion.RegisteredModelID,
+			).Where(
+				"current_stage = ?", stage,
+			).Where(
+				"id != ?", modelVersion.ID,
+			).Updates(map[string]any{
+				"current_stage": models.ModelVersionStageArchived,
+				"updated_at":    now,
+			}).Error; err != nil {
+				return eris.Wrap(err, "error archiving existing model versions")
+			}
+		}
+
+		modelVersion.CurrentStage = stage
+		modelVersion.UpdatedAt = now
+		if err := tx.Model(
+			modelVersion,
+		).Omit(
+			clause.Associations,
+		).Select(
+			"CurrentStage", "UpdatedAt",
+		).Updates(modelVersion).Error; err != nil {
+			return eris.Wrap(err, "error updating model version stage")
+		}
+		return tx.Model(
+			&models.RegisteredModel{},
+		).Where(
+			"id = ?", modelVersion.RegisteredModelID,
+		).Update("updated_at", now).Error
+	}); err != nil {
+		return eris.Wrapf(err, "error transitioning model version with id: %s to stage: %s", modelVersion.ID, stage)
+	}
+	return nil
+}
+
+// SetTag creates or updates models.ModelVersionTag entity.
+func (r ModelVersionRepository) SetTag(ctx context.Context, tag *models.ModelVersionTag) error {
+	if err := r.GetDB().WithContext(ctx).Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(tag).Error; err != nil {
+		return eris.Wrapf(err, "error setting tag for model version with id: %s", tag.ModelVersionID)
+	}
+	return nil
+}
+
+// DeleteTag removes existing models.ModelVersionTag entity.
+func (r ModelVersionRepository) DeleteTag(ctx context.Context, tag *models.ModelVersionTag) error {
+	if err := r.GetDB().WithContext(ctx).Delete(tag).Error; err != nil {
+		return eris.Wrapf(err, "error deleting tag '%s' for model version with id: %s", tag.Key, tag.ModelVersionID)
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/metricstore"
 )
 
 // NamespaceRepositoryProvider provides an interface to work with `namespace` entity.
@@ -33,15 +34,24 @@ type NamespaceRepositoryProvider interface {
 // NamespaceRepository repository to work with `namespace` entity.
 type NamespaceRepository struct {
 	repositories.BaseRepositoryProvider
+	// metricStore is the store of the metric history. Metrics are kept by the database when it is not set.
+	metricStore metricstore.Store
 }
 
 // NewNamespaceRepository creates repository to work with `namespace` entity.
 func NewNamespaceRepository(db *gorm.DB) *NamespaceRepository {
 	return &NamespaceRepository{
-		repositories.NewBaseRepository(db),
+		BaseRepositoryProvider: repositories.NewBaseRepository(db),
 	}
 }
 
+// WithMetricStore sets the store of the metric history, so the metrics of the runs are removed
+// from the store together with the namespace.
+func (r *NamespaceRepository) WithMetricStore(metricStore metricstore.Store) *NamespaceRepository {
+	r.metricStore = metricStore
+	return r
+}
+
 // Create creates new models.Namespace entity.
 func (r NamespaceRepository) Create(ctx context.Context, namespace *models.Namespace) error {
 	if err := r.GetDB().WithContext(ctx).Create(namespace).Error; err != nil {
@@ -60,9 +70,31 @@ func (r NamespaceRepository) Update(ctx context.Context, namespace *models.Names
 
 // Delete removes a namespace and it's associated experiments by its ID.
 func (r NamespaceRepository) Delete(ctx context.Context, namespace *models.Namespace) error {
+	// finding all the runs of the namespace, including the deleted ones, as they are removed by cascade too.
+	var runIDs []string
+	if r.metricStore != nil {
+		if err := r.GetDB().WithContext(ctx).Unscoped().Model(
+			&models.Run{},
+		).Joins(
+			"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id",
+		).Where(
+			"experiments.namespace_id = ?", namespace.ID,
+		).Pluck(
+			"runs.run_uuid", &runIDs,
+		).Error; err != nil {
+			return eris.Wrapf(err, "error getting runs of namespace with id: %d", namespace.ID)
+		}
+	}
+
 	if err := r.GetDB().WithContext(ctx).Delete(namespace).Error; err != nil {
 		return eris.Wrap(err, "error deleting namespace entity")
 	}
+
+	if len(runIDs) > 0 {
+		if err := r.metricStore.Delete(ctx, runIDs); err != nil {
+			return eris.Wrapf(err, "error deleting metrics of namespace with id: %d from metric store", namespace.ID)
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,271 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// ModelSearchFilter represents a single filter condition used to search
+// models.RegisteredModel and models.ModelVersion entities.
+type ModelSearchFilter struct {
+	// Column is the name of the attribute column. Ignored when TagKey is provided.
+	Column string
+	// TagKey is the key of the tag to filter by.
+	TagKey   string
+	Operator string
+	Value    any
+}
+
+// ModelSearchOrder represents a single order condition used to search
+// models.RegisteredModel and models.ModelVersion entities.
+type ModelSearchOrder struct {
+	Column string
+	Desc   bool
+}
+
+// RegisteredModelRepositoryProvider provides an interface to work with models.RegisteredModel entity.
+type RegisteredModelRepositoryProvider interface {
+	repositories.BaseRepositoryProvider
+	// Create creates new models.RegisteredModel entity.
+	Create(ctx context.Context, registeredModel *models.RegisteredModel) error
+	// Update updates existing models.RegisteredModel entity.
+	Update(ctx context.Context, registeredModel *models.RegisteredModel) error
+	// Delete removes existing models.RegisteredModel entity and all its versions.
+	Delete(ctx context.Context, registeredModel *models.RegisteredModel) error
+	// GetByNamespaceIDAndName returns models.RegisteredModel entity by Namespace ID and its name.
+	GetByNamespaceIDAndName(ctx context.Context, namespaceID uint, name string) (*models.RegisteredModel, error)
+	// Search returns the list of models.RegisteredModel entities which satisfy provided conditions.
+	Search(
+		ctx context.Context,
+		namespaceID uint,
+		filters []ModelSearchFilter,
+		order []ModelSearchOrder,
+		limit, offset int,
+	) ([]models.RegisteredModel, error)
+	// SetTag creates or updates models.RegisteredModelTag entity.
+	SetTag(ctx context.Context, tag *models.RegisteredModelTag) error
+	// DeleteTag removes existing models.RegisteredModelTag entity.
+	DeleteTag(ctx context.Context, tag *models.RegisteredModelTag) error
+	// SetAlias creates or updates models.RegisteredModelAlias entity.
+	SetAlias(ctx context.Context, alias *models.RegisteredModelAlias) error
+	// DeleteAlias removes existing models.RegisteredModelAlias entity.
+	DeleteAlias(ctx context.Context, alias *models.RegisteredModelAlias) error
+}
+
+// RegisteredModelRepository repository to work with models.RegisteredModel entity.
+type RegisteredModelRepository struct {
+	repositories.BaseRepositoryProvider
+}
+
+// NewRegisteredModelRepository creates repository to work with models.RegisteredModel entity.
+func NewRegisteredModelRepository(db *gorm.DB) *RegisteredModelRepository {
+	return &RegisteredModelRepository{
+		repositories.NewBaseRepository(db),
+	}
+}
+
+// Create creates new models.RegisteredModel entity.
+func (r RegisteredModelRepository) Create(ctx context.Context, registeredModel *models.RegisteredModel) error {
+	if err := r.GetDB().WithContext(ctx).Create(registeredModel).Error; err != nil {
+		return eris.Wrapf(err, "error creating registered model with name: %s", registeredModel.Name)
+	}
+	return nil
+}
+
+// Update updates existing models.RegisteredModel entity.
+func (r RegisteredModelRepository) Update(ctx context.Context, registeredModel *models.RegisteredModel) error {
+	if err := r.GetDB().WithContext(ctx).Model(
+		registeredModel,
+	).Omit(
+		clause.Associations,
+	).Select(
+		"Name", "Description", "UpdatedAt",
+	).Updates(registeredModel).Error; err != nil {
+		return eris.Wrapf(err, "error updating registered model with id: %s", registeredModel.ID)
+	}
+	return nil
+}
+
+// Delete removes existing models.RegisteredModel entity and all its versions.
+func (r RegisteredModelRepository) Delete(ctx context.Context, registeredModel *models.RegisteredModel) error {
+	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(
+			"model_version_id IN (?)",
+			tx.Model(
+				&models.ModelVersion{},
+			).Select(
+				"id",
+			).Where(
+				"registered_model_id = ?", registeredModel.ID,
+			),
+		).Delete(&models.ModelVersionTag{}).Error; err != nil {
+			return eris.Wrap(err, "error deleting model version tags")
+		}
+		return tx.Select("Tags", "Aliases", "Versions").Delete(registeredModel).Error
+	}); err != nil {
+		return eris.Wrapf(err, "error deleting registered model with id: %s", registeredModel.ID)
+	}
+	return nil
+}
+
+// GetByNamespaceIDAndName returns models.RegisteredModel entity by Namespace ID and its name.
+func (r RegisteredModelRepository) GetByNamespaceIDAndName(
+	ctx context.Context, namespaceID uint, name string,
+) (*models.RegisteredModel, error) {
+	var registeredModel models.RegisteredModel
+	if err := r.GetDB().WithContext(ctx).Preload(
+		"Tags",
+	).Preload(
+		"Aliases",
+	).Preload(
+		"Versions.Tags",
+	).Where(
+		"registered_models.namespace_id = ?", namespaceID,
+	).Where(
+		"registered_models.name = ?", name,
+	).First(&registeredModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, eris.Wrapf(err, "error getting registered model by name: %s", name)
+	}
+	return &registeredModel, nil
+}
+
+// Search returns the list of models.RegisteredModel entities which satisfy provided conditions.
+func (r RegisteredModelRepository) Search(
+	ctx context.Context,
+	namespaceID uint,
+	filters []ModelSearchFilter,
+	order []ModelSearchOrder,
+	limit, offset int,
+) ([]models.RegisteredModel, error) {
+	query := r.GetDB().WithContext(ctx).Model(
+		&models.RegisteredModel{},
+	).Where(
+		"registered_models.namespace_id = ?", namespaceID,
+	)
+	applyModelSearchFilters(
+		query, "registered_models", "registered_model_tags", "registered_model_id", filters,
+	)
+	applyModelSearchOrder(query, "registered_models", order)
+
+	var registeredModels []models.RegisteredModel
+	if err := query.Preload(
+		"Tags",
+	).Preload(
+		"Aliases",
+	).Preload(
+		"Versions.Tags",
+	).Limit(
+		limit,
+	).Offset(
+		offset,
+	).Find(&registeredModels).Error; err != nil {
+		return nil, eris.Wrap(err, "error searching registered models")
+	}
+	return registeredModels, nil
+}
+
+// SetTag creates or updates models.RegisteredModelTag entity.
+func (r RegisteredModelRepository) SetTag(ctx context.Context, tag *models.RegisteredModelTag) error {
+	if err := r.GetDB().WithContext(ctx).Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(tag).Error; err != nil {
+		return eris.Wrapf(err, "error setting tag for registered model with id: %s", tag.RegisteredModelID)
+	}
+	return nil
+}
+
+// DeleteTag removes existing models.RegisteredModelTag entity.
+func (r RegisteredModelRepository) DeleteTag(ctx context.Context, tag *models.RegisteredModelTag) error {
+	if err := r.GetDB().WithContext(ctx).Delete(tag).Error; err != nil {
+		return eris.Wrapf(
+			err, "error deleting tag '%s' for registered model with id: %s", tag.Key, tag.RegisteredModelID,
+		)
+	}
+	return nil
+}
+
+// SetAlias creates or updates models.RegisteredModelAlias entity.
+func (r RegisteredModelRepository) SetAlias(ctx context.Context, alias *models.RegisteredModelAlias) error {
+	if err := r.GetDB().WithContext(ctx).Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(alias).Error; err != nil {
+		return eris.Wrapf(err, "error setting alias for registered model with id: %s", alias.RegisteredModelID)
+	}
+	return nil
+}
+
+// DeleteAlias removes existing models.RegisteredModelAlias entity.
+func (r RegisteredModelRepository) DeleteAlias(ctx context.Context, alias *models.RegisteredModelAlias) error {
+	if err := r.GetDB().WithContext(ctx).Delete(alias).Error; err != nil {
+		return eris.Wrapf(
+			err, "error deleting alias '%s' for registered model with id: %s", alias.Alias, alias.RegisteredModelID,
+		)
+	}
+	return nil
+}
+
+// applyModelSearchFilters applies search filters to the query. Attribute filters are applied to the `table`,
+// tag filters are joined from `tagTable` using `tagColumn` as a reference to the `table` primary key.
+func applyModelSearchFilters(
+	query *gorm.DB, table, tagTable, tagColumn string, filters []ModelSearchFilter,
+) {
+	isSQLite := query.Dialector.Name() == "sqlite"
+	for n, filter := range filters {
+		operator, value := filter.Operator, filter.Value
+		if filter.TagKey == "" {
+			column := filter.Column
+			if !strings.Contains(column, ".") {
+				column = fmt.Sprintf("%s.%s", table, column)
+			}
+			if isSQLite && strings.ToUpper(operator) == "ILIKE" {
+				column, operator, value = fmt.Sprintf("LOWER(%s)", column), "LIKE", strings.ToLower(fmt.Sprint(value))
+			}
+			query.Where(fmt.Sprintf("%s %s ?", column, operator), value)
+			continue
+		}
+
+		where := fmt.Sprintf("value %s ?", operator)
+		if isSQLite && strings.ToUpper(operator) == "ILIKE" {
+			where, value = "LOWER(value) LIKE ?", strings.ToLower(fmt.Sprint(value))
+		}
+		alias := fmt.Sprintf("filter_%d", n)
+		query.Joins(
+			fmt.Sprintf("JOIN (?) AS %s ON %s.id = %s.%s", alias, table, alias, tagColumn),
+			query.Session(&gorm.Session{NewDB: true}).Table(
+				tagTable,
+			).Select(
+				tagColumn,
+			).Where(
+				"key = ?", filter.TagKey,
+			).Where(
+				where, value,
+			),
+		)
+	}
+}
+
+// applyModelSearchOrder applies order conditions to the query.
+func applyModelSearchOrder(query *gorm.DB, table string, order []ModelSearchOrder) {
+	for _, o := range order {
+		column := o.Column
+		if !strings.Contains(column, ".") {
+			column = fmt.Sprintf("%s.%s", table, column)
+		}
+		query.Order(clause.OrderByColumn{
+			Column: clause.Column{Name: column},
+			Desc:   o.Desc,
+		})
+	}
+}
@@ -34,13 +34,13 @@ type RunRepositoryProvider interface {
 	Update(ctx context.Context, run *models.Run) error
 	// Archive marks existing models.Run entity as archived.
 	Archive(ctx context.Context, run *models.Run) error
-	// Delete removes the existing models.Run
+	// Delete marks the existing models.Run as deleted and queues it to be purged.
 	Delete(ctx context.Context, namespaceID uint, run *models.Run) error
 	// Restore marks existing models.Run entity as active.
 	Restore(ctx context.Context, run *models.Run) error
 	// ArchiveBatch marks existing models.Run entities as archived.
 	ArchiveBatch(ctx context.Context, namespaceID uint, ids []string) error
-	// DeleteBatch removes the existing models.Run from the db.
+	// DeleteBatch marks existing models.Run entities as deleted and queues them to be purged.
 	DeleteBatch(ctx context.Context, namespaceID uint, ids []string) error
 	// RestoreBatch marks existing models.Run entities as active.
 	RestoreBatch(ctx context.Context, namespaceID uint, ids []string) error
@@ -58,7 +58,7 @@ type RunRepository struct {
 // NewRunRepository creates repository to work with models.Run entity.
 func NewRunRepository(db *gorm.DB) *RunRepository {
 	return &RunRepository{
-		repositories.NewBaseRepository(db),
+		BaseRepositoryProvider: repositories.NewBaseRepository(db),
 	}
 }
 
@@ -113,6 +113,10 @@ func (r RunRepository) GetByNamespaceIDAndRunID(
 		"Params",
 	).Preload(
 		"Tags",
+	).Preload(
+		"Inputs.Tags",
+	).Preload(
+		"Inputs.Dataset",
 	).Joins(
 		"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
 		namespaceID,
@@ -194,50 +198,55 @@ func (r RunRepository) ArchiveBatch(ctx context.Context, namespaceID uint, ids [
 	return nil
 }
 
-// Delete removes the existing models.Run from the db.
+// Delete marks the existing models.Run as deleted and queues it to be purged.
 func (r RunRepository) Delete(ctx context.Context, namespaceID uint, run *models.Run) error {
 	return r.DeleteBatch(ctx, namespaceID, []string{run.ID})
 }
 
-// DeleteBatch removes existing models.Run from the db.
+// DeleteBatch marks existing models.Run entities as deleted and queues them to be purged. Deleted runs are hidden
+// from all the queries, their metrics, artifacts and the runs themselves are removed by the purge worker.
 func (r RunRepository) DeleteBatch(ctx context.Context, namespaceID uint, ids []string) error {
-	if err := r.GetDB().Transaction(func(tx *gorm.DB) error {
-		runs := make([]models.Run, 0, len(ids))
-		if err := tx.Clauses(
-			clause.Returning{Columns: []clause.Column{{Name: "row_num"}}},
+	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var runIDs []string
+		if err := tx.Model(
+			&models.Run{},
+		).Joins(
+			"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
+			namespaceID,
 		).Where(
-			"run_uuid IN (?)",
-			r.GetDB().Model(
-				models.Run{},
-			).Select(
-				"run_uuid",
-			).Joins(
-				"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
-				namespaceID,
-			).Where(
-				"run_uuid IN (?)", ids,
-			),
-		).Delete(
-			&runs,
+			"runs.run_uuid IN (?)", ids,
+		).Pluck(
+			"runs.run_uuid", &runIDs,
 		).Error; err != nil {
-			return eris.Wrapf(err, "error deleting existing runs with ids: %s", ids)
+			return eris.Wrapf(err, "error getting existing runs with ids: %s", ids)
 		}
 
 		// verify deletion
-		// NOTE: tx.RowsAffected does not provide correct number of deleted, using the returning slice instead
-		if len(runs) != len(ids) {
+		if len(runIDs) != len(ids) {
 			return eris.New("count of deleted runs does not match length of ids input (invalid run ID?)")
 		}
 
-		// renumber the remainder
-		if err := r.renumberRows(tx, getMinRowNum(runs)); err != nil {
-			return eris.Wrapf(err, "error renumbering runs.row_num")
+		if err := tx.Where("run_uuid IN (?)", runIDs).Delete(&models.Run{}).Error; err != nil {
+			return eris.Wrapf(err, "error deleting existing runs with ids: %s", ids)
+		}
+
+		now := time.Now().UTC()
+		purges := make([]models.RunPurge, len(runIDs))
+		for i, runID := range runIDs {
+			purges[i] = models.RunPurge{
+				RunID:       runID,
+				NamespaceID: namespaceID,
+				Status:      models.RunPurgeStatusPending,
+				RequestedAt: now,
+			}
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&purges).Error; err != nil {
+			return eris.Wrapf(err, "error creating purges of runs with ids: %s", ids)
 		}
 		return nil
 	}); err != nil {
 		return eris.Wrapf(err, "error deleting runs")
 	}
-
 	return nil
 }
 
@@ -318,18 +327,6 @@ func (r RunRepository) SetRunTagsBatch(ctx context.Context, run *models.Run, bat
 	return nil
 }
 
-// getMinRowNum will find the lowest row_num for the slice of runs
-// or 0 for an empty slice
-func getMinRowNum(runs []models.Run) models.RowNum {
-	var minRowNum models.RowNum
-	for _, run := range runs {
-		if minRowNum == models.RowNum(0) || run.RowNum < minRowNum {
-			minRowNum = run.RowNum
-		}
-	}
-	return minRowNum
-}
-
 // renumberRows will update the runs.row_num field with the correct ordinal
 func (r RunRepository) renumberRows(tx *gorm.DB, startWith models.RowNum) error {
 	if startWith < models.RowNum(0) {
@@ -0,0 +1,263 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/metricstore"
+)
+
+// RunPurgeRepositoryProvider provides an interface to work with models.RunPurge entity.
+type RunPurgeRepositoryProvider interface {
+	repositories.BaseRepositoryProvider
+	// List returns all the purges ordered by the time of the run deletion.
+	List(ctx context.Context) ([]models.RunPurge, error)
+	// GetRun returns the deleted run of the purge together with its experiment
+	// or nil when the run doesn't exist anymore.
+	GetRun(ctx context.Context, purge *models.RunPurge) (*models.Run, error)
+	// DeleteMetrics removes the metrics of the deleted run by chunks and keeps the number of removed metrics.
+	DeleteMetrics(ctx context.Context, purge *models.RunPurge, chunkSize int) error
+	// Complete removes the deleted run together with the rest of its data and the purge itself.
+	Complete(ctx context.Context, purge *models.RunPurge) error
+	// Fail keeps the error of the purge, so the purge is retried later.
+	Fail(ctx context.Context, purge *models.RunPurge, purgeErr error) error
+	// CreateForDeletedRuns marks the runs in `deleted` lifecycle stage as deleted and queues them to be purged.
+	CreateForDeletedRuns(ctx context.Context, olderThan time.Time, runIDs []string) (int64, error)
+}
+
+// RunPurgeRepository repository to work with models.RunPurge entity.
+type RunPurgeRepository struct {
+	repositories.BaseRepositoryProvider
+	// metricStore is the store of the metric history. Metrics are kept by the database when it is not set.
+	metricStore metricstore.Store
+}
+
+// NewRunPurgeRepository creates repository to work with models.RunPurge entity.
+func NewRunPurgeRepository(db *gorm.DB) *RunPurgeRepository {
+	return &RunPurgeRepository{
+		BaseRepositoryProvider: repositories.NewBaseRepository(db),
+	}
+}
+
+// WithMetricStore sets the store of the metric history.
+func (r *RunPurgeRepository) WithMetricStore(metricStore metricstore.Store) *RunPurgeRepository {
+	r.metricStore = metricStore
+	return r
+}
+
+// List returns all the purges ordered by the time of the run deletion.
+func (r RunPurgeRepository) List(ctx context.Context) ([]models.RunPurge, error) {
+	var purges []models.RunPurge
+	if err := r.GetDB().WithContext(ctx).Preload(
+		"Namespace",
+	).Order(
+		"requested_at",
+	).Order(
+		"run_uuid",
+	).Find(&purges).Error; err != nil {
+		return nil, eris.Wrap(err, "error getting run purges")
+	}
+	return purges, nil
+}
+
+// GetRun returns the deleted run of the purge together with its experiment
+// or nil when the run doesn't exist anymore.
+func (r RunPurgeRepository) GetRun(ctx context.Context, purge *models.RunPurge) (*models.Run, error) {
+	var runs []models.Run
+	if err := r.GetDB().WithContext(ctx).Unscoped().Preload(
+		"Experiment",
+	).Where(
+		"run_uuid = ?", purge.RunID,
+	).Limit(1).Find(&runs).Error; err != nil {
+		return nil, eris.Wrapf(err, "error getting deleted run with id: %s", purge.RunID)
+	}
+	if len(runs) == 0 {
+		return nil, nil
+	}
+	return &runs[0], nil
+}
+
+// DeleteMetrics removes the metrics of the deleted run by chunks and keeps the number of removed metrics.
+// Every chunk removes at most chunkSize iterations of one metric, so the transactions stay short
+// and the progress is visible while the run is purged.
+func (r RunPurgeRepository) DeleteMetrics(ctx context.Context, purge *models.RunPurge, chunkSize int) error {
+	var series []models.LatestMetric
+	if err := r.GetDB().WithContext(ctx).Select(
+		"key", "context_id", "last_iter",
+	).Where(
+		"run_uuid = ?", purge.RunID,
+	).Find(&series).Error; err != nil {
+		return eris.Wrapf(err, "error getting metric series of run with id: %s", purge.RunID)
+	}
+
+	for _, s := range series {
+		for upper := int64(chunkSize); ; upper += int64(chunkSize) {
+			result := r.GetDB().WithContext(ctx).Where(
+				"run_uuid = ? AND key = ? AND context_id = ? AND iter < ?", purge.RunID, s.Key, s.ContextID, upper,
+			).Delete(&models.Metric{})
+			if err := result.Error; err != nil {
+				return eris.Wrapf(err, "error deleting metrics of run with id: %s", purge.RunID)
+			}
+			if err := r.addDeletedMetrics(ctx, purge, result.RowsAffected); err != nil {
+				return err
+			}
+			if upper > s.LastIter {
+				break
+			}
+		}
+	}
+
+	// metrics without the latest metric are not expected, but they are removed anyway.
+	result := r.GetDB().WithContext(ctx).Where("run_uuid = ?", purge.RunID).Delete(&models.Metric{})
+	if err := result.Error; err != nil {
+		return eris.Wrapf(err, "error deleting metrics of run with id: %s", purge.RunID)
+	}
+	if err := r.addDeletedMetrics(ctx, purge, result.RowsAffected); err != nil {
+		return err
+	}
+
+	if r.metricStore != nil {
+		if err := r.metricStore.Delete(ctx, []string{purge.RunID}); err != nil {
+			return eris.Wrapf(err, "error deleting metrics of run with id: %s from metric store", purge.RunID)
+		}
+	}
+	return nil
+}
+
+// addDeletedMetrics adds the number of removed metrics to the progress of the purge.
+func (r RunPurgeRepository) addDeletedMetrics(ctx context.Context, purge *models.RunPurge, count int64) error {
+	if count == 0 {
+		return nil
+	}
+	purge.DeletedMetrics += count
+	if err := r.GetDB().WithContext(ctx).Model(purge).Update(
+		"deleted_metrics", purge.DeletedMetrics,
+	).Error; err != nil {
+		return eris.Wrapf(err, "error updating purge of run with id: %s", purge.RunID)
+	}
+	return nil
+}
+
+// Complete removes the deleted run together with the rest of its data and the purge itself.
+func (r RunPurgeRepository) Complete(ctx context.Context, purge *models.RunPurge) error {
+	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("run_uuid = ?", purge.RunID).Delete(&models.Log{}).Error; err != nil {
+			return eris.Wrapf(err, "error deleting logs of run with id: %s", purge.RunID)
+		}
+		if err := deleteRunInputs(tx, []string{purge.RunID}); err != nil {
+			return eris.Wrapf(err, "error deleting inputs of run with id: %s", purge.RunID)
+		}
+
+		var runs []models.Run
+		if err := tx.Unscoped().Clauses(
+			clause.Returning{Columns: []clause.Column{{Name: "row_num"}}},
+		).Where(
+			"run_uuid = ?", purge.RunID,
+		).Delete(&runs).Error; err != nil {
+			return eris.Wrapf(err, "error deleting run with id: %s", purge.RunID)
+		}
+
+		// the run could be already removed together with its experiment.
+		if len(runs) > 0 {
+			if err := NewRunRepository(tx).renumberRows(tx, runs[0].RowNum); err != nil {
+				return eris.Wrapf(err, "error renumbering runs.row_num")
+			}
+		}
+
+		if err := tx.Delete(purge).Error; err != nil {
+			return eris.Wrapf(err, "error deleting purge of run with id: %s", purge.RunID)
+		}
+		return nil
+	}); err != nil {
+		return eris.Wrapf(err, "error completing purge of run with id: %s", purge.RunID)
+	}
+	return nil
+}
+
+// Fail keeps the error of the purge, so the purge is retried later.
+func (r RunPurgeRepository) Fail(ctx context.Context, purge *models.RunPurge, purgeErr error) error {
+	purge.Status = models.RunPurgeStatusFailed
+	purge.Attempts++
+	purge.Error = purgeErr.Error()
+	if len(purge.Error) > models.RunPurgeErrorMaxLength {
+		purge.Error = purge.Error[:models.RunPurgeErrorMaxLength]
+	}
+	if err := r.GetDB().WithContext(ctx).Model(purge).Updates(map[string]any{
+		"status":   purge.Status,
+		"attempts": purge.Attempts,
+		"error":    purge.Error,
+	}).Error; err != nil {
+		return eris.Wrapf(err, "error updating purge of run with id: %s", purge.RunID)
+	}
+	return nil
+}
+
+// CreateForDeletedRuns marks the runs in `deleted` lifecycle stage as deleted and queues them to be purged.
+// Only the runs deleted before olderThan are queued when it is not zero, and only the requested runs
+// when runIDs is not empty.
+func (r RunPurgeRepository) CreateForDeletedRuns(
+	ctx context.Context, olderThan time.Time, runIDs []string,
+) (int64, error) {
+	var count int64
+	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(
+			&models.Run{},
+		).Select(
+			"runs.run_uuid", "experiments.namespace_id",
+		).Joins(
+			"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id",
+		).Where(
+			"runs.lifecycle_stage = ?", models.LifecycleStageDeleted,
+		)
+		if !olderThan.IsZero() {
+			query = query.Where("runs.deleted_time <= ?", olderThan.UnixMilli())
+		}
+		if len(runIDs) > 0 {
+			query = query.Where("runs.run_uuid IN ?", runIDs)
+		}
+
+		var runs []struct {
+			RunID       string `gorm:"column:run_uuid"`
+			NamespaceID uint
+		}
+		if err := query.Scan(&runs).Error; err != nil {
+			return eris.Wrap(err, "error getting deleted runs")
+		}
+		if len(runIDs) > 0 && len(runs) != len(runIDs) {
+			return eris.New("count of deleted runs does not match length of ids input (run not in deleted lifecycle stage?)")
+		}
+		if len(runs) == 0 {
+			return nil
+		}
+
+		now := time.Now().UTC()
+		ids := make([]string, len(runs))
+		purges := make([]models.RunPurge, len(runs))
+		for i, run := range runs {
+			ids[i] = run.RunID
+			purges[i] = models.RunPurge{
+				RunID:       run.RunID,
+				NamespaceID: run.NamespaceID,
+				Status:      models.RunPurgeStatusPending,
+				RequestedAt: now,
+			}
+		}
+		if err := tx.Where("run_uuid IN ?", ids).Delete(&models.Run{}).Error; err != nil {
+			return eris.Wrap(err, "error deleting runs")
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&purges).Error; err != nil {
+			return eris.Wrap(err, "error creating purges of runs")
+		}
+		count = int64(len(runs))
+		return nil
+	}); err != nil {
+		return 0, eris.Wrap(err, "error queueing deleted runs to be purged")
+	}
+	return count, nil
+}
@@ -0,0 +1,213 @@
+package filter
+
+import (
+	"strings"
+)
+
+// supported comparison operators.
+const (
+	EqualOperator            = "="
+	NotEqualOperator         = "!="
+	LessOperator             = "<"
+	LessOrEqualOperator      = "<="
+	GreaterOperator          = ">"
+	GreaterOrEqualOperator   = ">="
+	LikeOperator             = "LIKE"
+	ILikeOperator            = "ILIKE"
+	InOperator               = "IN"
+	NotInOperator            = "NOT IN"
+	IsNullOperator           = "IS NULL"
+	IsNotNullOperator        = "IS NOT NULL"
+	andOperator              = "AND"
+	orOperator               = "OR"
+	notKeyword               = "NOT"
+	isKeyword                = "IS"
+	nullKeyword              = "NULL"
+	identifierEntitySplitter = "."
+)
+
+// reservedWords contains the words, which can't be used as unquoted names or values.
+var reservedWords = []string{
+	andOperator, orOperator, notKeyword, isKeyword, nullKeyword, LikeOperator, ILikeOperator, InOperator,
+}
+
+// Expression represents the node of the parsed filter.
+type Expression interface {
+	// String returns the canonical representation of the expression, which parses back into the same expression.
+	String() string
+	// Position returns the 1-based position of the expression in the filter.
+	Position() int
+}
+
+// And represents the operands joined with `AND` operator.
+type And struct {
+	Operands []Expression
+	Pos      int
+}
+
+// String returns the canonical representation of the expression.
+func (e *And) String() string {
+	operands := make([]string, len(e.Operands))
+	for i, operand := range e.Operands {
+		if _, ok := operand.(*Or); ok {
+			operands[i] = "(" + operand.String() + ")"
+		} else {
+			operands[i] = operand.String()
+		}
+	}
+	return strings.Join(operands, " "+andOperator+" ")
+}
+
+// Position returns the 1-based position of the expression in the filter.
+func (e *And) Position() int {
+	return e.Pos
+}
+
+// Or represents the operands joined with `OR` operator.
+type Or struct {
+	Operands []Expression
+	Pos      int
+	// OperatorPos is the position of the first `OR` operator.
+	OperatorPos int
+}
+
+// String returns the canonical representation of the expression.
+func (e *Or) String() string {
+	operands := make([]string, len(e.Operands))
+	for i, operand := range e.Operands {
+		operands[i] = operand.String()
+	}
+	return strings.Join(operands, " "+orOperator+" ")
+}
+
+// Position returns the 1-based position of the expression in the filter.
+func (e *Or) Position() int {
+	return e.Pos
+}
+
+// Comparison represents single `entity.key <operator> value` condition.
+type Comparison struct {
+	// Entity is the entity type, e.g. `metrics` or `tags`. It is empty, when the entity was omitted.
+	Entity string
+	// Key is the unquoted name of the attribute, metric, param or tag.
+	Key string
+	// Operator is one of the supported comparison operators in upper case.
+	Operator string
+	// Value is the compared value. It is nil for `IS NULL` and `IS NOT NULL` operators.
+	Value *Value
+	Pos   int
+}
+
+// String returns the canonical representation of the expression.
+func (e *Comparison) String() string {
+	var identifier string
+	switch {
+	case e.Entity == "" && isPlainName(e.Key, false):
+		identifier = e.Key
+	case e.Entity == "":
+		identifier = quote(e.Key, '`')
+	case isPlainName(e.Key, true):
+		identifier = e.Entity + identifierEntitySplitter + e.Key
+	default:
+		identifier = e.Entity + identifierEntitySplitter + quote(e.Key, '`')
+	}
+	if e.Value == nil {
+		return identifier + " " + e.Operator
+	}
+	return identifier + " " + e.Operator + " " + e.Value.String()
+}
+
+// Position returns the 1-based position of the expression in the filter.
+func (e *Comparison) Position() int {
+	return e.Pos
+}
+
+// ValueKind represents the kind of the compared value.
+type ValueKind int
+
+// supported value kinds.
+const (
+	// StringValue is the quoted string, e.g. `'value'`.
+	StringValue ValueKind = iota
+	// NumberValue is the unquoted numeric literal, e.g. `1.5`.
+	NumberValue
+	// IdentifierValue is the unquoted word, which isn't a number, e.g. `value`.
+	IdentifierValue
+	// ListValue is the list of values in parentheses, e.g. `('a', 'b')`.
+	ListValue
+)
+
+// Value represents the value the key is compared with.
+type Value struct {
+	Kind ValueKind
+	// Text is the unquoted text of the scalar value.
+	Text string
+	// Items are the values of the list.
+	Items []*Value
+	// Raw is the text of the value as it appears in the filter.
+	Raw string
+	Pos int
+}
+
+// Strings returns the texts of the list items.
+func (v *Value) Strings() []string {
+	values := make([]string, len(v.Items))
+	for i, item := range v.Items {
+		values[i] = item.Text
+	}
+	return values
+}
+
+// String returns the canonical representation of the value.
+func (v *Value) String() string {
+	switch v.Kind {
+	case StringValue:
+		return quote(v.Text, '\'')
+	case ListValue:
+		items := make([]string, len(v.Items))
+		for i, item := range v.Items {
+			items[i] = item.String()
+		}
+		return "(" + strings.Join(items, ", ") + ")"
+	default:
+		return v.Text
+	}
+}
+
+// quote encloses the text into the quote character doubling the quote characters inside of it.
+func quote(text string, quote rune) string {
+	q := string(quote)
+	return q + strings.ReplaceAll(text, q, q+q) + q
+}
+
+// isPlainName checks that the name could be written without quotes.
+func isPlainName(name string, hasEntity bool) bool {
+	if name == "" || (!hasEntity && isReservedWord(name)) {
+		return false
+	}
+	parts := strings.Split(name, identifierEntitySplitter)
+	if !hasEntity && len(parts) > 1 {
+		return false
+	}
+	for _, part := range parts {
+		if part == "" {
+			return false
+		}
+		for _, r := range part {
+			if r == '.' || !isWordChar(r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isReservedWord checks that the word is one of the reserved words.
+func isReservedWord(word string) bool {
+	for _, reserved := range reservedWords {
+		if strings.EqualFold(word, reserved) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,213 @@
+package filter
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tokenKind represents the kind of the filter token.
+type tokenKind int
+
+// supported token kinds.
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenName
+	tokenOperator
+	tokenLeftParen
+	tokenRightParen
+	tokenComma
+)
+
+// token represents single lexeme of the filter.
+type token struct {
+	kind tokenKind
+	// text is the unquoted text of the token.
+	text string
+	// raw is the text of the token as it appears in the filter.
+	raw string
+	// offset is the byte offset of the first character of the token.
+	offset int
+	// pos is the 1-based position of the first character of the token.
+	pos int
+	// end is the 1-based position right after the last character of the token.
+	end int
+}
+
+// describe returns the token description suitable for the error message.
+func (t token) describe() string {
+	if t.kind == tokenEOF {
+		return "end of filter"
+	}
+	return "'" + t.raw + "'"
+}
+
+// keyword checks that token is the word matching the keyword, case-insensitively.
+func (t token) keyword(keyword string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+// lexer splits the filter into tokens.
+type lexer struct {
+	input string
+	// offset is the byte offset of the current character.
+	offset int
+	// pos is the 1-based position of the current character.
+	pos int
+}
+
+// newLexer creates new lexer instance.
+func newLexer(input string) *lexer {
+	return &lexer{input: input, pos: 1}
+}
+
+// peek returns the current character without consuming it.
+func (l *lexer) peek() rune {
+	if l.offset >= len(l.input) {
+		return utf8.RuneError
+	}
+	r, _ := utf8.DecodeRuneInString(l.input[l.offset:])
+	return r
+}
+
+// peekAt returns the n-th character after the current one without consuming anything.
+func (l *lexer) peekAt(n int) rune {
+	offset := l.offset
+	for ; n > 0 && offset < len(l.input); n-- {
+		_, size := utf8.DecodeRuneInString(l.input[offset:])
+		offset += size
+	}
+	if offset >= len(l.input) {
+		return utf8.RuneError
+	}
+	r, _ := utf8.DecodeRuneInString(l.input[offset:])
+	return r
+}
+
+// advance consumes the current character.
+func (l *lexer) advance() {
+	_, size := utf8.DecodeRuneInString(l.input[l.offset:])
+	l.offset += size
+	l.pos++
+}
+
+// next returns the next token of the filter.
+func (l *lexer) next() (token, error) {
+	for l.offset < len(l.input) && unicode.IsSpace(l.peek()) {
+		l.advance()
+	}
+	if l.offset >= len(l.input) {
+		return token{kind: tokenEOF, offset: l.offset, pos: l.pos, end: l.pos}, nil
+	}
+
+	start, pos := l.offset, l.pos
+	emit := func(kind tokenKind, text string) token {
+		return token{kind: kind, text: text, raw: l.input[start:l.offset], offset: start, pos: pos, end: l.pos}
+	}
+
+	switch r := l.peek(); {
+	case r == '(':
+		l.advance()
+		return emit(tokenLeftParen, "("), nil
+	case r == ')':
+		l.advance()
+		return emit(tokenRightParen, ")"), nil
+	case r == ',':
+		l.advance()
+		return emit(tokenComma, ","), nil
+	case r == '=':
+		l.advance()
+		return emit(tokenOperator, EqualOperator), nil
+	case r == '!':
+		l.advance()
+		if l.peek() != '=' {
+			return token{}, newSyntaxError(pos, "unexpected character '!'")
+		}
+		l.advance()
+		return emit(tokenOperator, NotEqualOperator), nil
+	case r == '<' || r == '>':
+		l.advance()
+		if l.peek() == '=' {
+			l.advance()
+		}
+		return emit(tokenOperator, l.input[start:l.offset]), nil
+	case r == '\'' || r == '"':
+		text, err := l.quoted(r, "string")
+		if err != nil {
+			return token{}, err
+		}
+		return emit(tokenString, text), nil
+	case r == '`':
+		text, err := l.quoted(r, "name")
+		if err != nil {
+			return token{}, err
+		}
+		return emit(tokenName, text), nil
+	case isWordChar(r) || (r == '-' && (unicode.IsDigit(l.peekAt(1)) || l.peekAt(1) == '.')):
+		l.advance()
+		for l.offset < len(l.input) {
+			r := l.peek()
+			if isWordChar(r) {
+				l.advance()
+				continue
+			}
+			// allow signed exponent of numeric literals, e.g. `1e-5`.
+			if (r == '-' || r == '+') && isNumericPrefix(l.input[start:l.offset]) && unicode.IsDigit(l.peekAt(1)) {
+				l.advance()
+				continue
+			}
+			break
+		}
+		return emit(tokenWord, l.input[start:l.offset]), nil
+	default:
+		return token{}, newSyntaxError(pos, "unexpected character '%c'", r)
+	}
+}
+
+// quoted consumes literal enclosed into the quote character. The quote character itself is escaped
+// by doubling it.
+func (l *lexer) quoted(quote rune, kind string) (string, error) {
+	pos := l.pos
+	l.advance()
+
+	var text strings.Builder
+	for l.offset < len(l.input) {
+		r, offset := l.peek(), l.offset
+		l.advance()
+		if r != quote {
+			text.WriteString(l.input[offset:l.offset])
+			continue
+		}
+		if l.peek() != quote {
+			return text.String(), nil
+		}
+		text.WriteRune(r)
+		l.advance()
+	}
+	return "", newSyntaxError(pos, "unterminated %s literal", kind)
+}
+
+// isWordChar checks that character could be a part of the unquoted word.
+func isWordChar(r rune) bool {
+	return r == '_' || r == '.' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// isNumericPrefix checks that word is the beginning of numeric literal with the exponent, e.g. `1e`.
+func isNumericPrefix(word string) bool {
+	if len(word) < 2 || (word[len(word)-1] != 'e' && word[len(word)-1] != 'E') {
+		return false
+	}
+	digits := false
+	for _, r := range strings.TrimPrefix(word[:len(word)-1], "-") {
+		switch {
+		case unicode.IsDigit(r):
+			digits = true
+		case r == '.':
+		default:
+			return false
+		}
+	}
+	return digits
+}
@@ -0,0 +1,339 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// numberLiteral matches unquoted decimal numeric literals.
+var numberLiteral = regexp.MustCompile(`^-?(?:\d+\.?\d*|\.\d+)(?:[eE][+-]?\d+)?$`)
+
+// SyntaxError represents the error, which happened during filter parsing.
+type SyntaxError struct {
+	// Pos is the 1-based position of the character in the filter, where error happened.
+	Pos     int
+	Message string
+}
+
+// Error returns the error message including the position.
+func (e SyntaxError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Message, e.Pos)
+}
+
+// newSyntaxError creates new SyntaxError instance.
+func newSyntaxError(pos int, format string, args ...any) *SyntaxError {
+	return &SyntaxError{Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+// Parse parses MLflow search filter into the expression tree.
+//
+// The grammar of the filter is:
+//
+//	filter     = or
+//	or         = and { "OR" and }
+//	and        = primary { "AND" primary }
+//	primary    = "(" or ")" | comparison
+//	comparison = identifier operator value | identifier "IS" [ "NOT" ] "NULL"
+//	identifier = [ entity "." ] key
+//	operator   = "=" | "!=" | "<" | "<=" | ">" | ">=" | "LIKE" | "ILIKE" | [ "NOT" ] "IN"
+//	value      = scalar | "(" scalar { "," scalar } ")"
+//	scalar     = string | number | word
+//
+// Keys could be enclosed into backticks or double quotes, e.g. tags.`mlflow.runName`. Strings are enclosed into
+// single or double quotes. The quote character inside the quoted text is escaped by doubling it.
+// Keywords are case-insensitive.
+func Parse(filter string) (Expression, error) {
+	p := parser{input: filter, lexer: newLexer(filter)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	expression, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.current.kind != tokenEOF {
+		return nil, newSyntaxError(p.current.pos, "expected AND, OR or end of filter but found %s", p.current.describe())
+	}
+	return expression, nil
+}
+
+// ParseComparisons parses MLflow search filter into the list of comparisons joined with `AND` operator.
+func ParseComparisons(filter string) ([]*Comparison, error) {
+	expression, err := Parse(filter)
+	if err != nil {
+		return nil, err
+	}
+	return Comparisons(expression)
+}
+
+// Comparisons flattens the expression joined with `AND` operator into the list of comparisons.
+// `OR` operator isn't supported by the search queries yet, so the error is returned for it.
+func Comparisons(expression Expression) ([]*Comparison, error) {
+	switch e := expression.(type) {
+	case *Comparison:
+		return []*Comparison{e}, nil
+	case *And:
+		var comparisons []*Comparison
+		for _, operand := range e.Operands {
+			operandComparisons, err := Comparisons(operand)
+			if err != nil {
+				return nil, err
+			}
+			comparisons = append(comparisons, operandComparisons...)
+		}
+		return comparisons, nil
+	case *Or:
+		return nil, newSyntaxError(e.OperatorPos, "%s operator is not supported", orOperator)
+	default:
+		return nil, newSyntaxError(expression.Position(), "unsupported expression '%s'", expression)
+	}
+}
+
+// parser implements recursive descent parser of the filter.
+type parser struct {
+	input   string
+	lexer   *lexer
+	current token
+}
+
+// advance moves parser to the next token.
+func (p *parser) advance() error {
+	t, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.current = t
+	return nil
+}
+
+// parseOr parses operands joined with `OR` operator.
+func (p *parser) parseOr() (Expression, error) {
+	operand, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	if !p.current.keyword(orOperator) {
+		return operand, nil
+	}
+
+	expression := &Or{Pos: operand.Position(), OperatorPos: p.current.pos}
+	if nested, ok := operand.(*Or); ok {
+		expression.OperatorPos = nested.OperatorPos
+	}
+	for {
+		// nested expressions are flattened, so `(a OR b) OR c` becomes `a OR b OR c`.
+		if nested, ok := operand.(*Or); ok {
+			expression.Operands = append(expression.Operands, nested.Operands...)
+		} else {
+			expression.Operands = append(expression.Operands, operand)
+		}
+		if !p.current.keyword(orOperator) {
+			return expression, nil
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if operand, err = p.parseAnd(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseAnd parses operands joined with `AND` operator.
+func (p *parser) parseAnd() (Expression, error) {
+	operand, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if !p.current.keyword(andOperator) {
+		return operand, nil
+	}
+
+	expression := &And{Pos: operand.Position()}
+	for {
+		// nested expressions are flattened, so `(a AND b) AND c` becomes `a AND b AND c`.
+		if nested, ok := operand.(*And); ok {
+			expression.Operands = append(expression.Operands, nested.Operands...)
+		} else {
+			expression.Operands = append(expression.Operands, operand)
+		}
+		if !p.current.keyword(andOperator) {
+			return expression, nil
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if operand, err = p.parsePrimary(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parsePrimary parses either the expression in parentheses or the comparison.
+func (p *parser) parsePrimary() (Expression, error) {
+	if p.current.kind != tokenLeftParen {
+		return p.parseComparison()
+	}
+
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	expression, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.current.kind != tokenRightParen {
+		return nil, newSyntaxError(p.current.pos, "expected ')' but found %s", p.current.describe())
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	return expression, nil
+}
+
+// parseComparison parses single comparison.
+func (p *parser) parseComparison() (Expression, error) {
+	comparison := &Comparison{Pos: p.current.pos}
+	if err := p.parseIdentifier(comparison); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case p.current.kind == tokenOperator:
+		comparison.Operator = p.current.text
+	case p.current.keyword(LikeOperator), p.current.keyword(ILikeOperator), p.current.keyword(InOperator):
+		comparison.Operator = strings.ToUpper(p.current.text)
+	case p.current.keyword(notKeyword):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if !p.current.keyword(InOperator) {
+			return nil, newSyntaxError(p.current.pos, "expected IN but found %s", p.current.describe())
+		}
+		comparison.Operator = NotInOperator
+	case p.current.keyword(isKeyword):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		comparison.Operator = IsNullOperator
+		if p.current.keyword(notKeyword) {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			comparison.Operator = IsNotNullOperator
+		}
+		if !p.current.keyword(nullKeyword) {
+			return nil, newSyntaxError(p.current.pos, "expected NULL but found %s", p.current.describe())
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return comparison, nil
+	default:
+		return nil, newSyntaxError(
+			p.current.pos, "expected comparison operator but found %s", p.current.describe(),
+		)
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	comparison.Value = value
+	return comparison, nil
+}
+
+// parseIdentifier parses the entity and the key of the comparison.
+func (p *parser) parseIdentifier(comparison *Comparison) error {
+	identifier := p.current
+	switch identifier.kind {
+	case tokenWord:
+		if isReservedWord(identifier.text) {
+			return newSyntaxError(identifier.pos, "expected name but found %s", identifier.describe())
+		}
+		if err := p.advance(); err != nil {
+			return err
+		}
+		// quoted key directly follows the entity, e.g. tags.`mlflow.runName`.
+		entity, found := strings.CutSuffix(identifier.text, identifierEntitySplitter)
+		if found && entity != "" && !strings.Contains(entity, identifierEntitySplitter) &&
+			(p.current.kind == tokenName || p.current.kind == tokenString) && p.current.pos == identifier.end {
+			comparison.Entity, comparison.Key = entity, p.current.text
+			if comparison.Key == "" {
+				return newSyntaxError(p.current.pos, "empty name")
+			}
+			return p.advance()
+		}
+		entity, key, found := strings.Cut(identifier.text, identifierEntitySplitter)
+		if !found {
+			comparison.Key = identifier.text
+			return nil
+		}
+		if entity == "" || key == "" {
+			return newSyntaxError(identifier.pos, "invalid name %s", identifier.describe())
+		}
+		comparison.Entity, comparison.Key = entity, key
+		return nil
+	case tokenName, tokenString:
+		if identifier.text == "" {
+			return newSyntaxError(identifier.pos, "empty name")
+		}
+		comparison.Key = identifier.text
+		return p.advance()
+	default:
+		return newSyntaxError(identifier.pos, "expected name but found %s", identifier.describe())
+	}
+}
+
+// parseValue parses either the scalar value or the list of values.
+func (p *parser) parseValue() (*Value, error) {
+	if p.current.kind != tokenLeftParen {
+		return p.parseScalar()
+	}
+
+	list := &Value{Kind: ListValue, Pos: p.current.pos}
+	start := p.current.offset
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	for {
+		item, err := p.parseScalar()
+		if err != nil {
+			return nil, err
+		}
+		list.Items = append(list.Items, item)
+
+		switch p.current.kind {
+		case tokenComma:
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		case tokenRightParen:
+			list.Raw = p.input[start : p.current.offset+1]
+			return list, p.advance()
+		default:
+			return nil, newSyntaxError(p.current.pos, "expected ',' or ')' but found %s", p.current.describe())
+		}
+	}
+}
+
+// parseScalar parses single string, number or unquoted word.
+func (p *parser) parseScalar() (*Value, error) {
+	value := &Value{Text: p.current.text, Raw: p.current.raw, Pos: p.current.pos}
+	switch {
+	case p.current.kind == tokenString:
+		value.Kind = StringValue
+	case p.current.kind == tokenWord && !isReservedWord(p.current.text):
+		value.Kind = IdentifierValue
+		if numberLiteral.MatchString(p.current.text) {
+			value.Kind = NumberValue
+		}
+	default:
+		return nil, newSyntaxError(p.current.pos, "expected value but found %s", p.current.describe())
+	}
+	return value, p.advance()
+}
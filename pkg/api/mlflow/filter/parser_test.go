@@ -0,0 +1,363 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Ok(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   string
+		expected Expression
+	}{
+		{
+			name:   "AttributeWithoutEntity",
+			filter: "name = 'value'",
+			expected: &Comparison{
+				Key:      "name",
+				Operator: EqualOperator,
+				Value:    &Value{Kind: StringValue, Text: "value", Raw: "'value'", Pos: 8},
+				Pos:      1,
+			},
+		},
+		{
+			name:   "NumericValue",
+			filter: "metrics.loss >= -1.5e-3",
+			expected: &Comparison{
+				Entity:   "metrics",
+				Key:      "loss",
+				Operator: GreaterOrEqualOperator,
+				Value:    &Value{Kind: NumberValue, Text: "-1.5e-3", Raw: "-1.5e-3", Pos: 17},
+				Pos:      1,
+			},
+		},
+		{
+			name:   "UnquotedValue",
+			filter: "attributes.creation_time > cc",
+			expected: &Comparison{
+				Entity:   "attributes",
+				Key:      "creation_time",
+				Operator: GreaterOperator,
+				Value:    &Value{Kind: IdentifierValue, Text: "cc", Raw: "cc", Pos: 28},
+				Pos:      1,
+			},
+		},
+		{
+			name:   "DottedKey",
+			filter: "tags.mlflow.runName = \"run\"",
+			expected: &Comparison{
+				Entity:   "tags",
+				Key:      "mlflow.runName",
+				Operator: EqualOperator,
+				Value:    &Value{Kind: StringValue, Text: "run", Raw: "\"run\"", Pos: 23},
+				Pos:      1,
+			},
+		},
+		{
+			name:   "BacktickEscapedKey",
+			filter: "tags.`mlflow.runName` != 'run'",
+			expected: &Comparison{
+				Entity:   "tags",
+				Key:      "mlflow.runName",
+				Operator: NotEqualOperator,
+				Value:    &Value{Kind: StringValue, Text: "run", Raw: "'run'", Pos: 26},
+				Pos:      1,
+			},
+		},
+		{
+			name:   "QuotedKeyWithSpacesAndEscapedQuotes",
+			filter: "params.\"my \"\"param\"\"\" = 'a'",
+			expected: &Comparison{
+				Entity:   "params",
+				Key:      "my \"param\"",
+				Operator: EqualOperator,
+				Value:    &Value{Kind: StringValue, Text: "a", Raw: "'a'", Pos: 25},
+				Pos:      1,
+			},
+		},
+		{
+			name:   "ValueContainingAnd",
+			filter: "tags.note LIKE '%this and that%' AND params.p = 'it''s'",
+			expected: &And{
+				Operands: []Expression{
+					&Comparison{
+						Entity:   "tags",
+						Key:      "note",
+						Operator: LikeOperator,
+						Value: &Value{
+							Kind: StringValue, Text: "%this and that%", Raw: "'%this and that%'", Pos: 16,
+						},
+						Pos: 1,
+					},
+					&Comparison{
+						Entity:   "params",
+						Key:      "p",
+						Operator: EqualOperator,
+						Value:    &Value{Kind: StringValue, Text: "it's", Raw: "'it''s'", Pos: 49},
+						Pos:      38,
+					},
+				},
+				Pos: 1,
+			},
+		},
+		{
+			name:   "NotInListWithCommas",
+			filter: "run_id not in ('a,b', 'c')",
+			expected: &Comparison{
+				Key:      "run_id",
+				Operator: NotInOperator,
+				Value: &Value{
+					Kind: ListValue,
+					Items: []*Value{
+						{Kind: StringValue, Text: "a,b", Raw: "'a,b'", Pos: 16},
+						{Kind: StringValue, Text: "c", Raw: "'c'", Pos: 23},
+					},
+					Raw: "('a,b', 'c')",
+					Pos: 15,
+				},
+				Pos: 1,
+			},
+		},
+		{
+			name:   "IsNotNull",
+			filter: "tags.note is not null",
+			expected: &Comparison{
+				Entity:   "tags",
+				Key:      "note",
+				Operator: IsNotNullOperator,
+				Pos:      1,
+			},
+		},
+		{
+			name:   "OrWithPrecedenceAndGroups",
+			filter: "(a = 1 OR b = 2) OR c = 3 AND d ILIKE 'x'",
+			expected: &Or{
+				Operands: []Expression{
+					&Comparison{
+						Key:      "a",
+						Operator: EqualOperator,
+						Value:    &Value{Kind: NumberValue, Text: "1", Raw: "1", Pos: 6},
+						Pos:      2,
+					},
+					&Comparison{
+						Key:      "b",
+						Operator: EqualOperator,
+						Value:    &Value{Kind: NumberValue, Text: "2", Raw: "2", Pos: 15},
+						Pos:      11,
+					},
+					&And{
+						Operands: []Expression{
+							&Comparison{
+								Key:      "c",
+								Operator: EqualOperator,
+								Value:    &Value{Kind: NumberValue, Text: "3", Raw: "3", Pos: 25},
+								Pos:      21,
+							},
+							&Comparison{
+								Key:      "d",
+								Operator: ILikeOperator,
+								Value:    &Value{Kind: StringValue, Text: "x", Raw: "'x'", Pos: 39},
+								Pos:      31,
+							},
+						},
+						Pos: 21,
+					},
+				},
+				Pos:         2,
+				OperatorPos: 8,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expression, err := Parse(tt.filter)
+			require.Nil(t, err)
+			assert.Equal(t, tt.expected, expression)
+		})
+	}
+}
+
+func TestParse_Error(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		error  string
+	}{
+		{
+			name:   "EmptyFilter",
+			filter: "   ",
+			error:  "expected name but found end of filter at position 4",
+		},
+		{
+			name:   "MissingOperator",
+			filter: "invalid_filter",
+			error:  "expected comparison operator but found end of filter at position 15",
+		},
+		{
+			name:   "MissingValue",
+			filter: "name = 'a' AND tags.t =",
+			error:  "expected value but found end of filter at position 24",
+		},
+		{
+			name:   "UnterminatedString",
+			filter: "name = 'a' AND tags.t = 'b",
+			error:  "unterminated string literal at position 25",
+		},
+		{
+			name:   "UnterminatedName",
+			filter: "tags.`a = 'b'",
+			error:  "unterminated name literal at position 6",
+		},
+		{
+			name:   "UnexpectedCharacter",
+			filter: "name ~ 'a'",
+			error:  "unexpected character '~' at position 6",
+		},
+		{
+			name:   "InvalidName",
+			filter: ".name = 'a'",
+			error:  "invalid name '.name' at position 1",
+		},
+		{
+			name:   "EmptyName",
+			filter: "tags.`` = 'a'",
+			error:  "empty name at position 6",
+		},
+		{
+			name:   "ReservedWordAsName",
+			filter: "and = 'a'",
+			error:  "expected name but found 'and' at position 1",
+		},
+		{
+			name:   "ReservedWordAsValue",
+			filter: "name = and tags.t = 'a'",
+			error:  "expected value but found 'and' at position 8",
+		},
+		{
+			name:   "MissingNull",
+			filter: "name is model",
+			error:  "expected NULL but found 'model' at position 9",
+		},
+		{
+			name:   "MissingIn",
+			filter: "name not like 'a'",
+			error:  "expected IN but found 'like' at position 10",
+		},
+		{
+			name:   "InvalidList",
+			filter: "run_id IN ('a' 'b')",
+			error:  "expected ',' or ')' but found ''b'' at position 16",
+		},
+		{
+			name:   "EmptyList",
+			filter: "run_id IN ()",
+			error:  "expected value but found ')' at position 12",
+		},
+		{
+			name:   "UnclosedGroup",
+			filter: "(name = 'a' OR name = 'b'",
+			error:  "expected ')' but found end of filter at position 26",
+		},
+		{
+			name:   "MissingConjunction",
+			filter: "name = 'a' name = 'b'",
+			error:  "expected AND, OR or end of filter but found 'name' at position 12",
+		},
+		{
+			name:   "PositionCountsCharacters",
+			filter: "tags.`ключ` = 'значение' ?",
+			error:  "unexpected character '?' at position 26",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expression, err := Parse(tt.filter)
+			assert.Nil(t, expression)
+			assert.EqualError(t, err, tt.error)
+		})
+	}
+}
+
+func TestParseComparisons_Ok(t *testing.T) {
+	comparisons, err := ParseComparisons("name = 'a' AND (tags.t LIKE 'b%' AND metrics.m < 1)")
+	require.Nil(t, err)
+	require.Len(t, comparisons, 3)
+	assert.Equal(t, "name = 'a'", comparisons[0].String())
+	assert.Equal(t, "tags.t LIKE 'b%'", comparisons[1].String())
+	assert.Equal(t, "metrics.m < 1", comparisons[2].String())
+}
+
+func TestParseComparisons_Error(t *testing.T) {
+	comparisons, err := ParseComparisons("name = 'a' AND (tags.t = 'b' or metrics.m < 1)")
+	assert.Nil(t, comparisons)
+	assert.EqualError(t, err, "OR operator is not supported at position 30")
+}
+
+func TestExpression_String(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   string
+		expected string
+	}{
+		{
+			name:     "NormalizesKeywordsAndQuotes",
+			filter:   `name  like "it's"  and  run_id not in ('a',"b")`,
+			expected: `name LIKE 'it''s' AND run_id NOT IN ('a', 'b')`,
+		},
+		{
+			name:     "QuotesKeysWhenNeeded",
+			filter:   "`a.b` = 1 AND tags.`my tag` = 2 AND tags.`x.y` = 3 AND `in` IS NULL",
+			expected: "`a.b` = 1 AND tags.`my tag` = 2 AND tags.x.y = 3 AND `in` IS NULL",
+		},
+		{
+			name:     "KeepsGroupsOfOrOperands",
+			filter:   "(a = 1 OR b = 2) AND (c = 3 AND d = 4)",
+			expected: "(a = 1 OR b = 2) AND c = 3 AND d = 4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expression, err := Parse(tt.filter)
+			require.Nil(t, err)
+			assert.Equal(t, tt.expected, expression.String())
+		})
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, filter := range []string{
+		"name = 'value'",
+		"attributes.start_time >= 123456789 AND metrics.loss < -1.5e-3",
+		"tags.mlflow.runName ILIKE '%run and test%'",
+		"tags.`mlflow.runName` != \"it''s\"",
+		"params.\"my param\" LIKE 'a'",
+		"run_id NOT IN ('a,b', 'c') AND dataset.context IN ('training')",
+		"(a = 1 OR b = 2) AND c IS NOT NULL",
+		"invalid_filter",
+		"tags.`unterminated = 'a'",
+	} {
+		f.Add(filter)
+	}
+
+	f.Fuzz(func(t *testing.T, filter string) {
+		expression, err := Parse(filter)
+		if err != nil {
+			var syntaxError *SyntaxError
+			require.ErrorAs(t, err, &syntaxError)
+			assert.Positive(t, syntaxError.Pos)
+			return
+		}
+
+		// canonical representation has to be parsed back into the same expression.
+		canonical := expression.String()
+		reparsed, err := Parse(canonical)
+		require.Nil(t, err, "canonical filter %q of %q is invalid", canonical, filter)
+		assert.Equal(t, canonical, reparsed.String())
+	})
+}
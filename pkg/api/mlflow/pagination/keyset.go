@@ -0,0 +1,156 @@
+package pagination
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+)
+
+// Column represents the column of the effective search order.
+type Column struct {
+	clause.Column
+	Desc bool
+	// NotNull omits NULL values ordering for the columns, which can't contain NULL values.
+	NotNull bool
+}
+
+// Order applies the order of the columns to the query. NULL values are placed after all the other values
+// regardless of the direction, the same way as MLflow does.
+func Order(tx *gorm.DB, columns []Column) *gorm.DB {
+	for _, column := range columns {
+		name := tx.Statement.Quote(column.Column)
+		if !column.NotNull {
+			tx.Order(fmt.Sprintf("%s IS NULL", name))
+		}
+		if column.Desc {
+			tx.Order(fmt.Sprintf("%s DESC", name))
+		} else {
+			tx.Order(name)
+		}
+	}
+	return tx
+}
+
+// After applies the keyset condition to the query, which selects the rows following the row with provided keys
+// in the order of the columns.
+func After(tx *gorm.DB, columns []Column, keys []request.PageTokenKey) error {
+	if len(keys) != len(columns) {
+		return eris.Errorf("expected %d keys, got %d", len(columns), len(keys))
+	}
+
+	// the row follows the cursor, when it has the same values in the first columns
+	// and the value after the cursor in the next column.
+	var conditions, equal []string
+	var vars, equalVars []any
+	for i, column := range columns {
+		value, err := keyValue(keys[i])
+		if err != nil {
+			return eris.Wrapf(err, "error decoding key %d", i)
+		}
+
+		name := tx.Statement.Quote(column.Column)
+		if value == nil {
+			// nothing follows NULL value except the other NULL values.
+			equal = append(equal, fmt.Sprintf("%s IS NULL", name))
+			continue
+		}
+
+		operator := ">"
+		if column.Desc {
+			operator = "<"
+		}
+		after := fmt.Sprintf("%s %s ?", name, operator)
+		if !column.NotNull {
+			after = fmt.Sprintf("(%s OR %s IS NULL)", after, name)
+		}
+		conditions = append(conditions, strings.Join(append(slices.Clone(equal), after), " AND "))
+		vars = append(append(vars, equalVars...), value)
+
+		equal = append(equal, fmt.Sprintf("%s = ?", name))
+		equalVars = append(equalVars, value)
+	}
+	if len(conditions) == 0 {
+		return eris.New("keys don't contain any value")
+	}
+
+	tx.Where(fmt.Sprintf("((%s))", strings.Join(conditions, ") OR (")), vars...)
+	return nil
+}
+
+// Keys returns the keys of the columns of the single row selected by the query.
+func Keys(tx *gorm.DB, columns []Column) ([]request.PageTokenKey, error) {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = tx.Statement.Quote(column.Column)
+	}
+
+	values := make([]any, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := tx.Select(strings.Join(names, ", ")).Limit(1).Row().Scan(dest...); err != nil {
+		return nil, eris.Wrap(err, "error selecting keys")
+	}
+
+	keys := make([]request.PageTokenKey, len(columns))
+	for i, value := range values {
+		key, err := newKey(value)
+		if err != nil {
+			return nil, eris.Wrapf(err, "error encoding key of column '%s'", columns[i].Name)
+		}
+		keys[i] = key
+	}
+	return keys, nil
+}
+
+// newKey converts database value into request.PageTokenKey.
+func newKey(value any) (request.PageTokenKey, error) {
+	switch v := value.(type) {
+	case nil:
+		return request.PageTokenKey{}, nil
+	case int64:
+		return request.PageTokenKey{Int: &v}, nil
+	case int32:
+		i := int64(v)
+		return request.PageTokenKey{Int: &i}, nil
+	case float64:
+		f := strconv.FormatFloat(v, 'g', -1, 64)
+		return request.PageTokenKey{Float: &f}, nil
+	case float32:
+		f := strconv.FormatFloat(float64(v), 'g', -1, 32)
+		return request.PageTokenKey{Float: &f}, nil
+	case string:
+		return request.PageTokenKey{String: &v}, nil
+	case []byte:
+		s := string(v)
+		return request.PageTokenKey{String: &s}, nil
+	default:
+		return request.PageTokenKey{}, eris.Errorf("unsupported value type %T", value)
+	}
+}
+
+// keyValue converts request.PageTokenKey back into the value suitable for the query.
+func keyValue(key request.PageTokenKey) (any, error) {
+	switch {
+	case key.Int != nil:
+		return *key.Int, nil
+	case key.Float != nil:
+		f, err := strconv.ParseFloat(*key.Float, 64)
+		if err != nil {
+			return nil, eris.Wrapf(err, "invalid float value '%s'", *key.Float)
+		}
+		return f, nil
+	case key.String != nil:
+		return *key.String, nil
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,163 @@
+package pagination
+
+import (
+	"math"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/common"
+)
+
+func newDryRunDB(t *testing.T) *gorm.DB {
+	mockedDB, _, err := sqlmock.New()
+	require.Nil(t, err)
+
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:       mockedDB,
+		DriverName: "postgres",
+	}), &gorm.Config{})
+	require.Nil(t, err)
+	return db.Session(&gorm.Session{DryRun: true}).Table("runs")
+}
+
+var testColumns = []Column{
+	{Column: clause.Column{Table: "order_0", Name: "value"}, Desc: true},
+	{Column: clause.Column{Table: "runs", Name: "start_time"}},
+	{Column: clause.Column{Table: "runs", Name: "run_uuid"}, NotNull: true},
+}
+
+func TestOrder_Ok(t *testing.T) {
+	tx := Order(newDryRunDB(t), testColumns).Find(&[]map[string]any{})
+	require.Nil(t, tx.Error)
+	assert.Equal(
+		t,
+		`SELECT * FROM "runs" ORDER BY "order_0"."value" IS NULL,"order_0"."value" DESC,`+
+			`"runs"."start_time" IS NULL,"runs"."start_time","runs"."run_uuid"`,
+		tx.Statement.SQL.String(),
+	)
+}
+
+func TestAfter_Ok(t *testing.T) {
+	tests := []struct {
+		name         string
+		keys         []request.PageTokenKey
+		expectedSQL  string
+		expectedVars []any
+	}{
+		{
+			name: "AllValues",
+			keys: []request.PageTokenKey{
+				{Float: common.GetPointer("0.5")},
+				{Int: common.GetPointer[int64](10)},
+				{String: common.GetPointer("id")},
+			},
+			expectedSQL: `SELECT * FROM "runs" WHERE ((("order_0"."value" < $1 OR "order_0"."value" IS NULL)) OR ` +
+				`("order_0"."value" = $2 AND ("runs"."start_time" > $3 OR "runs"."start_time" IS NULL)) OR ` +
+				`("order_0"."value" = $4 AND "runs"."start_time" = $5 AND "runs"."run_uuid" > $6))`,
+			expectedVars: []any{0.5, 0.5, int64(10), 0.5, int64(10), "id"},
+		},
+		{
+			name: "NullValue",
+			keys: []request.PageTokenKey{
+				{},
+				{Int: common.GetPointer[int64](10)},
+				{String: common.GetPointer("id")},
+			},
+			expectedSQL: `SELECT * FROM "runs" WHERE ((` +
+				`"order_0"."value" IS NULL AND ("runs"."start_time" > $1 OR "runs"."start_time" IS NULL)) OR ` +
+				`("order_0"."value" IS NULL AND "runs"."start_time" = $2 AND "runs"."run_uuid" > $3))`,
+			expectedVars: []any{int64(10), int64(10), "id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := newDryRunDB(t)
+			require.Nil(t, After(tx, testColumns, tt.keys))
+			tx = tx.Find(&[]map[string]any{})
+			require.Nil(t, tx.Error)
+			assert.Equal(t, tt.expectedSQL, tx.Statement.SQL.String())
+			assert.Equal(t, tt.expectedVars, tx.Statement.Vars)
+		})
+	}
+}
+
+func TestAfter_Error(t *testing.T) {
+	tests := []struct {
+		name  string
+		keys  []request.PageTokenKey
+		error string
+	}{
+		{
+			name:  "KeysMismatch",
+			keys:  []request.PageTokenKey{{String: common.GetPointer("id")}},
+			error: "expected 3 keys, got 1",
+		},
+		{
+			name:  "NoValues",
+			keys:  []request.PageTokenKey{{}, {}, {}},
+			error: "keys don't contain any value",
+		},
+		{
+			name: "InvalidFloat",
+			keys: []request.PageTokenKey{
+				{Float: common.GetPointer("abc")}, {}, {String: common.GetPointer("id")},
+			},
+			error: "error decoding key 0: invalid float value 'abc': " +
+				`strconv.ParseFloat: parsing "abc": invalid syntax`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, After(newDryRunDB(t), testColumns, tt.keys), tt.error)
+		})
+	}
+}
+
+func TestKey_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected any
+	}{
+		{name: "Null", value: nil, expected: nil},
+		{name: "Int64", value: int64(-5), expected: int64(-5)},
+		{name: "Int32", value: int32(7), expected: int64(7)},
+		{name: "Float64", value: 0.1, expected: 0.1},
+		{name: "Float32", value: float32(0.5), expected: 0.5},
+		{name: "Infinity", value: math.Inf(-1), expected: math.Inf(-1)},
+		{name: "String", value: "value", expected: "value"},
+		{name: "Bytes", value: []byte("value"), expected: "value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := newKey(tt.value)
+			require.Nil(t, err)
+			value, err := keyValue(key)
+			require.Nil(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestKey_NaN(t *testing.T) {
+	key, err := newKey(math.NaN())
+	require.Nil(t, err)
+	value, err := keyValue(key)
+	require.Nil(t, err)
+	assert.True(t, math.IsNaN(value.(float64)))
+}
+
+func TestKey_Error(t *testing.T) {
+	_, err := newKey(true)
+	assert.EqualError(t, err, "unsupported value type bool")
+}
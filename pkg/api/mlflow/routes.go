@@ -9,10 +9,32 @@ import (
 
 // List of route prefixes.
 const (
-	RunsRoutePrefix        = "/runs"
-	MetricsRoutePrefix     = "/metrics"
-	ArtifactsRoutePrefix   = "/artifacts"
-	ExperimentsRoutePrefix = "/experiments"
+	RunsRoutePrefix             = "/runs"
+	MetricsRoutePrefix          = "/metrics"
+	ArtifactsRoutePrefix        = "/artifacts"
+	ExperimentsRoutePrefix      = "/experiments"
+	ModelVersionsRoutePrefix    = "/model-versions"
+	RegisteredModelsRoutePrefix = "/registered-models"
+	ExportsRoutePrefix          = "/exports"
+)
+
+// List of `mlflow-artifacts` route prefixes.
+const (
+	MlflowArtifactsArtifactsRoutePrefix = "/artifacts"
+	MlflowArtifactsMpuRoutePrefix       = "/mpu"
+)
+
+// List of `/mlflow-artifacts/artifacts/*` routes.
+const (
+	MlflowArtifactsListRoute     = "/"
+	MlflowArtifactsArtifactRoute = "/*"
+)
+
+// List of `/mlflow-artifacts/mpu/*` routes.
+const (
+	MlflowArtifactsMpuCreateRoute   = "/create/*"
+	MlflowArtifactsMpuCompleteRoute = "/complete/*"
+	MlflowArtifactsMpuAbortRoute    = "/abort/*"
 )
 
 // List of `/artifact/*` routes.
@@ -23,15 +45,16 @@ const (
 
 // List of `/experiments/*` routes.
 const (
-	ExperimentsGetRoute         = "/get"
-	ExperimentsListRoute        = "/list"
-	ExperimentsCreateRoute      = "/create"
-	ExperimentsDeleteRoute      = "/delete"
-	ExperimentsRestoreRoute     = "/restore"
-	ExperimentsSearchRoute      = "/search"
-	ExperimentsUpdateRoute      = "/update"
-	ExperimentsGetByNameRoute   = "/get-by-name"
-	ExperimentsSetExperimentTag = "/set-experiment-tag"
+	ExperimentsGetRoute            = "/get"
+	ExperimentsListRoute           = "/list"
+	ExperimentsCreateRoute         = "/create"
+	ExperimentsDeleteRoute         = "/delete"
+	ExperimentsRestoreRoute        = "/restore"
+	ExperimentsSearchRoute         = "/search"
+	ExperimentsUpdateRoute         = "/update"
+	ExperimentsGetByNameRoute      = "/get-by-name"
+	ExperimentsSetExperimentTag    = "/set-experiment-tag"
+	ExperimentsSearchDatasetsRoute = "/search-datasets"
 )
 
 // List of `/metrics/*` routes.
@@ -41,28 +64,70 @@ const (
 	MetricsGetHistoryBulkRoute = "/get-history-bulk"
 )
 
+// List of `/model-versions/*` routes.
+const (
+	ModelVersionsGetRoute             = "/get"
+	ModelVersionsCreateRoute          = "/create"
+	ModelVersionsDeleteRoute          = "/delete"
+	ModelVersionsSearchRoute          = "/search"
+	ModelVersionsSetTagRoute          = "/set-tag"
+	ModelVersionsUpdateRoute          = "/update"
+	ModelVersionsDeleteTagRoute       = "/delete-tag"
+	ModelVersionsGetDownloadURIRoute  = "/get-download-uri"
+	ModelVersionsTransitionStageRoute = "/transition-stage"
+)
+
+// List of `/registered-models/*` routes.
+const (
+	RegisteredModelsGetRoute               = "/get"
+	RegisteredModelsAliasRoute             = "/alias"
+	RegisteredModelsCreateRoute            = "/create"
+	RegisteredModelsDeleteRoute            = "/delete"
+	RegisteredModelsRenameRoute            = "/rename"
+	RegisteredModelsSearchRoute            = "/search"
+	RegisteredModelsSetTagRoute            = "/set-tag"
+	RegisteredModelsUpdateRoute            = "/update"
+	RegisteredModelsDeleteTagRoute         = "/delete-tag"
+	RegisteredModelsGetLatestVersionsRoute = "/get-latest-versions"
+)
+
+// List of `/exports/*` routes.
+const (
+	ExportsGetRoute    = "/get"
+	ExportsCreateRoute = "/create"
+)
+
 // List of `/runs/*` routes.
 const (
-	RunsGetRoute          = "/get"
-	RunsCreateRoute       = "/create"
-	RunsDeleteRoute       = "/delete"
-	RunsSearchRoute       = "/search"
-	RunsSetTagRoute       = "/set-tag"
-	RunsUpdateRoute       = "/update"
-	RunsRestoreRoute      = "/restore"
-	RunsDeleteTagRoute    = "/delete-tag"
-	RunsLogBatchRoute     = "/log-batch"
-	RunsLogMetricRoute    = "/log-metric"
-	RunsLogParameterRoute = "/log-parameter"
-	RunsLogOutputRoute    = "/log-output"
-	RunsLogArtifactRoute  = "/log-artifact"
+	RunsGetRoute             = "/get"
+	RunsCreateRoute          = "/create"
+	RunsDeleteRoute          = "/delete"
+	RunsSearchRoute          = "/search"
+	RunsSetTagRoute          = "/set-tag"
+	RunsUpdateRoute          = "/update"
+	RunsRestoreRoute         = "/restore"
+	RunsDeleteTagRoute       = "/delete-tag"
+	RunsLogBatchRoute        = "/log-batch"
+	RunsLogMetricRoute       = "/log-metric"
+	RunsLogParameterRoute    = "/log-parameter"
+	RunsLogOutputRoute       = "/log-output"
+	RunsLogInputsRoute       = "/log-inputs"
+	RunsLogArtifactRoute     = "/log-artifact"
+	RunsLogTextRoute         = "/log-text"
+	RunsLogAudioRoute        = "/log-audio"
+	RunsLogFigureRoute       = "/log-figure"
+	RunsLogDistributionRoute = "/log-distribution"
+	RunsExportRoute          = "/export"
+	RunsExportMetricsRoute   = "/export-metrics"
 )
 
 // Router represents `mlflow` router.
 type Router struct {
-	prefixList        []string
-	controller        *controller.Controller
-	globalMiddlewares []fiber.Handler
+	prefixList               []string
+	artifactsProxyPrefixList []string
+	artifactsProxyEnabled    bool
+	controller               *controller.Controller
+	globalMiddlewares        []fiber.Handler
 }
 
 // NewRouter creates new instance of `mlflow` router.
@@ -72,6 +137,10 @@ func NewRouter(controller *controller.Controller) *Router {
 			"/api/2.0/mlflow/",
 			"/ajax-api/2.0/mlflow/",
 		},
+		artifactsProxyPrefixList: []string{
+			"/api/2.0/mlflow-artifacts/",
+			"/ajax-api/2.0/mlflow-artifacts/",
+		},
 		controller:        controller,
 		globalMiddlewares: make([]fiber.Handler, 0),
 	}
@@ -79,6 +148,12 @@ func NewRouter(controller *controller.Controller) *Router {
 
 // Init makes initialization of all `mlflow` routes.
 func (r *Router) Init(router fiber.Router) {
+	// `mlflow-artifacts` routes have to be registered first,
+	// otherwise they will be handled by `not found` handler of `mlflow` routes.
+	if r.artifactsProxyEnabled {
+		r.initArtifactsProxy(router)
+	}
+
 	for _, prefix := range r.prefixList {
 		mainGroup := router.Group(prefix)
 		// apply global middlewares.
@@ -101,8 +176,13 @@ func (r *Router) Init(router fiber.Router) {
 		experiments.Get(ExperimentsSearchRoute, r.controller.SearchExperiments)
 		experiments.Post(ExperimentsSearchRoute, r.controller.SearchExperiments)
 		experiments.Post(ExperimentsSetExperimentTag, r.controller.SetExperimentTag)
+		experiments.Post(ExperimentsSearchDatasetsRoute, r.controller.SearchDatasets)
 		experiments.Post(ExperimentsUpdateRoute, r.controller.UpdateExperiment)
 
+		exports := mainGroup.Group(ExportsRoutePrefix)
+		exports.Get(ExportsGetRoute, r.controller.GetExport)
+		exports.Post(ExportsCreateRoute, r.controller.CreateExport)
+
 		metrics := mainGroup.Group(MetricsRoutePrefix)
 		metrics.Get(MetricsGetHistoryRoute, r.controller.GetMetricHistory)
 		metrics.Get(MetricsGetHistoryBulkRoute, r.controller.GetMetricHistoryBulk)
@@ -121,10 +201,66 @@ func (r *Router) Init(router fiber.Router) {
 		runs.Post(RunsSetTagRoute, r.controller.SetRunTag)
 		runs.Post(RunsUpdateRoute, r.controller.UpdateRun)
 		runs.Post(RunsLogOutputRoute, r.controller.LogOutput)
+		runs.Post(RunsLogInputsRoute, r.controller.LogInputs)
 		runs.Post(RunsLogArtifactRoute, r.controller.LogArtifact)
+		runs.Post(RunsLogTextRoute, r.controller.LogText)
+		runs.Post(RunsLogAudioRoute, r.controller.LogAudio)
+		runs.Post(RunsLogDistributionRoute, r.controller.LogDistribution)
+		runs.Post(RunsLogFigureRoute, r.controller.LogFigure)
+		runs.Post(RunsExportRoute, r.controller.ExportRuns)
+		runs.Post(RunsExportMetricsRoute, r.controller.ExportRunMetrics)
+
+		modelVersions := mainGroup.Group(ModelVersionsRoutePrefix)
+		modelVersions.Post(ModelVersionsCreateRoute, r.controller.CreateModelVersion)
+		modelVersions.Delete(ModelVersionsDeleteRoute, r.controller.DeleteModelVersion)
+		modelVersions.Delete(ModelVersionsDeleteTagRoute, r.controller.DeleteModelVersionTag)
+		modelVersions.Get(ModelVersionsGetRoute, r.controller.GetModelVersion)
+		modelVersions.Get(ModelVersionsGetDownloadURIRoute, r.controller.GetModelVersionDownloadURI)
+		modelVersions.Get(ModelVersionsSearchRoute, r.controller.SearchModelVersions)
+		modelVersions.Post(ModelVersionsSetTagRoute, r.controller.SetModelVersionTag)
+		modelVersions.Post(ModelVersionsTransitionStageRoute, r.controller.TransitionModelVersionStage)
+		modelVersions.Patch(ModelVersionsUpdateRoute, r.controller.UpdateModelVersion)
+
+		registeredModels := mainGroup.Group(RegisteredModelsRoutePrefix)
+		registeredModels.Delete(RegisteredModelsAliasRoute, r.controller.DeleteRegisteredModelAlias)
+		registeredModels.Get(RegisteredModelsAliasRoute, r.controller.GetModelVersionByAlias)
+		registeredModels.Post(RegisteredModelsAliasRoute, r.controller.SetRegisteredModelAlias)
+		registeredModels.Post(RegisteredModelsCreateRoute, r.controller.CreateRegisteredModel)
+		registeredModels.Delete(RegisteredModelsDeleteRoute, r.controller.DeleteRegisteredModel)
+		registeredModels.Delete(RegisteredModelsDeleteTagRoute, r.controller.DeleteRegisteredModelTag)
+		registeredModels.Get(RegisteredModelsGetRoute, r.controller.GetRegisteredModel)
+		registeredModels.Get(RegisteredModelsGetLatestVersionsRoute, r.controller.GetLatestVersions)
+		registeredModels.Post(RegisteredModelsGetLatestVersionsRoute, r.controller.GetLatestVersions)
+		registeredModels.Post(RegisteredModelsRenameRoute, r.controller.RenameRegisteredModel)
+		registeredModels.Get(RegisteredModelsSearchRoute, r.controller.SearchRegisteredModels)
+		registeredModels.Post(RegisteredModelsSetTagRoute, r.controller.SetRegisteredModelTag)
+		registeredModels.Patch(RegisteredModelsUpdateRoute, r.controller.UpdateRegisteredModel)
+
+		mainGroup.Use(func(c *fiber.Ctx) error {
+			return api.NewEndpointNotFound("Not found")
+		})
+	}
+}
 
-		mainGroup.Get("/model-versions/search", r.controller.SearchModelVersions)
-		mainGroup.Get("/registered-models/search", r.controller.SearchRegisteredModels)
+// initArtifactsProxy makes initialization of `mlflow-artifacts` routes.
+func (r *Router) initArtifactsProxy(router fiber.Router) {
+	for _, prefix := range r.artifactsProxyPrefixList {
+		mainGroup := router.Group(prefix)
+		// apply global middlewares.
+		for _, globalMiddleware := range r.globalMiddlewares {
+			mainGroup.Use(globalMiddleware)
+		}
+
+		artifacts := mainGroup.Group(MlflowArtifactsArtifactsRoutePrefix)
+		artifacts.Get(MlflowArtifactsListRoute, r.controller.ListProxiedArtifacts)
+		artifacts.Get(MlflowArtifactsArtifactRoute, r.controller.DownloadProxiedArtifact)
+		artifacts.Put(MlflowArtifactsArtifactRoute, r.controller.UploadProxiedArtifact)
+		artifacts.Delete(MlflowArtifactsArtifactRoute, r.controller.DeleteProxiedArtifact)
+
+		mpu := mainGroup.Group(MlflowArtifactsMpuRoutePrefix)
+		mpu.Post(MlflowArtifactsMpuCreateRoute, r.controller.CreateMultipartUpload)
+		mpu.Post(MlflowArtifactsMpuCompleteRoute, r.controller.CompleteMultipartUpload)
+		mpu.Post(MlflowArtifactsMpuAbortRoute, r.controller.AbortMultipartUpload)
 
 		mainGroup.Use(func(c *fiber.Ctx) error {
 			return api.NewEndpointNotFound("Not found")
@@ -132,6 +268,12 @@ func (r *Router) Init(router fiber.Router) {
 	}
 }
 
+// EnableArtifactsProxy enables `mlflow-artifacts` routes to proxy artifacts to the artifacts destination.
+func (r *Router) EnableArtifactsProxy(enabled bool) *Router {
+	r.artifactsProxyEnabled = enabled
+	return r
+}
+
 // AddGlobalMiddleware adds a global middleware which will be applied for each route.
 func (r *Router) AddGlobalMiddleware(middleware fiber.Handler) *Router {
 	r.globalMiddlewares = append(r.globalMiddlewares, middleware)
@@ -45,6 +45,9 @@ func ErrorHandler(c *fiber.Ctx, err error) error {
 	case api.ErrorCodeEndpointNotFound, api.ErrorCodeResourceDoesNotExist:
 		code = fiber.StatusNotFound
 		fn = log.Debugf
+	case api.ErrorCodeNotImplemented:
+		code = fiber.StatusNotImplemented
+		fn = log.Infof
 	default:
 		code = fiber.StatusInternalServerError
 		fn = log.Errorf
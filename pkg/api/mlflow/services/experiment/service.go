@@ -18,17 +18,14 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/convertors"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/filter"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/pagination"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/pkg/common/config"
 	"github.com/G-Research/fasttrackml/pkg/database"
 )
 
-//nolint:lll
-var (
-	filterAnd       = regexp.MustCompile(`(?i)\s+AND\s+`)
-	filterCond      = regexp.MustCompile(`^(?:(\w+)\.)?("[^"]+"|` + "`[^`]+`" + `|[\w\.]+)\s+(<|<=|>|>=|=|!=|(?i:I?LIKE)|(?i:(?:NOT )?IN))\s+(\((?:'[^']+'(?:,\s*)?)+\)|"[^"]+"|'[^']+'|[\w\.]+)$`)
-	experimentOrder = regexp.MustCompile(`^(?:attr(?:ibutes?)?\.)?(\w+)(?i:\s+(ASC|DESC))?$`)
-)
+var experimentOrder = regexp.MustCompile(`^(?:attr(?:ibutes?)?\.)?(\w+)(?i:\s+(ASC|DESC))?$`)
 
 // supported expression list.
 const (
@@ -49,6 +46,7 @@ type Service struct {
 	config               *config.Config
 	tagRepository        repositories.TagRepositoryProvider
 	experimentRepository repositories.ExperimentRepositoryProvider
+	inputRepository      repositories.InputRepositoryProvider
 }
 
 // NewService creates new Service instance.
@@ -56,11 +54,13 @@ func NewService(
 	config *config.Config,
 	tagRepository repositories.TagRepositoryProvider,
 	experimentRepository repositories.ExperimentRepositoryProvider,
+	inputRepository repositories.InputRepositoryProvider,
 ) *Service {
 	return &Service{
 		config:               config,
 		tagRepository:        tagRepository,
 		experimentRepository: experimentRepository,
+		inputRepository:      inputRepository,
 	}
 }
 
@@ -270,9 +270,9 @@ func (s Service) SetExperimentTag(
 // TODO:get back and fix `gocyclo` problem.
 func (s Service) SearchExperiments(
 	ctx context.Context, ns *models.Namespace, req *request.SearchExperimentsRequest,
-) ([]models.Experiment, int, int, error) {
+) ([]models.Experiment, *request.PageToken, error) {
 	if err := ValidateSearchExperimentsRequest(req); err != nil {
-		return nil, 0, 0, err
+		return nil, nil, err
 	}
 
 	query := database.DB.Where(
@@ -306,34 +306,35 @@ func (s Service) SearchExperiments(
 	query.Limit(limit + 1)
 
 	// PageToken
-	var offset int
+	var token request.PageToken
 	if req.PageToken != "" {
-		var token request.PageToken
 		if err := json.NewDecoder(
 			base64.NewDecoder(
 				base64.StdEncoding,
 				strings.NewReader(req.PageToken),
 			),
 		).Decode(&token); err != nil {
-			return nil, 0, 0, api.NewInvalidParameterValueError("invalid page_token '%s': %s", req.PageToken, err)
+			return nil, nil, api.NewInvalidParameterValueError("invalid page_token '%s': %s", req.PageToken, err)
 		}
-		offset = int(token.Offset)
+		// tokens issued by the older versions contain the offset instead of the keys.
+		query.Offset(int(token.Offset))
 	}
-	query.Offset(offset)
 
 	// Filter
 	if req.Filter != "" {
-		for n, f := range filterAnd.Split(req.Filter, -1) {
-			components := filterCond.FindStringSubmatch(f)
-			if len(components) != 5 {
-				return nil, 0, 0, api.NewInvalidParameterValueError("malformed filter '%s'", f)
+		conditions, err := filter.ParseComparisons(req.Filter)
+		if err != nil {
+			return nil, nil, api.NewInvalidParameterValueError("malformed filter '%s': %s", req.Filter, err)
+		}
+		for n, condition := range conditions {
+			entity := condition.Entity
+			key := condition.Key
+			comparison := condition.Operator
+			var value any
+			if condition.Value != nil {
+				value = condition.Value.Text
 			}
 
-			entity := components[1]
-			key := strings.Trim(components[2], "\"`")
-			comparison := components[3]
-			var value any = components[4]
-
 			switch entity {
 			case "", "attribute", "attributes", "attr":
 				switch key {
@@ -341,35 +342,38 @@ func (s Service) SearchExperiments(
 					switch comparison {
 					case GraterExpression, GraterOrEqualExpression, NotEqualExpression,
 						EqualExpression, LessExpression, LessOrEqualExpression:
-						v, err := strconv.Atoi(value.(string))
+						v, err := strconv.Atoi(condition.Value.Text)
 						if err != nil {
-							return nil, 0, 0, api.NewInvalidParameterValueError("invalid numeric value '%s'", value)
+							return nil, nil, api.NewInvalidParameterValueError(
+								"invalid numeric value '%s'", condition.Value.Raw,
+							)
 						}
 						value = v
 					default:
-						return nil, 0, 0, api.NewInvalidParameterValueError(
+						return nil, nil, api.NewInvalidParameterValueError(
 							"invalid numeric attribute comparison operator '%s'", comparison,
 						)
 					}
 				case "name":
 					switch strings.ToUpper(comparison) {
 					case NotEqualExpression, EqualExpression, LikeExpression, ILikeExpression:
-						if strings.HasPrefix(value.(string), "(") {
-							return nil, 0, 0, api.NewInvalidParameterValueError("invalid string value '%s'", value)
+						if condition.Value.Kind == filter.ListValue {
+							return nil, nil, api.NewInvalidParameterValueError(
+								"invalid string value '%s'", condition.Value.Raw,
+							)
 						}
-						value = strings.Trim(value.(string), `"'`)
 						if database.DB.Dialector.Name() == "sqlite" && strings.ToUpper(comparison) == ILikeExpression {
 							key = fmt.Sprintf("LOWER(%s)", key)
 							comparison = LikeExpression
 							value = strings.ToLower(value.(string))
 						}
 					default:
-						return nil, 0, 0, api.NewInvalidParameterValueError(
+						return nil, nil, api.NewInvalidParameterValueError(
 							"invalid string attribute comparison operator '%s'", comparison,
 						)
 					}
 				default:
-					return nil, 0, 0, api.NewInvalidParameterValueError(
+					return nil, nil, api.NewInvalidParameterValueError(
 						"invalid attribute '%s'. Valid values are ['name', 'creation_time', 'last_update_time']", key,
 					)
 				}
@@ -377,12 +381,11 @@ func (s Service) SearchExperiments(
 			case "tag", "tags":
 				switch strings.ToUpper(comparison) {
 				case NotEqualExpression, EqualExpression, LikeExpression, ILikeExpression:
-					if strings.HasPrefix(value.(string), "(") {
-						return nil, 0, 0, api.NewInvalidParameterValueError("invalid string value '%s'", value)
+					if condition.Value.Kind == filter.ListValue {
+						return nil, nil, api.NewInvalidParameterValueError("invalid string value '%s'", condition.Value.Raw)
 					}
-					value = strings.Trim(value.(string), `"'`)
 				default:
-					return nil, 0, 0, api.NewInvalidParameterValueError("invalid tag comparison operator '%s'", comparison)
+					return nil, nil, api.NewInvalidParameterValueError("invalid tag comparison operator '%s'", comparison)
 				}
 				table := fmt.Sprintf("filter_%d", n)
 				where := fmt.Sprintf("value %s ?", comparison)
@@ -397,7 +400,7 @@ func (s Service) SearchExperiments(
 					).Where("key = ?", key).Where(where, value).Model(&database.ExperimentTag{}),
 				)
 			default:
-				return nil, 0, 0, api.NewInvalidParameterValueError(
+				return nil, nil, api.NewInvalidParameterValueError(
 					"invalid entity type '%s'. Valid values are ['tag', 'attribute']", entity,
 				)
 			}
@@ -405,11 +408,12 @@ func (s Service) SearchExperiments(
 	}
 
 	// OrderBy
+	var columns []pagination.Column
 	expOrder := false
 	for _, o := range req.OrderBy {
 		components := experimentOrder.FindStringSubmatch(o)
 		if len(components) == 0 {
-			return nil, 0, 0, api.NewInvalidParameterValueError("invalid order_by clause '%s'", o)
+			return nil, nil, api.NewInvalidParameterValueError("invalid order_by clause '%s'", o)
 		}
 
 		column := components[1]
@@ -419,29 +423,80 @@ func (s Service) SearchExperiments(
 			fallthrough
 		case "name", "creation_time", "last_update_time":
 		default:
-			return nil, 0, 0, api.NewInvalidParameterValueError(
+			return nil, nil, api.NewInvalidParameterValueError(
 				`invalid attribute '%s'. Valid values are ['name', 'experiment_id', 'creation_time', 'last_update_time']`,
 				column,
 			)
 		}
-		query.Order(clause.OrderByColumn{
-			Column: clause.Column{Name: column},
-			Desc:   len(components) == 3 && strings.ToUpper(components[2]) == "DESC",
+		columns = append(columns, pagination.Column{
+			Column:  clause.Column{Table: "experiments", Name: column},
+			Desc:    len(components) == 3 && strings.ToUpper(components[2]) == "DESC",
+			NotNull: column == "experiment_id",
 		})
-
 	}
 	if len(req.OrderBy) == 0 {
-		query.Order("experiments.creation_time DESC")
+		columns = append(columns, pagination.Column{
+			Column: clause.Column{Table: "experiments", Name: "creation_time"},
+			Desc:   true,
+		})
 	}
 	if !expOrder {
-		query.Order("experiments.experiment_id ASC")
+		columns = append(columns, pagination.Column{
+			Column:  clause.Column{Table: "experiments", Name: "experiment_id"},
+			NotNull: true,
+		})
+	}
+	pagination.Order(query, columns)
+	if len(token.Keys) > 0 {
+		if err := pagination.After(query, columns, token.Keys); err != nil {
+			return nil, nil, api.NewInvalidParameterValueError("invalid page_token '%s': %s", req.PageToken, err)
+		}
 	}
 
 	// Actual query
 	var exps []models.Experiment
 	if err := query.Preload("Tags").Find(&exps).Error; err != nil {
-		return nil, 0, 0, api.NewInternalError("unable to search runs: %s", err)
+		return nil, nil, api.NewInternalError("unable to search runs: %s", err)
+	}
+
+	// NextPageToken
+	if len(exps) <= limit {
+		return exps, nil, nil
+	}
+	exps = exps[:limit]
+	keys, err := pagination.Keys(
+		database.DB.Model(&database.Experiment{}).Where("experiments.experiment_id = ?", exps[limit-1].ID), columns,
+	)
+	if err != nil {
+		return nil, nil, api.NewInternalError("unable to build next_page_token: %s", err)
 	}
 
-	return exps, limit, offset, nil
+	return exps, &request.PageToken{Keys: keys}, nil
+}
+
+// SearchDatasets returns the summaries of the datasets used by the runs of the requested experiments.
+func (s Service) SearchDatasets(
+	ctx context.Context, ns *models.Namespace, req *request.SearchDatasetsRequest,
+) ([]models.DatasetSummary, error) {
+	if err := ValidateSearchDatasetsRequest(req); err != nil {
+		return nil, err
+	}
+
+	experimentIDs := make([]int32, len(req.ExperimentIDs))
+	for n, id := range req.ExperimentIDs {
+		parsedID, err := strconv.ParseInt(id, 10, 32)
+		if err != nil {
+			return nil, api.NewBadRequestError(`unable to parse experiment id '%s': %s`, id, err)
+		}
+		experimentIDs[n] = int32(parsedID)
+		if experimentIDs[n] == models.DefaultExperimentID && ns.DefaultExperimentID != nil {
+			experimentIDs[n] = *ns.DefaultExperimentID
+		}
+	}
+
+	summaries, err := s.inputRepository.SearchDatasets(ctx, ns.ID, experimentIDs)
+	if err != nil {
+		return nil, api.NewInternalError("Unable to search datasets: %s", err)
+	}
+	return summaries, nil
 }
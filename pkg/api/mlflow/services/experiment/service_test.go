@@ -39,6 +39,7 @@ func TestService_CreateExperiment_Ok(t *testing.T) {
 		&config.Config{},
 		&repositories.MockTagRepositoryProvider{},
 		&experimentRepository,
+		&repositories.MockInputRepositoryProvider{},
 	)
 	experiment, err := service.CreateExperiment(context.TODO(), &ns, &request.CreateExperimentRequest{
 		Name: "name",
@@ -88,6 +89,7 @@ func TestService_CreateExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -110,6 +112,7 @@ func TestService_CreateExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -128,6 +131,7 @@ func TestService_CreateExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -146,6 +150,7 @@ func TestService_CreateExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -167,6 +172,7 @@ func TestService_CreateExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -196,6 +202,7 @@ func TestService_CreateExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -239,6 +246,7 @@ func TestService_DeleteExperiment_Ok(t *testing.T) {
 		&config.Config{},
 		&repositories.MockTagRepositoryProvider{},
 		&experimentRepository,
+		&repositories.MockInputRepositoryProvider{},
 	)
 	err := service.DeleteExperiment(context.TODO(), &ns, &request.DeleteExperimentRequest{
 		ID: "1",
@@ -271,6 +279,7 @@ func TestService_DeleteExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -287,6 +296,7 @@ func TestService_DeleteExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -305,6 +315,7 @@ func TestService_DeleteExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -326,6 +337,7 @@ func TestService_DeleteExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -349,6 +361,7 @@ func TestService_DeleteExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -398,6 +411,7 @@ func TestService_GetExperiment_Ok(t *testing.T) {
 		&config.Config{},
 		&repositories.MockTagRepositoryProvider{},
 		&experimentRepository,
+		&repositories.MockInputRepositoryProvider{},
 	)
 	experiment, err := service.GetExperiment(context.TODO(), &ns, &request.GetExperimentRequest{
 		ID: "1",
@@ -446,6 +460,7 @@ func TestService_GetExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -462,6 +477,7 @@ func TestService_GetExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -480,6 +496,7 @@ func TestService_GetExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -530,6 +547,7 @@ func TestService_GetExperimentByName_Ok(t *testing.T) {
 		&config.Config{},
 		&repositories.MockTagRepositoryProvider{},
 		&experimentRepository,
+		&repositories.MockInputRepositoryProvider{},
 	)
 	experiment, err := service.GetExperimentByName(
 		context.TODO(),
@@ -582,6 +600,7 @@ func TestService_GetExperimentByName_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -600,6 +619,7 @@ func TestService_GetExperimentByName_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -618,6 +638,7 @@ func TestService_GetExperimentByName_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -661,6 +682,7 @@ func TestService_RestoreExperiment_Ok(t *testing.T) {
 		&config.Config{},
 		&repositories.MockTagRepositoryProvider{},
 		&experimentRepository,
+		&repositories.MockInputRepositoryProvider{},
 	)
 	err := service.RestoreExperiment(context.TODO(), &ns, &request.RestoreExperimentRequest{
 		ID: "1",
@@ -692,6 +714,7 @@ func TestService_RestoreExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -708,6 +731,7 @@ func TestService_RestoreExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -726,6 +750,7 @@ func TestService_RestoreExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -749,6 +774,7 @@ func TestService_RestoreExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -793,6 +819,7 @@ func TestService_SetExperimentTag_Ok(t *testing.T) {
 		&config.Config{},
 		&tagsRepository,
 		&experimentRepository,
+		&repositories.MockInputRepositoryProvider{},
 	)
 	err := service.SetExperimentTag(context.TODO(), &ns, &request.SetExperimentTagRequest{
 		ID:    "1",
@@ -826,6 +853,7 @@ func TestService_SetExperimentTag_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -840,6 +868,7 @@ func TestService_SetExperimentTag_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -857,6 +886,7 @@ func TestService_SetExperimentTag_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -876,6 +906,7 @@ func TestService_SetExperimentTag_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -904,6 +935,7 @@ func TestService_SetExperimentTag_Error(t *testing.T) {
 					&config.Config{},
 					&tagRepository,
 					&experimentRepository,
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -946,6 +978,7 @@ func TestService_UpdateExperiment_Ok(t *testing.T) {
 		&config.Config{},
 		&repositories.MockTagRepositoryProvider{},
 		&experimentRepository,
+		&repositories.MockInputRepositoryProvider{},
 	)
 	err := service.UpdateExperiment(context.TODO(), &ns, &request.UpdateExperimentRequest{
 		ID:   "1",
@@ -978,6 +1011,7 @@ func TestService_UpdateExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -992,6 +1026,7 @@ func TestService_UpdateExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -1009,6 +1044,7 @@ func TestService_UpdateExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -1028,6 +1064,7 @@ func TestService_UpdateExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -1052,6 +1089,7 @@ func TestService_UpdateExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -1064,3 +1102,92 @@ func TestService_UpdateExperiment_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestService_SearchDatasets_Ok(t *testing.T) {
+	// init repository mocks.
+	inputRepository := repositories.MockInputRepositoryProvider{}
+	inputRepository.On(
+		"SearchDatasets", context.TODO(), uint(1), []int32{5, 2},
+	).Return([]models.DatasetSummary{
+		{
+			ExperimentID: 2,
+			Name:         "name",
+			Digest:       "digest",
+			Context:      "training",
+		},
+	}, nil)
+
+	// call service under testing.
+	service := NewService(
+		&config.Config{},
+		&repositories.MockTagRepositoryProvider{},
+		&repositories.MockExperimentRepositoryProvider{},
+		&inputRepository,
+	)
+	summaries, err := service.SearchDatasets(context.TODO(), &models.Namespace{
+		ID:                  1,
+		DefaultExperimentID: common.GetPointer(int32(5)),
+	}, &request.SearchDatasetsRequest{
+		ExperimentIDs: []string{"0", "2"},
+	})
+
+	// compare results.
+	require.Nil(t, err)
+	assert.Equal(t, []models.DatasetSummary{
+		{
+			ExperimentID: 2,
+			Name:         "name",
+			Digest:       "digest",
+			Context:      "training",
+		},
+	}, summaries)
+}
+
+func TestService_SearchDatasets_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.SearchDatasetsRequest
+		service func() *Service
+	}{
+		{
+			name:    "EmptyExperimentIDs",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'experiment_ids'"),
+			request: &request.SearchDatasetsRequest{},
+			service: func() *Service {
+				return NewService(
+					&config.Config{},
+					&repositories.MockTagRepositoryProvider{},
+					&repositories.MockExperimentRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+				)
+			},
+		},
+		{
+			name:  "DatabaseError",
+			error: api.NewInternalError("Unable to search datasets: database error"),
+			request: &request.SearchDatasetsRequest{
+				ExperimentIDs: []string{"1"},
+			},
+			service: func() *Service {
+				inputRepository := repositories.MockInputRepositoryProvider{}
+				inputRepository.On(
+					"SearchDatasets", context.TODO(), uint(1), []int32{1},
+				).Return(nil, errors.New("database error"))
+				return NewService(
+					&config.Config{},
+					&repositories.MockTagRepositoryProvider{},
+					&repositories.MockExperimentRepositoryProvider{},
+					&inputRepository,
+				)
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.service().SearchDatasets(context.TODO(), &models.Namespace{ID: 1}, tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
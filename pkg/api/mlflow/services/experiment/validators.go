@@ -93,3 +93,11 @@ func ValidateSetExperimentTagRequest(req *request.SetExperimentTagRequest) error
 	}
 	return nil
 }
+
+// ValidateSearchDatasetsRequest validates `POST /mlflow/experiments/search-datasets` request.
+func ValidateSearchDatasetsRequest(req *request.SearchDatasetsRequest) error {
+	if len(req.ExperimentIDs) == 0 {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'experiment_ids'")
+	}
+	return nil
+}
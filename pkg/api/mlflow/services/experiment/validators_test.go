@@ -268,3 +268,15 @@ func TestValidateSetExperimentTagRequest_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateSearchDatasetsRequest_Ok(t *testing.T) {
+	err := ValidateSearchDatasetsRequest(&request.SearchDatasetsRequest{
+		ExperimentIDs: []string{"1"},
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateSearchDatasetsRequest_Error(t *testing.T) {
+	err := ValidateSearchDatasetsRequest(&request.SearchDatasetsRequest{})
+	assert.Equal(t, api.NewInvalidParameterValueError("Missing value for required parameter 'experiment_ids'"), err)
+}
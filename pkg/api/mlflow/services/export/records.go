@@ -0,0 +1,274 @@
+package export
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+	"github.com/rotisserie/eris"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/run"
+	"github.com/G-Research/fasttrackml/pkg/common/metricstore"
+)
+
+// RecordBatchSize is the number of rows in the single exported record batch.
+const RecordBatchSize = 100000
+
+// metricFields contains Arrow fields of the metric history columns.
+var metricFields = map[string]arrow.Field{
+	run.ExportMetricRunID:     {Name: run.ExportMetricRunID, Type: arrow.BinaryTypes.String},
+	run.ExportMetricKey:       {Name: run.ExportMetricKey, Type: arrow.BinaryTypes.String},
+	run.ExportMetricStep:      {Name: run.ExportMetricStep, Type: arrow.PrimitiveTypes.Int64},
+	run.ExportMetricTimestamp: {Name: run.ExportMetricTimestamp, Type: arrow.PrimitiveTypes.Int64},
+	run.ExportMetricValue:     {Name: run.ExportMetricValue, Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	run.ExportMetricContext:   {Name: run.ExportMetricContext, Type: arrow.BinaryTypes.String},
+}
+
+// Rows represents the exported rows. metricstore.Cursor and run.ExportedRuns are the ones.
+type Rows interface {
+	// Next advances to the next row. It returns false when there are no more rows or an error happened.
+	Next() bool
+	// Err returns the error happened during the iteration.
+	Err() error
+	// Close releases the rows.
+	Close() error
+}
+
+// WriteMetrics writes the metrics of the cursor with provided columns in provided format and returns
+// the number of the written metrics. NaN values are written as nulls. The cursor is closed.
+func WriteMetrics(
+	w io.Writer, format request.ExportFormat, cursor metricstore.Cursor, columns []string,
+) (int, error) {
+	fields := make([]arrow.Field, len(columns))
+	for i, column := range columns {
+		fields[i] = metricFields[column]
+	}
+
+	return writeRecords(w, format, arrow.NewSchema(fields, nil), cursor, func(b *array.RecordBuilder) []func() {
+		appenders := make([]func(), len(columns))
+		for i, column := range columns {
+			switch column {
+			case run.ExportMetricRunID:
+				field := b.Field(i).(*array.StringBuilder)
+				appenders[i] = func() { field.Append(cursor.Metric().RunID) }
+			case run.ExportMetricKey:
+				field := b.Field(i).(*array.StringBuilder)
+				appenders[i] = func() { field.Append(cursor.Metric().Key) }
+			case run.ExportMetricStep:
+				field := b.Field(i).(*array.Int64Builder)
+				appenders[i] = func() { field.Append(cursor.Metric().Step) }
+			case run.ExportMetricTimestamp:
+				field := b.Field(i).(*array.Int64Builder)
+				appenders[i] = func() { field.Append(cursor.Metric().Timestamp) }
+			case run.ExportMetricValue:
+				field := b.Field(i).(*array.Float64Builder)
+				appenders[i] = func() {
+					if m := cursor.Metric(); m.IsNan {
+						field.AppendNull()
+					} else {
+						field.Append(m.Value)
+					}
+				}
+			case run.ExportMetricContext:
+				field := b.Field(i).(*array.StringBuilder)
+				appenders[i] = func() { field.Append(string(cursor.Metric().Context)) }
+			}
+		}
+		return appenders
+	})
+}
+
+// WriteRuns writes the exported runs in provided format, single row per run, and returns the number
+// of the written runs. Missing params, tags and metrics are written as nulls, so as NaN metric values.
+// The runs are closed.
+func WriteRuns(w io.Writer, format request.ExportFormat, runs *run.ExportedRuns) (int, error) {
+	fields := make([]arrow.Field, len(runs.Columns))
+	for i, column := range runs.Columns {
+		fields[i] = arrow.Field{Name: column.Name(), Type: arrow.BinaryTypes.String, Nullable: true}
+		switch {
+		case column.Entity == run.ExportEntityMetrics:
+			fields[i].Type = arrow.PrimitiveTypes.Float64
+		case column.Entity == run.ExportEntityAttributes &&
+			(column.Key == run.ExportAttributeStartTime || column.Key == run.ExportAttributeEndTime):
+			fields[i].Type = arrow.PrimitiveTypes.Int64
+		}
+	}
+
+	rows := &runRows{ExportedRuns: runs}
+	return writeRecords(w, format, arrow.NewSchema(fields, nil), rows, func(b *array.RecordBuilder) []func() {
+		appenders := make([]func(), len(runs.Columns))
+		for i, column := range runs.Columns {
+			column := column
+			switch column.Entity {
+			case run.ExportEntityMetrics:
+				field := b.Field(i).(*array.Float64Builder)
+				appenders[i] = func() {
+					if metric, ok := rows.metrics[column.Key]; ok && !metric.IsNan {
+						field.Append(metric.Value)
+					} else {
+						field.AppendNull()
+					}
+				}
+			case run.ExportEntityParams:
+				appenders[i] = newStringAppender(b.Field(i), func() (string, bool) {
+					value, ok := rows.params[column.Key]
+					return value, ok
+				})
+			case run.ExportEntityTags:
+				appenders[i] = newStringAppender(b.Field(i), func() (string, bool) {
+					value, ok := rows.tags[column.Key]
+					return value, ok
+				})
+			default:
+				appenders[i] = newRunAttributeAppender(b.Field(i), column.Key, rows.Run)
+			}
+		}
+		return appenders
+	})
+}
+
+// writeRecords writes the rows as the records with provided schema in provided format and returns the number
+// of the written rows. Each of the appenders created by newAppenders appends the value of the current row
+// to the builder of the single column. The rows are closed.
+func writeRecords(
+	w io.Writer,
+	format request.ExportFormat,
+	schema *arrow.Schema,
+	rows Rows,
+	newAppenders func(b *array.RecordBuilder) []func(),
+) (int, error) {
+	//nolint:errcheck
+	defer rows.Close()
+
+	pool := memory.NewGoAllocator()
+	writer, err := NewRecordWriter(w, format, schema, pool)
+	if err != nil {
+		return 0, err
+	}
+	//nolint:errcheck
+	defer writer.Close()
+
+	b := array.NewRecordBuilder(pool, schema)
+	defer b.Release()
+
+	appenders := newAppenders(b)
+	count := 0
+	for rows.Next() {
+		for _, appendValue := range appenders {
+			appendValue()
+		}
+		if count++; count%RecordBatchSize == 0 {
+			if err := writeRecord(writer, b.NewRecord()); err != nil {
+				return count, err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return count, eris.Wrap(err, "error reading rows")
+	}
+	// the last record is written even if it is empty, so the schema and the header are always written.
+	if count == 0 || count%RecordBatchSize != 0 {
+		if err := writeRecord(writer, b.NewRecord()); err != nil {
+			return count, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return count, eris.Wrap(err, "error closing record writer")
+	}
+	return count, nil
+}
+
+// writeRecord writes the record and releases it.
+func writeRecord(w RecordWriter, r arrow.Record) error {
+	defer r.Release()
+	if err := w.Write(r); err != nil {
+		return eris.Wrap(err, "error writing record batch")
+	}
+	return nil
+}
+
+// runRows represents the exported runs with the values of the current run indexed by key.
+type runRows struct {
+	*run.ExportedRuns
+	metrics map[string]models.LatestMetric
+	params  map[string]string
+	tags    map[string]string
+}
+
+// Next advances to the next run.
+func (r *runRows) Next() bool {
+	if !r.ExportedRuns.Next() {
+		return false
+	}
+	current := r.Run()
+	r.metrics = make(map[string]models.LatestMetric, len(current.LatestMetrics))
+	for _, metric := range current.LatestMetrics {
+		r.metrics[metric.Key] = metric
+	}
+	r.params = make(map[string]string, len(current.Params))
+	for _, param := range current.Params {
+		r.params[param.Key] = param.ValueString()
+	}
+	r.tags = make(map[string]string, len(current.Tags))
+	for _, tag := range current.Tags {
+		r.tags[tag.Key] = tag.Value
+	}
+	return true
+}
+
+// newRunAttributeAppender creates the appender of the run attribute.
+func newRunAttributeAppender(b array.Builder, attribute string, current func() *models.Run) func() {
+	switch attribute {
+	case run.ExportAttributeStartTime, run.ExportAttributeEndTime:
+		field := b.(*array.Int64Builder)
+		return func() {
+			value := current().StartTime
+			if attribute == run.ExportAttributeEndTime {
+				value = current().EndTime
+			}
+			if value.Valid {
+				field.Append(value.Int64)
+			} else {
+				field.AppendNull()
+			}
+		}
+	default:
+		return newStringAppender(b, func() (string, bool) {
+			r := current()
+			switch attribute {
+			case run.ExportAttributeRunID:
+				return r.ID, true
+			case run.ExportAttributeExperimentID:
+				return strconv.FormatInt(int64(r.ExperimentID), 10), true
+			case run.ExportAttributeRunName:
+				return r.Name, true
+			case run.ExportAttributeStatus:
+				return string(r.Status), true
+			case run.ExportAttributeUserID:
+				return r.UserID, true
+			case run.ExportAttributeLifecycleStage:
+				return string(r.LifecycleStage), true
+			case run.ExportAttributeArtifactURI:
+				return r.ArtifactURI, true
+			}
+			return "", false
+		})
+	}
+}
+
+// newStringAppender creates the appender of the string value, which is null when it is not found.
+func newStringAppender(b array.Builder, value func() (string, bool)) func() {
+	field := b.(*array.StringBuilder)
+	return func() {
+		if v, ok := value(); ok {
+			field.Append(v)
+		} else {
+			field.AppendNull()
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"testing"
+
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+	"github.com/apache/arrow/go/v14/parquet"
+	"github.com/apache/arrow/go/v14/parquet/pqarrow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+	"github.com/G-Research/fasttrackml/pkg/common/metricstore"
+)
+
+func TestWriteMetrics_CSV_Ok(t *testing.T) {
+	buf := new(bytes.Buffer)
+	count, err := WriteMetrics(buf, request.ExportFormatCSV, metricstore.NewSliceCursor([]metricstore.Metric{
+		{RunID: "id1", Key: "loss", Step: 1, Timestamp: 10, Value: 1.5, Context: types.JSONB(`{"subset":"train"}`)},
+		{RunID: "id1", Key: "loss", Step: 2, Timestamp: 20, IsNan: true, Context: types.JSONB(`{}`)},
+	}), []string{"run_id", "key", "step", "timestamp", "value", "context"})
+	require.Nil(t, err)
+	assert.Equal(t, 2, count)
+
+	records, err := csv.NewReader(buf).ReadAll()
+	require.Nil(t, err)
+	assert.Equal(t, [][]string{
+		{"run_id", "key", "step", "timestamp", "value", "context"},
+		{"id1", "loss", "1", "10", "1.5", `{"subset":"train"}`},
+		{"id1", "loss", "2", "20", "", "{}"},
+	}, records)
+}
+
+func TestWriteMetrics_Empty_Ok(t *testing.T) {
+	// the header is written even if there are no metrics.
+	buf := new(bytes.Buffer)
+	count, err := WriteMetrics(buf, request.ExportFormatCSV, metricstore.NewSliceCursor(nil), []string{"run_id", "value"})
+	require.Nil(t, err)
+	assert.Equal(t, 0, count)
+	assert.Equal(t, "run_id,value\n", buf.String())
+}
+
+func TestWriteMetrics_Parquet_Ok(t *testing.T) {
+	// the metrics are written in several record batches.
+	metrics := make([]metricstore.Metric, RecordBatchSize+1)
+	for i := range metrics {
+		metrics[i] = metricstore.Metric{RunID: fmt.Sprintf("id%d", i%2), Key: "loss", Step: int64(i), Value: float64(i)}
+	}
+
+	buf := new(bytes.Buffer)
+	count, err := WriteMetrics(
+		buf, request.ExportFormatParquet, metricstore.NewSliceCursor(metrics), []string{"run_id", "step", "value"},
+	)
+	require.Nil(t, err)
+	assert.Equal(t, len(metrics), count)
+
+	table, err := pqarrow.ReadTable(
+		context.Background(),
+		bytes.NewReader(buf.Bytes()),
+		parquet.NewReaderProperties(memory.DefaultAllocator),
+		pqarrow.ArrowReadProperties{},
+		memory.DefaultAllocator,
+	)
+	require.Nil(t, err)
+	defer table.Release()
+
+	assert.Equal(t, int64(len(metrics)), table.NumRows())
+	assert.Equal(t, "run_id", table.Schema().Field(0).Name)
+	chunks := table.Column(1).Data().Chunks()
+	steps := chunks[len(chunks)-1].(*array.Int64)
+	assert.Equal(t, int64(RecordBatchSize), steps.Value(steps.Len()-1))
+}
+
+func TestNewRecordWriter_Error(t *testing.T) {
+	_, err := NewRecordWriter(new(bytes.Buffer), request.ExportFormat("xlsx"), nil, memory.DefaultAllocator)
+	assert.EqualError(t, err, "unsupported export format 'xlsx'")
+}
@@ -0,0 +1,260 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/run"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+)
+
+const (
+	// exportRetention is how long the finished exports are kept by the database.
+	exportRetention = 24 * time.Hour
+	// exportFinishTimeout is how long the result of the export could be written to the database.
+	exportFinishTimeout = 10 * time.Second
+)
+
+// Service provides service layer to work with the background exports. The exported data is written
+// to the temporary file first and then uploaded to the artifact storage, so neither the runs nor
+// the metrics are held in memory.
+type Service struct {
+	// ctx is the context of the background exports, which are cancelled together with it.
+	ctx                    context.Context
+	config                 *config.Config
+	runService             *run.Service
+	exportRepository       repositories.ExportRepositoryProvider
+	artifactStorageFactory storage.ArtifactStorageFactoryProvider
+	// running holds a slot of each running export. It is nil when the number of exports is not limited.
+	running chan struct{}
+}
+
+// NewService creates new Service instance.
+func NewService(
+	ctx context.Context,
+	config *config.Config,
+	runService *run.Service,
+	exportRepository repositories.ExportRepositoryProvider,
+	artifactStorageFactory storage.ArtifactStorageFactoryProvider,
+) *Service {
+	var running chan struct{}
+	if config.ExportMaxRunning > 0 {
+		running = make(chan struct{}, config.ExportMaxRunning)
+	}
+	return &Service{
+		ctx:                    ctx,
+		config:                 config,
+		runService:             runService,
+		exportRepository:       exportRepository,
+		artifactStorageFactory: artifactStorageFactory,
+		running:                running,
+	}
+}
+
+// CreateExport starts the background export requested by CreateExportRequest.
+func (s Service) CreateExport(
+	ctx context.Context, namespace *models.Namespace, req *request.CreateExportRequest,
+) (*models.Export, error) {
+	if err := ValidateCreateExportRequest(req); err != nil {
+		return nil, err
+	}
+
+	// the slot is released once the export is finished, or right away when the export isn't started.
+	if !s.acquire() {
+		return nil, api.NewResourceExhaustedError(
+			"unable to create export: %d exports are already running", s.config.ExportMaxRunning,
+		)
+	}
+	started := false
+	defer func() {
+		if !started {
+			s.release()
+		}
+	}()
+
+	// the files are written to the exports directory of the namespace, so the clients can't write them
+	// to the artifacts of the other namespaces or outside of the artifact root.
+	destination := strings.TrimRight(s.config.DefaultArtifactRoot, "/") + "/exports/" + namespace.Code
+	if req.Destination != "" {
+		destination += "/" + path.Clean(filepath.ToSlash(req.Destination))
+	}
+	artifactStorage, err := s.artifactStorageFactory.GetStorage(ctx, destination)
+	if err != nil {
+		return nil, api.NewInvalidParameterValueError("unsupported destination '%s': %s", destination, err)
+	}
+
+	id := strings.ReplaceAll(uuid.New().String(), "-", "")
+	name := id + FileExtension(req.Format)
+	export := models.Export{
+		ID:           id,
+		NamespaceID:  namespace.ID,
+		Table:        string(req.Table),
+		Format:       string(req.Format),
+		URI:          strings.TrimRight(destination, "/") + "/" + name,
+		Status:       models.ExportStatusRunning,
+		CreationTime: time.Now().UnixMilli(),
+	}
+	if export.Format == "" {
+		export.Format = string(request.ExportFormatArrow)
+	}
+	if err := s.exportRepository.DeleteFinishedBefore(
+		ctx, time.Now().Add(-exportRetention).UnixMilli(),
+	); err != nil {
+		return nil, api.NewInternalError("unable to delete expired exports: %s", err)
+	}
+	// the export is created before the rows are requested, as the metrics cursor keeps its connection
+	// until the export is finished.
+	if err := s.exportRepository.Create(ctx, &export); err != nil {
+		return nil, api.NewInternalError("unable to create export: %s", err)
+	}
+
+	// the rows are requested right away to report invalid requests, but they are read by the export,
+	// which outlives the request.
+	write, err := s.getWriter(namespace, req)
+	if err != nil {
+		s.finish(export, 0, err)
+		return nil, err
+	}
+
+	started = true
+	go func() {
+		defer s.release()
+		rows, err := s.upload(
+			storage.WithNamespace(s.ctx, namespace.Code), artifactStorage, destination, name, write,
+		)
+		if err != nil {
+			log.Errorf("error exporting %s to %s: %+v", export.Table, export.URI, err)
+		}
+		s.finish(export, rows, err)
+	}()
+	return &export, nil
+}
+
+// GetExport returns the export requested by GetExportRequest.
+func (s Service) GetExport(
+	ctx context.Context, namespace *models.Namespace, req *request.GetExportRequest,
+) (*models.Export, error) {
+	if err := ValidateGetExportRequest(req); err != nil {
+		return nil, err
+	}
+
+	export, err := s.exportRepository.GetByNamespaceIDAndID(ctx, namespace.ID, req.ID)
+	if err != nil {
+		return nil, api.NewInternalError("unable to get export '%s': %s", req.ID, err)
+	}
+	if export == nil {
+		return nil, api.NewResourceDoesNotExistError("unable to find export '%s'", req.ID)
+	}
+	return export, nil
+}
+
+// getWriter requests the exported rows and returns the function, which writes them in the requested format.
+func (s Service) getWriter(
+	namespace *models.Namespace, req *request.CreateExportRequest,
+) (func(w io.Writer) (int, error), error) {
+	switch req.Table {
+	case request.ExportTableRuns, request.ExportTableLatestMetrics:
+		if req.Table == request.ExportTableLatestMetrics && len(req.Columns) == 0 {
+			req.Columns = []string{run.ExportAttributeRunID, run.ExportEntityMetrics + ".*"}
+		}
+		runs, err := s.runService.ExportRuns(s.ctx, namespace, &req.ExportRunsRequest)
+		if err != nil {
+			return nil, err
+		}
+		return func(w io.Writer) (int, error) {
+			return WriteRuns(w, req.Format, runs)
+		}, nil
+	default:
+		cursor, columns, err := s.runService.ExportRunMetrics(s.ctx, namespace, &req.ExportRunsRequest)
+		if err != nil {
+			return nil, err
+		}
+		return func(w io.Writer) (int, error) {
+			return WriteMetrics(w, req.Format, cursor, columns)
+		}, nil
+	}
+}
+
+// upload writes the exported data to the temporary file and uploads the file to the artifact storage.
+// The file is used, because the artifact storages need the size of the uploaded data.
+func (s Service) upload(
+	ctx context.Context,
+	artifactStorage storage.ArtifactStorageProvider,
+	destination, name string,
+	write func(w io.Writer) (int, error),
+) (int, error) {
+	file, err := os.CreateTemp("", "export-*-"+name)
+	if err != nil {
+		return 0, eris.Wrap(err, "error creating temporary file")
+	}
+	//nolint:errcheck
+	defer os.Remove(file.Name())
+	//nolint:errcheck
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+	rows, err := write(w)
+	if err != nil {
+		return rows, err
+	}
+	if err := w.Flush(); err != nil {
+		return rows, eris.Wrap(err, "error writing temporary file")
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return rows, eris.Wrap(err, "error reading temporary file")
+	}
+
+	if err := artifactStorage.Put(ctx, destination, name, file); err != nil {
+		return rows, eris.Wrap(err, "error uploading exported file")
+	}
+	return rows, nil
+}
+
+// finish keeps the result of the export. The export could be cancelled together with the server,
+// so the result is kept with its own context.
+func (s Service) finish(export models.Export, rows int, exportErr error) {
+	export.Rows, export.Status, export.FinishTime = rows, models.ExportStatusCompleted, time.Now().UnixMilli()
+	if exportErr != nil {
+		export.Status, export.Error = models.ExportStatusFailed, exportErr.Error()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), exportFinishTimeout)
+	defer cancel()
+	if err := s.exportRepository.Finish(ctx, &export); err != nil {
+		log.Errorf("error keeping result of export %s: %+v", export.ID, err)
+	}
+}
+
+// acquire takes a slot of the running export and returns false when all the slots are taken.
+func (s Service) acquire() bool {
+	if s.running == nil {
+		return true
+	}
+	select {
+	case s.running <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// release frees the slot of the finished export.
+func (s Service) release() {
+	if s.running != nil {
+		<-s.running
+	}
+}
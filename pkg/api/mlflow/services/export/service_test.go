@@ -0,0 +1,32 @@
+package export
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+func TestService_CreateExport_ResourceExhausted_Error(t *testing.T) {
+	service := NewService(context.Background(), &config.Config{ExportMaxRunning: 1}, nil, nil, nil)
+	// the only slot is held by the running export.
+	require.True(t, service.acquire())
+
+	export, err := service.CreateExport(
+		context.Background(), &models.Namespace{Code: "default"}, &request.CreateExportRequest{
+			Table: request.ExportTableRuns,
+		},
+	)
+	assert.Nil(t, export)
+	assert.Equal(t, api.NewResourceExhaustedError("unable to create export: 1 exports are already running"), err)
+
+	// the slot of the rejected export isn't taken, so the next export starts once the running one is finished.
+	service.release()
+	require.True(t, service.acquire())
+}
@@ -0,0 +1,40 @@
+package export
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+// AllowedExportTableList supported list of ExportTable.
+var AllowedExportTableList = map[request.ExportTable]struct{}{
+	request.ExportTableRuns:          {},
+	request.ExportTableMetrics:       {},
+	request.ExportTableLatestMetrics: {},
+}
+
+// ValidateCreateExportRequest validates `POST /mlflow/exports/create` request.
+func ValidateCreateExportRequest(req *request.CreateExportRequest) error {
+	if req.Table == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'table'")
+	}
+	if _, ok := AllowedExportTableList[req.Table]; !ok {
+		return api.NewInvalidParameterValueError("Invalid table '%s'", req.Table)
+	}
+	if req.Destination != "" && (strings.Contains(req.Destination, ":") || !filepath.IsLocal(req.Destination)) {
+		return api.NewInvalidParameterValueError(
+			"Invalid destination '%s'. It has to be a relative path in the exports directory", req.Destination,
+		)
+	}
+	return nil
+}
+
+// ValidateGetExportRequest validates `GET /mlflow/exports/get` request.
+func ValidateGetExportRequest(req *request.GetExportRequest) error {
+	if req.ID == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'export_id'")
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package export
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+func TestValidateCreateExportRequest_Ok(t *testing.T) {
+	err := ValidateCreateExportRequest(&request.CreateExportRequest{
+		Table:       request.ExportTableLatestMetrics,
+		Destination: "daily/metrics",
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateCreateExportRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.CreateExportRequest
+	}{
+		{
+			name:    "EmptyTable",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'table'"),
+			request: &request.CreateExportRequest{},
+		},
+		{
+			name:  "NotAllowedTableProperty",
+			error: api.NewInvalidParameterValueError("Invalid table 'not-allowed-table'"),
+			request: &request.CreateExportRequest{
+				Table: request.ExportTable("not-allowed-table"),
+			},
+		},
+		{
+			name: "DestinationURI",
+			error: api.NewInvalidParameterValueError(
+				"Invalid destination 's3://bucket/exports'. It has to be a relative path in the exports directory",
+			),
+			request: &request.CreateExportRequest{
+				Table:       request.ExportTableRuns,
+				Destination: "s3://bucket/exports",
+			},
+		},
+		{
+			name: "DestinationOutsideExports",
+			error: api.NewInvalidParameterValueError(
+				"Invalid destination '../../other/exports'. It has to be a relative path in the exports directory",
+			),
+			request: &request.CreateExportRequest{
+				Table:       request.ExportTableRuns,
+				Destination: "../../other/exports",
+			},
+		},
+		{
+			name: "AbsoluteDestination",
+			error: api.NewInvalidParameterValueError(
+				"Invalid destination '/tmp/exports'. It has to be a relative path in the exports directory",
+			),
+			request: &request.CreateExportRequest{
+				Table:       request.ExportTableRuns,
+				Destination: "/tmp/exports",
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCreateExportRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestValidateGetExportRequest_Ok(t *testing.T) {
+	err := ValidateGetExportRequest(&request.GetExportRequest{
+		ID: "id",
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateGetExportRequest_Error(t *testing.T) {
+	err := ValidateGetExportRequest(&request.GetExportRequest{})
+	assert.Equal(t, api.NewInvalidParameterValueError("Missing value for required parameter 'export_id'"), err)
+}
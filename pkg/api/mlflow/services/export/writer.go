@@ -0,0 +1,84 @@
+package export
+
+import (
+	"io"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/csv"
+	"github.com/apache/arrow/go/v14/arrow/ipc"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+	"github.com/apache/arrow/go/v14/parquet"
+	"github.com/apache/arrow/go/v14/parquet/compress"
+	"github.com/apache/arrow/go/v14/parquet/pqarrow"
+	"github.com/rotisserie/eris"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+)
+
+// RecordWriter writes Arrow records in one of the export formats.
+type RecordWriter interface {
+	// Write writes the record.
+	Write(rec arrow.Record) error
+	// Close flushes the written records and finishes the output. The underlying writer is not closed.
+	Close() error
+}
+
+// NewRecordWriter creates RecordWriter of the records with provided schema in provided format.
+func NewRecordWriter(
+	w io.Writer, format request.ExportFormat, schema *arrow.Schema, pool memory.Allocator,
+) (RecordWriter, error) {
+	switch format {
+	case request.ExportFormatArrow, "":
+		return ipc.NewWriter(w, ipc.WithAllocator(pool), ipc.WithSchema(schema)), nil
+	case request.ExportFormatCSV:
+		return csvWriter{csv.NewWriter(w, schema, csv.WithHeader(true), csv.WithNullWriter(""))}, nil
+	case request.ExportFormatParquet:
+		// parquet writer closes the underlying writer, so it is hidden behind io.Writer.
+		writer, err := pqarrow.NewFileWriter(
+			schema,
+			struct{ io.Writer }{w},
+			parquet.NewWriterProperties(parquet.WithCompression(compress.Codecs.Snappy), parquet.WithAllocator(pool)),
+			pqarrow.NewArrowWriterProperties(pqarrow.WithAllocator(pool)),
+		)
+		if err != nil {
+			return nil, eris.Wrap(err, "error creating parquet writer")
+		}
+		return writer, nil
+	default:
+		return nil, eris.Errorf("unsupported export format '%s'", format)
+	}
+}
+
+// ContentType returns the content type of the data exported in provided format.
+func ContentType(format request.ExportFormat) string {
+	switch format {
+	case request.ExportFormatCSV:
+		return "text/csv"
+	case request.ExportFormatParquet:
+		return "application/vnd.apache.parquet"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// FileExtension returns the extension of the file exported in provided format.
+func FileExtension(format request.ExportFormat) string {
+	switch format {
+	case request.ExportFormatCSV:
+		return ".csv"
+	case request.ExportFormatParquet:
+		return ".parquet"
+	default:
+		return ".arrow"
+	}
+}
+
+// csvWriter adapts csv.Writer to RecordWriter.
+type csvWriter struct {
+	*csv.Writer
+}
+
+// Close flushes the written records.
+func (w csvWriter) Close() error {
+	return w.Flush()
+}
@@ -0,0 +1,281 @@
+// Package ingestion buffers metrics logged by `LogMetric` and `LogBatch` requests and writes them
+// to the database asynchronously, in large transactions.
+package ingestion
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/events"
+	"github.com/G-Research/fasttrackml/pkg/common/services/live"
+)
+
+const (
+	// batchSize is a number of rows inserted by one statement.
+	batchSize = 1000
+	// maxFlushAttempts is a number of attempts to write metrics of the batch before they are dropped.
+	maxFlushAttempts = 3
+)
+
+var (
+	// ErrQueueFull is returned when the queue has no room for the metrics, clients should retry later.
+	ErrQueueFull = eris.New("metric ingestion queue is full")
+	// ErrQueueClosed is returned when the queue has been drained and doesn't accept metrics anymore.
+	ErrQueueClosed = eris.New("metric ingestion queue is closed")
+)
+
+// QueueProvider provides an interface to write metrics asynchronously.
+type QueueProvider interface {
+	// Enqueue adds metrics of the run to the queue. Metrics are written to the database later.
+	Enqueue(namespaceID uint, run *models.Run, metrics []models.Metric) error
+	// Drain stops the queue and writes all the buffered metrics to the database.
+	Drain(ctx context.Context) error
+}
+
+// pendingBatch represents metrics of the run enqueued between two flushes. The attempts are counted
+// per batch, so metrics enqueued after the failed flush get all the attempts of their own.
+type pendingBatch struct {
+	metrics  []models.Metric
+	attempts int
+}
+
+// pendingRun represents metrics of the run waiting to be written.
+type pendingRun struct {
+	namespaceID uint
+	run         *models.Run
+	// batches are ordered from the oldest ones, so metrics are written in order.
+	batches []pendingBatch
+}
+
+// metrics returns metrics of all the batches.
+func (p *pendingRun) metrics() []models.Metric {
+	if len(p.batches) == 1 {
+		return p.batches[0].metrics
+	}
+	var metrics []models.Metric
+	for _, batch := range p.batches {
+		metrics = append(metrics, batch.metrics...)
+	}
+	return metrics
+}
+
+// Queue coalesces metrics of the same run across requests and writes them in one transaction
+// when either flush interval elapses or number of buffered metrics reaches flush size.
+// The number of buffered metrics is bounded by the queue size, including metrics being written.
+type Queue struct {
+	mu               sync.Mutex
+	size             int
+	flushSize        int
+	maxSize          int
+	flushInterval    time.Duration
+	pending          map[string]*pendingRun
+	closed           bool
+	flush            chan struct{}
+	done             chan struct{}
+	stopped          chan struct{}
+	metricRepository repositories.MetricRepositoryProvider
+	liveUpdatesHub   live.HubProvider
+}
+
+// NewQueue creates new Queue instance.
+func NewQueue(config *config.Config, metricRepository repositories.MetricRepositoryProvider) *Queue {
+	return &Queue{
+		maxSize:          config.MetricQueueSize,
+		flushSize:        config.MetricQueueFlushSize,
+		flushInterval:    config.MetricQueueFlushInterval,
+		pending:          make(map[string]*pendingRun),
+		flush:            make(chan struct{}, 1),
+		done:             make(chan struct{}),
+		stopped:          make(chan struct{}),
+		metricRepository: metricRepository,
+	}
+}
+
+// WithLiveUpdates enables publishing of written metrics to the live updates subscribers.
+func (q *Queue) WithLiveUpdates(hub live.HubProvider) *Queue {
+	q.liveUpdatesHub = hub
+	return q
+}
+
+// Run runs the queue background job, which flushes the buffered metrics.
+func (q *Queue) Run() {
+	go func() {
+		defer close(q.stopped)
+		ticker := time.NewTicker(q.flushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-q.done:
+				log.Debug("metric ingestion queue stopped. exiting.")
+				return
+			case <-ticker.C:
+				q.flushPending(context.Background())
+			case <-q.flush:
+				q.flushPending(context.Background())
+			}
+		}
+	}()
+}
+
+// Enqueue adds metrics of the run to the queue. Metrics are written to the database later.
+func (q *Queue) Enqueue(namespaceID uint, run *models.Run, metrics []models.Metric) error {
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+	if q.size+len(metrics) > q.maxSize {
+		return ErrQueueFull
+	}
+
+	pending, ok := q.pending[run.ID]
+	if !ok {
+		pending = &pendingRun{namespaceID: namespaceID, run: run, batches: []pendingBatch{{}}}
+		q.pending[run.ID] = pending
+	}
+	// metrics enqueued since the last flush make the last batch, which hasn't been attempted yet.
+	if last := &pending.batches[len(pending.batches)-1]; last.attempts == 0 {
+		last.metrics = append(last.metrics, metrics...)
+	} else {
+		pending.batches = append(pending.batches, pendingBatch{metrics: metrics})
+	}
+	q.size += len(metrics)
+	if q.size >= q.flushSize {
+		select {
+		case q.flush <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Drain stops the queue and writes all the buffered metrics to the database. Metrics which are still
+// buffered when the context is done are lost.
+func (q *Queue) Drain(ctx context.Context) error {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return nil
+	}
+	q.closed = true
+	q.mu.Unlock()
+
+	// wait for the background flush to finish, so metrics are written in order.
+	close(q.done)
+	select {
+	case <-q.stopped:
+	case <-ctx.Done():
+		return eris.Wrap(ctx.Err(), "error waiting for metric ingestion queue to stop")
+	}
+
+	for q.Len() > 0 {
+		if ctx.Err() != nil {
+			return eris.Wrapf(ctx.Err(), "error draining metric ingestion queue, %d metrics were lost", q.Len())
+		}
+		q.flushPending(ctx)
+	}
+	return nil
+}
+
+// Len returns the number of buffered metrics.
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.size
+}
+
+// flushPending writes all the buffered metrics in one transaction. When the transaction fails,
+// metrics of each run are written separately, so one failing run doesn't block the others.
+func (q *Queue) flushPending(ctx context.Context) {
+	q.mu.Lock()
+	pending := q.pending
+	q.pending = make(map[string]*pendingRun)
+	q.mu.Unlock()
+	if len(pending) == 0 {
+		return
+	}
+
+	runsMetrics := make([]repositories.RunMetrics, 0, len(pending))
+	for _, item := range pending {
+		runsMetrics = append(runsMetrics, repositories.RunMetrics{Run: item.run, Metrics: item.metrics()})
+	}
+	start := time.Now()
+	err := q.metricRepository.CreateRunsBatch(ctx, batchSize, runsMetrics)
+	if err == nil {
+		for _, item := range pending {
+			q.complete(item)
+		}
+		log.Debugf("metric ingestion queue wrote metrics of %d runs in %s", len(pending), time.Since(start))
+		return
+	}
+	log.Warnf("error writing metrics of %d runs, retrying them one by one: %+v", len(pending), err)
+
+	for _, item := range pending {
+		if err := q.metricRepository.CreateRunsBatch(ctx, batchSize, []repositories.RunMetrics{
+			{Run: item.run, Metrics: item.metrics()},
+		}); err != nil {
+			q.retry(item, err)
+			continue
+		}
+		q.complete(item)
+	}
+}
+
+// complete releases the room taken by the written metrics and publishes them to the live updates subscribers.
+func (q *Queue) complete(item *pendingRun) {
+	metrics := item.metrics()
+	q.mu.Lock()
+	q.size -= len(metrics)
+	q.mu.Unlock()
+
+	if q.liveUpdatesHub == nil {
+		return
+	}
+	event := events.NewRunMetricsEvent(item.namespaceID, item.run.ID, metrics)
+	if err := q.liveUpdatesHub.Publish(&event); err != nil {
+		log.Warnf("error publishing live update of run '%s': %+v", item.run.ID, err)
+	}
+}
+
+// retry puts metrics of the run back to the queue, ahead of the metrics enqueued in the meantime.
+// Metrics of the batch are dropped when they can't be written after maxFlushAttempts.
+func (q *Queue) retry(item *pendingRun, err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	batches := make([]pendingBatch, 0, len(item.batches))
+	for _, batch := range item.batches {
+		batch.attempts++
+		if batch.attempts >= maxFlushAttempts {
+			q.size -= len(batch.metrics)
+			log.Errorf(
+				"error writing %d metrics of run '%s', metrics were dropped after %d attempts: %+v",
+				len(batch.metrics), item.run.ID, batch.attempts, err,
+			)
+			continue
+		}
+		log.Warnf(
+			"error writing %d metrics of run '%s', attempt %d: %+v", len(batch.metrics), item.run.ID, batch.attempts, err,
+		)
+		batches = append(batches, batch)
+	}
+	if pending, ok := q.pending[item.run.ID]; ok {
+		batches = append(batches, pending.batches...)
+	}
+	if len(batches) == 0 {
+		return
+	}
+	item.batches = batches
+	q.pending[item.run.ID] = item
+}
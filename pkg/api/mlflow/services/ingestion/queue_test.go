@@ -0,0 +1,173 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+func newTestQueue(metricRepository repositories.MetricRepositoryProvider, size int) *Queue {
+	queue := NewQueue(&config.Config{
+		MetricQueueSize:          size,
+		MetricQueueFlushSize:     size,
+		MetricQueueFlushInterval: time.Hour,
+	}, metricRepository)
+	queue.Run()
+	return queue
+}
+
+func TestQueue_Drain_Ok(t *testing.T) {
+	run1, run2 := &models.Run{ID: "run1"}, &models.Run{ID: "run2"}
+
+	// init repository mocks.
+	metricRepository := repositories.MockMetricRepositoryProvider{}
+	metricRepository.On(
+		"CreateRunsBatch",
+		mock.Anything,
+		batchSize,
+		mock.MatchedBy(func(runsMetrics []repositories.RunMetrics) bool {
+			keys := map[string][]string{}
+			for _, runMetrics := range runsMetrics {
+				for _, metric := range runMetrics.Metrics {
+					keys[runMetrics.Run.ID] = append(keys[runMetrics.Run.ID], metric.Key)
+				}
+			}
+			return assert.ObjectsAreEqual(map[string][]string{
+				"run1": {"loss", "loss", "accuracy"},
+				"run2": {"loss"},
+			}, keys)
+		}),
+	).Return(nil).Once()
+
+	// metrics of the same run are coalesced across the requests and written in one transaction.
+	queue := newTestQueue(&metricRepository, 10)
+	require.Nil(t, queue.Enqueue(1, run1, []models.Metric{{RunID: "run1", Key: "loss", Step: 1}}))
+	require.Nil(t, queue.Enqueue(1, run2, []models.Metric{{RunID: "run2", Key: "loss", Step: 1}}))
+	require.Nil(t, queue.Enqueue(1, run1, []models.Metric{
+		{RunID: "run1", Key: "loss", Step: 2},
+		{RunID: "run1", Key: "accuracy", Step: 2},
+	}))
+	assert.Equal(t, 4, queue.Len())
+
+	require.Nil(t, queue.Drain(context.Background()))
+	assert.Equal(t, 0, queue.Len())
+	metricRepository.AssertExpectations(t)
+
+	// drained queue doesn't accept metrics anymore.
+	assert.Equal(t, ErrQueueClosed, queue.Enqueue(1, run1, []models.Metric{{RunID: "run1", Key: "loss"}}))
+}
+
+func TestQueue_Enqueue_Error(t *testing.T) {
+	run := &models.Run{ID: "run1"}
+	metricRepository := repositories.MockMetricRepositoryProvider{}
+	queue := newTestQueue(&metricRepository, 2)
+
+	require.Nil(t, queue.Enqueue(1, run, []models.Metric{{RunID: "run1", Key: "loss", Step: 1}}))
+	// metrics which don't fit into the queue are rejected as a whole.
+	assert.Equal(t, ErrQueueFull, queue.Enqueue(1, run, []models.Metric{
+		{RunID: "run1", Key: "loss", Step: 2},
+		{RunID: "run1", Key: "loss", Step: 3},
+	}))
+	assert.Equal(t, 1, queue.Len())
+}
+
+func TestQueue_Drain_Error(t *testing.T) {
+	run1, run2 := &models.Run{ID: "run1"}, &models.Run{ID: "run2"}
+	matchRuns := func(runIDs ...string) any {
+		return mock.MatchedBy(func(runsMetrics []repositories.RunMetrics) bool {
+			if len(runsMetrics) != len(runIDs) {
+				return false
+			}
+			for i := range runIDs {
+				if runsMetrics[i].Run.ID != runIDs[i] {
+					return false
+				}
+			}
+			return true
+		})
+	}
+
+	// init repository mocks. metrics of run2 can't be written, so they fail the whole transaction.
+	metricRepository := repositories.MockMetricRepositoryProvider{}
+	metricRepository.On(
+		"CreateRunsBatch", mock.Anything, batchSize, mock.MatchedBy(func(runsMetrics []repositories.RunMetrics) bool {
+			return len(runsMetrics) == 2
+		}),
+	).Return(errors.New("database error")).Once()
+	metricRepository.On(
+		"CreateRunsBatch", mock.Anything, batchSize, matchRuns("run1"),
+	).Return(nil).Once()
+	metricRepository.On(
+		"CreateRunsBatch", mock.Anything, batchSize, matchRuns("run2"),
+	).Return(errors.New("database error"))
+
+	queue := newTestQueue(&metricRepository, 10)
+	require.Nil(t, queue.Enqueue(1, run1, []models.Metric{{RunID: "run1", Key: "loss"}}))
+	require.Nil(t, queue.Enqueue(1, run2, []models.Metric{{RunID: "run2", Key: "loss"}}))
+
+	// metrics of run1 are written separately, metrics of run2 are dropped after all the attempts.
+	require.Nil(t, queue.Drain(context.Background()))
+	assert.Equal(t, 0, queue.Len())
+	metricRepository.AssertExpectations(t)
+	// the first flush writes both runs and then each run separately,
+	// the next flushes write run2 as a whole and then separately.
+	metricRepository.AssertNumberOfCalls(t, "CreateRunsBatch", 3+2*(maxFlushAttempts-1))
+}
+
+func TestQueue_Retry_Ok(t *testing.T) {
+	run := &models.Run{ID: "run1"}
+	matchKeys := func(keys ...string) any {
+		return mock.MatchedBy(func(runsMetrics []repositories.RunMetrics) bool {
+			var metricKeys []string
+			for _, runMetrics := range runsMetrics {
+				for _, metric := range runMetrics.Metrics {
+					metricKeys = append(metricKeys, metric.Key)
+				}
+			}
+			return assert.ObjectsAreEqual(keys, metricKeys)
+		})
+	}
+
+	// init repository mocks. metrics of the old batch can't be written, so they fail the whole transaction.
+	metricRepository := repositories.MockMetricRepositoryProvider{}
+	metricRepository.On(
+		"CreateRunsBatch", mock.Anything, batchSize, matchKeys("old"),
+	).Return(errors.New("database error"))
+	metricRepository.On(
+		"CreateRunsBatch", mock.Anything, batchSize, matchKeys("old", "new"),
+	).Return(errors.New("database error"))
+	metricRepository.On(
+		"CreateRunsBatch", mock.Anything, batchSize, matchKeys("new"),
+	).Return(nil).Once()
+
+	// the queue isn't run, so the metrics are flushed by the test only.
+	queue := NewQueue(&config.Config{
+		MetricQueueSize:          10,
+		MetricQueueFlushSize:     10,
+		MetricQueueFlushInterval: time.Hour,
+	}, &metricRepository)
+	require.Nil(t, queue.Enqueue(1, run, []models.Metric{{RunID: "run1", Key: "old"}}))
+	for i := 0; i < maxFlushAttempts-1; i++ {
+		queue.flushPending(context.Background())
+	}
+	assert.Equal(t, 1, queue.Len())
+
+	// metrics enqueued after the failed flush are written together with the retried ones, but they
+	// aren't dropped together with them.
+	require.Nil(t, queue.Enqueue(1, run, []models.Metric{{RunID: "run1", Key: "new"}}))
+	queue.flushPending(context.Background())
+	assert.Equal(t, 1, queue.Len())
+
+	queue.flushPending(context.Background())
+	assert.Equal(t, 0, queue.Len())
+	metricRepository.AssertExpectations(t)
+}
@@ -2,12 +2,12 @@ package metric
 
 import (
 	"context"
-	"database/sql"
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/metricstore"
 )
 
 // Service provides service layer to work with `metric` business logic.
@@ -75,13 +75,13 @@ func (s Service) GetMetricHistoryBulk(
 
 func (s Service) GetMetricHistories(
 	ctx context.Context, namespace *models.Namespace, req *request.GetMetricHistoriesRequest,
-) (*sql.Rows, func(*sql.Rows, interface{}) error, error) {
+) (metricstore.Cursor, error) {
 	adjustGetMetricHistoriesRequestForNamespace(namespace, req)
 	if err := ValidateGetMetricHistoriesRequest(req); err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	rows, iterator, err := s.metricRepository.GetMetricHistories(
+	cursor, err := s.metricRepository.GetMetricHistories(
 		ctx,
 		namespace.ID,
 		req.ExperimentIDs,
@@ -92,8 +92,8 @@ func (s Service) GetMetricHistories(
 		req.Context,
 	)
 	if err != nil {
-		return nil, nil, api.NewInternalError("Unable to search runs: %s", err)
+		return nil, api.NewInternalError("Unable to search runs: %s", err)
 	}
 
-	return rows, iterator, nil
+	return cursor, nil
 }
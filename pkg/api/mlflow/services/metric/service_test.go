@@ -2,7 +2,6 @@ package metric
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"testing"
 
@@ -15,6 +14,7 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/metricstore"
 )
 
 func TestService_GetMetricHistory_Ok(t *testing.T) {
@@ -269,12 +269,11 @@ func TestService_GetMetricHistoryBulk_Error(t *testing.T) {
 
 func TestNewService_GetMetricHistories_Ok(t *testing.T) {
 	testData := []struct {
-		name         string
-		namespace    *models.Namespace
-		request      *request.GetMetricHistoriesRequest
-		expectedRows *sql.Rows
-		expectedErr  error
-		expectedIter func(*sql.Rows, interface{}) error
+		name           string
+		namespace      *models.Namespace
+		request        *request.GetMetricHistoriesRequest
+		expectedCursor metricstore.Cursor
+		expectedErr    error
 	}{
 		{
 			name: "WithExplicitExperimentIDs",
@@ -288,11 +287,8 @@ func TestNewService_GetMetricHistories_Ok(t *testing.T) {
 				ViewType:      request.ViewTypeActiveOnly,
 				MaxResults:    1,
 			},
-			expectedRows: &sql.Rows{},
-			expectedIter: func(*sql.Rows, interface{}) error {
-				return nil
-			},
-			expectedErr: nil,
+			expectedCursor: metricstore.NewSliceCursor(nil),
+			expectedErr:    nil,
 		},
 		{
 			name: "WithDefaultExperimentID",
@@ -306,11 +302,8 @@ func TestNewService_GetMetricHistories_Ok(t *testing.T) {
 				ViewType:      request.ViewTypeActiveOnly,
 				MaxResults:    1,
 			},
-			expectedRows: &sql.Rows{},
-			expectedIter: func(*sql.Rows, interface{}) error {
-				return nil
-			},
-			expectedErr: nil,
+			expectedCursor: metricstore.NewSliceCursor(nil),
+			expectedErr:    nil,
 		},
 	}
 
@@ -337,19 +330,16 @@ func TestNewService_GetMetricHistories_Ok(t *testing.T) {
 				int32(1),
 				map[string]string(nil),
 			).Return(
-				tt.expectedRows,
-				tt.expectedIter,
+				tt.expectedCursor,
 				nil,
 			)
 
 			// call service under testing.
 			service := NewService(&runRepository, &metricRepository)
-			//nolint:rowserrcheck,sqlclosecheck
-			rows, iterator, err := service.GetMetricHistories(context.TODO(), tt.namespace, tt.request)
+			cursor, err := service.GetMetricHistories(context.TODO(), tt.namespace, tt.request)
 			assert.Equal(t, tt.expectedErr, err)
-			assert.Equal(t, tt.expectedRows, rows)
-			require.Nil(t, rows.Err())
-			assert.NotNil(t, iterator)
+			assert.Equal(t, tt.expectedCursor, cursor)
+			require.Nil(t, cursor.Err())
 		})
 	}
 }
@@ -426,7 +416,6 @@ func TestNewService_GetMetricHistories_Error(t *testing.T) {
 					int32(1),
 					map[string]string(nil),
 				).Return(
-					nil,
 					nil,
 					errors.New("database error"),
 				)
@@ -438,8 +427,7 @@ func TestNewService_GetMetricHistories_Error(t *testing.T) {
 	for _, tt := range testData {
 		t.Run(tt.name, func(t *testing.T) {
 			// call service under testing.
-			//nolint:rowserrcheck,sqlclosecheck
-			_, _, err := tt.service().GetMetricHistories(context.TODO(), &models.Namespace{ID: 1}, tt.request)
+			_, err := tt.service().GetMetricHistories(context.TODO(), &models.Namespace{ID: 1}, tt.request)
 			assert.Equal(t, tt.error, err)
 		})
 	}
@@ -0,0 +1,194 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/filter"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+var modelOrder = regexp.MustCompile(`^(?:attr(?:ibutes?)?\.)?(\w+)(?i:\s+(ASC|DESC))?$`)
+
+// supported expression list.
+const (
+	InExpression       = "IN"
+	LikeExpression     = "LIKE"
+	ILikeExpression    = "ILIKE"
+	EqualExpression    = "="
+	NotEqualExpression = "!="
+)
+
+// filterAttribute describes attribute, which could be used in the search filter.
+type filterAttribute struct {
+	column    string
+	operators []string
+}
+
+// registeredModelFilterAttributes is the list of attributes supported by registered models search.
+var registeredModelFilterAttributes = map[string]filterAttribute{
+	"name": {
+		column:    "name",
+		operators: []string{EqualExpression, NotEqualExpression, LikeExpression, ILikeExpression},
+	},
+}
+
+// modelVersionFilterAttributes is the list of attributes supported by model versions search.
+var modelVersionFilterAttributes = map[string]filterAttribute{
+	"name": {
+		column:    "registered_models.name",
+		operators: []string{EqualExpression, NotEqualExpression, LikeExpression, ILikeExpression},
+	},
+	"run_id": {
+		column:    "run_uuid",
+		operators: []string{EqualExpression, NotEqualExpression, InExpression},
+	},
+	"source_path": {
+		column:    "source",
+		operators: []string{EqualExpression, NotEqualExpression, LikeExpression, ILikeExpression},
+	},
+}
+
+// registeredModelOrderColumns is the list of columns supported by registered models search ordering.
+var registeredModelOrderColumns = map[string]string{
+	"name":                   "name",
+	"timestamp":              "created_at",
+	"creation_timestamp":     "created_at",
+	"last_updated_timestamp": "updated_at",
+}
+
+// modelVersionOrderColumns is the list of columns supported by model versions search ordering.
+var modelVersionOrderColumns = map[string]string{
+	"name":                   "registered_models.name",
+	"version_number":         "version",
+	"timestamp":              "created_at",
+	"creation_timestamp":     "created_at",
+	"last_updated_timestamp": "updated_at",
+}
+
+// parseFilter converts string filter into the list of repositories.ModelSearchFilter.
+func parseFilter(expression string, attributes map[string]filterAttribute) ([]repositories.ModelSearchFilter, error) {
+	if expression == "" {
+		return nil, nil
+	}
+
+	conditions, err := filter.ParseComparisons(expression)
+	if err != nil {
+		return nil, api.NewInvalidParameterValueError("malformed filter '%s': %s", expression, err)
+	}
+
+	var filters []repositories.ModelSearchFilter
+	for _, condition := range conditions {
+		key, comparison := condition.Key, condition.Operator
+		switch condition.Entity {
+		case "", "attribute", "attributes", "attr":
+			attribute, ok := attributes[key]
+			if !ok {
+				return nil, api.NewInvalidParameterValueError(
+					"invalid attribute '%s'. Valid values are %v", key, attributeNames(attributes),
+				)
+			}
+			if !slices.Contains(attribute.operators, comparison) {
+				return nil, api.NewInvalidParameterValueError(
+					"invalid comparison operator '%s' for attribute '%s'", comparison, key,
+				)
+			}
+			value, err := parseFilterValue(comparison, condition.Value)
+			if err != nil {
+				return nil, err
+			}
+			filters = append(filters, repositories.ModelSearchFilter{
+				Column:   attribute.column,
+				Operator: comparison,
+				Value:    value,
+			})
+		case "tag", "tags":
+			switch comparison {
+			case EqualExpression, NotEqualExpression, LikeExpression, ILikeExpression:
+			default:
+				return nil, api.NewInvalidParameterValueError("invalid tag comparison operator '%s'", comparison)
+			}
+			value, err := parseFilterValue(comparison, condition.Value)
+			if err != nil {
+				return nil, err
+			}
+			filters = append(filters, repositories.ModelSearchFilter{
+				TagKey:   key,
+				Operator: comparison,
+				Value:    value,
+			})
+		default:
+			return nil, api.NewInvalidParameterValueError(
+				"invalid entity type '%s'. Valid values are ['tag', 'attribute']", condition.Entity,
+			)
+		}
+	}
+	return filters, nil
+}
+
+// parseFilterValue converts parsed filter value into the value suitable for the comparison.
+func parseFilterValue(comparison string, value *filter.Value) (any, error) {
+	switch {
+	case comparison == InExpression && value.Kind == filter.ListValue:
+		return value.Strings(), nil
+	case comparison == InExpression:
+		return []string{value.Text}, nil
+	case value.Kind == filter.ListValue:
+		return nil, api.NewInvalidParameterValueError("invalid string value '%s'", value.Raw)
+	default:
+		return value.Text, nil
+	}
+}
+
+// parseOrder converts `order_by` clauses into the list of repositories.ModelSearchOrder.
+func parseOrder(orderBy []string, columns map[string]string) ([]repositories.ModelSearchOrder, error) {
+	order := make([]repositories.ModelSearchOrder, 0, len(orderBy))
+	for _, o := range orderBy {
+		components := modelOrder.FindStringSubmatch(strings.TrimSpace(o))
+		if len(components) == 0 {
+			return nil, api.NewInvalidParameterValueError("invalid order_by clause '%s'", o)
+		}
+		column, ok := columns[components[1]]
+		if !ok {
+			return nil, api.NewInvalidParameterValueError("invalid order_by attribute '%s'", components[1])
+		}
+		order = append(order, repositories.ModelSearchOrder{
+			Column: column,
+			Desc:   strings.ToUpper(components[2]) == "DESC",
+		})
+	}
+	return order, nil
+}
+
+// parsePageToken decodes `page_token` parameter into the offset.
+func parsePageToken(pageToken string) (int, error) {
+	if pageToken == "" {
+		return 0, nil
+	}
+	var token request.PageToken
+	if err := json.NewDecoder(
+		base64.NewDecoder(
+			base64.StdEncoding,
+			strings.NewReader(pageToken),
+		),
+	).Decode(&token); err != nil {
+		return 0, api.NewInvalidParameterValueError("invalid page_token '%s': %s", pageToken, err)
+	}
+	return int(token.Offset), nil
+}
+
+// attributeNames returns the list of supported attribute names formatted for the error message.
+func attributeNames(attributes map[string]filterAttribute) string {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, fmt.Sprintf("'%s'", name))
+	}
+	slices.Sort(names)
+	return fmt.Sprintf("[%s]", strings.Join(names, ", "))
+}
@@ -2,26 +2,586 @@ package model
 
 import (
 	"context"
+	"strconv"
+	"time"
 
-	"github.com/gofiber/fiber/v2"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+const (
+	DefaultRegisteredModelsPerPage = 100
+	DefaultModelVersionsPerPage    = 10000
 )
 
 // Service provides service layer to work with `model` business logic.
-type Service struct{}
+type Service struct {
+	runRepository             repositories.RunRepositoryProvider
+	modelVersionRepository    repositories.ModelVersionRepositoryProvider
+	registeredModelRepository repositories.RegisteredModelRepositoryProvider
+}
 
 // NewService creates new Service instance.
-func NewService() *Service {
-	return &Service{}
+func NewService(
+	runRepository repositories.RunRepositoryProvider,
+	modelVersionRepository repositories.ModelVersionRepositoryProvider,
+	registeredModelRepository repositories.RegisteredModelRepositoryProvider,
+) *Service {
+	return &Service{
+		runRepository:             runRepository,
+		modelVersionRepository:    modelVersionRepository,
+		registeredModelRepository: registeredModelRepository,
+	}
+}
+
+// CreateRegisteredModel creates new models.RegisteredModel entity.
+func (s Service) CreateRegisteredModel(
+	ctx context.Context, ns *models.Namespace, req *request.CreateRegisteredModelRequest,
+) (*models.RegisteredModel, error) {
+	if err := ValidateCreateRegisteredModelRequest(req); err != nil {
+		return nil, err
+	}
+
+	registeredModel, err := s.registeredModelRepository.GetByNamespaceIDAndName(ctx, ns.ID, req.Name)
+	if err != nil {
+		return nil, api.NewInternalError("error getting registered model with name: '%s', error: %s", req.Name, err)
+	}
+	if registeredModel != nil {
+		return nil, api.NewResourceAlreadyExistsError("Registered Model (name=%s) already exists.", req.Name)
+	}
+
+	registeredModel = &models.RegisteredModel{
+		Name:        req.Name,
+		Description: req.Description,
+		NamespaceID: ns.ID,
+		Tags:        make([]models.RegisteredModelTag, len(req.Tags)),
+	}
+	for n, tag := range req.Tags {
+		registeredModel.Tags[n] = models.RegisteredModelTag{
+			Key:   tag.Key,
+			Value: tag.Value,
+		}
+	}
+	if err := s.registeredModelRepository.Create(ctx, registeredModel); err != nil {
+		return nil, api.NewInternalError("error creating registered model '%s': %s", req.Name, err)
+	}
+	return registeredModel, nil
+}
+
+// GetRegisteredModel returns existing models.RegisteredModel entity by its name.
+func (s Service) GetRegisteredModel(
+	ctx context.Context, ns *models.Namespace, req *request.GetRegisteredModelRequest,
+) (*models.RegisteredModel, error) {
+	if err := ValidateGetRegisteredModelRequest(req); err != nil {
+		return nil, err
+	}
+	return s.getRegisteredModel(ctx, ns, req.Name)
+}
+
+// RenameRegisteredModel renames existing models.RegisteredModel entity.
+func (s Service) RenameRegisteredModel(
+	ctx context.Context, ns *models.Namespace, req *request.RenameRegisteredModelRequest,
+) (*models.RegisteredModel, error) {
+	if err := ValidateRenameRegisteredModelRequest(req); err != nil {
+		return nil, err
+	}
+
+	registeredModel, err := s.getRegisteredModel(ctx, ns, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.registeredModelRepository.GetByNamespaceIDAndName(ctx, ns.ID, req.NewName)
+	if err != nil {
+		return nil, api.NewInternalError(
+			"error getting registered model with name: '%s', error: %s", req.NewName, err,
+		)
+	}
+	if existing != nil {
+		return nil, api.NewResourceAlreadyExistsError("Registered Model (name=%s) already exists.", req.NewName)
+	}
+
+	registeredModel.Name = req.NewName
+	registeredModel.UpdatedAt = time.Now()
+	if err := s.registeredModelRepository.Update(ctx, registeredModel); err != nil {
+		return nil, api.NewInternalError("error renaming registered model '%s': %s", req.Name, err)
+	}
+	return registeredModel, nil
+}
+
+// UpdateRegisteredModel updates description of existing models.RegisteredModel entity.
+func (s Service) UpdateRegisteredModel(
+	ctx context.Context, ns *models.Namespace, req *request.UpdateRegisteredModelRequest,
+) (*models.RegisteredModel, error) {
+	if err := ValidateUpdateRegisteredModelRequest(req); err != nil {
+		return nil, err
+	}
+
+	registeredModel, err := s.getRegisteredModel(ctx, ns, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	registeredModel.Description = req.Description
+	registeredModel.UpdatedAt = time.Now()
+	if err := s.registeredModelRepository.Update(ctx, registeredModel); err != nil {
+		return nil, api.NewInternalError("error updating registered model '%s': %s", req.Name, err)
+	}
+	return registeredModel, nil
+}
+
+// DeleteRegisteredModel removes existing models.RegisteredModel entity with all its versions.
+func (s Service) DeleteRegisteredModel(
+	ctx context.Context, ns *models.Namespace, req *request.DeleteRegisteredModelRequest,
+) error {
+	if err := ValidateDeleteRegisteredModelRequest(req); err != nil {
+		return err
+	}
+
+	registeredModel, err := s.getRegisteredModel(ctx, ns, req.Name)
+	if err != nil {
+		return err
+	}
+	if err := s.registeredModelRepository.Delete(ctx, registeredModel); err != nil {
+		return api.NewInternalError("error deleting registered model '%s': %s", req.Name, err)
+	}
+	return nil
+}
+
+// SearchRegisteredModels returns the list of models.RegisteredModel entities which satisfy the request.
+func (s Service) SearchRegisteredModels(
+	ctx context.Context, ns *models.Namespace, req *request.SearchRegisteredModelsRequest,
+) ([]models.RegisteredModel, int, int, error) {
+	if err := ValidateSearchRegisteredModelsRequest(req); err != nil {
+		return nil, 0, 0, err
+	}
+
+	filters, err := parseFilter(req.Filter, registeredModelFilterAttributes)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	order, err := parseOrder(req.OrderBy, registeredModelOrderColumns)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if len(order) == 0 {
+		order = append(order, repositories.ModelSearchOrder{Column: "name"})
+	}
+	order = append(order, repositories.ModelSearchOrder{Column: "id"})
+
+	offset, err := parsePageToken(req.PageToken)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	limit := int(req.MaxResults)
+	if limit == 0 {
+		limit = DefaultRegisteredModelsPerPage
+	}
+
+	registeredModels, err := s.registeredModelRepository.Search(ctx, ns.ID, filters, order, limit+1, offset)
+	if err != nil {
+		return nil, 0, 0, api.NewInternalError("unable to search registered models: %s", err)
+	}
+	return registeredModels, limit, offset, nil
+}
+
+// GetLatestVersions returns existing models.RegisteredModel entity to get the latest versions from.
+func (s Service) GetLatestVersions(
+	ctx context.Context, ns *models.Namespace, req *request.GetLatestVersionsRequest,
+) (*models.RegisteredModel, []models.ModelVersionStage, error) {
+	if err := ValidateGetLatestVersionsRequest(req); err != nil {
+		return nil, nil, err
+	}
+
+	registeredModel, err := s.getRegisteredModel(ctx, ns, req.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stages := make([]models.ModelVersionStage, len(req.Stages))
+	for n, stage := range req.Stages {
+		stages[n], _ = models.ParseModelVersionStage(stage)
+	}
+	return registeredModel, stages, nil
+}
+
+// SetRegisteredModelTag creates or updates models.RegisteredModelTag entity.
+func (s Service) SetRegisteredModelTag(
+	ctx context.Context, ns *models.Namespace, req *request.SetRegisteredModelTagRequest,
+) error {
+	if err := ValidateSetRegisteredModelTagRequest(req); err != nil {
+		return err
+	}
+
+	registeredModel, err := s.getRegisteredModel(ctx, ns, req.Name)
+	if err != nil {
+		return err
+	}
+	if err := s.registeredModelRepository.SetTag(ctx, &models.RegisteredModelTag{
+		Key:               req.Key,
+		Value:             req.Value,
+		RegisteredModelID: registeredModel.ID,
+	}); err != nil {
+		return api.NewInternalError("error setting tag '%s' for registered model '%s': %s", req.Key, req.Name, err)
+	}
+	return nil
+}
+
+// DeleteRegisteredModelTag removes existing models.RegisteredModelTag entity.
+func (s Service) DeleteRegisteredModelTag(
+	ctx context.Context, ns *models.Namespace, req *request.DeleteRegisteredModelTagRequest,
+) error {
+	if err := ValidateDeleteRegisteredModelTagRequest(req); err != nil {
+		return err
+	}
+
+	registeredModel, err := s.getRegisteredModel(ctx, ns, req.Name)
+	if err != nil {
+		return err
+	}
+	if err := s.registeredModelRepository.DeleteTag(ctx, &models.RegisteredModelTag{
+		Key:               req.Key,
+		RegisteredModelID: registeredModel.ID,
+	}); err != nil {
+		return api.NewInternalError(
+			"error deleting tag '%s' for registered model '%s': %s", req.Key, req.Name, err,
+		)
+	}
+	return nil
+}
+
+// SetRegisteredModelAlias creates or updates models.RegisteredModelAlias entity.
+func (s Service) SetRegisteredModelAlias(
+	ctx context.Context, ns *models.Namespace, req *request.SetRegisteredModelAliasRequest,
+) error {
+	if err := ValidateSetRegisteredModelAliasRequest(req); err != nil {
+		return err
+	}
+
+	modelVersion, err := s.getModelVersion(ctx, ns, req.Name, req.Version)
+	if err != nil {
+		return err
+	}
+	if err := s.registeredModelRepository.SetAlias(ctx, &models.RegisteredModelAlias{
+		Alias:             req.Alias,
+		Version:           modelVersion.Version,
+		RegisteredModelID: modelVersion.RegisteredModelID,
+	}); err != nil {
+		return api.NewInternalError(
+			"error setting alias '%s' for registered model '%s': %s", req.Alias, req.Name, err,
+		)
+	}
+	return nil
 }
 
-func (s Service) SearchModelVersions(ctx context.Context) (any, error) {
-	return fiber.Map{
-		"model_versions": []any{},
-	}, nil
+// DeleteRegisteredModelAlias removes existing models.RegisteredModelAlias entity.
+func (s Service) DeleteRegisteredModelAlias(
+	ctx context.Context, ns *models.Namespace, req *request.DeleteRegisteredModelAliasRequest,
+) error {
+	if err := ValidateDeleteRegisteredModelAliasRequest(req); err != nil {
+		return err
+	}
+
+	registeredModel, err := s.getRegisteredModel(ctx, ns, req.Name)
+	if err != nil {
+		return err
+	}
+	if err := s.registeredModelRepository.DeleteAlias(ctx, &models.RegisteredModelAlias{
+		Alias:             req.Alias,
+		RegisteredModelID: registeredModel.ID,
+	}); err != nil {
+		return api.NewInternalError(
+			"error deleting alias '%s' for registered model '%s': %s", req.Alias, req.Name, err,
+		)
+	}
+	return nil
+}
+
+// GetModelVersionByAlias returns models.ModelVersion entity the alias points to.
+func (s Service) GetModelVersionByAlias(
+	ctx context.Context, ns *models.Namespace, req *request.GetModelVersionByAliasRequest,
+) (*models.ModelVersion, error) {
+	if err := ValidateGetModelVersionByAliasRequest(req); err != nil {
+		return nil, err
+	}
+
+	registeredModel, err := s.getRegisteredModel(ctx, ns, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	for _, alias := range registeredModel.Aliases {
+		if alias.Alias == req.Alias {
+			return s.getModelVersion(ctx, ns, req.Name, strconv.FormatInt(alias.Version, 10))
+		}
+	}
+	return nil, api.NewResourceDoesNotExistError(
+		"Registered model alias %s not found for model %s.", req.Alias, req.Name,
+	)
+}
+
+// CreateModelVersion creates new models.ModelVersion entity.
+func (s Service) CreateModelVersion(
+	ctx context.Context, ns *models.Namespace, req *request.CreateModelVersionRequest,
+) (*models.ModelVersion, error) {
+	if err := ValidateCreateModelVersionRequest(req); err != nil {
+		return nil, err
+	}
+
+	registeredModel, err := s.getRegisteredModel(ctx, ns, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.RunID != "" {
+		run, err := s.runRepository.GetByNamespaceIDAndRunID(ctx, ns.ID, req.RunID)
+		if err != nil {
+			return nil, api.NewInternalError("error getting run with id: '%s', error: %s", req.RunID, err)
+		}
+		if run == nil {
+			return nil, api.NewResourceDoesNotExistError("Run with id=%s not found", req.RunID)
+		}
+	}
+
+	modelVersion := models.ModelVersion{
+		RegisteredModelID: registeredModel.ID,
+		RegisteredModel:   *registeredModel,
+		Description:       req.Description,
+		CurrentStage:      models.ModelVersionStageNone,
+		Source:            req.Source,
+		RunID:             req.RunID,
+		RunLink:           req.RunLink,
+		Status:            models.ModelVersionStatusReady,
+		Tags:              make([]models.ModelVersionTag, len(req.Tags)),
+	}
+	for n, tag := range req.Tags {
+		modelVersion.Tags[n] = models.ModelVersionTag{
+			Key:   tag.Key,
+			Value: tag.Value,
+		}
+	}
+	if err := s.modelVersionRepository.Create(ctx, &modelVersion); err != nil {
+		return nil, api.NewInternalError("error creating model version for registered model '%s': %s", req.Name, err)
+	}
+	return &modelVersion, nil
+}
+
+// GetModelVersion returns existing models.ModelVersion entity.
+func (s Service) GetModelVersion(
+	ctx context.Context, ns *models.Namespace, req *request.GetModelVersionRequest,
+) (*models.ModelVersion, error) {
+	if err := ValidateGetModelVersionRequest(req); err != nil {
+		return nil, err
+	}
+	return s.getModelVersion(ctx, ns, req.Name, req.Version)
+}
+
+// UpdateModelVersion updates description of existing models.ModelVersion entity.
+func (s Service) UpdateModelVersion(
+	ctx context.Context, ns *models.Namespace, req *request.UpdateModelVersionRequest,
+) (*models.ModelVersion, error) {
+	if err := ValidateUpdateModelVersionRequest(req); err != nil {
+		return nil, err
+	}
+
+	modelVersion, err := s.getModelVersion(ctx, ns, req.Name, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	modelVersion.Description = req.Description
+	modelVersion.UpdatedAt = time.Now()
+	if err := s.modelVersionRepository.Update(ctx, modelVersion); err != nil {
+		return nil, api.NewInternalError(
+			"error updating model version %s for registered model '%s': %s", req.Version, req.Name, err,
+		)
+	}
+	return modelVersion, nil
 }
 
-func (s Service) SearchRegisteredModels(ctx context.Context) (any, error) {
-	return fiber.Map{
-		"registered_models": []any{},
-	}, nil
+// DeleteModelVersion removes existing models.ModelVersion entity.
+func (s Service) DeleteModelVersion(
+	ctx context.Context, ns *models.Namespace, req *request.DeleteModelVersionRequest,
+) error {
+	if err := ValidateDeleteModelVersionRequest(req); err != nil {
+		return err
+	}
+
+	modelVersion, err := s.getModelVersion(ctx, ns, req.Name, req.Version)
+	if err != nil {
+		return err
+	}
+	if err := s.modelVersionRepository.Delete(ctx, modelVersion); err != nil {
+		return api.NewInternalError(
+			"error deleting model version %s for registered model '%s': %s", req.Version, req.Name, err,
+		)
+	}
+	return nil
+}
+
+// SearchModelVersions returns the list of models.ModelVersion entities which satisfy the request.
+func (s Service) SearchModelVersions(
+	ctx context.Context, ns *models.Namespace, req *request.SearchModelVersionsRequest,
+) ([]models.ModelVersion, int, int, error) {
+	if err := ValidateSearchModelVersionsRequest(req); err != nil {
+		return nil, 0, 0, err
+	}
+
+	filters, err := parseFilter(req.Filter, modelVersionFilterAttributes)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	order, err := parseOrder(req.OrderBy, modelVersionOrderColumns)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if len(order) == 0 {
+		order = append(
+			order,
+			repositories.ModelSearchOrder{Column: "registered_models.name"},
+			repositories.ModelSearchOrder{Column: "version", Desc: true},
+		)
+	}
+	order = append(order, repositories.ModelSearchOrder{Column: "id"})
+
+	offset, err := parsePageToken(req.PageToken)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	limit := int(req.MaxResults)
+	if limit == 0 {
+		limit = DefaultModelVersionsPerPage
+	}
+
+	modelVersions, err := s.modelVersionRepository.Search(ctx, ns.ID, filters, order, limit+1, offset)
+	if err != nil {
+		return nil, 0, 0, api.NewInternalError("unable to search model versions: %s", err)
+	}
+	return modelVersions, limit, offset, nil
+}
+
+// GetModelVersionDownloadURI returns existing models.ModelVersion entity to get the download uri from.
+func (s Service) GetModelVersionDownloadURI(
+	ctx context.Context, ns *models.Namespace, req *request.GetModelVersionDownloadURIRequest,
+) (*models.ModelVersion, error) {
+	if err := ValidateGetModelVersionDownloadURIRequest(req); err != nil {
+		return nil, err
+	}
+	return s.getModelVersion(ctx, ns, req.Name, req.Version)
+}
+
+// TransitionModelVersionStage moves existing models.ModelVersion entity to the requested stage.
+func (s Service) TransitionModelVersionStage(
+	ctx context.Context, ns *models.Namespace, req *request.TransitionModelVersionStageRequest,
+) (*models.ModelVersion, error) {
+	if err := ValidateTransitionModelVersionStageRequest(req); err != nil {
+		return nil, err
+	}
+
+	modelVersion, err := s.getModelVersion(ctx, ns, req.Name, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	stage, _ := models.ParseModelVersionStage(req.Stage)
+	if err := s.modelVersionRepository.TransitionStage(
+		ctx, modelVersion, stage, req.ArchiveExistingVersions,
+	); err != nil {
+		return nil, api.NewInternalError(
+			"error transitioning model version %s for registered model '%s': %s", req.Version, req.Name, err,
+		)
+	}
+	return modelVersion, nil
+}
+
+// SetModelVersionTag creates or updates models.ModelVersionTag entity.
+func (s Service) SetModelVersionTag(
+	ctx context.Context, ns *models.Namespace, req *request.SetModelVersionTagRequest,
+) error {
+	if err := ValidateSetModelVersionTagRequest(req); err != nil {
+		return err
+	}
+
+	modelVersion, err := s.getModelVersion(ctx, ns, req.Name, req.Version)
+	if err != nil {
+		return err
+	}
+	if err := s.modelVersionRepository.SetTag(ctx, &models.ModelVersionTag{
+		Key:            req.Key,
+		Value:          req.Value,
+		ModelVersionID: modelVersion.ID,
+	}); err != nil {
+		return api.NewInternalError(
+			"error setting tag '%s' for model version %s of registered model '%s': %s",
+			req.Key, req.Version, req.Name, err,
+		)
+	}
+	return nil
+}
+
+// DeleteModelVersionTag removes existing models.ModelVersionTag entity.
+func (s Service) DeleteModelVersionTag(
+	ctx context.Context, ns *models.Namespace, req *request.DeleteModelVersionTagRequest,
+) error {
+	if err := ValidateDeleteModelVersionTagRequest(req); err != nil {
+		return err
+	}
+
+	modelVersion, err := s.getModelVersion(ctx, ns, req.Name, req.Version)
+	if err != nil {
+		return err
+	}
+	if err := s.modelVersionRepository.DeleteTag(ctx, &models.ModelVersionTag{
+		Key:            req.Key,
+		ModelVersionID: modelVersion.ID,
+	}); err != nil {
+		return api.NewInternalError(
+			"error deleting tag '%s' for model version %s of registered model '%s': %s",
+			req.Key, req.Version, req.Name, err,
+		)
+	}
+	return nil
+}
+
+// getRegisteredModel returns existing models.RegisteredModel entity or `RESOURCE_DOES_NOT_EXIST` error.
+func (s Service) getRegisteredModel(
+	ctx context.Context, ns *models.Namespace, name string,
+) (*models.RegisteredModel, error) {
+	registeredModel, err := s.registeredModelRepository.GetByNamespaceIDAndName(ctx, ns.ID, name)
+	if err != nil {
+		return nil, api.NewInternalError("error getting registered model with name: '%s', error: %s", name, err)
+	}
+	if registeredModel == nil {
+		return nil, api.NewResourceDoesNotExistError("Registered Model with name=%s not found", name)
+	}
+	return registeredModel, nil
+}
+
+// getModelVersion returns existing models.ModelVersion entity or `RESOURCE_DOES_NOT_EXIST` error.
+func (s Service) getModelVersion(
+	ctx context.Context, ns *models.Namespace, name, version string,
+) (*models.ModelVersion, error) {
+	parsedVersion, err := strconv.ParseInt(version, 10, 64)
+	if err != nil {
+		return nil, api.NewInvalidParameterValueError("unable to parse model version '%s': %s", version, err)
+	}
+
+	registeredModel, err := s.getRegisteredModel(ctx, ns, name)
+	if err != nil {
+		return nil, err
+	}
+
+	modelVersion, err := s.modelVersionRepository.GetByRegisteredModelIDAndVersion(
+		ctx, registeredModel.ID, parsedVersion,
+	)
+	if err != nil {
+		return nil, api.NewInternalError(
+			"error getting model version %s for registered model '%s': %s", version, name, err,
+		)
+	}
+	if modelVersion == nil {
+		return nil, api.NewResourceDoesNotExistError("Model Version (name=%s, version=%s) not found", name, version)
+	}
+	return modelVersion, nil
 }
@@ -0,0 +1,379 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+func TestService_CreateRegisteredModel_Ok(t *testing.T) {
+	// initialise namespace to which registered model under the test belongs to.
+	ns := models.Namespace{
+		ID:   1,
+		Code: "code",
+	}
+
+	// init repository mocks.
+	registeredModelRepository := repositories.MockRegisteredModelRepositoryProvider{}
+	registeredModelRepository.On(
+		"GetByNamespaceIDAndName", context.TODO(), ns.ID, "name",
+	).Return(nil, nil)
+	registeredModelRepository.On(
+		"Create", context.TODO(), mock.Anything,
+	).Return(nil)
+
+	// call service under testing.
+	service := NewService(
+		&repositories.MockRunRepositoryProvider{},
+		&repositories.MockModelVersionRepositoryProvider{},
+		&registeredModelRepository,
+	)
+	registeredModel, err := service.CreateRegisteredModel(context.TODO(), &ns, &request.CreateRegisteredModelRequest{
+		Name: "name",
+		Tags: []request.RegisteredModelTagPartialRequest{
+			{
+				Key:   "key",
+				Value: "value",
+			},
+		},
+		Description: "description",
+	})
+
+	// compare results.
+	require.Nil(t, err)
+	assert.Equal(t, "name", registeredModel.Name)
+	assert.Equal(t, "description", registeredModel.Description)
+	assert.Equal(t, ns.ID, registeredModel.NamespaceID)
+	assert.Equal(t, []models.RegisteredModelTag{
+		{
+			Key:   "key",
+			Value: "value",
+		},
+	}, registeredModel.Tags)
+}
+
+func TestService_CreateRegisteredModel_Error(t *testing.T) {
+	// initialise namespace to which registered model under the test belongs to.
+	ns := models.Namespace{
+		ID:   1,
+		Code: "code",
+	}
+
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.CreateRegisteredModelRequest
+		service func() *Service
+	}{
+		{
+			name:    "EmptyName",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'name'"),
+			request: &request.CreateRegisteredModelRequest{},
+			service: func() *Service {
+				return NewService(
+					&repositories.MockRunRepositoryProvider{},
+					&repositories.MockModelVersionRepositoryProvider{},
+					&repositories.MockRegisteredModelRepositoryProvider{},
+				)
+			},
+		},
+		{
+			name:    "RegisteredModelAlreadyExists",
+			error:   api.NewResourceAlreadyExistsError("Registered Model (name=name) already exists."),
+			request: &request.CreateRegisteredModelRequest{Name: "name"},
+			service: func() *Service {
+				registeredModelRepository := repositories.MockRegisteredModelRepositoryProvider{}
+				registeredModelRepository.On(
+					"GetByNamespaceIDAndName", context.TODO(), ns.ID, "name",
+				).Return(&models.RegisteredModel{Name: "name"}, nil)
+				return NewService(
+					&repositories.MockRunRepositoryProvider{},
+					&repositories.MockModelVersionRepositoryProvider{},
+					&registeredModelRepository,
+				)
+			},
+		},
+		{
+			name:    "DatabaseError",
+			error:   api.NewInternalError("error creating registered model 'name': database error"),
+			request: &request.CreateRegisteredModelRequest{Name: "name"},
+			service: func() *Service {
+				registeredModelRepository := repositories.MockRegisteredModelRepositoryProvider{}
+				registeredModelRepository.On(
+					"GetByNamespaceIDAndName", context.TODO(), ns.ID, "name",
+				).Return(nil, nil)
+				registeredModelRepository.On(
+					"Create", context.TODO(), mock.Anything,
+				).Return(errors.New("database error"))
+				return NewService(
+					&repositories.MockRunRepositoryProvider{},
+					&repositories.MockModelVersionRepositoryProvider{},
+					&registeredModelRepository,
+				)
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.service().CreateRegisteredModel(context.TODO(), &ns, tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestService_SearchRegisteredModels_Ok(t *testing.T) {
+	// initialise namespace to which registered models under the test belong to.
+	ns := models.Namespace{
+		ID:   1,
+		Code: "code",
+	}
+
+	// init repository mocks.
+	registeredModelRepository := repositories.MockRegisteredModelRepositoryProvider{}
+	registeredModelRepository.On(
+		"Search",
+		context.TODO(),
+		ns.ID,
+		[]repositories.ModelSearchFilter{
+			{Column: "name", Operator: "ILIKE", Value: "%model%"},
+			{TagKey: "key", Operator: "=", Value: "value"},
+		},
+		[]repositories.ModelSearchOrder{
+			{Column: "updated_at", Desc: true},
+			{Column: "id"},
+		},
+		11,
+		0,
+	).Return([]models.RegisteredModel{{Name: "model"}}, nil)
+
+	// call service under testing.
+	service := NewService(
+		&repositories.MockRunRepositoryProvider{},
+		&repositories.MockModelVersionRepositoryProvider{},
+		&registeredModelRepository,
+	)
+	registeredModels, limit, offset, err := service.SearchRegisteredModels(
+		context.TODO(), &ns, &request.SearchRegisteredModelsRequest{
+			Filter:     "name ILIKE '%model%' AND tags.key = 'value'",
+			MaxResults: 10,
+			OrderBy:    []string{"last_updated_timestamp DESC"},
+		},
+	)
+
+	// compare results.
+	require.Nil(t, err)
+	assert.Equal(t, 10, limit)
+	assert.Equal(t, 0, offset)
+	assert.Equal(t, []models.RegisteredModel{{Name: "model"}}, registeredModels)
+}
+
+func TestService_SearchModelVersions_Error(t *testing.T) {
+	// initialise namespace to which model versions under the test belong to.
+	ns := models.Namespace{
+		ID:   1,
+		Code: "code",
+	}
+
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.SearchModelVersionsRequest
+	}{
+		{
+			name: "MalformedFilter",
+			error: api.NewInvalidParameterValueError(
+				"malformed filter 'name is model': expected NULL but found 'model' at position 9",
+			),
+			request: &request.SearchModelVersionsRequest{Filter: "name is model"},
+		},
+		{
+			name: "UnsupportedAttribute",
+			error: api.NewInvalidParameterValueError(
+				"invalid attribute 'unknown'. Valid values are ['name', 'run_id', 'source_path']",
+			),
+			request: &request.SearchModelVersionsRequest{Filter: "unknown = 'value'"},
+		},
+		{
+			name:    "UnsupportedOperator",
+			error:   api.NewInvalidParameterValueError("invalid comparison operator 'LIKE' for attribute 'run_id'"),
+			request: &request.SearchModelVersionsRequest{Filter: "run_id LIKE '%id%'"},
+		},
+		{
+			name:    "UnsupportedOrder",
+			error:   api.NewInvalidParameterValueError("invalid order_by attribute 'unknown'"),
+			request: &request.SearchModelVersionsRequest{OrderBy: []string{"unknown DESC"}},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewService(
+				&repositories.MockRunRepositoryProvider{},
+				&repositories.MockModelVersionRepositoryProvider{},
+				&repositories.MockRegisteredModelRepositoryProvider{},
+			)
+			_, _, _, err := service.SearchModelVersions(context.TODO(), &ns, tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestService_CreateModelVersion_Ok(t *testing.T) {
+	// initialise namespace to which model version under the test belongs to.
+	ns := models.Namespace{
+		ID:   1,
+		Code: "code",
+	}
+	registeredModel := models.RegisteredModel{
+		Base: models.Base{ID: uuid.New()},
+		Name: "name",
+	}
+
+	// init repository mocks.
+	runRepository := repositories.MockRunRepositoryProvider{}
+	runRepository.On(
+		"GetByNamespaceIDAndRunID", context.TODO(), ns.ID, "run_id",
+	).Return(&models.Run{ID: "run_id"}, nil)
+	registeredModelRepository := repositories.MockRegisteredModelRepositoryProvider{}
+	registeredModelRepository.On(
+		"GetByNamespaceIDAndName", context.TODO(), ns.ID, "name",
+	).Return(&registeredModel, nil)
+	modelVersionRepository := repositories.MockModelVersionRepositoryProvider{}
+	modelVersionRepository.On(
+		"Create", context.TODO(), mock.Anything,
+	).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.ModelVersion).Version = 1
+	}).Return(nil)
+
+	// call service under testing.
+	service := NewService(&runRepository, &modelVersionRepository, &registeredModelRepository)
+	modelVersion, err := service.CreateModelVersion(context.TODO(), &ns, &request.CreateModelVersionRequest{
+		Name:   "name",
+		Source: "s3://bucket/path",
+		RunID:  "run_id",
+	})
+
+	// compare results.
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), modelVersion.Version)
+	assert.Equal(t, registeredModel.ID, modelVersion.RegisteredModelID)
+	assert.Equal(t, "s3://bucket/path", modelVersion.Source)
+	assert.Equal(t, "run_id", modelVersion.RunID)
+	assert.Equal(t, models.ModelVersionStageNone, modelVersion.CurrentStage)
+	assert.Equal(t, models.ModelVersionStatusReady, modelVersion.Status)
+}
+
+func TestService_CreateModelVersion_Error(t *testing.T) {
+	// initialise namespace to which model version under the test belongs to.
+	ns := models.Namespace{
+		ID:   1,
+		Code: "code",
+	}
+
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.CreateModelVersionRequest
+		service func() *Service
+	}{
+		{
+			name:    "RegisteredModelNotFound",
+			error:   api.NewResourceDoesNotExistError("Registered Model with name=name not found"),
+			request: &request.CreateModelVersionRequest{Name: "name", Source: "source"},
+			service: func() *Service {
+				registeredModelRepository := repositories.MockRegisteredModelRepositoryProvider{}
+				registeredModelRepository.On(
+					"GetByNamespaceIDAndName", context.TODO(), ns.ID, "name",
+				).Return(nil, nil)
+				return NewService(
+					&repositories.MockRunRepositoryProvider{},
+					&repositories.MockModelVersionRepositoryProvider{},
+					&registeredModelRepository,
+				)
+			},
+		},
+		{
+			name:    "RunNotFound",
+			error:   api.NewResourceDoesNotExistError("Run with id=run_id not found"),
+			request: &request.CreateModelVersionRequest{Name: "name", Source: "source", RunID: "run_id"},
+			service: func() *Service {
+				runRepository := repositories.MockRunRepositoryProvider{}
+				runRepository.On(
+					"GetByNamespaceIDAndRunID", context.TODO(), ns.ID, "run_id",
+				).Return(nil, nil)
+				registeredModelRepository := repositories.MockRegisteredModelRepositoryProvider{}
+				registeredModelRepository.On(
+					"GetByNamespaceIDAndName", context.TODO(), ns.ID, "name",
+				).Return(&models.RegisteredModel{Name: "name"}, nil)
+				return NewService(
+					&runRepository,
+					&repositories.MockModelVersionRepositoryProvider{},
+					&registeredModelRepository,
+				)
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.service().CreateModelVersion(context.TODO(), &ns, tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestService_TransitionModelVersionStage_Ok(t *testing.T) {
+	// initialise namespace to which model version under the test belongs to.
+	ns := models.Namespace{
+		ID:   1,
+		Code: "code",
+	}
+	registeredModel := models.RegisteredModel{
+		Base: models.Base{ID: uuid.New()},
+		Name: "name",
+	}
+	modelVersion := models.ModelVersion{
+		Version:           1,
+		RegisteredModelID: registeredModel.ID,
+		CurrentStage:      models.ModelVersionStageNone,
+	}
+
+	// init repository mocks.
+	registeredModelRepository := repositories.MockRegisteredModelRepositoryProvider{}
+	registeredModelRepository.On(
+		"GetByNamespaceIDAndName", context.TODO(), ns.ID, "name",
+	).Return(&registeredModel, nil)
+	modelVersionRepository := repositories.MockModelVersionRepositoryProvider{}
+	modelVersionRepository.On(
+		"GetByRegisteredModelIDAndVersion", context.TODO(), registeredModel.ID, int64(1),
+	).Return(&modelVersion, nil)
+	modelVersionRepository.On(
+		"TransitionStage", context.TODO(), &modelVersion, models.ModelVersionStageProduction, true,
+	).Return(nil)
+
+	// call service under testing.
+	service := NewService(
+		&repositories.MockRunRepositoryProvider{}, &modelVersionRepository, &registeredModelRepository,
+	)
+	_, err := service.TransitionModelVersionStage(context.TODO(), &ns, &request.TransitionModelVersionStageRequest{
+		Name:                    "name",
+		Version:                 "1",
+		Stage:                   "production",
+		ArchiveExistingVersions: true,
+	})
+
+	// compare results.
+	require.Nil(t, err)
+	modelVersionRepository.AssertExpectations(t)
+}
@@ -0,0 +1,330 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+func TestValidateCreateRegisteredModelRequest_Ok(t *testing.T) {
+	err := ValidateCreateRegisteredModelRequest(&request.CreateRegisteredModelRequest{
+		Name: "name",
+		Tags: []request.RegisteredModelTagPartialRequest{
+			{
+				Key:   "key",
+				Value: "value",
+			},
+		},
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateCreateRegisteredModelRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.CreateRegisteredModelRequest
+	}{
+		{
+			name:    "EmptyNameProperty",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'name'"),
+			request: &request.CreateRegisteredModelRequest{},
+		},
+		{
+			name:  "EmptyTagKeyProperty",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'key'"),
+			request: &request.CreateRegisteredModelRequest{
+				Name: "name",
+				Tags: []request.RegisteredModelTagPartialRequest{
+					{
+						Value: "value",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCreateRegisteredModelRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestValidateRenameRegisteredModelRequest_Ok(t *testing.T) {
+	err := ValidateRenameRegisteredModelRequest(&request.RenameRegisteredModelRequest{
+		Name:    "name",
+		NewName: "new_name",
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateRenameRegisteredModelRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.RenameRegisteredModelRequest
+	}{
+		{
+			name:    "EmptyNameProperty",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'name'"),
+			request: &request.RenameRegisteredModelRequest{},
+		},
+		{
+			name:  "EmptyNewNameProperty",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'new_name'"),
+			request: &request.RenameRegisteredModelRequest{
+				Name: "name",
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRenameRegisteredModelRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestValidateSearchRegisteredModelsRequest_Error(t *testing.T) {
+	err := ValidateSearchRegisteredModelsRequest(&request.SearchRegisteredModelsRequest{
+		MaxResults: MaxRegisteredModelsPerPage + 1,
+	})
+	assert.Equal(t, api.NewInvalidParameterValueError(
+		"Invalid value for parameter 'max_results' supplied. It must be at most 1000, but got value 1001",
+	), err)
+}
+
+func TestValidateGetLatestVersionsRequest_Ok(t *testing.T) {
+	err := ValidateGetLatestVersionsRequest(&request.GetLatestVersionsRequest{
+		Name:   "name",
+		Stages: []string{"staging", "Production"},
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateGetLatestVersionsRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.GetLatestVersionsRequest
+	}{
+		{
+			name:    "EmptyNameProperty",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'name'"),
+			request: &request.GetLatestVersionsRequest{},
+		},
+		{
+			name: "IncorrectStageProperty",
+			error: api.NewInvalidParameterValueError(
+				"Invalid Model Version stage: unknown. Value must be one of [None Staging Production Archived].",
+			),
+			request: &request.GetLatestVersionsRequest{
+				Name:   "name",
+				Stages: []string{"unknown"},
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateGetLatestVersionsRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestValidateSetRegisteredModelAliasRequest_Ok(t *testing.T) {
+	err := ValidateSetRegisteredModelAliasRequest(&request.SetRegisteredModelAliasRequest{
+		Name:    "name",
+		Alias:   "champion",
+		Version: "1",
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateSetRegisteredModelAliasRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.SetRegisteredModelAliasRequest
+	}{
+		{
+			name:    "EmptyNameProperty",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'name'"),
+			request: &request.SetRegisteredModelAliasRequest{},
+		},
+		{
+			name:  "EmptyAliasProperty",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'alias'"),
+			request: &request.SetRegisteredModelAliasRequest{
+				Name: "name",
+			},
+		},
+		{
+			name: "NumericAliasProperty",
+			error: api.NewInvalidParameterValueError(
+				"Invalid alias name: '1'. Alias can not be a version number or 'latest'",
+			),
+			request: &request.SetRegisteredModelAliasRequest{
+				Name:  "name",
+				Alias: "1",
+			},
+		},
+		{
+			name: "ReservedAliasProperty",
+			error: api.NewInvalidParameterValueError(
+				"Invalid alias name: 'latest'. Alias can not be a version number or 'latest'",
+			),
+			request: &request.SetRegisteredModelAliasRequest{
+				Name:  "name",
+				Alias: "latest",
+			},
+		},
+		{
+			name:  "EmptyVersionProperty",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'version'"),
+			request: &request.SetRegisteredModelAliasRequest{
+				Name:  "name",
+				Alias: "champion",
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSetRegisteredModelAliasRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestValidateCreateModelVersionRequest_Ok(t *testing.T) {
+	err := ValidateCreateModelVersionRequest(&request.CreateModelVersionRequest{
+		Name:   "name",
+		Source: "s3://bucket/path",
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateCreateModelVersionRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.CreateModelVersionRequest
+	}{
+		{
+			name:    "EmptyNameProperty",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'name'"),
+			request: &request.CreateModelVersionRequest{},
+		},
+		{
+			name:  "EmptySourceProperty",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'source'"),
+			request: &request.CreateModelVersionRequest{
+				Name: "name",
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCreateModelVersionRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestValidateGetModelVersionRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.GetModelVersionRequest
+	}{
+		{
+			name:    "EmptyNameProperty",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'name'"),
+			request: &request.GetModelVersionRequest{},
+		},
+		{
+			name:  "EmptyVersionProperty",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'version'"),
+			request: &request.GetModelVersionRequest{
+				Name: "name",
+			},
+		},
+		{
+			name:  "IncorrectVersionProperty",
+			error: api.NewInvalidParameterValueError("Parameter 'version' must be a positive integer, got 'abc'"),
+			request: &request.GetModelVersionRequest{
+				Name:    "name",
+				Version: "abc",
+			},
+		},
+		{
+			name:  "NegativeVersionProperty",
+			error: api.NewInvalidParameterValueError("Parameter 'version' must be a positive integer, got '-1'"),
+			request: &request.GetModelVersionRequest{
+				Name:    "name",
+				Version: "-1",
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateGetModelVersionRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestValidateTransitionModelVersionStageRequest_Ok(t *testing.T) {
+	err := ValidateTransitionModelVersionStageRequest(&request.TransitionModelVersionStageRequest{
+		Name:    "name",
+		Version: "1",
+		Stage:   "production",
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateTransitionModelVersionStageRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.TransitionModelVersionStageRequest
+	}{
+		{
+			name:  "EmptyStageProperty",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'stage'"),
+			request: &request.TransitionModelVersionStageRequest{
+				Name:    "name",
+				Version: "1",
+			},
+		},
+		{
+			name: "IncorrectStageProperty",
+			error: api.NewInvalidParameterValueError(
+				"Invalid Model Version stage: unknown. Value must be one of [None Staging Production Archived].",
+			),
+			request: &request.TransitionModelVersionStageRequest{
+				Name:    "name",
+				Version: "1",
+				Stage:   "unknown",
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTransitionModelVersionStageRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
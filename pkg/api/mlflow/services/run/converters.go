@@ -1,12 +1,15 @@
 package run
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
 )
 
 // adjustSearchRunsRequestForNamespace preprocesses the SearchRunRequest for the given namespace.
@@ -18,6 +21,15 @@ func adjustSearchRunsRequestForNamespace(ns *models.Namespace, req *request.Sear
 	}
 }
 
+// adjustExportRunsRequestForNamespace preprocesses the ExportRunsRequest for the given namespace.
+func adjustExportRunsRequestForNamespace(ns *models.Namespace, req *request.ExportRunsRequest) {
+	for i, expID := range req.ExperimentIDs {
+		if expID == "0" {
+			req.ExperimentIDs[i] = fmt.Sprintf("%d", *ns.DefaultExperimentID)
+		}
+	}
+}
+
 // adjustCreateRunRequestForNamespace preprocesses the CreateRunRequest for the given namespace.
 func adjustCreateRunRequestForNamespace(ns *models.Namespace, req *request.CreateRunRequest) {
 	if req.ExperimentID == "0" {
@@ -42,5 +54,70 @@ func ConvertCreateRunArtifactRequestToModel(
 		Format:  req.Format,
 		Caption: req.Caption,
 		BlobURI: req.BlobURI,
+		Type:    models.ArtifactTypeImages,
+	}
+}
+
+// ConvertLogTextRequestToModel converts request of `POST /runs/log-text` endpoint to an internal Model object.
+func ConvertLogTextRequestToModel(req *request.LogTextRequest) (*models.Artifact, error) {
+	data, err := json.Marshal(models.ArtifactTextData{Text: req.Text})
+	if err != nil {
+		return nil, eris.Wrap(err, "error marshaling text data")
+	}
+	return &models.Artifact{
+		ID:    uuid.New(),
+		Name:  req.Name,
+		Step:  req.Step,
+		RunID: req.RunID,
+		Index: req.Index,
+		Type:  models.ArtifactTypeTexts,
+		Data:  data,
+	}, nil
+}
+
+// ConvertLogAudioRequestToModel converts request of `POST /runs/log-audio` endpoint to an internal Model object.
+func ConvertLogAudioRequestToModel(req *request.LogAudioRequest) *models.Artifact {
+	return &models.Artifact{
+		ID:      uuid.New(),
+		Name:    req.Name,
+		Step:    req.Step,
+		RunID:   req.RunID,
+		Index:   req.Index,
+		Format:  req.Format,
+		Caption: req.Caption,
+		BlobURI: req.BlobURI,
+		Type:    models.ArtifactTypeAudios,
+	}
+}
+
+// ConvertLogDistributionRequestToModel converts request of `POST /runs/log-distribution` endpoint
+// to an internal Model object.
+func ConvertLogDistributionRequestToModel(req *request.LogDistributionRequest) (*models.Artifact, error) {
+	data, err := json.Marshal(models.ArtifactDistributionData{
+		Weights:  req.Weights,
+		BinRange: req.BinRange,
+	})
+	if err != nil {
+		return nil, eris.Wrap(err, "error marshaling distribution data")
+	}
+	return &models.Artifact{
+		ID:    uuid.New(),
+		Name:  req.Name,
+		Step:  req.Step,
+		RunID: req.RunID,
+		Type:  models.ArtifactTypeDistributions,
+		Data:  data,
+	}, nil
+}
+
+// ConvertLogFigureRequestToModel converts request of `POST /runs/log-figure` endpoint to an internal Model object.
+func ConvertLogFigureRequestToModel(req *request.LogFigureRequest) *models.Artifact {
+	return &models.Artifact{
+		ID:    uuid.New(),
+		Name:  req.Name,
+		Step:  req.Step,
+		RunID: req.RunID,
+		Type:  models.ArtifactTypeFigures,
+		Data:  types.JSONB(req.Figure),
 	}
 }
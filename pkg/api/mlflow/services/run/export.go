@@ -0,0 +1,498 @@
+package run
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim/query"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/metricstore"
+	"github.com/G-Research/fasttrackml/pkg/database"
+)
+
+// supported entities of the exported run columns.
+const (
+	ExportEntityAttributes = ""
+	ExportEntityMetrics    = "metrics"
+	ExportEntityParams     = "params"
+	ExportEntityTags       = "tags"
+)
+
+// supported attributes of the exported runs.
+const (
+	ExportAttributeRunID          = "run_id"
+	ExportAttributeExperimentID   = "experiment_id"
+	ExportAttributeRunName        = "run_name"
+	ExportAttributeStatus         = "status"
+	ExportAttributeUserID         = "user_id"
+	ExportAttributeStartTime      = "start_time"
+	ExportAttributeEndTime        = "end_time"
+	ExportAttributeLifecycleStage = "lifecycle_stage"
+	ExportAttributeArtifactURI    = "artifact_uri"
+)
+
+// supported columns of the exported metric histories.
+const (
+	ExportMetricRunID     = "run_id"
+	ExportMetricKey       = "key"
+	ExportMetricStep      = "step"
+	ExportMetricTimestamp = "timestamp"
+	ExportMetricValue     = "value"
+	ExportMetricContext   = "context"
+)
+
+const (
+	// exportAllKeys is the key of the column, which stands for all the keys of the entity, e.g. `params.*`.
+	exportAllKeys = "*"
+	// exportRunsBatchSize is the number of the runs loaded at once by the export.
+	exportRunsBatchSize = 1000
+)
+
+var (
+	// ExportAttributes is the list of the run attributes in the order they are exported by default.
+	ExportAttributes = []string{
+		ExportAttributeRunID,
+		ExportAttributeExperimentID,
+		ExportAttributeRunName,
+		ExportAttributeStatus,
+		ExportAttributeUserID,
+		ExportAttributeStartTime,
+		ExportAttributeEndTime,
+		ExportAttributeLifecycleStage,
+		ExportAttributeArtifactURI,
+	}
+	// ExportMetricColumns is the list of the metric history columns in the order they are exported by default.
+	ExportMetricColumns = []string{
+		ExportMetricRunID,
+		ExportMetricKey,
+		ExportMetricStep,
+		ExportMetricTimestamp,
+		ExportMetricValue,
+		ExportMetricContext,
+	}
+)
+
+// ExportColumn represents the column of the exported runs.
+type ExportColumn struct {
+	// Entity is one of `metrics`, `params` and `tags`. It is empty for the run attributes.
+	Entity string
+	Key    string
+}
+
+// Name returns the name of the column, e.g. `params.lr`.
+func (c ExportColumn) Name() string {
+	if c.Entity == ExportEntityAttributes {
+		return c.Key
+	}
+	return c.Entity + "." + c.Key
+}
+
+// ExportedRuns represents the runs requested by ExportRunsRequest. The runs are loaded in batches together with
+// the params, tags and latest metrics of the exported columns only, so all the runs are never held in memory.
+// Each run contains single latest metric for each key, which is the latest one among all the metric contexts.
+type ExportedRuns struct {
+	Columns []ExportColumn
+	ctx     context.Context
+	ids     []string
+	// relations contains the keys of the preloaded relations. Nil keys mean that all the keys are loaded.
+	relations map[string][]string
+	batch     []models.Run
+	index     int
+	err       error
+}
+
+// Next advances to the next run. It returns false when there are no more runs or an error happened.
+func (r *ExportedRuns) Next() bool {
+	r.index++
+	for r.err == nil && r.index >= len(r.batch) {
+		if len(r.ids) == 0 {
+			return false
+		}
+		n := min(exportRunsBatchSize, len(r.ids))
+		r.batch, r.err = r.load(r.ids[:n])
+		r.ids, r.index = r.ids[n:], 0
+	}
+	return r.err == nil
+}
+
+// Run returns the current run.
+func (r *ExportedRuns) Run() *models.Run {
+	return &r.batch[r.index]
+}
+
+// Err returns the error happened during loading of the runs.
+func (r *ExportedRuns) Err() error {
+	return r.err
+}
+
+// Close releases the loaded runs.
+func (r *ExportedRuns) Close() error {
+	r.ids, r.batch = nil, nil
+	return nil
+}
+
+// load loads the batch of the runs keeping the order of the ids.
+func (r *ExportedRuns) load(ids []string) ([]models.Run, error) {
+	tx := database.DB.WithContext(r.ctx).Where("run_uuid IN ?", ids)
+	for relation, keys := range r.relations {
+		if keys == nil {
+			tx.Preload(relation)
+		} else {
+			tx.Preload(relation, "key IN ?", keys)
+		}
+	}
+
+	var runs []models.Run
+	if err := tx.Find(&runs).Error; err != nil {
+		return nil, eris.Wrap(err, "error loading exported runs")
+	}
+
+	order := make(map[string]int, len(ids))
+	for i, id := range ids {
+		order[id] = i
+	}
+	slices.SortFunc(runs, func(a, b models.Run) int {
+		return order[a.ID] - order[b.ID]
+	})
+	for i := range runs {
+		runs[i].LatestMetrics = latestMetricPerKey(runs[i].LatestMetrics)
+	}
+	return runs, nil
+}
+
+// ExportRuns returns the runs requested by ExportRunsRequest together with the exported columns.
+func (s Service) ExportRuns(
+	ctx context.Context, namespace *models.Namespace, req *request.ExportRunsRequest,
+) (*ExportedRuns, error) {
+	if err := ValidateExportRunsRequest(req); err != nil {
+		return nil, err
+	}
+	adjustExportRunsRequestForNamespace(namespace, req)
+
+	columns, err := parseExportColumns(req.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	runIDs, err := exportRunIDs(ctx, namespace, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the keys of the requested columns are loaded, all the keys are looked up to build the columns.
+	relations := map[string][]string{}
+	keys := map[string][]string{}
+	for _, entity := range []struct {
+		name     string
+		relation string
+		model    any
+	}{
+		{name: ExportEntityMetrics, relation: "LatestMetrics", model: &database.LatestMetric{}},
+		{name: ExportEntityParams, relation: "Params", model: &database.Param{}},
+		{name: ExportEntityTags, relation: "Tags", model: &database.Tag{}},
+	} {
+		var entityKeys []string
+		requested, allKeys := false, false
+		for _, column := range columns {
+			if column.Entity == entity.name {
+				requested = true
+				allKeys = allKeys || column.Key == exportAllKeys
+				entityKeys = append(entityKeys, column.Key)
+			}
+		}
+		switch {
+		case allKeys:
+			relations[entity.relation] = nil
+			runs, err := newExportRunsQuery(ctx, namespace, req)
+			if err != nil {
+				return nil, err
+			}
+			if err := database.DB.WithContext(ctx).Model(
+				entity.model,
+			).Distinct(
+				"key",
+			).Where(
+				"run_uuid IN (?)", runs.Model(&database.Run{}).Select("runs.run_uuid"),
+			).Order(
+				"key",
+			).Pluck("key", &entityKeys).Error; err != nil {
+				return nil, api.NewInternalError("unable to get %s keys of exported runs: %s", entity.name, err)
+			}
+			keys[entity.name] = entityKeys
+		case requested:
+			relations[entity.relation] = entityKeys
+		}
+	}
+
+	return &ExportedRuns{
+		Columns:   expandExportColumns(columns, keys),
+		ctx:       ctx,
+		ids:       runIDs,
+		relations: relations,
+		index:     -1,
+	}, nil
+}
+
+// ExportRunMetrics returns the metric histories of the runs requested by ExportRunsRequest together with
+// the exported columns.
+func (s Service) ExportRunMetrics(
+	ctx context.Context, namespace *models.Namespace, req *request.ExportRunsRequest,
+) (metricstore.Cursor, []string, error) {
+	if err := ValidateExportRunsRequest(req); err != nil {
+		return nil, nil, err
+	}
+	adjustExportRunsRequestForNamespace(namespace, req)
+
+	columns := req.Columns
+	if len(columns) == 0 {
+		columns = ExportMetricColumns
+	}
+	for _, column := range columns {
+		if !slices.Contains(ExportMetricColumns, column) {
+			return nil, nil, api.NewInvalidParameterValueError(
+				"invalid column '%s'. Valid values are ['%s']", column, strings.Join(ExportMetricColumns, "', '"),
+			)
+		}
+	}
+
+	runIDs, err := exportRunIDs(ctx, namespace, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cursor := &exportMetricsCursor{
+		runIDs: runIDs,
+		read: func(runIDs []string) (metricstore.Cursor, error) {
+			return s.metricRepository.GetMetricHistories(
+				ctx, namespace.ID, nil, runIDs, req.MetricKeys, req.ViewType, math.MaxInt32, nil,
+			)
+		},
+	}
+	// the first batch is read right away to report the errors before the export is started.
+	if cursor.next() {
+		return nil, nil, api.NewInternalError("unable to export metrics: %s", cursor.err)
+	}
+	return cursor, columns, nil
+}
+
+// exportMetricsCursor reads the metric histories of the exported runs in batches.
+type exportMetricsCursor struct {
+	metricstore.Cursor
+	runIDs []string
+	read   func(runIDs []string) (metricstore.Cursor, error)
+	err    error
+}
+
+// Next advances to the next metric, reading the metrics of the next batch of the runs when needed.
+func (c *exportMetricsCursor) Next() bool {
+	for c.Cursor != nil {
+		if c.Cursor.Next() {
+			return true
+		}
+		if c.err = c.Cursor.Err(); c.err != nil {
+			return false
+		}
+		if c.next() {
+			return false
+		}
+	}
+	return false
+}
+
+// next closes the current cursor and reads the metrics of the next batch of the runs. It returns true
+// when an error happened.
+func (c *exportMetricsCursor) next() bool {
+	if err := c.Close(); err != nil {
+		c.err = err
+		return true
+	}
+	if len(c.runIDs) == 0 {
+		return false
+	}
+	n := min(exportRunsBatchSize, len(c.runIDs))
+	c.Cursor, c.err = c.read(c.runIDs[:n])
+	c.runIDs = c.runIDs[n:]
+	return c.err != nil
+}
+
+// Err returns the error happened during the iteration.
+func (c *exportMetricsCursor) Err() error {
+	return c.err
+}
+
+// Close closes the current cursor.
+func (c *exportMetricsCursor) Close() error {
+	if c.Cursor == nil {
+		return nil
+	}
+	cursor := c.Cursor
+	c.Cursor = nil
+	return cursor.Close()
+}
+
+// exportRunIDs returns the ids of the runs requested by ExportRunsRequest in the export order.
+func exportRunIDs(
+	ctx context.Context, namespace *models.Namespace, req *request.ExportRunsRequest,
+) ([]string, error) {
+	tx, err := newExportRunsQuery(ctx, namespace, req)
+	if err != nil {
+		return nil, err
+	}
+	var runIDs []string
+	// the same order as the default order of the search runs.
+	if err := tx.Model(
+		&database.Run{},
+	).Order(
+		"runs.start_time DESC",
+	).Order(
+		"runs.run_uuid",
+	).Pluck("runs.run_uuid", &runIDs).Error; err != nil {
+		return nil, api.NewInternalError("unable to export runs: %s", err)
+	}
+	return runIDs, nil
+}
+
+// newExportRunsQuery creates the query of the runs requested by ExportRunsRequest.
+func newExportRunsQuery(
+	ctx context.Context, namespace *models.Namespace, req *request.ExportRunsRequest,
+) (*gorm.DB, error) {
+	tx := database.DB.WithContext(ctx).Joins(
+		"LEFT JOIN experiments ON experiments.experiment_id = runs.experiment_id",
+	).Where(
+		"experiments.namespace_id = ?", namespace.ID,
+	)
+	if len(req.ExperimentIDs) > 0 {
+		tx.Where("runs.experiment_id IN ?", req.ExperimentIDs)
+	}
+
+	if req.Query != "" {
+		// the archived runs are excluded unless the query says otherwise, the same as by Aim search.
+		qp := query.QueryParser{
+			Default: query.DefaultExpression{
+				Contains:   "run.archived",
+				Expression: "not run.archived",
+			},
+			Tables: map[string]string{
+				"runs":        "runs",
+				"experiments": "experiments",
+			},
+			Dialector: tx.Dialector.Name(),
+		}
+		pq, err := qp.Parse(req.Query)
+		if err != nil {
+			return nil, api.NewInvalidParameterValueError("invalid query '%s': %s", req.Query, err)
+		}
+		return pq.Filter(tx), nil
+	}
+
+	var lifecyleStages []database.LifecycleStage
+	switch req.ViewType {
+	case request.ViewTypeActiveOnly, "":
+		lifecyleStages = []database.LifecycleStage{
+			database.LifecycleStageActive,
+		}
+	case request.ViewTypeDeletedOnly:
+		lifecyleStages = []database.LifecycleStage{
+			database.LifecycleStageDeleted,
+		}
+	case request.ViewTypeAll:
+		lifecyleStages = []database.LifecycleStage{
+			database.LifecycleStageActive,
+			database.LifecycleStageDeleted,
+		}
+	}
+	tx.Where("runs.lifecycle_stage IN ?", lifecyleStages)
+
+	if req.Filter != "" {
+		if err := applySearchRunsFilter(tx, req.Filter); err != nil {
+			return nil, err
+		}
+	}
+	return tx, nil
+}
+
+// parseExportColumns parses the names of the exported columns. All the attributes and the keys of all the entities
+// are exported when there are no columns.
+func parseExportColumns(names []string) ([]ExportColumn, error) {
+	if len(names) == 0 {
+		columns := make([]ExportColumn, 0, len(ExportAttributes)+3)
+		for _, attribute := range ExportAttributes {
+			columns = append(columns, ExportColumn{Key: attribute})
+		}
+		return append(
+			columns,
+			ExportColumn{Entity: ExportEntityMetrics, Key: exportAllKeys},
+			ExportColumn{Entity: ExportEntityParams, Key: exportAllKeys},
+			ExportColumn{Entity: ExportEntityTags, Key: exportAllKeys},
+		), nil
+	}
+
+	columns := make([]ExportColumn, len(names))
+	for i, name := range names {
+		entity, key, found := strings.Cut(name, ".")
+		switch {
+		case !found && slices.Contains(ExportAttributes, name):
+			columns[i] = ExportColumn{Key: name}
+		case found && key != "" &&
+			(entity == ExportEntityMetrics || entity == ExportEntityParams || entity == ExportEntityTags):
+			columns[i] = ExportColumn{Entity: entity, Key: key}
+		default:
+			return nil, api.NewInvalidParameterValueError(
+				"invalid column '%s'. Valid values are ['%s'] or 'metrics.<key>', 'params.<key>', 'tags.<key>' "+
+					"where key could be '*' to export all the keys",
+				name,
+				strings.Join(ExportAttributes, "', '"),
+			)
+		}
+	}
+	return columns, nil
+}
+
+// expandExportColumns replaces the columns of all the keys of the entity with the keys of the entity.
+// Duplicated columns are removed.
+func expandExportColumns(columns []ExportColumn, keys map[string][]string) []ExportColumn {
+	expanded := make([]ExportColumn, 0, len(columns))
+	for _, column := range columns {
+		if column.Key != exportAllKeys || column.Entity == ExportEntityAttributes {
+			expanded = append(expanded, column)
+			continue
+		}
+		for _, key := range keys[column.Entity] {
+			expanded = append(expanded, ExportColumn{Entity: column.Entity, Key: key})
+		}
+	}
+
+	var unique []ExportColumn
+	for _, column := range expanded {
+		if !slices.Contains(unique, column) {
+			unique = append(unique, column)
+		}
+	}
+	return unique
+}
+
+// latestMetricPerKey returns single latest metric for each key. The metrics of the same key are logged
+// with different contexts, so the metric with the latest step and timestamp is chosen.
+func latestMetricPerKey(metrics []models.LatestMetric) []models.LatestMetric {
+	var latest []models.LatestMetric
+	index := map[string]int{}
+	for _, metric := range metrics {
+		i, ok := index[metric.Key]
+		switch {
+		case !ok:
+			index[metric.Key] = len(latest)
+			latest = append(latest, metric)
+		case metric.Step > latest[i].Step ||
+			(metric.Step == latest[i].Step && metric.Timestamp > latest[i].Timestamp):
+			latest[i] = metric
+		}
+	}
+	return latest
+}
@@ -0,0 +1,99 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+func Test_parseExportColumns_Ok(t *testing.T) {
+	testData := []struct {
+		name    string
+		names   []string
+		columns []ExportColumn
+	}{
+		{
+			name: "EmptyColumns",
+			columns: []ExportColumn{
+				{Key: ExportAttributeRunID},
+				{Key: ExportAttributeExperimentID},
+				{Key: ExportAttributeRunName},
+				{Key: ExportAttributeStatus},
+				{Key: ExportAttributeUserID},
+				{Key: ExportAttributeStartTime},
+				{Key: ExportAttributeEndTime},
+				{Key: ExportAttributeLifecycleStage},
+				{Key: ExportAttributeArtifactURI},
+				{Entity: ExportEntityMetrics, Key: "*"},
+				{Entity: ExportEntityParams, Key: "*"},
+				{Entity: ExportEntityTags, Key: "*"},
+			},
+		},
+		{
+			name:  "RequestedColumns",
+			names: []string{"run_id", "metrics.loss", "params.*", "tags.mlflow.user"},
+			columns: []ExportColumn{
+				{Key: ExportAttributeRunID},
+				{Entity: ExportEntityMetrics, Key: "loss"},
+				{Entity: ExportEntityParams, Key: "*"},
+				{Entity: ExportEntityTags, Key: "mlflow.user"},
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, err := parseExportColumns(tt.names)
+			require.Nil(t, err)
+			assert.Equal(t, tt.columns, columns)
+		})
+	}
+}
+
+func Test_parseExportColumns_Error(t *testing.T) {
+	for _, name := range []string{"unknown", "metrics.", "attributes.run_id", "run_id.key"} {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseExportColumns([]string{name})
+			require.NotNil(t, err)
+			assert.Equal(t, api.ErrorCode(api.ErrorCodeInvalidParameterValue), err.(*api.ErrorResponse).ErrorCode)
+		})
+	}
+}
+
+func Test_expandExportColumns_Ok(t *testing.T) {
+	columns := expandExportColumns([]ExportColumn{
+		{Key: ExportAttributeRunID},
+		{Entity: ExportEntityMetrics, Key: "loss"},
+		{Entity: ExportEntityMetrics, Key: "*"},
+		{Entity: ExportEntityParams, Key: "*"},
+		{Entity: ExportEntityTags, Key: "missing"},
+	}, map[string][]string{
+		ExportEntityMetrics: {"accuracy", "loss"},
+		ExportEntityParams:  {"lr"},
+		ExportEntityTags:    {"tag"},
+	})
+	assert.Equal(t, []ExportColumn{
+		{Key: ExportAttributeRunID},
+		{Entity: ExportEntityMetrics, Key: "loss"},
+		{Entity: ExportEntityMetrics, Key: "accuracy"},
+		{Entity: ExportEntityParams, Key: "lr"},
+		{Entity: ExportEntityTags, Key: "missing"},
+	}, columns)
+}
+
+func Test_latestMetricPerKey_Ok(t *testing.T) {
+	metrics := latestMetricPerKey([]models.LatestMetric{
+		{Key: "loss", Step: 1, Timestamp: 2, Value: 1, ContextID: 1},
+		{Key: "accuracy", Step: 1, Timestamp: 1, Value: 2, ContextID: 1},
+		{Key: "loss", Step: 2, Timestamp: 1, Value: 3, ContextID: 2},
+		{Key: "accuracy", Step: 1, Timestamp: 2, Value: 4, ContextID: 2},
+	})
+	assert.Equal(t, []models.LatestMetric{
+		{Key: "loss", Step: 2, Timestamp: 1, Value: 3, ContextID: 2},
+		{Key: "accuracy", Step: 1, Timestamp: 2, Value: 4, ContextID: 2},
+	}, metrics)
+}
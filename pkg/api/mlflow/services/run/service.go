@@ -19,16 +19,18 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/convertors"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/filter"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/pagination"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/ingestion"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/events"
+	"github.com/G-Research/fasttrackml/pkg/common/services/live"
 	"github.com/G-Research/fasttrackml/pkg/database"
 )
 
 //nolint:lll
 var (
-	runOrder      = regexp.MustCompile(`^(attribute|metric|param|tag)s?\.("[^"]+"|` + "`[^`]+`" + `|[\w\.]+)(?i:\s+(ASC|DESC))?$`)
-	filterAnd     = regexp.MustCompile(`(?i)\s+AND\s+`)
-	filterCond    = regexp.MustCompile(`^(?:(\w+)\.)?("[^"]+"|` + "`[^`]+`" + `|[\w\.]+)\s+(<|<=|>|>=|=|!=|(?i:I?LIKE)|(?i:(?:NOT )?IN))\s+(\((?:'[^']+'(?:,\s*)?)+\)|"[^"]+"|'[^']+'|[\w\.]+)$`)
-	filterInGroup = regexp.MustCompile(`,\s*`)
+	runOrder = regexp.MustCompile(`^(attribute|metric|param|tag)s?\.("[^"]+"|` + "`[^`]+`" + `|[\w\.]+)(?i:\s+(ASC|DESC))?$`)
 )
 
 // supported expression list.
@@ -54,6 +56,9 @@ type Service struct {
 	metricRepository     repositories.MetricRepositoryProvider
 	experimentRepository repositories.ExperimentRepositoryProvider
 	artifactRepository   repositories.ArtifactRepositoryProvider
+	inputRepository      repositories.InputRepositoryProvider
+	liveUpdatesHub       live.HubProvider
+	metricQueue          ingestion.QueueProvider
 }
 
 // NewService creates new Service instance.
@@ -65,6 +70,7 @@ func NewService(
 	experimentRepository repositories.ExperimentRepositoryProvider,
 	logRepository repositories.LogRepositoryProvider,
 	artifactRepository repositories.ArtifactRepositoryProvider,
+	inputRepository repositories.InputRepositoryProvider,
 ) *Service {
 	return &Service{
 		logRepository:        logRepository,
@@ -74,9 +80,22 @@ func NewService(
 		metricRepository:     metricRepository,
 		experimentRepository: experimentRepository,
 		artifactRepository:   artifactRepository,
+		inputRepository:      inputRepository,
 	}
 }
 
+// WithLiveUpdates enables publishing of run updates to the live updates subscribers.
+func (s *Service) WithLiveUpdates(hub live.HubProvider) *Service {
+	s.liveUpdatesHub = hub
+	return s
+}
+
+// WithMetricQueue enables asynchronous writes of logged metrics through the ingestion queue.
+func (s *Service) WithMetricQueue(queue ingestion.QueueProvider) *Service {
+	s.metricQueue = queue
+	return s
+}
+
 func (s Service) CreateRun(
 	ctx context.Context, ns *models.Namespace, req *request.CreateRunRequest,
 ) (*models.Run, error) {
@@ -98,6 +117,12 @@ func (s Service) CreateRun(
 	if err := s.runRepository.Create(ctx, run); err != nil {
 		return nil, api.NewInternalError("error inserting run: %s", err)
 	}
+	s.publishRunEvent(events.RunEvent{
+		Type:        events.RunEventTypeStatus,
+		NamespaceID: ns.ID,
+		RunID:       run.ID,
+		Status:      run.Status,
+	})
 
 	return run, nil
 }
@@ -135,6 +160,13 @@ func (s Service) UpdateRun(
 	}); err != nil {
 		return nil, api.NewInternalError("unable to update run '%s': %s", run.ID, err)
 	}
+	s.publishRunEvent(events.RunEvent{
+		Type:        events.RunEventTypeStatus,
+		NamespaceID: namespace.ID,
+		RunID:       run.ID,
+		Status:      run.Status,
+		EndTime:     run.EndTime.Int64,
+	})
 
 	return run, nil
 }
@@ -163,9 +195,9 @@ func (s Service) GetRun(
 // TODO:get back and fix `gocyclo` problem.
 func (s Service) SearchRuns(
 	ctx context.Context, namespace *models.Namespace, req *request.SearchRunsRequest,
-) ([]models.Run, int, int, error) {
+) ([]models.Run, *request.PageToken, error) {
 	if err := ValidateSearchRunsRequest(req); err != nil {
-		return nil, 0, 0, err
+		return nil, nil, err
 	}
 	adjustSearchRunsRequestForNamespace(namespace, req)
 
@@ -202,270 +234,359 @@ func (s Service) SearchRuns(
 	if limit == 0 {
 		limit = 1000
 	}
-	tx.Limit(limit)
+	tx.Limit(limit + 1)
 
 	// PageToken
-	var offset int
+	var token request.PageToken
 	if req.PageToken != "" {
-		var token request.PageToken
 		if err := json.NewDecoder(
 			base64.NewDecoder(
 				base64.StdEncoding,
 				strings.NewReader(req.PageToken),
 			),
 		).Decode(&token); err != nil {
-			return nil, 0, 0, api.NewInvalidParameterValueError("invalid page_token '%s': %s", req.PageToken, err)
+			return nil, nil, api.NewInvalidParameterValueError("invalid page_token '%s': %s", req.PageToken, err)
 		}
-		offset = int(token.Offset)
+		// tokens issued by the older versions contain the offset instead of the keys.
+		tx.Offset(int(token.Offset))
 	}
-	tx.Offset(offset)
 
 	// Filter
 	if req.Filter != "" {
-		for n, f := range filterAnd.Split(req.Filter, -1) {
-			components := filterCond.FindStringSubmatch(f)
-			if len(components) != 5 {
-				return nil, 0, 0, api.NewInvalidParameterValueError("malformed filter '%s'", f)
-			}
-
-			entity := components[1]
-			key := strings.Trim(components[2], "\"`")
-			comparison := components[3]
-			var value any = components[4]
-			valueCol := "value"
-
-			var kind any
-			switch entity {
-			case "", "attribute", "attributes", "attr", "run":
-				switch key {
-				case "start_time", "end_time":
-					switch comparison {
-					case GraterExpression, GraterOrEqualExpression, NotEqualExpression,
-						EqualExpression, LessExpression, LessOrEqualExpression:
-						v, err := strconv.Atoi(value.(string))
-						if err != nil {
-							return nil, 0, 0, api.NewInvalidParameterValueError("invalid numeric value '%s'", value)
-						}
-						value = v
-					default:
-						return nil, 0, 0, api.NewInvalidParameterValueError(
-							"invalid numeric attribute comparison operator '%s'", comparison,
-						)
-					}
-				case "run_name":
-					key = "mlflow.runName"
-					kind = &database.Tag{}
-					fallthrough
-				case "status", "user_id", "artifact_uri":
-					switch strings.ToUpper(comparison) {
-					case NotEqualExpression, EqualExpression, LikeExpression, ILikeExpression:
-						if strings.HasPrefix(value.(string), "(") {
-							return nil, 0, 0, api.NewInvalidParameterValueError("invalid string value '%s'", value)
-						}
-						value = strings.Trim(value.(string), `"'`)
-					default:
-						return nil, 0, 0, api.NewInvalidParameterValueError(
-							"invalid string attribute comparison operator '%s'", comparison,
-						)
-					}
-				case "run_id":
-					key = "run_uuid"
-					switch strings.ToUpper(comparison) {
-					case NotEqualExpression, EqualExpression, LikeExpression, ILikeExpression:
-						if strings.HasPrefix(value.(string), "(") {
-							return nil, 0, 0, api.NewInvalidParameterValueError("invalid string value '%s'", value)
-						}
-						value = strings.Trim(value.(string), `"'`)
-					case InExpression, NotInExpression:
-						if !strings.HasPrefix(value.(string), "(") {
-							return nil, 0, 0, api.NewInvalidParameterValueError("invalid list definition '%s'", value)
-						}
-						var values []string
-						for _, v := range filterInGroup.Split(value.(string)[1:len(value.(string))-1], -1) {
-							values = append(values, strings.Trim(v, "'"))
-						}
-						value = values
-					default:
-						return nil, 0, 0, api.NewInvalidParameterValueError(
-							"invalid string attribute comparison operator '%s'", comparison,
-						)
-					}
-				default:
-					return nil, 0, 0, api.NewInvalidParameterValueError(
-						`invalid attribute '%s'. `+
-							`Valid values are ['run_name', 'start_time', 'end_time', 'status', 'user_id', 'artifact_uri', 'run_id']`,
-						key,
-					)
-				}
-			case "metric", "metrics":
-				switch comparison {
-				case GraterExpression, GraterOrEqualExpression,
-					NotEqualExpression, EqualExpression, LessExpression, LessOrEqualExpression:
-					v, err := strconv.ParseFloat(value.(string), 64)
-					if err != nil {
-						return nil, 0, 0, api.NewInvalidParameterValueError("invalid numeric value '%s'", value)
-					}
-					value = v
-				default:
-					return nil, 0, 0, api.NewInvalidParameterValueError(
-						"invalid metric comparison operator '%s'", comparison,
-					)
-				}
-				kind = &database.LatestMetric{}
-			case "parameter", "parameters", "param", "params":
-				switch strings.ToUpper(comparison) {
-				case NotEqualExpression, EqualExpression:
-					switch v := value.(type) {
-					case string:
-						if strings.HasPrefix(v, "(") {
-							return nil, 0, 0, api.NewInvalidParameterValueError("invalid string value '%s'", value)
-						}
-						value = strings.Trim(v, `"'`)
-						valueCol = "value_str"
-					case int64, int32, int:
-						valueCol = "value_int"
-					case float64, float32:
-						valueCol = "value_float"
-					default:
-						return nil, 0, 0, api.NewInvalidParameterValueError(
-							"invalid value '%v' for comparison operator '%s'", v, comparison,
-						)
-					}
-				case LikeExpression, ILikeExpression:
-					switch v := value.(type) {
-					case string:
-						if strings.HasPrefix(v, "(") {
-							return nil, 0, 0, api.NewInvalidParameterValueError("invalid string value '%s'", value)
-						}
-						value = strings.Trim(v, `"'`)
-						valueCol = "value_str"
-					default:
-						return nil, 0, 0, api.NewInvalidParameterValueError(
-							"invalid value '%v' for comparison operator '%s'", v, comparison,
-						)
-					}
-				case GraterExpression, GraterOrEqualExpression, LessExpression, LessOrEqualExpression:
-					switch v := value.(type) {
-					case int64, int32, int:
-						valueCol = "value_int"
-					case float64, float32:
-						valueCol = "value_float"
-					default:
-						return nil, 0, 0, api.NewInvalidParameterValueError(
-							"invalid value '%v' for comparison operator '%s'", v, comparison,
-						)
-					}
-				default:
-					return nil, 0, 0, api.NewInvalidParameterValueError(
-						"invalid param comparison operator '%s'", comparison,
-					)
-				}
-				kind = &database.Param{}
-			case "tag", "tags":
-				switch strings.ToUpper(comparison) {
-				case NotEqualExpression, EqualExpression, LikeExpression, ILikeExpression:
-					if strings.HasPrefix(value.(string), "(") {
-						return nil, 0, 0, api.NewInvalidParameterValueError("invalid string value '%s'", value)
-					}
-					value = strings.Trim(value.(string), `"'`)
-				default:
-					return nil, 0, 0, api.NewInvalidParameterValueError(
-						"invalid tag comparison operator '%s'", comparison,
-					)
-				}
-				kind = &database.Tag{}
-			default:
-				return nil, 0, 0, api.NewInvalidParameterValueError(
-					"invalid entity type '%s'. Valid values are ['metric', 'parameter', 'tag', 'attribute']", entity,
-				)
-			}
-
-			if kind == nil {
-				if database.DB.Dialector.Name() == "sqlite" && strings.ToUpper(comparison) == ILikeExpression {
-					key = fmt.Sprintf("LOWER(runs.%s)", key)
-					comparison = LikeExpression
-					value = strings.ToLower(value.(string))
-					tx.Where(fmt.Sprintf("%s %s ?", key, comparison), value)
-				} else {
-					tx.Where(fmt.Sprintf("runs.%s %s ?", key, comparison), value)
-				}
-			} else {
-				table := fmt.Sprintf("filter_%d", n)
-				where := fmt.Sprintf("%s %s ?", valueCol, comparison)
-				if database.DB.Dialector.Name() == "sqlite" && strings.ToUpper(comparison) == ILikeExpression {
-					where = fmt.Sprintf("LOWER(%s) LIKE ?", valueCol)
-					value = strings.ToLower(fmt.Sprintf("%v", value))
-				}
-				tx.Joins(
-					fmt.Sprintf("JOIN (?) AS %s ON runs.run_uuid = %s.run_uuid", table, table),
-					database.DB.Select("run_uuid", valueCol).Where("key = ?", key).Where(where, value).Model(kind),
-				)
-			}
+		if err := applySearchRunsFilter(tx, req.Filter); err != nil {
+			return nil, nil, err
 		}
 	}
 
 	// OrderBy
 	// TODO order numeric, nan, null?
 	// TODO collation for strings on postgres?
+	var columns []pagination.Column
+	keysQuery := database.DB.Model(&database.Run{})
 	startTimeOrder := false
 	for n, o := range req.OrderBy {
 		components := runOrder.FindStringSubmatch(o)
 		log.Debugf("Components: %#v", components)
 		if len(components) < 3 {
-			return nil, 0, 0, api.NewInvalidParameterValueError("invalid order_by clause '%s'", o)
+			return nil, nil, api.NewInvalidParameterValueError("invalid order_by clause '%s'", o)
 		}
 
-		column := strings.Trim(components[2], "`\"")
+		column := clause.Column{Table: "runs", Name: strings.Trim(components[2], "`\"")}
 
 		var kind any
+		valueColumn := "value"
 		switch components[1] {
 		case "attribute":
-			if column == "start_time" {
+			if column.Name == "start_time" {
 				startTimeOrder = true
 			}
 		case "metric":
 			kind = &database.LatestMetric{}
 		case "param":
 			kind = &database.Param{}
+			valueColumn = "value_str AS value"
 		case "tag":
 			kind = &database.Tag{}
 		default:
-			return nil, 0, 0, api.NewInvalidParameterValueError(
+			return nil, nil, api.NewInvalidParameterValueError(
 				"invalid entity type '%s'. Valid values are ['metric', 'parameter', 'tag', 'attribute']",
 				components[1],
 			)
 		}
 		if kind != nil {
 			table := fmt.Sprintf("order_%d", n)
-			tx.Joins(
-				fmt.Sprintf("LEFT OUTER JOIN (?) AS %s ON runs.run_uuid = %s.run_uuid", table, table),
-				database.DB.Select("run_uuid", "value").Where("key = ?", column).Model(kind),
-			)
-			column = fmt.Sprintf("%s.value", table)
+			join := fmt.Sprintf("LEFT OUTER JOIN (?) AS %s ON runs.run_uuid = %s.run_uuid", table, table)
+			// the same join is needed to select the keys of the next page token.
+			for _, query := range []*gorm.DB{tx, keysQuery} {
+				query.Joins(join, database.DB.Select("run_uuid", valueColumn).Where("key = ?", column.Name).Model(kind))
+			}
+			column = clause.Column{Table: table, Name: "value"}
 		}
-		tx.Order(clause.OrderByColumn{
-			Column: clause.Column{
-				Name: column,
-			},
-			Desc: len(components) == 4 && strings.ToUpper(components[3]) == "DESC",
+		columns = append(columns, pagination.Column{
+			Column: column,
+			Desc:   len(components) == 4 && strings.ToUpper(components[3]) == "DESC",
 		})
 	}
 	if !startTimeOrder {
-		tx.Order("runs.start_time DESC")
+		columns = append(columns, pagination.Column{
+			Column: clause.Column{Table: "runs", Name: "start_time"},
+			Desc:   true,
+		})
+	}
+	columns = append(columns, pagination.Column{
+		Column:  clause.Column{Table: "runs", Name: "run_uuid"},
+		NotNull: true,
+	})
+	pagination.Order(tx, columns)
+	if len(token.Keys) > 0 {
+		if err := pagination.After(tx, columns, token.Keys); err != nil {
+			return nil, nil, api.NewInvalidParameterValueError("invalid page_token '%s': %s", req.PageToken, err)
+		}
 	}
-	tx.Order("runs.run_uuid")
 
 	// Actual query
 	var runs []models.Run
 	tx.Preload("LatestMetrics").
 		Preload("Params").
 		Preload("Tags").
+		Preload("Inputs.Tags").
+		Preload("Inputs.Dataset").
 		Find(&runs)
 	if tx.Error != nil {
-		return nil, 0, 0, api.NewInternalError("unable to search runs: %s", tx.Error)
+		return nil, nil, api.NewInternalError("unable to search runs: %s", tx.Error)
+	}
+
+	// NextPageToken
+	if len(runs) <= limit {
+		return runs, nil, nil
 	}
+	runs = runs[:limit]
+	keys, err := pagination.Keys(keysQuery.Where("runs.run_uuid = ?", runs[limit-1].ID), columns)
+	if err != nil {
+		return nil, nil, api.NewInternalError("unable to build next_page_token: %s", err)
+	}
+
+	return runs, &request.PageToken{Keys: keys}, nil
+}
+
+// applySearchRunsFilter applies MLflow search filter to the query of the runs.
+// nolint:gocyclo
+// TODO:get back and fix `gocyclo` problem.
+func applySearchRunsFilter(tx *gorm.DB, searchFilter string) error {
+	conditions, err := filter.ParseComparisons(searchFilter)
+	if err != nil {
+		return api.NewInvalidParameterValueError("malformed filter '%s': %s", searchFilter, err)
+	}
+	for n, condition := range conditions {
+		entity := condition.Entity
+		key := condition.Key
+		comparison := condition.Operator
+		var value any
+		if condition.Value != nil {
+			value = condition.Value.Text
+		}
+		valueCol := "value"
+
+		var kind any
+		switch entity {
+		case "", "attribute", "attributes", "attr", "run":
+			switch key {
+			case "start_time", "end_time":
+				switch comparison {
+				case GraterExpression, GraterOrEqualExpression, NotEqualExpression,
+					EqualExpression, LessExpression, LessOrEqualExpression:
+					v, err := strconv.Atoi(condition.Value.Text)
+					if err != nil {
+						return api.NewInvalidParameterValueError(
+							"invalid numeric value '%s'", condition.Value.Raw,
+						)
+					}
+					value = v
+				default:
+					return api.NewInvalidParameterValueError(
+						"invalid numeric attribute comparison operator '%s'", comparison,
+					)
+				}
+			case "run_name":
+				key = "mlflow.runName"
+				kind = &database.Tag{}
+				fallthrough
+			case "status", "user_id", "artifact_uri":
+				switch strings.ToUpper(comparison) {
+				case NotEqualExpression, EqualExpression, LikeExpression, ILikeExpression:
+					if condition.Value.Kind == filter.ListValue {
+						return api.NewInvalidParameterValueError("invalid string value '%s'", condition.Value.Raw)
+					}
+				default:
+					return api.NewInvalidParameterValueError(
+						"invalid string attribute comparison operator '%s'", comparison,
+					)
+				}
+			case "run_id":
+				key = "run_uuid"
+				switch strings.ToUpper(comparison) {
+				case NotEqualExpression, EqualExpression, LikeExpression, ILikeExpression:
+					if condition.Value.Kind == filter.ListValue {
+						return api.NewInvalidParameterValueError("invalid string value '%s'", condition.Value.Raw)
+					}
+				case InExpression, NotInExpression:
+					if condition.Value.Kind != filter.ListValue {
+						return api.NewInvalidParameterValueError("invalid list definition '%s'", condition.Value.Raw)
+					}
+					value = condition.Value.Strings()
+				default:
+					return api.NewInvalidParameterValueError(
+						"invalid string attribute comparison operator '%s'", comparison,
+					)
+				}
+			default:
+				return api.NewInvalidParameterValueError(
+					`invalid attribute '%s'. `+
+						`Valid values are ['run_name', 'start_time', 'end_time', 'status', 'user_id', 'artifact_uri', 'run_id']`,
+					key,
+				)
+			}
+		case "metric", "metrics":
+			switch comparison {
+			case GraterExpression, GraterOrEqualExpression,
+				NotEqualExpression, EqualExpression, LessExpression, LessOrEqualExpression:
+				v, err := strconv.ParseFloat(condition.Value.Text, 64)
+				if err != nil {
+					return api.NewInvalidParameterValueError(
+						"invalid numeric value '%s'", condition.Value.Raw,
+					)
+				}
+				value = v
+			default:
+				return api.NewInvalidParameterValueError(
+					"invalid metric comparison operator '%s'", comparison,
+				)
+			}
+			kind = &database.LatestMetric{}
+		case "parameter", "parameters", "param", "params":
+			switch strings.ToUpper(comparison) {
+			case NotEqualExpression, EqualExpression:
+				switch v := value.(type) {
+				case string:
+					if condition.Value.Kind == filter.ListValue {
+						return api.NewInvalidParameterValueError(
+							"invalid string value '%s'", condition.Value.Raw,
+						)
+					}
+					valueCol = "value_str"
+				case int64, int32, int:
+					valueCol = "value_int"
+				case float64, float32:
+					valueCol = "value_float"
+				default:
+					return api.NewInvalidParameterValueError(
+						"invalid value '%v' for comparison operator '%s'", v, comparison,
+					)
+				}
+			case LikeExpression, ILikeExpression:
+				switch v := value.(type) {
+				case string:
+					if condition.Value.Kind == filter.ListValue {
+						return api.NewInvalidParameterValueError(
+							"invalid string value '%s'", condition.Value.Raw,
+						)
+					}
+					valueCol = "value_str"
+				default:
+					return api.NewInvalidParameterValueError(
+						"invalid value '%v' for comparison operator '%s'", v, comparison,
+					)
+				}
+			case GraterExpression, GraterOrEqualExpression, LessExpression, LessOrEqualExpression:
+				switch v := value.(type) {
+				case int64, int32, int:
+					valueCol = "value_int"
+				case float64, float32:
+					valueCol = "value_float"
+				default:
+					return api.NewInvalidParameterValueError(
+						"invalid value '%v' for comparison operator '%s'", v, comparison,
+					)
+				}
+			default:
+				return api.NewInvalidParameterValueError(
+					"invalid param comparison operator '%s'", comparison,
+				)
+			}
+			kind = &database.Param{}
+		case "tag", "tags":
+			switch strings.ToUpper(comparison) {
+			case NotEqualExpression, EqualExpression, LikeExpression, ILikeExpression:
+				if condition.Value.Kind == filter.ListValue {
+					return api.NewInvalidParameterValueError("invalid string value '%s'", condition.Value.Raw)
+				}
+			default:
+				return api.NewInvalidParameterValueError(
+					"invalid tag comparison operator '%s'", comparison,
+				)
+			}
+			kind = &database.Tag{}
+		case "dataset", "datasets":
+			column := fmt.Sprintf("datasets.%s", key)
+			switch key {
+			case "name", "digest":
+			case "context":
+				column = "input_tags.value"
+			default:
+				return api.NewInvalidParameterValueError(
+					"invalid dataset attribute '%s'. Valid values are ['name', 'digest', 'context']", key,
+				)
+			}
+			switch strings.ToUpper(comparison) {
+			case NotEqualExpression, EqualExpression, LikeExpression, ILikeExpression:
+				if condition.Value.Kind == filter.ListValue {
+					return api.NewInvalidParameterValueError("invalid string value '%s'", condition.Value.Raw)
+				}
+			case InExpression, NotInExpression:
+				if condition.Value.Kind != filter.ListValue {
+					return api.NewInvalidParameterValueError("invalid list definition '%s'", condition.Value.Raw)
+				}
+				value = condition.Value.Strings()
+			default:
+				return api.NewInvalidParameterValueError(
+					"invalid dataset comparison operator '%s'", comparison,
+				)
+			}
+
+			// the run matches, when any of its dataset inputs matches the condition.
+			where := fmt.Sprintf("%s %s ?", column, comparison)
+			if database.DB.Dialector.Name() == "sqlite" && strings.ToUpper(comparison) == ILikeExpression {
+				where = fmt.Sprintf("LOWER(%s) LIKE ?", column)
+				value = strings.ToLower(value.(string))
+			}
+			inputs := database.DB.Model(
+				&database.Input{},
+			).Select(
+				"inputs.destination_id",
+			).Joins(
+				"INNER JOIN datasets ON datasets.dataset_uuid = inputs.source_id",
+			).Where(
+				"inputs.source_type = ? AND inputs.destination_type = ?",
+				models.InputSourceTypeDataset, models.InputDestinationTypeRun,
+			)
+			if key == "context" {
+				inputs = inputs.Joins(
+					"INNER JOIN input_tags ON input_tags.input_uuid = inputs.input_uuid AND input_tags.name = ?",
+					models.InputTagDatasetContext,
+				)
+			}
+			tx.Where("runs.run_uuid IN (?)", inputs.Where(where, value))
+			continue
+		default:
+			return api.NewInvalidParameterValueError(
+				"invalid entity type '%s'. Valid values are ['metric', 'parameter', 'tag', 'attribute', 'dataset']",
+				entity,
+			)
+		}
 
-	return runs, limit, offset, nil
+		if kind == nil {
+			if database.DB.Dialector.Name() == "sqlite" && strings.ToUpper(comparison) == ILikeExpression {
+				key = fmt.Sprintf("LOWER(runs.%s)", key)
+				comparison = LikeExpression
+				value = strings.ToLower(value.(string))
+				tx.Where(fmt.Sprintf("%s %s ?", key, comparison), value)
+			} else {
+				tx.Where(fmt.Sprintf("runs.%s %s ?", key, comparison), value)
+			}
+		} else {
+			table := fmt.Sprintf("filter_%d", n)
+			where := fmt.Sprintf("%s %s ?", valueCol, comparison)
+			if database.DB.Dialector.Name() == "sqlite" && strings.ToUpper(comparison) == ILikeExpression {
+				where = fmt.Sprintf("LOWER(%s) LIKE ?", valueCol)
+				value = strings.ToLower(fmt.Sprintf("%v", value))
+			}
+			tx.Joins(
+				fmt.Sprintf("JOIN (?) AS %s ON runs.run_uuid = %s.run_uuid", table, table),
+				database.DB.Select("run_uuid", valueCol).Where("key = ?", key).Where(where, value).Model(kind),
+			)
+		}
+	}
+	return nil
 }
 
 // DeleteRun handles delete models.Run entity business logic.
@@ -538,7 +659,10 @@ func (s Service) LogMetric(
 	if err != nil {
 		return api.NewInvalidParameterValueError(err.Error())
 	}
-	if err := s.metricRepository.CreateBatch(ctx, run, 1, []models.Metric{*metric}); err != nil {
+	if err := s.logMetrics(ctx, namespace, run, 1, []models.Metric{*metric}); err != nil {
+		if errors.Is(err, ingestion.ErrQueueFull) {
+			return api.NewResourceExhaustedError("unable to log metric '%s' for run '%s': %s", req.Key, run.ID, err)
+		}
 		return api.NewInternalError("unable to log metric '%s' for run '%s': %s", req.Key, req.GetRunID(), err)
 	}
 
@@ -664,7 +788,10 @@ func (s Service) LogBatch(
 		}
 		return api.NewInternalError("unable to insert params for run '%s': %s", run.ID, err)
 	}
-	if err := s.metricRepository.CreateBatch(ctx, run, 100, metrics); err != nil {
+	if err := s.logMetrics(ctx, namespace, run, 100, metrics); err != nil {
+		if errors.Is(err, ingestion.ErrQueueFull) {
+			return api.NewResourceExhaustedError("unable to insert metrics for run '%s': %s", run.ID, err)
+		}
 		return api.NewInternalError("unable to insert metrics for run '%s': %s", run.ID, err)
 	}
 	if err := s.runRepository.SetRunTagsBatch(ctx, run, 100, tags); err != nil {
@@ -674,6 +801,32 @@ func (s Service) LogBatch(
 	return nil
 }
 
+func (s Service) LogInputs(
+	ctx context.Context,
+	namespace *models.Namespace,
+	req *request.LogInputsRequest,
+) error {
+	if err := ValidateLogInputsRequest(req); err != nil {
+		return err
+	}
+
+	run, err := s.runRepository.GetByNamespaceIDRunIDAndLifecycleStage(
+		ctx, namespace.ID, req.RunID, models.LifecycleStageActive,
+	)
+	if err != nil {
+		return api.NewInternalError("Unable to find run '%s': %s", req.RunID, err)
+	}
+	if run == nil {
+		return api.NewResourceDoesNotExistError("Run '%s' not found", req.RunID)
+	}
+
+	inputs := convertors.ConvertLogInputsRequestToDBModel(run, req)
+	if err := s.inputRepository.CreateBatch(ctx, inputs); err != nil {
+		return api.NewInternalError("unable to insert inputs for run '%s': %s", run.ID, err)
+	}
+	return nil
+}
+
 func (s Service) LogOutput(
 	ctx context.Context,
 	namespace *models.Namespace,
@@ -695,9 +848,43 @@ func (s Service) LogOutput(
 	if err := s.logRepository.Create(ctx, log); err != nil {
 		return api.NewInternalError("unable to save log for run '%s'", req.RunID)
 	}
+	s.publishRunEvent(events.RunEvent{
+		Type:        events.RunEventTypeLog,
+		NamespaceID: namespace.ID,
+		RunID:       run.ID,
+		Log:         req.Data,
+	})
+	return nil
+}
+
+// logMetrics writes metrics of the run to the database or, when ingestion queue is enabled,
+// enqueues them to be written asynchronously. Live updates of queued metrics are published by the queue.
+func (s Service) logMetrics(
+	ctx context.Context, namespace *models.Namespace, run *models.Run, batchSize int, metrics []models.Metric,
+) error {
+	if s.metricQueue != nil {
+		return s.metricQueue.Enqueue(namespace.ID, run, metrics)
+	}
+	if err := s.metricRepository.CreateBatch(ctx, run, batchSize, metrics); err != nil {
+		return err
+	}
+	if len(metrics) > 0 {
+		s.publishRunEvent(events.NewRunMetricsEvent(namespace.ID, run.ID, metrics))
+	}
 	return nil
 }
 
+// publishRunEvent publishes run event to the live updates subscribers, if live updates are enabled.
+// Failed publishing is only logged, as the run data is already stored.
+func (s Service) publishRunEvent(event events.RunEvent) {
+	if s.liveUpdatesHub == nil {
+		return
+	}
+	if err := s.liveUpdatesHub.Publish(&event); err != nil {
+		log.Warnf("error publishing live update of run '%s': %+v", event.RunID, err)
+	}
+}
+
 // LogArtifact creates new Run artifact.
 func (s Service) LogArtifact(
 	ctx context.Context, namespaceID uint, req *request.LogArtifactRequest,
@@ -708,3 +895,60 @@ func (s Service) LogArtifact(
 	}
 	return nil
 }
+
+// LogText creates new Run text.
+func (s Service) LogText(ctx context.Context, namespaceID uint, req *request.LogTextRequest) error {
+	if err := ValidateLogTextRequest(req); err != nil {
+		return err
+	}
+	artifact, err := ConvertLogTextRequestToModel(req)
+	if err != nil {
+		return api.NewBadRequestError("unable to convert request: %s", err)
+	}
+	return s.createSequenceArtifact(ctx, namespaceID, artifact)
+}
+
+// LogAudio creates new Run audio.
+func (s Service) LogAudio(ctx context.Context, namespaceID uint, req *request.LogAudioRequest) error {
+	if err := ValidateLogAudioRequest(req); err != nil {
+		return err
+	}
+	return s.createSequenceArtifact(ctx, namespaceID, ConvertLogAudioRequestToModel(req))
+}
+
+// LogDistribution creates new Run distribution.
+func (s Service) LogDistribution(
+	ctx context.Context, namespaceID uint, req *request.LogDistributionRequest,
+) error {
+	if err := ValidateLogDistributionRequest(req); err != nil {
+		return err
+	}
+	artifact, err := ConvertLogDistributionRequestToModel(req)
+	if err != nil {
+		return api.NewBadRequestError("unable to convert request: %s", err)
+	}
+	return s.createSequenceArtifact(ctx, namespaceID, artifact)
+}
+
+// LogFigure creates new Run figure.
+func (s Service) LogFigure(ctx context.Context, namespaceID uint, req *request.LogFigureRequest) error {
+	if err := ValidateLogFigureRequest(req); err != nil {
+		return err
+	}
+	return s.createSequenceArtifact(ctx, namespaceID, ConvertLogFigureRequestToModel(req))
+}
+
+// createSequenceArtifact checks that the Run exists in the namespace and creates the sequence artifact.
+func (s Service) createSequenceArtifact(ctx context.Context, namespaceID uint, artifact *models.Artifact) error {
+	run, err := s.runRepository.GetByNamespaceIDAndRunID(ctx, namespaceID, artifact.RunID)
+	if err != nil {
+		return api.NewInternalError("unable to find run '%s': %s", artifact.RunID, err)
+	}
+	if run == nil {
+		return api.NewResourceDoesNotExistError("unable to find run '%s'", artifact.RunID)
+	}
+	if err := s.artifactRepository.Create(ctx, artifact); err != nil {
+		return api.NewInternalError("error creating run %s: %s", artifact.Type, err)
+	}
+	return nil
+}
@@ -69,6 +69,7 @@ func TestService_CreateRun_Ok(t *testing.T) {
 		&experimentRepository,
 		&repositories.MockLogRepositoryProvider{},
 		&repositories.MockArtifactRepositoryProvider{},
+		&repositories.MockInputRepositoryProvider{},
 	)
 	run, err := service.CreateRun(context.TODO(), &ns, &request.CreateRunRequest{
 		ExperimentID: "0", // default experiment id provided by the client is "0"
@@ -126,6 +127,7 @@ func TestService_CreateRun_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -151,6 +153,7 @@ func TestService_CreateRun_Error(t *testing.T) {
 					&experimentRepository,
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -205,6 +208,7 @@ func TestService_CreateRun_Error(t *testing.T) {
 					&experimentRepository,
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -243,6 +247,7 @@ func TestService_UpdateRun_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -268,6 +273,7 @@ func TestService_UpdateRun_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -310,6 +316,7 @@ func TestService_RestoreRun_Ok(t *testing.T) {
 		&repositories.MockExperimentRepositoryProvider{},
 		&repositories.MockLogRepositoryProvider{},
 		&repositories.MockArtifactRepositoryProvider{},
+		&repositories.MockInputRepositoryProvider{},
 	)
 	err := service.RestoreRun(context.TODO(), &models.Namespace{ID: 1}, &request.RestoreRunRequest{RunID: "1"})
 
@@ -337,6 +344,7 @@ func TestService_RestoreRun_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -362,6 +370,7 @@ func TestService_RestoreRun_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -399,6 +408,7 @@ func TestService_RestoreRun_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -442,6 +452,7 @@ func TestService_SetRunTag_Ok(t *testing.T) {
 		&repositories.MockExperimentRepositoryProvider{},
 		&repositories.MockLogRepositoryProvider{},
 		&repositories.MockArtifactRepositoryProvider{},
+		&repositories.MockInputRepositoryProvider{},
 	)
 	err := service.SetRunTag(context.TODO(), &models.Namespace{
 		ID: 1,
@@ -456,6 +467,162 @@ func TestService_SetRunTag_Ok(t *testing.T) {
 }
 func TestService_SetRunTag_Error(t *testing.T) {}
 
+func TestService_LogInputs_Ok(t *testing.T) {
+	// init repository mocks.
+	runRepository := repositories.MockRunRepositoryProvider{}
+	runRepository.On(
+		"GetByNamespaceIDRunIDAndLifecycleStage",
+		context.TODO(),
+		uint(1),
+		"1",
+		models.LifecycleStageActive,
+	).Return(
+		&models.Run{ID: "1", ExperimentID: 2, LifecycleStage: models.LifecycleStageActive}, nil,
+	)
+	inputRepository := repositories.MockInputRepositoryProvider{}
+	inputRepository.On(
+		"CreateBatch",
+		context.TODO(),
+		mock.MatchedBy(func(inputs []models.Input) bool {
+			return len(inputs) == 1 &&
+				inputs[0].DestinationID == "1" &&
+				inputs[0].Dataset.ExperimentID == 2 &&
+				inputs[0].Dataset.Name == "name" &&
+				len(inputs[0].Tags) == 1 &&
+				inputs[0].Tags[0].InputID == inputs[0].ID
+		}),
+	).Return(nil)
+
+	// call service under testing.
+	service := NewService(
+		&repositories.MockTagRepositoryProvider{},
+		&runRepository,
+		&repositories.MockParamRepositoryProvider{},
+		&repositories.MockMetricRepositoryProvider{},
+		&repositories.MockExperimentRepositoryProvider{},
+		&repositories.MockLogRepositoryProvider{},
+		&repositories.MockArtifactRepositoryProvider{},
+		&inputRepository,
+	)
+	err := service.LogInputs(context.TODO(), &models.Namespace{
+		ID: 1,
+	}, &request.LogInputsRequest{
+		RunID: "1",
+		Datasets: []request.DatasetInputPartialRequest{
+			{
+				Tags: []request.InputTagPartialRequest{
+					{
+						Key:   "mlflow.data.context",
+						Value: "training",
+					},
+				},
+				Dataset: request.DatasetPartialRequest{
+					Name:       "name",
+					Digest:     "digest",
+					SourceType: "local",
+					Source:     "source",
+				},
+			},
+		},
+	})
+
+	// compare results.
+	require.Nil(t, err)
+	inputRepository.AssertExpectations(t)
+}
+
+func TestService_LogInputs_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.LogInputsRequest
+		service func() *Service
+	}{
+		{
+			name:    "EmptyRunID",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'run_id'"),
+			request: &request.LogInputsRequest{},
+			service: func() *Service {
+				return NewService(
+					&repositories.MockTagRepositoryProvider{},
+					&repositories.MockRunRepositoryProvider{},
+					&repositories.MockParamRepositoryProvider{},
+					&repositories.MockMetricRepositoryProvider{},
+					&repositories.MockExperimentRepositoryProvider{},
+					&repositories.MockLogRepositoryProvider{},
+					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+				)
+			},
+		},
+		{
+			name:  "RunNotFound",
+			error: api.NewResourceDoesNotExistError("Run '1' not found"),
+			request: &request.LogInputsRequest{
+				RunID: "1",
+			},
+			service: func() *Service {
+				runRepository := repositories.MockRunRepositoryProvider{}
+				runRepository.On(
+					"GetByNamespaceIDRunIDAndLifecycleStage",
+					context.TODO(),
+					uint(1),
+					"1",
+					models.LifecycleStageActive,
+				).Return(nil, nil)
+				return NewService(
+					&repositories.MockTagRepositoryProvider{},
+					&runRepository,
+					&repositories.MockParamRepositoryProvider{},
+					&repositories.MockMetricRepositoryProvider{},
+					&repositories.MockExperimentRepositoryProvider{},
+					&repositories.MockLogRepositoryProvider{},
+					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+				)
+			},
+		},
+		{
+			name:  "DatabaseError",
+			error: api.NewInternalError("unable to insert inputs for run '1': database error"),
+			request: &request.LogInputsRequest{
+				RunID: "1",
+			},
+			service: func() *Service {
+				runRepository := repositories.MockRunRepositoryProvider{}
+				runRepository.On(
+					"GetByNamespaceIDRunIDAndLifecycleStage",
+					context.TODO(),
+					uint(1),
+					"1",
+					models.LifecycleStageActive,
+				).Return(&models.Run{ID: "1"}, nil)
+				inputRepository := repositories.MockInputRepositoryProvider{}
+				inputRepository.On(
+					"CreateBatch", context.TODO(), []models.Input{},
+				).Return(errors.New("database error"))
+				return NewService(
+					&repositories.MockTagRepositoryProvider{},
+					&runRepository,
+					&repositories.MockParamRepositoryProvider{},
+					&repositories.MockMetricRepositoryProvider{},
+					&repositories.MockExperimentRepositoryProvider{},
+					&repositories.MockLogRepositoryProvider{},
+					&repositories.MockArtifactRepositoryProvider{},
+					&inputRepository,
+				)
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.service().LogInputs(context.TODO(), &models.Namespace{ID: 1}, tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
 func TestService_DeleteRun_Ok(t *testing.T) {
 	// init repository mocks.
 	runRepository := repositories.MockRunRepositoryProvider{}
@@ -480,6 +647,7 @@ func TestService_DeleteRun_Ok(t *testing.T) {
 		&repositories.MockExperimentRepositoryProvider{},
 		&repositories.MockLogRepositoryProvider{},
 		&repositories.MockArtifactRepositoryProvider{},
+		&repositories.MockInputRepositoryProvider{},
 	)
 	err := service.DeleteRun(context.TODO(), &models.Namespace{ID: 1}, &request.DeleteRunRequest{RunID: "1"})
 
@@ -507,6 +675,7 @@ func TestService_DeleteRun_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -532,6 +701,7 @@ func TestService_DeleteRun_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -557,6 +727,7 @@ func TestService_DeleteRun_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -592,6 +763,7 @@ func TestService_DeleteRun_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -629,6 +801,7 @@ func TestService_DeleteRunTag_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -655,6 +828,7 @@ func TestService_DeleteRunTag_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -681,6 +855,7 @@ func TestService_DeleteRunTag_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -718,6 +893,7 @@ func TestService_DeleteRunTag_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -755,6 +931,7 @@ func TestService_DeleteRunTag_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -806,6 +983,7 @@ func TestService_DeleteRunTag_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -874,6 +1052,7 @@ func TestService_GetRun_Ok(t *testing.T) {
 		&repositories.MockExperimentRepositoryProvider{},
 		&repositories.MockLogRepositoryProvider{},
 		&repositories.MockArtifactRepositoryProvider{},
+		&repositories.MockInputRepositoryProvider{},
 	)
 	run, err := service.GetRun(context.TODO(), &models.Namespace{
 		ID: 1,
@@ -937,6 +1116,7 @@ func TestService_GetRun_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -962,6 +1142,7 @@ func TestService_GetRun_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -1040,6 +1221,7 @@ func TestService_LogBatch_Ok(t *testing.T) {
 		&repositories.MockExperimentRepositoryProvider{},
 		&repositories.MockLogRepositoryProvider{},
 		&repositories.MockArtifactRepositoryProvider{},
+		&repositories.MockInputRepositoryProvider{},
 	)
 	err := service.LogBatch(context.TODO(), &models.Namespace{
 		ID: 1,
@@ -1091,6 +1273,7 @@ func TestService_LogBatch_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -1117,6 +1300,7 @@ func TestService_LogBatch_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -1143,6 +1327,7 @@ func TestService_LogBatch_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -1169,6 +1354,7 @@ func TestService_LogBatch_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -1204,6 +1390,7 @@ func TestService_LogBatch_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -1251,6 +1438,7 @@ func TestService_LogBatch_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -1298,6 +1486,7 @@ func TestService_LogBatch_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -1375,6 +1564,7 @@ func TestService_LogBatch_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -1474,6 +1664,7 @@ func TestService_LogBatch_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -1525,6 +1716,7 @@ func TestService_LogMetric_Ok(t *testing.T) {
 		&repositories.MockExperimentRepositoryProvider{},
 		&repositories.MockLogRepositoryProvider{},
 		&repositories.MockArtifactRepositoryProvider{},
+		&repositories.MockInputRepositoryProvider{},
 	)
 	err := service.LogMetric(context.TODO(), &models.Namespace{
 		ID: 1,
@@ -1560,6 +1752,7 @@ func TestService_LogMetric_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -1578,6 +1771,7 @@ func TestService_LogMetric_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -1597,6 +1791,7 @@ func TestService_LogMetric_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -1625,6 +1820,7 @@ func TestService_LogMetric_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -1655,6 +1851,7 @@ func TestService_LogMetric_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -1705,6 +1902,7 @@ func TestService_LogMetric_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -1754,6 +1952,7 @@ func TestService_LogParam_Ok(t *testing.T) {
 		&repositories.MockExperimentRepositoryProvider{},
 		&repositories.MockLogRepositoryProvider{},
 		&repositories.MockArtifactRepositoryProvider{},
+		&repositories.MockInputRepositoryProvider{},
 	)
 	err := service.LogParam(context.TODO(), &models.Namespace{
 		ID: 1,
@@ -1787,6 +1986,7 @@ func TestService_LogParam_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -1805,6 +2005,7 @@ func TestService_LogParam_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -1833,6 +2034,7 @@ func TestService_LogParam_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -1861,6 +2063,7 @@ func TestService_LogParam_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -1905,6 +2108,7 @@ func TestService_LogParam_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
@@ -1949,6 +2153,7 @@ func TestService_LogParam_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
 				)
 			},
 		},
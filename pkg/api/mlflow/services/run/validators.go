@@ -1,6 +1,9 @@
 package run
 
 import (
+	"bytes"
+	"encoding/json"
+
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 )
@@ -9,6 +12,15 @@ const (
 	MaxResultsPerPage = 1000000
 )
 
+// max lengths of the dataset input fields, they are limited by the size of `datasets` and `input_tags` columns.
+const (
+	MaxDatasetNameLength       = 500
+	MaxDatasetDigestLength     = 36
+	MaxDatasetSourceTypeLength = 36
+	MaxInputTagKeyLength       = 255
+	MaxInputTagValueLength     = 500
+)
+
 // AllowedViewTypeList supported list of ViewType.
 var (
 	AllowedViewTypeList = map[request.ViewType]struct{}{
@@ -17,6 +29,13 @@ var (
 		request.ViewTypeActiveOnly:  {},
 		request.ViewTypeDeletedOnly: {},
 	}
+	// AllowedExportFormatList supported list of ExportFormat.
+	AllowedExportFormatList = map[request.ExportFormat]struct{}{
+		"":                          {},
+		request.ExportFormatArrow:   {},
+		request.ExportFormatCSV:     {},
+		request.ExportFormatParquet: {},
+	}
 )
 
 // ValidateUpdateRunRequest validates `POST /mlflow/runs/update` request.
@@ -123,6 +142,30 @@ func ValidateLogBatchRequest(req *request.LogBatchRequest) error {
 	return nil
 }
 
+// ValidateLogInputsRequest validates `POST /mlflow/runs/log-inputs` request.
+func ValidateLogInputsRequest(req *request.LogInputsRequest) error {
+	if req.RunID == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'run_id'")
+	}
+	for _, input := range req.Datasets {
+		dataset := input.Dataset
+		if dataset.Name == "" || dataset.Digest == "" || dataset.SourceType == "" || dataset.Source == "" {
+			return api.NewInvalidParameterValueError("Invalid value for parameter 'datasets' supplied")
+		}
+		if len(dataset.Name) > MaxDatasetNameLength ||
+			len(dataset.Digest) > MaxDatasetDigestLength ||
+			len(dataset.SourceType) > MaxDatasetSourceTypeLength {
+			return api.NewInvalidParameterValueError("Invalid value for parameter 'datasets' supplied")
+		}
+		for _, tag := range input.Tags {
+			if tag.Key == "" || len(tag.Key) > MaxInputTagKeyLength || len(tag.Value) > MaxInputTagValueLength {
+				return api.NewInvalidParameterValueError("Invalid value for parameter 'tags' supplied")
+			}
+		}
+	}
+	return nil
+}
+
 // ValidateSearchRunsRequest validates `POST /mlflow/runs/search` request.
 func ValidateSearchRunsRequest(req *request.SearchRunsRequest) error {
 	if _, ok := AllowedViewTypeList[req.ViewType]; !ok {
@@ -134,6 +177,29 @@ func ValidateSearchRunsRequest(req *request.SearchRunsRequest) error {
 	return nil
 }
 
+// ValidateExportRunsRequest validates `POST /mlflow/runs/export` and `POST /mlflow/runs/export-metrics` requests.
+func ValidateExportRunsRequest(req *request.ExportRunsRequest) error {
+	if req.Query != "" {
+		if req.Filter != "" {
+			return api.NewInvalidParameterValueError("filter and query cannot both be specified at the same time")
+		}
+		if req.ViewType != "" {
+			return api.NewInvalidParameterValueError(
+				"run_view_type and query cannot both be specified at the same time",
+			)
+		}
+	} else if len(req.ExperimentIDs) == 0 {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'experiment_ids'")
+	}
+	if _, ok := AllowedViewTypeList[req.ViewType]; !ok {
+		return api.NewInvalidParameterValueError("Invalid run_view_type '%s'", req.ViewType)
+	}
+	if _, ok := AllowedExportFormatList[req.Format]; !ok {
+		return api.NewInvalidParameterValueError("Invalid format '%s'", req.Format)
+	}
+	return nil
+}
+
 // ValidateLogOutputRequest validates `POST /mlflow/runs/output-log` request.
 func ValidateLogOutputRequest(req *request.LogOutputRequest) error {
 	if req.RunID == "" {
@@ -144,3 +210,58 @@ func ValidateLogOutputRequest(req *request.LogOutputRequest) error {
 	}
 	return nil
 }
+
+// validateLogSequenceRequest validates common parameters of `POST /mlflow/runs/log-<sequence>` requests.
+func validateLogSequenceRequest(runID, name string) error {
+	if runID == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'run_id'")
+	}
+	if name == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'name'")
+	}
+	return nil
+}
+
+// ValidateLogTextRequest validates `POST /mlflow/runs/log-text` request.
+func ValidateLogTextRequest(req *request.LogTextRequest) error {
+	return validateLogSequenceRequest(req.RunID, req.Name)
+}
+
+// ValidateLogAudioRequest validates `POST /mlflow/runs/log-audio` request.
+func ValidateLogAudioRequest(req *request.LogAudioRequest) error {
+	if err := validateLogSequenceRequest(req.RunID, req.Name); err != nil {
+		return err
+	}
+	if req.BlobURI == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'blob_uri'")
+	}
+	return nil
+}
+
+// ValidateLogDistributionRequest validates `POST /mlflow/runs/log-distribution` request.
+func ValidateLogDistributionRequest(req *request.LogDistributionRequest) error {
+	if err := validateLogSequenceRequest(req.RunID, req.Name); err != nil {
+		return err
+	}
+	if len(req.Weights) == 0 {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'weights'")
+	}
+	if req.BinRange[0] >= req.BinRange[1] {
+		return api.NewInvalidParameterValueError("Invalid value for parameter 'bin_range' supplied")
+	}
+	return nil
+}
+
+// ValidateLogFigureRequest validates `POST /mlflow/runs/log-figure` request.
+func ValidateLogFigureRequest(req *request.LogFigureRequest) error {
+	if err := validateLogSequenceRequest(req.RunID, req.Name); err != nil {
+		return err
+	}
+	if len(req.Figure) == 0 {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'figure'")
+	}
+	if !json.Valid(req.Figure) || !bytes.HasPrefix(bytes.TrimSpace(req.Figure), []byte("{")) {
+		return api.NewInvalidParameterValueError("Invalid value for parameter 'figure' supplied")
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package run
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -368,6 +369,122 @@ func TestValidateLogBatchRequest_Error(t *testing.T) {
 	}
 }
 
+func TestValidateLogInputsRequest_Ok(t *testing.T) {
+	err := ValidateLogInputsRequest(&request.LogInputsRequest{
+		RunID: "id",
+		Datasets: []request.DatasetInputPartialRequest{
+			{
+				Tags: []request.InputTagPartialRequest{
+					{
+						Key:   "mlflow.data.context",
+						Value: "training",
+					},
+				},
+				Dataset: request.DatasetPartialRequest{
+					Name:       "name",
+					Digest:     "digest",
+					SourceType: "local",
+					Source:     "source",
+				},
+			},
+		},
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateLogInputsRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.LogInputsRequest
+	}{
+		{
+			name:    "EmptyRunID",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'run_id'"),
+			request: &request.LogInputsRequest{},
+		},
+		{
+			name:  "EmptyDatasetName",
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'datasets' supplied"),
+			request: &request.LogInputsRequest{
+				RunID: "id",
+				Datasets: []request.DatasetInputPartialRequest{
+					{
+						Dataset: request.DatasetPartialRequest{
+							Digest:     "digest",
+							SourceType: "local",
+							Source:     "source",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "EmptyDatasetSource",
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'datasets' supplied"),
+			request: &request.LogInputsRequest{
+				RunID: "id",
+				Datasets: []request.DatasetInputPartialRequest{
+					{
+						Dataset: request.DatasetPartialRequest{
+							Name:       "name",
+							Digest:     "digest",
+							SourceType: "local",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "TooLongDatasetSourceType",
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'datasets' supplied"),
+			request: &request.LogInputsRequest{
+				RunID: "id",
+				Datasets: []request.DatasetInputPartialRequest{
+					{
+						Dataset: request.DatasetPartialRequest{
+							Name:       "name",
+							Digest:     "digest",
+							SourceType: strings.Repeat("s", MaxDatasetSourceTypeLength+1),
+							Source:     "source",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "TooLongTagValue",
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'tags' supplied"),
+			request: &request.LogInputsRequest{
+				RunID: "id",
+				Datasets: []request.DatasetInputPartialRequest{
+					{
+						Tags: []request.InputTagPartialRequest{
+							{
+								Key:   "key",
+								Value: strings.Repeat("v", MaxInputTagValueLength+1),
+							},
+						},
+						Dataset: request.DatasetPartialRequest{
+							Name:       "name",
+							Digest:     "digest",
+							SourceType: "local",
+							Source:     "source",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLogInputsRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
 func TestValidateSearchRunsRequest_Ok(t *testing.T) {
 	err := ValidateSearchRunsRequest(&request.SearchRunsRequest{
 		ViewType:   request.ViewTypeAll,
@@ -407,6 +524,76 @@ func TestValidateSearchRunsRequest_Error(t *testing.T) {
 	}
 }
 
+func TestValidateExportRunsRequest_Ok(t *testing.T) {
+	err := ValidateExportRunsRequest(&request.ExportRunsRequest{
+		ExperimentIDs: []string{"1"},
+		ViewType:      request.ViewTypeAll,
+		Format:        request.ExportFormatParquet,
+	})
+	require.Nil(t, err)
+
+	err = ValidateExportRunsRequest(&request.ExportRunsRequest{
+		Query:  "run.archived",
+		Format: request.ExportFormatCSV,
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateExportRunsRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.ExportRunsRequest
+	}{
+		{
+			name:    "EmptyExperimentIDs",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'experiment_ids'"),
+			request: &request.ExportRunsRequest{},
+		},
+		{
+			name:  "NotAllowedViewTypeProperty",
+			error: api.NewInvalidParameterValueError("Invalid run_view_type 'not-allowed-view-type'"),
+			request: &request.ExportRunsRequest{
+				ExperimentIDs: []string{"1"},
+				ViewType:      request.ViewType("not-allowed-view-type"),
+			},
+		},
+		{
+			name:  "FilterAndQuery",
+			error: api.NewInvalidParameterValueError("filter and query cannot both be specified at the same time"),
+			request: &request.ExportRunsRequest{
+				Filter: "metrics.loss < 1",
+				Query:  "run.metrics['loss'].last < 1",
+			},
+		},
+		{
+			name: "ViewTypeAndQuery",
+			error: api.NewInvalidParameterValueError(
+				"run_view_type and query cannot both be specified at the same time",
+			),
+			request: &request.ExportRunsRequest{
+				Query:    "run.archived",
+				ViewType: request.ViewTypeAll,
+			},
+		},
+		{
+			name:  "NotAllowedFormatProperty",
+			error: api.NewInvalidParameterValueError("Invalid format 'not-allowed-format'"),
+			request: &request.ExportRunsRequest{
+				ExperimentIDs: []string{"1"},
+				Format:        request.ExportFormat("not-allowed-format"),
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateExportRunsRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
 func TestValidateLogOutputRequest_Ok(t *testing.T) {
 	err := ValidateLogOutputRequest(&request.LogOutputRequest{
 		RunID: "id",
@@ -444,3 +631,110 @@ func TestValidateLogOutputRequest_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateLogDistributionRequest_Ok(t *testing.T) {
+	err := ValidateLogDistributionRequest(&request.LogDistributionRequest{
+		RunID:    "id",
+		Name:     "weights",
+		Weights:  []float64{1, 2, 3},
+		BinRange: [2]float64{-1, 1},
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateLogDistributionRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.LogDistributionRequest
+	}{
+		{
+			name:  "EmptyRunID",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'run_id'"),
+			request: &request.LogDistributionRequest{
+				Name: "weights",
+			},
+		},
+		{
+			name:  "EmptyName",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'name'"),
+			request: &request.LogDistributionRequest{
+				RunID: "id",
+			},
+		},
+		{
+			name:  "EmptyWeights",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'weights'"),
+			request: &request.LogDistributionRequest{
+				RunID: "id",
+				Name:  "weights",
+			},
+		},
+		{
+			name:  "IncorrectBinRange",
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'bin_range' supplied"),
+			request: &request.LogDistributionRequest{
+				RunID:    "id",
+				Name:     "weights",
+				Weights:  []float64{1},
+				BinRange: [2]float64{1, 1},
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLogDistributionRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestValidateLogFigureRequest_Ok(t *testing.T) {
+	err := ValidateLogFigureRequest(&request.LogFigureRequest{
+		RunID:  "id",
+		Name:   "figure",
+		Figure: []byte(`{"data": [{"type": "bar", "y": [1, 2]}]}`),
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateLogFigureRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.LogFigureRequest
+	}{
+		{
+			name:  "EmptyRunID",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'run_id'"),
+			request: &request.LogFigureRequest{
+				Name: "figure",
+			},
+		},
+		{
+			name:  "EmptyFigure",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'figure'"),
+			request: &request.LogFigureRequest{
+				RunID: "id",
+				Name:  "figure",
+			},
+		},
+		{
+			name:  "NotObjectFigure",
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'figure' supplied"),
+			request: &request.LogFigureRequest{
+				RunID:  "id",
+				Name:   "figure",
+				Figure: []byte(`[1, 2]`),
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLogFigureRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
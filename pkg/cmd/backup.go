@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/database"
+)
+
+var BackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Writes a consistent snapshot of the database to a backup file",
+	Long: `The backup command will write the point-in-time consistent
+         snapshot of the whole database into a zstd compressed tar
+         archive, which could be restored by the restore command.
+         SQLite databases are copied by the online backup API, Postgres
+         tables are dumped inside one repeatable read transaction, so
+         the server could keep running while the backup is taken.`,
+	RunE: backupCmd,
+}
+
+func backupCmd(cmd *cobra.Command, args []string) error {
+	if err := checkMetricStore(cmd); err != nil {
+		return err
+	}
+	db, err := database.NewDBProvider(
+		viper.GetString("database-uri"),
+		time.Second*1,
+		20,
+	)
+	if err != nil {
+		return fmt.Errorf("error connecting to DB: %w", err)
+	}
+	//nolint:errcheck
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	var options []func(*database.Backuper)
+	if viper.GetBool("with-artifacts") {
+		artifactStorage, err := storage.NewLocal(config.NewConfig())
+		if err != nil {
+			return fmt.Errorf("error creating local artifact storage: %w", err)
+		}
+		options = append(options, database.WithBackupArtifacts(artifactStorage))
+	}
+
+	output, err := os.Create(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("error creating output file: %w", err)
+	}
+	//nolint:errcheck
+	defer output.Close()
+
+	if err := database.NewBackuper(db, options...).Backup(ctx, output); err != nil {
+		return err
+	}
+	return output.Close()
+}
+
+// nolint:errcheck,gosec
+func init() {
+	RootCmd.AddCommand(BackupCmd)
+
+	BackupCmd.Flags().StringP("database-uri", "d", "sqlite://fasttrackml.db", "Database URI")
+	BackupCmd.Flags().StringP("output", "o", "", "Output backup file (eg., backup.tar.zst)")
+	BackupCmd.Flags().Bool("with-artifacts", false, "Include run artifacts stored in the local file system")
+	BackupCmd.Flags().String("metric-store", "sql", "Metric history store of the server (sql or parquet)")
+	BackupCmd.MarkFlagRequired("output")
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/database"
+)
+
+var ExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exports a namespace to an archive file",
+	Long: `The export command will write the contents of the namespace
+         (experiments, runs, metrics, dashboards, etc.) into a portable
+         zstd compressed tar archive, which could be restored by the
+         import command in another FastTrackML installation.`,
+	RunE: exportCmd,
+}
+
+func exportCmd(cmd *cobra.Command, args []string) error {
+	if err := checkMetricStore(cmd); err != nil {
+		return err
+	}
+	db, err := database.NewDBProvider(
+		viper.GetString("database-uri"),
+		time.Second*1,
+		20,
+	)
+	if err != nil {
+		return fmt.Errorf("error connecting to DB: %w", err)
+	}
+	//nolint:errcheck
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	var options []func(*database.Exporter)
+	if viper.GetBool("with-artifacts") {
+		artifactStorageFactory, err := storage.NewArtifactStorageFactory(config.NewConfig())
+		if err != nil {
+			return fmt.Errorf("error creating artifact storage factory: %w", err)
+		}
+		options = append(options, database.WithExportArtifacts(artifactStorageFactory))
+	}
+
+	output, err := os.Create(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("error creating output file: %w", err)
+	}
+	//nolint:errcheck
+	defer output.Close()
+
+	if err := database.NewExporter(
+		db.GormDB().WithContext(ctx),
+		viper.GetString("namespace"),
+		options...,
+	).Export(ctx, output); err != nil {
+		return err
+	}
+	return output.Close()
+}
+
+// nolint:errcheck,gosec
+func init() {
+	RootCmd.AddCommand(ExportCmd)
+
+	ExportCmd.Flags().StringP("database-uri", "d", "sqlite://fasttrackml.db", "Database URI")
+	ExportCmd.Flags().StringP("namespace", "n", "default", "Namespace to export")
+	ExportCmd.Flags().StringP("output", "o", "", "Output archive file (eg., bundle.tar.zst)")
+	ExportCmd.Flags().Bool("with-artifacts", false, "Include run artifacts into the archive")
+	ExportCmd.Flags().String("metric-store", "sql", "Metric history store of the server (sql or parquet)")
+	ExportCmd.MarkFlagRequired("output")
+}
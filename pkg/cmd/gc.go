@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/metricstore"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/database"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/service/purge"
+)
+
+var GCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Permanently deletes runs in the deleted lifecycle stage",
+	Long: `The gc command will permanently delete the runs in the deleted
+         lifecycle stage together with their metrics and artifacts, the
+         same way as the gc command of MLflow. The runs already deleted
+         and waiting for the purge worker of the server are purged too.`,
+	RunE: gcCmd,
+}
+
+func gcCmd(cmd *cobra.Command, args []string) error {
+	cfg := config.NewConfig()
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if cfg.RunPurgeChunkSize <= 0 {
+		return fmt.Errorf("'run-purge-chunk-size' flag must be positive")
+	}
+
+	db, err := database.NewDBProvider(
+		cfg.DatabaseURI,
+		time.Second*1,
+		20,
+	)
+	if err != nil {
+		return fmt.Errorf("error connecting to DB: %w", err)
+	}
+	//nolint:errcheck
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	if err := database.CheckAndMigrateDB(false, db.GormDB().WithContext(ctx)); err != nil {
+		return fmt.Errorf("error checking database schema: %w", err)
+	}
+
+	artifactStorageFactory, err := storage.NewArtifactStorageFactory(cfg)
+	if err != nil {
+		return fmt.Errorf("error creating artifact storage factory: %w", err)
+	}
+
+	purgeRepository := repositories.NewRunPurgeRepository(db.GormDB())
+	if cfg.MetricStore == metricstore.TypeParquet {
+		metricStore, err := metricstore.NewParquetStore(ctx, artifactStorageFactory, cfg.MetricStoreURI)
+		if err != nil {
+			return fmt.Errorf("error creating parquet metric store: %w", err)
+		}
+		purgeRepository.WithMetricStore(metricStore)
+	}
+
+	var olderThan time.Time
+	if duration := viper.GetDuration("older-than"); duration > 0 {
+		olderThan = time.Now().Add(-duration)
+	}
+	queued, err := purgeRepository.CreateForDeletedRuns(ctx, olderThan, viper.GetStringSlice("run-ids"))
+	if err != nil {
+		return err
+	}
+	log.Infof("%d runs in the deleted lifecycle stage are queued to be purged", queued)
+
+	purged, err := purge.NewPurger(ctx, cfg, purgeRepository, artifactStorageFactory).PurgeAll()
+	log.Infof("%d deleted runs are purged", purged)
+	return err
+}
+
+// nolint:errcheck,gosec
+func init() {
+	RootCmd.AddCommand(GCCmd)
+
+	GCCmd.Flags().StringP("database-uri", "d", "sqlite://fasttrackml.db", "Database URI")
+	GCCmd.Flags().Duration("older-than", 0, "Only delete runs deleted earlier than the duration ago (eg., 720h)")
+	GCCmd.Flags().StringSlice("run-ids", nil, "Comma separated list of the deleted runs to delete (default all)")
+	GCCmd.Flags().Int("run-purge-chunk-size", 10000, "Number of metric iterations removed at once")
+	GCCmd.Flags().String("metric-store", "sql", "Metric history store (sql or parquet)")
+	GCCmd.Flags().String("metric-store-uri", "", "Location of Parquet segments of the 'parquet' metric store")
+}
@@ -13,5 +13,5 @@ var MigrationsCmd = &cobra.Command{
 
 func init() {
 	RootCmd.AddCommand(MigrationsCmd)
-	MigrationsCmd.AddCommand(migrations.CreateCmd, migrations.RebuildCmd)
+	MigrationsCmd.AddCommand(migrations.CreateCmd, migrations.RebuildCmd, migrations.PartitionMetricsCmd)
 }
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/database"
+)
+
+var RestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restores the database from a backup file",
+	Long: `The restore command will replace the content of the database
+         by the snapshot written by the backup command. The database
+         has to be empty, unless --overwrite flag is provided. Please
+         make sure that the FasttrackML server is not currently
+         connected to the database.
+
+         The backup has to be restored into the database of the same
+         type. SQLite backups of the older schema versions are migrated
+         while they are restored, Postgres backups have to be restored
+         by the same version of FastTrackML they were created with.`,
+	RunE: restoreCmd,
+}
+
+func restoreCmd(cmd *cobra.Command, args []string) error {
+	if err := checkMetricStore(cmd); err != nil {
+		return err
+	}
+	db, err := database.NewDBProvider(
+		viper.GetString("database-uri"),
+		time.Second*1,
+		20,
+	)
+	if err != nil {
+		return fmt.Errorf("error connecting to DB: %w", err)
+	}
+	//nolint:errcheck
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	var options []func(*database.Restorer)
+	if viper.GetBool("overwrite") {
+		options = append(options, database.WithRestoreOverwrite())
+	}
+	if viper.GetBool("with-artifacts") {
+		artifactStorage, err := storage.NewLocal(config.NewConfig())
+		if err != nil {
+			return fmt.Errorf("error creating local artifact storage: %w", err)
+		}
+		options = append(options, database.WithRestoreArtifacts(artifactStorage))
+	}
+
+	input, err := os.Open(viper.GetString("input"))
+	if err != nil {
+		return fmt.Errorf("error opening input file: %w", err)
+	}
+	//nolint:errcheck
+	defer input.Close()
+
+	return database.NewRestorer(db, options...).Restore(ctx, input)
+}
+
+// nolint:errcheck,gosec
+func init() {
+	RootCmd.AddCommand(RestoreCmd)
+
+	RestoreCmd.Flags().StringP("database-uri", "d", "sqlite://fasttrackml.db", "Database URI")
+	RestoreCmd.Flags().StringP("input", "i", "", "Input backup file created by backup command (eg., backup.tar.zst)")
+	RestoreCmd.Flags().Bool("overwrite", false, "Replace the content of the existing database")
+	RestoreCmd.Flags().Bool("with-artifacts", false, "Restore run artifacts stored in the backup")
+	RestoreCmd.Flags().String("metric-store", "sql", "Metric history store of the server (sql or parquet)")
+	RestoreCmd.MarkFlagRequired("input")
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/G-Research/fasttrackml/pkg/common/metricstore"
 	"github.com/G-Research/fasttrackml/pkg/version"
 )
 
@@ -45,6 +46,15 @@ func initCmd(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// checkMetricStore returns an error when the metrics are kept by `parquet` metric store,
+// as the command works with the metrics table of the database only.
+func checkMetricStore(cmd *cobra.Command) error {
+	if viper.GetString("metric-store") == metricstore.TypeParquet {
+		return fmt.Errorf("%s command doesn't support 'parquet' metric store", cmd.Name())
+	}
+	return nil
+}
+
 func init() {
 	RootCmd.PersistentFlags().StringP("log-level", "l", "info", "Log level")
 	RootCmd.SetVersionTemplate("FastTrackML version {{.Version}}\n")
@@ -70,6 +70,14 @@ func init() {
 	ServerCmd.Flags().String("s3-endpoint-uri", "", "S3 compatible storage base endpoint url")
 	ServerCmd.Flags().String("gs-endpoint-uri", "", "Google Storage base endpoint url")
 	ServerCmd.Flags().MarkHidden("gs-endpoint-uri")
+	ServerCmd.Flags().Bool("serve-artifacts", false, "Serve artifacts by proxying 'mlflow-artifacts' requests")
+	ServerCmd.Flags().String("artifacts-destination", "./mlartifacts", "Destination of artifacts served by the proxy")
+	ServerCmd.Flags().String("azure-storage-connection-string", "", "Azure storage connection string")
+	ServerCmd.Flags().String("azure-storage-access-key", "", "Azure storage account access key")
+	ServerCmd.Flags().String("azure-storage-endpoint-uri", "", "Azure blob storage service endpoint url")
+	ServerCmd.Flags().String("http-artifacts-username", "", "BasicAuth username for HTTP artifact storage")
+	ServerCmd.Flags().String("http-artifacts-password", "", "BasicAuth password for HTTP artifact storage")
+	ServerCmd.Flags().String("http-artifacts-token", "", "Bearer token for HTTP artifact storage")
 	ServerCmd.Flags().String("auth-username", "", "BasicAuth username")
 	ServerCmd.Flags().String("auth-password", "", "BasicAuth password")
 	ServerCmd.Flags().String("auth-users-config", "", "Users configuration file")
@@ -90,6 +98,22 @@ func init() {
 	ServerCmd.Flags().MarkHidden("dev-mode")
 	ServerCmd.Flags().Int("log-output-max", 2000, "Maximum log rows per run to retain.")
 	ServerCmd.Flags().Duration("log-output-retention", 7*24*time.Hour, "Run logs retention period")
+	ServerCmd.Flags().Bool("metric-queue-enabled", false, "Write logged metrics asynchronously in batches")
+	ServerCmd.Flags().Int("metric-queue-size", 1000000, "Maximum number of metrics buffered by the ingestion queue")
+	ServerCmd.Flags().Int("metric-queue-flush-size", 50000, "Number of buffered metrics which triggers a flush")
+	ServerCmd.Flags().Duration("metric-queue-flush-interval", 1*time.Second, "Ingestion queue flush interval")
+	ServerCmd.Flags().Duration("metric-retention-interval", time.Hour, "Metric retention policy interval (0 to disable)")
+	ServerCmd.Flags().String("metric-store", "sql", "Metric history store (sql or parquet)")
+	ServerCmd.Flags().String("metric-store-uri", "", "Location of Parquet segments of the 'parquet' metric store")
+	ServerCmd.Flags().Duration("run-purge-interval", 10*time.Second, "Deleted runs purge interval (0 to disable)")
+	ServerCmd.Flags().Int("run-purge-chunk-size", 10000, "Number of metric iterations removed at once by run purge")
+	ServerCmd.Flags().Bool("audit-log-enabled", false, "Record mutating Aim, Mlflow and admin calls in the audit log")
+	ServerCmd.Flags().Duration("audit-log-retention", 90*24*time.Hour, "Audit log retention period (0 to keep forever)")
+	ServerCmd.Flags().Int("export-max-running", 4, "Maximum number of background exports running at once (0 for no limit)")
 	viper.BindEnv("auth-username", "MLFLOW_TRACKING_USERNAME")
 	viper.BindEnv("auth-password", "MLFLOW_TRACKING_PASSWORD")
+	viper.BindEnv(
+		"azure-storage-connection-string", "FML_AZURE_STORAGE_CONNECTION_STRING", "AZURE_STORAGE_CONNECTION_STRING",
+	)
+	viper.BindEnv("azure-storage-access-key", "FML_AZURE_STORAGE_ACCESS_KEY", "AZURE_STORAGE_ACCESS_KEY")
 }
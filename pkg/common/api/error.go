@@ -33,6 +33,10 @@ const (
 	ErrorCodeEndpointNotFound       = "ENDPOINT_NOT_FOUND"
 	ErrorCodeResourceAlreadyExists  = "RESOURCE_ALREADY_EXISTS"
 	ErrorCodeResourceDoesNotExist   = "RESOURCE_DOES_NOT_EXIST"
+	ErrorCodeNotImplemented         = "NOT_IMPLEMENTED"
+	ErrorCodePermissionDenied       = "PERMISSION_DENIED"
+	ErrorCodeUnauthenticated        = "UNAUTHENTICATED"
+	ErrorCodeResourceExhausted      = "RESOURCE_EXHAUSTED"
 )
 
 // NewBadRequestError creates new Response object with ErrorCodeBadRequest.
@@ -88,3 +92,39 @@ func NewEndpointNotFound(msg string, args ...any) *ErrorResponse {
 		StatusCode: http.StatusNotFound,
 	}
 }
+
+// NewNotImplementedError creates new Response object with ErrorCodeNotImplemented.
+func NewNotImplementedError(msg string, args ...any) *ErrorResponse {
+	return &ErrorResponse{
+		Message:    fmt.Sprintf(msg, args...),
+		ErrorCode:  ErrorCodeNotImplemented,
+		StatusCode: http.StatusNotImplemented,
+	}
+}
+
+// NewPermissionDeniedError creates new Response object with ErrorCodePermissionDenied.
+func NewPermissionDeniedError(msg string, args ...any) *ErrorResponse {
+	return &ErrorResponse{
+		Message:    fmt.Sprintf(msg, args...),
+		ErrorCode:  ErrorCodePermissionDenied,
+		StatusCode: http.StatusForbidden,
+	}
+}
+
+// NewUnauthenticatedError creates new Response object with ErrorCodeUnauthenticated.
+func NewUnauthenticatedError(msg string, args ...any) *ErrorResponse {
+	return &ErrorResponse{
+		Message:    fmt.Sprintf(msg, args...),
+		ErrorCode:  ErrorCodeUnauthenticated,
+		StatusCode: http.StatusUnauthorized,
+	}
+}
+
+// NewResourceExhaustedError creates new Response object with ErrorCodeResourceExhausted.
+func NewResourceExhaustedError(msg string, args ...any) *ErrorResponse {
+	return &ErrorResponse{
+		Message:    fmt.Sprintf(msg, args...),
+		ErrorCode:  ErrorCodeResourceExhausted,
+		StatusCode: http.StatusTooManyRequests,
+	}
+}
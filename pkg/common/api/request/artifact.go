@@ -1,5 +1,7 @@
 package request
 
+import "path"
+
 // ListArtifactsRequest is a request object for `GET /mlflow/artifacts/list` endpoint.
 type ListArtifactsRequest struct {
 	Path    string `query:"path"`
@@ -29,3 +31,67 @@ func (r GetArtifactRequest) GetRunID() string {
 	}
 	return r.RunUUID
 }
+
+// DownloadArtifactRequest is a request object for `GET /mlflow-artifacts/artifacts/*` endpoint.
+type DownloadArtifactRequest struct {
+	Path string
+}
+
+// UploadArtifactRequest is a request object for `PUT /mlflow-artifacts/artifacts/*` endpoint.
+type UploadArtifactRequest struct {
+	Path string
+}
+
+// DeleteArtifactRequest is a request object for `DELETE /mlflow-artifacts/artifacts/*` endpoint.
+type DeleteArtifactRequest struct {
+	Path string
+}
+
+// ListProxiedArtifactsRequest is a request object for `GET /mlflow-artifacts/artifacts` endpoint.
+type ListProxiedArtifactsRequest struct {
+	Path string `query:"path"`
+}
+
+// CreateMultipartUploadRequest is a request object for `POST /mlflow-artifacts/mpu/create/*` endpoint.
+type CreateMultipartUploadRequest struct {
+	ArtifactPath string `json:"-"`
+	Path         string `json:"path"`
+	NumParts     int32  `json:"num_parts"`
+}
+
+// GetObjectPath returns full path of the uploaded object.
+func (r CreateMultipartUploadRequest) GetObjectPath() string {
+	return path.Join(r.ArtifactPath, path.Base(r.Path))
+}
+
+// MultipartUploadPartRequest is a partial request object for `POST /mlflow-artifacts/mpu/complete/*` endpoint.
+type MultipartUploadPartRequest struct {
+	URL        string `json:"url"`
+	ETag       string `json:"etag"`
+	PartNumber int32  `json:"part_number"`
+}
+
+// CompleteMultipartUploadRequest is a request object for `POST /mlflow-artifacts/mpu/complete/*` endpoint.
+type CompleteMultipartUploadRequest struct {
+	ArtifactPath string                       `json:"-"`
+	Path         string                       `json:"path"`
+	UploadID     string                       `json:"upload_id"`
+	Parts        []MultipartUploadPartRequest `json:"parts"`
+}
+
+// GetObjectPath returns full path of the uploaded object.
+func (r CompleteMultipartUploadRequest) GetObjectPath() string {
+	return path.Join(r.ArtifactPath, path.Base(r.Path))
+}
+
+// AbortMultipartUploadRequest is a request object for `POST /mlflow-artifacts/mpu/abort/*` endpoint.
+type AbortMultipartUploadRequest struct {
+	ArtifactPath string `json:"-"`
+	Path         string `json:"path"`
+	UploadID     string `json:"upload_id"`
+}
+
+// GetObjectPath returns full path of the uploaded object.
+func (r AbortMultipartUploadRequest) GetObjectPath() string {
+	return path.Join(r.ArtifactPath, path.Base(r.Path))
+}
@@ -1,6 +1,10 @@
 package response
 
-import "github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+import (
+	"path"
+
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+)
 
 // FilePartialResponse is a partial response object for different responses.
 type FilePartialResponse struct {
@@ -34,3 +38,57 @@ func NewListArtifactsResponse(
 
 	return &response
 }
+
+// ListProxiedArtifactsResponse is a response object for `GET /mlflow-artifacts/artifacts` endpoint.
+type ListProxiedArtifactsResponse struct {
+	Files []FilePartialResponse `json:"files"`
+}
+
+// NewListProxiedArtifactsResponse creates new instance of ListProxiedArtifactsResponse.
+func NewListProxiedArtifactsResponse(artifacts []storage.ArtifactObject) *ListProxiedArtifactsResponse {
+	response := ListProxiedArtifactsResponse{
+		Files: make([]FilePartialResponse, len(artifacts)),
+	}
+
+	// mlflow-artifacts clients expect only the base name of the listed objects.
+	for i, artifact := range artifacts {
+		response.Files[i] = FilePartialResponse{
+			Path:     path.Base(artifact.GetPath()),
+			IsDir:    artifact.IsDirectory(),
+			FileSize: artifact.GetSize(),
+		}
+	}
+
+	return &response
+}
+
+// MultipartUploadCredentialPartialResponse is a partial response object for `POST /mlflow-artifacts/mpu/create/*`.
+type MultipartUploadCredentialPartialResponse struct {
+	URL        string            `json:"url"`
+	Headers    map[string]string `json:"headers"`
+	PartNumber int32             `json:"part_number"`
+}
+
+// CreateMultipartUploadResponse is a response object for `POST /mlflow-artifacts/mpu/create/*` endpoint.
+type CreateMultipartUploadResponse struct {
+	UploadID    string                                     `json:"upload_id"`
+	Credentials []MultipartUploadCredentialPartialResponse `json:"credentials"`
+}
+
+// NewCreateMultipartUploadResponse creates new instance of CreateMultipartUploadResponse.
+func NewCreateMultipartUploadResponse(upload *storage.MultipartUpload) *CreateMultipartUploadResponse {
+	response := CreateMultipartUploadResponse{
+		UploadID:    upload.UploadID,
+		Credentials: make([]MultipartUploadCredentialPartialResponse, len(upload.Credentials)),
+	}
+
+	for i, credential := range upload.Credentials {
+		response.Credentials[i] = MultipartUploadCredentialPartialResponse{
+			URL:        credential.URL,
+			Headers:    credential.Headers,
+			PartNumber: credential.PartNumber,
+		}
+	}
+
+	return &response
+}
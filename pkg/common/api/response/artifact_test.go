@@ -38,3 +38,33 @@ func TestNewListArtifactsResponse_Ok(t *testing.T) {
 		RootURI: "rootUri",
 	}, response)
 }
+
+func TestNewListProxiedArtifactsResponse_Ok(t *testing.T) {
+	response := NewListProxiedArtifactsResponse([]storage.ArtifactObject{
+		{
+			Path:  "dir/path1",
+			Size:  1234567890,
+			IsDir: false,
+		},
+		{
+			Path:  "dir/path2",
+			Size:  0,
+			IsDir: true,
+		},
+	})
+
+	assert.Equal(t, &ListProxiedArtifactsResponse{
+		Files: []FilePartialResponse{
+			{
+				Path:     "path1",
+				IsDir:    false,
+				FileSize: 1234567890,
+			},
+			{
+				Path:     "path2",
+				IsDir:    true,
+				FileSize: 0,
+			},
+		},
+	}, response)
+}
@@ -76,6 +76,7 @@ func (c Client) Verify(ctx context.Context, accessToken string) (*User, error) {
 		return nil, eris.Wrapf(err, "error converting claim %s property", c.config.Auth.AuthOIDCClaimRoles)
 	}
 	return &User{
+		name:    getUserName(idToken.Subject, claims),
 		roles:   roles,
 		isAdmin: slices.Contains(roles, c.config.Auth.AuthOIDCAdminRole),
 	}, nil
@@ -37,3 +37,14 @@ func NormaliseListenAddress(listenAddress string) string {
 	}
 	return fmt.Sprintf("http://%s", listenAddress)
 }
+
+// getUserName returns the name of the user to identify user in the audit log. Email and preferred username
+// claims are more readable, so they are preferred over the subject of the token.
+func getUserName(subject string, claims map[string]interface{}) string {
+	for _, claim := range []string{"email", "preferred_username"} {
+		if name, ok := claims[claim].(string); ok && name != "" {
+			return name
+		}
+	}
+	return subject
+}
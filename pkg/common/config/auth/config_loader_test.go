@@ -171,6 +171,68 @@ func TestUserPermissions_HasAccess_Ok(t *testing.T) {
 	}
 }
 
+func TestUserPermissions_HasPermission_Ok(t *testing.T) {
+	permissions := models.NewUserPermissions(map[string]map[string]struct{}{
+		"token": {
+			"ns:namespace1":       struct{}{},
+			"ns:namespace2:read":  struct{}{},
+			"ns:namespace3:read":  struct{}{},
+			"ns:namespace3:write": struct{}{},
+		},
+	})
+	tests := []struct {
+		name       string
+		namespace  string
+		permission models.Permission
+		expected   bool
+	}{
+		{
+			name:       "TestNamespaceRoleGrantsOwnerPermission",
+			namespace:  "namespace1",
+			permission: models.PermissionOwner,
+			expected:   true,
+		},
+		{
+			name:       "TestReadRoleGrantsReadPermission",
+			namespace:  "namespace2",
+			permission: models.PermissionRead,
+			expected:   true,
+		},
+		{
+			name:       "TestReadRoleDoesNotGrantWritePermission",
+			namespace:  "namespace2",
+			permission: models.PermissionWrite,
+			expected:   false,
+		},
+		{
+			name:       "TestHighestRoleIsUsed",
+			namespace:  "namespace3",
+			permission: models.PermissionWrite,
+			expected:   true,
+		},
+		{
+			name:       "TestWriteRoleDoesNotGrantOwnerPermission",
+			namespace:  "namespace3",
+			permission: models.PermissionOwner,
+			expected:   false,
+		},
+		{
+			name:       "TestNoRoleForNamespace",
+			namespace:  "namespace4",
+			permission: models.PermissionRead,
+			expected:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authToken := permissions.ValidateAuthToken("token")
+			assert.NotNil(t, authToken)
+			assert.Equal(t, tt.expected, authToken.HasUserPermission(tt.namespace, tt.permission))
+		})
+	}
+}
+
 func TestUserPermissions_HasAdminAccess_Ok(t *testing.T) {
 	tests := []struct {
 		name        string
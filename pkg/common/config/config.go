@@ -13,22 +13,48 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/common/config/auth"
 )
 
+// supportedArtifactSchemes is the list of supported artifact storage schemes.
+var supportedArtifactSchemes = []string{"", "file", "s3", "gs", "wasbs", "wasb", "abfss", "abfs", "http", "https"}
+
+// azureArtifactSchemes is the list of Azure artifact storage schemes.
+var azureArtifactSchemes = []string{"wasbs", "wasb", "abfss", "abfs"}
+
 // Config represents main service configuration.
 type Config struct {
-	Auth                  auth.Config
-	DevMode               bool
-	ListenAddress         string
-	DefaultArtifactRoot   string
-	S3EndpointURI         string
-	GSEndpointURI         string
-	DatabaseURI           string
-	DatabaseReset         bool
-	DatabasePoolMax       int
-	DatabaseMigrate       bool
-	DatabaseSlowThreshold time.Duration
-	LiveUpdatesEnabled    bool
-	RunLogOutputMax       int
-	RunLogOutputRetain    time.Duration
+	Auth                         auth.Config
+	DevMode                      bool
+	ListenAddress                string
+	DefaultArtifactRoot          string
+	S3EndpointURI                string
+	GSEndpointURI                string
+	ServeArtifacts               bool
+	ArtifactsDestination         string
+	AzureStorageConnectionString string
+	AzureStorageAccessKey        string
+	AzureStorageEndpointURI      string
+	HTTPArtifactsUsername        string
+	HTTPArtifactsPassword        string
+	HTTPArtifactsToken           string
+	DatabaseURI                  string
+	DatabaseReset                bool
+	DatabasePoolMax              int
+	DatabaseMigrate              bool
+	DatabaseSlowThreshold        time.Duration
+	LiveUpdatesEnabled           bool
+	RunLogOutputMax              int
+	RunLogOutputRetain           time.Duration
+	AuditLogEnabled              bool
+	AuditLogRetain               time.Duration
+	MetricQueueEnabled           bool
+	MetricQueueSize              int
+	MetricQueueFlushSize         int
+	MetricQueueFlushInterval     time.Duration
+	MetricRetentionInterval      time.Duration
+	MetricStore                  string
+	MetricStoreURI               string
+	RunPurgeInterval             time.Duration
+	RunPurgeChunkSize            int
+	ExportMaxRunning             int
 }
 
 // NewConfig creates a new instance of Config.
@@ -45,19 +71,39 @@ func NewConfig() *Config {
 			AuthOIDCClientSecret:     viper.GetString("auth-oidc-client-secret"),
 			AuthOIDCProviderEndpoint: viper.GetString("auth-oidc-provider-endpoint"),
 		},
-		DevMode:               viper.GetBool("dev-mode"),
-		ListenAddress:         viper.GetString("listen-address"),
-		DefaultArtifactRoot:   viper.GetString("default-artifact-root"),
-		S3EndpointURI:         viper.GetString("s3-endpoint-uri"),
-		GSEndpointURI:         viper.GetString("gs-endpoint-uri"),
-		DatabaseURI:           viper.GetString("database-uri"),
-		DatabaseReset:         viper.GetBool("database-reset"),
-		DatabasePoolMax:       viper.GetInt("database-pool-max"),
-		DatabaseMigrate:       viper.GetBool("database-migrate"),
-		DatabaseSlowThreshold: viper.GetDuration("database-slow-threshold"),
-		LiveUpdatesEnabled:    viper.GetBool("live-updates-enabled"),
-		RunLogOutputMax:       viper.GetInt("log-output-max"),
-		RunLogOutputRetain:    viper.GetDuration("log-output-retention"),
+		DevMode:                      viper.GetBool("dev-mode"),
+		ListenAddress:                viper.GetString("listen-address"),
+		DefaultArtifactRoot:          viper.GetString("default-artifact-root"),
+		S3EndpointURI:                viper.GetString("s3-endpoint-uri"),
+		GSEndpointURI:                viper.GetString("gs-endpoint-uri"),
+		ServeArtifacts:               viper.GetBool("serve-artifacts"),
+		ArtifactsDestination:         viper.GetString("artifacts-destination"),
+		AzureStorageConnectionString: viper.GetString("azure-storage-connection-string"),
+		AzureStorageAccessKey:        viper.GetString("azure-storage-access-key"),
+		AzureStorageEndpointURI:      viper.GetString("azure-storage-endpoint-uri"),
+		HTTPArtifactsUsername:        viper.GetString("http-artifacts-username"),
+		HTTPArtifactsPassword:        viper.GetString("http-artifacts-password"),
+		HTTPArtifactsToken:           viper.GetString("http-artifacts-token"),
+		DatabaseURI:                  viper.GetString("database-uri"),
+		DatabaseReset:                viper.GetBool("database-reset"),
+		DatabasePoolMax:              viper.GetInt("database-pool-max"),
+		DatabaseMigrate:              viper.GetBool("database-migrate"),
+		DatabaseSlowThreshold:        viper.GetDuration("database-slow-threshold"),
+		LiveUpdatesEnabled:           viper.GetBool("live-updates-enabled"),
+		RunLogOutputMax:              viper.GetInt("log-output-max"),
+		RunLogOutputRetain:           viper.GetDuration("log-output-retention"),
+		AuditLogEnabled:              viper.GetBool("audit-log-enabled"),
+		AuditLogRetain:               viper.GetDuration("audit-log-retention"),
+		MetricQueueEnabled:           viper.GetBool("metric-queue-enabled"),
+		MetricQueueSize:              viper.GetInt("metric-queue-size"),
+		MetricQueueFlushSize:         viper.GetInt("metric-queue-flush-size"),
+		MetricQueueFlushInterval:     viper.GetDuration("metric-queue-flush-interval"),
+		MetricRetentionInterval:      viper.GetDuration("metric-retention-interval"),
+		MetricStore:                  viper.GetString("metric-store"),
+		MetricStoreURI:               viper.GetString("metric-store-uri"),
+		RunPurgeInterval:             viper.GetDuration("run-purge-interval"),
+		RunPurgeChunkSize:            viper.GetInt("run-purge-chunk-size"),
+		ExportMaxRunning:             viper.GetInt("export-max-running"),
 	}
 }
 
@@ -80,18 +126,83 @@ func (c *Config) validateConfiguration() error {
 		return eris.Wrap(err, "error parsing 'default-artifact-root' flag")
 	}
 
-	if parsed.User != nil || parsed.RawQuery != "" || parsed.RawFragment != "" {
-		return eris.New("incorrect format of 'default-artifact-root' flag")
+	if parsed.Scheme == "mlflow-artifacts" {
+		if !c.ServeArtifacts {
+			return eris.New("'mlflow-artifacts' schema of 'default-artifact-root' flag requires 'serve-artifacts' flag")
+		}
+	} else if err := validateArtifactURI(parsed, "default-artifact-root"); err != nil {
+		return err
 	}
 
-	if !slices.Contains([]string{"", "file", "s3", "gs"}, parsed.Scheme) {
-		return eris.New("unsupported schema of 'default-artifact-root' flag")
+	// 2. validate ArtifactsDestination configuration parameter when artifacts proxy is enabled.
+	if c.ServeArtifacts {
+		parsed, err := url.Parse(c.ArtifactsDestination)
+		if err != nil {
+			return eris.Wrap(err, "error parsing 'artifacts-destination' flag")
+		}
+		if err := validateArtifactURI(parsed, "artifacts-destination"); err != nil {
+			return err
+		}
 	}
 
 	if err := c.Auth.ValidateConfiguration(); err != nil {
 		return eris.Wrap(err, "error validating auth configuration")
 	}
 
+	// 3. validate metric ingestion queue configuration when the queue is enabled.
+	if c.MetricQueueEnabled {
+		if c.MetricQueueSize <= 0 || c.MetricQueueFlushSize <= 0 || c.MetricQueueFlushInterval <= 0 {
+			return eris.New(
+				"'metric-queue-size', 'metric-queue-flush-size' and 'metric-queue-flush-interval' flags must be positive",
+			)
+		}
+		if c.MetricQueueFlushSize > c.MetricQueueSize {
+			return eris.New("'metric-queue-flush-size' flag must not be greater than 'metric-queue-size' flag")
+		}
+	}
+
+	// 4. validate metric store configuration. Empty value means the default `sql` store.
+	switch c.MetricStore {
+	case "", "sql":
+	case "parquet":
+		if c.MetricStoreURI == "" {
+			return eris.New("'parquet' metric store requires 'metric-store-uri' flag")
+		}
+		parsed, err := url.Parse(c.MetricStoreURI)
+		if err != nil {
+			return eris.Wrap(err, "error parsing 'metric-store-uri' flag")
+		}
+		if err := validateArtifactURI(parsed, "metric-store-uri"); err != nil {
+			return err
+		}
+	default:
+		return eris.Errorf("unsupported value of 'metric-store' flag: %s", c.MetricStore)
+	}
+
+	// 5. validate run purge configuration when the purge worker is enabled.
+	if c.RunPurgeInterval > 0 && c.RunPurgeChunkSize <= 0 {
+		return eris.New("'run-purge-chunk-size' flag must be positive")
+	}
+
+	// 6. validate background exports configuration. Zero value means that the number of exports is not limited.
+	if c.ExportMaxRunning < 0 {
+		return eris.New("'export-max-running' flag must not be negative")
+	}
+
+	return nil
+}
+
+// validateArtifactURI validates artifact storage uri provided by the flag.
+func validateArtifactURI(parsed *url.URL, flag string) error {
+	// Azure uris keep container name in the user info part, e.g. wasbs://container@account.blob.core.windows.net.
+	if (parsed.User != nil && !slices.Contains(azureArtifactSchemes, parsed.Scheme)) ||
+		parsed.RawQuery != "" || parsed.RawFragment != "" {
+		return eris.Errorf("incorrect format of '%s' flag", flag)
+	}
+
+	if !slices.Contains(supportedArtifactSchemes, parsed.Scheme) {
+		return eris.Errorf("unsupported schema of '%s' flag", flag)
+	}
 	return nil
 }
 
@@ -110,6 +221,38 @@ func (c *Config) normalizeConfiguration() error {
 		c.DefaultArtifactRoot = "file://" + absoluteArtifactRoot
 	}
 
+	if c.ServeArtifacts {
+		parsed, err := url.Parse(c.ArtifactsDestination)
+		if err != nil {
+			return eris.Wrap(err, "error parsing 'artifacts-destination' flag")
+		}
+		switch parsed.Scheme {
+		case "", "file":
+			absoluteDestination, err := filepath.Abs(path.Join(parsed.Host, parsed.Path))
+			if err != nil {
+				return eris.Wrapf(
+					err, "error getting absolute path for 'artifacts-destination': %s", c.ArtifactsDestination,
+				)
+			}
+			c.ArtifactsDestination = "file://" + absoluteDestination
+		}
+	}
+
+	if c.MetricStoreURI != "" {
+		parsed, err := url.Parse(c.MetricStoreURI)
+		if err != nil {
+			return eris.Wrap(err, "error parsing 'metric-store-uri' flag")
+		}
+		switch parsed.Scheme {
+		case "", "file":
+			absoluteStoreURI, err := filepath.Abs(path.Join(parsed.Host, parsed.Path))
+			if err != nil {
+				return eris.Wrapf(err, "error getting absolute path for 'metric-store-uri': %s", c.MetricStoreURI)
+			}
+			c.MetricStoreURI = "file://" + absoluteStoreURI
+		}
+	}
+
 	if err := c.Auth.NormalizeConfiguration(); err != nil {
 		return eris.Wrap(err, "error normalizing auth configuration")
 	}
@@ -3,6 +3,7 @@ package config
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/rotisserie/eris"
 	"github.com/stretchr/testify/assert"
@@ -24,6 +25,24 @@ func TestConfig_Validate_Ok(t *testing.T) {
 				DefaultArtifactRoot: "s3://bucket_name",
 			},
 		},
+		{
+			name: "DefaultArtifactRootHasAzurePrefix",
+			providedConfig: &Config{
+				DefaultArtifactRoot: "wasbs://container@account.blob.core.windows.net/path",
+			},
+			expectedConfig: &Config{
+				DefaultArtifactRoot: "wasbs://container@account.blob.core.windows.net/path",
+			},
+		},
+		{
+			name: "DefaultArtifactRootHasHTTPSPrefix",
+			providedConfig: &Config{
+				DefaultArtifactRoot: "https://example.com/artifacts",
+			},
+			expectedConfig: &Config{
+				DefaultArtifactRoot: "https://example.com/artifacts",
+			},
+		},
 		{
 			name: "DefaultArtifactRootHasFilePrefixAndIsRelative",
 			providedConfig: &Config{
@@ -68,12 +87,74 @@ func TestConfig_Validate_Ok(t *testing.T) {
 				})(),
 			},
 		},
+		{
+			name: "DefaultArtifactRootHasMlflowArtifactsPrefixAndArtifactsDestinationIsRelative",
+			providedConfig: &Config{
+				ServeArtifacts:       true,
+				DefaultArtifactRoot:  "mlflow-artifacts:/",
+				ArtifactsDestination: "path1/path2/path3",
+			},
+			expectedConfig: &Config{
+				ServeArtifacts:      true,
+				DefaultArtifactRoot: "mlflow-artifacts:/",
+				ArtifactsDestination: (func() string {
+					path, err := filepath.Abs("path1/path2/path3")
+					require.Nil(t, err)
+					return "file://" + path
+				})(),
+			},
+		},
+		{
+			name: "ArtifactsDestinationHasS3Prefix",
+			providedConfig: &Config{
+				ServeArtifacts:       true,
+				DefaultArtifactRoot:  "mlflow-artifacts:/",
+				ArtifactsDestination: "s3://bucket_name",
+			},
+			expectedConfig: &Config{
+				ServeArtifacts:       true,
+				DefaultArtifactRoot:  "mlflow-artifacts:/",
+				ArtifactsDestination: "s3://bucket_name",
+			},
+		},
+		{
+			name: "MetricStoreIsParquetAndURIIsRelative",
+			providedConfig: &Config{
+				DefaultArtifactRoot: "s3://bucket_name",
+				MetricStore:         "parquet",
+				MetricStoreURI:      "path1/path2/path3",
+			},
+			expectedConfig: &Config{
+				DefaultArtifactRoot: "s3://bucket_name",
+				MetricStore:         "parquet",
+				MetricStoreURI: (func() string {
+					path, err := filepath.Abs("path1/path2/path3")
+					require.Nil(t, err)
+					return "file://" + path
+				})(),
+			},
+		},
+		{
+			name: "MetricStoreIsParquetAndURIHasS3Prefix",
+			providedConfig: &Config{
+				DefaultArtifactRoot: "s3://bucket_name",
+				MetricStore:         "parquet",
+				MetricStoreURI:      "s3://bucket_name/metrics",
+			},
+			expectedConfig: &Config{
+				DefaultArtifactRoot: "s3://bucket_name",
+				MetricStore:         "parquet",
+				MetricStoreURI:      "s3://bucket_name/metrics",
+			},
+		},
 	}
 
 	for _, tt := range testData {
 		t.Run(tt.name, func(t *testing.T) {
 			require.Nil(t, tt.providedConfig.Validate())
 			assert.Equal(t, tt.providedConfig.DefaultArtifactRoot, tt.expectedConfig.DefaultArtifactRoot)
+			assert.Equal(t, tt.providedConfig.ArtifactsDestination, tt.expectedConfig.ArtifactsDestination)
+			assert.Equal(t, tt.providedConfig.MetricStoreURI, tt.expectedConfig.MetricStoreURI)
 		})
 	}
 }
@@ -103,6 +184,99 @@ func TestConfig_Validate_Error(t *testing.T) {
 				DefaultArtifactRoot: "unsupported://something",
 			},
 		},
+		{
+			name: "DefaultArtifactRootHasUserInfoWithNonAzureSchema",
+			error: eris.New(
+				"error validating service configuration: incorrect format of 'default-artifact-root' flag",
+			),
+			config: &Config{
+				DefaultArtifactRoot: "s3://user@bucket_name",
+			},
+		},
+		{
+			name: "DefaultArtifactRootHasMlflowArtifactsSchemaWithoutServeArtifacts",
+			error: eris.New(
+				"error validating service configuration: " +
+					"'mlflow-artifacts' schema of 'default-artifact-root' flag requires 'serve-artifacts' flag",
+			),
+			config: &Config{
+				DefaultArtifactRoot: "mlflow-artifacts:/",
+			},
+		},
+		{
+			name: "ArtifactsDestinationHasUnsupportedSchema",
+			error: eris.New(
+				"error validating service configuration: unsupported schema of 'artifacts-destination' flag",
+			),
+			config: &Config{
+				ServeArtifacts:       true,
+				DefaultArtifactRoot:  "mlflow-artifacts:/",
+				ArtifactsDestination: "mlflow-artifacts:/",
+			},
+		},
+		{
+			name: "MetricQueueHasZeroSize",
+			error: eris.New(
+				"error validating service configuration: 'metric-queue-size', 'metric-queue-flush-size' " +
+					"and 'metric-queue-flush-interval' flags must be positive",
+			),
+			config: &Config{
+				MetricQueueEnabled:       true,
+				MetricQueueFlushSize:     100,
+				MetricQueueFlushInterval: time.Second,
+			},
+		},
+		{
+			name: "MetricQueueFlushSizeIsGreaterThanSize",
+			error: eris.New(
+				"error validating service configuration: " +
+					"'metric-queue-flush-size' flag must not be greater than 'metric-queue-size' flag",
+			),
+			config: &Config{
+				MetricQueueEnabled:       true,
+				MetricQueueSize:          100,
+				MetricQueueFlushSize:     1000,
+				MetricQueueFlushInterval: time.Second,
+			},
+		},
+		{
+			name:  "MetricStoreIsNotSupported",
+			error: eris.New("error validating service configuration: unsupported value of 'metric-store' flag: csv"),
+			config: &Config{
+				MetricStore: "csv",
+			},
+		},
+		{
+			name: "MetricStoreIsParquetWithoutURI",
+			error: eris.New(
+				"error validating service configuration: 'parquet' metric store requires 'metric-store-uri' flag",
+			),
+			config: &Config{
+				MetricStore: "parquet",
+			},
+		},
+		{
+			name:  "MetricStoreURIHasUnsupportedPrefix",
+			error: eris.New("error validating service configuration: unsupported schema of 'metric-store-uri' flag"),
+			config: &Config{
+				MetricStore:    "parquet",
+				MetricStoreURI: "unsupported://bucket_name",
+			},
+		},
+		{
+			name:  "RunPurgeHasZeroChunkSize",
+			error: eris.New("error validating service configuration: 'run-purge-chunk-size' flag must be positive"),
+			config: &Config{
+				RunPurgeInterval: time.Second,
+			},
+		},
+		{
+			name:  "ExportMaxRunningIsNegative",
+			error: eris.New("error validating service configuration: 'export-max-running' flag must not be negative"),
+			config: &Config{
+				ExportMaxRunning: -1,
+			},
+		},
 	}
 
 	for _, tt := range testData {
@@ -17,6 +17,8 @@ type EventListenerProvider interface {
 	Listen()
 	// Subscribe subscribe to particular channel.
 	Subscribe(subscriber chan<- string)
+	// Notify sends event to all the listeners of the channel.
+	Notify(payload string) error
 	// GetChannelName returns channel name.
 	GetChannelName() string
 }
@@ -25,6 +27,7 @@ type EventListenerProvider interface {
 type EventListener struct {
 	mu            sync.Mutex
 	ctx           context.Context
+	db            *gorm.DB
 	channel       string
 	connection    *stdlib.Conn
 	subscriptions map[string][]chan<- string
@@ -34,6 +37,7 @@ type EventListener struct {
 func NewEventListener(ctx context.Context, db *gorm.DB, channel string) (*EventListener, error) {
 	eventListener := EventListener{
 		ctx:           ctx,
+		db:            db,
 		channel:       channel,
 		subscriptions: make(map[string][]chan<- string),
 	}
@@ -76,6 +80,11 @@ func NewNamespaceListener(ctx context.Context, db *gorm.DB) (*EventListener, err
 	return NewEventListener(ctx, db, "namespace_update_events")
 }
 
+// NewRunListener creates new database event listener for Run live updates.
+func NewRunListener(ctx context.Context, db *gorm.DB) (*EventListener, error) {
+	return NewEventListener(ctx, db, "run_update_events")
+}
+
 // Listen listens for incoming database events.
 func (el *EventListener) Listen() {
 	// if listener not nil, then listen for incoming events from database.
@@ -93,9 +102,7 @@ func (el *EventListener) Listen() {
 						log.Errorf("error occurred while listening for the event: %+v", err)
 						return
 					}
-					for _, ch := range el.subscriptions[el.channel] {
-						ch <- notification.Payload
-					}
+					el.notifySubscribers(notification.Payload)
 				}
 			}
 		}()
@@ -113,6 +120,30 @@ func (el *EventListener) Subscribe(subscriber chan<- string) {
 	}
 }
 
+// Notify sends event to all the listeners of the channel. For `postgres` the event is sent with `pg_notify`,
+// so the listeners of the other instances receive it too. For other databases the event is delivered
+// to the subscribers of the current instance only.
+func (el *EventListener) Notify(payload string) error {
+	if el.connection == nil {
+		el.notifySubscribers(payload)
+		return nil
+	}
+	if err := el.db.WithContext(el.ctx).Exec(`SELECT pg_notify(?, ?)`, el.channel, payload).Error; err != nil {
+		return eris.Wrap(err, "error triggering 'pg_notify'")
+	}
+	return nil
+}
+
+// notifySubscribers delivers event to the subscribers of the current instance.
+func (el *EventListener) notifySubscribers(payload string) {
+	el.mu.Lock()
+	subscribers := el.subscriptions[el.channel]
+	el.mu.Unlock()
+	for _, ch := range subscribers {
+		ch <- payload
+	}
+}
+
 // GetChannelName returns current channel name.
 func (el *EventListener) GetChannelName() string {
 	return el.channel
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+)
+
+// APITokenPrefix is a prefix of each generated API token, which makes tokens easy to recognize.
+const APITokenPrefix = "fml_"
+
+// apiTokenDisplayLength is a length of the token part which is kept in plain text to identify token in the UI.
+const apiTokenDisplayLength = 8
+
+// APIToken represents model to work with `api_tokens` table.
+// Only the SHA-256 hash of the token is stored, the token itself is shown once when it is created.
+type APIToken struct {
+	Base
+	Name       string `gorm:"not null"`
+	TokenHash  string `gorm:"unique;index;not null"`
+	Prefix     string `gorm:"not null"`
+	Roles      string `gorm:"not null"`
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+// NewAPIToken generates a new random API token and returns the model to store it along with the plain token.
+func NewAPIToken(name string, roles []string, expiresAt *time.Time) (*APIToken, string, error) {
+	data := make([]byte, 32)
+	if _, err := rand.Read(data); err != nil {
+		return nil, "", eris.Wrap(err, "error generating api token")
+	}
+	token := APITokenPrefix + hex.EncodeToString(data)
+	return &APIToken{
+		Name:      name,
+		TokenHash: HashAPIToken(token),
+		Prefix:    token[:len(APITokenPrefix)+apiTokenDisplayLength],
+		Roles:     strings.Join(roles, ","),
+		ExpiresAt: expiresAt,
+	}, token, nil
+}
+
+// HashAPIToken returns the hash of the token under which the token is stored.
+func HashAPIToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// GetRoles returns the list of roles assigned to the token.
+func (t APIToken) GetRoles() []string {
+	if t.Roles == "" {
+		return nil
+	}
+	return strings.Split(t.Roles, ",")
+}
+
+// IsRevoked makes check that token was revoked.
+func (t APIToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// IsExpired makes check that token is expired at the moment of time.
+func (t APIToken) IsExpired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
+
+// IsActive makes check that token could be used at the moment of time.
+func (t APIToken) IsActive(now time.Time) bool {
+	return !t.IsRevoked() && !t.IsExpired(now)
+}
+
+// AuthToken converts token roles into the auth token used to check access permissions.
+func (t APIToken) AuthToken() *BasicAuthToken {
+	roles := make(map[string]struct{})
+	for _, role := range t.GetRoles() {
+		roles[role] = struct{}{}
+	}
+	return &BasicAuthToken{
+		roles: roles,
+	}
+}
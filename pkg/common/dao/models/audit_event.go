@@ -0,0 +1,32 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEvent represents model to work with `audit_events` table.
+// Each event describes one mutating call of Aim, Mlflow or admin API.
+type AuditEvent struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt     time.Time `gorm:"not null;index"`
+	Actor         string    `gorm:"not null;index"`
+	NamespaceCode string    `gorm:"index"`
+	Action        string    `gorm:"not null;index"`
+	Method        string    `gorm:"not null"`
+	Path          string    `gorm:"not null"`
+	TargetIDs     string
+	Request       string
+	StatusCode    int
+	RemoteAddress string
+}
+
+// GetTargetIDs returns the list of IDs of entities affected by the call.
+func (e AuditEvent) GetTargetIDs() []string {
+	if e.TargetIDs == "" {
+		return nil
+	}
+	return strings.Split(e.TargetIDs, ",")
+}
@@ -0,0 +1,57 @@
+package models
+
+import "fmt"
+
+// Permission represents level of access to a namespace.
+type Permission string
+
+// Supported permission levels. Each level includes the lower ones.
+const (
+	// PermissionRead allows to browse the namespace.
+	PermissionRead Permission = "read"
+	// PermissionWrite allows to create and modify entities of the namespace, e.g. to log runs.
+	PermissionWrite Permission = "write"
+	// PermissionOwner allows everything, including deletion of runs and experiments.
+	PermissionOwner Permission = "owner"
+)
+
+// Permissions list of supported permission levels, from the lowest to the highest one.
+var Permissions = []Permission{
+	PermissionRead,
+	PermissionWrite,
+	PermissionOwner,
+}
+
+// level returns position of the permission in the Permissions list or -1 for unknown permission.
+func (p Permission) level() int {
+	for i, permission := range Permissions {
+		if p == permission {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsValid makes check that the permission is supported.
+func (p Permission) IsValid() bool {
+	return p.level() != -1
+}
+
+// Allows makes check that the permission includes the required one.
+func (p Permission) Allows(required Permission) bool {
+	return p.IsValid() && p.level() >= required.level()
+}
+
+// GetNamespacePermission returns the highest permission to the namespace granted by the roles.
+// Roles have `ns:<code>:<permission>` format, `ns:<code>` role grants owner permission.
+func GetNamespacePermission(roles map[string]struct{}, namespace string) (Permission, bool) {
+	if _, ok := roles[fmt.Sprintf("ns:%s", namespace)]; ok {
+		return PermissionOwner, true
+	}
+	for i := len(Permissions) - 1; i >= 0; i-- {
+		if _, ok := roles[fmt.Sprintf("ns:%s:%s", namespace, Permissions[i])]; ok {
+			return Permissions[i], true
+		}
+	}
+	return "", false
+}
@@ -1,7 +1,5 @@
 package models
 
-import "fmt"
-
 // BasicAuthToken represents object to store auth information related to Basic Auth.
 type BasicAuthToken struct {
 	roles map[string]struct{}
@@ -15,12 +13,16 @@ func (p BasicAuthToken) HasAdminAccess() bool {
 	return false
 }
 
-// HasUserAccess makes check that user has permission to access to the requested namespace.
+// HasUserAccess makes check that user has any permission to access to the requested namespace.
 func (p BasicAuthToken) HasUserAccess(namespace string) bool {
-	if _, ok := p.roles[fmt.Sprintf("ns:%s", namespace)]; !ok {
-		return ok
-	}
-	return true
+	_, ok := GetNamespacePermission(p.roles, namespace)
+	return ok
+}
+
+// HasUserPermission makes check that user has the required permission to the requested namespace.
+func (p BasicAuthToken) HasUserPermission(namespace string, required Permission) bool {
+	permission, ok := GetNamespacePermission(p.roles, namespace)
+	return ok && permission.Allows(required)
 }
 
 // GetRoles returns User roles assigned to current Auth token.
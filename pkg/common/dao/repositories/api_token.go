@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
+)
+
+// APITokenRepositoryProvider provides an interface to work with `api_token` entity.
+type APITokenRepositoryProvider interface {
+	BaseRepositoryProvider
+	// Create creates new models.APIToken entity.
+	Create(ctx context.Context, token *models.APIToken) error
+	// Revoke marks models.APIToken entity as revoked.
+	Revoke(ctx context.Context, token *models.APIToken) error
+	// UpdateLastUsedAt updates the time when models.APIToken entity was used last time.
+	UpdateLastUsedAt(ctx context.Context, token *models.APIToken, lastUsedAt time.Time) error
+	// GetByID returns token by its ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*models.APIToken, error)
+	// GetByHash returns token by hash of its value.
+	GetByHash(ctx context.Context, hash string) (*models.APIToken, error)
+	// List returns all tokens.
+	List(ctx context.Context) ([]models.APIToken, error)
+}
+
+// APITokenRepository repository to work with `api_token` entity.
+type APITokenRepository struct {
+	BaseRepositoryProvider
+}
+
+// NewAPITokenRepository creates repository to work with `api_token` entity.
+func NewAPITokenRepository(db *gorm.DB) *APITokenRepository {
+	return &APITokenRepository{
+		NewBaseRepository(db),
+	}
+}
+
+// Create creates new models.APIToken entity.
+func (r APITokenRepository) Create(ctx context.Context, token *models.APIToken) error {
+	if err := r.GetDB().WithContext(ctx).Create(token).Error; err != nil {
+		return eris.Wrap(err, "error creating api token entity")
+	}
+	return nil
+}
+
+// Revoke marks models.APIToken entity as revoked.
+func (r APITokenRepository) Revoke(ctx context.Context, token *models.APIToken) error {
+	revokedAt := time.Now().UTC()
+	if err := r.GetDB().WithContext(ctx).Model(token).Update("revoked_at", revokedAt).Error; err != nil {
+		return eris.Wrapf(err, "error revoking api token with id: %s", token.ID)
+	}
+	token.RevokedAt = &revokedAt
+	return nil
+}
+
+// UpdateLastUsedAt updates the time when models.APIToken entity was used last time.
+func (r APITokenRepository) UpdateLastUsedAt(
+	ctx context.Context, token *models.APIToken, lastUsedAt time.Time,
+) error {
+	if err := r.GetDB().WithContext(ctx).Model(
+		token,
+	).UpdateColumn(
+		"last_used_at", lastUsedAt,
+	).Error; err != nil {
+		return eris.Wrapf(err, "error updating last used time of api token with id: %s", token.ID)
+	}
+	token.LastUsedAt = &lastUsedAt
+	return nil
+}
+
+// GetByID returns token by its ID.
+func (r APITokenRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.APIToken, error) {
+	var token models.APIToken
+	if err := r.GetDB().WithContext(ctx).Where("id = ?", id).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, eris.Wrapf(err, "error getting api token by id: %s", id)
+	}
+	return &token, nil
+}
+
+// GetByHash returns token by hash of its value.
+func (r APITokenRepository) GetByHash(ctx context.Context, hash string) (*models.APIToken, error) {
+	var token models.APIToken
+	if err := r.GetDB().WithContext(ctx).Where("token_hash = ?", hash).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, eris.Wrap(err, "error getting api token by hash")
+	}
+	return &token, nil
+}
+
+// List returns all tokens.
+func (r APITokenRepository) List(ctx context.Context) ([]models.APIToken, error) {
+	var tokens []models.APIToken
+	if err := r.GetDB().WithContext(ctx).Order("created_at DESC").Find(&tokens).Error; err != nil {
+		return nil, eris.Wrap(err, "error listing api tokens")
+	}
+	return tokens, nil
+}
@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
+)
+
+// AuditEventFilter represents conditions to search `audit_event` entities. Empty fields are ignored.
+type AuditEventFilter struct {
+	Actor         string
+	NamespaceCode string
+	Action        string
+	TargetID      string
+	From          *time.Time
+	To            *time.Time
+	Limit         int
+}
+
+// AuditEventRepositoryProvider provides an interface to work with `audit_event` entity.
+type AuditEventRepositoryProvider interface {
+	BaseRepositoryProvider
+	// Create creates new models.AuditEvent entity.
+	Create(ctx context.Context, event *models.AuditEvent) error
+	// List returns the latest events matching the filter.
+	List(ctx context.Context, filter AuditEventFilter) ([]models.AuditEvent, error)
+	// Iterate calls the function for the batches of events matching the filter, from the oldest ones.
+	Iterate(ctx context.Context, filter AuditEventFilter, fn func(events []models.AuditEvent) error) error
+	// CleanExpired deletes the events older than the period.
+	CleanExpired(ctx context.Context, period time.Duration) (int64, error)
+}
+
+// AuditEventRepository repository to work with `audit_event` entity.
+type AuditEventRepository struct {
+	BaseRepositoryProvider
+}
+
+// NewAuditEventRepository creates repository to work with `audit_event` entity.
+func NewAuditEventRepository(db *gorm.DB) *AuditEventRepository {
+	return &AuditEventRepository{
+		NewBaseRepository(db),
+	}
+}
+
+// Create creates new models.AuditEvent entity.
+func (r AuditEventRepository) Create(ctx context.Context, event *models.AuditEvent) error {
+	if err := r.GetDB().WithContext(ctx).Create(event).Error; err != nil {
+		return eris.Wrap(err, "error creating audit event entity")
+	}
+	return nil
+}
+
+// List returns the latest events matching the filter.
+func (r AuditEventRepository) List(ctx context.Context, filter AuditEventFilter) ([]models.AuditEvent, error) {
+	var events []models.AuditEvent
+	query := r.applyFilter(r.GetDB().WithContext(ctx), filter).Order("created_at DESC")
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if err := query.Find(&events).Error; err != nil {
+		return nil, eris.Wrap(err, "error listing audit events")
+	}
+	return events, nil
+}
+
+// Iterate calls the function for the batches of events matching the filter, from the oldest ones.
+func (r AuditEventRepository) Iterate(
+	ctx context.Context, filter AuditEventFilter, fn func(events []models.AuditEvent) error,
+) error {
+	var events []models.AuditEvent
+	if err := r.applyFilter(
+		r.GetDB().WithContext(ctx), filter,
+	).Order(
+		"created_at",
+	).FindInBatches(&events, 1000, func(tx *gorm.DB, batch int) error {
+		return fn(events)
+	}).Error; err != nil {
+		return eris.Wrap(err, "error iterating audit events")
+	}
+	return nil
+}
+
+// CleanExpired deletes the events older than the period.
+func (r AuditEventRepository) CleanExpired(ctx context.Context, period time.Duration) (int64, error) {
+	result := r.GetDB().WithContext(ctx).Where(
+		"created_at < ?", time.Now().UTC().Add(-period),
+	).Delete(&models.AuditEvent{})
+	if result.Error != nil {
+		return 0, eris.Wrap(result.Error, "error deleting expired audit events")
+	}
+	return result.RowsAffected, nil
+}
+
+// applyFilter applies filter conditions to the query.
+func (r AuditEventRepository) applyFilter(query *gorm.DB, filter AuditEventFilter) *gorm.DB {
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.NamespaceCode != "" {
+		query = query.Where("namespace_code = ?", filter.NamespaceCode)
+	}
+	if filter.Action != "" {
+		query = query.Where("action LIKE ?", filter.Action+"%")
+	}
+	if filter.TargetID != "" {
+		// target ids are stored as comma separated list.
+		query = query.Where("','||target_ids||',' LIKE ?", "%,"+filter.TargetID+",%")
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	return query
+}
@@ -3,7 +3,6 @@ package repositories
 import (
 	"context"
 	"encoding/json"
-	"slices"
 
 	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/rotisserie/eris"
@@ -12,19 +11,23 @@ import (
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/common/dao"
+	commonModels "github.com/G-Research/fasttrackml/pkg/common/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/common/events"
 )
 
 // RoleRepositoryProvider provides an interface to work with `role` entity.
 type RoleRepositoryProvider interface {
-	// ValidateRolesAccessToNamespace makes validation that requested roles has access to requested namespace.
-	ValidateRolesAccessToNamespace(ctx context.Context, roles []string, namespaceCode string) (bool, error)
+	// GetRolesPermissionToNamespace returns the highest permission which requested roles have
+	// to requested namespace. Empty permission means that roles have no access to namespace.
+	GetRolesPermissionToNamespace(
+		ctx context.Context, roles []string, namespaceCode string,
+	) (commonModels.Permission, error)
 }
 
 // RoleCachedRepository cached repository to work with `role` entity.
 type RoleCachedRepository struct {
 	db                     *gorm.DB
-	cache                  *lru.Cache[string, []string]
+	cache                  *lru.Cache[string, map[string]commonModels.Permission]
 	namespaceEventListener dao.EventListenerProvider
 }
 
@@ -32,7 +35,7 @@ type RoleCachedRepository struct {
 func NewRoleCachedRepository(
 	ctx context.Context, db *gorm.DB, namespaceEventListener dao.EventListenerProvider,
 ) (*RoleCachedRepository, error) {
-	cache, err := lru.New[string, []string](1000)
+	cache, err := lru.New[string, map[string]commonModels.Permission](1000)
 	if err != nil {
 		return nil, eris.Wrap(err, "error creating lru cache for roles entities")
 	}
@@ -64,19 +67,15 @@ func NewRoleCachedRepository(
 	return &repository, nil
 }
 
-// ValidateRolesAccessToNamespace makes validation that requested roles have access to requested namespace.
-func (r RoleCachedRepository) ValidateRolesAccessToNamespace(
+// GetRolesPermissionToNamespace returns the highest permission which requested roles have
+// to requested namespace. Empty permission means that roles have no access to namespace.
+func (r RoleCachedRepository) GetRolesPermissionToNamespace(
 	ctx context.Context, requestedRoles []string, requestedNamespaceCode string,
-) (bool, error) {
+) (commonModels.Permission, error) {
 	// if namespace already exists in cache, check permissions immediately.
 	namespaceRoles, ok := r.cache.Get(requestedNamespaceCode)
 	if ok {
-		for _, requestedRole := range requestedRoles {
-			if slices.Contains(namespaceRoles, requestedRole) {
-				return true, nil
-			}
-		}
-		return false, nil
+		return getRolesPermission(namespaceRoles, requestedRoles), nil
 	}
 
 	// otherwise, check database and store result in cache.
@@ -94,25 +93,32 @@ func (r RoleCachedRepository) ValidateRolesAccessToNamespace(
 			&models.Namespace{Code: requestedNamespaceCode},
 		),
 	).Find(&data).Error; err != nil {
-		return false, eris.Wrapf(err, "error getting roles for namespace with code: %s", requestedNamespaceCode)
+		return "", eris.Wrapf(err, "error getting roles for namespace with code: %s", requestedNamespaceCode)
 	}
 
-	namespaceRoles = make([]string, len(data))
-	for i, namespaceRole := range data {
-		namespaceRoles[i] = namespaceRole.Role.Name
+	namespaceRoles = make(map[string]commonModels.Permission, len(data))
+	for _, namespaceRole := range data {
+		namespaceRoles[namespaceRole.Role.Name] = namespaceRole.Permission
 	}
 
 	// save into cache.
 	r.cache.Add(requestedNamespaceCode, namespaceRoles)
 
 	// check permissions from a database.
+	return getRolesPermission(namespaceRoles, requestedRoles), nil
+}
+
+// getRolesPermission returns the highest permission of requested roles among namespace roles.
+func getRolesPermission(
+	namespaceRoles map[string]commonModels.Permission, requestedRoles []string,
+) commonModels.Permission {
+	var result commonModels.Permission
 	for _, requestedRole := range requestedRoles {
-		if slices.Contains(namespaceRoles, requestedRole) {
-			return true, nil
+		if permission, ok := namespaceRoles[requestedRole]; ok && !result.Allows(permission) {
+			result = permission
 		}
 	}
-
-	return false, nil
+	return result
 }
 
 // processEvent process incoming event from database.
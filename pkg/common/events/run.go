@@ -0,0 +1,63 @@
+package events
+
+import (
+	"encoding/json"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+)
+
+// RunEventType represents type of the run event.
+type RunEventType string
+
+// Supported run event types.
+const (
+	RunEventTypeMetrics RunEventType = "metrics"
+	RunEventTypeStatus  RunEventType = "status"
+	RunEventTypeLog     RunEventType = "log"
+)
+
+// RunEvent represents update of the run pushed to the live updates subscribers.
+type RunEvent struct {
+	Type        RunEventType     `json:"type"`
+	NamespaceID uint             `json:"namespace_id"`
+	RunID       string           `json:"run_id"`
+	Status      models.Status    `json:"status,omitempty"`
+	EndTime     int64            `json:"end_time,omitempty"`
+	Metrics     []RunEventMetric `json:"metrics,omitempty"`
+	Log         string           `json:"log,omitempty"`
+}
+
+// RunEventMetric represents new metric point of the run.
+type RunEventMetric struct {
+	Key       string          `json:"key"`
+	Value     float64         `json:"value"`
+	IsNan     bool            `json:"is_nan"`
+	Step      int64           `json:"step"`
+	Iter      int64           `json:"iter"`
+	Timestamp int64           `json:"timestamp"`
+	Context   json.RawMessage `json:"context,omitempty"`
+}
+
+// NewRunMetricsEvent creates new RunEvent from the logged metrics.
+func NewRunMetricsEvent(namespaceID uint, runID string, metrics []models.Metric) RunEvent {
+	eventMetrics := make([]RunEventMetric, len(metrics))
+	for i, metric := range metrics {
+		eventMetrics[i] = RunEventMetric{
+			Key:       metric.Key,
+			Value:     metric.Value,
+			IsNan:     metric.IsNan,
+			Step:      metric.Step,
+			Iter:      metric.Iter,
+			Timestamp: metric.Timestamp,
+		}
+		if len(metric.Context.Json) > 0 {
+			eventMetrics[i].Context = json.RawMessage(metric.Context.Json)
+		}
+	}
+	return RunEvent{
+		Type:        RunEventTypeMetrics,
+		NamespaceID: namespaceID,
+		RunID:       runID,
+		Metrics:     eventMetrics,
+	}
+}
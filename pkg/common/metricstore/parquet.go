@@ -0,0 +1,665 @@
+package metricstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+	"github.com/apache/arrow/go/v14/parquet"
+	"github.com/apache/arrow/go/v14/parquet/compress"
+	"github.com/apache/arrow/go/v14/parquet/pqarrow"
+	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+)
+
+const (
+	// parquetSegmentExtension is the extension of the segment objects.
+	parquetSegmentExtension = ".parquet"
+	// parquetMaxSegments is the number of segments of the run, which triggers compaction of the segments.
+	parquetMaxSegments = 16
+	// parquetCacheSize is the maximal number of metrics kept by the cache of the decoded segments.
+	parquetCacheSize = 1000000
+)
+
+// parquetSegmentSchema is the schema of the segment. Run id is not a part of the schema,
+// because each segment contains the metrics of one run.
+var parquetSegmentSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "key", Type: arrow.BinaryTypes.String},
+	{Name: "context_id", Type: arrow.PrimitiveTypes.Uint64},
+	{Name: "iter", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "step", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "timestamp", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "value", Type: arrow.PrimitiveTypes.Float64},
+	{Name: "is_nan", Type: arrow.FixedWidthTypes.Boolean},
+}, nil)
+
+// segmentPoint represents a single metric of the segment.
+type segmentPoint struct {
+	Key       string
+	ContextID uint
+	Iter      int64
+	Step      int64
+	Timestamp int64
+	Value     float64
+	IsNan     bool
+}
+
+// seriesID identifies the series of the run.
+type seriesID struct {
+	Key       string
+	ContextID uint
+}
+
+// ParquetStore represents the metric store, which keeps the metrics of each run as immutable Parquet segments
+// in the artifact storage under `<uri>/<run id>/`. Every write creates a new segment, and the segments are
+// merged into one segment when the run has too many of them.
+type ParquetStore struct {
+	uri     string
+	storage storage.ArtifactStorageProvider
+	cache   *segmentCache
+	// locks serializes compaction of the segments of the same run.
+	locks sync.Map
+}
+
+// NewParquetStore creates the metric store, which keeps the metrics in the artifact storage by provided uri.
+func NewParquetStore(
+	ctx context.Context, artifactStorageFactory storage.ArtifactStorageFactoryProvider, uri string,
+) (*ParquetStore, error) {
+	artifactStorage, err := artifactStorageFactory.GetStorage(ctx, uri)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error getting artifact storage by uri: %s", uri)
+	}
+	return &ParquetStore{
+		uri:     uri,
+		storage: artifactStorage,
+		cache:   newSegmentCache(parquetCacheSize),
+	}, nil
+}
+
+// Write implements Store interface. Metrics of each run are written as a new segment.
+func (s *ParquetStore) Write(ctx context.Context, metrics []Metric) error {
+	runs, points := []string{}, map[string][]segmentPoint{}
+	for _, metric := range metrics {
+		if _, ok := points[metric.RunID]; !ok {
+			runs = append(runs, metric.RunID)
+		}
+		points[metric.RunID] = append(points[metric.RunID], segmentPoint{
+			Key:       metric.Key,
+			ContextID: metric.ContextID,
+			Iter:      metric.Iter,
+			Step:      metric.Step,
+			Timestamp: metric.Timestamp,
+			Value:     metric.Value,
+			IsNan:     metric.IsNan,
+		})
+	}
+
+	for _, runID := range runs {
+		if _, err := s.writeSegment(ctx, runID, newSegmentName(time.Now()), points[runID]); err != nil {
+			return eris.Wrapf(err, "error writing metrics of run: %s", runID)
+		}
+		if err := s.compact(ctx, runID); err != nil {
+			return eris.Wrapf(err, "error compacting metric segments of run: %s", runID)
+		}
+	}
+	return nil
+}
+
+// Read implements Store interface. Segments of each run are loaded when the cursor reaches the run.
+func (s *ParquetStore) Read(ctx context.Context, query *Query) (Cursor, error) {
+	for _, column := range query.OrderBy {
+		switch column {
+		case ColumnKey, ColumnContextID, ColumnIter, ColumnStep, ColumnTimestamp, ColumnValue:
+		default:
+			return nil, eris.Errorf("unsupported order column: %s", column)
+		}
+	}
+	cursor := &parquetCursor{
+		ctx:       ctx,
+		store:     s,
+		query:     query,
+		series:    query.Series,
+		remaining: query.Limit,
+	}
+	// the first run is read immediately, so the errors of the storage are reported by Read.
+	if err := cursor.readNextRun(); err != nil {
+		return nil, err
+	}
+	return cursor, nil
+}
+
+// Delete implements Store interface.
+func (s *ParquetStore) Delete(ctx context.Context, runIDs []string) error {
+	for _, runID := range runIDs {
+		segments, err := s.listSegments(ctx, runID)
+		if err != nil {
+			return err
+		}
+		if len(segments) > 0 {
+			if err := s.storage.Delete(ctx, s.uri, runID); err != nil {
+				return eris.Wrapf(err, "error deleting metric segments of run: %s", runID)
+			}
+		}
+		for _, segment := range segments {
+			s.cache.remove(segment)
+		}
+		s.locks.Delete(runID)
+	}
+	return nil
+}
+
+// listSegments returns the paths of the segments of the run ordered from the oldest to the newest one.
+func (s *ParquetStore) listSegments(ctx context.Context, runID string) ([]string, error) {
+	objects, err := s.storage.List(ctx, s.uri, runID)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error listing metric segments of run: %s", runID)
+	}
+	segments := make([]string, 0, len(objects))
+	for _, object := range objects {
+		if !object.IsDirectory() && strings.HasSuffix(object.GetPath(), parquetSegmentExtension) {
+			segments = append(segments, path.Join(runID, path.Base(object.GetPath())))
+		}
+	}
+	// names of the segments start with their creation time, so the names are ordered by the time.
+	sort.Slice(segments, func(i, j int) bool {
+		return path.Base(segments[i]) < path.Base(segments[j])
+	})
+	return segments, nil
+}
+
+// loadRun loads the series of the run. When the same iteration of the series has been written by several
+// segments, the metric of the newest segment is used.
+func (s *ParquetStore) loadRun(ctx context.Context, runID string) (map[seriesID][]segmentPoint, error) {
+	series, err := s.loadSegments(ctx, runID)
+	if err != nil {
+		// the segment could be removed by the concurrent compaction, so the segments are listed again.
+		log.Debugf("error loading metric segments of run %s, retrying: %s", runID, err)
+		return s.loadSegments(ctx, runID)
+	}
+	return series, nil
+}
+
+// loadSegments loads and merges all the segments of the run.
+func (s *ParquetStore) loadSegments(ctx context.Context, runID string) (map[seriesID][]segmentPoint, error) {
+	segments, err := s.listSegments(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	loaded := make([][]segmentPoint, 0, len(segments))
+	for _, segment := range segments {
+		points, err := s.loadSegment(ctx, segment)
+		if err != nil {
+			return nil, err
+		}
+		loaded = append(loaded, points)
+	}
+	return mergeSegments(loaded), nil
+}
+
+// loadSegment returns the metrics of the segment. Segments are immutable, so they are cached once decoded.
+func (s *ParquetStore) loadSegment(ctx context.Context, segment string) ([]segmentPoint, error) {
+	if points, ok := s.cache.get(segment); ok {
+		return points, nil
+	}
+	reader, err := s.storage.Get(ctx, s.uri, segment)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error getting metric segment: %s", segment)
+	}
+	//nolint:errcheck
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error reading metric segment: %s", segment)
+	}
+	points, err := decodeSegment(ctx, data)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error decoding metric segment: %s", segment)
+	}
+	s.cache.put(segment, points)
+	return points, nil
+}
+
+// writeSegment encodes the metrics and writes them as a new segment of the run.
+func (s *ParquetStore) writeSegment(
+	ctx context.Context, runID, name string, points []segmentPoint,
+) (string, error) {
+	data, err := encodeSegment(points)
+	if err != nil {
+		return "", eris.Wrap(err, "error encoding metric segment")
+	}
+	segment := path.Join(runID, name)
+	if err := s.storage.Put(ctx, s.uri, segment, bytes.NewReader(data)); err != nil {
+		return "", eris.Wrapf(err, "error putting metric segment: %s", segment)
+	}
+	s.cache.put(segment, points)
+	return segment, nil
+}
+
+// compact merges the segments of the run into one segment, when the run has too many segments.
+// The merged segment takes the place of the newest merged segment, so it is still older than the segments
+// written concurrently.
+func (s *ParquetStore) compact(ctx context.Context, runID string) error {
+	lock, _ := s.locks.LoadOrStore(runID, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	segments, err := s.listSegments(ctx, runID)
+	if err != nil {
+		return err
+	}
+	if len(segments) <= parquetMaxSegments {
+		return nil
+	}
+
+	series, err := s.loadSegments(ctx, runID)
+	if err != nil {
+		return err
+	}
+	var points []segmentPoint
+	for _, seriesPoints := range series {
+		points = append(points, seriesPoints...)
+	}
+
+	newest := path.Base(segments[len(segments)-1])
+	name := fmt.Sprintf(
+		"%s-%s%s", strings.SplitN(newest, "-", 2)[0], uuid.NewString(), parquetSegmentExtension,
+	)
+	if _, err := s.writeSegment(ctx, runID, name, points); err != nil {
+		return err
+	}
+	for _, segment := range segments {
+		if path.Base(segment) == name {
+			continue
+		}
+		if err := s.storage.Delete(ctx, s.uri, segment); err != nil {
+			return eris.Wrapf(err, "error deleting compacted metric segment: %s", segment)
+		}
+		s.cache.remove(segment)
+	}
+	return nil
+}
+
+// newSegmentName returns the name of the new segment. The name starts with the creation time, so the names of
+// the segments are ordered by the time, and ends with the random suffix, so concurrent writes don't conflict.
+func newSegmentName(now time.Time) string {
+	return fmt.Sprintf("%020d-%s%s", now.UnixNano(), uuid.NewString(), parquetSegmentExtension)
+}
+
+// mergeSegments merges the metrics of the segments ordered from the oldest to the newest one into the series
+// ordered by iterations.
+func mergeSegments(segments [][]segmentPoint) map[seriesID][]segmentPoint {
+	type pointID struct {
+		seriesID
+		Iter int64
+	}
+	var positions map[pointID]int
+	if len(segments) > 1 {
+		positions = map[pointID]int{}
+	}
+
+	series := map[seriesID][]segmentPoint{}
+	for _, points := range segments {
+		for _, point := range points {
+			id := seriesID{Key: point.Key, ContextID: point.ContextID}
+			if positions != nil {
+				key := pointID{seriesID: id, Iter: point.Iter}
+				if position, ok := positions[key]; ok {
+					series[id][position] = point
+					continue
+				}
+				positions[key] = len(series[id])
+			}
+			series[id] = append(series[id], point)
+		}
+	}
+	for _, points := range series {
+		sort.SliceStable(points, func(i, j int) bool {
+			return points[i].Iter < points[j].Iter
+		})
+	}
+	return series
+}
+
+// encodeSegment encodes the metrics as Parquet file.
+func encodeSegment(points []segmentPoint) ([]byte, error) {
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, parquetSegmentSchema)
+	defer builder.Release()
+
+	keys := builder.Field(0).(*array.StringBuilder)
+	contextIDs := builder.Field(1).(*array.Uint64Builder)
+	iters := builder.Field(2).(*array.Int64Builder)
+	steps := builder.Field(3).(*array.Int64Builder)
+	timestamps := builder.Field(4).(*array.Int64Builder)
+	values := builder.Field(5).(*array.Float64Builder)
+	isNans := builder.Field(6).(*array.BooleanBuilder)
+	for _, point := range points {
+		keys.Append(point.Key)
+		contextIDs.Append(uint64(point.ContextID))
+		iters.Append(point.Iter)
+		steps.Append(point.Step)
+		timestamps.Append(point.Timestamp)
+		values.Append(point.Value)
+		isNans.Append(point.IsNan)
+	}
+	record := builder.NewRecord()
+	defer record.Release()
+
+	var buffer bytes.Buffer
+	writer, err := pqarrow.NewFileWriter(
+		parquetSegmentSchema,
+		&buffer,
+		parquet.NewWriterProperties(parquet.WithCompression(compress.Codecs.Snappy)),
+		pqarrow.DefaultWriterProps(),
+	)
+	if err != nil {
+		return nil, eris.Wrap(err, "error creating parquet writer")
+	}
+	if err := writer.Write(record); err != nil {
+		return nil, eris.Wrap(err, "error writing parquet record")
+	}
+	if err := writer.Close(); err != nil {
+		return nil, eris.Wrap(err, "error closing parquet writer")
+	}
+	return buffer.Bytes(), nil
+}
+
+// decodeSegment decodes the metrics of Parquet file.
+func decodeSegment(ctx context.Context, data []byte) ([]segmentPoint, error) {
+	table, err := pqarrow.ReadTable(
+		ctx,
+		bytes.NewReader(data),
+		parquet.NewReaderProperties(memory.DefaultAllocator),
+		pqarrow.ArrowReadProperties{},
+		memory.DefaultAllocator,
+	)
+	if err != nil {
+		return nil, eris.Wrap(err, "error reading parquet table")
+	}
+	defer table.Release()
+	if !isSegmentSchema(table.Schema()) {
+		return nil, eris.Errorf("unsupported schema of metric segment: %s", table.Schema())
+	}
+
+	reader := array.NewTableReader(table, -1)
+	defer reader.Release()
+
+	points := make([]segmentPoint, 0, table.NumRows())
+	// keys are repeated a lot, so the same string is used for the same key.
+	keys := map[string]string{}
+	for reader.Next() {
+		record := reader.Record()
+		keyColumn := record.Column(0).(*array.String)
+		contextIDColumn := record.Column(1).(*array.Uint64)
+		iterColumn := record.Column(2).(*array.Int64)
+		stepColumn := record.Column(3).(*array.Int64)
+		timestampColumn := record.Column(4).(*array.Int64)
+		valueColumn := record.Column(5).(*array.Float64)
+		isNanColumn := record.Column(6).(*array.Boolean)
+		for i := 0; i < int(record.NumRows()); i++ {
+			key, ok := keys[keyColumn.Value(i)]
+			if !ok {
+				key = strings.Clone(keyColumn.Value(i))
+				keys[key] = key
+			}
+			points = append(points, segmentPoint{
+				Key:       key,
+				ContextID: uint(contextIDColumn.Value(i)),
+				Iter:      iterColumn.Value(i),
+				Step:      stepColumn.Value(i),
+				Timestamp: timestampColumn.Value(i),
+				Value:     valueColumn.Value(i),
+				IsNan:     isNanColumn.Value(i),
+			})
+		}
+	}
+	if err := reader.Err(); err != nil {
+		return nil, eris.Wrap(err, "error reading parquet records")
+	}
+	return points, nil
+}
+
+// isSegmentSchema checks that the schema has the columns of the segment schema. Metadata of the columns is
+// ignored, because it is added by the parquet reader.
+func isSegmentSchema(schema *arrow.Schema) bool {
+	if schema.NumFields() != parquetSegmentSchema.NumFields() {
+		return false
+	}
+	for i, field := range schema.Fields() {
+		expected := parquetSegmentSchema.Field(i)
+		if field.Name != expected.Name || !arrow.TypeEqual(field.Type, expected.Type) {
+			return false
+		}
+	}
+	return true
+}
+
+// parquetCursor represents the cursor over the metrics, which loads the runs one by one.
+type parquetCursor struct {
+	ctx       context.Context
+	store     *ParquetStore
+	query     *Query
+	series    []Series
+	metrics   []Metric
+	current   int
+	remaining int
+	err       error
+}
+
+// Next implements Cursor interface.
+func (c *parquetCursor) Next() bool {
+	if c.query.Limit > 0 && c.remaining == 0 {
+		return false
+	}
+	for c.err == nil && c.current+1 >= len(c.metrics) {
+		if len(c.series) == 0 {
+			return false
+		}
+		c.err = c.readNextRun()
+	}
+	if c.err != nil {
+		return false
+	}
+	if c.query.Limit > 0 {
+		c.remaining--
+	}
+	c.current++
+	return true
+}
+
+// readNextRun reads the metrics of the series of the next run.
+func (c *parquetCursor) readNextRun() error {
+	c.metrics, c.current = nil, -1
+	if len(c.series) == 0 {
+		return nil
+	}
+	end := 1
+	for end < len(c.series) && c.series[end].RunID == c.series[0].RunID {
+		end++
+	}
+	metrics, err := c.readRun(c.series[:end])
+	if err != nil {
+		return err
+	}
+	c.series, c.metrics = c.series[end:], metrics
+	return nil
+}
+
+// readRun returns the metrics of the series of the same run ordered by the query columns.
+func (c *parquetCursor) readRun(series []Series) ([]Metric, error) {
+	runID := series[0].RunID
+	runSeries, err := c.store.loadRun(c.ctx, runID)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error loading metrics of run: %s", runID)
+	}
+
+	var metrics []Metric
+	for _, s := range series {
+		var xAxis map[int64]segmentPoint
+		if c.query.XAxisKey != "" {
+			points := runSeries[seriesID{Key: c.query.XAxisKey, ContextID: s.ContextID}]
+			xAxis = make(map[int64]segmentPoint, len(points))
+			for _, point := range points {
+				xAxis[point.Iter] = point
+			}
+		}
+		for _, point := range runSeries[seriesID{Key: s.Key, ContextID: s.ContextID}] {
+			if s.MaxIter > 0 && point.Iter > s.MaxIter {
+				continue
+			}
+			if s.Interval > 0 && math.Mod(float64(point.Iter)+1+s.Interval/2, s.Interval) >= 1 {
+				continue
+			}
+			metric := Metric{
+				RunID:     runID,
+				Key:       s.GetName(),
+				ContextID: s.ContextID,
+				Context:   s.Context,
+				Iter:      point.Iter,
+				Step:      point.Step,
+				Timestamp: point.Timestamp,
+				Value:     point.Value,
+				IsNan:     point.IsNan,
+			}
+			if xAxisPoint, ok := xAxis[point.Iter]; ok {
+				metric.XAxisValue, metric.XAxisIsNaN = xAxisPoint.Value, xAxisPoint.IsNan
+			}
+			metrics = append(metrics, metric)
+		}
+	}
+
+	sort.SliceStable(metrics, func(i, j int) bool {
+		return compareMetrics(&metrics[i], &metrics[j], c.query.OrderBy) < 0
+	})
+	return metrics, nil
+}
+
+// Metric implements Cursor interface.
+func (c *parquetCursor) Metric() *Metric {
+	return &c.metrics[c.current]
+}
+
+// Err implements Cursor interface.
+func (c *parquetCursor) Err() error {
+	return c.err
+}
+
+// Close implements Cursor interface.
+func (c *parquetCursor) Close() error {
+	c.series, c.metrics = nil, nil
+	return nil
+}
+
+// compareMetrics compares the metrics by the provided columns.
+func compareMetrics(a, b *Metric, columns []Column) int {
+	for _, column := range columns {
+		var result int
+		switch column {
+		case ColumnKey:
+			result = strings.Compare(a.Key, b.Key)
+		case ColumnContextID:
+			result = compare(a.ContextID, b.ContextID)
+		case ColumnIter:
+			result = compare(a.Iter, b.Iter)
+		case ColumnStep:
+			result = compare(a.Step, b.Step)
+		case ColumnTimestamp:
+			result = compare(a.Timestamp, b.Timestamp)
+		case ColumnValue:
+			result = compare(a.Value, b.Value)
+		}
+		if result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+// compare compares two ordered values.
+func compare[T uint | int64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// segmentCache represents LRU cache of the decoded segments limited by the total number of the metrics.
+type segmentCache struct {
+	mu       sync.Mutex
+	size     int
+	capacity int
+	order    []string
+	segments map[string][]segmentPoint
+}
+
+// newSegmentCache creates the cache of the provided capacity.
+func newSegmentCache(capacity int) *segmentCache {
+	return &segmentCache{
+		capacity: capacity,
+		segments: map[string][]segmentPoint{},
+	}
+}
+
+// get returns the cached segment and marks it as recently used.
+func (c *segmentCache) get(segment string) ([]segmentPoint, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	points, ok := c.segments[segment]
+	if ok {
+		c.touch(segment)
+	}
+	return points, ok
+}
+
+// put caches the segment and evicts the least recently used segments, which exceed the capacity.
+func (c *segmentCache) put(segment string, points []segmentPoint) {
+	if len(points) > c.capacity {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.segments[segment]; ok {
+		c.touch(segment)
+		return
+	}
+	c.segments[segment], c.size = points, c.size+len(points)
+	c.order = append(c.order, segment)
+	for c.size > c.capacity {
+		c.size -= len(c.segments[c.order[0]])
+		delete(c.segments, c.order[0])
+		c.order = c.order[1:]
+	}
+}
+
+// remove removes the segment from the cache.
+func (c *segmentCache) remove(segment string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if points, ok := c.segments[segment]; ok {
+		c.size -= len(points)
+		delete(c.segments, segment)
+		c.order = slices.DeleteFunc(c.order, func(s string) bool { return s == segment })
+	}
+}
+
+// touch moves the segment to the end of the eviction order.
+func (c *segmentCache) touch(segment string) {
+	index := slices.Index(c.order, segment)
+	c.order = append(slices.Delete(c.order, index, index+1), segment)
+}
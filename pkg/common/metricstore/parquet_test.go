@@ -0,0 +1,231 @@
+package metricstore
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+)
+
+func newTestParquetStore(t *testing.T) (*ParquetStore, string) {
+	dir := t.TempDir()
+	artifactStorageFactory, err := storage.NewArtifactStorageFactory(&config.Config{})
+	require.Nil(t, err)
+	store, err := NewParquetStore(context.Background(), artifactStorageFactory, "file://"+dir)
+	require.Nil(t, err)
+	return store, dir
+}
+
+func newTestMetrics(runID, key string, contextID uint, iters int) []Metric {
+	metrics := make([]Metric, iters)
+	for i := range metrics {
+		metrics[i] = Metric{
+			RunID:     runID,
+			Key:       key,
+			ContextID: contextID,
+			Iter:      int64(i),
+			Step:      int64(i),
+			Timestamp: int64(1000 + i),
+			Value:     float64(i) * 0.5,
+		}
+	}
+	return metrics
+}
+
+func TestParquetStore_Read_Ok(t *testing.T) {
+	store, _ := newTestParquetStore(t)
+	ctx := context.Background()
+
+	var metrics []Metric
+	metrics = append(metrics, newTestMetrics("run1", "loss", 1, 10)...)
+	metrics = append(metrics, newTestMetrics("run1", "accuracy", 1, 10)...)
+	metrics = append(metrics, newTestMetrics("run1", "loss", 2, 5)...)
+	metrics = append(metrics, newTestMetrics("run2", "loss", 1, 3)...)
+	require.Nil(t, store.Write(ctx, metrics))
+
+	testData := []struct {
+		name     string
+		query    *Query
+		expected []string
+	}{
+		{
+			name: "RunsAreReturnedInOrderOfSeries",
+			query: &Query{
+				Series: []Series{
+					{RunID: "run2", Key: "loss", ContextID: 1},
+					{RunID: "run1", Key: "loss", ContextID: 2},
+				},
+				OrderBy: []Column{ColumnKey, ColumnIter},
+			},
+			expected: []string{
+				"run2/loss/1/0", "run2/loss/1/1", "run2/loss/1/2",
+				"run1/loss/2/0", "run1/loss/2/1", "run1/loss/2/2", "run1/loss/2/3", "run1/loss/2/4",
+			},
+		},
+		{
+			name: "MetricsAreOrderedByColumns",
+			query: &Query{
+				Series: []Series{
+					{RunID: "run1", Key: "loss", ContextID: 1, MaxIter: 1},
+					{RunID: "run1", Key: "accuracy", ContextID: 1, MaxIter: 1},
+				},
+				OrderBy: []Column{ColumnIter, ColumnKey},
+			},
+			expected: []string{
+				"run1/accuracy/1/0", "run1/loss/1/0", "run1/accuracy/1/1", "run1/loss/1/1",
+			},
+		},
+		{
+			name: "SeriesIsRenamed",
+			query: &Query{
+				Series: []Series{
+					{RunID: "run2", Key: "loss", ContextID: 1, Name: "renamed"},
+				},
+				OrderBy: []Column{ColumnIter},
+			},
+			expected: []string{"run2/renamed/1/0", "run2/renamed/1/1", "run2/renamed/1/2"},
+		},
+		{
+			name: "MetricsAreLimited",
+			query: &Query{
+				Series: []Series{
+					{RunID: "run2", Key: "loss", ContextID: 1},
+					{RunID: "run1", Key: "loss", ContextID: 1},
+				},
+				OrderBy: []Column{ColumnIter},
+				Limit:   4,
+			},
+			expected: []string{"run2/loss/1/0", "run2/loss/1/1", "run2/loss/1/2", "run1/loss/1/0"},
+		},
+		{
+			name: "MetricsAreSampledByInterval",
+			query: &Query{
+				Series: []Series{
+					{RunID: "run1", Key: "loss", ContextID: 1, Interval: 2.5},
+				},
+				OrderBy: []Column{ColumnIter},
+			},
+			expected: []string{"run1/loss/1/1", "run1/loss/1/3", "run1/loss/1/6", "run1/loss/1/8"},
+		},
+		{
+			name: "UnknownSeriesIsEmpty",
+			query: &Query{
+				Series: []Series{
+					{RunID: "run3", Key: "loss", ContextID: 1},
+					{RunID: "run1", Key: "unknown", ContextID: 1},
+				},
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := ReadAll(ctx, store, tt.query)
+			require.Nil(t, err)
+			var actualIDs []string
+			for _, metric := range actual {
+				actualIDs = append(
+					actualIDs, fmt.Sprintf("%s/%s/%d/%d", metric.RunID, metric.Key, metric.ContextID, metric.Iter),
+				)
+			}
+			assert.Equal(t, tt.expected, actualIDs)
+		})
+	}
+}
+
+func TestParquetStore_Read_XAxis_Ok(t *testing.T) {
+	store, _ := newTestParquetStore(t)
+	ctx := context.Background()
+
+	require.Nil(t, store.Write(ctx, newTestMetrics("run1", "loss", 1, 3)))
+	require.Nil(t, store.Write(ctx, []Metric{
+		{RunID: "run1", Key: "epoch", ContextID: 1, Iter: 0, Value: 10},
+		{RunID: "run1", Key: "epoch", ContextID: 1, Iter: 2, Value: 12},
+	}))
+
+	metricContext := types.JSONB(`{"subset":"train"}`)
+	actual, err := ReadAll(ctx, store, &Query{
+		Series:   []Series{{RunID: "run1", Key: "loss", ContextID: 1, Context: metricContext}},
+		OrderBy:  []Column{ColumnIter},
+		XAxisKey: "epoch",
+	})
+	require.Nil(t, err)
+	require.Len(t, actual, 3)
+	assert.Equal(
+		t, []float64{10, 0, 12}, []float64{actual[0].XAxisValue, actual[1].XAxisValue, actual[2].XAxisValue},
+	)
+	for _, metric := range actual {
+		assert.Equal(t, metricContext, metric.Context)
+	}
+}
+
+func TestParquetStore_Write_Ok(t *testing.T) {
+	store, dir := newTestParquetStore(t)
+	ctx := context.Background()
+
+	// the same iteration written again is replaced by the newest value.
+	require.Nil(t, store.Write(ctx, newTestMetrics("run1", "loss", 1, 3)))
+	require.Nil(t, store.Write(ctx, []Metric{{RunID: "run1", Key: "loss", ContextID: 1, Iter: 1, Value: 100}}))
+
+	actual, err := ReadAll(ctx, store, &Query{
+		Series:  []Series{{RunID: "run1", Key: "loss", ContextID: 1}},
+		OrderBy: []Column{ColumnIter},
+	})
+	require.Nil(t, err)
+	require.Len(t, actual, 3)
+	assert.Equal(t, []float64{0, 100, 1}, []float64{actual[0].Value, actual[1].Value, actual[2].Value})
+
+	// segments are compacted once the run has too many of them.
+	for i := 0; i < parquetMaxSegments-1; i++ {
+		require.Nil(t, store.Write(ctx, []Metric{{RunID: "run1", Key: "loss", ContextID: 1, Iter: int64(3 + i)}}))
+	}
+	segments, err := os.ReadDir(filepath.Join(dir, "run1"))
+	require.Nil(t, err)
+	assert.Len(t, segments, 1)
+
+	// cache is cleared, so the compacted segment is decoded again.
+	store.cache = newSegmentCache(parquetCacheSize)
+	actual, err = ReadAll(ctx, store, &Query{
+		Series:  []Series{{RunID: "run1", Key: "loss", ContextID: 1}},
+		OrderBy: []Column{ColumnIter},
+	})
+	require.Nil(t, err)
+	require.Len(t, actual, 2+parquetMaxSegments)
+	assert.Equal(t, float64(100), actual[1].Value)
+}
+
+func TestParquetStore_Delete_Ok(t *testing.T) {
+	store, dir := newTestParquetStore(t)
+	ctx := context.Background()
+
+	require.Nil(t, store.Write(
+		ctx, append(newTestMetrics("run1", "loss", 1, 3), newTestMetrics("run2", "loss", 1, 3)...),
+	))
+	require.Nil(t, store.Delete(ctx, []string{"run1", "run3"}))
+
+	_, err := os.Stat(filepath.Join(dir, "run1"))
+	assert.True(t, os.IsNotExist(err))
+
+	actual, err := ReadAll(ctx, store, &Query{
+		Series: []Series{
+			{RunID: "run1", Key: "loss", ContextID: 1},
+			{RunID: "run2", Key: "loss", ContextID: 1},
+		},
+	})
+	require.Nil(t, err)
+	assert.Len(t, actual, 3)
+}
+
+func TestParquetStore_Read_Error(t *testing.T) {
+	store, _ := newTestParquetStore(t)
+	_, err := store.Read(context.Background(), &Query{OrderBy: []Column{"unknown"}})
+	assert.EqualError(t, err, "unsupported order column: unknown")
+}
@@ -0,0 +1,374 @@
+package metricstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+)
+
+const (
+	// sqlDefaultBatchSize is the default number of metrics inserted by one `INSERT` statement.
+	sqlDefaultBatchSize = 1000
+	// sqlReadChunkSize is the maximal number of series requested by one query. Series of the same run are always
+	// requested together, so the number could be exceeded by the run with a lot of series.
+	sqlReadChunkSize = 1000
+)
+
+// sqlMetric represents model to write metrics to `metrics` table.
+type sqlMetric struct {
+	Key       string
+	Value     float64
+	Timestamp int64
+	RunID     string `gorm:"column:run_uuid"`
+	Step      int64
+	IsNan     bool
+	Iter      int64
+	ContextID uint
+}
+
+// TableName returns current table name.
+func (m sqlMetric) TableName() string {
+	return "metrics"
+}
+
+// SQLStore represents the default metric store, which keeps metrics in `metrics` table of the database.
+type SQLStore struct {
+	db *gorm.DB
+	// conn is the connection pinned by the transaction, which is used to insert metrics by Postgres `COPY`.
+	conn      *sql.Conn
+	batchSize int
+}
+
+// NewSQLStore creates the metric store on top of the provided database. The pinned connection is optional,
+// it allows to insert metrics by Postgres `COPY` inside the transaction.
+func NewSQLStore(db *gorm.DB, conn *sql.Conn) *SQLStore {
+	return &SQLStore{
+		db:        db,
+		conn:      conn,
+		batchSize: sqlDefaultBatchSize,
+	}
+}
+
+// WithBatchSize sets the number of metrics inserted by one `INSERT` statement.
+func (s *SQLStore) WithBatchSize(batchSize int) *SQLStore {
+	if batchSize > 0 {
+		s.batchSize = batchSize
+	}
+	return s
+}
+
+// Write implements Store interface. Metrics, which already exist, are ignored.
+func (s SQLStore) Write(ctx context.Context, metrics []Metric) error {
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	rows := make([]sqlMetric, len(metrics))
+	for i, metric := range metrics {
+		rows[i] = sqlMetric{
+			Key:       metric.Key,
+			Value:     metric.Value,
+			Timestamp: metric.Timestamp,
+			RunID:     metric.RunID,
+			Step:      metric.Step,
+			IsNan:     metric.IsNan,
+			Iter:      metric.Iter,
+			ContextID: metric.ContextID,
+		}
+	}
+
+	if s.isCopyAvailable(len(rows)) {
+		if err := s.copyMetrics(ctx, rows); err != nil {
+			return eris.Wrap(err, "error copying metrics")
+		}
+		return nil
+	}
+	if err := s.db.WithContext(ctx).Clauses(
+		clause.OnConflict{DoNothing: true},
+	).CreateInBatches(&rows, s.batchSize).Error; err != nil {
+		return eris.Wrap(err, "error creating metrics")
+	}
+	return nil
+}
+
+// Read implements Store interface.
+func (s SQLStore) Read(ctx context.Context, query *Query) (Cursor, error) {
+	for _, column := range query.OrderBy {
+		if _, err := getSQLOrderColumn(column); err != nil {
+			return nil, err
+		}
+	}
+	cursor := &sqlCursor{
+		ctx:    ctx,
+		store:  s,
+		query:  query,
+		chunks: splitSeriesByRuns(query.Series, sqlReadChunkSize),
+	}
+	if query.Limit > 0 {
+		cursor.remaining = query.Limit
+	}
+	// the first chunk is read immediately, so the errors of the query are reported by Read.
+	if err := cursor.readNextChunk(); err != nil {
+		return nil, err
+	}
+	return cursor, nil
+}
+
+// Delete implements Store interface.
+func (s SQLStore) Delete(ctx context.Context, runIDs []string) error {
+	if len(runIDs) == 0 {
+		return nil
+	}
+	if err := s.db.WithContext(ctx).Where("run_uuid IN ?", runIDs).Delete(&sqlMetric{}).Error; err != nil {
+		return eris.Wrapf(err, "error deleting metrics of runs: %v", runIDs)
+	}
+	return nil
+}
+
+// readChunk runs the query of the chunk of series. Runs of the chunk are ordered by their offsets in the query.
+func (s SQLStore) readChunk(ctx context.Context, query *Query, chunk seriesChunk, limit int) (*sql.Rows, error) {
+	var values strings.Builder
+	args := make([]any, 0, len(chunk.series)*7+len(chunk.runIDs)*2+2)
+	var maxIterFilter, intervalFilter bool
+	for i, series := range chunk.series {
+		if i > 0 {
+			values.WriteString(", ")
+		}
+		values.WriteString(
+			"(CAST(? AS bigint), ?, ?, CAST(? AS bigint), ?, CAST(? AS bigint), CAST(? AS numeric))",
+		)
+		args = append(
+			args,
+			chunk.runOrders[i], series.RunID, series.Key, series.ContextID, series.GetName(),
+			series.MaxIter, series.Interval,
+		)
+		maxIterFilter = maxIterFilter || series.MaxIter > 0
+		intervalFilter = intervalFilter || series.Interval > 0
+	}
+
+	columns := "metrics.run_uuid, series.name, metrics.context_id, metrics.iter, metrics.step, metrics.timestamp, " +
+		"metrics.value, metrics.is_nan"
+	var xAxisJoin string
+	if query.XAxisKey != "" {
+		columns += ", x_axis.value, x_axis.is_nan"
+		xAxisJoin = "LEFT JOIN metrics x_axis ON x_axis.run_uuid = metrics.run_uuid AND " +
+			"x_axis.iter = metrics.iter AND x_axis.context_id = metrics.context_id AND x_axis.key = ? " +
+			"AND x_axis.run_uuid IN ?"
+		args = append(args, query.XAxisKey, chunk.runIDs)
+	}
+
+	// metrics are filtered by run ids explicitly, so partitions of `metrics` table are pruned on Postgres.
+	conditions := []string{"metrics.run_uuid IN ?"}
+	args = append(args, chunk.runIDs)
+	if maxIterFilter {
+		conditions = append(conditions, "(series.max_iter = 0 OR metrics.iter <= series.max_iter)")
+	}
+	if intervalFilter {
+		conditions = append(
+			conditions,
+			"CASE WHEN series.sampling_interval = 0 THEN 1 = 1 "+
+				"ELSE MOD(metrics.iter + 1 + series.sampling_interval / 2.0, series.sampling_interval) < 1 END",
+		)
+	}
+
+	orderBy := []string{"series.run_order"}
+	for _, column := range query.OrderBy {
+		// columns have been already validated.
+		//nolint:errcheck
+		orderColumn, _ := getSQLOrderColumn(column)
+		orderBy = append(orderBy, orderColumn)
+	}
+
+	statement := fmt.Sprintf(
+		"WITH series(run_order, run_uuid, key, context_id, name, max_iter, sampling_interval) AS (VALUES %s) "+
+			"SELECT %s FROM metrics "+
+			"INNER JOIN series ON series.run_uuid = metrics.run_uuid AND series.key = metrics.key "+
+			"AND series.context_id = metrics.context_id %s "+
+			"WHERE %s ORDER BY %s",
+		&values, columns, xAxisJoin, strings.Join(conditions, " AND "), strings.Join(orderBy, ", "),
+	)
+	if limit > 0 {
+		statement += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := s.db.WithContext(ctx).Raw(statement, args...).Rows()
+	if err != nil {
+		return nil, eris.Wrap(err, "error reading metrics")
+	}
+	if err := rows.Err(); err != nil {
+		return nil, eris.Wrap(err, "error getting metrics rows cursor")
+	}
+	return rows, nil
+}
+
+// SeriesTable returns the table of the metric series of the runs aliased as `series`, which has `run_uuid`, `key`
+// and `context_id` columns. The metric history is kept by the database when the store is nil, so the series are
+// found in `metrics` table. The other stores know only the series of `latest_metrics`.
+func SeriesTable(db *gorm.DB, store Store, runIDs []string) *gorm.DB {
+	if store != nil {
+		return db.Table("latest_metrics AS series")
+	}
+	return db.Table("(?) AS series", db.Session(&gorm.Session{NewDB: true}).Model(
+		&sqlMetric{},
+	).Distinct(
+		"run_uuid", "key", "context_id",
+	).Where(
+		"run_uuid IN ?", runIDs,
+	))
+}
+
+// getSQLOrderColumn returns the column of the query the metrics are ordered by.
+func getSQLOrderColumn(column Column) (string, error) {
+	switch column {
+	case ColumnKey:
+		return "series.name", nil
+	case ColumnContextID, ColumnIter, ColumnStep, ColumnTimestamp, ColumnValue:
+		return "metrics." + string(column), nil
+	default:
+		return "", eris.Errorf("unsupported order column: %s", column)
+	}
+}
+
+// sqlCursor represents the cursor over the metrics, which reads the chunks of series one by one.
+type sqlCursor struct {
+	ctx       context.Context
+	store     SQLStore
+	query     *Query
+	chunks    []seriesChunk
+	rows      *sql.Rows
+	contexts  map[uint]types.JSONB
+	metric    Metric
+	remaining int
+	err       error
+}
+
+// Next implements Cursor interface.
+func (c *sqlCursor) Next() bool {
+	for c.err == nil && c.rows != nil {
+		if c.rows.Next() {
+			if err := c.scan(); err != nil {
+				c.err = err
+				return false
+			}
+			if c.query.Limit > 0 {
+				c.remaining--
+			}
+			return true
+		}
+
+		if err := c.rows.Err(); err != nil {
+			c.err = eris.Wrap(err, "error reading metrics")
+			return false
+		}
+		if err := c.rows.Close(); err != nil {
+			c.err = eris.Wrap(err, "error closing metrics rows cursor")
+			return false
+		}
+		c.err = c.readNextChunk()
+	}
+	return false
+}
+
+// readNextChunk runs the query of the next chunk of series. Rows are not set when there are no more chunks
+// or the limit has been reached.
+func (c *sqlCursor) readNextChunk() error {
+	c.rows = nil
+	if len(c.chunks) == 0 || (c.query.Limit > 0 && c.remaining == 0) {
+		return nil
+	}
+	chunk := c.chunks[0]
+	c.chunks = c.chunks[1:]
+	rows, err := c.store.readChunk(c.ctx, c.query, chunk, c.remaining)
+	if err != nil {
+		return err
+	}
+	c.rows, c.contexts = rows, chunk.contexts
+	return nil
+}
+
+// scan scans the current row into the metric.
+func (c *sqlCursor) scan() error {
+	c.metric = Metric{}
+	dest := []any{
+		&c.metric.RunID, &c.metric.Key, &c.metric.ContextID, &c.metric.Iter, &c.metric.Step, &c.metric.Timestamp,
+		&c.metric.Value, &c.metric.IsNan,
+	}
+	var xAxisValue sql.NullFloat64
+	var xAxisIsNaN sql.NullBool
+	if c.query.XAxisKey != "" {
+		dest = append(dest, &xAxisValue, &xAxisIsNaN)
+	}
+	if err := c.rows.Scan(dest...); err != nil {
+		return eris.Wrap(err, "error scanning metric")
+	}
+	c.metric.XAxisValue, c.metric.XAxisIsNaN = xAxisValue.Float64, xAxisIsNaN.Bool
+	c.metric.Context = c.contexts[c.metric.ContextID]
+	return nil
+}
+
+// Metric implements Cursor interface.
+func (c *sqlCursor) Metric() *Metric {
+	return &c.metric
+}
+
+// Err implements Cursor interface.
+func (c *sqlCursor) Err() error {
+	return c.err
+}
+
+// Close implements Cursor interface.
+func (c *sqlCursor) Close() error {
+	c.chunks = nil
+	if c.rows != nil {
+		rows := c.rows
+		c.rows = nil
+		if err := rows.Close(); err != nil {
+			return eris.Wrap(err, "error closing metrics rows cursor")
+		}
+	}
+	return nil
+}
+
+// seriesChunk represents the chunk of series read by one query.
+type seriesChunk struct {
+	series    []Series
+	runOrders []int
+	runIDs    []string
+	contexts  map[uint]types.JSONB
+}
+
+// splitSeriesByRuns splits the series into chunks of the provided size. Series of the same run always belong to
+// the same chunk, so the metrics of each run are ordered by one query.
+func splitSeriesByRuns(series []Series, size int) []seriesChunk {
+	var chunks []seriesChunk
+	var current *seriesChunk
+	for i := 0; i < len(series); {
+		// find the series of the same run.
+		end := i + 1
+		for end < len(series) && series[end].RunID == series[i].RunID {
+			end++
+		}
+		if current == nil || (len(current.series) > 0 && len(current.series)+end-i > size) {
+			chunks = append(chunks, seriesChunk{contexts: map[uint]types.JSONB{}})
+			current = &chunks[len(chunks)-1]
+		}
+		for _, s := range series[i:end] {
+			current.series = append(current.series, s)
+			current.runOrders = append(current.runOrders, len(current.runIDs))
+			if s.Context != nil {
+				current.contexts[s.ContextID] = s.Context
+			}
+		}
+		current.runIDs = append(current.runIDs, series[i].RunID)
+		i = end
+	}
+	return chunks
+}
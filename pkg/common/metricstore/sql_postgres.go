@@ -0,0 +1,130 @@
+package metricstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/rotisserie/eris"
+	"gorm.io/driver/postgres"
+)
+
+const (
+	// metricsCopyThreshold is the minimal number of metrics which are inserted by `COPY`. Smaller batches
+	// are inserted by regular `INSERT`, which is cheaper than creation of the staging table.
+	metricsCopyThreshold = 100
+	// metricsStagingTable is the temporary table metrics are copied to before they are merged into `metrics`.
+	metricsStagingTable = "metrics_staging"
+)
+
+// metricsCopyColumns is the list of `metrics` table columns populated by `COPY`.
+var metricsCopyColumns = []string{
+	"key", "value", "timestamp", "run_uuid", "step", "is_nan", "iter", "context_id",
+}
+
+// pgxExecutor is the common interface of pgx connection and transaction.
+type pgxExecutor interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, src pgx.CopyFromSource) (int64, error)
+}
+
+// isCopyAvailable makes check that metrics can be inserted by Postgres `COPY`. `COPY` requires the underlying
+// pgx connection, which can't be obtained from the transaction started without the pinned connection.
+func (s SQLStore) isCopyAvailable(metricsCount int) bool {
+	if metricsCount < metricsCopyThreshold || s.db.Dialector.Name() != (postgres.Dialector{}).Name() {
+		return false
+	}
+	if s.conn != nil {
+		return true
+	}
+	switch s.db.Statement.ConnPool.(type) {
+	case *sql.DB, *sql.Conn:
+		return true
+	}
+	return false
+}
+
+// copyMetrics inserts metrics by Postgres `COPY` into the temporary staging table, and then merges
+// the staging table into `metrics` table, ignoring already existing metrics the same way `INSERT` does.
+func (s SQLStore) copyMetrics(ctx context.Context, metrics []sqlMetric) error {
+	conn, inTransaction := s.conn, s.conn != nil
+	switch connPool := s.db.Statement.ConnPool.(type) {
+	case *sql.Conn:
+		if conn == nil {
+			conn = connPool
+		}
+	case *sql.DB:
+		if conn == nil {
+			var err error
+			if conn, err = connPool.Conn(ctx); err != nil {
+				return eris.Wrap(err, "error getting database connection")
+			}
+			//nolint:errcheck
+			defer conn.Close()
+		}
+	}
+	if conn == nil {
+		return eris.New("error getting database connection, metrics can't be copied inside the transaction")
+	}
+
+	return conn.Raw(func(driverConn any) error {
+		stdlibConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return eris.New("error getting underlying driver connection. driver connection has no type *stdlib.Conn")
+		}
+		// when the store works inside the transaction, the staging table is merged in that transaction.
+		if inTransaction {
+			return copyMetricsToStagingTable(ctx, stdlibConn.Conn(), metrics)
+		}
+		return pgx.BeginFunc(ctx, stdlibConn.Conn(), func(tx pgx.Tx) error {
+			return copyMetricsToStagingTable(ctx, tx, metrics)
+		})
+	})
+}
+
+// copyMetricsToStagingTable copies metrics to the staging table and merges them into `metrics` table.
+func copyMetricsToStagingTable(ctx context.Context, executor pgxExecutor, metrics []sqlMetric) error {
+	if _, err := executor.Exec(ctx, fmt.Sprintf(
+		"CREATE TEMP TABLE %s (LIKE metrics INCLUDING DEFAULTS) ON COMMIT DROP", metricsStagingTable,
+	)); err != nil {
+		return eris.Wrap(err, "error creating metrics staging table")
+	}
+
+	if _, err := executor.CopyFrom(
+		ctx,
+		pgx.Identifier{metricsStagingTable},
+		metricsCopyColumns,
+		pgx.CopyFromSlice(len(metrics), func(i int) ([]any, error) {
+			return []any{
+				metrics[i].Key,
+				metrics[i].Value,
+				metrics[i].Timestamp,
+				metrics[i].RunID,
+				metrics[i].Step,
+				metrics[i].IsNan,
+				metrics[i].Iter,
+				int64(metrics[i].ContextID),
+			}, nil
+		}),
+	); err != nil {
+		return eris.Wrap(err, "error copying metrics to staging table")
+	}
+
+	columns := strings.Join(metricsCopyColumns, ", ")
+	if _, err := executor.Exec(ctx, fmt.Sprintf(
+		"INSERT INTO metrics (%s) SELECT %s FROM %s ON CONFLICT DO NOTHING",
+		columns, columns, metricsStagingTable,
+	)); err != nil {
+		return eris.Wrap(err, "error merging metrics staging table")
+	}
+
+	// the table is dropped explicitly, because several batches can be copied in the same transaction.
+	if _, err := executor.Exec(ctx, fmt.Sprintf("DROP TABLE %s", metricsStagingTable)); err != nil {
+		return eris.Wrap(err, "error dropping metrics staging table")
+	}
+	return nil
+}
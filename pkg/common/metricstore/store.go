@@ -0,0 +1,157 @@
+package metricstore
+
+import (
+	"context"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+)
+
+// Supported types of the metric store.
+const (
+	TypeSQL     = "sql"
+	TypeParquet = "parquet"
+)
+
+// Column represents a column the metrics could be ordered by.
+type Column string
+
+// Supported columns of the metrics.
+const (
+	ColumnKey       Column = "key"
+	ColumnContextID Column = "context_id"
+	ColumnIter      Column = "iter"
+	ColumnStep      Column = "step"
+	ColumnTimestamp Column = "timestamp"
+	ColumnValue     Column = "value"
+)
+
+// Metric represents a single point of the metric series.
+type Metric struct {
+	RunID      string
+	Key        string
+	ContextID  uint
+	Context    types.JSONB
+	Iter       int64
+	Step       int64
+	Timestamp  int64
+	Value      float64
+	IsNan      bool
+	XAxisValue float64
+	XAxisIsNaN bool
+}
+
+// Series represents a metric series requested from the store.
+type Series struct {
+	RunID     string
+	Key       string
+	ContextID uint
+	// Context is the context json, which is returned together with the metrics of the series.
+	Context types.JSONB
+	// Name is the key the metrics of the series are returned with. Key is used when it is empty.
+	Name string
+	// MaxIter limits iterations of the series. Zero means that iterations are not limited.
+	MaxIter int64
+	// Interval is the sampling interval of the series. Only the iterations which hit the interval are returned.
+	// Zero means that all the iterations are returned.
+	Interval float64
+}
+
+// GetName returns the key the metrics of the series are returned with.
+func (s Series) GetName() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.Key
+}
+
+// Query represents a query of the metric series.
+type Query struct {
+	// Series is the list of requested series. Runs are returned in the order of their first series,
+	// so the series of the same run have to be provided one after another.
+	Series []Series
+	// OrderBy is the list of columns the metrics of each run are ordered by.
+	OrderBy []Column
+	// XAxisKey is the key of the metric, which values are returned as x-axis values of the metrics with the same
+	// run, context and iteration.
+	XAxisKey string
+	// Limit limits the number of returned metrics. Zero means that the number is not limited.
+	Limit int
+}
+
+// Cursor provides an interface to iterate over the metrics returned by the store.
+type Cursor interface {
+	// Next advances the cursor to the next metric. It returns false when there are no more metrics or an error
+	// happened, which is reported by Err.
+	Next() bool
+	// Metric returns the current metric.
+	Metric() *Metric
+	// Err returns the error happened during the iteration.
+	Err() error
+	// Close closes the cursor.
+	Close() error
+}
+
+// Store provides an interface to work with the metric points. Metadata, such as runs, latest metrics and contexts,
+// is kept by the database, so the store is responsible for the metric history only.
+type Store interface {
+	// Write writes the metrics. Iterations and context ids of the metrics have to be already assigned.
+	Write(ctx context.Context, metrics []Metric) error
+	// Read returns a cursor over the metrics matching the query.
+	Read(ctx context.Context, query *Query) (Cursor, error)
+	// Delete deletes all the metrics of the runs.
+	Delete(ctx context.Context, runIDs []string) error
+}
+
+// sliceCursor represents the cursor over the metrics, which are already loaded.
+type sliceCursor struct {
+	metrics []Metric
+	current int
+}
+
+// NewSliceCursor creates a cursor over the provided metrics.
+func NewSliceCursor(metrics []Metric) Cursor {
+	return &sliceCursor{metrics: metrics, current: -1}
+}
+
+// Next implements Cursor interface.
+func (c *sliceCursor) Next() bool {
+	if c.current+1 >= len(c.metrics) {
+		return false
+	}
+	c.current++
+	return true
+}
+
+// Metric implements Cursor interface.
+func (c *sliceCursor) Metric() *Metric {
+	return &c.metrics[c.current]
+}
+
+// Err implements Cursor interface.
+func (c *sliceCursor) Err() error {
+	return nil
+}
+
+// Close implements Cursor interface.
+func (c *sliceCursor) Close() error {
+	return nil
+}
+
+// ReadAll reads all the metrics matching the query.
+func ReadAll(ctx context.Context, store Store, query *Query) ([]Metric, error) {
+	cursor, err := store.Read(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	//nolint:errcheck
+	defer cursor.Close()
+
+	var metrics []Metric
+	for cursor.Next() {
+		metrics = append(metrics, *cursor.Metric())
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// apiTokenLastUsedInterval is the minimal interval between updates of the token last used time,
+// so that not every request made with the token leads to a database write.
+const apiTokenLastUsedInterval = time.Minute
+
+// APITokenMiddleware represents API token middleware.
+type APITokenMiddleware struct {
+	fallback        fiber.Handler
+	tokenRepository repositories.APITokenRepositoryProvider
+}
+
+// NewAPITokenMiddleware creates new API token middleware logic. Requests to Aim or Mlflow resources
+// with `Authorization: Bearer` header are authenticated by API token, the rest of requests are
+// handled by fallback middleware.
+func NewAPITokenMiddleware(
+	tokenRepository repositories.APITokenRepositoryProvider, fallback fiber.Handler,
+) fiber.Handler {
+	return APITokenMiddleware{
+		fallback:        fallback,
+		tokenRepository: tokenRepository,
+	}.Handle()
+}
+
+// Handle handles API token middleware logic.
+func (m APITokenMiddleware) Handle() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		token, ok := strings.CutPrefix(ctx.Get(fiber.HeaderAuthorization), "Bearer ")
+		if !ok || !MlflowAimPrefixRegexp.MatchString(ctx.Path()) {
+			if m.fallback != nil {
+				return m.fallback(ctx)
+			}
+			return ctx.Next()
+		}
+
+		apiToken, err := m.tokenRepository.GetByHash(ctx.Context(), models.HashAPIToken(token))
+		if err != nil {
+			log.Errorf("error validating api token: %+v", err)
+			return api.NewInternalError("error validating api token")
+		}
+		now := time.Now().UTC()
+		if apiToken == nil || !apiToken.IsActive(now) {
+			return ctx.Status(
+				http.StatusUnauthorized,
+			).JSON(
+				api.NewUnauthenticatedError("api token is invalid, expired or revoked"),
+			)
+		}
+		log.Debugf("api token %s has roles: %v associated", apiToken.Prefix, apiToken.GetRoles())
+
+		if apiToken.LastUsedAt == nil || now.Sub(*apiToken.LastUsedAt) >= apiTokenLastUsedInterval {
+			if err := m.tokenRepository.UpdateLastUsedAt(ctx.Context(), apiToken, now); err != nil {
+				log.Errorf("error recording usage of api token %s: %+v", apiToken.Prefix, err)
+			}
+		}
+		setActor(ctx, "token:"+apiToken.Name)
+		return handleAuthTokenAimMlflowResourceRequest(ctx, apiToken.AuthToken())
+	}
+}
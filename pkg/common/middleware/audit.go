@@ -0,0 +1,285 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/services/audit"
+)
+
+const (
+	actorContextKey = "actor"
+	// basicAuthUsernameContextKey is the key under which global Basic Auth middleware stores the username.
+	basicAuthUsernameContextKey = "username"
+)
+
+// AnonymousActor is the actor of requests made without authentication.
+const AnonymousActor = "anonymous"
+
+// maxAuditRequestLength is the maximal length of the request body stored in the audit event.
+const maxAuditRequestLength = 1024
+
+// auditTargetKeys is the list of request fields which hold IDs of the affected entities.
+// JSON bodies are parsed by auditBodyTargets, which has to have the same fields.
+var auditTargetKeys = []string{"run_id", "run_uuid", "experiment_id", "name", "version", "code"}
+
+// auditBodyTargets represents the fields of JSON request body, which hold IDs of the affected entities.
+// The other fields, e.g. logged metrics, are skipped without being decoded.
+type auditBodyTargets struct {
+	RunID        auditTargetID `json:"run_id"`
+	RunUUID      auditTargetID `json:"run_uuid"`
+	ExperimentID auditTargetID `json:"experiment_id"`
+	Name         auditTargetID `json:"name"`
+	Version      auditTargetID `json:"version"`
+	Code         auditTargetID `json:"code"`
+}
+
+// auditTargetID is the ID of the affected entity, which is either JSON string or number.
+// Values of the other types are ignored.
+type auditTargetID string
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (id *auditTargetID) UnmarshalJSON(data []byte) error {
+	switch {
+	case len(data) > 0 && data[0] == '"':
+		var value string
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		*id = auditTargetID(value)
+	case len(data) > 0 && (data[0] == '-' || data[0] >= '0' && data[0] <= '9'):
+		*id = auditTargetID(data)
+	}
+	return nil
+}
+
+// regexps to parse requested API and IDs of the affected entities from the path.
+var (
+	auditPrefixRegexp = regexp.MustCompile(
+		`^/(?:mlflow/)?(?:ajax-)?api/2\.0/(mlflow|mlflow-artifacts)/|^/(aim)/api/|^/(admin)/`,
+	)
+	auditIDRegexp = regexp.MustCompile(
+		`^(\d+|[0-9a-fA-F]{32}|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})$`,
+	)
+)
+
+// auditMethodActions maps HTTP methods to the action names of REST style requests.
+var auditMethodActions = map[string]string{
+	http.MethodPost:   "create",
+	http.MethodPut:    "update",
+	http.MethodPatch:  "update",
+	http.MethodDelete: "delete",
+}
+
+// AuditMiddleware represents audit middleware.
+type AuditMiddleware struct {
+	auditWriter audit.WriterProvider
+}
+
+// NewAuditMiddleware creates new audit middleware logic, which records mutating Aim, Mlflow and admin calls.
+// It has to be attached after auth middlewares, so the actor of the request is known.
+func NewAuditMiddleware(auditWriter audit.WriterProvider) fiber.Handler {
+	return AuditMiddleware{
+		auditWriter: auditWriter,
+	}.Handle()
+}
+
+// Handle handles audit middleware logic.
+func (m AuditMiddleware) Handle() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if !IsMutatingRequest(ctx.Method(), ctx.Path()) {
+			return ctx.Next()
+		}
+
+		method, path := strings.Clone(ctx.Method()), strings.Clone(ctx.Path())
+		err := ctx.Next()
+
+		action, targetIDs := GetAuditAction(method, path)
+		contentType := strings.Clone(ctx.Get(fiber.HeaderContentType))
+		body := ctx.Body()
+		for _, id := range getAuditBodyTargetIDs(contentType, body) {
+			if !slices.Contains(targetIDs, id) {
+				targetIDs = append(targetIDs, id)
+			}
+		}
+		event := models.AuditEvent{
+			ID:            uuid.New(),
+			CreatedAt:     time.Now().UTC(),
+			Actor:         GetActorFromContext(ctx.Context()),
+			Action:        action,
+			Method:        method,
+			Path:          path,
+			TargetIDs:     strings.Join(targetIDs, ","),
+			Request:       getAuditRequestSummary(contentType, body),
+			StatusCode:    getAuditStatusCode(ctx, err),
+			RemoteAddress: strings.Clone(ctx.IP()),
+		}
+		if MlflowAimPrefixRegexp.MatchString(path) {
+			if namespace, err := GetNamespaceFromContext(ctx.Context()); err == nil {
+				event.NamespaceCode = namespace.Code
+			}
+		}
+		m.auditWriter.Write(&event)
+		return err
+	}
+}
+
+// IsMutatingRequest makes check that request modifies Aim, Mlflow or admin resources.
+func IsMutatingRequest(method, path string) bool {
+	switch {
+	case AdminPrefixRegexp.MatchString(path):
+		return method != http.MethodGet && method != http.MethodHead && method != http.MethodOptions
+	case MlflowAimPrefixRegexp.MatchString(path):
+		return GetRequiredPermission(method, path) != models.PermissionRead
+	}
+	return false
+}
+
+// GetAuditAction returns the action name of the request, e.g. `mlflow.runs.delete` or `aim.runs.update`,
+// and IDs of the affected entities found in the path.
+func GetAuditAction(method, path string) (string, []string) {
+	matches := auditPrefixRegexp.FindStringSubmatch(path)
+	if matches == nil {
+		return strings.ToLower(method), nil
+	}
+	var prefix string
+	for _, match := range matches[1:] {
+		if match != "" {
+			prefix = match
+		}
+	}
+
+	var ids []string
+	segments := strings.Split(strings.Trim(path[len(matches[0]):], "/"), "/")
+	// the rest of the artifacts path is the path of the artifact itself.
+	if prefix == "mlflow-artifacts" && len(segments) > 1 {
+		ids = append(ids, strings.Join(segments[1:], "/"))
+		segments = segments[:1]
+	}
+
+	names, lastIsID := []string{prefix}, false
+	for _, segment := range segments {
+		switch {
+		case segment == "":
+			continue
+		case auditIDRegexp.MatchString(segment):
+			ids, lastIsID = append(ids, segment), true
+		default:
+			names, lastIsID = append(names, segment), false
+		}
+	}
+	// REST style requests have no action in the path, so the action is defined by the method.
+	if action, ok := auditMethodActions[method]; ok && (method != http.MethodPost || lastIsID || len(names) <= 2) {
+		names = append(names, action)
+	}
+	return strings.Join(names, "."), ids
+}
+
+// GetActorFromContext returns the actor who made the request.
+func GetActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorContextKey).(string); ok && actor != "" {
+		return actor
+	}
+	if username, ok := ctx.Value(basicAuthUsernameContextKey).(string); ok && username != "" {
+		return username
+	}
+	return AnonymousActor
+}
+
+// setActor stores the actor who made the request in the context.
+func setActor(ctx *fiber.Ctx, actor string) {
+	ctx.Locals(actorContextKey, actor)
+}
+
+// getAuditBodyTargetIDs returns IDs of the affected entities found in the request body.
+func getAuditBodyTargetIDs(contentType string, body []byte) []string {
+	var ids []string
+	switch {
+	case strings.HasPrefix(contentType, fiber.MIMEApplicationForm):
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil
+		}
+		for _, key := range auditTargetKeys {
+			if value := values.Get(key); value != "" {
+				ids = append(ids, value)
+			}
+		}
+	case contentType == "" || strings.HasPrefix(contentType, fiber.MIMEApplicationJSON):
+		body = bytes.TrimLeft(body, " \t\r\n")
+		// batch requests of Aim API contain the list of IDs.
+		if len(body) > 0 && body[0] == '[' {
+			var items []auditTargetID
+			if err := json.Unmarshal(body, &items); err != nil {
+				return nil
+			}
+			for _, item := range items {
+				if item != "" {
+					ids = append(ids, string(item))
+				}
+			}
+			return ids
+		}
+		var targets auditBodyTargets
+		if err := json.Unmarshal(body, &targets); err != nil {
+			return nil
+		}
+		for _, id := range []auditTargetID{
+			targets.RunID, targets.RunUUID, targets.ExperimentID, targets.Name, targets.Version, targets.Code,
+		} {
+			if id != "" {
+				ids = append(ids, string(id))
+			}
+		}
+	}
+	return ids
+}
+
+// getAuditRequestSummary returns the request body truncated to maxAuditRequestLength.
+// Only the size of binary bodies, e.g. uploaded artifacts, is recorded.
+func getAuditRequestSummary(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if contentType != "" &&
+		!strings.HasPrefix(contentType, fiber.MIMEApplicationJSON) &&
+		!strings.HasPrefix(contentType, fiber.MIMEApplicationForm) &&
+		!strings.HasPrefix(contentType, "text/") {
+		return fmt.Sprintf("<%d bytes of %s>", len(body), contentType)
+	}
+	if len(body) > maxAuditRequestLength {
+		return strings.ToValidUTF8(string(body[:maxAuditRequestLength]), "") + "..."
+	}
+	return strings.ToValidUTF8(string(body), "")
+}
+
+// getAuditStatusCode returns the response status code. Errors are not handled by error handler yet,
+// so their status code has to be taken from the error itself.
+func getAuditStatusCode(ctx *fiber.Ctx, err error) int {
+	if err == nil {
+		return ctx.Response().StatusCode()
+	}
+	var apiError *api.ErrorResponse
+	if errors.As(err, &apiError) {
+		return apiError.StatusCode
+	}
+	var fiberError *fiber.Error
+	if errors.As(err, &fiberError) {
+		return fiberError.Code
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,207 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAuditAction_Ok(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		action string
+		ids    []string
+	}{
+		{
+			name:   "MlflowCreateRun",
+			method: http.MethodPost,
+			path:   "/api/2.0/mlflow/runs/create",
+			action: "mlflow.runs.create",
+		},
+		{
+			name:   "MlflowDeleteExperimentFromAjaxAPI",
+			method: http.MethodPost,
+			path:   "/ajax-api/2.0/mlflow/experiments/delete",
+			action: "mlflow.experiments.delete",
+		},
+		{
+			name:   "MlflowUploadArtifact",
+			method: http.MethodPut,
+			path:   "/api/2.0/mlflow-artifacts/artifacts/1/abc/artifacts/model.pkl",
+			action: "mlflow-artifacts.artifacts.update",
+			ids:    []string{"1/abc/artifacts/model.pkl"},
+		},
+		{
+			name:   "AimUpdateRun",
+			method: http.MethodPut,
+			path:   "/aim/api/runs/0123456789abcdef0123456789abcdef/",
+			action: "aim.runs.update",
+			ids:    []string{"0123456789abcdef0123456789abcdef"},
+		},
+		{
+			name:   "AimDeleteRunsBatch",
+			method: http.MethodPost,
+			path:   "/aim/api/runs/delete-batch/",
+			action: "aim.runs.delete-batch",
+		},
+		{
+			name:   "AimCreateApp",
+			method: http.MethodPost,
+			path:   "/aim/api/apps/",
+			action: "aim.apps.create",
+		},
+		{
+			name:   "AimDeleteDashboard",
+			method: http.MethodDelete,
+			path:   "/aim/api/dashboards/5e7ec8a4-8f4b-4e4c-9d0e-4c7e3b0d1b7a",
+			action: "aim.dashboards.delete",
+			ids:    []string{"5e7ec8a4-8f4b-4e4c-9d0e-4c7e3b0d1b7a"},
+		},
+		{
+			name:   "AdminUpdateNamespace",
+			method: http.MethodPut,
+			path:   "/admin/namespaces/2/",
+			action: "admin.namespaces.update",
+			ids:    []string{"2"},
+		},
+		{
+			name:   "AdminCreateToken",
+			method: http.MethodPost,
+			path:   "/admin/tokens/",
+			action: "admin.tokens.create",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, ids := GetAuditAction(tt.method, tt.path)
+			assert.Equal(t, tt.action, action)
+			assert.Equal(t, tt.ids, ids)
+		})
+	}
+}
+
+func TestIsMutatingRequest_Ok(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		mutating bool
+	}{
+		{
+			name:     "MlflowSearchRuns",
+			method:   http.MethodPost,
+			path:     "/api/2.0/mlflow/runs/search",
+			mutating: false,
+		},
+		{
+			name:     "MlflowLogBatch",
+			method:   http.MethodPost,
+			path:     "/api/2.0/mlflow/runs/log-batch",
+			mutating: true,
+		},
+		{
+			name:     "AimSearchMetrics",
+			method:   http.MethodPost,
+			path:     "/aim/api/runs/search/metric/",
+			mutating: false,
+		},
+		{
+			name:     "AimArchiveRunsBatch",
+			method:   http.MethodPost,
+			path:     "/aim/api/runs/archive-batch",
+			mutating: true,
+		},
+		{
+			name:     "AdminGetNamespaces",
+			method:   http.MethodGet,
+			path:     "/admin/namespaces/",
+			mutating: false,
+		},
+		{
+			name:     "AdminDeleteNamespace",
+			method:   http.MethodDelete,
+			path:     "/admin/namespaces/2/",
+			mutating: true,
+		},
+		{
+			name:     "Health",
+			method:   http.MethodPost,
+			path:     "/health",
+			mutating: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.mutating, IsMutatingRequest(tt.method, tt.path))
+		})
+	}
+}
+
+func TestGetAuditBodyTargetIDs_Ok(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		ids         []string
+	}{
+		{
+			name:        "JSONObject",
+			contentType: fiber.MIMEApplicationJSON,
+			body:        `{"run_id": "abc", "key": "loss", "experiment_id": 1}`,
+			ids:         []string{"abc", "1"},
+		},
+		{
+			name:        "JSONArray",
+			contentType: fiber.MIMEApplicationJSONCharsetUTF8,
+			body:        `["abc", "def"]`,
+			ids:         []string{"abc", "def"},
+		},
+		{
+			name:        "JSONWithNestedFields",
+			contentType: fiber.MIMEApplicationJSON,
+			body: `{"run_id": "abc", "metrics": [{"run_id": "def", "key": "loss", "value": 1}], ` +
+				`"name": {"key": "value"}, "version": null}`,
+			ids: []string{"abc"},
+		},
+		{
+			name:        "JSONArrayWithObjects",
+			contentType: fiber.MIMEApplicationJSON,
+			body:        ` [{"run_id": "abc"}, "def", 2]`,
+			ids:         []string{"def", "2"},
+		},
+		{
+			name:        "InvalidJSON",
+			contentType: fiber.MIMEApplicationJSON,
+			body:        `{"run_id": "abc"`,
+		},
+		{
+			name:        "JSONWithoutContentType",
+			contentType: "",
+			body:        `{"name": "model"}`,
+			ids:         []string{"model"},
+		},
+		{
+			name:        "Form",
+			contentType: fiber.MIMEApplicationForm,
+			body:        "code=namespace&description=test",
+			ids:         []string{"namespace"},
+		},
+		{
+			name:        "Binary",
+			contentType: fiber.MIMEOctetStream,
+			body:        `{"run_id": "abc"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.ids, getAuditBodyTargetIDs(tt.contentType, []byte(tt.body)))
+		})
+	}
+}
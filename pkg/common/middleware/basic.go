@@ -2,7 +2,9 @@ package middleware
 
 import (
 	"context"
+	"encoding/base64"
 	"net/http"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/rotisserie/eris"
@@ -32,14 +34,18 @@ func NewBasicAuthMiddleware(userPermissions *models.UserPermissions) fiber.Handl
 // Handle handles OIDC middleware logic.
 func (m BasicAuthMiddleware) Handle() fiber.Handler {
 	return func(ctx *fiber.Ctx) (err error) {
-		authToken := m.userPermissions.ValidateAuthToken(ctx.Get(fiber.HeaderAuthorization)[6:])
+		token := ctx.Get(fiber.HeaderAuthorization)[6:]
+		authToken := m.userPermissions.ValidateAuthToken(token)
+		if authToken != nil {
+			setActor(ctx, getBasicAuthUsername(token))
+		}
 		switch {
 		case AdminPrefixRegexp.MatchString(ctx.Path()):
 			return m.handleAdminResourceRequest(ctx, authToken)
 		case ChooserPrefixRegexp.MatchString(ctx.Path()):
 			return m.handleChooserResourceRequest(ctx, authToken)
 		case MlflowAimPrefixRegexp.MatchString(ctx.Path()):
-			return m.handleAimMlflowResourceRequest(ctx, authToken)
+			return handleAuthTokenAimMlflowResourceRequest(ctx, authToken)
 		}
 		return ctx.Next()
 	}
@@ -74,8 +80,9 @@ func (m BasicAuthMiddleware) handleChooserResourceRequest(ctx *fiber.Ctx, authTo
 	return ctx.Next()
 }
 
-// handleAimMlflowResourceRequest applies Basic Auth check for Aim or Mlflow resources.
-func (m BasicAuthMiddleware) handleAimMlflowResourceRequest(ctx *fiber.Ctx, authToken *models.BasicAuthToken) error {
+// handleAuthTokenAimMlflowResourceRequest applies auth token check for Aim or Mlflow resources.
+// It is used by both Basic Auth and API token middlewares.
+func handleAuthTokenAimMlflowResourceRequest(ctx *fiber.Ctx, authToken *models.BasicAuthToken) error {
 	namespace, err := GetNamespaceFromContext(ctx.Context())
 	if err != nil {
 		return api.NewInternalError("error getting namespace from context")
@@ -88,16 +95,40 @@ func (m BasicAuthMiddleware) handleAimMlflowResourceRequest(ctx *fiber.Ctx, auth
 			api.NewResourceDoesNotExistError("unable to find namespace with code: %s", namespace.Code),
 		)
 	}
-	if !authToken.HasUserAccess(namespace.Code) && !authToken.HasAdminAccess() {
+	if authToken.HasAdminAccess() {
+		return ctx.Next()
+	}
+	if !authToken.HasUserAccess(namespace.Code) {
 		return ctx.Status(
 			http.StatusNotFound,
 		).JSON(
 			api.NewResourceDoesNotExistError("unable to find namespace with code: %s", namespace.Code),
 		)
 	}
+	if permission := GetRequiredPermission(ctx.Method(), ctx.Path()); !authToken.HasUserPermission(
+		namespace.Code, permission,
+	) {
+		return ctx.Status(
+			http.StatusForbidden,
+		).JSON(
+			api.NewPermissionDeniedError(
+				"'%s' permission to namespace with code: %s is required", permission, namespace.Code,
+			),
+		)
+	}
 	return ctx.Next()
 }
 
+// getBasicAuthUsername returns the username encoded in Basic Auth token.
+func getBasicAuthUsername(token string) string {
+	data, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return ""
+	}
+	username, _, _ := strings.Cut(string(data), ":")
+	return username
+}
+
 // GetBasicAuthTokenFromContext returns Basic Auth Token from the context.
 func GetBasicAuthTokenFromContext(ctx context.Context) (*models.BasicAuthToken, error) {
 	authToken, ok := ctx.Value(basicAuthTokenContextKey).(*models.BasicAuthToken)
@@ -66,6 +66,7 @@ func (m OIDCMiddleware) handleAdminResourceRequest(ctx *fiber.Ctx) error {
 	}
 
 	log.Debugf("user has roles: %v associated", user.GetRoles())
+	setActor(ctx, user.GetName())
 	if !user.IsAdmin() {
 		return ctx.Redirect("/errors/not-found", http.StatusMovedPermanently)
 	}
@@ -86,6 +87,7 @@ func (m OIDCMiddleware) handleChooserResourceRequest(ctx *fiber.Ctx) error {
 		return ctx.Redirect("/login", http.StatusMovedPermanently)
 	}
 	log.Debugf("user has roles: %v associated", user.GetRoles())
+	setActor(ctx, user.GetName())
 	ctx.Locals(oidcUserContextKey, user)
 	return ctx.Next()
 }
@@ -107,25 +109,37 @@ func (m OIDCMiddleware) handleAimMlflowResourceRequest(ctx *fiber.Ctx) error {
 		)
 	}
 	log.Debugf("user has roles: %v associated", user.GetRoles())
+	setActor(ctx, user.GetName())
 
 	if user.IsAdmin() {
 		return ctx.Next()
 	}
 
-	isValid, err := m.rolesRepository.ValidateRolesAccessToNamespace(ctx.Context(), user.GetRoles(), namespace.Code)
+	permission, err := m.rolesRepository.GetRolesPermissionToNamespace(
+		ctx.Context(), user.GetRoles(), namespace.Code,
+	)
 	if err != nil {
 		log.Errorf("error validating access to requested namespace with code: %s, %+v", namespace.Code, err)
 		return api.NewInternalError(
 			"error validating access to requested namespace with code: %s", namespace.Code,
 		)
 	}
-	if !isValid {
+	if permission == "" {
 		return ctx.Status(
 			http.StatusForbidden,
 		).JSON(
 			api.NewResourceDoesNotExistError("unable to find namespace with code: %s", namespace.Code),
 		)
 	}
+	if required := GetRequiredPermission(ctx.Method(), ctx.Path()); !permission.Allows(required) {
+		return ctx.Status(
+			http.StatusForbidden,
+		).JSON(
+			api.NewPermissionDeniedError(
+				"'%s' permission to namespace with code: %s is required", required, namespace.Code,
+			),
+		)
+	}
 	return ctx.Next()
 }
 
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
+)
+
+// regexps to classify Aim and Mlflow requests by the permission they require.
+var (
+	// ReadRequestRegexp matches `POST` requests which only read data, like searches.
+	ReadRequestRegexp = regexp.MustCompile(
+		`/search(/[^/]+)*/?$|/search-datasets$|/get-batch/?$|/metrics/get-histories$|/get-latest-versions$|` +
+			`/runs/export(-metrics)?$`,
+	)
+	// OwnerRequestRegexp matches requests which delete runs, experiments, models or artifacts.
+	OwnerRequestRegexp = regexp.MustCompile(
+		`/(runs|experiments|registered-models|model-versions)/(delete|delete-batch)/?$`,
+	)
+	// OwnerDeleteRequestRegexp matches `DELETE` requests of Aim runs and experiments and Mlflow artifacts.
+	OwnerDeleteRequestRegexp = regexp.MustCompile(
+		`^/aim/api/(runs|experiments)/[^/]+/?$|^/(ajax-)?api/2.0/mlflow-artifacts/`,
+	)
+)
+
+// GetRequiredPermission returns the permission to the namespace required by Aim or Mlflow request.
+func GetRequiredPermission(method, path string) models.Permission {
+	switch {
+	case OwnerRequestRegexp.MatchString(path),
+		method == http.MethodDelete && OwnerDeleteRequestRegexp.MatchString(path):
+		return models.PermissionOwner
+	case method == http.MethodGet, method == http.MethodHead, method == http.MethodOptions,
+		method == http.MethodPost && ReadRequestRegexp.MatchString(path):
+		return models.PermissionRead
+	default:
+		return models.PermissionWrite
+	}
+}
@@ -0,0 +1,151 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
+)
+
+func TestGetRequiredPermission_Ok(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		permission models.Permission
+	}{
+		{
+			name:       "AimGetRuns",
+			method:     http.MethodGet,
+			path:       "/aim/api/runs/search/run/",
+			permission: models.PermissionRead,
+		},
+		{
+			name:       "AimSearchMetrics",
+			method:     http.MethodPost,
+			path:       "/aim/api/runs/search/metric/",
+			permission: models.PermissionRead,
+		},
+		{
+			name:       "AimGetRunMetricsBatch",
+			method:     http.MethodPost,
+			path:       "/aim/api/runs/id/metric/get-batch/",
+			permission: models.PermissionRead,
+		},
+		{
+			name:       "MlflowSearchRuns",
+			method:     http.MethodPost,
+			path:       "/api/2.0/mlflow/runs/search",
+			permission: models.PermissionRead,
+		},
+		{
+			name:       "MlflowSearchDatasets",
+			method:     http.MethodPost,
+			path:       "/api/2.0/mlflow/experiments/search-datasets",
+			permission: models.PermissionRead,
+		},
+		{
+			name:       "MlflowGetMetricHistories",
+			method:     http.MethodPost,
+			path:       "/api/2.0/mlflow/metrics/get-histories",
+			permission: models.PermissionRead,
+		},
+		{
+			name:       "MlflowExportRuns",
+			method:     http.MethodPost,
+			path:       "/api/2.0/mlflow/runs/export",
+			permission: models.PermissionRead,
+		},
+		{
+			name:       "MlflowExportRunMetrics",
+			method:     http.MethodPost,
+			path:       "/api/2.0/mlflow/runs/export-metrics",
+			permission: models.PermissionRead,
+		},
+		{
+			name:       "MlflowCreateExport",
+			method:     http.MethodPost,
+			path:       "/api/2.0/mlflow/exports/create",
+			permission: models.PermissionWrite,
+		},
+		{
+			name:       "MlflowGetExport",
+			method:     http.MethodGet,
+			path:       "/api/2.0/mlflow/exports/get",
+			permission: models.PermissionRead,
+		},
+		{
+			name:       "MlflowLogBatch",
+			method:     http.MethodPost,
+			path:       "/api/2.0/mlflow/runs/log-batch",
+			permission: models.PermissionWrite,
+		},
+		{
+			name:       "MlflowDeleteRunTag",
+			method:     http.MethodPost,
+			path:       "/api/2.0/mlflow/runs/delete-tag",
+			permission: models.PermissionWrite,
+		},
+		{
+			name:       "AimArchiveRuns",
+			method:     http.MethodPost,
+			path:       "/aim/api/runs/archive-batch/",
+			permission: models.PermissionWrite,
+		},
+		{
+			name:       "AimDeleteRunTag",
+			method:     http.MethodDelete,
+			path:       "/aim/api/runs/id/tags/tag-id",
+			permission: models.PermissionWrite,
+		},
+		{
+			name:       "AimDeleteDashboard",
+			method:     http.MethodDelete,
+			path:       "/aim/api/dashboards/id/",
+			permission: models.PermissionWrite,
+		},
+		{
+			name:       "AimDeleteRun",
+			method:     http.MethodDelete,
+			path:       "/aim/api/runs/id/",
+			permission: models.PermissionOwner,
+		},
+		{
+			name:       "AimDeleteRuns",
+			method:     http.MethodPost,
+			path:       "/aim/api/runs/delete-batch/",
+			permission: models.PermissionOwner,
+		},
+		{
+			name:       "AimDeleteExperiment",
+			method:     http.MethodDelete,
+			path:       "/aim/api/experiments/id/",
+			permission: models.PermissionOwner,
+		},
+		{
+			name:       "MlflowDeleteExperiment",
+			method:     http.MethodPost,
+			path:       "/ajax-api/2.0/mlflow/experiments/delete",
+			permission: models.PermissionOwner,
+		},
+		{
+			name:       "MlflowDeleteRegisteredModel",
+			method:     http.MethodDelete,
+			path:       "/api/2.0/mlflow/registered-models/delete",
+			permission: models.PermissionOwner,
+		},
+		{
+			name:       "MlflowDeleteArtifact",
+			method:     http.MethodDelete,
+			path:       "/api/2.0/mlflow-artifacts/artifacts/path/file.txt",
+			permission: models.PermissionOwner,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.permission, GetRequiredPermission(tt.method, tt.path))
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// Package rocksdb implements a minimal read-only reader of RocksDB databases. It reads the key-value pairs
+// straight from the table (`*.sst`) and write-ahead log (`*.log`) files of the database directory, so no
+// native RocksDB library is needed. Only the features used by the default RocksDB configuration are
+// supported: block based tables, the default column family, puts, deletions and range deletions.
+package rocksdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/rotisserie/eris"
+)
+
+// supported list of value types.
+const (
+	typeDeletion                   = 0x0
+	typeValue                      = 0x1
+	typeMerge                      = 0x2
+	typeLogData                    = 0x3
+	typeColumnFamilyDeletion       = 0x4
+	typeColumnFamilyValue          = 0x5
+	typeColumnFamilyMerge          = 0x6
+	typeSingleDeletion             = 0x7
+	typeColumnFamilySingleDeletion = 0x8
+	typeNoop                       = 0xD
+	typeColumnFamilyRangeDeletion  = 0xE
+	typeRangeDeletion              = 0xF
+)
+
+// KeyValue represents a live key-value pair of the database.
+type KeyValue struct {
+	Key   []byte
+	Value []byte
+}
+
+// record represents a versioned record of the key.
+type record struct {
+	seq   uint64
+	kind  byte
+	value []byte
+}
+
+// tombstone represents a range deletion of [begin, end) keys.
+type tombstone struct {
+	seq   uint64
+	begin []byte
+	end   []byte
+}
+
+// collector collects records of the tables and logs and resolves the latest version of each key.
+type collector struct {
+	records    map[string]record
+	tombstones []tombstone
+}
+
+// add stores the record if it is newer than already collected one.
+func (c *collector) add(key []byte, seq uint64, kind byte, value []byte) {
+	if current, ok := c.records[string(key)]; ok && current.seq > seq {
+		return
+	}
+	c.records[string(key)] = record{seq: seq, kind: kind, value: value}
+}
+
+// addTombstone stores the range deletion.
+func (c *collector) addTombstone(begin, end []byte, seq uint64) {
+	c.tombstones = append(c.tombstones, tombstone{seq: seq, begin: begin, end: end})
+}
+
+// deleted checks that the record of the key has been deleted by one of the range deletions.
+func (c *collector) deleted(key []byte, seq uint64) bool {
+	for _, t := range c.tombstones {
+		if t.seq > seq && bytes.Compare(key, t.begin) >= 0 && bytes.Compare(key, t.end) < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadAll reads all the live key-value pairs of the database located in the directory.
+// Pairs are returned in the key order. Obsolete files are expected to be purged by RocksDB.
+func ReadAll(dir string) ([]KeyValue, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error reading database directory %s", dir)
+	}
+
+	c := collector{records: map[string]record{}}
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		switch {
+		case entry.IsDir():
+			continue
+		case strings.HasSuffix(entry.Name(), ".sst"):
+			if err := readTable(path, &c); err != nil {
+				return nil, eris.Wrapf(err, "error reading table file %s", path)
+			}
+		case strings.HasSuffix(entry.Name(), ".log"):
+			if err := readLog(path, &c); err != nil {
+				return nil, eris.Wrapf(err, "error reading log file %s", path)
+			}
+		}
+	}
+
+	keyValues := make([]KeyValue, 0, len(c.records))
+	for key, r := range c.records {
+		if r.kind != typeValue || c.deleted([]byte(key), r.seq) {
+			continue
+		}
+		keyValues = append(keyValues, KeyValue{Key: []byte(key), Value: r.value})
+	}
+	sort.Slice(keyValues, func(i, j int) bool {
+		return bytes.Compare(keyValues[i].Key, keyValues[j].Key) < 0
+	})
+	return slices.Clip(keyValues), nil
+}
+
+// readUvarint reads varint encoded unsigned number.
+func readUvarint(data []byte) (uint64, []byte, error) {
+	value, n := binary.Uvarint(data)
+	if n <= 0 {
+		return 0, nil, eris.New("malformed varint")
+	}
+	return value, data[n:], nil
+}
+
+// readVarint reads zigzag varint encoded signed number.
+func readVarint(data []byte) (int64, []byte, error) {
+	value, n := binary.Varint(data)
+	if n <= 0 {
+		return 0, nil, eris.New("malformed varint")
+	}
+	return value, data[n:], nil
+}
+
+// readLengthPrefixed reads varint length prefixed slice.
+func readLengthPrefixed(data []byte) ([]byte, []byte, error) {
+	length, data, err := readUvarint(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if uint64(len(data)) < length {
+		return nil, nil, eris.New("malformed length prefixed slice")
+	}
+	return data[:length], data[length:], nil
+}
@@ -0,0 +1,325 @@
+package rocksdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRecord represents a record of the write batch or the table.
+type testRecord struct {
+	kind  byte
+	key   string
+	value string
+}
+
+// appendLengthPrefixed appends varint length prefixed slice.
+func appendLengthPrefixed(dst []byte, value string) []byte {
+	dst = binary.AppendUvarint(dst, uint64(len(value)))
+	return append(dst, value...)
+}
+
+// buildWriteBatch encodes records as a write batch starting from the sequence number.
+func buildWriteBatch(seq uint64, records ...testRecord) []byte {
+	batch := binary.LittleEndian.AppendUint64(nil, seq)
+	batch = binary.LittleEndian.AppendUint32(batch, uint32(len(records)))
+	for _, r := range records {
+		batch = append(batch, r.kind)
+		batch = appendLengthPrefixed(batch, r.key)
+		if r.kind != typeDeletion {
+			batch = appendLengthPrefixed(batch, r.value)
+		}
+	}
+	return batch
+}
+
+// buildLog encodes write batches as a log file, fragmenting them across the log blocks.
+func buildLog(batches ...[]byte) []byte {
+	var data []byte
+	for _, batch := range batches {
+		first := true
+		for {
+			left := logBlockSize - len(data)%logBlockSize
+			if left < logHeaderSize {
+				data = append(data, make([]byte, left)...)
+				continue
+			}
+			fragment := batch[:min(len(batch), left-logHeaderSize)]
+			batch = batch[len(fragment):]
+			var kind byte
+			switch {
+			case first && len(batch) == 0:
+				kind = logFullType
+			case first:
+				kind = logFirstType
+			case len(batch) == 0:
+				kind = logLastType
+			default:
+				kind = logMiddleType
+			}
+			data = binary.LittleEndian.AppendUint32(data, 0)
+			data = binary.LittleEndian.AppendUint16(data, uint16(len(fragment)))
+			data = append(data, kind)
+			data = append(data, fragment...)
+			first = false
+			if len(batch) == 0 {
+				break
+			}
+		}
+	}
+	return data
+}
+
+// buildBlock encodes the entries as a block with prefix compressed keys.
+func buildBlock(entries ...[2][]byte) []byte {
+	var block []byte
+	var restarts []uint32
+	var previous []byte
+	for i, entry := range entries {
+		shared := 0
+		if i%2 == 0 {
+			restarts = append(restarts, uint32(len(block)))
+		} else {
+			for shared < len(previous) && shared < len(entry[0]) && previous[shared] == entry[0][shared] {
+				shared++
+			}
+		}
+		block = binary.AppendUvarint(block, uint64(shared))
+		block = binary.AppendUvarint(block, uint64(len(entry[0])-shared))
+		block = binary.AppendUvarint(block, uint64(len(entry[1])))
+		block = append(block, entry[0][shared:]...)
+		block = append(block, entry[1]...)
+		previous = entry[0]
+	}
+	for _, restart := range restarts {
+		block = binary.LittleEndian.AppendUint32(block, restart)
+	}
+	return binary.LittleEndian.AppendUint32(block, uint32(len(restarts)))
+}
+
+// internalKey encodes user key with the sequence number and the value type.
+func internalKey(key string, seq uint64, kind byte) []byte {
+	return binary.LittleEndian.AppendUint64([]byte(key), seq<<8|uint64(kind))
+}
+
+// tableBuilder builds table files with format version 5.
+type tableBuilder struct {
+	data []byte
+}
+
+// addBlock appends the block with the trailer and returns its handle.
+func (b *tableBuilder) addBlock(block []byte, compression byte) blockHandle {
+	switch compression {
+	case compressionSnappy:
+		block = snappy.Encode(nil, block)
+	case compressionZSTD:
+		encoder, _ := zstd.NewWriter(nil)
+		block = encoder.EncodeAll(block, binary.AppendUvarint(nil, uint64(len(block))))
+	}
+	handle := blockHandle{offset: uint64(len(b.data)), size: uint64(len(block))}
+	b.data = append(b.data, block...)
+	b.data = append(b.data, compression, 0, 0, 0, 0)
+	return handle
+}
+
+// encodeHandle encodes block handle.
+func encodeHandle(handle blockHandle) []byte {
+	return binary.AppendUvarint(binary.AppendUvarint(nil, handle.offset), handle.size)
+}
+
+// build writes data blocks, delta encoded index, range deletions, properties and the footer.
+func (b *tableBuilder) build(dataBlocks [][][2][]byte, rangeDeletions [][2][]byte) []byte {
+	var handles []blockHandle
+	compressions := []byte{compressionNone, compressionSnappy, compressionZSTD, compressionZSTD}
+	for i, entries := range dataBlocks {
+		handles = append(handles, b.addBlock(buildBlock(entries...), compressions[i%len(compressions)]))
+	}
+
+	// index keys are not used by the reader, so they just have to share the prefix to make values delta encoded.
+	var index []byte
+	for i, handle := range handles {
+		if i == 0 {
+			index = append(index, 0, 1, 'k')
+			index = append(index, encodeHandle(handle)...)
+			continue
+		}
+		index = append(index, 1, 1, byte('a'+i))
+		index = binary.AppendVarint(index, int64(handle.size)-int64(handles[i-1].size))
+	}
+	index = binary.LittleEndian.AppendUint32(index, 0)
+	index = binary.LittleEndian.AppendUint32(index, 1)
+	indexHandle := b.addBlock(index, compressionNone)
+
+	var metaIndex [][2][]byte
+	if len(rangeDeletions) > 0 {
+		rangeDeletionHandle := b.addBlock(buildBlock(rangeDeletions...), compressionNone)
+		metaIndex = append(metaIndex, [2][]byte{[]byte(tableRangeDeletionBlock), encodeHandle(rangeDeletionHandle)})
+	}
+	properties := b.addBlock(buildBlock(
+		[2][]byte{[]byte(tableIndexTypeProperty), binary.LittleEndian.AppendUint32(nil, indexTypeBinarySearch)},
+		[2][]byte{[]byte(tableIndexValueIsDeltaProperty), binary.AppendUvarint(nil, 1)},
+	), compressionNone)
+	metaIndex = append([][2][]byte{{[]byte(tablePropertiesBlock), encodeHandle(properties)}}, metaIndex...)
+	metaIndexHandle := b.addBlock(buildBlock(metaIndex...), compressionNone)
+	return append(b.data, buildFooter(metaIndexHandle, indexHandle)...)
+}
+
+// buildFooter encodes the footer of format version 5.
+func buildFooter(metaIndexHandle, indexHandle blockHandle) []byte {
+	footer := append([]byte{1}, encodeHandle(metaIndexHandle)...)
+	footer = append(footer, encodeHandle(indexHandle)...)
+	footer = append(footer, make([]byte, 41-len(footer))...)
+	footer = binary.LittleEndian.AppendUint32(footer, 5)
+	return binary.LittleEndian.AppendUint64(footer, tableMagicNumber)
+}
+
+// buildCorruptedTable builds the table, which meta index block is the raw data of the compression type.
+func buildCorruptedTable(data []byte, compression byte) []byte {
+	handle := blockHandle{size: uint64(len(data))}
+	data = append(data, compression, 0, 0, 0, 0)
+	return append(data, buildFooter(handle, handle)...)
+}
+
+func TestReadAll_Log_Ok(t *testing.T) {
+	dir := t.TempDir()
+	large := strings.Repeat("v", 2*logBlockSize)
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "000004.log"), buildLog(
+		buildWriteBatch(1,
+			testRecord{kind: typeValue, key: "a", value: "1"},
+			testRecord{kind: typeValue, key: "b", value: "2"},
+			testRecord{kind: typeValue, key: "c/1", value: "3"},
+			testRecord{kind: typeValue, key: "c/2", value: "4"},
+		),
+		buildWriteBatch(5,
+			testRecord{kind: typeDeletion, key: "b"},
+			testRecord{kind: typeValue, key: "a", value: "5"},
+			testRecord{kind: typeValue, key: "large", value: large},
+		),
+		buildWriteBatch(8,
+			testRecord{kind: typeRangeDeletion, key: "c/", value: "c0"},
+			testRecord{kind: typeValue, key: "c/3", value: "6"},
+		),
+	), 0o600))
+	// not a part of the database.
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "LOCK"), nil, 0o600))
+
+	keyValues, err := ReadAll(dir)
+	require.Nil(t, err)
+	assert.Equal(t, []KeyValue{
+		{Key: []byte("a"), Value: []byte("5")},
+		{Key: []byte("c/3"), Value: []byte("6")},
+		{Key: []byte("large"), Value: []byte(large)},
+	}, keyValues)
+}
+
+func TestReadAll_Table_Ok(t *testing.T) {
+	dir := t.TempDir()
+	builder := tableBuilder{}
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "000007.sst"), builder.build(
+		[][][2][]byte{
+			{
+				{internalKey("key1", 1, typeValue), []byte("value1")},
+				{internalKey("key2", 4, typeDeletion), nil},
+				{internalKey("key2", 2, typeValue), []byte("value2")},
+			},
+			{
+				{internalKey("key3", 3, typeValue), []byte("value3")},
+				{internalKey("key4", 5, typeValue), []byte("value4")},
+				{internalKey("key5", 6, typeValue), []byte("value5")},
+			},
+			{
+				{internalKey("key6", 8, typeValue), []byte("value6")},
+			},
+			{
+				{internalKey("key7", 9, typeValue), []byte("value7")},
+			},
+		},
+		[][2][]byte{
+			{internalKey("key4", 7, typeRangeDeletion), []byte("key5")},
+		},
+	), 0o600))
+	// newer value of the log overrides the table one.
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "000008.log"), buildLog(
+		buildWriteBatch(8, testRecord{kind: typeValue, key: "key3", value: "value3-updated"}),
+	), 0o600))
+
+	keyValues, err := ReadAll(dir)
+	require.Nil(t, err)
+	assert.Equal(t, []KeyValue{
+		{Key: []byte("key1"), Value: []byte("value1")},
+		{Key: []byte("key3"), Value: []byte("value3-updated")},
+		{Key: []byte("key5"), Value: []byte("value5")},
+		{Key: []byte("key6"), Value: []byte("value6")},
+		{Key: []byte("key7"), Value: []byte("value7")},
+	}, keyValues)
+}
+
+func TestReadAll_Error(t *testing.T) {
+	tests := []struct {
+		name  string
+		file  string
+		data  []byte
+		error string
+	}{
+		{
+			name:  "NotATable",
+			file:  "000001.sst",
+			data:  bytes.Repeat([]byte{1}, tableFooterSize),
+			error: "unsupported table format",
+		},
+		{
+			name:  "BlockOutsideOfFile",
+			file:  "000001.sst",
+			data:  buildFooter(blockHandle{offset: 0, size: 1 << 62}, blockHandle{}),
+			error: "block at offset 0 with size 4611686018427387904 is outside of the table file",
+		},
+		{
+			name:  "BlockTrailerOutsideOfFile",
+			file:  "000001.sst",
+			data:  buildFooter(blockHandle{offset: 50, size: 1}, blockHandle{}),
+			error: "block at offset 50 with size 1 is outside of the table file",
+		},
+		{
+			name:  "DecompressedSizeOutOfRange",
+			file:  "000001.sst",
+			data:  buildCorruptedTable(binary.AppendUvarint(nil, 1<<40), compressionLZ4),
+			error: "decompressed block size 1099511627776 exceeds the limit of 268435456",
+		},
+		{
+			name:  "CorruptedZSTDBlock",
+			file:  "000001.sst",
+			data:  buildCorruptedTable(append(binary.AppendUvarint(nil, 10), "not zstd"...), compressionZSTD),
+			error: "error decompressing block with compression type 7",
+		},
+		{
+			name: "UnsupportedColumnFamily",
+			file: "000001.log",
+			data: buildLog(append(binary.LittleEndian.AppendUint32(
+				binary.LittleEndian.AppendUint64(nil, 1), 1,
+			), typeColumnFamilyValue, 1, 1, 'k', 1, 'v')),
+			error: "unsupported column family 1",
+		},
+		{
+			name:  "UnsupportedMerge",
+			file:  "000001.log",
+			data:  buildLog(buildWriteBatch(1, testRecord{kind: typeMerge, key: "k", value: "v"})),
+			error: "unsupported write batch record type 2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.Nil(t, os.WriteFile(filepath.Join(dir, tt.file), tt.data, 0o600))
+			_, err := ReadAll(dir)
+			assert.ErrorContains(t, err, tt.error)
+		})
+	}
+}
@@ -0,0 +1,488 @@
+package rocksdb
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"compress/flate"
+	"encoding/binary"
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+	"github.com/rotisserie/eris"
+)
+
+// table file format constants.
+const (
+	tableMagicNumber       = 0x88e241b785f4cff7
+	tableLegacyMagicNumber = 0xdb4775248b80fb57
+	tableFooterSize        = 53
+	tableLegacyFooterSize  = 48
+	tableBlockTrailerSize  = 5
+	tableInternalKeySize   = 8
+	// tableMaxFormatVersion is the latest supported format version. The footer of newer versions
+	// doesn't contain the index block handle.
+	tableMaxFormatVersion = 5
+	// tableMaxBlockSize is the limit of the decompressed block size, so corrupted sizes of the compressed
+	// blocks don't make the reader run out of memory. It is far above the block sizes used by Aim.
+	tableMaxBlockSize = 256 << 20
+)
+
+// supported list of block compression types.
+const (
+	compressionNone   = 0x0
+	compressionSnappy = 0x1
+	compressionZlib   = 0x2
+	compressionBzip2  = 0x3
+	compressionLZ4    = 0x4
+	compressionLZ4HC  = 0x5
+	compressionZSTD   = 0x7
+)
+
+// supported list of index types.
+const (
+	indexTypeBinarySearch        = 0
+	indexTypeHashSearch          = 1
+	indexTypeTwoLevelIndexSearch = 2
+	indexTypeBinarySearchWithKey = 3
+)
+
+// table properties and meta blocks used by the reader.
+const (
+	tablePropertiesBlock           = "rocksdb.properties"
+	tableRangeDeletionBlock        = "rocksdb.range_del"
+	tableIndexTypeProperty         = "rocksdb.block.based.table.index.type"
+	tableIndexValueIsDeltaProperty = "rocksdb.index.value.is.delta.encoded"
+)
+
+// blockHandle points to the block of the table file.
+type blockHandle struct {
+	offset uint64
+	size   uint64
+}
+
+// readBlockHandle reads varint encoded block handle.
+func readBlockHandle(data []byte) (blockHandle, []byte, error) {
+	offset, data, err := readUvarint(data)
+	if err != nil {
+		return blockHandle{}, nil, eris.Wrap(err, "error reading block offset")
+	}
+	size, data, err := readUvarint(data)
+	if err != nil {
+		return blockHandle{}, nil, eris.Wrap(err, "error reading block size")
+	}
+	return blockHandle{offset: offset, size: size}, data, nil
+}
+
+// table represents block based table file.
+type table struct {
+	file io.ReaderAt
+	size uint64
+	// decoder is created by the first zstd compressed block and reused by the others.
+	decoder       *zstd.Decoder
+	formatVersion uint32
+	indexType     uint32
+	deltaEncoded  bool
+}
+
+// readTable reads all the records and range deletions of the table file.
+func readTable(path string, c *collector) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return eris.Wrap(err, "error opening file")
+	}
+	//nolint:errcheck
+	defer file.Close()
+	stat, err := file.Stat()
+	if err != nil {
+		return eris.Wrap(err, "error getting file info")
+	}
+
+	// 1. read the footer and the meta blocks.
+	t := table{file: file, size: uint64(stat.Size())}
+	defer t.close()
+	metaIndexHandle, indexHandle, err := t.readFooter(stat.Size())
+	if err != nil {
+		return err
+	}
+	metaIndex, err := t.readBlock(metaIndexHandle)
+	if err != nil {
+		return eris.Wrap(err, "error reading meta index block")
+	}
+	var rangeDeletionHandle *blockHandle
+	if err := iterateBlock(metaIndex, func(key, value []byte) error {
+		handle, _, err := readBlockHandle(value)
+		if err != nil {
+			return err
+		}
+		switch string(key) {
+		case tablePropertiesBlock:
+			return t.readProperties(handle)
+		case tableRangeDeletionBlock:
+			rangeDeletionHandle = &handle
+		}
+		return nil
+	}); err != nil {
+		return eris.Wrap(err, "error reading meta index block")
+	}
+
+	// 2. read range deletions.
+	if rangeDeletionHandle != nil {
+		block, err := t.readBlock(*rangeDeletionHandle)
+		if err != nil {
+			return eris.Wrap(err, "error reading range deletion block")
+		}
+		if err := iterateBlock(block, func(key, value []byte) error {
+			userKey, seq, _, err := parseInternalKey(key)
+			if err != nil {
+				return err
+			}
+			c.addTombstone(userKey, value, seq)
+			return nil
+		}); err != nil {
+			return eris.Wrap(err, "error reading range deletion block")
+		}
+	}
+
+	// 3. read data blocks pointed by the index.
+	handles, err := t.readIndex(indexHandle, t.indexType == indexTypeTwoLevelIndexSearch)
+	if err != nil {
+		return eris.Wrap(err, "error reading index block")
+	}
+	for _, handle := range handles {
+		block, err := t.readBlock(handle)
+		if err != nil {
+			return eris.Wrap(err, "error reading data block")
+		}
+		if err := iterateBlock(block, func(key, value []byte) error {
+			userKey, seq, kind, err := parseInternalKey(key)
+			if err != nil {
+				return err
+			}
+			switch kind {
+			case typeValue:
+				c.add(userKey, seq, typeValue, value)
+			case typeDeletion, typeSingleDeletion:
+				c.add(userKey, seq, typeDeletion, nil)
+			default:
+				return eris.Errorf("unsupported value type %d", kind)
+			}
+			return nil
+		}); err != nil {
+			return eris.Wrap(err, "error reading data block")
+		}
+	}
+	return nil
+}
+
+// readFooter reads the footer and returns meta index and index block handles.
+func (t *table) readFooter(size int64) (blockHandle, blockHandle, error) {
+	if size < tableLegacyFooterSize {
+		return blockHandle{}, blockHandle{}, eris.New("file is too short to be a table")
+	}
+	footer := make([]byte, min(size, tableFooterSize))
+	if _, err := t.file.ReadAt(footer, size-int64(len(footer))); err != nil {
+		return blockHandle{}, blockHandle{}, eris.Wrap(err, "error reading footer")
+	}
+
+	var handles []byte
+	switch binary.LittleEndian.Uint64(footer[len(footer)-8:]) {
+	case tableLegacyMagicNumber:
+		handles = footer[len(footer)-tableLegacyFooterSize:]
+	case tableMagicNumber:
+		if len(footer) < tableFooterSize {
+			return blockHandle{}, blockHandle{}, eris.New("file is too short to be a table")
+		}
+		t.formatVersion = binary.LittleEndian.Uint32(footer[41:])
+		if t.formatVersion > tableMaxFormatVersion {
+			return blockHandle{}, blockHandle{}, eris.Errorf("unsupported format version %d", t.formatVersion)
+		}
+		handles = footer[1:]
+	default:
+		return blockHandle{}, blockHandle{}, eris.New("unsupported table format")
+	}
+
+	metaIndexHandle, handles, err := readBlockHandle(handles)
+	if err != nil {
+		return blockHandle{}, blockHandle{}, err
+	}
+	indexHandle, _, err := readBlockHandle(handles)
+	if err != nil {
+		return blockHandle{}, blockHandle{}, err
+	}
+	return metaIndexHandle, indexHandle, nil
+}
+
+// readProperties reads table properties which affect the index format.
+func (t *table) readProperties(handle blockHandle) error {
+	block, err := t.readBlock(handle)
+	if err != nil {
+		return eris.Wrap(err, "error reading properties block")
+	}
+	return iterateBlock(block, func(key, value []byte) error {
+		switch string(key) {
+		case tableIndexTypeProperty:
+			if len(value) != 4 {
+				return eris.Errorf("malformed %s property", key)
+			}
+			t.indexType = binary.LittleEndian.Uint32(value)
+		case tableIndexValueIsDeltaProperty:
+			delta, _, err := readUvarint(value)
+			if err != nil {
+				return eris.Wrapf(err, "malformed %s property", key)
+			}
+			t.deltaEncoded = delta != 0
+		}
+		return nil
+	})
+}
+
+// readIndex returns data block handles of the index. Partitioned index has one more level of indirection.
+func (t *table) readIndex(handle blockHandle, partitioned bool) ([]blockHandle, error) {
+	switch t.indexType {
+	case indexTypeBinarySearch, indexTypeHashSearch, indexTypeTwoLevelIndexSearch, indexTypeBinarySearchWithKey:
+	default:
+		return nil, eris.Errorf("unsupported index type %d", t.indexType)
+	}
+
+	block, err := t.readBlock(handle)
+	if err != nil {
+		return nil, err
+	}
+
+	// with delta encoding, only the size difference is stored for the handles sharing the key prefix.
+	var handles []blockHandle
+	readHandle := func(value []byte, delta bool) ([]byte, error) {
+		var handle blockHandle
+		if delta && len(handles) > 0 {
+			sizeDelta, rest, err := readVarint(value)
+			if err != nil {
+				return nil, eris.Wrap(err, "error reading block handle delta")
+			}
+			previous := handles[len(handles)-1]
+			handle = blockHandle{
+				offset: previous.offset + previous.size + tableBlockTrailerSize,
+				size:   uint64(int64(previous.size) + sizeDelta),
+			}
+			value = rest
+		} else {
+			if handle, value, err = readBlockHandle(value); err != nil {
+				return nil, err
+			}
+		}
+		if t.indexType == indexTypeBinarySearchWithKey {
+			// first key of the block is not needed to read the table.
+			if _, value, err = readLengthPrefixed(value); err != nil {
+				return nil, eris.Wrap(err, "error reading first key of the block")
+			}
+		}
+		handles = append(handles, handle)
+		return value, nil
+	}
+	if t.deltaEncoded {
+		data, err := blockEntries(block)
+		if err != nil {
+			return nil, err
+		}
+		var key []byte
+		for len(data) > 0 {
+			var shared uint64
+			if key, shared, _, data, err = readBlockEntryKey(data, key, false); err != nil {
+				return nil, err
+			}
+			if data, err = readHandle(data, shared != 0); err != nil {
+				return nil, err
+			}
+		}
+	} else if err := iterateBlock(block, func(key, value []byte) error {
+		_, err := readHandle(value, false)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if !partitioned {
+		return handles, nil
+	}
+	var dataHandles []blockHandle
+	for _, partition := range handles {
+		partitionHandles, err := t.readIndex(partition, false)
+		if err != nil {
+			return nil, eris.Wrap(err, "error reading index partition")
+		}
+		dataHandles = append(dataHandles, partitionHandles...)
+	}
+	return dataHandles, nil
+}
+
+// close releases the resources of the table reader.
+func (t *table) close() {
+	if t.decoder != nil {
+		t.decoder.Close()
+	}
+}
+
+// readBlock reads and decompresses the block.
+func (t *table) readBlock(handle blockHandle) ([]byte, error) {
+	// the handles are read from the file, so they are checked before the buffers are allocated.
+	if handle.size > t.size || handle.offset > t.size-handle.size || t.size-handle.size-handle.offset <
+		tableBlockTrailerSize {
+		return nil, eris.Errorf(
+			"block at offset %d with size %d is outside of the table file", handle.offset, handle.size,
+		)
+	}
+	data := make([]byte, handle.size+tableBlockTrailerSize)
+	if _, err := t.file.ReadAt(data, int64(handle.offset)); err != nil {
+		return nil, eris.Wrap(err, "error reading block")
+	}
+	compression := data[handle.size]
+	data = data[:handle.size]
+	if compression == compressionNone {
+		return data, nil
+	}
+
+	// since format version 2, the compressed data is prefixed with the decompressed size except for snappy.
+	var size uint64
+	if compression != compressionSnappy && t.formatVersion >= 2 {
+		var err error
+		if size, data, err = readUvarint(data); err != nil {
+			return nil, eris.Wrap(err, "error reading decompressed size")
+		}
+		if size > tableMaxBlockSize {
+			return nil, eris.Errorf("decompressed block size %d exceeds the limit of %d", size, tableMaxBlockSize)
+		}
+	}
+
+	var (
+		block []byte
+		err   error
+	)
+	switch compression {
+	case compressionSnappy:
+		var n int
+		if n, err = snappy.DecodedLen(data); err == nil {
+			if n > tableMaxBlockSize {
+				return nil, eris.Errorf("decompressed block size %d exceeds the limit of %d", n, tableMaxBlockSize)
+			}
+			block, err = snappy.Decode(nil, data)
+		}
+	case compressionZlib:
+		block, err = readLimited(flate.NewReader(bytes.NewReader(data)))
+	case compressionBzip2:
+		block, err = readLimited(bzip2.NewReader(bytes.NewReader(data)))
+	case compressionLZ4, compressionLZ4HC:
+		if t.formatVersion < 2 {
+			return nil, eris.New("unsupported lz4 compression format")
+		}
+		block = make([]byte, size)
+		var n int
+		n, err = lz4.UncompressBlock(data, block)
+		block = block[:n]
+	case compressionZSTD:
+		if t.decoder == nil {
+			t.decoder, err = zstd.NewReader(
+				nil, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(tableMaxBlockSize),
+			)
+		}
+		if err == nil {
+			block, err = t.decoder.DecodeAll(data, make([]byte, 0, size))
+		}
+	default:
+		return nil, eris.Errorf("unsupported compression type %d", compression)
+	}
+	if err != nil {
+		return nil, eris.Wrapf(err, "error decompressing block with compression type %d", compression)
+	}
+	return block, nil
+}
+
+// readLimited reads the decompressed block, which size isn't stored in the table, up to tableMaxBlockSize.
+func readLimited(reader io.Reader) ([]byte, error) {
+	block, err := io.ReadAll(io.LimitReader(reader, tableMaxBlockSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(block) > tableMaxBlockSize {
+		return nil, eris.Errorf("decompressed block size exceeds the limit of %d", tableMaxBlockSize)
+	}
+	return block, nil
+}
+
+// iterateBlock calls the function for each entry of the block.
+func iterateBlock(block []byte, fn func(key, value []byte) error) error {
+	data, err := blockEntries(block)
+	if err != nil {
+		return err
+	}
+	var key []byte
+	for len(data) > 0 {
+		var valueLength uint64
+		if key, _, valueLength, data, err = readBlockEntryKey(data, key, true); err != nil {
+			return err
+		}
+		if valueLength > uint64(len(data)) {
+			return eris.New("malformed block entry")
+		}
+		if err := fn(key, data[:valueLength]); err != nil {
+			return err
+		}
+		data = data[valueLength:]
+	}
+	return nil
+}
+
+// blockEntries strips the restarts and the optional data block hash index from the block.
+func blockEntries(block []byte) ([]byte, error) {
+	if len(block) < 4 {
+		return nil, eris.New("malformed block")
+	}
+	footer := binary.LittleEndian.Uint32(block[len(block)-4:])
+	end := len(block) - 4
+	if footer&(1<<31) != 0 {
+		// data block hash index is stored after the restarts.
+		if end < 2 {
+			return nil, eris.New("malformed block")
+		}
+		end -= 2 + int(binary.LittleEndian.Uint16(block[end-2:]))
+	}
+	end -= 4 * int(footer&(1<<31-1))
+	if end < 0 {
+		return nil, eris.New("malformed block")
+	}
+	return block[:end], nil
+}
+
+// readBlockEntryKey reads the entry header and the key sharing its prefix with the previous key.
+// The value length is not stored when values are delta encoded.
+func readBlockEntryKey(
+	data, previousKey []byte, withValueLength bool,
+) (key []byte, shared, valueLength uint64, rest []byte, err error) {
+	if shared, rest, err = readUvarint(data); err != nil {
+		return nil, 0, 0, nil, err
+	}
+	var nonShared uint64
+	if nonShared, rest, err = readUvarint(rest); err != nil {
+		return nil, 0, 0, nil, err
+	}
+	if withValueLength {
+		if valueLength, rest, err = readUvarint(rest); err != nil {
+			return nil, 0, 0, nil, err
+		}
+	}
+	if shared > uint64(len(previousKey)) || nonShared > uint64(len(rest)) {
+		return nil, 0, 0, nil, eris.New("malformed block entry")
+	}
+	key = append(previousKey[:shared:shared], rest[:nonShared]...)
+	return key, shared, valueLength, rest[nonShared:], nil
+}
+
+// parseInternalKey splits internal key into user key, sequence number and value type.
+func parseInternalKey(key []byte) ([]byte, uint64, byte, error) {
+	if len(key) < tableInternalKeySize {
+		return nil, 0, 0, eris.New("malformed internal key")
+	}
+	trailer := binary.LittleEndian.Uint64(key[len(key)-tableInternalKeySize:])
+	return key[:len(key)-tableInternalKeySize], trailer >> 8, byte(trailer), nil
+}
@@ -0,0 +1,165 @@
+package rocksdb
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rotisserie/eris"
+)
+
+// log file format constants.
+const (
+	logBlockSize            = 32768
+	logHeaderSize           = 7
+	logRecyclableHeaderSize = 11
+	logWriteBatchHeaderSize = 12
+)
+
+// supported list of log record types.
+const (
+	logZeroType                           = 0
+	logFullType                           = 1
+	logFirstType                          = 2
+	logMiddleType                         = 3
+	logLastType                           = 4
+	logRecyclableFullType                 = 5
+	logRecyclableFirstType                = 6
+	logRecyclableMiddleType               = 7
+	logRecyclableLastType                 = 8
+	logUserDefinedTimestampSizeType       = 10
+	logRecyclableUserDefinedTimestampType = 11
+)
+
+// readLog reads write batches of the write-ahead log file. The tail of the log could be left
+// incomplete by the crashed process, so it is silently ignored like RocksDB does during recovery.
+func readLog(path string, c *collector) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return eris.Wrap(err, "error reading file")
+	}
+	logNumber, err := strconv.ParseUint(strings.TrimSuffix(filepath.Base(path), ".log"), 10, 32)
+	if err != nil {
+		return eris.Wrap(err, "error parsing log number")
+	}
+
+	var batch []byte
+	for offset := 0; offset < len(data); {
+		blockEnd := min(offset-offset%logBlockSize+logBlockSize, len(data))
+		if blockEnd-offset < logHeaderSize {
+			offset = blockEnd
+			continue
+		}
+
+		// 1. read the record header.
+		length := int(binary.LittleEndian.Uint16(data[offset+4:]))
+		kind := data[offset+6]
+		headerSize := logHeaderSize
+		if kind >= logRecyclableFullType && kind <= logRecyclableLastType ||
+			kind == logRecyclableUserDefinedTimestampType {
+			headerSize = logRecyclableHeaderSize
+			// recycled log could contain records of the previous log.
+			if blockEnd-offset < headerSize ||
+				binary.LittleEndian.Uint32(data[offset+logHeaderSize:]) != uint32(logNumber) {
+				return nil
+			}
+		}
+		if kind == logZeroType && length == 0 {
+			// preallocated space.
+			offset = blockEnd
+			continue
+		}
+		if offset+headerSize+length > blockEnd {
+			return nil
+		}
+		payload := data[offset+headerSize : offset+headerSize+length]
+		offset += headerSize + length
+
+		// 2. assemble fragmented records into the write batch.
+		switch kind {
+		case logFullType, logRecyclableFullType:
+			batch = payload
+		case logFirstType, logRecyclableFirstType:
+			batch = append([]byte{}, payload...)
+			continue
+		case logMiddleType, logRecyclableMiddleType:
+			batch = append(batch, payload...)
+			continue
+		case logLastType, logRecyclableLastType:
+			batch = append(batch, payload...)
+		case logUserDefinedTimestampSizeType, logRecyclableUserDefinedTimestampType:
+			continue
+		default:
+			return eris.Errorf("unsupported log record type %d", kind)
+		}
+		if err := readWriteBatch(batch, c); err != nil {
+			return err
+		}
+		batch = nil
+	}
+	return nil
+}
+
+// readWriteBatch reads the records of the write batch. Each record except log data gets its own sequence number.
+func readWriteBatch(data []byte, c *collector) error {
+	if len(data) < logWriteBatchHeaderSize {
+		return eris.New("malformed write batch")
+	}
+	seq := binary.LittleEndian.Uint64(data)
+	data = data[logWriteBatchHeaderSize:]
+	for len(data) > 0 {
+		kind := data[0]
+		data = data[1:]
+
+		var err error
+		switch kind {
+		case typeColumnFamilyValue, typeColumnFamilyDeletion, typeColumnFamilySingleDeletion,
+			typeColumnFamilyRangeDeletion, typeColumnFamilyMerge:
+			var family uint64
+			if family, data, err = readUvarint(data); err != nil {
+				return err
+			}
+			if family != 0 {
+				return eris.Errorf("unsupported column family %d", family)
+			}
+		}
+
+		var key, value []byte
+		switch kind {
+		case typeValue, typeColumnFamilyValue:
+			if key, data, err = readLengthPrefixed(data); err != nil {
+				return err
+			}
+			if value, data, err = readLengthPrefixed(data); err != nil {
+				return err
+			}
+			c.add(key, seq, typeValue, value)
+		case typeDeletion, typeColumnFamilyDeletion, typeSingleDeletion, typeColumnFamilySingleDeletion:
+			if key, data, err = readLengthPrefixed(data); err != nil {
+				return err
+			}
+			c.add(key, seq, typeDeletion, nil)
+		case typeRangeDeletion, typeColumnFamilyRangeDeletion:
+			if key, data, err = readLengthPrefixed(data); err != nil {
+				return err
+			}
+			if value, data, err = readLengthPrefixed(data); err != nil {
+				return err
+			}
+			c.addTombstone(key, value, seq)
+		case typeLogData:
+			if _, data, err = readLengthPrefixed(data); err != nil {
+				return err
+			}
+			continue
+		case typeNoop:
+			continue
+		default:
+			return eris.Errorf("unsupported write batch record type %d", kind)
+		}
+		seq++
+	}
+	return nil
+}
@@ -50,6 +50,7 @@ func (s Service) ListArtifacts(
 		return "", nil, api.NewResourceDoesNotExistError("unable to find run '%s'", req.GetRunID())
 	}
 
+	ctx = storage.WithNamespace(ctx, namespace.Code)
 	artifactStorage, err := s.artifactStorageFactory.GetStorage(ctx, run.ArtifactURI)
 	if err != nil {
 		return "", nil, api.NewInternalError("run with id '%s' has unsupported artifact storage", run.ID)
@@ -83,6 +84,7 @@ func (s Service) GetArtifact(
 	if run == nil {
 		return nil, api.NewResourceDoesNotExistError("unable to find run '%s'", req.GetRunID())
 	}
+	ctx = storage.WithNamespace(ctx, namespace.Code)
 	artifactStorage, err := s.artifactStorageFactory.GetStorage(ctx, run.ArtifactURI)
 	if err != nil {
 		return nil, api.NewInternalError("run with id '%s' has unsupported artifact storage", run.ID)
@@ -100,3 +102,187 @@ func (s Service) GetArtifact(
 	}
 	return artifactReader, nil
 }
+
+// DownloadArtifact handles the business logic of `GET /mlflow-artifacts/artifacts/*` endpoint.
+func (s Service) DownloadArtifact(
+	ctx context.Context, namespace *models.Namespace, req *request.DownloadArtifactRequest,
+) (io.ReadCloser, error) {
+	if err := ValidateDownloadArtifactRequest(req); err != nil {
+		return nil, err
+	}
+
+	ctx = storage.WithNamespace(ctx, namespace.Code)
+	artifactStorage, err := s.getProxiedStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	artifactReader, err := artifactStorage.Get(ctx, storage.MlflowArtifactsRootURI, req.Path)
+	if err != nil {
+		return nil, convertProxiedStorageError(err, "error getting artifact object '%s'", req.Path)
+	}
+	return artifactReader, nil
+}
+
+// UploadArtifact handles the business logic of `PUT /mlflow-artifacts/artifacts/*` endpoint.
+func (s Service) UploadArtifact(
+	ctx context.Context, namespace *models.Namespace, req *request.UploadArtifactRequest, reader io.Reader,
+) error {
+	if err := ValidateUploadArtifactRequest(req); err != nil {
+		return err
+	}
+
+	ctx = storage.WithNamespace(ctx, namespace.Code)
+	artifactStorage, err := s.getProxiedStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := artifactStorage.Put(ctx, storage.MlflowArtifactsRootURI, req.Path, reader); err != nil {
+		return convertProxiedStorageError(err, "error uploading artifact object '%s'", req.Path)
+	}
+	return nil
+}
+
+// DeleteArtifact handles the business logic of `DELETE /mlflow-artifacts/artifacts/*` endpoint.
+func (s Service) DeleteArtifact(
+	ctx context.Context, namespace *models.Namespace, req *request.DeleteArtifactRequest,
+) error {
+	if err := ValidateDeleteArtifactRequest(req); err != nil {
+		return err
+	}
+
+	ctx = storage.WithNamespace(ctx, namespace.Code)
+	artifactStorage, err := s.getProxiedStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := artifactStorage.Delete(ctx, storage.MlflowArtifactsRootURI, req.Path); err != nil {
+		return convertProxiedStorageError(err, "error deleting artifact object '%s'", req.Path)
+	}
+	return nil
+}
+
+// ListProxiedArtifacts handles the business logic of `GET /mlflow-artifacts/artifacts` endpoint.
+func (s Service) ListProxiedArtifacts(
+	ctx context.Context, namespace *models.Namespace, req *request.ListProxiedArtifactsRequest,
+) ([]storage.ArtifactObject, error) {
+	if err := ValidateListProxiedArtifactsRequest(req); err != nil {
+		return nil, err
+	}
+
+	ctx = storage.WithNamespace(ctx, namespace.Code)
+	artifactStorage, err := s.getProxiedStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts, err := artifactStorage.List(ctx, storage.MlflowArtifactsRootURI, req.Path)
+	if err != nil {
+		return nil, api.NewInternalError("error getting artifact list from storage")
+	}
+
+	// sort artifacts by path
+	slices.SortFunc(artifacts, func(a, b storage.ArtifactObject) int {
+		return cmp.Compare(a.Path, b.Path)
+	})
+
+	return artifacts, nil
+}
+
+// CreateMultipartUpload handles the business logic of `POST /mlflow-artifacts/mpu/create/*` endpoint.
+func (s Service) CreateMultipartUpload(
+	ctx context.Context, namespace *models.Namespace, req *request.CreateMultipartUploadRequest,
+) (*storage.MultipartUpload, error) {
+	if err := ValidateCreateMultipartUploadRequest(req); err != nil {
+		return nil, err
+	}
+
+	ctx = storage.WithNamespace(ctx, namespace.Code)
+	artifactStorage, err := s.getProxiedStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	upload, err := artifactStorage.CreateMultipartUpload(
+		ctx, storage.MlflowArtifactsRootURI, req.GetObjectPath(), req.NumParts,
+	)
+	if err != nil {
+		return nil, convertProxiedStorageError(err, "error creating multipart upload for '%s'", req.GetObjectPath())
+	}
+	return upload, nil
+}
+
+// CompleteMultipartUpload handles the business logic of `POST /mlflow-artifacts/mpu/complete/*` endpoint.
+func (s Service) CompleteMultipartUpload(
+	ctx context.Context, namespace *models.Namespace, req *request.CompleteMultipartUploadRequest,
+) error {
+	if err := ValidateCompleteMultipartUploadRequest(req); err != nil {
+		return err
+	}
+
+	ctx = storage.WithNamespace(ctx, namespace.Code)
+	artifactStorage, err := s.getProxiedStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	parts := make([]storage.MultipartUploadPart, len(req.Parts))
+	for i, part := range req.Parts {
+		parts[i] = storage.MultipartUploadPart{
+			URL:        part.URL,
+			ETag:       part.ETag,
+			PartNumber: part.PartNumber,
+		}
+	}
+	if err := artifactStorage.CompleteMultipartUpload(
+		ctx, storage.MlflowArtifactsRootURI, req.GetObjectPath(), req.UploadID, parts,
+	); err != nil {
+		return convertProxiedStorageError(err, "error completing multipart upload for '%s'", req.GetObjectPath())
+	}
+	return nil
+}
+
+// AbortMultipartUpload handles the business logic of `POST /mlflow-artifacts/mpu/abort/*` endpoint.
+func (s Service) AbortMultipartUpload(
+	ctx context.Context, namespace *models.Namespace, req *request.AbortMultipartUploadRequest,
+) error {
+	if err := ValidateAbortMultipartUploadRequest(req); err != nil {
+		return err
+	}
+
+	ctx = storage.WithNamespace(ctx, namespace.Code)
+	artifactStorage, err := s.getProxiedStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := artifactStorage.AbortMultipartUpload(
+		ctx, storage.MlflowArtifactsRootURI, req.GetObjectPath(), req.UploadID,
+	); err != nil {
+		return convertProxiedStorageError(err, "error aborting multipart upload for '%s'", req.GetObjectPath())
+	}
+	return nil
+}
+
+// getProxiedStorage returns artifact storage of the configured artifacts destination.
+func (s Service) getProxiedStorage(ctx context.Context) (storage.ArtifactStorageProvider, error) {
+	artifactStorage, err := s.artifactStorageFactory.GetStorage(ctx, storage.MlflowArtifactsRootURI)
+	if err != nil {
+		return nil, api.NewInternalError("error getting artifacts destination storage: %s", err)
+	}
+	return artifactStorage, nil
+}
+
+// convertProxiedStorageError converts artifact storage error into the api error.
+func convertProxiedStorageError(err error, msg string, args ...any) error {
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return api.NewResourceDoesNotExistError(msg, args...)
+	case errors.Is(err, storage.ErrMultipartUploadNotSupported):
+		return api.NewNotImplementedError("%s: %s", fmt.Sprintf(msg, args...), err)
+	default:
+		return api.NewInternalError("%s: %s", fmt.Sprintf(msg, args...), err)
+	}
+}
@@ -5,10 +5,13 @@ import (
 	"context"
 	"errors"
 	"io"
+	"io/fs"
 	"strings"
 	"testing"
 
+	"github.com/rotisserie/eris"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
@@ -21,7 +24,7 @@ import (
 func TestService_ListArtifacts_Ok(t *testing.T) {
 	artifactStorage := storage.MockArtifactStorageProvider{}
 	artifactStorage.On(
-		"List", context.TODO(), "/artifact/uri", "",
+		"List", storage.WithNamespace(context.TODO(), "default"), "/artifact/uri", "",
 	).Return(
 		[]storage.ArtifactObject{
 			{
@@ -39,7 +42,7 @@ func TestService_ListArtifacts_Ok(t *testing.T) {
 
 	artifactStorageFactory := storage.MockArtifactStorageFactoryProvider{}
 	artifactStorageFactory.On(
-		"GetStorage", context.TODO(), "/artifact/uri",
+		"GetStorage", storage.WithNamespace(context.TODO(), "default"), "/artifact/uri",
 	).Return(&artifactStorage, nil)
 
 	// init repository mocks.
@@ -59,7 +62,8 @@ func TestService_ListArtifacts_Ok(t *testing.T) {
 	rootURI, artifacts, err := service.ListArtifacts(
 		context.TODO(),
 		&models.Namespace{
-			ID: 1,
+			ID:   1,
+			Code: "default",
 		},
 		&request.ListArtifactsRequest{
 			RunID: "id",
@@ -143,14 +147,14 @@ func TestService_ListArtifacts_Error(t *testing.T) {
 			service: func() *Service {
 				artifactStorage := storage.MockArtifactStorageProvider{}
 				artifactStorage.On(
-					"List", context.TODO(), "/artifact/uri", "",
+					"List", storage.WithNamespace(context.TODO(), "default"), "/artifact/uri", "",
 				).Return(
 					nil, errors.New("storage error"),
 				)
 
 				artifactStorageFactory := storage.MockArtifactStorageFactoryProvider{}
 				artifactStorageFactory.On(
-					"GetStorage", context.TODO(), "/artifact/uri",
+					"GetStorage", storage.WithNamespace(context.TODO(), "default"), "/artifact/uri",
 				).Return(&artifactStorage, nil)
 
 				runRepository := repositories.MockRunRepositoryProvider{}
@@ -175,7 +179,8 @@ func TestService_ListArtifacts_Error(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// call service under testing.
 			_, _, err := tt.service().ListArtifacts(context.TODO(), &models.Namespace{
-				ID: 1,
+				ID:   1,
+				Code: "default",
 			}, tt.request)
 			assert.Equal(t, tt.error, err)
 		})
@@ -185,14 +190,14 @@ func TestService_ListArtifacts_Error(t *testing.T) {
 func TestService_GetArtifact_Ok(t *testing.T) {
 	artifactStorage := storage.MockArtifactStorageProvider{}
 	artifactStorage.On(
-		"Get", context.TODO(), "/artifact/uri", "",
+		"Get", storage.WithNamespace(context.TODO(), "default"), "/artifact/uri", "",
 	).Return(
 		io.NopCloser(strings.NewReader("content")), nil,
 	)
 
 	artifactStorageFactory := storage.MockArtifactStorageFactoryProvider{}
 	artifactStorageFactory.On(
-		"GetStorage", context.TODO(), "/artifact/uri",
+		"GetStorage", storage.WithNamespace(context.TODO(), "default"), "/artifact/uri",
 	).Return(&artifactStorage, nil)
 
 	// init repository mocks.
@@ -212,7 +217,8 @@ func TestService_GetArtifact_Ok(t *testing.T) {
 	data, err := service.GetArtifact(
 		context.TODO(),
 		&models.Namespace{
-			ID: 1,
+			ID:   1,
+			Code: "default",
 		},
 		&request.GetArtifactRequest{
 			RunID: "id",
@@ -287,14 +293,14 @@ func TestService_GetArtifact_Error(t *testing.T) {
 			service: func() *Service {
 				artifactStorage := storage.MockArtifactStorageProvider{}
 				artifactStorage.On(
-					"Get", context.TODO(), "/artifact/uri", "",
+					"Get", storage.WithNamespace(context.TODO(), "default"), "/artifact/uri", "",
 				).Return(
 					nil, errors.New("storage error"),
 				)
 
 				artifactStorageFactory := storage.MockArtifactStorageFactoryProvider{}
 				artifactStorageFactory.On(
-					"GetStorage", context.TODO(), "/artifact/uri",
+					"GetStorage", storage.WithNamespace(context.TODO(), "default"), "/artifact/uri",
 				).Return(&artifactStorage, nil)
 
 				runRepository := repositories.MockRunRepositoryProvider{}
@@ -322,14 +328,14 @@ func TestService_GetArtifact_Error(t *testing.T) {
 			service: func() *Service {
 				artifactStorage := storage.MockArtifactStorageProvider{}
 				artifactStorage.On(
-					"Get", context.TODO(), "/artifact/uri", "",
+					"Get", storage.WithNamespace(context.TODO(), "default"), "/artifact/uri", "",
 				).Return(
 					nil, errors.New("storage error"),
 				)
 
 				artifactStorageFactory := storage.MockArtifactStorageFactoryProvider{}
 				artifactStorageFactory.On(
-					"GetStorage", context.TODO(), "/artifact/uri",
+					"GetStorage", storage.WithNamespace(context.TODO(), "default"), "/artifact/uri",
 				).Return(nil, errors.New("unsupported error"))
 
 				runRepository := repositories.MockRunRepositoryProvider{}
@@ -354,9 +360,135 @@ func TestService_GetArtifact_Error(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// call service under testing.
 			_, err := tt.service().GetArtifact(context.TODO(), &models.Namespace{
-				ID: 1,
+				ID:   1,
+				Code: "default",
 			}, tt.request)
 			assert.Equal(t, tt.error, err)
 		})
 	}
 }
+
+func TestService_UploadArtifact_Ok(t *testing.T) {
+	artifactStorage := storage.MockArtifactStorageProvider{}
+	artifactStorage.On(
+		"Put",
+		storage.WithNamespace(context.TODO(), "default"),
+		storage.MlflowArtifactsRootURI,
+		"1/id/artifacts/file.txt",
+		mock.Anything,
+	).Return(nil)
+
+	artifactStorageFactory := storage.MockArtifactStorageFactoryProvider{}
+	artifactStorageFactory.On(
+		"GetStorage", storage.WithNamespace(context.TODO(), "default"), storage.MlflowArtifactsRootURI,
+	).Return(&artifactStorage, nil)
+
+	// call service under testing.
+	service := NewService(&repositories.MockRunRepositoryProvider{}, &artifactStorageFactory)
+	err := service.UploadArtifact(
+		context.TODO(),
+		&models.Namespace{Code: "default"},
+		&request.UploadArtifactRequest{
+			Path: "1/id/artifacts/file.txt",
+		},
+		strings.NewReader("content"),
+	)
+
+	require.Nil(t, err)
+	artifactStorage.AssertExpectations(t)
+}
+
+func TestService_DownloadArtifact_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.DownloadArtifactRequest
+		service func() *Service
+	}{
+		{
+			name:    "EmptyPath",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'path'"),
+			request: &request.DownloadArtifactRequest{},
+			service: func() *Service {
+				return NewService(
+					&repositories.MockRunRepositoryProvider{},
+					&storage.MockArtifactStorageFactoryProvider{},
+				)
+			},
+		},
+		{
+			name:  "PathIsRelativeAndContains2Dots",
+			error: api.NewInvalidParameterValueError("Invalid path"),
+			request: &request.DownloadArtifactRequest{
+				Path: "../file.txt",
+			},
+			service: func() *Service {
+				return NewService(
+					&repositories.MockRunRepositoryProvider{},
+					&storage.MockArtifactStorageFactoryProvider{},
+				)
+			},
+		},
+		{
+			name:  "ArtifactNotFound",
+			error: api.NewResourceDoesNotExistError("error getting artifact object 'file.txt'"),
+			request: &request.DownloadArtifactRequest{
+				Path: "file.txt",
+			},
+			service: func() *Service {
+				artifactStorage := storage.MockArtifactStorageProvider{}
+				artifactStorage.On(
+					"Get", storage.WithNamespace(context.TODO(), "default"), storage.MlflowArtifactsRootURI, "file.txt",
+				).Return(
+					nil, eris.Wrap(fs.ErrNotExist, "object does not exist"),
+				)
+
+				artifactStorageFactory := storage.MockArtifactStorageFactoryProvider{}
+				artifactStorageFactory.On(
+					"GetStorage", storage.WithNamespace(context.TODO(), "default"), storage.MlflowArtifactsRootURI,
+				).Return(&artifactStorage, nil)
+				return NewService(&repositories.MockRunRepositoryProvider{}, &artifactStorageFactory)
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.service().DownloadArtifact(context.TODO(), &models.Namespace{Code: "default"}, tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestService_CreateMultipartUpload_Error(t *testing.T) {
+	artifactStorage := storage.MockArtifactStorageProvider{}
+	artifactStorage.On(
+		"CreateMultipartUpload",
+		storage.WithNamespace(context.TODO(), "default"),
+		storage.MlflowArtifactsRootURI,
+		"dir/file.txt",
+		int32(2),
+	).Return(nil, storage.ErrMultipartUploadNotSupported)
+
+	artifactStorageFactory := storage.MockArtifactStorageFactoryProvider{}
+	artifactStorageFactory.On(
+		"GetStorage", storage.WithNamespace(context.TODO(), "default"), storage.MlflowArtifactsRootURI,
+	).Return(&artifactStorage, nil)
+
+	// call service under testing.
+	service := NewService(&repositories.MockRunRepositoryProvider{}, &artifactStorageFactory)
+	upload, err := service.CreateMultipartUpload(
+		context.TODO(),
+		&models.Namespace{Code: "default"},
+		&request.CreateMultipartUploadRequest{
+			ArtifactPath: "dir",
+			Path:         "/local/path/file.txt",
+			NumParts:     2,
+		},
+	)
+
+	assert.Nil(t, upload)
+	assert.Equal(t, api.NewNotImplementedError(
+		"error creating multipart upload for 'dir/file.txt': %s", storage.ErrMultipartUploadNotSupported,
+	), err)
+}
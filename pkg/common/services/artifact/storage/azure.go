@@ -0,0 +1,317 @@
+package storage
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+// Azure storage names.
+const (
+	AzureBlobStorageName             = "wasbs"
+	AzureBlobInsecureStorageName     = "wasb"
+	AzureDataLakeStorageName         = "abfss"
+	AzureDataLakeInsecureStorageName = "abfs"
+)
+
+const (
+	// azureSASURLExpiration is a lifetime of SAS urls generated for multipart upload.
+	azureSASURLExpiration = 1 * time.Hour
+	// azureBlockIDFormat is a format of block ids. all the block ids of the blob must have the same length.
+	azureBlockIDFormat = "%05d"
+	// azureDefaultServiceURLFormat is a format of blob service url used when endpoint is not configured.
+	azureDefaultServiceURLFormat = "https://%s.blob.core.windows.net/"
+)
+
+// Azure represents adapter to work with Azure Blob Storage and Azure Data Lake Storage Gen2 artifacts.
+type Azure struct {
+	config  *config.Config
+	clients sync.Map
+}
+
+// NewAzure creates new Azure storage instance.
+func NewAzure(config *config.Config) (*Azure, error) {
+	return &Azure{
+		config:  config,
+		clients: sync.Map{},
+	}, nil
+}
+
+// List implements ArtifactStorageProvider interface.
+func (s *Azure) List(ctx context.Context, artifactURI, path string) ([]ArtifactObject, error) {
+	// 1. process input parameters.
+	containerName, rootPrefix, client, err := s.getContainerClient(artifactURI)
+	if err != nil {
+		return nil, err
+	}
+	prefix := filepath.Join(rootPrefix, path)
+	if prefix != "" {
+		prefix = prefix + "/"
+	}
+
+	// 2. read data from azure storage.
+	var artifactList []ArtifactObject
+	pager := client.NewListBlobsHierarchyPager("/", &container.ListBlobsHierarchyOptions{
+		Prefix: &prefix,
+	})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, eris.Wrap(err, "error getting azure page objects")
+		}
+
+		log.Debugf(
+			"got %d directories from Azure storage for container %q and prefix %q",
+			len(page.Segment.BlobPrefixes), containerName, prefix,
+		)
+		for _, dir := range page.Segment.BlobPrefixes {
+			relPath, err := filepath.Rel(rootPrefix, *dir.Name)
+			if err != nil {
+				return nil, eris.Wrapf(err, "error getting relative path for dir: %s", *dir.Name)
+			}
+			artifactList = append(artifactList, ArtifactObject{
+				Path:  relPath,
+				Size:  0,
+				IsDir: true,
+			})
+		}
+
+		log.Debugf(
+			"got %d objects from Azure storage for container %q and prefix %q",
+			len(page.Segment.BlobItems), containerName, prefix,
+		)
+		for _, object := range page.Segment.BlobItems {
+			relPath, err := filepath.Rel(rootPrefix, *object.Name)
+			if err != nil {
+				return nil, eris.Wrapf(err, "error getting relative path for object: %s", *object.Name)
+			}
+			artifactObject := ArtifactObject{
+				Path:  relPath,
+				IsDir: false,
+			}
+			if object.Properties != nil && object.Properties.ContentLength != nil {
+				artifactObject.Size = *object.Properties.ContentLength
+			}
+			artifactList = append(artifactList, artifactObject)
+		}
+	}
+
+	return artifactList, nil
+}
+
+// Get returns file content at the storage location.
+func (s *Azure) Get(ctx context.Context, artifactURI, path string) (io.ReadCloser, error) {
+	_, prefix, client, err := s.getContainerClient(artifactURI)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.NewBlobClient(filepath.Join(prefix, path)).DownloadStream(ctx, nil)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound, bloberror.ContainerNotFound) {
+			return nil, eris.Wrap(fs.ErrNotExist, "object does not exist")
+		}
+		return nil, eris.Wrap(err, "error getting object")
+	}
+
+	return resp.Body, nil
+}
+
+// Put uploads content of provided reader as a blob at the storage location.
+func (s *Azure) Put(ctx context.Context, artifactURI, path string, reader io.Reader) error {
+	_, prefix, client, err := s.getContainerClient(artifactURI)
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.NewBlockBlobClient(filepath.Join(prefix, path)).UploadStream(ctx, reader, nil); err != nil {
+		return eris.Wrap(err, "error putting object")
+	}
+	return nil
+}
+
+// Delete deletes blob or all the blobs under the `directory` at the storage location.
+func (s *Azure) Delete(ctx context.Context, artifactURI, path string) error {
+	// 1. collect the blob itself and all the nested blobs.
+	_, prefix, client, err := s.getContainerClient(artifactURI)
+	if err != nil {
+		return err
+	}
+	key := filepath.Join(prefix, path)
+
+	var names []string
+	pager := client.NewListBlobsFlatPager(&container.ListBlobsFlatOptions{
+		Prefix: &key,
+	})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return eris.Wrap(err, "error getting azure page objects")
+		}
+		for _, object := range page.Segment.BlobItems {
+			if *object.Name == key || strings.HasPrefix(*object.Name, key+"/") {
+				names = append(names, *object.Name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		return eris.Wrap(fs.ErrNotExist, "object does not exist")
+	}
+
+	// 2. delete blobs.
+	for _, name := range names {
+		if _, err := client.NewBlobClient(name).Delete(ctx, nil); err != nil {
+			return eris.Wrapf(err, "error deleting object: %s", name)
+		}
+	}
+	return nil
+}
+
+// CreateMultipartUpload generates SAS urls to stage each part as a block of the block blob.
+// SAS urls could be signed only when storage access key or connection string with the key is configured.
+func (s *Azure) CreateMultipartUpload(
+	ctx context.Context, artifactURI, path string, numParts int32,
+) (*MultipartUpload, error) {
+	_, prefix, client, err := s.getContainerClient(artifactURI)
+	if err != nil {
+		return nil, err
+	}
+
+	sasURL, err := client.NewBlockBlobClient(filepath.Join(prefix, path)).GetSASURL(
+		sas.BlobPermissions{Create: true, Write: true}, time.Now().Add(azureSASURLExpiration), nil,
+	)
+	if err != nil {
+		if errors.Is(err, bloberror.MissingSharedKeyCredential) {
+			return nil, ErrMultipartUploadNotSupported
+		}
+		return nil, eris.Wrap(err, "error signing url")
+	}
+
+	// blocks are staged directly to the blob, so there is no need for an upload id.
+	upload := MultipartUpload{
+		Credentials: make([]MultipartUploadCredential, numParts),
+	}
+	for i := int32(0); i < numParts; i++ {
+		partNumber := i + 1
+		upload.Credentials[i] = MultipartUploadCredential{
+			URL: fmt.Sprintf(
+				"%s&comp=block&blockid=%s", sasURL, url.QueryEscape(getAzureBlockID(partNumber)),
+			),
+			Headers:    map[string]string{},
+			PartNumber: partNumber,
+		}
+	}
+	return &upload, nil
+}
+
+// CompleteMultipartUpload commits the staged blocks into the block blob.
+func (s *Azure) CompleteMultipartUpload(
+	ctx context.Context, artifactURI, path, uploadID string, parts []MultipartUploadPart,
+) error {
+	_, prefix, client, err := s.getContainerClient(artifactURI)
+	if err != nil {
+		return err
+	}
+
+	slices.SortFunc(parts, func(a, b MultipartUploadPart) int {
+		return cmp.Compare(a.PartNumber, b.PartNumber)
+	})
+	blockIDs := make([]string, len(parts))
+	for i, part := range parts {
+		blockIDs[i] = getAzureBlockID(part.PartNumber)
+	}
+	if _, err := client.NewBlockBlobClient(filepath.Join(prefix, path)).CommitBlockList(
+		ctx, blockIDs, nil,
+	); err != nil {
+		return eris.Wrap(err, "error committing block list")
+	}
+	return nil
+}
+
+// AbortMultipartUpload implements ArtifactStorageProvider interface.
+// Uncommitted blocks are garbage collected by Azure, so there is nothing to clean up.
+func (s *Azure) AbortMultipartUpload(ctx context.Context, artifactURI, path, uploadID string) error {
+	return nil
+}
+
+// getContainerClient returns container name, prefix and container client for provided uri.
+func (s *Azure) getContainerClient(artifactURI string) (string, string, *container.Client, error) {
+	containerName, account, prefix, err := ExtractAzureContainerAccountAndPrefix(artifactURI)
+	if err != nil {
+		return "", "", nil, eris.Wrap(err, "error extracting container, account and prefix from provided uri")
+	}
+	client, err := s.getClient(account)
+	if err != nil {
+		return "", "", nil, err
+	}
+	return containerName, prefix, client.ServiceClient().NewContainerClient(containerName), nil
+}
+
+// getClient returns cached client for the storage account or creates a new one
+// based on the configured credentials.
+func (s *Azure) getClient(account string) (*azblob.Client, error) {
+	if client, ok := s.clients.Load(account); ok {
+		return client.(*azblob.Client), nil
+	}
+
+	serviceURL := s.config.AzureStorageEndpointURI
+	if serviceURL == "" {
+		serviceURL = fmt.Sprintf(azureDefaultServiceURLFormat, account)
+	}
+
+	var client *azblob.Client
+	switch {
+	case s.config.AzureStorageConnectionString != "":
+		var err error
+		client, err = azblob.NewClientFromConnectionString(s.config.AzureStorageConnectionString, nil)
+		if err != nil {
+			return nil, eris.Wrap(err, "error creating azure client from connection string")
+		}
+	case s.config.AzureStorageAccessKey != "":
+		credential, err := azblob.NewSharedKeyCredential(account, s.config.AzureStorageAccessKey)
+		if err != nil {
+			return nil, eris.Wrap(err, "error creating azure shared key credential")
+		}
+		client, err = azblob.NewClientWithSharedKeyCredential(serviceURL, credential, nil)
+		if err != nil {
+			return nil, eris.Wrap(err, "error creating azure client with shared key credential")
+		}
+	default:
+		credential, err := azidentity.NewDefaultAzureCredential(nil)
+		if err != nil {
+			return nil, eris.Wrap(err, "error creating azure default credential")
+		}
+		client, err = azblob.NewClient(serviceURL, credential, nil)
+		if err != nil {
+			return nil, eris.Wrap(err, "error creating azure client")
+		}
+	}
+
+	s.clients.Store(account, client)
+	return client, nil
+}
+
+// getAzureBlockID returns base64 encoded block id for the part number.
+func getAzureBlockID(partNumber int32) string {
+	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf(azureBlockIDFormat, partNumber)))
+}
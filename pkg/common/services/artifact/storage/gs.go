@@ -1,13 +1,20 @@
 package storage
 
 import (
+	"cmp"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"io/fs"
+	"net/http"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"cloud.google.com/go/storage"
+	"github.com/google/uuid"
 	"github.com/rotisserie/eris"
 	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
@@ -20,6 +27,13 @@ const (
 	GSStorageName = "gs"
 )
 
+const (
+	// gsSignedURLExpiration is a lifetime of signed multipart upload urls.
+	gsSignedURLExpiration = 1 * time.Hour
+	// gsMaxComposeSources is a maximum number of source objects accepted by a single compose request.
+	gsMaxComposeSources = 32
+)
+
 // GS represents adapter to work with GS storage artifacts.
 type GS struct {
 	client *storage.Client
@@ -112,3 +126,165 @@ func (s GS) Get(ctx context.Context, artifactURI, path string) (io.ReadCloser, e
 
 	return reader, nil
 }
+
+// Put uploads content of provided reader as an object at the storage location.
+func (s GS) Put(ctx context.Context, artifactURI, path string, reader io.Reader) error {
+	bucketName, prefix, err := ExtractBucketAndPrefix(artifactURI)
+	if err != nil {
+		return eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+
+	writer := s.client.Bucket(bucketName).Object(filepath.Join(prefix, path)).NewWriter(ctx)
+	if _, err := io.Copy(writer, reader); err != nil {
+		//nolint:errcheck
+		writer.Close()
+		return eris.Wrap(err, "error writing object")
+	}
+	if err := writer.Close(); err != nil {
+		return eris.Wrap(err, "error finalizing object")
+	}
+	return nil
+}
+
+// Delete deletes object or all the objects under the `directory` at the storage location.
+func (s GS) Delete(ctx context.Context, artifactURI, path string) error {
+	bucketName, prefix, err := ExtractBucketAndPrefix(artifactURI)
+	if err != nil {
+		return eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+	key := filepath.Join(prefix, path)
+
+	deleted, err := s.deleteObjects(ctx, bucketName, key, func(name string) bool {
+		return name == key || strings.HasPrefix(name, key+"/")
+	})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return eris.Wrap(fs.ErrNotExist, "object does not exist")
+	}
+	return nil
+}
+
+// CreateMultipartUpload generates signed urls to upload each part as a separate temporary object.
+// Parts will be composed into the final object by CompleteMultipartUpload.
+func (s GS) CreateMultipartUpload(
+	ctx context.Context, artifactURI, path string, numParts int32,
+) (*MultipartUpload, error) {
+	bucketName, prefix, err := ExtractBucketAndPrefix(artifactURI)
+	if err != nil {
+		return nil, eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+	key := filepath.Join(prefix, path)
+
+	upload := MultipartUpload{
+		UploadID:    uuid.NewString(),
+		Credentials: make([]MultipartUploadCredential, numParts),
+	}
+	for i := int32(0); i < numParts; i++ {
+		partNumber := i + 1
+		url, err := s.client.Bucket(bucketName).SignedURL(
+			getGSPartName(key, upload.UploadID, partNumber),
+			&storage.SignedURLOptions{
+				Scheme:  storage.SigningSchemeV4,
+				Method:  http.MethodPut,
+				Expires: time.Now().Add(gsSignedURLExpiration),
+			},
+		)
+		if err != nil {
+			return nil, eris.Wrapf(err, "error signing url for part %d", partNumber)
+		}
+		upload.Credentials[i] = MultipartUploadCredential{
+			URL:        url,
+			Headers:    map[string]string{},
+			PartNumber: partNumber,
+		}
+	}
+	return &upload, nil
+}
+
+// CompleteMultipartUpload composes uploaded parts into the final object and removes the parts.
+func (s GS) CompleteMultipartUpload(
+	ctx context.Context, artifactURI, path, uploadID string, parts []MultipartUploadPart,
+) error {
+	bucketName, prefix, err := ExtractBucketAndPrefix(artifactURI)
+	if err != nil {
+		return eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+	key := filepath.Join(prefix, path)
+	bucket := s.client.Bucket(bucketName)
+
+	// 1. compose parts in order. compose request is limited by the number of sources,
+	// so the final object is accumulated by appending next batch of parts to it.
+	slices.SortFunc(parts, func(a, b MultipartUploadPart) int {
+		return cmp.Compare(a.PartNumber, b.PartNumber)
+	})
+	destination := bucket.Object(key)
+	for start := 0; start < len(parts); {
+		var sources []*storage.ObjectHandle
+		if start > 0 {
+			sources = append(sources, destination)
+		}
+		for ; start < len(parts) && len(sources) < gsMaxComposeSources; start++ {
+			sources = append(sources, bucket.Object(getGSPartName(key, uploadID, parts[start].PartNumber)))
+		}
+		if _, err := destination.ComposerFrom(sources...).Run(ctx); err != nil {
+			return eris.Wrap(err, "error composing object parts")
+		}
+	}
+
+	// 2. remove temporary part objects.
+	return s.AbortMultipartUpload(ctx, artifactURI, path, uploadID)
+}
+
+// AbortMultipartUpload removes all the uploaded parts.
+func (s GS) AbortMultipartUpload(ctx context.Context, artifactURI, path, uploadID string) error {
+	bucketName, prefix, err := ExtractBucketAndPrefix(artifactURI)
+	if err != nil {
+		return eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+
+	partsPrefix := getGSPartsPrefix(filepath.Join(prefix, path), uploadID)
+	if _, err := s.deleteObjects(ctx, bucketName, partsPrefix, func(string) bool {
+		return true
+	}); err != nil {
+		return eris.Wrap(err, "error deleting object parts")
+	}
+	return nil
+}
+
+// deleteObjects deletes objects with provided prefix accepted by filter function.
+func (s GS) deleteObjects(
+	ctx context.Context, bucketName, prefix string, filter func(name string) bool,
+) (int, error) {
+	deleted := 0
+	bucket := s.client.Bucket(bucketName)
+	it := bucket.Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		object, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return 0, eris.Wrap(err, "error getting object information")
+		}
+		if !filter(object.Name) {
+			continue
+		}
+		if err := bucket.Object(object.Name).Delete(ctx); err != nil {
+			return 0, eris.Wrapf(err, "error deleting object: %s", object.Name)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// getGSPartsPrefix returns prefix of temporary part objects for the provided upload.
+func getGSPartsPrefix(key, uploadID string) string {
+	return fmt.Sprintf("%s.%s.parts/", key, uploadID)
+}
+
+// getGSPartName returns name of temporary part object.
+func getGSPartName(key, uploadID string, partNumber int32) string {
+	return fmt.Sprintf("%s%05d", getGSPartsPrefix(key, uploadID), partNumber)
+}
@@ -11,7 +11,7 @@ import (
 // uri could be any S3 compatible storage uri like AWS S3 or Google Storage.
 // after processing of uri s3://fasttrackml/2/30357ed2eaac4f2cacdbcd0e06e9e48a/artifacts result will be:
 // - bucket = fasttrackml
-// - prefix = 2/30357ed2eaac4f2cacdbcd0e06e9e48a/artifacts
+// - prefix = 2/30357ed2eaac4f2c/artifacts
 func ExtractBucketAndPrefix(uri string) (string, string, error) {
 	u, err := url.Parse(uri)
 	if err != nil {
@@ -20,3 +20,22 @@ func ExtractBucketAndPrefix(uri string) (string, string, error) {
 
 	return u.Host, strings.TrimLeft(u.Path, "/"), nil
 }
+
+// ExtractAzureContainerAccountAndPrefix extracts container name, account name and prefix path from provided uri.
+// after processing of uri wasbs://fasttrackml@account.blob.core.windows.net/2/30357ed2eaac4f2c/artifacts
+// result will be:
+// - container = fasttrackml
+// - account = account
+// - prefix = 2/30357ed2eaac4f2c/artifacts
+func ExtractAzureContainerAccountAndPrefix(uri string) (string, string, string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", "", "", eris.Wrapf(err, "error parsing provided uri: %s", uri)
+	}
+	if u.User == nil || u.User.Username() == "" {
+		return "", "", "", eris.Errorf("container name is missing in provided uri: %s", uri)
+	}
+
+	account, _, _ := strings.Cut(u.Hostname(), ".")
+	return u.User.Username(), account, strings.TrimLeft(u.Path, "/"), nil
+}
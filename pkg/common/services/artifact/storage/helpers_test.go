@@ -14,3 +14,17 @@ func TestExtractS3BucketAndPrefix_Ok(t *testing.T) {
 	assert.Equal(t, "fasttrackml", bucket)
 	assert.Equal(t, "2/30357ed2eaac4f2cacdbcd0e06e9e48a/artifacts", prefix)
 }
+
+func TestExtractAzureContainerAccountAndPrefix_Ok(t *testing.T) {
+	uri := "abfss://fasttrackml@account.dfs.core.windows.net/2/30357ed2eaac4f2cacdbcd0e06e9e48a/artifacts"
+	container, account, prefix, err := ExtractAzureContainerAccountAndPrefix(uri)
+	require.Nil(t, err)
+	assert.Equal(t, "fasttrackml", container)
+	assert.Equal(t, "account", account)
+	assert.Equal(t, "2/30357ed2eaac4f2cacdbcd0e06e9e48a/artifacts", prefix)
+}
+
+func TestExtractAzureContainerAccountAndPrefix_Error(t *testing.T) {
+	_, _, _, err := ExtractAzureContainerAccountAndPrefix("wasbs://account.blob.core.windows.net/path")
+	assert.EqualError(t, err, "container name is missing in provided uri: wasbs://account.blob.core.windows.net/path")
+}
@@ -0,0 +1,230 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+// HTTP storage names.
+const (
+	HTTPStorageName  = "http"
+	HTTPSStorageName = "https"
+)
+
+// httpClientTimeout is the time limit of the single request, including reading of the response body.
+const httpClientTimeout = 10 * time.Minute
+
+// httpMlflowArtifactsEndpoint is a path of `mlflow-artifacts` api. Only uris pointing to this api support listing.
+const httpMlflowArtifactsEndpoint = "/mlflow-artifacts/artifacts"
+
+// httpListArtifactsResponse is a response of `mlflow-artifacts` list endpoint.
+type httpListArtifactsResponse struct {
+	Files []struct {
+		Path     string `json:"path"`
+		IsDir    bool   `json:"is_dir"`
+		FileSize int64  `json:"file_size"`
+	} `json:"files"`
+}
+
+// HTTP represents adapter to work with artifacts served by generic HTTP server or `mlflow-artifacts` api.
+type HTTP struct {
+	client   *http.Client
+	username string
+	password string
+	token    string
+	// origins are scheme and host of the configured artifact roots. Requests are made only to them,
+	// so the server can't be used to reach the other hosts.
+	origins []string
+}
+
+// NewHTTP creates new HTTP storage instance.
+func NewHTTP(config *config.Config) (*HTTP, error) {
+	origins := httpOrigins(config)
+	return &HTTP{
+		client: &http.Client{
+			Timeout: httpClientTimeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if !slices.Contains(origins, httpOrigin(req.URL)) {
+					return eris.Errorf("redirect to %s is outside of the configured artifact roots", req.URL)
+				}
+				return nil
+			},
+		},
+		username: config.HTTPArtifactsUsername,
+		password: config.HTTPArtifactsPassword,
+		token:    config.HTTPArtifactsToken,
+		origins:  origins,
+	}, nil
+}
+
+// List implements ArtifactStorageProvider interface.
+func (s HTTP) List(ctx context.Context, artifactURI, path string) ([]ArtifactObject, error) {
+	// 1. generic HTTP servers have no way to list objects, so only `mlflow-artifacts` api is supported.
+	host, root, ok := strings.Cut(artifactURI, httpMlflowArtifactsEndpoint)
+	if !ok {
+		log.Debugf("listing is not supported for artifact uri %q, returning empty list", artifactURI)
+		return []ArtifactObject{}, nil
+	}
+
+	// 2. request the list of objects.
+	u, err := url.Parse(host + httpMlflowArtifactsEndpoint)
+	if err != nil {
+		return nil, eris.Wrap(err, "error parsing artifact uri")
+	}
+	u.RawQuery = url.Values{"path": []string{joinHTTPPath(strings.TrimPrefix(root, "/"), path)}}.Encode()
+	resp, err := s.do(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	//nolint:errcheck
+	defer resp.Body.Close()
+
+	var listResponse httpListArtifactsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&listResponse); err != nil {
+		return nil, eris.Wrap(err, "error decoding list response")
+	}
+
+	// 3. `mlflow-artifacts` api returns base names, so convert them into paths relative to the artifact uri.
+	log.Debugf("got %d objects from HTTP storage for uri %q and path %q", len(listResponse.Files), artifactURI, path)
+	artifactList := make([]ArtifactObject, len(listResponse.Files))
+	for i, file := range listResponse.Files {
+		artifactList[i] = ArtifactObject{
+			Path:  joinHTTPPath(path, file.Path),
+			Size:  file.FileSize,
+			IsDir: file.IsDir,
+		}
+	}
+	return artifactList, nil
+}
+
+// Get returns file content at the storage location.
+func (s HTTP) Get(ctx context.Context, artifactURI, path string) (io.ReadCloser, error) {
+	objectURL, err := url.JoinPath(artifactURI, path)
+	if err != nil {
+		return nil, eris.Wrap(err, "error building object url")
+	}
+
+	resp, err := s.do(ctx, http.MethodGet, objectURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Put uploads content of provided reader to the storage location.
+func (s HTTP) Put(ctx context.Context, artifactURI, path string, reader io.Reader) error {
+	objectURL, err := url.JoinPath(artifactURI, path)
+	if err != nil {
+		return eris.Wrap(err, "error building object url")
+	}
+
+	resp, err := s.do(ctx, http.MethodPut, objectURL, reader)
+	if err != nil {
+		return err
+	}
+	//nolint:errcheck
+	defer resp.Body.Close()
+	return nil
+}
+
+// Delete deletes object at the storage location.
+func (s HTTP) Delete(ctx context.Context, artifactURI, path string) error {
+	objectURL, err := url.JoinPath(artifactURI, path)
+	if err != nil {
+		return eris.Wrap(err, "error building object url")
+	}
+
+	resp, err := s.do(ctx, http.MethodDelete, objectURL, nil)
+	if err != nil {
+		return err
+	}
+	//nolint:errcheck
+	defer resp.Body.Close()
+	return nil
+}
+
+// CreateMultipartUpload implements ArtifactStorageProvider interface.
+func (s HTTP) CreateMultipartUpload(
+	ctx context.Context, artifactURI, path string, numParts int32,
+) (*MultipartUpload, error) {
+	return nil, ErrMultipartUploadNotSupported
+}
+
+// CompleteMultipartUpload implements ArtifactStorageProvider interface.
+func (s HTTP) CompleteMultipartUpload(
+	ctx context.Context, artifactURI, path, uploadID string, parts []MultipartUploadPart,
+) error {
+	return ErrMultipartUploadNotSupported
+}
+
+// AbortMultipartUpload implements ArtifactStorageProvider interface.
+func (s HTTP) AbortMultipartUpload(ctx context.Context, artifactURI, path, uploadID string) error {
+	return ErrMultipartUploadNotSupported
+}
+
+// do makes authenticated HTTP request to the configured artifact roots and checks the response status.
+func (s HTTP) do(ctx context.Context, method, url string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, eris.Wrap(err, "error creating request")
+	}
+	if !slices.Contains(s.origins, httpOrigin(req.URL)) {
+		return nil, eris.Errorf("artifact uri %s is outside of the configured artifact roots", url)
+	}
+	switch {
+	case s.token != "":
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.token))
+	case s.username != "":
+		req.SetBasicAuth(s.username, s.password)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error doing %s request", method)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		//nolint:errcheck
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, eris.Wrap(fs.ErrNotExist, "object does not exist")
+		}
+		return nil, eris.Errorf("unexpected response status: %s", resp.Status)
+	}
+	return resp, nil
+}
+
+// httpOrigins returns origins of the configured artifact roots, which are served by the HTTP storage.
+func httpOrigins(config *config.Config) []string {
+	var origins []string
+	for _, uri := range []string{config.DefaultArtifactRoot, config.ArtifactsDestination} {
+		if u, err := url.Parse(uri); err == nil && u.Host != "" &&
+			(u.Scheme == HTTPStorageName || u.Scheme == HTTPSStorageName) {
+			origins = append(origins, httpOrigin(u))
+		}
+	}
+	return origins
+}
+
+// httpOrigin returns scheme and host of the url.
+func httpOrigin(u *url.URL) string {
+	return u.Scheme + "://" + strings.ToLower(u.Host)
+}
+
+// joinHTTPPath joins url path elements, skipping empty ones.
+func joinHTTPPath(elem ...string) string {
+	return strings.TrimPrefix(path.Join(elem...), "/")
+}
@@ -0,0 +1,188 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+// newHTTPTestServer creates in-memory HTTP stand-in of `mlflow-artifacts` api.
+func newHTTPTestServer(t *testing.T) *httptest.Server {
+	objects := sync.Map{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		key := strings.TrimPrefix(r.URL.Path, "/api/2.0/mlflow-artifacts/artifacts/")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/2.0/mlflow-artifacts/artifacts":
+			//nolint:errcheck
+			w.Write([]byte(`{"files":[{"path":"artifact.dir","is_dir":true},{"path":"artifact.file","file_size":7}]}`))
+		case r.Method == http.MethodGet:
+			content, ok := objects.Load(key)
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			//nolint:errcheck
+			w.Write(content.([]byte))
+		case r.Method == http.MethodPut:
+			content, err := io.ReadAll(r.Body)
+			require.Nil(t, err)
+			objects.Store(key, content)
+		case r.Method == http.MethodDelete:
+			objects.Delete(key)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHTTP_PutAndGet_Ok(t *testing.T) {
+	server := newHTTPTestServer(t)
+	storage, err := NewHTTP(&config.Config{
+		DefaultArtifactRoot: server.URL + "/api/2.0/mlflow-artifacts/artifacts",
+		HTTPArtifactsToken:  "token",
+	})
+	require.Nil(t, err)
+
+	artifactURI := server.URL + "/api/2.0/mlflow-artifacts/artifacts/1/id/artifacts"
+	require.Nil(t, storage.Put(context.Background(), artifactURI, "artifact.file", strings.NewReader("content")))
+
+	reader, err := storage.Get(context.Background(), artifactURI, "artifact.file")
+	require.Nil(t, err)
+	//nolint:errcheck
+	defer reader.Close()
+	content, err := io.ReadAll(reader)
+	require.Nil(t, err)
+	assert.Equal(t, "content", string(content))
+
+	require.Nil(t, storage.Delete(context.Background(), artifactURI, "artifact.file"))
+	_, err = storage.Get(context.Background(), artifactURI, "artifact.file")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestHTTP_List_Ok(t *testing.T) {
+	server := newHTTPTestServer(t)
+	storage, err := NewHTTP(&config.Config{
+		DefaultArtifactRoot: server.URL + "/api/2.0/mlflow-artifacts/artifacts",
+		HTTPArtifactsToken:  "token",
+	})
+	require.Nil(t, err)
+
+	// `mlflow-artifacts` uri supports listing.
+	artifacts, err := storage.List(
+		context.Background(), server.URL+"/api/2.0/mlflow-artifacts/artifacts/1/id/artifacts", "dir",
+	)
+	require.Nil(t, err)
+	assert.Equal(t, []ArtifactObject{
+		{
+			Path:  "dir/artifact.dir",
+			IsDir: true,
+		},
+		{
+			Path: "dir/artifact.file",
+			Size: 7,
+		},
+	}, artifacts)
+
+	// generic HTTP uri returns an empty list.
+	artifacts, err = storage.List(context.Background(), server.URL+"/static/artifacts", "")
+	require.Nil(t, err)
+	assert.Empty(t, artifacts)
+}
+
+func TestHTTP_Get_Error(t *testing.T) {
+	var requests int
+	foreignServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		//nolint:errcheck
+		w.Write([]byte("content"))
+	}))
+	t.Cleanup(foreignServer.Close)
+
+	server := newHTTPTestServer(t)
+	redirectServer := httptest.NewServer(http.RedirectHandler(foreignServer.URL+"/artifact.file", http.StatusFound))
+	t.Cleanup(redirectServer.Close)
+
+	storage, err := NewHTTP(&config.Config{
+		DefaultArtifactRoot:   redirectServer.URL + "/artifacts",
+		ArtifactsDestination:  server.URL + "/artifacts",
+		HTTPArtifactsUsername: "user",
+		HTTPArtifactsPassword: "password",
+	})
+	require.Nil(t, err)
+
+	tests := []struct {
+		name        string
+		artifactURI string
+		error       string
+	}{
+		{
+			name:        "Unauthorized",
+			artifactURI: server.URL + "/artifacts",
+			error:       "unexpected response status: 401 Unauthorized",
+		},
+		{
+			name:        "ForeignHost",
+			artifactURI: foreignServer.URL + "/artifacts",
+			error: fmt.Sprintf(
+				"artifact uri %s/artifacts/artifact.file is outside of the configured artifact roots", foreignServer.URL,
+			),
+		},
+		{
+			name:        "RedirectToForeignHost",
+			artifactURI: redirectServer.URL + "/artifacts",
+			error: fmt.Sprintf(
+				`error doing GET request: Get "%s/artifact.file": redirect to %s/artifact.file `+
+					`is outside of the configured artifact roots`,
+				foreignServer.URL, foreignServer.URL,
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := storage.Get(context.Background(), tt.artifactURI, "artifact.file")
+			assert.EqualError(t, err, tt.error)
+		})
+	}
+	// the foreign host doesn't get any request.
+	assert.Zero(t, requests)
+}
+
+func TestArtifactStorageFactory_GetStorage_HTTP(t *testing.T) {
+	factory, err := NewArtifactStorageFactory(&config.Config{
+		DefaultArtifactRoot: "s3://bucket/artifacts",
+	})
+	require.Nil(t, err)
+	_, err = factory.GetStorage(context.Background(), "http://169.254.169.254/latest/meta-data")
+	assert.EqualError(
+		t, err, "artifact uri http://169.254.169.254/latest/meta-data is outside of the configured artifact roots",
+	)
+
+	factory, err = NewArtifactStorageFactory(&config.Config{
+		DefaultArtifactRoot: "https://artifacts.example.com/artifacts",
+	})
+	require.Nil(t, err)
+	storage, err := factory.GetStorage(context.Background(), "https://ARTIFACTS.example.com/artifacts/1/id/artifacts")
+	require.Nil(t, err)
+	assert.IsType(t, &HTTP{}, storage)
+	_, err = factory.GetStorage(context.Background(), "http://artifacts.example.com/artifacts/1/id/artifacts")
+	assert.EqualError(
+		t, err, "artifact uri http://artifacts.example.com/artifacts/1/id/artifacts is outside of the configured "+
+			"artifact roots",
+	)
+}
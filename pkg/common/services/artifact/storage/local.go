@@ -95,3 +95,67 @@ func (s Local) Get(ctx context.Context, artifactURI, path string) (io.ReadCloser
 
 	return file, nil
 }
+
+// Put writes content of provided reader into the file at the storage location.
+func (s Local) Put(ctx context.Context, artifactURI, path string, reader io.Reader) error {
+	// 1. trim the `file://` prefix if it exists.
+	artifactURI = strings.TrimPrefix(artifactURI, "file://")
+
+	// 2. process `path` parameter and create parent directories.
+	absPath := filepath.Join(artifactURI, path)
+	if err := os.MkdirAll(filepath.Dir(absPath), os.ModePerm); err != nil {
+		return eris.Wrap(err, "error creating artifact directory")
+	}
+
+	// 3. write the file.
+	// artifactURI and path are validated by the caller
+	// #nosec G304
+	file, err := os.Create(absPath)
+	if err != nil {
+		return eris.Wrap(err, "unable to create file")
+	}
+	//nolint:errcheck
+	defer file.Close()
+
+	if _, err := io.Copy(file, reader); err != nil {
+		return eris.Wrap(err, "error writing file content")
+	}
+	return nil
+}
+
+// Delete removes file or directory at the storage location.
+func (s Local) Delete(ctx context.Context, artifactURI, path string) error {
+	// 1. trim the `file://` prefix if it exists.
+	artifactURI = strings.TrimPrefix(artifactURI, "file://")
+
+	// 2. process `path` parameter and check that it exists.
+	absPath := filepath.Join(artifactURI, path)
+	if _, err := os.Stat(absPath); err != nil {
+		return eris.Wrap(err, "path could not be found")
+	}
+
+	// 3. remove the file or the whole directory.
+	if err := os.RemoveAll(absPath); err != nil {
+		return eris.Wrap(err, "unable to delete path")
+	}
+	return nil
+}
+
+// CreateMultipartUpload implements ArtifactStorageProvider interface.
+func (s Local) CreateMultipartUpload(
+	ctx context.Context, artifactURI, path string, numParts int32,
+) (*MultipartUpload, error) {
+	return nil, ErrMultipartUploadNotSupported
+}
+
+// CompleteMultipartUpload implements ArtifactStorageProvider interface.
+func (s Local) CompleteMultipartUpload(
+	ctx context.Context, artifactURI, path, uploadID string, parts []MultipartUploadPart,
+) error {
+	return ErrMultipartUploadNotSupported
+}
+
+// AbortMultipartUpload implements ArtifactStorageProvider interface.
+func (s Local) AbortMultipartUpload(ctx context.Context, artifactURI, path, uploadID string) error {
+	return ErrMultipartUploadNotSupported
+}
@@ -5,6 +5,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -132,3 +133,45 @@ func TestLocal_ListArtifacts_Ok(t *testing.T) {
 		})
 	}
 }
+
+func TestLocal_PutArtifact_Ok(t *testing.T) {
+	// setup
+	runArtifactRoot := t.TempDir()
+
+	// invoke
+	storage, err := NewLocal(nil)
+	require.Nil(t, err)
+
+	err = storage.Put(
+		context.Background(), "file://"+runArtifactRoot, "subdir/file.txt", strings.NewReader("artifact content"),
+	)
+	require.Nil(t, err)
+
+	// verify
+	// #nosec G304
+	content, err := os.ReadFile(filepath.Join(runArtifactRoot, "subdir", "file.txt"))
+	require.Nil(t, err)
+	assert.Equal(t, "artifact content", string(content))
+}
+
+func TestLocal_DeleteArtifact_Ok(t *testing.T) {
+	// setup
+	runArtifactRoot := t.TempDir()
+	err := os.MkdirAll(filepath.Join(runArtifactRoot, "subdir"), os.ModePerm)
+	require.Nil(t, err)
+	err = os.WriteFile(filepath.Join(runArtifactRoot, "subdir", "file.txt"), []byte("content"), 0o600)
+	require.Nil(t, err)
+
+	// invoke
+	storage, err := NewLocal(nil)
+	require.Nil(t, err)
+
+	require.Nil(t, storage.Delete(context.Background(), runArtifactRoot, "subdir"))
+
+	// verify
+	_, err = os.Stat(filepath.Join(runArtifactRoot, "subdir"))
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	err = storage.Delete(context.Background(), runArtifactRoot, "subdir")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+// MlflowArtifactsStorageName is a name of the storage proxied by the tracking server.
+const (
+	MlflowArtifactsStorageName = "mlflow-artifacts"
+	MlflowArtifactsRootURI     = "mlflow-artifacts:/"
+)
+
+// namespaceContextKey is a context key of the namespace code, which scopes `mlflow-artifacts` uris.
+type namespaceContextKey struct{}
+
+// WithNamespace returns the context, which scopes `mlflow-artifacts` uris by provided namespace code.
+func WithNamespace(ctx context.Context, namespaceCode string) context.Context {
+	return context.WithValue(ctx, namespaceContextKey{}, namespaceCode)
+}
+
+// MlflowArtifacts represents adapter which resolves `mlflow-artifacts` uris
+// against the configured artifacts destination and delegates to the underlying storage.
+// Every namespace has its own directory `<destination>/<namespace code>` inside the artifacts destination,
+// so the artifacts of one namespace can't be accessed through the other namespace.
+type MlflowArtifacts struct {
+	destination string
+	storage     ArtifactStorageProvider
+}
+
+// NewMlflowArtifacts creates new MlflowArtifacts storage instance.
+func NewMlflowArtifacts(
+	ctx context.Context, factory ArtifactStorageFactoryProvider, config *config.Config,
+) (*MlflowArtifacts, error) {
+	if !config.ServeArtifacts {
+		return nil, eris.New("artifacts proxy is disabled, use 'serve-artifacts' flag to enable it")
+	}
+	storage, err := factory.GetStorage(ctx, config.ArtifactsDestination)
+	if err != nil {
+		return nil, eris.Wrap(err, "error initializing artifacts destination storage")
+	}
+	return &MlflowArtifacts{
+		destination: strings.TrimSuffix(config.ArtifactsDestination, "/"),
+		storage:     storage,
+	}, nil
+}
+
+// List implements ArtifactStorageProvider interface.
+func (s MlflowArtifacts) List(ctx context.Context, artifactURI, path string) ([]ArtifactObject, error) {
+	artifactURI, err := s.resolveURI(ctx, artifactURI, path)
+	if err != nil {
+		return nil, err
+	}
+	return s.storage.List(ctx, artifactURI, path)
+}
+
+// Get implements ArtifactStorageProvider interface.
+func (s MlflowArtifacts) Get(ctx context.Context, artifactURI, path string) (io.ReadCloser, error) {
+	artifactURI, err := s.resolveURI(ctx, artifactURI, path)
+	if err != nil {
+		return nil, err
+	}
+	return s.storage.Get(ctx, artifactURI, path)
+}
+
+// Put implements ArtifactStorageProvider interface.
+func (s MlflowArtifacts) Put(ctx context.Context, artifactURI, path string, reader io.Reader) error {
+	artifactURI, err := s.resolveURI(ctx, artifactURI, path)
+	if err != nil {
+		return err
+	}
+	return s.storage.Put(ctx, artifactURI, path, reader)
+}
+
+// Delete implements ArtifactStorageProvider interface.
+func (s MlflowArtifacts) Delete(ctx context.Context, artifactURI, path string) error {
+	artifactURI, err := s.resolveURI(ctx, artifactURI, path)
+	if err != nil {
+		return err
+	}
+	return s.storage.Delete(ctx, artifactURI, path)
+}
+
+// CreateMultipartUpload implements ArtifactStorageProvider interface.
+func (s MlflowArtifacts) CreateMultipartUpload(
+	ctx context.Context, artifactURI, path string, numParts int32,
+) (*MultipartUpload, error) {
+	artifactURI, err := s.resolveURI(ctx, artifactURI, path)
+	if err != nil {
+		return nil, err
+	}
+	return s.storage.CreateMultipartUpload(ctx, artifactURI, path, numParts)
+}
+
+// CompleteMultipartUpload implements ArtifactStorageProvider interface.
+func (s MlflowArtifacts) CompleteMultipartUpload(
+	ctx context.Context, artifactURI, path, uploadID string, parts []MultipartUploadPart,
+) error {
+	artifactURI, err := s.resolveURI(ctx, artifactURI, path)
+	if err != nil {
+		return err
+	}
+	return s.storage.CompleteMultipartUpload(ctx, artifactURI, path, uploadID, parts)
+}
+
+// AbortMultipartUpload implements ArtifactStorageProvider interface.
+func (s MlflowArtifacts) AbortMultipartUpload(ctx context.Context, artifactURI, path, uploadID string) error {
+	artifactURI, err := s.resolveURI(ctx, artifactURI, path)
+	if err != nil {
+		return err
+	}
+	return s.storage.AbortMultipartUpload(ctx, artifactURI, path, uploadID)
+}
+
+// resolveURI converts `mlflow-artifacts:/path` or `mlflow-artifacts://host/path` uri
+// into the uri inside the namespace directory of the artifacts destination.
+func (s MlflowArtifacts) resolveURI(ctx context.Context, artifactURI, artifactPath string) (string, error) {
+	namespaceCode, ok := ctx.Value(namespaceContextKey{}).(string)
+	if !ok || namespaceCode == "" {
+		return "", eris.New("namespace is required to resolve mlflow-artifacts uri")
+	}
+	u, err := url.Parse(artifactURI)
+	if err != nil {
+		return "", eris.Wrap(err, "error parsing artifact uri")
+	}
+	if u.Scheme != MlflowArtifactsStorageName {
+		return "", eris.Errorf("unsupported schema has been provided: %s", u.Scheme)
+	}
+	if slices.Contains(strings.Split(artifactPath, "/"), "..") {
+		return "", eris.Errorf("invalid artifact path: %s", artifactPath)
+	}
+	// cleaning of the rooted path drops the leading `..` elements, so the uri stays inside the namespace.
+	uriPath := strings.TrimPrefix(path.Clean("/"+u.Path), "/")
+	return s.destination + "/" + namespaceCode + "/" + uriPath, nil
+}
@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+func TestMlflowArtifacts_Get_Ok(t *testing.T) {
+	tests := []struct {
+		name        string
+		artifactURI string
+	}{
+		{
+			name:        "URIWithoutHost",
+			artifactURI: "mlflow-artifacts:/1/id/artifacts",
+		},
+		{
+			name:        "URIWithHost",
+			artifactURI: "mlflow-artifacts://localhost:5000/1/id/artifacts",
+		},
+		{
+			name:        "URIOutsideNamespace",
+			artifactURI: "mlflow-artifacts:/../../1/id/artifacts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			destinationStorage := MockArtifactStorageProvider{}
+			destinationStorage.On(
+				"Get", WithNamespace(context.TODO(), "ns1"), "s3://bucket/prefix/ns1/1/id/artifacts", "file.txt",
+			).Return(io.NopCloser(strings.NewReader("content")), nil)
+
+			factory := MockArtifactStorageFactoryProvider{}
+			factory.On("GetStorage", context.TODO(), "s3://bucket/prefix/").Return(&destinationStorage, nil)
+
+			storage, err := NewMlflowArtifacts(context.TODO(), &factory, &config.Config{
+				ServeArtifacts:       true,
+				ArtifactsDestination: "s3://bucket/prefix/",
+			})
+			require.Nil(t, err)
+
+			reader, err := storage.Get(WithNamespace(context.TODO(), "ns1"), tt.artifactURI, "file.txt")
+			require.Nil(t, err)
+			content, err := io.ReadAll(reader)
+			require.Nil(t, err)
+			assert.Equal(t, "content", string(content))
+		})
+	}
+}
+
+func TestMlflowArtifacts_Error(t *testing.T) {
+	_, err := NewMlflowArtifacts(context.TODO(), &MockArtifactStorageFactoryProvider{}, &config.Config{})
+	assert.EqualError(t, err, "artifacts proxy is disabled, use 'serve-artifacts' flag to enable it")
+}
+
+func TestMlflowArtifacts_Get_Error(t *testing.T) {
+	tests := []struct {
+		name  string
+		ctx   context.Context
+		path  string
+		error string
+	}{
+		{
+			name:  "MissingNamespace",
+			ctx:   context.TODO(),
+			path:  "file.txt",
+			error: "namespace is required to resolve mlflow-artifacts uri",
+		},
+		{
+			name:  "PathOutsideNamespace",
+			ctx:   WithNamespace(context.TODO(), "ns1"),
+			path:  "../ns2/file.txt",
+			error: "invalid artifact path: ../ns2/file.txt",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := MockArtifactStorageFactoryProvider{}
+			factory.On(
+				"GetStorage", context.TODO(), "s3://bucket/prefix",
+			).Return(&MockArtifactStorageProvider{}, nil)
+
+			storage, err := NewMlflowArtifacts(context.TODO(), &factory, &config.Config{
+				ServeArtifacts:       true,
+				ArtifactsDestination: "s3://bucket/prefix",
+			})
+			require.Nil(t, err)
+
+			_, err = storage.Get(tt.ctx, "mlflow-artifacts:/1/id/artifacts", tt.path)
+			assert.EqualError(t, err, tt.error)
+		})
+	}
+}
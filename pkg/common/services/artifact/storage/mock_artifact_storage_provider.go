@@ -14,6 +14,74 @@ type MockArtifactStorageProvider struct {
 	mock.Mock
 }
 
+// AbortMultipartUpload provides a mock function with given fields: ctx, artifactURI, path, uploadID
+func (_m *MockArtifactStorageProvider) AbortMultipartUpload(ctx context.Context, artifactURI string, path string, uploadID string) error {
+	ret := _m.Called(ctx, artifactURI, path, uploadID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, artifactURI, path, uploadID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CompleteMultipartUpload provides a mock function with given fields: ctx, artifactURI, path, uploadID, parts
+func (_m *MockArtifactStorageProvider) CompleteMultipartUpload(ctx context.Context, artifactURI string, path string, uploadID string, parts []MultipartUploadPart) error {
+	ret := _m.Called(ctx, artifactURI, path, uploadID, parts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []MultipartUploadPart) error); ok {
+		r0 = rf(ctx, artifactURI, path, uploadID, parts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateMultipartUpload provides a mock function with given fields: ctx, artifactURI, path, numParts
+func (_m *MockArtifactStorageProvider) CreateMultipartUpload(ctx context.Context, artifactURI string, path string, numParts int32) (*MultipartUpload, error) {
+	ret := _m.Called(ctx, artifactURI, path, numParts)
+
+	var r0 *MultipartUpload
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int32) (*MultipartUpload, error)); ok {
+		return rf(ctx, artifactURI, path, numParts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int32) *MultipartUpload); ok {
+		r0 = rf(ctx, artifactURI, path, numParts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*MultipartUpload)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int32) error); ok {
+		r1 = rf(ctx, artifactURI, path, numParts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, artifactURI, path
+func (_m *MockArtifactStorageProvider) Delete(ctx context.Context, artifactURI string, path string) error {
+	ret := _m.Called(ctx, artifactURI, path)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, artifactURI, path)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: ctx, artifactURI, path
 func (_m *MockArtifactStorageProvider) Get(ctx context.Context, artifactURI string, path string) (io.ReadCloser, error) {
 	ret := _m.Called(ctx, artifactURI, path)
@@ -66,6 +134,20 @@ func (_m *MockArtifactStorageProvider) List(ctx context.Context, artifactURI str
 	return r0, r1
 }
 
+// Put provides a mock function with given fields: ctx, artifactURI, path, reader
+func (_m *MockArtifactStorageProvider) Put(ctx context.Context, artifactURI string, path string, reader io.Reader) error {
+	ret := _m.Called(ctx, artifactURI, path, reader)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, io.Reader) error); ok {
+		r0 = rf(ctx, artifactURI, path, reader)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewMockArtifactStorageProvider creates a new instance of MockArtifactStorageProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockArtifactStorageProvider(t interface {
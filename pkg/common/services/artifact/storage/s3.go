@@ -6,6 +6,8 @@ import (
 	"io"
 	"io/fs"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsConfig "github.com/aws/aws-sdk-go-v2/config"
@@ -22,9 +24,17 @@ const (
 	S3StorageName = "s3"
 )
 
+const (
+	// s3PresignedURLExpiration is a lifetime of presigned multipart upload urls.
+	s3PresignedURLExpiration = 1 * time.Hour
+	// s3DeleteObjectsBatchSize is a maximum number of keys accepted by a single `DeleteObjects` request.
+	s3DeleteObjectsBatchSize = 1000
+)
+
 // S3 represents S3 adapter to work with artifacts.
 type S3 struct {
-	client *s3.Client
+	client        *s3.Client
+	presignClient *s3.PresignClient
 }
 
 // NewS3 creates new S3 instance.
@@ -44,8 +54,10 @@ func NewS3(ctx context.Context, config *config.Config) (*S3, error) {
 		return nil, eris.Wrap(err, "error loading configuration for S3 client")
 	}
 
+	client := s3.NewFromConfig(cfg, clientOptions...)
 	return &S3{
-		s3.NewFromConfig(cfg, clientOptions...),
+		client:        client,
+		presignClient: s3.NewPresignClient(client, s3.WithPresignExpires(s3PresignedURLExpiration)),
 	}, nil
 }
 
@@ -133,3 +145,161 @@ func (s S3) Get(ctx context.Context, artifactURI, path string) (io.ReadCloser, e
 
 	return resp.Body, nil
 }
+
+// Put uploads content of provided reader as an object at the storage location.
+func (s S3) Put(ctx context.Context, artifactURI, path string, reader io.Reader) error {
+	bucketName, prefix, err := ExtractBucketAndPrefix(artifactURI)
+	if err != nil {
+		return eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+
+	if _, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(filepath.Join(prefix, path)),
+		Body:   reader,
+	}); err != nil {
+		return eris.Wrap(err, "error putting object")
+	}
+	return nil
+}
+
+// Delete deletes object or all the objects under the `directory` at the storage location.
+func (s S3) Delete(ctx context.Context, artifactURI, path string) error {
+	// 1. create s3 request input.
+	bucketName, prefix, err := ExtractBucketAndPrefix(artifactURI)
+	if err != nil {
+		return eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+	key := filepath.Join(prefix, path)
+
+	// 2. collect the object itself and all the nested objects.
+	var objects []types.ObjectIdentifier
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucketName),
+		Prefix: aws.String(key),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return eris.Wrap(err, "error getting s3 page objects")
+		}
+		for _, object := range page.Contents {
+			if *object.Key == key || strings.HasPrefix(*object.Key, key+"/") {
+				objects = append(objects, types.ObjectIdentifier{Key: object.Key})
+			}
+		}
+	}
+	if len(objects) == 0 {
+		return eris.Wrap(fs.ErrNotExist, "object does not exist")
+	}
+
+	// 3. delete objects in batches.
+	log.Debugf("deleting %d objects from S3 storage for bucket %q and key %q", len(objects), bucketName, key)
+	for start := 0; start < len(objects); start += s3DeleteObjectsBatchSize {
+		end := min(start+s3DeleteObjectsBatchSize, len(objects))
+		if _, err := s.client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(bucketName),
+			Delete: &types.Delete{
+				Objects: objects[start:end],
+				Quiet:   aws.Bool(true),
+			},
+		}); err != nil {
+			return eris.Wrap(err, "error deleting objects")
+		}
+	}
+	return nil
+}
+
+// CreateMultipartUpload initiates s3 multipart upload and presigns `UploadPart` request for each part.
+func (s S3) CreateMultipartUpload(
+	ctx context.Context, artifactURI, path string, numParts int32,
+) (*MultipartUpload, error) {
+	bucketName, prefix, err := ExtractBucketAndPrefix(artifactURI)
+	if err != nil {
+		return nil, eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+	key := filepath.Join(prefix, path)
+
+	resp, err := s.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, eris.Wrap(err, "error creating multipart upload")
+	}
+
+	upload := MultipartUpload{
+		UploadID:    *resp.UploadId,
+		Credentials: make([]MultipartUploadCredential, numParts),
+	}
+	for i := int32(0); i < numParts; i++ {
+		partNumber := i + 1
+		presigned, err := s.presignClient.PresignUploadPart(ctx, &s3.UploadPartInput{
+			Bucket:     aws.String(bucketName),
+			Key:        aws.String(key),
+			UploadId:   resp.UploadId,
+			PartNumber: aws.Int32(partNumber),
+		})
+		if err != nil {
+			return nil, eris.Wrapf(err, "error presigning upload part %d", partNumber)
+		}
+		headers := make(map[string]string, len(presigned.SignedHeader))
+		for name := range presigned.SignedHeader {
+			if !strings.EqualFold(name, "host") {
+				headers[name] = presigned.SignedHeader.Get(name)
+			}
+		}
+		upload.Credentials[i] = MultipartUploadCredential{
+			URL:        presigned.URL,
+			Headers:    headers,
+			PartNumber: partNumber,
+		}
+	}
+	return &upload, nil
+}
+
+// CompleteMultipartUpload completes s3 multipart upload.
+func (s S3) CompleteMultipartUpload(
+	ctx context.Context, artifactURI, path, uploadID string, parts []MultipartUploadPart,
+) error {
+	bucketName, prefix, err := ExtractBucketAndPrefix(artifactURI)
+	if err != nil {
+		return eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+
+	completedParts := make([]types.CompletedPart, len(parts))
+	for i, part := range parts {
+		completedParts[i] = types.CompletedPart{
+			ETag:       aws.String(part.ETag),
+			PartNumber: aws.Int32(part.PartNumber),
+		}
+	}
+	if _, err := s.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:   aws.String(bucketName),
+		Key:      aws.String(filepath.Join(prefix, path)),
+		UploadId: aws.String(uploadID),
+		MultipartUpload: &types.CompletedMultipartUpload{
+			Parts: completedParts,
+		},
+	}); err != nil {
+		return eris.Wrap(err, "error completing multipart upload")
+	}
+	return nil
+}
+
+// AbortMultipartUpload aborts s3 multipart upload.
+func (s S3) AbortMultipartUpload(ctx context.Context, artifactURI, path, uploadID string) error {
+	bucketName, prefix, err := ExtractBucketAndPrefix(artifactURI)
+	if err != nil {
+		return eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+
+	if _, err := s.client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(bucketName),
+		Key:      aws.String(filepath.Join(prefix, path)),
+		UploadId: aws.String(uploadID),
+	}); err != nil {
+		return eris.Wrap(err, "error aborting multipart upload")
+	}
+	return nil
+}
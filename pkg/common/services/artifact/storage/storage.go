@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"net/url"
+	"slices"
 	"sync"
 
 	"github.com/rotisserie/eris"
@@ -11,6 +12,9 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/common/config"
 )
 
+// ErrMultipartUploadNotSupported is returned when the storage does not support multipart upload.
+var ErrMultipartUploadNotSupported = eris.New("multipart upload is not supported by the artifact storage")
+
 // ArtifactObject represents Artifact object agnostic to selected storage.
 type ArtifactObject struct {
 	Path  string
@@ -33,12 +37,46 @@ func (o ArtifactObject) IsDirectory() bool {
 	return o.IsDir
 }
 
+// MultipartUploadCredential represents presigned credentials to upload a single part of the object.
+type MultipartUploadCredential struct {
+	URL        string
+	Headers    map[string]string
+	PartNumber int32
+}
+
+// MultipartUpload represents an initiated multipart upload.
+type MultipartUpload struct {
+	UploadID    string
+	Credentials []MultipartUploadCredential
+}
+
+// MultipartUploadPart represents a single uploaded part of the object.
+type MultipartUploadPart struct {
+	URL        string
+	ETag       string
+	PartNumber int32
+}
+
 // ArtifactStorageProvider provides an interface to work with artifact storage.
 type ArtifactStorageProvider interface {
 	// Get returns an io.ReadCloser for specific artifact.
 	Get(ctx context.Context, artifactURI, path string) (io.ReadCloser, error)
 	// List lists all artifact objects under a provided path.
 	List(ctx context.Context, artifactURI, path string) ([]ArtifactObject, error)
+	// Put writes content of provided reader as an artifact object under a provided path.
+	Put(ctx context.Context, artifactURI, path string, reader io.Reader) error
+	// Delete deletes artifact object or all the artifact objects under a provided path.
+	Delete(ctx context.Context, artifactURI, path string) error
+	// CreateMultipartUpload initiates multipart upload and returns presigned credentials for each part.
+	CreateMultipartUpload(
+		ctx context.Context, artifactURI, path string, numParts int32,
+	) (*MultipartUpload, error)
+	// CompleteMultipartUpload completes multipart upload by assembling the uploaded parts.
+	CompleteMultipartUpload(
+		ctx context.Context, artifactURI, path, uploadID string, parts []MultipartUploadPart,
+	) error
+	// AbortMultipartUpload aborts multipart upload and cleans up the uploaded parts.
+	AbortMultipartUpload(ctx context.Context, artifactURI, path, uploadID string) error
 }
 
 // ArtifactStorageFactoryProvider provides an interface provider to work with Artifact Storage.
@@ -72,6 +110,12 @@ func (s *ArtifactStorageFactory) GetStorage(
 	}
 
 	storageName := u.Scheme
+	// HTTP storage serves the configured artifact roots only, otherwise any uri provided by the client
+	// would make the server request internal hosts.
+	if (storageName == HTTPStorageName || storageName == HTTPSStorageName) &&
+		!slices.Contains(httpOrigins(s.config), httpOrigin(u)) {
+		return nil, eris.Errorf("artifact uri %s is outside of the configured artifact roots", runArtifactPath)
+	}
 	if storage, ok := s.storageList.Load(storageName); ok {
 		return storage.(ArtifactStorageProvider), nil
 	}
@@ -90,6 +134,24 @@ func (s *ArtifactStorageFactory) GetStorage(
 		if err != nil {
 			return nil, eris.Wrap(err, "error initializing s3 artifact storage")
 		}
+	case AzureBlobStorageName, AzureBlobInsecureStorageName, AzureDataLakeStorageName, AzureDataLakeInsecureStorageName:
+		var err error
+		storage, err = NewAzure(s.config)
+		if err != nil {
+			return nil, eris.Wrap(err, "error initializing azure artifact storage")
+		}
+	case HTTPStorageName, HTTPSStorageName:
+		var err error
+		storage, err = NewHTTP(s.config)
+		if err != nil {
+			return nil, eris.Wrap(err, "error initializing http artifact storage")
+		}
+	case MlflowArtifactsStorageName:
+		var err error
+		storage, err = NewMlflowArtifacts(ctx, s, s.config)
+		if err != nil {
+			return nil, eris.Wrap(err, "error initializing mlflow-artifacts artifact storage")
+		}
 	case "", LocalStorageName:
 		var err error
 		storage, err = NewLocal(s.config)
@@ -28,6 +28,72 @@ func ValidateGetArtifactRequest(req *request.GetArtifactRequest) error {
 	return validatePath(req.Path)
 }
 
+// ValidateDownloadArtifactRequest validates `GET /mlflow-artifacts/artifacts/*` request.
+func ValidateDownloadArtifactRequest(req *request.DownloadArtifactRequest) error {
+	return validateRequiredPath(req.Path)
+}
+
+// ValidateUploadArtifactRequest validates `PUT /mlflow-artifacts/artifacts/*` request.
+func ValidateUploadArtifactRequest(req *request.UploadArtifactRequest) error {
+	return validateRequiredPath(req.Path)
+}
+
+// ValidateDeleteArtifactRequest validates `DELETE /mlflow-artifacts/artifacts/*` request.
+func ValidateDeleteArtifactRequest(req *request.DeleteArtifactRequest) error {
+	return validateRequiredPath(req.Path)
+}
+
+// ValidateListProxiedArtifactsRequest validates `GET /mlflow-artifacts/artifacts` request.
+func ValidateListProxiedArtifactsRequest(req *request.ListProxiedArtifactsRequest) error {
+	return validatePath(req.Path)
+}
+
+// ValidateCreateMultipartUploadRequest validates `POST /mlflow-artifacts/mpu/create/*` request.
+func ValidateCreateMultipartUploadRequest(req *request.CreateMultipartUploadRequest) error {
+	if req.Path == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'path'")
+	}
+	if req.NumParts <= 0 {
+		return api.NewInvalidParameterValueError(
+			"Invalid value for parameter 'num_parts' supplied. It must be positive, but got value %d", req.NumParts,
+		)
+	}
+	return validatePath(req.GetObjectPath())
+}
+
+// ValidateCompleteMultipartUploadRequest validates `POST /mlflow-artifacts/mpu/complete/*` request.
+func ValidateCompleteMultipartUploadRequest(req *request.CompleteMultipartUploadRequest) error {
+	if req.Path == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'path'")
+	}
+	if req.UploadID == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'upload_id'")
+	}
+	if len(req.Parts) == 0 {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'parts'")
+	}
+	return validatePath(req.GetObjectPath())
+}
+
+// ValidateAbortMultipartUploadRequest validates `POST /mlflow-artifacts/mpu/abort/*` request.
+func ValidateAbortMultipartUploadRequest(req *request.AbortMultipartUploadRequest) error {
+	if req.Path == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'path'")
+	}
+	if req.UploadID == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'upload_id'")
+	}
+	return validatePath(req.GetObjectPath())
+}
+
+// validateRequiredPath validates required path parameter.
+func validateRequiredPath(path string) error {
+	if path == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'path'")
+	}
+	return validatePath(path)
+}
+
 // validatePath validates path parameter.
 func validatePath(path string) error {
 	parsedUrl, err := url.Parse(path)
@@ -300,3 +300,41 @@ func TestValidateGetArtifactRequest_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCreateMultipartUploadRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.CreateMultipartUploadRequest
+	}{
+		{
+			name:    "EmptyPath",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'path'"),
+			request: &request.CreateMultipartUploadRequest{},
+		},
+		{
+			name: "IncorrectNumParts",
+			error: api.NewInvalidParameterValueError(
+				"Invalid value for parameter 'num_parts' supplied. It must be positive, but got value 0",
+			),
+			request: &request.CreateMultipartUploadRequest{
+				Path: "file.txt",
+			},
+		},
+		{
+			name:  "ArtifactPathContains2Dots",
+			error: api.NewInvalidParameterValueError("Invalid path"),
+			request: &request.CreateMultipartUploadRequest{
+				ArtifactPath: "../dir",
+				Path:         "file.txt",
+				NumParts:     1,
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.error, ValidateCreateMultipartUploadRequest(tt.request))
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Package audit writes audit events in background, so the recorded requests don't wait for the database.
+package audit
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// BufferSize is the number of audit events buffered by the server's Writer.
+const BufferSize = 10000
+
+// WriterProvider provides an interface to record audit events.
+type WriterProvider interface {
+	// Write records the audit event. The event is written to the database later.
+	Write(event *models.AuditEvent)
+	// Drain stops the writer and writes all the buffered events to the database.
+	Drain(ctx context.Context) error
+}
+
+// Writer buffers audit events and writes them to the database in background. When the buffer
+// is full or the writer is drained, events are written by the caller, so they are never dropped.
+type Writer struct {
+	mu                   sync.Mutex
+	closed               bool
+	events               chan *models.AuditEvent
+	stopped              chan struct{}
+	auditEventRepository repositories.AuditEventRepositoryProvider
+}
+
+// NewWriter creates new Writer instance, which buffers up to size events.
+func NewWriter(auditEventRepository repositories.AuditEventRepositoryProvider, size int) *Writer {
+	return &Writer{
+		events:               make(chan *models.AuditEvent, size),
+		stopped:              make(chan struct{}),
+		auditEventRepository: auditEventRepository,
+	}
+}
+
+// Run runs the writer background job, which writes the buffered events.
+func (w *Writer) Run() {
+	go func() {
+		defer close(w.stopped)
+		for event := range w.events {
+			w.create(event)
+		}
+		log.Debug("audit events writer stopped. exiting.")
+	}()
+}
+
+// Write records the audit event. The event is written to the database later.
+func (w *Writer) Write(event *models.AuditEvent) {
+	w.mu.Lock()
+	if !w.closed {
+		select {
+		case w.events <- event:
+			w.mu.Unlock()
+			return
+		default:
+		}
+	}
+	w.mu.Unlock()
+	w.create(event)
+}
+
+// Drain stops the writer and writes all the buffered events to the database.
+func (w *Writer) Drain(ctx context.Context) error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.events)
+	}
+	w.mu.Unlock()
+
+	select {
+	case <-w.stopped:
+		return nil
+	case <-ctx.Done():
+		return eris.Wrapf(ctx.Err(), "error writing %d buffered audit events", len(w.events))
+	}
+}
+
+// create writes the event to the database.
+func (w *Writer) create(event *models.AuditEvent) {
+	if err := w.auditEventRepository.Create(context.Background(), event); err != nil {
+		log.Errorf("error recording audit event of %s %s: %+v", event.Method, event.Path, err)
+	}
+}
@@ -0,0 +1,70 @@
+package audit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// inMemoryRepository is an in-memory implementation of repositories.AuditEventRepositoryProvider.
+type inMemoryRepository struct {
+	repositories.AuditEventRepositoryProvider
+	mu     sync.Mutex
+	paths  []string
+	writes chan struct{}
+}
+
+func (r *inMemoryRepository) Create(ctx context.Context, event *models.AuditEvent) error {
+	if r.writes != nil {
+		<-r.writes
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.paths = append(r.paths, event.Path)
+	return nil
+}
+
+func (r *inMemoryRepository) getPaths() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string{}, r.paths...)
+}
+
+func TestWriter_Ok(t *testing.T) {
+	repository := &inMemoryRepository{}
+	writer := NewWriter(repository, 1)
+
+	// the first event is buffered, and the buffer is full, so the second one is written by the caller.
+	writer.Write(&models.AuditEvent{Path: "/1"})
+	writer.Write(&models.AuditEvent{Path: "/2"})
+	assert.Equal(t, []string{"/2"}, repository.getPaths())
+
+	// the buffered events are written by the background job.
+	writer.Run()
+	require.Nil(t, writer.Drain(context.Background()))
+	assert.Equal(t, []string{"/2", "/1"}, repository.getPaths())
+
+	// the drained writer writes the events by the caller.
+	writer.Write(&models.AuditEvent{Path: "/3"})
+	assert.Equal(t, []string{"/2", "/1", "/3"}, repository.getPaths())
+}
+
+func TestWriter_Drain_Error(t *testing.T) {
+	repository := &inMemoryRepository{writes: make(chan struct{})}
+	writer := NewWriter(repository, 1)
+	writer.Run()
+	writer.Write(&models.AuditEvent{Path: "/1"})
+
+	// the event isn't written before the timeout.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, writer.Drain(ctx), context.DeadlineExceeded)
+	close(repository.writes)
+}
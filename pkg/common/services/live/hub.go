@@ -0,0 +1,160 @@
+// Package live delivers run updates to the clients subscribed to them.
+package live
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao"
+	"github.com/G-Research/fasttrackml/pkg/common/events"
+)
+
+const (
+	// maxPayloadSize is a bit less than the maximum size of `postgres` notification payload.
+	maxPayloadSize = 7900
+	// subscriptionBufferSize is a number of events buffered for each subscription. Events are
+	// dropped for the subscriptions which do not keep up with them.
+	subscriptionBufferSize = 256
+)
+
+// HubProvider provides an interface to publish and subscribe to run updates.
+type HubProvider interface {
+	// Publish publishes run event to all the subscribers.
+	Publish(event *events.RunEvent) error
+	// Subscribe creates new subscription to the events of the runs. Empty runIDs means all the runs of namespace.
+	Subscribe(namespaceID uint, runIDs []string) *Subscription
+	// Unsubscribe removes the subscription.
+	Unsubscribe(subscription *Subscription)
+}
+
+// Subscription represents subscription to the events of the runs.
+type Subscription struct {
+	namespaceID uint
+	runIDs      map[string]struct{}
+	events      chan events.RunEvent
+}
+
+// Events returns channel with the incoming events.
+func (s *Subscription) Events() <-chan events.RunEvent {
+	return s.events
+}
+
+// matches checks that the event belongs to the subscription.
+func (s *Subscription) matches(event *events.RunEvent) bool {
+	if event.NamespaceID != s.namespaceID {
+		return false
+	}
+	if len(s.runIDs) == 0 {
+		return true
+	}
+	_, ok := s.runIDs[event.RunID]
+	return ok
+}
+
+// Hub delivers run events received from database event listener to the subscriptions.
+type Hub struct {
+	mu            sync.RWMutex
+	listener      dao.EventListenerProvider
+	subscriptions map[*Subscription]struct{}
+}
+
+// NewHub creates new Hub instance.
+func NewHub(ctx context.Context, listener dao.EventListenerProvider) *Hub {
+	hub := Hub{
+		listener:      listener,
+		subscriptions: make(map[*Subscription]struct{}),
+	}
+
+	ch := make(chan string)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case data := <-ch:
+				if err := hub.dispatch(data); err != nil {
+					log.Errorf(`error processing incoming run event: %s, error: %+v`, data, err)
+				}
+			}
+		}
+	}()
+
+	// subscribe to incoming events.
+	listener.Subscribe(ch)
+
+	return &hub
+}
+
+// Publish publishes run event to all the subscribers. Metric events which do not fit
+// into a single notification are split into several ones.
+func (h *Hub) Publish(event *events.RunEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return eris.Wrap(err, "error serializing RunEvent event")
+	}
+	if len(data) > maxPayloadSize {
+		if len(event.Metrics) < 2 {
+			return eris.Errorf("event of run '%s' exceeds maximum size of %d bytes", event.RunID, maxPayloadSize)
+		}
+		head, tail := *event, *event
+		head.Metrics, tail.Metrics = event.Metrics[:len(event.Metrics)/2], event.Metrics[len(event.Metrics)/2:]
+		if err := h.Publish(&head); err != nil {
+			return err
+		}
+		return h.Publish(&tail)
+	}
+	if err := h.listener.Notify(string(data)); err != nil {
+		return eris.Wrap(err, "error sending run event")
+	}
+	return nil
+}
+
+// Subscribe creates new subscription to the events of the runs. Empty runIDs means all the runs of namespace.
+func (h *Hub) Subscribe(namespaceID uint, runIDs []string) *Subscription {
+	subscription := Subscription{
+		namespaceID: namespaceID,
+		runIDs:      make(map[string]struct{}, len(runIDs)),
+		events:      make(chan events.RunEvent, subscriptionBufferSize),
+	}
+	for _, runID := range runIDs {
+		subscription.runIDs[runID] = struct{}{}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subscriptions[&subscription] = struct{}{}
+	return &subscription
+}
+
+// Unsubscribe removes the subscription.
+func (h *Hub) Unsubscribe(subscription *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subscriptions, subscription)
+}
+
+// dispatch delivers incoming event to the matching subscriptions.
+func (h *Hub) dispatch(data string) error {
+	var event events.RunEvent
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		return eris.Wrap(err, "error unmarshaling RunEvent event")
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for subscription := range h.subscriptions {
+		if !subscription.matches(&event) {
+			continue
+		}
+		select {
+		case subscription.events <- event:
+		default:
+			log.Warnf("live updates subscription does not keep up, dropping event of run '%s'", event.RunID)
+		}
+	}
+	return nil
+}
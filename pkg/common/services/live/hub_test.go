@@ -0,0 +1,151 @@
+package live
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/events"
+)
+
+// inProcessListener is an in-process implementation of dao.EventListenerProvider.
+type inProcessListener struct {
+	subscribers []chan<- string
+	payloads    []string
+}
+
+func (l *inProcessListener) Listen() {}
+
+func (l *inProcessListener) Subscribe(subscriber chan<- string) {
+	l.subscribers = append(l.subscribers, subscriber)
+}
+
+func (l *inProcessListener) Notify(payload string) error {
+	l.payloads = append(l.payloads, payload)
+	for _, ch := range l.subscribers {
+		ch <- payload
+	}
+	return nil
+}
+
+func (l *inProcessListener) GetChannelName() string {
+	return "test"
+}
+
+// receive returns the next event of the subscription or nil if there is no event.
+func receive(subscription *Subscription) *events.RunEvent {
+	select {
+	case event := <-subscription.Events():
+		return &event
+	case <-time.After(100 * time.Millisecond):
+		return nil
+	}
+}
+
+func TestHub_Ok(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	hub := NewHub(ctx, &inProcessListener{})
+
+	runSubscription := hub.Subscribe(1, []string{"run1"})
+	namespaceSubscription := hub.Subscribe(1, nil)
+	otherNamespaceSubscription := hub.Subscribe(2, nil)
+
+	require.Nil(t, hub.Publish(&events.RunEvent{
+		Type:        events.RunEventTypeStatus,
+		NamespaceID: 1,
+		RunID:       "run1",
+		Status:      models.StatusFinished,
+		EndTime:     123,
+	}))
+	event := receive(runSubscription)
+	require.NotNil(t, event)
+	assert.Equal(t, events.RunEvent{
+		Type:        events.RunEventTypeStatus,
+		NamespaceID: 1,
+		RunID:       "run1",
+		Status:      models.StatusFinished,
+		EndTime:     123,
+	}, *event)
+	assert.NotNil(t, receive(namespaceSubscription))
+	assert.Nil(t, receive(otherNamespaceSubscription))
+
+	// event of the other run is delivered only to the namespace subscription.
+	require.Nil(t, hub.Publish(&events.RunEvent{
+		Type:        events.RunEventTypeLog,
+		NamespaceID: 1,
+		RunID:       "run2",
+		Log:         "line",
+	}))
+	assert.Nil(t, receive(runSubscription))
+	event = receive(namespaceSubscription)
+	require.NotNil(t, event)
+	assert.Equal(t, "line", event.Log)
+
+	// removed subscription does not receive events anymore.
+	hub.Unsubscribe(namespaceSubscription)
+	require.Nil(t, hub.Publish(&events.RunEvent{
+		Type:        events.RunEventTypeLog,
+		NamespaceID: 1,
+		RunID:       "run2",
+		Log:         "line",
+	}))
+	assert.Nil(t, receive(namespaceSubscription))
+}
+
+func TestHub_Publish_SplitsMetrics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	listener := inProcessListener{}
+	hub := NewHub(ctx, &listener)
+	subscription := hub.Subscribe(1, nil)
+
+	metrics := make([]models.Metric, 100)
+	for i := range metrics {
+		metrics[i] = models.Metric{
+			Key:     "loss",
+			Value:   float64(i),
+			Step:    int64(i),
+			Iter:    int64(i + 1),
+			Context: models.Context{Json: []byte(`{"subset": "` + strings.Repeat("x", 200) + `"}`)},
+		}
+	}
+	event := events.NewRunMetricsEvent(1, "run1", metrics)
+	require.Nil(t, hub.Publish(&event))
+
+	assert.Greater(t, len(listener.payloads), 1)
+	for _, payload := range listener.payloads {
+		assert.LessOrEqual(t, len(payload), maxPayloadSize)
+	}
+	var iters []int64
+	for range listener.payloads {
+		received := receive(subscription)
+		require.NotNil(t, received)
+		for _, metric := range received.Metrics {
+			iters = append(iters, metric.Iter)
+		}
+	}
+	require.Len(t, iters, len(metrics))
+	for i, iter := range iters {
+		assert.Equal(t, int64(i+1), iter)
+	}
+}
+
+func TestHub_Publish_Error(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	hub := NewHub(ctx, &inProcessListener{})
+
+	err := hub.Publish(&events.RunEvent{
+		Type:        events.RunEventTypeLog,
+		NamespaceID: 1,
+		RunID:       "run1",
+		Log:         strings.Repeat("x", maxPayloadSize),
+	})
+	assert.EqualError(t, err, "event of run 'run1' exceeds maximum size of 7900 bytes")
+}
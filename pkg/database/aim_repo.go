@@ -0,0 +1,779 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim/encoding"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+	"github.com/G-Research/fasttrackml/pkg/common/rocksdb"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+)
+
+// Aim repository layout.
+const (
+	aimRepoDir             = ".aim"
+	aimRepoMetaDir         = "meta"
+	aimRepoSeqsDir         = "seqs"
+	aimRepoBlobsDir        = "BLOBS"
+	aimRepoChunksDir       = "chunks"
+	aimRepoStructuredDB    = "run_metadata.sqlite"
+	aimRepoDefaultExpName  = "default"
+	aimRepoImageType       = "aim.image"
+	aimRepoSystemParamsKey = "__system_params"
+	aimRepoCustomTypeKey   = "$type"
+	aimRepoMaxParamLength  = 500
+)
+
+// aimRepoRun represents run record of Aim structured db.
+type aimRepoRun struct {
+	Hash        string
+	Name        sql.NullString
+	Description sql.NullString
+	IsArchived  sql.NullBool
+	CreatedAt   sql.NullTime
+	UpdatedAt   sql.NullTime
+	FinalizedAt sql.NullTime
+	Experiment  sql.NullString
+	Tags        []aimRepoTag `gorm:"-"`
+}
+
+// aimRepoTag represents tag record of Aim structured db.
+type aimRepoTag struct {
+	Hash        string
+	Name        string
+	Color       sql.NullString
+	Description sql.NullString
+	IsArchived  sql.NullBool
+}
+
+// aimRepoSequence represents Aim sequence identified by the name and the context.
+type aimRepoSequence struct {
+	name    string
+	context map[string]any
+	values  []any
+	steps   []any
+	times   []any
+}
+
+// AimRepoImporter will handle the import of Aim repository (`.aim` directory) into destination db.
+type AimRepoImporter struct {
+	db                     *gorm.DB
+	path                   string
+	defaultArtifactRoot    string
+	namespaceCode          string
+	namespace              *Namespace
+	artifactStorageFactory storage.ArtifactStorageFactoryProvider
+	experiments            map[string]*Experiment
+	contextIDs             map[string]uint
+	sharedTags             map[string]*SharedTag
+}
+
+// NewAimRepoImporter initializes an AimRepoImporter.
+func NewAimRepoImporter(
+	db *gorm.DB, path, defaultArtifactRoot string, options ...func(importer *AimRepoImporter),
+) *AimRepoImporter {
+	importer := AimRepoImporter{
+		db:                  db,
+		path:                path,
+		defaultArtifactRoot: defaultArtifactRoot,
+		namespaceCode:       models.DefaultNamespaceCode,
+		experiments:         map[string]*Experiment{},
+		contextIDs:          map[string]uint{},
+		sharedTags:          map[string]*SharedTag{},
+	}
+	for _, o := range options {
+		o(&importer)
+	}
+	return &importer
+}
+
+// WithAimRepoNamespace sets AimRepoImporter destination Namespace.
+func WithAimRepoNamespace(namespace string) func(importer *AimRepoImporter) {
+	return func(s *AimRepoImporter) {
+		s.namespaceCode = namespace
+	}
+}
+
+// WithAimRepoArtifacts enables import of images using provided artifact storage factory.
+func WithAimRepoArtifacts(
+	artifactStorageFactory storage.ArtifactStorageFactoryProvider,
+) func(importer *AimRepoImporter) {
+	return func(s *AimRepoImporter) {
+		s.artifactStorageFactory = artifactStorageFactory
+	}
+}
+
+// Import copies runs of Aim repository into the destination namespace. Each run is imported
+// in its own transaction and runs which already exist in the destination db are skipped,
+// so the import could be resumed after the failure.
+func (s *AimRepoImporter) Import(ctx context.Context) error {
+	// 1. find the repository. both the repository root and `.aim` directory itself are accepted.
+	root, err := filepath.Abs(s.path)
+	if err != nil {
+		return eris.Wrapf(err, "error getting absolute path of %s", s.path)
+	}
+	if _, err := os.Stat(filepath.Join(root, aimRepoDir)); err == nil {
+		root = filepath.Join(root, aimRepoDir)
+	}
+	entries, err := os.ReadDir(filepath.Join(root, aimRepoMetaDir, aimRepoChunksDir))
+	if err != nil {
+		return eris.Wrapf(err, "no Aim repository found in %s", s.path)
+	}
+	s.path = root
+
+	// 2. prepare destination namespace and read run properties from the structured db.
+	namespace, err := GetOrCreateNamespace(s.db.WithContext(ctx), s.namespaceCode, "", s.defaultArtifactRoot)
+	if err != nil {
+		return eris.Wrapf(err, "error getting namespace %s", s.namespaceCode)
+	}
+	s.namespace = namespace
+
+	runs, err := s.readStructuredDB()
+	if err != nil {
+		return eris.Wrap(err, "error reading structured db")
+	}
+
+	// 3. import the runs one by one.
+	imported := 0
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		run, ok := runs[entry.Name()]
+		if !ok {
+			run = aimRepoRun{Hash: entry.Name()}
+		}
+		ok, err := s.importRun(ctx, &run)
+		if err != nil {
+			return eris.Wrapf(err, "error importing run %s", entry.Name())
+		}
+		if ok {
+			imported++
+		}
+	}
+	log.Infof("Importing Aim repository %s - found %d runs, imported %d runs", root, len(entries), imported)
+	return nil
+}
+
+// readStructuredDB reads run properties, experiments and tags from the structured db by the run hash.
+func (s *AimRepoImporter) readStructuredDB() (map[string]aimRepoRun, error) {
+	runs := map[string]aimRepoRun{}
+	path := filepath.Join(s.path, aimRepoStructuredDB)
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			log.Warnf("Aim structured db %s not found, run names, experiments and tags will be missing", path)
+			return runs, nil
+		}
+		return nil, eris.Wrapf(err, "error checking %s", path)
+	}
+
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=ro", path)), &gorm.Config{
+		Logger: logger.Discard,
+	})
+	if err != nil {
+		return nil, eris.Wrapf(err, "error opening %s", path)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, eris.Wrap(err, "error getting db connection")
+	}
+	//nolint:errcheck
+	defer sqlDB.Close()
+
+	var items []aimRepoRun
+	if err := db.Raw(
+		`SELECT run.hash, run.name, run.description, run.is_archived, run.created_at, run.updated_at,
+		        run.finalized_at, experiment.name AS experiment
+		 FROM run LEFT JOIN experiment ON experiment.id = run.experiment_id`,
+	).Scan(&items).Error; err != nil {
+		return nil, eris.Wrap(err, "error reading runs")
+	}
+	var tags []aimRepoTag
+	if err := db.Raw(
+		`SELECT run.hash, tag.name, tag.color, tag.description, tag.is_archived
+		 FROM run_tag JOIN run ON run.id = run_tag.run_id JOIN tag ON tag.id = run_tag.tag_id
+		 ORDER BY tag.name`,
+	).Scan(&tags).Error; err != nil {
+		return nil, eris.Wrap(err, "error reading tags")
+	}
+
+	for _, item := range items {
+		runs[item.Hash] = item
+	}
+	for _, tag := range tags {
+		if run, ok := runs[tag.Hash]; ok {
+			run.Tags = append(run.Tags, tag)
+			runs[tag.Hash] = run
+		}
+	}
+	return runs, nil
+}
+
+// importRun imports the run together with its params, metrics, tags and images in one transaction.
+// It returns false when the run already exists in the destination db.
+func (s *AimRepoImporter) importRun(ctx context.Context, item *aimRepoRun) (bool, error) {
+	// 1. skip already imported runs before reading the run containers.
+	db := s.db.WithContext(ctx)
+	var count int64
+	if err := db.Model(&Run{}).Where("run_uuid = ?", item.Hash).Count(&count).Error; err != nil {
+		return false, eris.Wrap(err, "error checking run existence")
+	}
+	if count > 0 {
+		log.Debugf("Run %s already exists, skipping it", item.Hash)
+		return false, nil
+	}
+
+	// 2. read run meta and sequences.
+	meta, err := readAimRepoTree(
+		[]string{filepath.Join(s.path, aimRepoMetaDir, aimRepoChunksDir, item.Hash)},
+		aimRepoMetaDir, aimRepoChunksDir, item.Hash,
+	)
+	if err != nil {
+		return false, eris.Wrap(err, "error reading run meta")
+	}
+	sequences, err := s.readSequences(item.Hash, meta)
+	if err != nil {
+		return false, eris.Wrap(err, "error reading run sequences")
+	}
+
+	experiment, err := s.getExperiment(db, item.Experiment.String)
+	if err != nil {
+		return false, err
+	}
+	artifactURI, err := url.JoinPath(experiment.ArtifactLocation, item.Hash, "artifacts")
+	if err != nil {
+		return false, eris.Wrapf(err, "error creating artifact uri for run %s", item.Hash)
+	}
+
+	run := Run{
+		ID:             item.Hash,
+		Name:           item.Hash,
+		SourceType:     "UNKNOWN",
+		Status:         StatusRunning,
+		StartTime:      aimRepoTimeToNullInt64(item.CreatedAt),
+		LifecycleStage: LifecycleStageActive,
+		ArtifactURI:    artifactURI,
+		ExperimentID:   *experiment.ID,
+	}
+	if item.Name.Valid {
+		run.Name = item.Name.String
+	}
+	if endTime, ok := meta["end_time"].(float64); ok {
+		run.EndTime = sql.NullInt64{Int64: int64(endTime * 1000), Valid: true}
+	} else if item.FinalizedAt.Valid {
+		run.EndTime = aimRepoTimeToNullInt64(item.FinalizedAt)
+	}
+	if run.EndTime.Valid {
+		run.Status = StatusFinished
+	}
+	if item.IsArchived.Bool {
+		run.LifecycleStage = LifecycleStageDeleted
+		run.DeletedTime = aimRepoTimeToNullInt64(item.UpdatedAt)
+	}
+
+	imported := false
+	err = db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&run)
+		if result.Error != nil {
+			return eris.Wrap(result.Error, "error creating run")
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		// 3. run params are stored as Aim run attributes.
+		attrs, _ := meta["attrs"].(map[string]any)
+		for _, param := range flattenAimRepoParams(item.Hash, "", attrs) {
+			if err := tx.Create(&param).Error; err != nil {
+				return eris.Wrapf(err, "error creating param %s", param.Key)
+			}
+		}
+		if item.Description.String != "" {
+			if err := tx.Create(&Tag{
+				Key:   "mlflow.note.content",
+				Value: item.Description.String,
+				RunID: item.Hash,
+			}).Error; err != nil {
+				return eris.Wrap(err, "error creating description tag")
+			}
+		}
+		for _, tag := range item.Tags {
+			if err := s.createRunSharedTag(tx, item.Hash, &tag); err != nil {
+				return err
+			}
+		}
+
+		// 4. metric and image sequences.
+		for _, sequence := range sequences {
+			if err := s.importSequence(ctx, tx, &run, sequence); err != nil {
+				return eris.Wrapf(err, "error importing sequence %s", sequence.name)
+			}
+		}
+		imported = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return imported, nil
+}
+
+// readSequences reads all the run sequences. Sequence values are stored in `val`, `step` and `time` arrays
+// of `seqs/v2/chunks/<hash>/<context>/<name>`, or `seqs/chunks/<hash>/<context>/<name>` for older repositories.
+func (s *AimRepoImporter) readSequences(hash string, meta map[string]any) ([]aimRepoSequence, error) {
+	dirs := []string{
+		filepath.Join(s.path, aimRepoSeqsDir, aimRepoChunksDir, hash),
+		// binary data could be stored in the separate container.
+		filepath.Join(s.path, aimRepoBlobsDir, aimRepoChunksDir, hash),
+	}
+	seqs, err := readAimRepoTree(dirs, aimRepoSeqsDir)
+	if err != nil {
+		return nil, err
+	}
+	if versioned, ok := seqs["v2"].(map[string]any); ok {
+		seqs = versioned
+	}
+	chunks, _ := seqs[aimRepoChunksDir].(map[string]any)
+	tree, _ := chunks[hash].(map[string]any)
+
+	contexts, _ := meta["contexts"].(map[string]any)
+	var sequences []aimRepoSequence
+	for _, contextIdx := range sortedAimRepoKeys(tree) {
+		names, _ := tree[contextIdx].(map[string]any)
+		context, ok := contexts[contextIdx].(map[string]any)
+		if !ok {
+			context = map[string]any{}
+		}
+		for _, name := range sortedAimRepoKeys(names) {
+			columns, _ := names[name].(map[string]any)
+			sequence := aimRepoSequence{
+				name:    name,
+				context: context,
+				values:  aimRepoArray(columns["val"]),
+				steps:   aimRepoArray(columns["step"]),
+				times:   aimRepoArray(columns["time"]),
+			}
+			sequences = append(sequences, sequence)
+		}
+	}
+	return sequences, nil
+}
+
+// importSequence stores numeric sequences as metrics and image sequences as artifacts.
+func (s *AimRepoImporter) importSequence(
+	ctx context.Context, tx *gorm.DB, run *Run, sequence aimRepoSequence,
+) error {
+	var metrics []Metric
+	var artifacts []Artifact
+	var latest *Metric
+	for i, value := range sequence.values {
+		step := int64(i)
+		if v, ok := aimRepoNumber(aimRepoAt(sequence.steps, i)); ok {
+			step = int64(v)
+		}
+		var timestamp int64
+		if v, ok := aimRepoNumber(aimRepoAt(sequence.times, i)); ok {
+			timestamp = int64(v * 1000)
+		}
+
+		if number, ok := aimRepoNumber(value); ok {
+			metric := Metric{
+				Key:       sequence.name,
+				Timestamp: timestamp,
+				RunID:     run.ID,
+				Step:      step,
+				Iter:      int64(i + 1),
+			}
+			switch {
+			case math.IsNaN(number):
+				metric.IsNan = true
+			case math.IsInf(number, 1):
+				metric.Value = math.MaxFloat64
+			case math.IsInf(number, -1):
+				metric.Value = -math.MaxFloat64
+			default:
+				metric.Value = number
+			}
+			metrics = append(metrics, metric)
+			if latest == nil || isNewerMetric(&metric, latest) {
+				latest = &metrics[len(metrics)-1]
+			}
+			continue
+		}
+
+		// single image or list of images could be tracked at each step.
+		images := aimRepoArray(value)
+		if images == nil {
+			images = []any{value}
+		}
+		for index, image := range images {
+			object, ok := image.(map[string]any)
+			if !ok || object[aimRepoCustomTypeKey] != aimRepoImageType {
+				log.Warnf("Skipping unsupported sequence %s of run %s", sequence.name, run.ID)
+				return nil
+			}
+			artifact, err := s.importImage(ctx, run, sequence.name, int64(i), step, int64(index), object)
+			if err != nil {
+				return err
+			}
+			if artifact != nil {
+				artifacts = append(artifacts, *artifact)
+			}
+		}
+	}
+
+	if len(metrics) > 0 {
+		contextID, err := s.getContextID(tx, sequence.context)
+		if err != nil {
+			return err
+		}
+		for i := range metrics {
+			metrics[i].ContextID = contextID
+		}
+		if err := tx.Omit(clause.Associations).Clauses(
+			clause.OnConflict{DoNothing: true},
+		).CreateInBatches(&metrics, archiveImportBatchSize).Error; err != nil {
+			return eris.Wrap(err, "error creating metrics")
+		}
+		if err := tx.Omit(clause.Associations).Create(&LatestMetric{
+			Key:       latest.Key,
+			Value:     latest.Value,
+			Timestamp: latest.Timestamp,
+			Step:      latest.Step,
+			IsNan:     latest.IsNan,
+			RunID:     run.ID,
+			LastIter:  int64(len(metrics)),
+			ContextID: contextID,
+		}).Error; err != nil {
+			return eris.Wrap(err, "error creating latest metric")
+		}
+	}
+	if len(artifacts) > 0 {
+		if err := tx.Omit(clause.Associations).CreateInBatches(&artifacts, archiveImportBatchSize).Error; err != nil {
+			return eris.Wrap(err, "error creating artifacts")
+		}
+	}
+	return nil
+}
+
+// importImage stores image data in the run artifact storage, next to the images logged by the client.
+func (s *AimRepoImporter) importImage(
+	ctx context.Context, run *Run, name string, iter, step, index int64, object map[string]any,
+) (*Artifact, error) {
+	if s.artifactStorageFactory == nil {
+		log.Warnf("Skipping image sequence %s of run %s, artifact storage is not configured", name, run.ID)
+		return nil, nil
+	}
+	data, ok := object["source"].([]byte)
+	if !ok {
+		return nil, eris.Errorf("image data of sequence %s is missing", name)
+	}
+
+	artifact := Artifact{
+		Name:    name,
+		Iter:    iter + 1,
+		Step:    step,
+		RunID:   run.ID,
+		Index:   index,
+		Format:  fmt.Sprintf("%v", object["format"]),
+		Caption: fmt.Sprintf("%v", object["caption"]),
+	}
+	if object["caption"] == nil {
+		artifact.Caption = ""
+	}
+	if width, ok := aimRepoNumber(object["width"]); ok {
+		artifact.Width = int64(width)
+	}
+	if height, ok := aimRepoNumber(object["height"]); ok {
+		artifact.Height = int64(height)
+	}
+	artifact.BlobURI = fmt.Sprintf("%s/%d_%d.%s", name, step, index, strings.ToLower(artifact.Format))
+
+	ctx = storage.WithNamespace(ctx, s.namespace.Code)
+	artifactStorage, err := s.artifactStorageFactory.GetStorage(ctx, run.ArtifactURI)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error getting artifact storage for run %s", run.ID)
+	}
+	if err := artifactStorage.Put(ctx, run.ArtifactURI, artifact.BlobURI, bytes.NewReader(data)); err != nil {
+		return nil, eris.Wrapf(err, "error putting image %s", artifact.BlobURI)
+	}
+	return &artifact, nil
+}
+
+// getExperiment finds the experiment with the same name in the destination namespace or creates a new one.
+// Aim default experiment is merged into the destination default experiment.
+func (s *AimRepoImporter) getExperiment(tx *gorm.DB, name string) (*Experiment, error) {
+	if experiment, ok := s.experiments[name]; ok {
+		return experiment, nil
+	}
+
+	var experiment Experiment
+	if name == "" || name == aimRepoDefaultExpName {
+		if err := tx.First(&experiment, *s.namespace.DefaultExperimentID).Error; err != nil {
+			return nil, eris.Wrap(err, "error getting default experiment")
+		}
+	} else {
+		now := time.Now().UTC().UnixMilli()
+		result := tx.Omit(clause.Associations).Where(
+			Experiment{Name: name, NamespaceID: s.namespace.ID},
+		).Attrs(Experiment{
+			LifecycleStage: LifecycleStageActive,
+			CreationTime:   sql.NullInt64{Int64: now, Valid: true},
+			LastUpdateTime: sql.NullInt64{Int64: now, Valid: true},
+		}).FirstOrCreate(&experiment)
+		if result.Error != nil {
+			return nil, eris.Wrapf(result.Error, "error creating experiment %s", name)
+		}
+		if result.RowsAffected > 0 {
+			if err := updateExperimentArtifactLocation(tx, &experiment, s.defaultArtifactRoot); err != nil {
+				return nil, err
+			}
+		}
+	}
+	s.experiments[name] = &experiment
+	return &experiment, nil
+}
+
+// getContextID finds or creates the metric context.
+func (s *AimRepoImporter) getContextID(tx *gorm.DB, value map[string]any) (uint, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return 0, eris.Wrap(err, "error marshaling context")
+	}
+	if id, ok := s.contextIDs[string(data)]; ok {
+		return id, nil
+	}
+	metricContext := Context{Json: types.JSONB(data)}
+	if err := tx.Where(&metricContext).FirstOrCreate(&metricContext).Error; err != nil {
+		return 0, eris.Wrap(err, "error getting metric context")
+	}
+	s.contextIDs[string(data)] = metricContext.ID
+	return metricContext.ID, nil
+}
+
+// createRunSharedTag finds or creates the namespace tag and attaches it to the run.
+func (s *AimRepoImporter) createRunSharedTag(tx *gorm.DB, runID string, item *aimRepoTag) error {
+	tag, ok := s.sharedTags[item.Name]
+	if !ok {
+		tag = &SharedTag{}
+		if err := tx.Where(
+			SharedTag{Name: item.Name, NamespaceID: s.namespace.ID},
+		).Attrs(SharedTag{
+			ID:          uuid.New(),
+			IsArchived:  item.IsArchived.Bool,
+			Color:       item.Color.String,
+			Description: item.Description.String,
+		}).FirstOrCreate(tag).Error; err != nil {
+			return eris.Wrapf(err, "error creating tag %s", item.Name)
+		}
+		s.sharedTags[item.Name] = tag
+	}
+	if err := tx.Exec(
+		"INSERT INTO run_shared_tags (shared_tag_id, run_id) VALUES(?, ?) ON CONFLICT DO NOTHING", tag.ID, runID,
+	).Error; err != nil {
+		return eris.Wrapf(err, "error attaching tag %s", item.Name)
+	}
+	return nil
+}
+
+// readAimRepoTree reads the containers into one tree and returns the subtree located at the path.
+// Containers which don't exist are ignored.
+func readAimRepoTree(dirs []string, path ...string) (map[string]any, error) {
+	var tree any = map[string]any{}
+	for _, dir := range dirs {
+		if _, err := os.Stat(dir); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, eris.Wrapf(err, "error checking %s", dir)
+		}
+		keyValues, err := rocksdb.ReadAll(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, kv := range keyValues {
+			keyPath, err := encoding.DecodePath(kv.Key)
+			if err != nil {
+				return nil, eris.Wrapf(err, "error decoding key %q", kv.Key)
+			}
+			value, err := encoding.DecodeValue(kv.Value)
+			if err != nil {
+				return nil, eris.Wrapf(err, "error decoding value of key %q", kv.Key)
+			}
+			tree = insertAimRepoValue(tree, keyPath, value)
+		}
+	}
+
+	for _, component := range path {
+		node, ok := tree.(map[string]any)
+		if !ok {
+			return map[string]any{}, nil
+		}
+		tree = node[component]
+	}
+	if node, ok := tree.(map[string]any); ok {
+		return node, nil
+	}
+	return map[string]any{}, nil
+}
+
+// insertAimRepoValue inserts the value into the tree. Objects are represented as maps keyed by strings
+// (index components are formatted), arrays as maps keyed by indexes and custom objects as maps
+// with additional `$type` key.
+func insertAimRepoValue(node any, path []any, value any) any {
+	if len(path) == 0 {
+		// the same flag could be stored in several containers, so existing items are kept.
+		switch v := value.(type) {
+		case encoding.ObjectFlag:
+			if n, ok := node.(map[string]any); ok {
+				return n
+			}
+			return map[string]any{}
+		case encoding.ArrayFlag:
+			if n, ok := node.(map[int64]any); ok {
+				return n
+			}
+			return map[int64]any{}
+		case encoding.CustomObjectFlag:
+			return map[string]any{aimRepoCustomTypeKey: v.Type}
+		default:
+			return value
+		}
+	}
+
+	switch n := node.(type) {
+	case map[int64]any:
+		if index, ok := path[0].(int64); ok {
+			n[index] = insertAimRepoValue(n[index], path[1:], value)
+			return n
+		}
+	case map[string]any:
+		key := fmt.Sprintf("%v", path[0])
+		n[key] = insertAimRepoValue(n[key], path[1:], value)
+		return n
+	}
+	return insertAimRepoValue(map[string]any{}, path, value)
+}
+
+// aimRepoArray converts array node into a slice ordered by indexes.
+func aimRepoArray(node any) []any {
+	items, ok := node.(map[int64]any)
+	if !ok {
+		return nil
+	}
+	indexes := make([]int64, 0, len(items))
+	for index := range items {
+		indexes = append(indexes, index)
+	}
+	slices.Sort(indexes)
+	values := make([]any, len(indexes))
+	for i, index := range indexes {
+		values[i] = items[index]
+	}
+	return values
+}
+
+// aimRepoAt returns the item of the slice or nil.
+func aimRepoAt(values []any, i int) any {
+	if i < len(values) {
+		return values[i]
+	}
+	return nil
+}
+
+// aimRepoNumber converts numeric value to float64.
+func aimRepoNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// sortedAimRepoKeys returns the keys of the object node in the sorted order.
+func sortedAimRepoKeys(node map[string]any) []string {
+	keys := make([]string, 0, len(node))
+	for key := range node {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// flattenAimRepoParams converts nested run attributes into params with dot separated keys.
+func flattenAimRepoParams(runID, prefix string, attrs map[string]any) []Param {
+	var params []Param
+	for _, key := range sortedAimRepoKeys(attrs) {
+		if prefix == "" && key == aimRepoSystemParamsKey {
+			continue
+		}
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+
+		param := Param{Key: name, RunID: runID}
+		switch value := attrs[key].(type) {
+		case map[string]any:
+			params = append(params, flattenAimRepoParams(runID, name, value)...)
+			continue
+		case map[int64]any:
+			data, err := json.Marshal(aimRepoArray(value))
+			if err != nil {
+				log.Warnf("Skipping param %s of run %s: %s", name, runID, err)
+				continue
+			}
+			param.ValueStr = common.GetPointer(string(data))
+		case int64:
+			param.ValueInt = &value
+		case float64:
+			param.ValueFloat = &value
+		case string:
+			param.ValueStr = common.GetPointer(value)
+		case bool:
+			param.ValueStr = common.GetPointer(strconv.FormatBool(value))
+		default:
+			continue
+		}
+		if param.ValueStr != nil && len(*param.ValueStr) > aimRepoMaxParamLength {
+			log.Warnf("Skipping param %s of run %s, value is too long", name, runID)
+			continue
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// aimRepoTimeToNullInt64 converts structured db time into milliseconds.
+func aimRepoTimeToNullInt64(value sql.NullTime) sql.NullInt64 {
+	if !value.Valid {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: value.Time.UnixMilli(), Valid: true}
+}
@@ -0,0 +1,220 @@
+package database
+
+import (
+	"archive/tar"
+	"database/sql"
+	"encoding/json"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+)
+
+// ArchiveFormatVersion is a version of the namespace archive layout. It has to be increased
+// every time when the layout or the records change in a backward incompatible way.
+const ArchiveFormatVersion = 1
+
+// archive entries.
+const (
+	archiveManifestFile  = "manifest.json"
+	archiveDataDir       = "data/"
+	archiveArtifactsDir  = "artifacts/"
+	archiveDataExtension = ".jsonl"
+)
+
+// archiveTables is the list of tables stored in the archive. Order matters, because
+// the archive is read sequentially and the tables have to be restored in dependency order.
+var archiveTables = []string{
+	"experiments",
+	"experiment_tags",
+	"runs",
+	"tags",
+	"params",
+	"contexts",
+	"metrics",
+	"latest_metrics",
+	"logs",
+	"apps",
+	"dashboards",
+	"shared_tags",
+	"run_shared_tags",
+}
+
+// ArchiveManifest describes the content of the namespace archive.
+type ArchiveManifest struct {
+	FormatVersion      int              `json:"format_version"`
+	FastTrackMLVersion string           `json:"fasttrackml_version"`
+	SchemaVersion      string           `json:"schema_version"`
+	CreatedAt          time.Time        `json:"created_at"`
+	Namespace          ArchiveNamespace `json:"namespace"`
+	Tables             []string         `json:"tables"`
+	Artifacts          bool             `json:"artifacts"`
+}
+
+// ArchiveNamespace represents the exported namespace.
+type ArchiveNamespace struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+type archiveExperiment struct {
+	ID               int32          `json:"experiment_id"`
+	Name             string         `json:"name"`
+	ArtifactLocation string         `json:"artifact_location"`
+	LifecycleStage   LifecycleStage `json:"lifecycle_stage"`
+	CreationTime     *int64         `json:"creation_time"`
+	LastUpdateTime   *int64         `json:"last_update_time"`
+	IsDefault        bool           `json:"is_default"`
+}
+
+type archiveExperimentTag struct {
+	ExperimentID int32  `json:"experiment_id"`
+	Key          string `json:"key"`
+	Value        string `json:"value"`
+}
+
+type archiveRun struct {
+	ID             string         `json:"run_uuid"`
+	Name           string         `json:"name"`
+	SourceType     string         `json:"source_type"`
+	SourceName     string         `json:"source_name"`
+	EntryPointName string         `json:"entry_point_name"`
+	UserID         string         `json:"user_id"`
+	Status         Status         `json:"status"`
+	StartTime      *int64         `json:"start_time"`
+	EndTime        *int64         `json:"end_time"`
+	SourceVersion  string         `json:"source_version"`
+	LifecycleStage LifecycleStage `json:"lifecycle_stage"`
+	ArtifactURI    string         `json:"artifact_uri"`
+	ExperimentID   int32          `json:"experiment_id"`
+	DeletedTime    *int64         `json:"deleted_time"`
+}
+
+type archiveParam struct {
+	RunID      string   `json:"run_uuid"`
+	Key        string   `json:"key"`
+	ValueStr   *string  `json:"value_str"`
+	ValueInt   *int64   `json:"value_int"`
+	ValueFloat *float64 `json:"value_float"`
+}
+
+type archiveTag struct {
+	RunID string `json:"run_uuid"`
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type archiveContext struct {
+	ID   uint        `json:"id"`
+	Json types.JSONB `json:"json"`
+}
+
+type archiveMetric struct {
+	RunID     string  `json:"run_uuid"`
+	Key       string  `json:"key"`
+	Value     float64 `json:"value"`
+	Timestamp int64   `json:"timestamp"`
+	Step      int64   `json:"step"`
+	IsNan     bool    `json:"is_nan"`
+	Iter      int64   `json:"iter"`
+	ContextID uint    `json:"context_id"`
+}
+
+type archiveLatestMetric struct {
+	RunID     string  `json:"run_uuid"`
+	Key       string  `json:"key"`
+	Value     float64 `json:"value"`
+	Timestamp int64   `json:"timestamp"`
+	Step      int64   `json:"step"`
+	IsNan     bool    `json:"is_nan"`
+	LastIter  int64   `json:"last_iter"`
+	ContextID uint    `json:"context_id"`
+}
+
+type archiveLog struct {
+	RunID     string `json:"run_uuid"`
+	Value     string `json:"value"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+type archiveApp struct {
+	ID         uuid.UUID `json:"id"`
+	Type       string    `json:"type"`
+	State      AppState  `json:"state"`
+	IsArchived bool      `json:"is_archived"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type archiveDashboard struct {
+	ID          uuid.UUID  `json:"id"`
+	AppID       *uuid.UUID `json:"app_id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	IsArchived  bool       `json:"is_archived"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type archiveSharedTag struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Color       string    `json:"color"`
+	Description string    `json:"description"`
+	IsArchived  bool      `json:"is_archived"`
+}
+
+type archiveRunSharedTag struct {
+	RunID       uuid.UUID `json:"run_id"`
+	SharedTagID uuid.UUID `json:"shared_tag_id"`
+}
+
+// writeArchiveEntry writes a regular file entry into the archive.
+func writeArchiveEntry(writer *tar.Writer, name string, size int64) error {
+	return writer.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     size,
+		Mode:     0o644,
+		ModTime:  time.Now().UTC(),
+	})
+}
+
+// splitArtifactEntry splits the artifact entry name `<run_uuid>/<path>` into the run id and the artifact path.
+// The artifact path has to stay inside the run artifacts, so absolute paths and paths with `..` are rejected.
+func splitArtifactEntry(name string) (string, string, error) {
+	runID, artifactPath, ok := strings.Cut(name, "/")
+	if !ok {
+		return "", "", eris.New("artifact path is missing")
+	}
+	if !filepath.IsLocal(artifactPath) {
+		return "", "", eris.Errorf("artifact path %s is outside of the run artifacts", artifactPath)
+	}
+	return runID, path.Clean(artifactPath), nil
+}
+
+// marshalArchiveManifest returns json representation of the manifest.
+func marshalArchiveManifest(manifest *ArchiveManifest) ([]byte, error) {
+	return json.MarshalIndent(manifest, "", "  ")
+}
+
+// nullInt64ToPointer converts sql.NullInt64 into pointer, so it could be stored as json `null`.
+func nullInt64ToPointer(value sql.NullInt64) *int64 {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Int64
+}
+
+// pointerToNullInt64 converts pointer back into sql.NullInt64.
+func pointerToNullInt64(value *int64) sql.NullInt64 {
+	if value == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *value, Valid: true}
+}
@@ -0,0 +1,479 @@
+package database
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/klauspost/compress/zstd"
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+)
+
+// archiveImportBatchSize is a number of rows inserted at once.
+const archiveImportBatchSize = 1000
+
+// archiveImportRunSharedTag is a row of `run_shared_tags` table. Unlike RunSharedTag, it keeps run id
+// in the same format as `runs` table does, otherwise the foreign key to the run can't be satisfied.
+type archiveImportRunSharedTag struct {
+	RunID       string    `gorm:"column:run_id"`
+	SharedTagID uuid.UUID `gorm:"column:shared_tag_id"`
+}
+
+// TableName returns actual table name.
+func (archiveImportRunSharedTag) TableName() string {
+	return "run_shared_tags"
+}
+
+// ArchiveImporter will handle the import of namespace archive created by Exporter.
+type ArchiveImporter struct {
+	db                     *gorm.DB
+	defaultArtifactRoot    string
+	namespaceCode          *string
+	namespace              *Namespace
+	artifactStorageFactory storage.ArtifactStorageFactoryProvider
+	importArtifacts        bool
+	experimentIDs          map[int32]int32
+	experimentLocations    map[int32]string
+	contextIDs             map[uint]uint
+	importedRuns           map[string]string
+}
+
+// NewArchiveImporter initializes an ArchiveImporter.
+func NewArchiveImporter(
+	db *gorm.DB, defaultArtifactRoot string, options ...func(importer *ArchiveImporter),
+) *ArchiveImporter {
+	importer := ArchiveImporter{
+		db:                  db,
+		defaultArtifactRoot: defaultArtifactRoot,
+		experimentIDs:       map[int32]int32{},
+		experimentLocations: map[int32]string{},
+		contextIDs:          map[uint]uint{},
+		importedRuns:        map[string]string{},
+	}
+	for _, o := range options {
+		o(&importer)
+	}
+	return &importer
+}
+
+// WithArchiveNamespace sets ArchiveImporter destination Namespace instead of the archived one.
+func WithArchiveNamespace(namespace string) func(importer *ArchiveImporter) {
+	return func(s *ArchiveImporter) {
+		s.namespaceCode = &namespace
+	}
+}
+
+// WithArchiveArtifacts enables import of run artifacts using provided artifact storage factory.
+func WithArchiveArtifacts(
+	artifactStorageFactory storage.ArtifactStorageFactoryProvider,
+) func(importer *ArchiveImporter) {
+	return func(s *ArchiveImporter) {
+		s.artifactStorageFactory = artifactStorageFactory
+	}
+}
+
+// Import reads zstd compressed tar archive and restores its content in the destination db.
+// Runs which already exist in the destination db are skipped together with their data,
+// so the same archive could be imported several times.
+func (s *ArchiveImporter) Import(ctx context.Context, reader io.Reader) error {
+	decompressor, err := zstd.NewReader(reader)
+	if err != nil {
+		return eris.Wrap(err, "error creating zstd reader")
+	}
+	defer decompressor.Close()
+	archive := tar.NewReader(decompressor)
+
+	// 1. read and check the manifest. it is always the first archive entry.
+	header, err := archive.Next()
+	if err != nil {
+		return eris.Wrap(err, "error reading archive")
+	}
+	if header.Name != archiveManifestFile {
+		return eris.Errorf("unexpected archive entry %s, archive has to start with %s", header.Name, archiveManifestFile)
+	}
+	var manifest ArchiveManifest
+	if err := json.NewDecoder(archive).Decode(&manifest); err != nil {
+		return eris.Wrap(err, "error decoding manifest")
+	}
+	if manifest.FormatVersion < 1 || manifest.FormatVersion > ArchiveFormatVersion {
+		return eris.Errorf("unsupported archive format version %d", manifest.FormatVersion)
+	}
+	if manifest.SchemaVersion != currentVersion() {
+		log.Infof(
+			"Archive was created with schema version %s, current schema version is %s",
+			manifest.SchemaVersion, currentVersion(),
+		)
+	}
+	if manifest.Artifacts && s.artifactStorageFactory == nil {
+		log.Info("Archive contains artifacts, but artifacts import is not enabled, skipping them")
+	}
+	s.importArtifacts = manifest.Artifacts && s.artifactStorageFactory != nil
+
+	// 2. find or create the destination namespace.
+	code := manifest.Namespace.Code
+	if s.namespaceCode != nil {
+		code = *s.namespaceCode
+	}
+	namespace, err := GetOrCreateNamespace(
+		s.db.WithContext(ctx), code, manifest.Namespace.Description, s.defaultArtifactRoot,
+	)
+	if err != nil {
+		return eris.Wrapf(err, "error getting namespace %s", code)
+	}
+	s.namespace = namespace
+
+	// 3. process the rest of archive entries one by one.
+	for {
+		header, err := archive.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return eris.Wrap(err, "error reading archive")
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(header.Name, archiveDataDir):
+			table := strings.TrimSuffix(strings.TrimPrefix(header.Name, archiveDataDir), archiveDataExtension)
+			if err := s.importTable(ctx, table, archive); err != nil {
+				return eris.Wrapf(err, "error importing table %s", table)
+			}
+		case strings.HasPrefix(header.Name, archiveArtifactsDir):
+			if err := s.importArtifact(ctx, strings.TrimPrefix(header.Name, archiveArtifactsDir), archive); err != nil {
+				return eris.Wrapf(err, "error importing artifact %s", header.Name)
+			}
+		default:
+			log.Warnf("Skipping unknown archive entry %s", header.Name)
+		}
+	}
+}
+
+// importTable restores records of one table from the json lines.
+//
+//nolint:gocyclo
+func (s *ArchiveImporter) importTable(ctx context.Context, table string, reader io.Reader) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int
+		var err error
+		switch table {
+		case "experiments":
+			count, err = importRows(reader, func(item archiveExperiment) error {
+				return s.importExperiment(tx, item)
+			})
+		case "experiment_tags":
+			count, err = importBatches(reader, tx, func(item archiveExperimentTag) (*ExperimentTag, error) {
+				experimentID, ok := s.experimentIDs[item.ExperimentID]
+				if !ok {
+					return nil, eris.Errorf("unknown experiment %d", item.ExperimentID)
+				}
+				return &ExperimentTag{ExperimentID: experimentID, Key: item.Key, Value: item.Value}, nil
+			})
+		case "runs":
+			count, err = importRows(reader, func(item archiveRun) error {
+				return s.importRun(tx, item)
+			})
+		case "tags":
+			count, err = importBatches(reader, tx, func(item archiveTag) (*Tag, error) {
+				if _, ok := s.importedRuns[item.RunID]; !ok {
+					return nil, nil
+				}
+				return &Tag{RunID: item.RunID, Key: item.Key, Value: item.Value}, nil
+			})
+		case "params":
+			count, err = importBatches(reader, tx, func(item archiveParam) (*Param, error) {
+				if _, ok := s.importedRuns[item.RunID]; !ok {
+					return nil, nil
+				}
+				return &Param{
+					RunID:      item.RunID,
+					Key:        item.Key,
+					ValueStr:   item.ValueStr,
+					ValueInt:   item.ValueInt,
+					ValueFloat: item.ValueFloat,
+				}, nil
+			})
+		case "contexts":
+			count, err = importRows(reader, func(item archiveContext) error {
+				metricContext := Context{Json: item.Json}
+				if err := tx.Clauses(clause.OnConflict{
+					Columns:   []clause.Column{{Name: "json"}},
+					UpdateAll: true,
+				}).Create(&metricContext).Error; err != nil {
+					return eris.Wrap(err, "error creating context")
+				}
+				s.contextIDs[item.ID] = metricContext.ID
+				return nil
+			})
+		case "metrics":
+			count, err = importBatches(reader, tx, func(item archiveMetric) (*Metric, error) {
+				if _, ok := s.importedRuns[item.RunID]; !ok {
+					return nil, nil
+				}
+				contextID, ok := s.contextIDs[item.ContextID]
+				if !ok {
+					return nil, eris.Errorf("unknown context %d", item.ContextID)
+				}
+				return &Metric{
+					RunID:     item.RunID,
+					Key:       item.Key,
+					Value:     item.Value,
+					Timestamp: item.Timestamp,
+					Step:      item.Step,
+					IsNan:     item.IsNan,
+					Iter:      item.Iter,
+					ContextID: contextID,
+				}, nil
+			})
+		case "latest_metrics":
+			count, err = importBatches(reader, tx, func(item archiveLatestMetric) (*LatestMetric, error) {
+				if _, ok := s.importedRuns[item.RunID]; !ok {
+					return nil, nil
+				}
+				contextID, ok := s.contextIDs[item.ContextID]
+				if !ok {
+					return nil, eris.Errorf("unknown context %d", item.ContextID)
+				}
+				return &LatestMetric{
+					RunID:     item.RunID,
+					Key:       item.Key,
+					Value:     item.Value,
+					Timestamp: item.Timestamp,
+					Step:      item.Step,
+					IsNan:     item.IsNan,
+					LastIter:  item.LastIter,
+					ContextID: contextID,
+				}, nil
+			})
+		case "logs":
+			count, err = importBatches(reader, tx, func(item archiveLog) (*Log, error) {
+				if _, ok := s.importedRuns[item.RunID]; !ok {
+					return nil, nil
+				}
+				return &Log{RunID: item.RunID, Value: item.Value, Timestamp: item.Timestamp}, nil
+			})
+		case "apps":
+			count, err = importBatches(reader, tx, func(item archiveApp) (*App, error) {
+				return &App{
+					Base: Base{
+						ID:        item.ID,
+						CreatedAt: item.CreatedAt,
+						UpdatedAt: item.UpdatedAt,
+					},
+					Type:        item.Type,
+					State:       item.State,
+					NamespaceID: s.namespace.ID,
+					IsArchived:  item.IsArchived,
+				}, nil
+			})
+		case "dashboards":
+			count, err = importBatches(reader, tx, func(item archiveDashboard) (*Dashboard, error) {
+				return &Dashboard{
+					Base: Base{
+						ID:        item.ID,
+						CreatedAt: item.CreatedAt,
+						UpdatedAt: item.UpdatedAt,
+					},
+					Name:        item.Name,
+					Description: item.Description,
+					AppID:       item.AppID,
+					IsArchived:  item.IsArchived,
+				}, nil
+			})
+		case "shared_tags":
+			count, err = importBatches(reader, tx, func(item archiveSharedTag) (*SharedTag, error) {
+				return &SharedTag{
+					ID:          item.ID,
+					IsArchived:  item.IsArchived,
+					Name:        item.Name,
+					Color:       item.Color,
+					Description: item.Description,
+					NamespaceID: s.namespace.ID,
+				}, nil
+			})
+		case "run_shared_tags":
+			count, err = importBatches(reader, tx, func(item archiveRunSharedTag) (*archiveImportRunSharedTag, error) {
+				runID := strings.ReplaceAll(item.RunID.String(), "-", "")
+				if _, ok := s.importedRuns[runID]; !ok {
+					return nil, nil
+				}
+				return &archiveImportRunSharedTag{RunID: runID, SharedTagID: item.SharedTagID}, nil
+			})
+		default:
+			log.Warnf("Skipping unknown table %s", table)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		log.Infof("Importing %s - found %d records", table, count)
+		return nil
+	})
+}
+
+// importExperiment finds the experiment with the same name in the destination namespace or creates a new one.
+// The default experiment of the archived namespace is always merged into the destination default experiment.
+func (s *ArchiveImporter) importExperiment(tx *gorm.DB, item archiveExperiment) error {
+	var experiment Experiment
+	if item.IsDefault {
+		if err := tx.First(&experiment, *s.namespace.DefaultExperimentID).Error; err != nil {
+			return eris.Wrap(err, "error getting default experiment")
+		}
+	} else {
+		result := tx.Omit(clause.Associations).Where(
+			Experiment{Name: item.Name, NamespaceID: s.namespace.ID},
+		).Attrs(Experiment{
+			LifecycleStage: item.LifecycleStage,
+			CreationTime:   pointerToNullInt64(item.CreationTime),
+			LastUpdateTime: pointerToNullInt64(item.LastUpdateTime),
+		}).FirstOrCreate(&experiment)
+		if result.Error != nil {
+			return eris.Wrapf(result.Error, "error creating experiment %s", item.Name)
+		}
+		if result.RowsAffected > 0 {
+			if err := updateExperimentArtifactLocation(tx, &experiment, s.defaultArtifactRoot); err != nil {
+				return err
+			}
+		}
+	}
+	s.experimentIDs[item.ID] = *experiment.ID
+	s.experimentLocations[*experiment.ID] = experiment.ArtifactLocation
+	return nil
+}
+
+// importRun creates the run unless it already exists in the destination db.
+func (s *ArchiveImporter) importRun(tx *gorm.DB, item archiveRun) error {
+	experimentID, ok := s.experimentIDs[item.ExperimentID]
+	if !ok {
+		return eris.Errorf("unknown experiment %d", item.ExperimentID)
+	}
+
+	// artifacts are restored into the destination artifact root, otherwise keep pointing to the original ones.
+	artifactURI := item.ArtifactURI
+	if s.importArtifacts {
+		uri, err := url.JoinPath(s.experimentLocations[experimentID], item.ID, "artifacts")
+		if err != nil {
+			return eris.Wrapf(err, "error creating artifact uri for run %s", item.ID)
+		}
+		artifactURI = uri
+	}
+
+	result := tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&Run{
+		ID:             item.ID,
+		Name:           item.Name,
+		SourceType:     item.SourceType,
+		SourceName:     item.SourceName,
+		EntryPointName: item.EntryPointName,
+		UserID:         item.UserID,
+		Status:         item.Status,
+		StartTime:      pointerToNullInt64(item.StartTime),
+		EndTime:        pointerToNullInt64(item.EndTime),
+		SourceVersion:  item.SourceVersion,
+		LifecycleStage: item.LifecycleStage,
+		ArtifactURI:    artifactURI,
+		ExperimentID:   experimentID,
+		DeletedTime:    pointerToNullInt64(item.DeletedTime),
+	})
+	if result.Error != nil {
+		return eris.Wrapf(result.Error, "error creating run %s", item.ID)
+	}
+	if result.RowsAffected > 0 {
+		s.importedRuns[item.ID] = artifactURI
+	} else {
+		log.Debugf("Run %s already exists, skipping it", item.ID)
+	}
+	return nil
+}
+
+// importArtifact uploads one archived artifact object of the run into the artifact storage.
+func (s *ArchiveImporter) importArtifact(ctx context.Context, name string, reader io.Reader) error {
+	if !s.importArtifacts {
+		return nil
+	}
+	runID, artifactPath, err := splitArtifactEntry(name)
+	if err != nil {
+		return err
+	}
+	artifactURI, ok := s.importedRuns[runID]
+	if !ok {
+		return nil
+	}
+
+	ctx = storage.WithNamespace(ctx, s.namespace.Code)
+	artifactStorage, err := s.artifactStorageFactory.GetStorage(ctx, artifactURI)
+	if err != nil {
+		return eris.Wrapf(err, "error getting artifact storage for run %s", runID)
+	}
+	if err := artifactStorage.Put(ctx, artifactURI, artifactPath, reader); err != nil {
+		return eris.Wrapf(err, "error putting artifact %s", artifactPath)
+	}
+	return nil
+}
+
+// importRows decodes json lines one by one and passes them to the process function.
+func importRows[T any](reader io.Reader, process func(item T) error) (int, error) {
+	decoder := json.NewDecoder(reader)
+	count := 0
+	for {
+		var item T
+		if err := decoder.Decode(&item); err != nil {
+			if errors.Is(err, io.EOF) {
+				return count, nil
+			}
+			return 0, eris.Wrap(err, "error decoding row")
+		}
+		if err := process(item); err != nil {
+			return 0, err
+		}
+		count++
+	}
+}
+
+// importBatches decodes json lines, converts them into models and inserts them in batches.
+// Rows converted into nil are skipped, rows which already exist are ignored.
+func importBatches[T any, M any](reader io.Reader, tx *gorm.DB, convert func(item T) (*M, error)) (int, error) {
+	batch := make([]*M, 0, archiveImportBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := tx.Session(&gorm.Session{SkipHooks: true}).Omit(clause.Associations).Clauses(
+			clause.OnConflict{DoNothing: true},
+		).Create(&batch).Error; err != nil {
+			return eris.Wrap(err, "error creating rows")
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	count, err := importRows(reader, func(item T) error {
+		row, err := convert(item)
+		if err != nil || row == nil {
+			return err
+		}
+		batch = append(batch, row)
+		if len(batch) == archiveImportBatchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := flush(); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,554 @@
+package database
+
+import (
+	"archive/tar"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/klauspost/compress/zstd"
+	"github.com/mattn/go-sqlite3"
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/version"
+)
+
+// BackupFormatVersion is a version of the backup layout. It has to be increased
+// every time when the layout changes in a backward incompatible way.
+const BackupFormatVersion = 1
+
+// backup entries.
+const (
+	backupManifestFile   = "manifest.json"
+	backupSQLiteFile     = "database.sqlite"
+	backupDataDir        = "data/"
+	backupArtifactsDir   = "artifacts/"
+	backupDataExtension  = ".copy"
+	backupSQLiteDatabase = "main"
+)
+
+// BackupManifest describes the content of the backup.
+type BackupManifest struct {
+	FormatVersion      int           `json:"format_version"`
+	FastTrackMLVersion string        `json:"fasttrackml_version"`
+	Dialect            string        `json:"dialect"`
+	SchemaVersion      string        `json:"schema_version"`
+	AlembicVersion     string        `json:"alembic_version"`
+	Encrypted          bool          `json:"encrypted"`
+	CreatedAt          time.Time     `json:"created_at"`
+	Tables             []BackupTable `json:"tables,omitempty"`
+	Artifacts          bool          `json:"artifacts"`
+}
+
+// BackupTable describes the Postgres table dumped into the backup. Tables are listed in dependency
+// order, so they could be restored one by one without violation of the foreign keys.
+type BackupTable struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+}
+
+// backupRun is the run, which local artifacts are stored in the backup.
+type backupRun struct {
+	ID          string `gorm:"column:run_uuid"`
+	ArtifactURI string `gorm:"column:artifact_uri"`
+}
+
+// Backuper will handle the point-in-time consistent backup of the whole database.
+type Backuper struct {
+	db              DBProvider
+	artifactStorage storage.ArtifactStorageProvider
+}
+
+// NewBackuper initializes a Backuper.
+func NewBackuper(db DBProvider, options ...func(backuper *Backuper)) *Backuper {
+	backuper := Backuper{
+		db: db,
+	}
+	for _, o := range options {
+		o(&backuper)
+	}
+	return &backuper
+}
+
+// WithBackupArtifacts enables backup of the run artifacts kept by the local artifact storage.
+func WithBackupArtifacts(artifactStorage storage.ArtifactStorageProvider) func(backuper *Backuper) {
+	return func(s *Backuper) {
+		s.artifactStorage = artifactStorage
+	}
+}
+
+// Backup writes the snapshot of the database as zstd compressed tar archive into the writer.
+// SQLite database is copied by the online backup API, Postgres tables are dumped by `COPY`
+// inside one repeatable read transaction.
+func (s *Backuper) Backup(ctx context.Context, writer io.Writer) error {
+	compressor, err := zstd.NewWriter(writer)
+	if err != nil {
+		return eris.Wrap(err, "error creating zstd writer")
+	}
+	archive := tar.NewWriter(compressor)
+
+	var runs []backupRun
+	switch dialect := s.db.GormDB().Dialector.Name(); dialect {
+	case sqlite.Dialector{}.Name():
+		runs, err = s.backupSQLite(ctx, archive)
+	case postgres.Dialector{}.Name():
+		runs, err = s.backupPostgres(ctx, archive)
+	default:
+		err = eris.Errorf("unsupported database dialect %s", dialect)
+	}
+	if err != nil {
+		return err
+	}
+
+	// artifacts are not the part of the database snapshot, they are copied as they are now.
+	for _, run := range runs {
+		count, err := writeRunArtifacts(
+			ctx, archive, s.artifactStorage, path.Join(backupArtifactsDir, run.ID), run.ArtifactURI, "",
+		)
+		if err != nil {
+			return eris.Wrapf(err, "error backing up artifacts of run %s", run.ID)
+		}
+		log.Debugf("Backing up artifacts of run %s - found %d objects", run.ID, count)
+	}
+
+	if err := archive.Close(); err != nil {
+		return eris.Wrap(err, "error closing tar writer")
+	}
+	if err := compressor.Close(); err != nil {
+		return eris.Wrap(err, "error closing zstd writer")
+	}
+	return nil
+}
+
+// backupSQLite copies the database into a temporary file and writes it into the archive.
+func (s *Backuper) backupSQLite(ctx context.Context, archive *tar.Writer) ([]backupRun, error) {
+	dir, err := os.MkdirTemp("", "fml-backup-*")
+	if err != nil {
+		return nil, eris.Wrap(err, "error creating temporary directory")
+	}
+	//nolint:errcheck
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, backupSQLiteFile)
+	snapshot, err := openSQLiteCopy(s.db.Dsn(), file)
+	if err != nil {
+		return nil, err
+	}
+	//nolint:errcheck
+	defer snapshot.Close()
+
+	if err := copySQLiteDatabase(ctx, snapshot.GormDB(), s.db.GormDB()); err != nil {
+		return nil, eris.Wrap(err, "error copying database")
+	}
+
+	manifest, runs, err := s.readSQLiteSnapshot(snapshot.GormDB().WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if manifest.Encrypted, err = isEncryptedSQLite(s.db.Dsn()); err != nil {
+		return nil, err
+	}
+	// the copy has to be closed first, so the file contains all the pages.
+	if err := snapshot.Close(); err != nil {
+		return nil, eris.Wrap(err, "error closing database copy")
+	}
+
+	if err := writeBackupManifest(archive, manifest); err != nil {
+		return nil, err
+	}
+	if err := writeArchiveFileFrom(archive, backupSQLiteFile, file); err != nil {
+		return nil, eris.Wrap(err, "error writing database")
+	}
+	log.Infof("Backing up SQLite database with schema version %s", manifest.SchemaVersion)
+	return runs, nil
+}
+
+// backupPostgres dumps all the tables inside one repeatable read transaction into the archive.
+func (s *Backuper) backupPostgres(ctx context.Context, archive *tar.Writer) ([]backupRun, error) {
+	var runs []backupRun
+	if err := withPgxConn(ctx, s.db.GormDB(), func(conn *pgx.Conn) error {
+		return pgx.BeginTxFunc(ctx, conn, pgx.TxOptions{
+			IsoLevel:   pgx.RepeatableRead,
+			AccessMode: pgx.ReadOnly,
+		}, func(tx pgx.Tx) error {
+			// the transaction snapshot is taken by the first query, so everything is read from the same snapshot.
+			var schemaVersion, alembicVersion string
+			if err := tx.QueryRow(ctx, "SELECT version FROM schema_version").Scan(&schemaVersion); err != nil {
+				return eris.Wrap(err, "error getting schema version")
+			}
+			if err := tx.QueryRow(ctx, "SELECT version_num FROM alembic_version").Scan(&alembicVersion); err != nil {
+				return eris.Wrap(err, "error getting alembic version")
+			}
+			manifest := s.newManifest(postgres.Dialector{}.Name(), schemaVersion, alembicVersion)
+
+			if s.artifactStorage != nil {
+				rows, err := tx.Query(
+					ctx, "SELECT run_uuid, artifact_uri FROM runs WHERE artifact_uri != '' ORDER BY run_uuid",
+				)
+				if err != nil {
+					return eris.Wrap(err, "error getting runs")
+				}
+				allRuns, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (backupRun, error) {
+					var run backupRun
+					if err := row.Scan(&run.ID, &run.ArtifactURI); err != nil {
+						return backupRun{}, err
+					}
+					return run, nil
+				})
+				if err != nil {
+					return eris.Wrap(err, "error getting runs")
+				}
+				runs = filterLocalArtifactRuns(allRuns)
+			}
+
+			var err error
+			if manifest.Tables, err = getPostgresBackupTables(ctx, tx); err != nil {
+				return err
+			}
+
+			if err := writeBackupManifest(archive, manifest); err != nil {
+				return err
+			}
+			for _, table := range manifest.Tables {
+				name := backupDataDir + table.Name + backupDataExtension
+				if err := writeArchiveFile(archive, name, func(w io.Writer) error {
+					tag, err := tx.Conn().PgConn().CopyTo(ctx, w, fmt.Sprintf(
+						"COPY (SELECT %s FROM %s) TO STDOUT", quotePostgresIdentifiers(table.Columns),
+						pgx.Identifier{table.Name}.Sanitize(),
+					))
+					if err != nil {
+						return eris.Wrap(err, "error copying table")
+					}
+					log.Infof("Backing up %s - found %d records", table.Name, tag.RowsAffected())
+					return nil
+				}); err != nil {
+					return eris.Wrapf(err, "error backing up table %s", table.Name)
+				}
+			}
+			log.Infof("Backing up Postgres database with schema version %s", manifest.SchemaVersion)
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// readSQLiteSnapshot reads the schema versions and the runs with local artifacts from the database copy.
+func (s *Backuper) readSQLiteSnapshot(db *gorm.DB) (*BackupManifest, []backupRun, error) {
+	var schemaVersion SchemaVersion
+	if err := db.First(&schemaVersion).Error; err != nil {
+		return nil, nil, eris.Wrap(err, "error getting schema version")
+	}
+	var alembicVersion AlembicVersion
+	if err := db.First(&alembicVersion).Error; err != nil {
+		return nil, nil, eris.Wrap(err, "error getting alembic version")
+	}
+	manifest := s.newManifest(sqlite.Dialector{}.Name(), schemaVersion.Version, alembicVersion.Version)
+
+	if s.artifactStorage == nil {
+		return manifest, nil, nil
+	}
+	var runs []backupRun
+	if err := db.Table("runs").Select(
+		"run_uuid", "artifact_uri",
+	).Where(
+		"artifact_uri != ''",
+	).Order(
+		"run_uuid",
+	).Scan(&runs).Error; err != nil {
+		return nil, nil, eris.Wrap(err, "error getting runs")
+	}
+	return manifest, filterLocalArtifactRuns(runs), nil
+}
+
+// newManifest creates the manifest of the backup.
+func (s *Backuper) newManifest(dialect, schemaVersion, alembicVersion string) *BackupManifest {
+	return &BackupManifest{
+		FormatVersion:      BackupFormatVersion,
+		FastTrackMLVersion: version.Version,
+		Dialect:            dialect,
+		SchemaVersion:      schemaVersion,
+		AlembicVersion:     alembicVersion,
+		CreatedAt:          time.Now().UTC(),
+		Artifacts:          s.artifactStorage != nil,
+	}
+}
+
+// filterLocalArtifactRuns returns the runs, which artifacts are kept by the local artifact storage.
+// Runs waiting for the purge are the part of the snapshot, so their artifacts are kept too.
+func filterLocalArtifactRuns(runs []backupRun) []backupRun {
+	localRuns := make([]backupRun, 0, len(runs))
+	for _, run := range runs {
+		if isLocalArtifactURI(run.ArtifactURI) {
+			localRuns = append(localRuns, run)
+		} else {
+			log.Debugf("Skipping artifacts of run %s stored in %s", run.ID, run.ArtifactURI)
+		}
+	}
+	return localRuns
+}
+
+// getPostgresBackupTables returns the tables of the current schema with their columns in dependency order.
+func getPostgresBackupTables(ctx context.Context, tx pgx.Tx) ([]BackupTable, error) {
+	// partitions are dumped together with the partitioned table.
+	rows, err := tx.Query(ctx, `
+		SELECT c.relname FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = current_schema() AND c.relkind IN ('r', 'p') AND NOT c.relispartition
+		ORDER BY c.relname`,
+	)
+	if err != nil {
+		return nil, eris.Wrap(err, "error getting tables")
+	}
+	names, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, eris.Wrap(err, "error getting tables")
+	}
+
+	dependencies := map[string][]string{}
+	var table, referenced string
+	rows, err = tx.Query(ctx, `
+		SELECT t.relname, r.relname FROM pg_constraint c
+		JOIN pg_class t ON t.oid = c.conrelid
+		JOIN pg_class r ON r.oid = c.confrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		WHERE n.nspname = current_schema() AND c.contype = 'f'`,
+	)
+	if err != nil {
+		return nil, eris.Wrap(err, "error getting foreign keys")
+	}
+	if _, err := pgx.ForEachRow(rows, []any{&table, &referenced}, func() error {
+		if table != referenced {
+			dependencies[table] = append(dependencies[table], referenced)
+		}
+		return nil
+	}); err != nil {
+		return nil, eris.Wrap(err, "error getting foreign keys")
+	}
+
+	names, err = sortTablesByDependencies(names, dependencies)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make([]BackupTable, len(names))
+	for i, name := range names {
+		rows, err := tx.Query(ctx, `
+			SELECT column_name FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = $1 AND is_generated = 'NEVER'
+			ORDER BY ordinal_position`,
+			name,
+		)
+		if err != nil {
+			return nil, eris.Wrapf(err, "error getting columns of table %s", name)
+		}
+		columns, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			return nil, eris.Wrapf(err, "error getting columns of table %s", name)
+		}
+		tables[i] = BackupTable{Name: name, Columns: columns}
+	}
+	return tables, nil
+}
+
+// sortTablesByDependencies orders the tables, so the referenced tables go before the tables referencing them.
+func sortTablesByDependencies(tables []string, dependencies map[string][]string) ([]string, error) {
+	sorted := make([]string, 0, len(tables))
+	pending := slices.Clone(tables)
+	for len(pending) > 0 {
+		var next []string
+		for _, table := range pending {
+			ready := true
+			for _, dependency := range dependencies[table] {
+				if slices.Contains(tables, dependency) && !slices.Contains(sorted, dependency) {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				sorted = append(sorted, table)
+			} else {
+				next = append(next, table)
+			}
+		}
+		if len(next) == len(pending) {
+			return nil, eris.Errorf("circular foreign keys between tables %s", strings.Join(next, ", "))
+		}
+		pending = next
+	}
+	return sorted, nil
+}
+
+// writeBackupManifest writes the manifest as the first entry of the archive.
+func writeBackupManifest(archive *tar.Writer, manifest *BackupManifest) error {
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return eris.Wrap(err, "error marshaling manifest")
+	}
+	if err := writeArchiveEntry(archive, backupManifestFile, int64(len(content))); err != nil {
+		return eris.Wrap(err, "error writing manifest header")
+	}
+	if _, err := archive.Write(content); err != nil {
+		return eris.Wrap(err, "error writing manifest")
+	}
+	return nil
+}
+
+// writeArchiveFileFrom writes the content of the local file into the archive.
+func writeArchiveFileFrom(archive *tar.Writer, name, file string) error {
+	// #nosec G304
+	reader, err := os.Open(file)
+	if err != nil {
+		return eris.Wrapf(err, "error opening %s", file)
+	}
+	//nolint:errcheck
+	defer reader.Close()
+	info, err := reader.Stat()
+	if err != nil {
+		return eris.Wrapf(err, "error getting size of %s", file)
+	}
+	if err := writeArchiveEntry(archive, name, info.Size()); err != nil {
+		return eris.Wrapf(err, "error writing header of %s", name)
+	}
+	if _, err := io.Copy(archive, reader); err != nil {
+		return eris.Wrapf(err, "error writing %s", name)
+	}
+	return nil
+}
+
+// openSQLiteCopy opens the SQLite database in the file using the encryption key of the original database.
+func openSQLiteCopy(dsn, file string) (DBProvider, error) {
+	dsnURL, err := url.Parse(dsn)
+	if err != nil {
+		return nil, eris.Wrap(err, "invalid database URL")
+	}
+	copyURL := url.URL{Scheme: SQLiteSchemaName, Path: file}
+	if key := dsnURL.Query().Get("_key"); key != "" {
+		copyURL.RawQuery = url.Values{"_key": []string{key}}.Encode()
+	}
+	db, err := NewDBProvider(copyURL.String(), time.Second*1, 1)
+	if err != nil {
+		return nil, eris.Wrap(err, "error opening database copy")
+	}
+	return db, nil
+}
+
+// isEncryptedSQLite makes check that the SQLite database is encrypted by SQLCipher.
+func isEncryptedSQLite(dsn string) (bool, error) {
+	dsnURL, err := url.Parse(dsn)
+	if err != nil {
+		return false, eris.Wrap(err, "invalid database URL")
+	}
+	return dsnURL.Query().Get("_key") != "", nil
+}
+
+// copySQLiteDatabase replaces the content of the destination database by the content of the source
+// database using the SQLite online backup API. All the pages are copied in one step, so the copy
+// is consistent even when the source database is being modified.
+func copySQLiteDatabase(ctx context.Context, destination, source *gorm.DB) error {
+	return withSQLiteConn(ctx, destination, func(destinationConn *sqlite3.SQLiteConn) error {
+		return withSQLiteConn(ctx, source, func(sourceConn *sqlite3.SQLiteConn) error {
+			backup, err := destinationConn.Backup(backupSQLiteDatabase, sourceConn, backupSQLiteDatabase)
+			if err != nil {
+				return eris.Wrap(err, "error starting backup")
+			}
+			done, err := backup.Step(-1)
+			if err != nil {
+				//nolint:errcheck,gosec
+				backup.Finish()
+				return eris.Wrap(err, "error copying pages")
+			}
+			if !done {
+				//nolint:errcheck,gosec
+				backup.Finish()
+				return eris.New("error copying pages, backup is not complete")
+			}
+			if err := backup.Finish(); err != nil {
+				return eris.Wrap(err, "error finishing backup")
+			}
+			return nil
+		})
+	})
+}
+
+// withSQLiteConn runs the function with the underlying connection of the SQLite database.
+func withSQLiteConn(ctx context.Context, db *gorm.DB, f func(conn *sqlite3.SQLiteConn) error) error {
+	conn, err := getConn(ctx, db)
+	if err != nil {
+		return err
+	}
+	//nolint:errcheck
+	defer conn.Close()
+	return conn.Raw(func(driverConn any) error {
+		sqliteConn, ok := driverConn.(*sqlite3.SQLiteConn)
+		if !ok {
+			return eris.New("error getting underlying driver connection. driver connection has no type *sqlite3.SQLiteConn")
+		}
+		return f(sqliteConn)
+	})
+}
+
+// withPgxConn runs the function with the underlying pgx connection of the Postgres database.
+func withPgxConn(ctx context.Context, db *gorm.DB, f func(conn *pgx.Conn) error) error {
+	conn, err := getConn(ctx, db)
+	if err != nil {
+		return err
+	}
+	//nolint:errcheck
+	defer conn.Close()
+	return conn.Raw(func(driverConn any) error {
+		stdlibConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return eris.New("error getting underlying driver connection. driver connection has no type *stdlib.Conn")
+		}
+		return f(stdlibConn.Conn())
+	})
+}
+
+// getConn returns the dedicated connection of the database.
+func getConn(ctx context.Context, db *gorm.DB) (*sql.Conn, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, eris.Wrap(err, "error getting database connection pool")
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, eris.Wrap(err, "error getting database connection")
+	}
+	return conn, nil
+}
+
+// quotePostgresIdentifiers returns the comma separated list of the quoted identifiers.
+func quotePostgresIdentifiers(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = pgx.Identifier{name}.Sanitize()
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// isLocalArtifactURI makes check that the artifacts are kept by the local artifact storage.
+func isLocalArtifactURI(artifactURI string) bool {
+	u, err := url.Parse(artifactURI)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "" || u.Scheme == storage.LocalStorageName
+}
@@ -0,0 +1,361 @@
+package database
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/klauspost/compress/zstd"
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+)
+
+// Restorer will handle the restore of the backup created by Backuper.
+type Restorer struct {
+	db              DBProvider
+	overwrite       bool
+	artifactStorage storage.ArtifactStorageProvider
+	artifactURIs    map[string]string
+}
+
+// NewRestorer initializes a Restorer.
+func NewRestorer(db DBProvider, options ...func(restorer *Restorer)) *Restorer {
+	restorer := Restorer{
+		db:           db,
+		artifactURIs: map[string]string{},
+	}
+	for _, o := range options {
+		o(&restorer)
+	}
+	return &restorer
+}
+
+// WithRestoreOverwrite allows Restorer to replace the content of the existing database.
+func WithRestoreOverwrite() func(restorer *Restorer) {
+	return func(s *Restorer) {
+		s.overwrite = true
+	}
+}
+
+// WithRestoreArtifacts enables restore of the run artifacts using provided local artifact storage.
+func WithRestoreArtifacts(artifactStorage storage.ArtifactStorageProvider) func(restorer *Restorer) {
+	return func(s *Restorer) {
+		s.artifactStorage = artifactStorage
+	}
+}
+
+// Restore reads the backup and replaces the content of the database by it. The database schema
+// of the backup is checked by CheckAndMigrateDB before anything is written into the database.
+func (s *Restorer) Restore(ctx context.Context, reader io.Reader) error {
+	decompressor, err := zstd.NewReader(reader)
+	if err != nil {
+		return eris.Wrap(err, "error creating zstd reader")
+	}
+	defer decompressor.Close()
+	archive := tar.NewReader(decompressor)
+
+	// 1. read and check the manifest. it is always the first archive entry.
+	header, err := archive.Next()
+	if err != nil {
+		return eris.Wrap(err, "error reading backup")
+	}
+	if header.Name != backupManifestFile {
+		return eris.Errorf("unexpected backup entry %s, backup has to start with %s", header.Name, backupManifestFile)
+	}
+	var manifest BackupManifest
+	if err := json.NewDecoder(archive).Decode(&manifest); err != nil {
+		return eris.Wrap(err, "error decoding manifest")
+	}
+	if manifest.FormatVersion < 1 || manifest.FormatVersion > BackupFormatVersion {
+		return eris.Errorf("unsupported backup format version %d", manifest.FormatVersion)
+	}
+	if dialect := s.db.GormDB().Dialector.Name(); manifest.Dialect != dialect {
+		return eris.Errorf(
+			"backup of %s database can't be restored into %s database, please use export and import commands",
+			manifest.Dialect, dialect,
+		)
+	}
+	// Postgres tables are restored into the schema created by this version of FastTrackML.
+	if manifest.Dialect == (postgres.Dialector{}).Name() && manifest.SchemaVersion != currentVersion() {
+		return eris.Errorf(
+			"unsupported backup schema version %s, current schema version is %s",
+			manifest.SchemaVersion, currentVersion(),
+		)
+	}
+	log.Infof(
+		"Restoring %s database backup with schema version %s created at %s",
+		manifest.Dialect, manifest.SchemaVersion, manifest.CreatedAt,
+	)
+
+	if !s.overwrite && !s.isEmpty(ctx) {
+		return eris.New("database is not empty, it has to be overwritten explicitly")
+	}
+
+	// 2. restore the database.
+	header, err = archive.Next()
+	switch {
+	case err != nil && !errors.Is(err, io.EOF):
+		return eris.Wrap(err, "error reading backup")
+	case manifest.Dialect == (sqlite.Dialector{}).Name():
+		if err != nil || header.Name != backupSQLiteFile {
+			return eris.Errorf("backup doesn't contain %s", backupSQLiteFile)
+		}
+		if err := s.restoreSQLite(ctx, archive, &manifest); err != nil {
+			return eris.Wrap(err, "error restoring database")
+		}
+		header, err = archive.Next()
+	default:
+		header, err = s.restorePostgres(ctx, archive, &manifest, header, err)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return eris.Wrap(err, "error restoring database")
+		}
+	}
+	if err := CheckAndMigrateDB(false, s.db.GormDB().WithContext(ctx)); err != nil {
+		return eris.Wrap(err, "error checking restored database schema")
+	}
+
+	// 3. process the artifacts.
+	if manifest.Artifacts && s.artifactStorage == nil {
+		log.Info("Backup contains artifacts, but artifacts restore is not enabled, skipping them")
+	}
+	for ; err == nil; header, err = archive.Next() {
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if !strings.HasPrefix(header.Name, backupArtifactsDir) {
+			log.Warnf("Skipping unknown backup entry %s", header.Name)
+			continue
+		}
+		if err := s.restoreArtifact(ctx, strings.TrimPrefix(header.Name, backupArtifactsDir), archive); err != nil {
+			return eris.Wrapf(err, "error restoring artifact %s", header.Name)
+		}
+	}
+	if !errors.Is(err, io.EOF) {
+		return eris.Wrap(err, "error reading backup")
+	}
+	return nil
+}
+
+// isEmpty makes check that the database has no FastTrackML schema yet.
+func (s *Restorer) isEmpty(ctx context.Context) bool {
+	return !s.db.GormDB().WithContext(ctx).Migrator().HasTable(&SchemaVersion{})
+}
+
+// restoreSQLite checks and migrates the backed up SQLite database in a temporary file,
+// and then copies it into the database using the online backup API.
+func (s *Restorer) restoreSQLite(ctx context.Context, reader io.Reader, manifest *BackupManifest) error {
+	dir, err := os.MkdirTemp("", "fml-restore-*")
+	if err != nil {
+		return eris.Wrap(err, "error creating temporary directory")
+	}
+	//nolint:errcheck
+	defer os.RemoveAll(dir)
+
+	// SQLCipher can't copy pages between encrypted and plain databases.
+	encrypted, err := isEncryptedSQLite(s.db.Dsn())
+	if err != nil {
+		return err
+	}
+	if encrypted != manifest.Encrypted {
+		return eris.New("encrypted backup has to be restored into encrypted database with the same key and vice versa")
+	}
+
+	file := filepath.Join(dir, backupSQLiteFile)
+	// #nosec G304
+	writer, err := os.Create(file)
+	if err != nil {
+		return eris.Wrap(err, "error creating temporary file")
+	}
+	//nolint:errcheck
+	defer writer.Close()
+	if _, err := io.Copy(writer, reader); err != nil {
+		return eris.Wrap(err, "error writing temporary file")
+	}
+	if err := writer.Close(); err != nil {
+		return eris.Wrap(err, "error closing temporary file")
+	}
+
+	snapshot, err := openSQLiteCopy(s.db.Dsn(), file)
+	if err != nil {
+		return err
+	}
+	//nolint:errcheck
+	defer snapshot.Close()
+	if err := CheckAndMigrateDB(true, snapshot.GormDB().WithContext(ctx)); err != nil {
+		return eris.Wrap(err, "error checking backup schema")
+	}
+
+	// all the pages of the database are replaced by the backup API, so the database isn't reset.
+	if err := copySQLiteDatabase(ctx, s.db.GormDB(), snapshot.GormDB()); err != nil {
+		return eris.Wrap(err, "error copying database")
+	}
+	return nil
+}
+
+// restorePostgres creates the schema and copies the backed up tables into it inside one transaction.
+// The existing schema is dropped by the same transaction, so the database stays unchanged
+// when the backup can't be restored. It returns the first archive entry, which doesn't belong to the tables.
+func (s *Restorer) restorePostgres(
+	ctx context.Context, archive *tar.Reader, manifest *BackupManifest, header *tar.Header, err error,
+) (*tar.Header, error) {
+	columns := make(map[string][]string, len(manifest.Tables))
+	for _, table := range manifest.Tables {
+		columns[table.Name] = table.Columns
+	}
+	reset := !s.isEmpty(ctx)
+
+	// gorm and pgx share the dedicated connection, so the schema and the copied tables belong to one transaction.
+	conn, connErr := getConn(ctx, s.db.GormDB())
+	if connErr != nil {
+		return nil, connErr
+	}
+	//nolint:errcheck
+	defer conn.Close()
+	db := s.db.GormDB().Session(&gorm.Session{NewDB: true, Context: ctx})
+	db.Statement.ConnPool = conn
+
+	if txErr := db.Transaction(func(tx *gorm.DB) error {
+		if reset {
+			log.Info("Resetting database schema")
+			if err := tx.Exec("DROP SCHEMA public CASCADE").Error; err != nil {
+				return eris.Wrap(err, "error dropping database schema")
+			}
+			if err := tx.Exec("CREATE SCHEMA public").Error; err != nil {
+				return eris.Wrap(err, "error creating database schema")
+			}
+		}
+		if err := CheckAndMigrateDB(true, tx); err != nil {
+			return eris.Wrap(err, "error creating database schema")
+		}
+		tables, tablesErr := tx.Migrator().GetTables()
+		if tablesErr != nil {
+			return eris.Wrap(tablesErr, "error getting database tables")
+		}
+
+		return conn.Raw(func(driverConn any) error {
+			stdlibConn, ok := driverConn.(*stdlib.Conn)
+			if !ok {
+				return eris.New("error getting underlying driver connection. driver connection has no type *stdlib.Conn")
+			}
+			pgxConn := stdlibConn.Conn()
+			for ; err == nil && strings.HasPrefix(header.Name, backupDataDir); header, err = archive.Next() {
+				table := strings.TrimSuffix(strings.TrimPrefix(header.Name, backupDataDir), backupDataExtension)
+				switch table {
+				// versions are created together with the schema.
+				case SchemaVersion{}.TableName(), AlembicVersion{}.TableName():
+					continue
+				}
+				tableColumns, ok := columns[table]
+				if !ok {
+					return eris.Errorf("unknown table %s", table)
+				}
+				if !slices.Contains(tables, table) {
+					log.Warnf("Skipping unknown table %s", table)
+					continue
+				}
+				tag, err := pgxConn.PgConn().CopyFrom(ctx, archive, fmt.Sprintf(
+					"COPY %s (%s) FROM STDIN",
+					pgx.Identifier{table}.Sanitize(), quotePostgresIdentifiers(tableColumns),
+				))
+				if err != nil {
+					return eris.Wrapf(err, "error restoring table %s", table)
+				}
+				log.Infof("Restoring %s - found %d records", table, tag.RowsAffected())
+			}
+			if err != nil && !errors.Is(err, io.EOF) {
+				return eris.Wrap(err, "error reading backup")
+			}
+			return resetPostgresSequences(ctx, pgxConn)
+		})
+	}); txErr != nil {
+		return nil, txErr
+	}
+	return header, err
+}
+
+// resetPostgresSequences moves the sequences of the restored tables after the restored values.
+func resetPostgresSequences(ctx context.Context, conn *pgx.Conn) error {
+	rows, err := conn.Query(ctx, `
+		SELECT table_name, column_name, pg_get_serial_sequence(quote_ident(table_name), column_name)
+		FROM information_schema.columns
+		WHERE table_schema = current_schema()
+		AND pg_get_serial_sequence(quote_ident(table_name), column_name) IS NOT NULL`,
+	)
+	if err != nil {
+		return eris.Wrap(err, "error getting sequences")
+	}
+	type sequence struct {
+		table, column, name string
+	}
+	sequences, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (sequence, error) {
+		var s sequence
+		if err := row.Scan(&s.table, &s.column, &s.name); err != nil {
+			return sequence{}, err
+		}
+		return s, nil
+	})
+	if err != nil {
+		return eris.Wrap(err, "error getting sequences")
+	}
+
+	for _, s := range sequences {
+		if _, err := conn.Exec(ctx, fmt.Sprintf(
+			"SELECT setval($1, COALESCE((SELECT MAX(%s) FROM %s), 0) + 1, false)",
+			pgx.Identifier{s.column}.Sanitize(), pgx.Identifier{s.table}.Sanitize(),
+		), s.name); err != nil {
+			return eris.Wrapf(err, "error resetting sequence %s", s.name)
+		}
+	}
+	return nil
+}
+
+// restoreArtifact writes one backed up artifact object of the run into the artifact storage.
+func (s *Restorer) restoreArtifact(ctx context.Context, name string, reader io.Reader) error {
+	if s.artifactStorage == nil {
+		return nil
+	}
+	runID, artifactPath, err := splitArtifactEntry(name)
+	if err != nil {
+		return err
+	}
+
+	artifactURI, ok := s.artifactURIs[runID]
+	if !ok {
+		var runs []backupRun
+		if err := s.db.GormDB().WithContext(ctx).Table("runs").Select(
+			"run_uuid", "artifact_uri",
+		).Where(
+			"run_uuid = ?", runID,
+		).Scan(&runs).Error; err != nil {
+			return eris.Wrapf(err, "error getting run %s", runID)
+		}
+		if len(runs) == 0 {
+			log.Warnf("Skipping artifacts of unknown run %s", runID)
+		} else {
+			artifactURI = runs[0].ArtifactURI
+		}
+		s.artifactURIs[runID] = artifactURI
+	}
+	if artifactURI == "" {
+		return nil
+	}
+
+	if err := s.artifactStorage.Put(ctx, artifactURI, artifactPath, reader); err != nil {
+		return eris.Wrapf(err, "error putting artifact %s", artifactPath)
+	}
+	return nil
+}
@@ -0,0 +1,450 @@
+package database
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/version"
+)
+
+// Exporter will handle the export of namespace data into portable archive.
+type Exporter struct {
+	db                     *gorm.DB
+	namespaceCode          string
+	namespace              *Namespace
+	artifactStorageFactory storage.ArtifactStorageFactoryProvider
+}
+
+// NewExporter initializes an Exporter.
+func NewExporter(db *gorm.DB, namespaceCode string, options ...func(exporter *Exporter)) *Exporter {
+	exporter := Exporter{
+		db:            db,
+		namespaceCode: namespaceCode,
+	}
+	for _, o := range options {
+		o(&exporter)
+	}
+	return &exporter
+}
+
+// WithExportArtifacts enables export of run artifacts using provided artifact storage factory.
+func WithExportArtifacts(artifactStorageFactory storage.ArtifactStorageFactoryProvider) func(exporter *Exporter) {
+	return func(s *Exporter) {
+		s.artifactStorageFactory = artifactStorageFactory
+	}
+}
+
+// Export writes the namespace data as zstd compressed tar archive into the writer.
+func (s *Exporter) Export(ctx context.Context, writer io.Writer) error {
+	// 1. find the namespace and current schema version.
+	var namespace Namespace
+	if err := s.db.WithContext(ctx).Where("code = ?", s.namespaceCode).First(&namespace).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return eris.Wrapf(err, "namespace %s not found", s.namespaceCode)
+		}
+		return eris.Wrapf(err, "error getting namespace %s", s.namespaceCode)
+	}
+	s.namespace = &namespace
+
+	var schemaVersion SchemaVersion
+	if err := s.db.WithContext(ctx).First(&schemaVersion).Error; err != nil {
+		return eris.Wrap(err, "error getting schema version")
+	}
+	if schemaVersion.Version != currentVersion() {
+		return eris.Errorf(
+			"unsupported database schema version %s, please run the server to migrate the database first",
+			schemaVersion.Version,
+		)
+	}
+
+	// 2. write the manifest and the tables.
+	compressor, err := zstd.NewWriter(writer)
+	if err != nil {
+		return eris.Wrap(err, "error creating zstd writer")
+	}
+	archive := tar.NewWriter(compressor)
+
+	manifest, err := marshalArchiveManifest(&ArchiveManifest{
+		FormatVersion:      ArchiveFormatVersion,
+		FastTrackMLVersion: version.Version,
+		SchemaVersion:      schemaVersion.Version,
+		CreatedAt:          time.Now().UTC(),
+		Namespace: ArchiveNamespace{
+			Code:        namespace.Code,
+			Description: namespace.Description,
+		},
+		Tables:    archiveTables,
+		Artifacts: s.artifactStorageFactory != nil,
+	})
+	if err != nil {
+		return eris.Wrap(err, "error marshaling manifest")
+	}
+	if err := writeArchiveEntry(archive, archiveManifestFile, int64(len(manifest))); err != nil {
+		return eris.Wrap(err, "error writing manifest header")
+	}
+	if _, err := archive.Write(manifest); err != nil {
+		return eris.Wrap(err, "error writing manifest")
+	}
+
+	for _, table := range archiveTables {
+		if err := writeArchiveFile(archive, archiveDataDir+table+archiveDataExtension, func(w io.Writer) error {
+			count, err := s.exportTable(ctx, table, json.NewEncoder(w))
+			if err != nil {
+				return err
+			}
+			log.Infof("Exporting %s - found %d records", table, count)
+			return nil
+		}); err != nil {
+			return eris.Wrapf(err, "error exporting table %s", table)
+		}
+	}
+
+	// 3. write the artifacts, if requested.
+	if s.artifactStorageFactory != nil {
+		if err := s.exportArtifacts(ctx, archive); err != nil {
+			return eris.Wrap(err, "error exporting artifacts")
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return eris.Wrap(err, "error closing tar writer")
+	}
+	if err := compressor.Close(); err != nil {
+		return eris.Wrap(err, "error closing zstd writer")
+	}
+	return nil
+}
+
+// exportTable writes all the namespace records of the table as json lines.
+func (s *Exporter) exportTable(ctx context.Context, table string, encoder *json.Encoder) (int, error) {
+	db := s.db.WithContext(ctx)
+	switch table {
+	case "experiments":
+		return exportRows(
+			s.scope(table, db.Model(&Experiment{})), encoder, func(item Experiment) any {
+				return archiveExperiment{
+					ID:               *item.ID,
+					Name:             item.Name,
+					ArtifactLocation: item.ArtifactLocation,
+					LifecycleStage:   item.LifecycleStage,
+					CreationTime:     nullInt64ToPointer(item.CreationTime),
+					LastUpdateTime:   nullInt64ToPointer(item.LastUpdateTime),
+					IsDefault: s.namespace.DefaultExperimentID != nil &&
+						*item.ID == *s.namespace.DefaultExperimentID,
+				}
+			},
+		)
+	case "experiment_tags":
+		return exportRows(
+			s.scope(table, db.Model(&ExperimentTag{})), encoder, func(item ExperimentTag) any {
+				return archiveExperimentTag{
+					ExperimentID: item.ExperimentID,
+					Key:          item.Key,
+					Value:        item.Value,
+				}
+			},
+		)
+	case "runs":
+		return exportRows(
+			s.scope(table, db.Model(&Run{})), encoder, func(item Run) any {
+				return archiveRun{
+					ID:             item.ID,
+					Name:           item.Name,
+					SourceType:     item.SourceType,
+					SourceName:     item.SourceName,
+					EntryPointName: item.EntryPointName,
+					UserID:         item.UserID,
+					Status:         item.Status,
+					StartTime:      nullInt64ToPointer(item.StartTime),
+					EndTime:        nullInt64ToPointer(item.EndTime),
+					SourceVersion:  item.SourceVersion,
+					LifecycleStage: item.LifecycleStage,
+					ArtifactURI:    item.ArtifactURI,
+					ExperimentID:   item.ExperimentID,
+					DeletedTime:    nullInt64ToPointer(item.DeletedTime),
+				}
+			},
+		)
+	case "tags":
+		return exportRows(
+			s.scope(table, db.Model(&Tag{})), encoder, func(item Tag) any {
+				return archiveTag{
+					RunID: item.RunID,
+					Key:   item.Key,
+					Value: item.Value,
+				}
+			},
+		)
+	case "params":
+		return exportRows(
+			s.scope(table, db.Model(&Param{})), encoder, func(item Param) any {
+				return archiveParam{
+					RunID:      item.RunID,
+					Key:        item.Key,
+					ValueStr:   item.ValueStr,
+					ValueInt:   item.ValueInt,
+					ValueFloat: item.ValueFloat,
+				}
+			},
+		)
+	case "contexts":
+		// contexts are shared between namespaces, so export only the ones referenced by the namespace metrics.
+		return exportRows(
+			db.Model(&Context{}).Where(
+				"id IN (?)", EntityLimitedByNamespace(
+					"metrics", db.Model(&Metric{}).Select("metrics.context_id"), s.namespace,
+				),
+			), encoder, func(item Context) any {
+				return archiveContext{
+					ID:   item.ID,
+					Json: item.Json,
+				}
+			},
+		)
+	case "metrics":
+		return exportRows(
+			s.scope(table, db.Model(&Metric{})), encoder, func(item Metric) any {
+				return archiveMetric{
+					RunID:     item.RunID,
+					Key:       item.Key,
+					Value:     item.Value,
+					Timestamp: item.Timestamp,
+					Step:      item.Step,
+					IsNan:     item.IsNan,
+					Iter:      item.Iter,
+					ContextID: item.ContextID,
+				}
+			},
+		)
+	case "latest_metrics":
+		return exportRows(
+			s.scope(table, db.Model(&LatestMetric{})), encoder, func(item LatestMetric) any {
+				return archiveLatestMetric{
+					RunID:     item.RunID,
+					Key:       item.Key,
+					Value:     item.Value,
+					Timestamp: item.Timestamp,
+					Step:      item.Step,
+					IsNan:     item.IsNan,
+					LastIter:  item.LastIter,
+					ContextID: item.ContextID,
+				}
+			},
+		)
+	case "logs":
+		return exportRows(
+			s.scope(table, db.Model(&Log{})).Order("logs.id"), encoder, func(item Log) any {
+				return archiveLog{
+					RunID:     item.RunID,
+					Value:     item.Value,
+					Timestamp: item.Timestamp,
+				}
+			},
+		)
+	case "apps":
+		return exportRows(
+			s.scope(table, db.Model(&App{})), encoder, func(item App) any {
+				return archiveApp{
+					ID:         item.ID,
+					Type:       item.Type,
+					State:      item.State,
+					IsArchived: item.IsArchived,
+					CreatedAt:  item.CreatedAt,
+					UpdatedAt:  item.UpdatedAt,
+				}
+			},
+		)
+	case "dashboards":
+		return exportRows(
+			s.scope(table, db.Model(&Dashboard{})), encoder, func(item Dashboard) any {
+				return archiveDashboard{
+					ID:          item.ID,
+					AppID:       item.AppID,
+					Name:        item.Name,
+					Description: item.Description,
+					IsArchived:  item.IsArchived,
+					CreatedAt:   item.CreatedAt,
+					UpdatedAt:   item.UpdatedAt,
+				}
+			},
+		)
+	case "shared_tags":
+		return exportRows(
+			s.scope(table, db.Model(&SharedTag{})), encoder, func(item SharedTag) any {
+				return archiveSharedTag{
+					ID:          item.ID,
+					Name:        item.Name,
+					Color:       item.Color,
+					Description: item.Description,
+					IsArchived:  item.IsArchived,
+				}
+			},
+		)
+	case "run_shared_tags":
+		return exportRows(
+			s.scope(table, db.Model(&RunSharedTag{})), encoder, func(item RunSharedTag) any {
+				return archiveRunSharedTag{
+					RunID:       item.RunID,
+					SharedTagID: item.SharedTagID,
+				}
+			},
+		)
+	default:
+		return 0, eris.Errorf("unsupported table %s", table)
+	}
+}
+
+// exportArtifacts writes artifacts of each namespace run under `artifacts/<run_uuid>/` directory.
+func (s *Exporter) exportArtifacts(ctx context.Context, archive *tar.Writer) error {
+	var runs []Run
+	if err := s.scope(
+		"runs", s.db.WithContext(ctx).Model(&Run{}),
+	).Where(
+		"runs.artifact_uri != ''",
+	).Find(&runs).Error; err != nil {
+		return eris.Wrap(err, "error getting runs")
+	}
+
+	ctx = storage.WithNamespace(ctx, s.namespace.Code)
+	for _, run := range runs {
+		artifactStorage, err := s.artifactStorageFactory.GetStorage(ctx, run.ArtifactURI)
+		if err != nil {
+			return eris.Wrapf(err, "error getting artifact storage for run %s", run.ID)
+		}
+		count, err := writeRunArtifacts(
+			ctx, archive, artifactStorage, path.Join(archiveArtifactsDir, run.ID), run.ArtifactURI, "",
+		)
+		if err != nil {
+			return eris.Wrapf(err, "error exporting artifacts of run %s", run.ID)
+		}
+		log.Debugf("Exporting artifacts of run %s - found %d objects", run.ID, count)
+	}
+	return nil
+}
+
+// writeRunArtifacts recursively writes artifact objects of the run under provided directory
+// of the archive.
+func writeRunArtifacts(
+	ctx context.Context,
+	archive *tar.Writer,
+	artifactStorage storage.ArtifactStorageProvider,
+	archiveDir, artifactURI, dir string,
+) (int, error) {
+	objects, err := artifactStorage.List(ctx, artifactURI, dir)
+	if err != nil {
+		return 0, eris.Wrapf(err, "error listing artifacts under path %q", dir)
+	}
+
+	count := 0
+	for _, object := range objects {
+		if object.IsDir {
+			nested, err := writeRunArtifacts(ctx, archive, artifactStorage, archiveDir, artifactURI, object.Path)
+			if err != nil {
+				return 0, err
+			}
+			count += nested
+			continue
+		}
+
+		name := path.Join(archiveDir, object.Path)
+		if err := writeArchiveFile(archive, name, func(w io.Writer) error {
+			reader, err := artifactStorage.Get(ctx, artifactURI, object.Path)
+			if err != nil {
+				return eris.Wrapf(err, "error getting artifact %q", object.Path)
+			}
+			//nolint:errcheck
+			defer reader.Close()
+			if _, err := io.Copy(w, reader); err != nil {
+				return eris.Wrapf(err, "error reading artifact %q", object.Path)
+			}
+			return nil
+		}); err != nil {
+			return 0, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// scope limits the query by the exported namespace. The data of the deleted runs waiting
+// for the purge is not exported, the same as the runs themselves.
+func (s *Exporter) scope(table string, db *gorm.DB) *gorm.DB {
+	switch table {
+	case "tags", "params", "metrics", "latest_metrics", "logs":
+		db = db.Where(fmt.Sprintf(
+			"%s.run_uuid NOT IN (SELECT run_uuid FROM runs WHERE deleted_at IS NOT NULL)", table,
+		))
+	}
+	return EntityLimitedByNamespace(table, db.Select(fmt.Sprintf("%s.*", table)), s.namespace)
+}
+
+// exportRows encodes each row returned by the query as json line.
+func exportRows[T any](query *gorm.DB, encoder *json.Encoder, convert func(item T) any) (int, error) {
+	rows, err := query.Rows()
+	if err != nil {
+		return 0, eris.Wrap(err, "error creating rows instance")
+	}
+	if err := rows.Err(); err != nil {
+		return 0, eris.Wrap(err, "error getting query result")
+	}
+	//nolint:errcheck
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		var item T
+		if err := query.ScanRows(rows, &item); err != nil {
+			return 0, eris.Wrap(err, "error scanning row")
+		}
+		if err := encoder.Encode(convert(item)); err != nil {
+			return 0, eris.Wrap(err, "error encoding row")
+		}
+		count++
+	}
+	return count, nil
+}
+
+// writeArchiveFile writes the content produced by write function as the archive file. Tar requires
+// the size of each file in advance, so the content is buffered in a temporary file first.
+func writeArchiveFile(archive *tar.Writer, name string, write func(w io.Writer) error) error {
+	file, err := os.CreateTemp("", "fml-export-*")
+	if err != nil {
+		return eris.Wrap(err, "error creating temporary file")
+	}
+	//nolint:errcheck
+	defer os.Remove(file.Name())
+	//nolint:errcheck
+	defer file.Close()
+
+	if err := write(file); err != nil {
+		return err
+	}
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return eris.Wrap(err, "error getting temporary file size")
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return eris.Wrap(err, "error rewinding temporary file")
+	}
+
+	if err := writeArchiveEntry(archive, name, size); err != nil {
+		return eris.Wrapf(err, "error writing header of %s", name)
+	}
+	if _, err := io.Copy(archive, file); err != nil {
+		return eris.Wrapf(err, "error writing %s", name)
+	}
+	return nil
+}
@@ -1,11 +1,14 @@
 package database
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
+	"net/url"
 	"reflect"
 	"regexp"
 	"slices"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/rotisserie/eris"
@@ -14,6 +17,7 @@ import (
 	"gorm.io/gorm/clause"
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 )
 
 type experimentInfo struct {
@@ -313,6 +317,67 @@ func (s *Importer) updateNamespaceDefaultExperiment() error {
 	return err
 }
 
+// GetOrCreateNamespace finds the namespace by code or creates it together with the default experiment.
+func GetOrCreateNamespace(db *gorm.DB, code, description, defaultArtifactRoot string) (*Namespace, error) {
+	var namespace Namespace
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("code = ?", code).First(&namespace).Error
+		switch {
+		case err == nil:
+			return nil
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return eris.Wrap(err, "error getting namespace")
+		}
+
+		namespace = Namespace{
+			Code:                code,
+			Description:         description,
+			DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+		}
+		if err := tx.Create(&namespace).Error; err != nil {
+			return eris.Wrap(err, "error creating namespace")
+		}
+
+		timestamp := time.Now().UTC().UnixMilli()
+		experiment := Experiment{
+			Name:           models.DefaultExperimentName,
+			NamespaceID:    namespace.ID,
+			LifecycleStage: LifecycleStageActive,
+			CreationTime:   sql.NullInt64{Int64: timestamp, Valid: true},
+			LastUpdateTime: sql.NullInt64{Int64: timestamp, Valid: true},
+		}
+		if err := tx.Omit(clause.Associations).Create(&experiment).Error; err != nil {
+			return eris.Wrap(err, "error creating default experiment")
+		}
+		if err := updateExperimentArtifactLocation(tx, &experiment, defaultArtifactRoot); err != nil {
+			return err
+		}
+
+		namespace.DefaultExperimentID = experiment.ID
+		if err := tx.Model(&namespace).Update("default_experiment_id", experiment.ID).Error; err != nil {
+			return eris.Wrap(err, "error updating namespace default experiment")
+		}
+		log.Infof("Created namespace %s", code)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return &namespace, nil
+}
+
+// updateExperimentArtifactLocation sets artifact location of newly created experiment under the default artifact root.
+func updateExperimentArtifactLocation(tx *gorm.DB, experiment *Experiment, defaultArtifactRoot string) error {
+	location, err := url.JoinPath(defaultArtifactRoot, fmt.Sprintf("%d", *experiment.ID))
+	if err != nil {
+		return eris.Wrapf(err, "error creating artifact_location for experiment '%s'", experiment.Name)
+	}
+	experiment.ArtifactLocation = location
+	if err := tx.Model(experiment).Update("artifact_location", location).Error; err != nil {
+		return eris.Wrapf(err, "error updating artifact_location for experiment '%s'", experiment.Name)
+	}
+	return nil
+}
+
 // ApplyNamespaceRestriction overwrite Namespace if it is needed.
 func ApplyNamespaceRestriction(table string, item map[string]any, namespace *Namespace) map[string]any {
 	if namespace != nil {
@@ -343,7 +408,13 @@ func EntityLimitedByNamespace(table string, db *gorm.DB, namespace *Namespace) *
 			).Where(
 				"experiments.namespace_id = ?", namespace.ID,
 			)
-		case "tags", "params", "metrics", "latest_metrics":
+		case "experiment_tags":
+			return db.Joins(
+				"LEFT JOIN experiments ON experiments.experiment_id = experiment_tags.experiment_id",
+			).Where(
+				"experiments.namespace_id = ?", namespace.ID,
+			)
+		case "tags", "params", "metrics", "latest_metrics", "logs":
 			return db.Joins(
 				fmt.Sprintf("LEFT JOIN runs ON runs.run_uuid = %s.run_uuid", table),
 			).Joins(
@@ -150,6 +150,19 @@ func CheckAndMigrateDB(migrate bool, db *gorm.DB) error {
 				&SchemaVersion{},
 				&Log{},
 				&Artifact{},
+				&RegisteredModel{},
+				&RegisteredModelTag{},
+				&RegisteredModelAlias{},
+				&ModelVersion{},
+				&ModelVersionTag{},
+				&APIToken{},
+				&AuditEvent{},
+				&MetricRetentionPolicy{},
+				&RunPurge{},
+				&Dataset{},
+				&Input{},
+				&InputTag{},
+				&Export{},
 			); err != nil {
 				return fmt.Errorf("error initializing database: %w", err)
 			}
@@ -24,10 +24,19 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/database/migrations/v_0015"
 	"github.com/G-Research/fasttrackml/pkg/database/migrations/v_0016"
 	"github.com/G-Research/fasttrackml/pkg/database/migrations/v_0017"
+	"github.com/G-Research/fasttrackml/pkg/database/migrations/v_0018"
+	"github.com/G-Research/fasttrackml/pkg/database/migrations/v_0019"
+	"github.com/G-Research/fasttrackml/pkg/database/migrations/v_0020"
+	"github.com/G-Research/fasttrackml/pkg/database/migrations/v_0021"
+	"github.com/G-Research/fasttrackml/pkg/database/migrations/v_0022"
+	"github.com/G-Research/fasttrackml/pkg/database/migrations/v_0023"
+	"github.com/G-Research/fasttrackml/pkg/database/migrations/v_0024"
+	"github.com/G-Research/fasttrackml/pkg/database/migrations/v_0025"
+	"github.com/G-Research/fasttrackml/pkg/database/migrations/v_0026"
 )
 
 func currentVersion() string {
-	return v_0017.Version
+	return v_0026.Version
 }
 
 func generatedMigrations(db *gorm.DB, schemaVersion string) error {
@@ -149,6 +158,69 @@ func generatedMigrations(db *gorm.DB, schemaVersion string) error {
 		if err := v_0017.Migrate(db); err != nil {
 			return fmt.Errorf("error migrating database to FastTrackML schema %s: %w", v_0017.Version, err)
 		}
+		fallthrough
+
+	case v_0017.Version:
+		log.Infof("Migrating database to FastTrackML schema %s", v_0018.Version)
+		if err := v_0018.Migrate(db); err != nil {
+			return fmt.Errorf("error migrating database to FastTrackML schema %s: %w", v_0018.Version, err)
+		}
+		fallthrough
+
+	case v_0018.Version:
+		log.Infof("Migrating database to FastTrackML schema %s", v_0019.Version)
+		if err := v_0019.Migrate(db); err != nil {
+			return fmt.Errorf("error migrating database to FastTrackML schema %s: %w", v_0019.Version, err)
+		}
+		fallthrough
+
+	case v_0019.Version:
+		log.Infof("Migrating database to FastTrackML schema %s", v_0020.Version)
+		if err := v_0020.Migrate(db); err != nil {
+			return fmt.Errorf("error migrating database to FastTrackML schema %s: %w", v_0020.Version, err)
+		}
+		fallthrough
+
+	case v_0020.Version:
+		log.Infof("Migrating database to FastTrackML schema %s", v_0021.Version)
+		if err := v_0021.Migrate(db); err != nil {
+			return fmt.Errorf("error migrating database to FastTrackML schema %s: %w", v_0021.Version, err)
+		}
+		fallthrough
+
+	case v_0021.Version:
+		log.Infof("Migrating database to FastTrackML schema %s", v_0022.Version)
+		if err := v_0022.Migrate(db); err != nil {
+			return fmt.Errorf("error migrating database to FastTrackML schema %s: %w", v_0022.Version, err)
+		}
+		fallthrough
+
+	case v_0022.Version:
+		log.Infof("Migrating database to FastTrackML schema %s", v_0023.Version)
+		if err := v_0023.Migrate(db); err != nil {
+			return fmt.Errorf("error migrating database to FastTrackML schema %s: %w", v_0023.Version, err)
+		}
+		fallthrough
+
+	case v_0023.Version:
+		log.Infof("Migrating database to FastTrackML schema %s", v_0024.Version)
+		if err := v_0024.Migrate(db); err != nil {
+			return fmt.Errorf("error migrating database to FastTrackML schema %s: %w", v_0024.Version, err)
+		}
+		fallthrough
+
+	case v_0024.Version:
+		log.Infof("Migrating database to FastTrackML schema %s", v_0025.Version)
+		if err := v_0025.Migrate(db); err != nil {
+			return fmt.Errorf("error migrating database to FastTrackML schema %s: %w", v_0025.Version, err)
+		}
+		fallthrough
+
+	case v_0025.Version:
+		log.Infof("Migrating database to FastTrackML schema %s", v_0026.Version)
+		if err := v_0026.Migrate(db); err != nil {
+			return fmt.Errorf("error migrating database to FastTrackML schema %s: %w", v_0026.Version, err)
+		}
 
 	default:
 		return fmt.Errorf("unsupported database FastTrackML schema version %s", schemaVersion)
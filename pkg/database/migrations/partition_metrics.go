@@ -0,0 +1,148 @@
+package migrations
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// metricsTable is the name of the table which is partitioned.
+const metricsTable = "metrics"
+
+// tableConstraint represents a constraint of the table together with its definition.
+type tableConstraint struct {
+	Name       string
+	Definition string
+}
+
+// PartitionMetricsTable converts the `metrics` table to the table partitioned by hash of `run_uuid` with the
+// given number of partitions. It is an opt-in migration, which is supported only by Postgres, and is not part of
+// the generated migrations, so schema version is not changed. Constraints and indexes of the table are recreated
+// with the same names and definitions, so the generated migrations keep working with the partitioned table.
+//
+// Partitions don't need to be maintained, because each run is routed to one of the partitions created upfront,
+// and queries filtering metrics by `run_uuid` are pruned to the partitions of the requested runs.
+func PartitionMetricsTable(db *gorm.DB, partitions int) error {
+	if db.Dialector.Name() != (postgres.Dialector{}).Name() {
+		return fmt.Errorf("partitioning of %s table is supported only by postgres", metricsTable)
+	}
+	if partitions < 2 {
+		return fmt.Errorf("number of partitions should be at least 2, got %d", partitions)
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var existingPartitions int
+		if err := tx.Raw(
+			`SELECT COUNT(inhrelid) FROM pg_partitioned_table
+			LEFT JOIN pg_inherits ON pg_inherits.inhparent = pg_partitioned_table.partrelid
+			WHERE pg_partitioned_table.partrelid = ?::regclass`,
+			metricsTable,
+		).Scan(&existingPartitions).Error; err != nil {
+			return fmt.Errorf("error checking partitions of %s table: %w", metricsTable, err)
+		}
+		switch {
+		case existingPartitions == partitions:
+			log.Infof("Table %s is already partitioned into %d partitions", metricsTable, partitions)
+			return nil
+		case existingPartitions > 0:
+			return fmt.Errorf(
+				"table %s is already partitioned into %d partitions", metricsTable, existingPartitions,
+			)
+		}
+
+		var referencingTables []string
+		if err := tx.Raw(
+			"SELECT conrelid::regclass::text FROM pg_constraint WHERE confrelid = ?::regclass", metricsTable,
+		).Scan(&referencingTables).Error; err != nil {
+			return fmt.Errorf("error checking foreign keys referencing %s table: %w", metricsTable, err)
+		}
+		if len(referencingTables) > 0 {
+			return fmt.Errorf(
+				"table %s is referenced by foreign keys of %s tables",
+				metricsTable, strings.Join(referencingTables, ", "),
+			)
+		}
+
+		// primary key has to be created before foreign keys, so constraints are ordered by their type.
+		// NOT NULL constraints are copied together with the columns.
+		var constraints []tableConstraint
+		if err := tx.Raw(
+			`SELECT conname AS name, pg_get_constraintdef(oid) AS definition FROM pg_constraint
+			WHERE conrelid = ?::regclass AND contype <> 'n'
+			ORDER BY CASE contype WHEN 'p' THEN 0 WHEN 'u' THEN 1 ELSE 2 END, conname`,
+			metricsTable,
+		).Scan(&constraints).Error; err != nil {
+			return fmt.Errorf("error getting constraints of %s table: %w", metricsTable, err)
+		}
+
+		// indexes backing the constraints are recreated together with the constraints.
+		var indexes []tableConstraint
+		if err := tx.Raw(
+			`SELECT idx.relname AS name, pg_get_indexdef(pg_index.indexrelid) AS definition FROM pg_index
+			JOIN pg_class idx ON idx.oid = pg_index.indexrelid
+			WHERE pg_index.indrelid = ?::regclass
+			AND NOT EXISTS (SELECT 1 FROM pg_constraint WHERE pg_constraint.conindid = pg_index.indexrelid)
+			ORDER BY idx.relname`,
+			metricsTable,
+		).Scan(&indexes).Error; err != nil {
+			return fmt.Errorf("error getting indexes of %s table: %w", metricsTable, err)
+		}
+
+		log.Infof("Partitioning %s table into %d partitions", metricsTable, partitions)
+		statements := []string{
+			fmt.Sprintf("LOCK TABLE %s IN ACCESS EXCLUSIVE MODE", metricsTable),
+			fmt.Sprintf("ALTER TABLE %s RENAME TO %s_unpartitioned", metricsTable, metricsTable),
+		}
+		// release the names of constraints and indexes, so they can be used by the partitioned table.
+		for _, constraint := range constraints {
+			statements = append(statements, fmt.Sprintf(
+				`ALTER TABLE %s_unpartitioned DROP CONSTRAINT "%s"`, metricsTable, constraint.Name,
+			))
+		}
+		for _, index := range indexes {
+			statements = append(statements, fmt.Sprintf(`DROP INDEX "%s"`, index.Name))
+		}
+		statements = append(statements, fmt.Sprintf(
+			"CREATE TABLE %s (LIKE %s_unpartitioned INCLUDING DEFAULTS) PARTITION BY HASH (run_uuid)",
+			metricsTable, metricsTable,
+		))
+		for i := 0; i < partitions; i++ {
+			statements = append(statements, fmt.Sprintf(
+				"CREATE TABLE %s_p%d PARTITION OF %s FOR VALUES WITH (MODULUS %d, REMAINDER %d)",
+				metricsTable, i, metricsTable, partitions, i,
+			))
+		}
+		statements = append(
+			statements,
+			fmt.Sprintf("INSERT INTO %s SELECT * FROM %s_unpartitioned", metricsTable, metricsTable),
+			fmt.Sprintf("DROP TABLE %s_unpartitioned", metricsTable),
+		)
+		for _, constraint := range constraints {
+			statements = append(statements, fmt.Sprintf(
+				`ALTER TABLE %s ADD CONSTRAINT "%s" %s`, metricsTable, constraint.Name, constraint.Definition,
+			))
+		}
+		for _, index := range indexes {
+			// index definition refers to the renamed table, so the table name is replaced.
+			create, rest, ok := strings.Cut(index.Definition, " ON ")
+			if ok {
+				_, rest, ok = strings.Cut(rest, " USING ")
+			}
+			if !ok {
+				return fmt.Errorf("unsupported definition of index %s: %s", index.Name, index.Definition)
+			}
+			statements = append(statements, fmt.Sprintf("%s ON %s USING %s", create, metricsTable, rest))
+		}
+		statements = append(statements, fmt.Sprintf("ANALYZE %s", metricsTable))
+
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return fmt.Errorf("error partitioning %s table: %w", metricsTable, err)
+			}
+		}
+		return nil
+	})
+}
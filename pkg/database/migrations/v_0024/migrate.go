@@ -0,0 +1,31 @@
+package v_0024
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/database/migrations"
+)
+
+const Version = "20261019104733"
+
+func Migrate(db *gorm.DB) error {
+	return migrations.RunWithoutForeignKeyIfNeeded(db, func() error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Migrator().AddColumn(&Run{}, "DeletedAt"); err != nil {
+				return err
+			}
+			if err := tx.Migrator().CreateIndex(&Run{}, "DeletedAt"); err != nil {
+				return err
+			}
+			if err := tx.Migrator().AutoMigrate(&RunPurge{}); err != nil {
+				return err
+			}
+
+			// Update the schema version
+			return tx.Model(&SchemaVersion{}).
+				Where("1 = 1").
+				Update("Version", Version).
+				Error
+		})
+	})
+}
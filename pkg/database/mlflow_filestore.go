@@ -0,0 +1,507 @@
+package database
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+)
+
+// MLflow FileStore layout.
+const (
+	mlflowFileStoreMetaFile     = "meta.yaml"
+	mlflowFileStoreTrashDir     = ".trash"
+	mlflowFileStoreTagsDir      = "tags"
+	mlflowFileStoreParamsDir    = "params"
+	mlflowFileStoreMetricsDir   = "metrics"
+	mlflowFileStoreArtifactsDir = "artifacts"
+)
+
+// mlflowFileStoreRunStatuses maps MLflow RunStatus enum values to run statuses.
+var mlflowFileStoreRunStatuses = map[int]Status{
+	1: StatusRunning,
+	2: StatusScheduled,
+	3: StatusFinished,
+	4: StatusFailed,
+	5: StatusKilled,
+}
+
+// mlflowFileStoreSourceTypes maps MLflow SourceType enum values to run source types.
+var mlflowFileStoreSourceTypes = map[int]string{
+	1:    "NOTEBOOK",
+	2:    "JOB",
+	3:    "PROJECT",
+	4:    "LOCAL",
+	1000: "UNKNOWN",
+}
+
+// mlflowFileStoreExperiment represents experiment `meta.yaml` file.
+type mlflowFileStoreExperiment struct {
+	ArtifactLocation string `yaml:"artifact_location"`
+	ExperimentID     string `yaml:"experiment_id"`
+	LifecycleStage   string `yaml:"lifecycle_stage"`
+	Name             string `yaml:"name"`
+	CreationTime     *int64 `yaml:"creation_time"`
+	LastUpdateTime   *int64 `yaml:"last_update_time"`
+}
+
+// mlflowFileStoreRun represents run `meta.yaml` file.
+type mlflowFileStoreRun struct {
+	ArtifactURI    string `yaml:"artifact_uri"`
+	EndTime        *int64 `yaml:"end_time"`
+	EntryPointName string `yaml:"entry_point_name"`
+	ExperimentID   string `yaml:"experiment_id"`
+	LifecycleStage string `yaml:"lifecycle_stage"`
+	RunID          string `yaml:"run_id"`
+	RunUUID        string `yaml:"run_uuid"`
+	RunName        string `yaml:"run_name"`
+	SourceName     string `yaml:"source_name"`
+	SourceType     int    `yaml:"source_type"`
+	SourceVersion  string `yaml:"source_version"`
+	StartTime      *int64 `yaml:"start_time"`
+	Status         int    `yaml:"status"`
+	UserID         string `yaml:"user_id"`
+	DeletedTime    *int64 `yaml:"deleted_time"`
+}
+
+// MlflowFileStoreImporter will handle the import of MLflow FileStore (`mlruns` directory) into destination db.
+type MlflowFileStoreImporter struct {
+	db                  *gorm.DB
+	path                string
+	defaultArtifactRoot string
+	namespaceCode       string
+	namespace           *Namespace
+	contextID           uint
+}
+
+// NewMlflowFileStoreImporter initializes a MlflowFileStoreImporter.
+func NewMlflowFileStoreImporter(
+	db *gorm.DB, path, defaultArtifactRoot string, options ...func(importer *MlflowFileStoreImporter),
+) *MlflowFileStoreImporter {
+	importer := MlflowFileStoreImporter{
+		db:                  db,
+		path:                path,
+		defaultArtifactRoot: defaultArtifactRoot,
+		namespaceCode:       models.DefaultNamespaceCode,
+	}
+	for _, o := range options {
+		o(&importer)
+	}
+	return &importer
+}
+
+// WithMlflowFileStoreNamespace sets MlflowFileStoreImporter destination Namespace.
+func WithMlflowFileStoreNamespace(namespace string) func(importer *MlflowFileStoreImporter) {
+	return func(s *MlflowFileStoreImporter) {
+		s.namespaceCode = namespace
+	}
+}
+
+// Import copies experiments and runs of MLflow FileStore into the destination namespace.
+// Each run is imported in its own transaction and runs which already exist in the destination db
+// are skipped, so the import could be resumed after the failure.
+func (s *MlflowFileStoreImporter) Import(ctx context.Context) error {
+	// 1. prepare destination namespace and metric context.
+	root, err := filepath.Abs(s.path)
+	if err != nil {
+		return eris.Wrapf(err, "error getting absolute path of %s", s.path)
+	}
+	s.path = root
+
+	namespace, err := GetOrCreateNamespace(s.db.WithContext(ctx), s.namespaceCode, "", s.defaultArtifactRoot)
+	if err != nil {
+		return eris.Wrapf(err, "error getting namespace %s", s.namespaceCode)
+	}
+	s.namespace = namespace
+
+	defaultContext := Context{Json: types.JSONB("{}")}
+	if err := s.db.WithContext(ctx).Where(&defaultContext).FirstOrCreate(&defaultContext).Error; err != nil {
+		return eris.Wrap(err, "error getting default metric context")
+	}
+	s.contextID = defaultContext.ID
+
+	// 2. find the experiments. deleted experiments are moved into `.trash` directory by MLflow.
+	var experimentDirs []string
+	for _, dir := range []string{root, filepath.Join(root, mlflowFileStoreTrashDir)} {
+		dirs, err := findMlflowFileStoreEntities(dir)
+		if err != nil {
+			return eris.Wrapf(err, "error reading experiments from %s", dir)
+		}
+		experimentDirs = append(experimentDirs, dirs...)
+	}
+	if len(experimentDirs) == 0 {
+		return eris.Errorf("no experiments found in %s", root)
+	}
+
+	// 3. import the experiments one by one together with their runs.
+	for _, dir := range experimentDirs {
+		if err := s.importExperiment(ctx, dir); err != nil {
+			return eris.Wrapf(err, "error importing experiment from %s", dir)
+		}
+	}
+	return nil
+}
+
+// importExperiment finds the experiment with the same name in the destination namespace or creates a new one
+// and imports its runs. The MLflow default experiment is merged into the destination default experiment.
+func (s *MlflowFileStoreImporter) importExperiment(ctx context.Context, dir string) error {
+	var meta mlflowFileStoreExperiment
+	if err := readMlflowFileStoreMeta(dir, &meta); err != nil {
+		return err
+	}
+
+	db := s.db.WithContext(ctx)
+	var experiment Experiment
+	if meta.ExperimentID == fmt.Sprintf("%d", models.DefaultExperimentID) {
+		if err := db.First(&experiment, *s.namespace.DefaultExperimentID).Error; err != nil {
+			return eris.Wrap(err, "error getting default experiment")
+		}
+	} else {
+		if err := db.Omit(clause.Associations).Where(
+			Experiment{Name: meta.Name, NamespaceID: s.namespace.ID},
+		).Attrs(Experiment{
+			ArtifactLocation: s.translateArtifactLocation(meta.ArtifactLocation, dir),
+			LifecycleStage:   LifecycleStage(meta.LifecycleStage),
+			CreationTime:     pointerToNullInt64(meta.CreationTime),
+			LastUpdateTime:   pointerToNullInt64(meta.LastUpdateTime),
+		}).FirstOrCreate(&experiment).Error; err != nil {
+			return eris.Wrapf(err, "error creating experiment %s", meta.Name)
+		}
+	}
+
+	tags, err := readMlflowFileStoreValues(filepath.Join(dir, mlflowFileStoreTagsDir))
+	if err != nil {
+		return eris.Wrap(err, "error reading experiment tags")
+	}
+	for key, value := range tags {
+		if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&ExperimentTag{
+			ExperimentID: *experiment.ID,
+			Key:          key,
+			Value:        value,
+		}).Error; err != nil {
+			return eris.Wrapf(err, "error creating experiment tag %s", key)
+		}
+	}
+
+	runDirs, err := findMlflowFileStoreEntities(dir)
+	if err != nil {
+		return eris.Wrap(err, "error reading runs")
+	}
+	imported := 0
+	for _, runDir := range runDirs {
+		ok, err := s.importRun(ctx, &experiment, runDir)
+		if err != nil {
+			return eris.Wrapf(err, "error importing run from %s", runDir)
+		}
+		if ok {
+			imported++
+		}
+	}
+	log.Infof(
+		"Importing experiment %s - found %d runs, imported %d runs", meta.Name, len(runDirs), imported,
+	)
+	return nil
+}
+
+// importRun imports the run together with its params, tags and metrics in one transaction.
+// It returns false when the run already exists in the destination db.
+func (s *MlflowFileStoreImporter) importRun(ctx context.Context, experiment *Experiment, dir string) (bool, error) {
+	var meta mlflowFileStoreRun
+	if err := readMlflowFileStoreMeta(dir, &meta); err != nil {
+		return false, err
+	}
+	runID := meta.RunUUID
+	if runID == "" {
+		runID = meta.RunID
+	}
+
+	tags, err := readMlflowFileStoreValues(filepath.Join(dir, mlflowFileStoreTagsDir))
+	if err != nil {
+		return false, eris.Wrap(err, "error reading tags")
+	}
+	params, err := readMlflowFileStoreValues(filepath.Join(dir, mlflowFileStoreParamsDir))
+	if err != nil {
+		return false, eris.Wrap(err, "error reading params")
+	}
+	metrics, latestMetrics, err := s.readMetrics(runID, filepath.Join(dir, mlflowFileStoreMetricsDir))
+	if err != nil {
+		return false, eris.Wrap(err, "error reading metrics")
+	}
+
+	name := meta.RunName
+	if name == "" {
+		name = tags["mlflow.runName"]
+	}
+	sourceType, ok := mlflowFileStoreSourceTypes[meta.SourceType]
+	if !ok {
+		sourceType = "UNKNOWN"
+	}
+	status, ok := mlflowFileStoreRunStatuses[meta.Status]
+	if !ok {
+		return false, eris.Errorf("unsupported run status %d", meta.Status)
+	}
+
+	imported := false
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&Run{
+			ID:             runID,
+			Name:           name,
+			SourceType:     sourceType,
+			SourceName:     meta.SourceName,
+			EntryPointName: meta.EntryPointName,
+			UserID:         meta.UserID,
+			Status:         status,
+			StartTime:      pointerToNullInt64(meta.StartTime),
+			EndTime:        pointerToNullInt64(meta.EndTime),
+			SourceVersion:  meta.SourceVersion,
+			LifecycleStage: LifecycleStage(meta.LifecycleStage),
+			ArtifactURI:    s.translateArtifactLocation(meta.ArtifactURI, filepath.Join(dir, mlflowFileStoreArtifactsDir)),
+			ExperimentID:   *experiment.ID,
+			DeletedTime:    pointerToNullInt64(meta.DeletedTime),
+		})
+		if result.Error != nil {
+			return eris.Wrap(result.Error, "error creating run")
+		}
+		if result.RowsAffected == 0 {
+			log.Debugf("Run %s already exists, skipping it", runID)
+			return nil
+		}
+
+		for key, value := range tags {
+			if err := tx.Create(&Tag{RunID: runID, Key: key, Value: value}).Error; err != nil {
+				return eris.Wrapf(err, "error creating tag %s", key)
+			}
+		}
+		for key, value := range params {
+			if err := tx.Create(&Param{RunID: runID, Key: key, ValueStr: &value}).Error; err != nil {
+				return eris.Wrapf(err, "error creating param %s", key)
+			}
+		}
+		if len(metrics) > 0 {
+			if err := tx.Omit(clause.Associations).Clauses(
+				clause.OnConflict{DoNothing: true},
+			).CreateInBatches(&metrics, archiveImportBatchSize).Error; err != nil {
+				return eris.Wrap(err, "error creating metrics")
+			}
+			if err := tx.Omit(clause.Associations).CreateInBatches(
+				&latestMetrics, archiveImportBatchSize,
+			).Error; err != nil {
+				return eris.Wrap(err, "error creating latest metrics")
+			}
+		}
+		imported = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return imported, nil
+}
+
+// readMetrics reads metric history files. Each line of the file has `timestamp value [step]` format.
+func (s *MlflowFileStoreImporter) readMetrics(runID, dir string) ([]Metric, []LatestMetric, error) {
+	files, err := findMlflowFileStoreFiles(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var metrics []Metric
+	var latestMetrics []LatestMetric
+	for key, file := range files {
+		lines, err := readMlflowFileStoreLines(file)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var latest *Metric
+		for i, line := range lines {
+			fields := strings.Fields(line)
+			if len(fields) < 2 || len(fields) > 3 {
+				return nil, nil, eris.Errorf("invalid metric line %q of metric %s", line, key)
+			}
+			metric := Metric{
+				Key:       key,
+				RunID:     runID,
+				Iter:      int64(i + 1),
+				ContextID: s.contextID,
+			}
+			if metric.Timestamp, err = strconv.ParseInt(fields[0], 10, 64); err != nil {
+				return nil, nil, eris.Wrapf(err, "invalid timestamp of metric %s", key)
+			}
+			value, err := strconv.ParseFloat(fields[1], 64)
+			if err != nil {
+				return nil, nil, eris.Wrapf(err, "invalid value of metric %s", key)
+			}
+			switch {
+			case math.IsNaN(value):
+				metric.IsNan = true
+			case math.IsInf(value, 1):
+				metric.Value = math.MaxFloat64
+			case math.IsInf(value, -1):
+				metric.Value = -math.MaxFloat64
+			default:
+				metric.Value = value
+			}
+			if len(fields) == 3 {
+				if metric.Step, err = strconv.ParseInt(fields[2], 10, 64); err != nil {
+					return nil, nil, eris.Wrapf(err, "invalid step of metric %s", key)
+				}
+			}
+			metrics = append(metrics, metric)
+
+			if latest == nil || isNewerMetric(&metric, latest) {
+				latest = &metric
+			}
+		}
+		if latest != nil {
+			latestMetrics = append(latestMetrics, LatestMetric{
+				Key:       latest.Key,
+				Value:     latest.Value,
+				Timestamp: latest.Timestamp,
+				Step:      latest.Step,
+				IsNan:     latest.IsNan,
+				RunID:     runID,
+				LastIter:  int64(len(lines)),
+				ContextID: s.contextID,
+			})
+		}
+	}
+	return metrics, latestMetrics, nil
+}
+
+// isNewerMetric checks whether the metric should replace the latest metric. Like the tracking server does,
+// the metric with the highest step wins, then the one with the highest timestamp and then the highest value.
+func isNewerMetric(metric, latest *Metric) bool {
+	return metric.Step > latest.Step ||
+		(metric.Step == latest.Step && metric.Timestamp > latest.Timestamp) ||
+		(metric.Step == latest.Step && metric.Timestamp == latest.Timestamp && metric.Value > latest.Value)
+}
+
+// translateArtifactLocation points artifact location to the imported FileStore directory
+// when the artifacts are stored locally, otherwise the original location is kept.
+func (s *MlflowFileStoreImporter) translateArtifactLocation(location, dir string) string {
+	u, err := url.Parse(location)
+	if err != nil || (u.Scheme != "" && u.Scheme != "file") {
+		return location
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return location
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(dir)}).String()
+}
+
+// findMlflowFileStoreEntities returns subdirectories which contain `meta.yaml` file.
+func findMlflowFileStoreEntities(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, eris.Wrapf(err, "error reading directory %s", dir)
+	}
+
+	var dirs []string
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == mlflowFileStoreTrashDir {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, entry.Name(), mlflowFileStoreMetaFile)); err == nil {
+			dirs = append(dirs, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return dirs, nil
+}
+
+// findMlflowFileStoreFiles returns files of the directory by their keys. Keys could contain `/`,
+// so they are stored as nested directories by MLflow.
+func findMlflowFileStoreFiles(dir string) (map[string]string, error) {
+	files := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && path == dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		key, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(key)] = path
+		return nil
+	})
+	if err != nil {
+		return nil, eris.Wrapf(err, "error reading directory %s", dir)
+	}
+	return files, nil
+}
+
+// readMlflowFileStoreValues reads tag or param files of the directory into a key-value map.
+func readMlflowFileStoreValues(dir string) (map[string]string, error) {
+	files, err := findMlflowFileStoreFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(files))
+	for key, file := range files {
+		value, err := os.ReadFile(file)
+		if err != nil {
+			return nil, eris.Wrapf(err, "error reading file %s", file)
+		}
+		values[key] = string(value)
+	}
+	return values, nil
+}
+
+// readMlflowFileStoreMeta reads `meta.yaml` file of the directory.
+func readMlflowFileStoreMeta(dir string, meta any) error {
+	content, err := os.ReadFile(filepath.Join(dir, mlflowFileStoreMetaFile))
+	if err != nil {
+		return eris.Wrapf(err, "error reading %s", mlflowFileStoreMetaFile)
+	}
+	if err := yaml.Unmarshal(content, meta); err != nil {
+		return eris.Wrapf(err, "error parsing %s", mlflowFileStoreMetaFile)
+	}
+	return nil
+}
+
+// readMlflowFileStoreLines reads non-empty lines of the file.
+func readMlflowFileStoreLines(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error opening file %s", path)
+	}
+	//nolint:errcheck
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, eris.Wrapf(err, "error reading file %s", path)
+	}
+	return lines, nil
+}
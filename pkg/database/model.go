@@ -93,6 +93,7 @@ type Run struct {
 	ExperimentID   int32
 	Experiment     Experiment
 	DeletedTime    sql.NullInt64  `gorm:"type:bigint"`
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
 	RowNum         RowNum         `gorm:"<-:create;index"`
 	Params         []Param        `gorm:"constraint:OnDelete:CASCADE"`
 	Tags           []Tag          `gorm:"constraint:OnDelete:CASCADE"`
@@ -308,6 +309,100 @@ type RoleNamespace struct {
 	RoleID      uuid.UUID `gorm:"not null;index:,unique,composite:relation"`
 	Namespace   Namespace `gorm:"constraint:OnDelete:CASCADE"`
 	NamespaceID uint      `gorm:"not null;index:,unique,composite:relation"`
+	Permission  string    `gorm:"not null;default:'owner'"`
+}
+
+type APIToken struct {
+	Base
+	Name       string `gorm:"not null"`
+	TokenHash  string `gorm:"unique;index;not null"`
+	Prefix     string `gorm:"not null"`
+	Roles      string `gorm:"not null"`
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+type AuditEvent struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt     time.Time `gorm:"not null;index"`
+	Actor         string    `gorm:"not null;index"`
+	NamespaceCode string    `gorm:"index"`
+	Action        string    `gorm:"not null;index"`
+	Method        string    `gorm:"not null"`
+	Path          string    `gorm:"not null"`
+	TargetIDs     string
+	Request       string
+	StatusCode    int
+	RemoteAddress string
+}
+
+type MetricRetentionPolicy struct {
+	ID                 uuid.UUID   `gorm:"type:uuid;primaryKey"`
+	NamespaceID        uint        `gorm:"not null;index"`
+	Namespace          Namespace   `gorm:"constraint:OnDelete:CASCADE"`
+	ExperimentID       *int32      `gorm:"index"`
+	Experiment         *Experiment `gorm:"constraint:OnDelete:CASCADE"`
+	FullResolutionDays int         `gorm:"not null"`
+	KeepEvery          int         `gorm:"not null"`
+	KeepMinMax         bool        `gorm:"not null;default:false"`
+	LastAppliedAt      *time.Time
+	RemovedMetrics     int64 `gorm:"not null;default:0"`
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+type RunPurge struct {
+	RunID          string    `gorm:"column:run_uuid;type:varchar(32);primaryKey"`
+	NamespaceID    uint      `gorm:"not null;index"`
+	Status         string    `gorm:"type:varchar(20);not null"`
+	DeletedMetrics int64     `gorm:"not null;default:0"`
+	Attempts       int       `gorm:"not null;default:0"`
+	Error          string    `gorm:"type:varchar(1000)"`
+	RequestedAt    time.Time `gorm:"not null;index"`
+	UpdatedAt      time.Time
+}
+
+type Export struct {
+	ID           string    `gorm:"column:export_uuid;type:varchar(32);primaryKey"`
+	NamespaceID  uint      `gorm:"not null;index"`
+	Namespace    Namespace `gorm:"constraint:OnDelete:CASCADE"`
+	Table        string    `gorm:"column:export_table;type:varchar(20);not null"`
+	Format       string    `gorm:"type:varchar(20);not null"`
+	URI          string    `gorm:"type:varchar(1000);not null"`
+	Status       string    `gorm:"type:varchar(20);not null"`
+	Rows         int       `gorm:"not null;default:0"`
+	Error        string    `gorm:"type:varchar(1000)"`
+	CreationTime int64     `gorm:"not null"`
+	FinishTime   int64     `gorm:"index"`
+}
+
+//nolint:lll
+type Dataset struct {
+	ID           string     `gorm:"column:dataset_uuid;type:varchar(36);not null;index:index_datasets_dataset_uuid"`
+	ExperimentID int32      `gorm:"not null;primaryKey;index:index_datasets_experiment_id_dataset_source_type,priority:1"`
+	Experiment   Experiment `gorm:"constraint:OnDelete:CASCADE"`
+	Name         string     `gorm:"type:varchar(500);not null;primaryKey"`
+	Digest       string     `gorm:"type:varchar(36);not null;primaryKey"`
+	SourceType   string     `gorm:"column:dataset_source_type;type:varchar(36);not null;index:index_datasets_experiment_id_dataset_source_type,priority:2"`
+	Source       string     `gorm:"column:dataset_source;type:text;not null"`
+	Schema       string     `gorm:"column:dataset_schema;type:text"`
+	Profile      string     `gorm:"column:dataset_profile;type:text"`
+}
+
+//nolint:lll
+type Input struct {
+	ID              string `gorm:"column:input_uuid;type:varchar(36);not null;index:index_inputs_input_uuid"`
+	SourceType      string `gorm:"type:varchar(36);not null;primaryKey;index:index_inputs_destination_type_destination_id_source_type,priority:3"`
+	SourceID        string `gorm:"type:varchar(36);not null;primaryKey"`
+	DestinationType string `gorm:"type:varchar(36);not null;primaryKey;index:index_inputs_destination_type_destination_id_source_type,priority:1"`
+	DestinationID   string `gorm:"type:varchar(36);not null;primaryKey;index:index_inputs_destination_type_destination_id_source_type,priority:2"`
+}
+
+type InputTag struct {
+	InputID string `gorm:"column:input_uuid;type:varchar(36);not null;primaryKey"`
+	Name    string `gorm:"type:varchar(255);not null;primaryKey"`
+	Value   string `gorm:"type:varchar(500);not null"`
 }
 
 type Artifact struct {
@@ -323,4 +418,53 @@ type Artifact struct {
 	Format  string
 	Caption string
 	BlobURI string
+	Type    string `gorm:"not null;default:'images';index"`
+	Data    types.JSONB
+}
+
+type RegisteredModel struct {
+	Base
+	Name        string                 `gorm:"type:varchar(256);not null;index:,unique,composite:name"`
+	Description string                 `gorm:"type:varchar(5000)"`
+	UserID      string                 `gorm:"type:varchar(256)"`
+	NamespaceID uint                   `gorm:"not null;index:,unique,composite:name"`
+	Namespace   Namespace              `gorm:"constraint:OnDelete:CASCADE"`
+	Tags        []RegisteredModelTag   `gorm:"constraint:OnDelete:CASCADE"`
+	Aliases     []RegisteredModelAlias `gorm:"constraint:OnDelete:CASCADE"`
+	Versions    []ModelVersion         `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+type RegisteredModelTag struct {
+	Key               string    `gorm:"type:varchar(250);not null;primaryKey"`
+	Value             string    `gorm:"type:varchar(5000)"`
+	RegisteredModelID uuid.UUID `gorm:"type:uuid;not null;primaryKey"`
+}
+
+type RegisteredModelAlias struct {
+	Alias             string    `gorm:"type:varchar(256);not null;primaryKey"`
+	Version           int64     `gorm:"not null"`
+	RegisteredModelID uuid.UUID `gorm:"type:uuid;not null;primaryKey"`
+}
+
+//nolint:lll
+type ModelVersion struct {
+	Base
+	Version           int64             `gorm:"not null;index:,unique,composite:version"`
+	RegisteredModelID uuid.UUID         `gorm:"type:uuid;not null;index:,unique,composite:version"`
+	RegisteredModel   RegisteredModel   `gorm:"constraint:OnDelete:CASCADE"`
+	Description       string            `gorm:"type:varchar(5000)"`
+	UserID            string            `gorm:"type:varchar(256)"`
+	CurrentStage      string            `gorm:"type:varchar(20);check:current_stage IN ('None', 'Staging', 'Production', 'Archived')"`
+	Source            string            `gorm:"type:varchar(500)"`
+	RunID             string            `gorm:"column:run_uuid;type:varchar(32);index"`
+	RunLink           string            `gorm:"type:varchar(500)"`
+	Status            string            `gorm:"type:varchar(20);check:status IN ('PENDING_REGISTRATION', 'FAILED_REGISTRATION', 'READY')"`
+	StatusMessage     string            `gorm:"type:varchar(500)"`
+	Tags              []ModelVersionTag `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+type ModelVersionTag struct {
+	Key            string    `gorm:"type:varchar(250);not null;primaryKey"`
+	Value          string    `gorm:"type:varchar(5000)"`
+	ModelVersionID uuid.UUID `gorm:"type:uuid;not null;primaryKey"`
 }
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -30,6 +31,8 @@ import (
 	mlflowRepositories "github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
 	mlflowService "github.com/G-Research/fasttrackml/pkg/api/mlflow/services"
 	mlflowExperimentService "github.com/G-Research/fasttrackml/pkg/api/mlflow/services/experiment"
+	mlflowExportService "github.com/G-Research/fasttrackml/pkg/api/mlflow/services/export"
+	mlflowIngestionService "github.com/G-Research/fasttrackml/pkg/api/mlflow/services/ingestion"
 	mlflowMetricService "github.com/G-Research/fasttrackml/pkg/api/mlflow/services/metric"
 	mlflowModelService "github.com/G-Research/fasttrackml/pkg/api/mlflow/services/model"
 	mlflowRunService "github.com/G-Research/fasttrackml/pkg/api/mlflow/services/run"
@@ -37,13 +40,20 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/common/config"
 	"github.com/G-Research/fasttrackml/pkg/common/dao"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/metricstore"
 	"github.com/G-Research/fasttrackml/pkg/common/middleware"
 	artifactService "github.com/G-Research/fasttrackml/pkg/common/services/artifact"
 	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/common/services/audit"
+	"github.com/G-Research/fasttrackml/pkg/common/services/live"
 	"github.com/G-Research/fasttrackml/pkg/database"
 	adminUI "github.com/G-Research/fasttrackml/pkg/ui/admin"
 	adminUIController "github.com/G-Research/fasttrackml/pkg/ui/admin/controller"
+	adminUIAuditService "github.com/G-Research/fasttrackml/pkg/ui/admin/service/audit"
 	adminUINamespaceService "github.com/G-Research/fasttrackml/pkg/ui/admin/service/namespace"
+	adminUIPurgeService "github.com/G-Research/fasttrackml/pkg/ui/admin/service/purge"
+	adminUIRetentionService "github.com/G-Research/fasttrackml/pkg/ui/admin/service/retention"
+	adminUITokenService "github.com/G-Research/fasttrackml/pkg/ui/admin/service/token"
 	aimUI "github.com/G-Research/fasttrackml/pkg/ui/aim"
 	"github.com/G-Research/fasttrackml/pkg/ui/chooser"
 	chooserController "github.com/G-Research/fasttrackml/pkg/ui/chooser/controller"
@@ -60,6 +70,9 @@ type Server interface {
 
 type server struct {
 	*fiber.App
+	db          database.DBProvider
+	metricQueue mlflowIngestionService.QueueProvider
+	auditWriter audit.WriterProvider
 }
 
 // NewServer creates a new server instance.
@@ -76,14 +89,72 @@ func NewServer(ctx context.Context, config *config.Config) (Server, error) {
 		return nil, eris.Wrap(err, "error creating artifact storage factory")
 	}
 
+	// create metric store.
+	metricStore, err := createMetricStore(ctx, config, artifactStorageFactory)
+	if err != nil {
+		return nil, err
+	}
+
+	// create metric ingestion queue.
+	var metricQueue *mlflowIngestionService.Queue
+	if config.MetricQueueEnabled {
+		log.Info("Metric ingestion queue - enabling asynchronous metric writes")
+		metricQueue = mlflowIngestionService.NewQueue(
+			config, mlflowRepositories.NewMetricRepository(db.GormDB()).WithMetricStore(metricStore),
+		)
+	}
+
+	// create audit events writer.
+	var auditWriter *audit.Writer
+	if config.AuditLogEnabled {
+		auditWriter = audit.NewWriter(repositories.NewAuditEventRepository(db.GormDB()), audit.BufferSize)
+	}
+
 	// create fiber app.
 	//nolint:contextcheck
-	app, err := createApp(ctx, config, db, artifactStorageFactory)
+	app, err := createApp(ctx, config, db, artifactStorageFactory, metricStore, metricQueue, auditWriter)
 	if err != nil {
 		return nil, eris.Wrapf(err, "error creating application")
 	}
 
-	return server{app}, nil
+	srv := server{App: app, db: db}
+	if metricQueue != nil {
+		metricQueue.Run()
+		srv.metricQueue = metricQueue
+	}
+	if auditWriter != nil {
+		auditWriter.Run()
+		srv.auditWriter = auditWriter
+	}
+	return srv, nil
+}
+
+// ShutdownWithTimeout gracefully shuts down the server. In-flight requests are completed, metrics
+// buffered by the ingestion queue and audit events are written before the database connection is closed.
+func (s server) ShutdownWithTimeout(timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := s.App.ShutdownWithContext(ctx)
+	if s.metricQueue != nil {
+		log.Info("Draining metric ingestion queue")
+		if drainErr := s.metricQueue.Drain(ctx); drainErr != nil {
+			log.Errorf("error draining metric ingestion queue: %+v", drainErr)
+			err = errors.Join(err, drainErr)
+		}
+	}
+	if s.auditWriter != nil {
+		log.Info("Draining audit events writer")
+		if drainErr := s.auditWriter.Drain(ctx); drainErr != nil {
+			log.Errorf("error draining audit events writer: %+v", drainErr)
+			err = errors.Join(err, drainErr)
+		}
+	}
+	log.Info("Shutting down database connection")
+	if closeErr := s.db.Close(); closeErr != nil {
+		err = errors.Join(err, eris.Wrap(closeErr, "error closing database connection"))
+	}
+	return err
 }
 
 // createDBProvider creates a new DB provider.
@@ -125,6 +196,24 @@ func createDBProvider(ctx context.Context, config *config.Config) (database.DBPr
 	return db, nil
 }
 
+// createMetricStore creates the metric store configured by 'metric-store' flag. Nil store means that
+// repositories keep the metrics in the database.
+func createMetricStore(
+	ctx context.Context, config *config.Config, artifactStorageFactory storage.ArtifactStorageFactoryProvider,
+) (metricstore.Store, error) {
+	switch config.MetricStore {
+	case metricstore.TypeParquet:
+		log.Infof("Metric store - keeping metrics as Parquet segments in %s", config.MetricStoreURI)
+		store, err := metricstore.NewParquetStore(ctx, artifactStorageFactory, config.MetricStoreURI)
+		if err != nil {
+			return nil, eris.Wrap(err, "error creating parquet metric store")
+		}
+		return store, nil
+	default:
+		return nil, nil
+	}
+}
+
 // createApp creates a new fiber app with base configuration.
 //
 //nolint:contextcheck
@@ -133,6 +222,9 @@ func createApp(
 	config *config.Config,
 	db database.DBProvider,
 	artifactStorageFactory storage.ArtifactStorageFactoryProvider,
+	metricStore metricstore.Store,
+	metricQueue *mlflowIngestionService.Queue,
+	auditWriter *audit.Writer,
 ) (*fiber.App, error) {
 	app := fiber.New(fiber.Config{
 		BodyLimit:             16 * 1024 * 1024,
@@ -149,7 +241,9 @@ func createApp(
 				return aimAPI.ErrorHandler(c, err)
 			case strings.HasPrefix(p, "/api/2.0/mlflow/") ||
 				strings.HasPrefix(p, "/ajax-api/2.0/mlflow/") ||
-				strings.HasPrefix(p, "/mlflow/ajax-api/2.0/mlflow/"):
+				strings.HasPrefix(p, "/mlflow/ajax-api/2.0/mlflow/") ||
+				strings.HasPrefix(p, "/api/2.0/mlflow-artifacts/") ||
+				strings.HasPrefix(p, "/ajax-api/2.0/mlflow-artifacts/"):
 				return mlflowService.ErrorHandler(c, err)
 
 			default:
@@ -158,11 +252,6 @@ func createApp(
 		},
 	})
 
-	app.Hooks().OnShutdown(func() error {
-		log.Info("Shutting down database connection")
-		return db.Close()
-	})
-
 	if config.DevMode {
 		log.Info("Development mode - enabling CORS")
 		app.Use(cors.New())
@@ -175,7 +264,7 @@ func createApp(
 	}
 
 	namespaceCachedRepository, err := mlflowRepositories.NewNamespaceCachedRepository(
-		ctx, mlflowRepositories.NewNamespaceRepository(db.GormDB()), namespaceEventListener,
+		ctx, mlflowRepositories.NewNamespaceRepository(db.GormDB()).WithMetricStore(metricStore), namespaceEventListener,
 	)
 	if err != nil {
 		return nil, eris.Wrap(err, "error creating namespace repository")
@@ -189,6 +278,17 @@ func createApp(
 
 	namespaceEventListener.Listen()
 
+	// create run notification listener and hub for Aim UI live updates.
+	var liveUpdatesHub live.HubProvider
+	if config.LiveUpdatesEnabled {
+		runEventListener, err := dao.NewRunListener(ctx, db.GormDB())
+		if err != nil {
+			return nil, eris.Wrap(err, "error creating run notification listener")
+		}
+		liveUpdatesHub = live.NewHub(ctx, runEventListener)
+		runEventListener.Listen()
+	}
+
 	// attach global middlewares.
 	if config.Auth.AuthUsername != "" && config.Auth.AuthPassword != "" {
 		log.Info("Auth - enabling Basic Auth")
@@ -204,7 +304,8 @@ func createApp(
 		Next: func(c *fiber.Ctx) bool {
 			// This is a little brittle, maybe there is a better way?
 			// Do not compress metric histories as urllib3 did not support file-like compressed reads until 2.0.0a1
-			return strings.HasSuffix(c.Path(), "/metrics/get-histories")
+			// and live updates stream, as compression buffers the events.
+			return strings.HasSuffix(c.Path(), "/metrics/get-histories") || strings.HasSuffix(c.Path(), "/runs/live/")
 		},
 	}))
 
@@ -222,6 +323,8 @@ func createApp(
 	})
 
 	// based on Auth configuration, attach global OIDC or Basic Auth middleware.
+	// in both cases requests with API tokens are authenticated by API token middleware.
+	apiTokenRepository := repositories.NewAPITokenRepository(db.GormDB())
 	switch {
 	case config.Auth.IsAuthTypeOIDC():
 		oidcClient, err := oidc.NewClient(ctx, config)
@@ -254,16 +357,28 @@ func createApp(
 			ctx.Response().Header.Add("Cache-Control", "no-store")
 			return ctx.Redirect("/", http.StatusMovedPermanently)
 		})
-		app.Use(middleware.NewOIDCMiddleware(oidcClient, rolesCachedRepository))
+		app.Use(middleware.NewAPITokenMiddleware(
+			apiTokenRepository, middleware.NewOIDCMiddleware(oidcClient, rolesCachedRepository),
+		))
 	case config.Auth.IsAuthTypeUser():
-		app.Use(middleware.NewBasicAuthMiddleware(config.Auth.AuthParsedUserPermissions))
+		app.Use(middleware.NewAPITokenMiddleware(
+			apiTokenRepository, middleware.NewBasicAuthMiddleware(config.Auth.AuthParsedUserPermissions),
+		))
+	}
+
+	// record mutating calls in the audit log, after auth middlewares, so the actor is known.
+	auditEventRepository := repositories.NewAuditEventRepository(db.GormDB())
+	if auditWriter != nil {
+		log.Info("Audit log - enabling audit middleware")
+		app.Use(middleware.NewAuditMiddleware(auditWriter))
 	}
 
 	app.Use(compress.New(compress.Config{
 		Next: func(c *fiber.Ctx) bool {
 			// This is a little brittle, maybe there is a better way?
 			// Do not compress metric histories as urllib3 did not support file-like compressed reads until 2.0.0a1
-			return strings.HasSuffix(c.Path(), "/metrics/get-histories")
+			// and live updates stream, as compression buffers the events.
+			return strings.HasSuffix(c.Path(), "/metrics/get-histories") || strings.HasSuffix(c.Path(), "/runs/live/")
 		},
 	}))
 
@@ -277,23 +392,23 @@ func createApp(
 				aimRepositories.NewAppRepository(db.GormDB()),
 			),
 			aimRunService.NewService(
-				aimRepositories.NewRunRepository(db.GormDB()),
+				aimRepositories.NewRunRepository(db.GormDB()).WithMetricStore(metricStore),
 				aimRepositories.NewLogRepository(db.GormDB()),
-				aimRepositories.NewMetricRepository(db.GormDB()),
+				aimRepositories.NewMetricRepository(db.GormDB()).WithMetricStore(metricStore),
 				aimRepositories.NewTagRepository(db.GormDB()),
 				aimRepositories.NewSharedTagRepository(db.GormDB()),
 				artifactStorageFactory,
 				aimRepositories.NewArtifactRepository(db.GormDB()),
-			),
+			).WithLiveUpdates(liveUpdatesHub),
 			artifactService.NewService(
 				mlflowRepositories.NewRunRepository(db.GormDB()),
 				artifactStorageFactory,
 			),
 			aimProjectService.NewService(
 				aimRepositories.NewTagRepository(db.GormDB()),
-				aimRepositories.NewRunRepository(db.GormDB()),
+				aimRepositories.NewRunRepository(db.GormDB()).WithMetricStore(metricStore),
 				aimRepositories.NewParamRepository(db.GormDB()),
-				aimRepositories.NewMetricRepository(db.GormDB()),
+				aimRepositories.NewMetricRepository(db.GormDB()).WithMetricStore(metricStore),
 				aimRepositories.NewExperimentRepository(db.GormDB()),
 				aimRepositories.NewArtifactRepository(db.GormDB()),
 				config.LiveUpdatesEnabled,
@@ -304,28 +419,40 @@ func createApp(
 			),
 			aimExperimentService.NewService(
 				aimRepositories.NewTagRepository(db.GormDB()),
-				aimRepositories.NewExperimentRepository(db.GormDB()),
+				aimRepositories.NewExperimentRepository(db.GormDB()).WithMetricStore(metricStore),
 			),
 		),
 	).Init(app)
 
+	// queued metrics are published to the live updates subscribers once they are written.
+	var metricQueueProvider mlflowIngestionService.QueueProvider
+	if metricQueue != nil {
+		metricQueueProvider = metricQueue.WithLiveUpdates(liveUpdatesHub)
+	}
+
 	// init `mlflow` api and ui routes.
 	// TODO:refactoring right now it might look scary. we prettify it a bit later.
+	runService := mlflowRunService.NewService(
+		mlflowRepositories.NewTagRepository(db.GormDB()),
+		mlflowRepositories.NewRunRepository(db.GormDB()),
+		mlflowRepositories.NewParamRepository(db.GormDB()),
+		mlflowRepositories.NewMetricRepository(db.GormDB()).WithMetricStore(metricStore),
+		mlflowRepositories.NewExperimentRepository(db.GormDB()),
+		mlflowRepositories.NewLogRepository(db.GormDB(), config.RunLogOutputMax),
+		mlflowRepositories.NewArtifactRepository(db.GormDB()),
+		mlflowRepositories.NewInputRepository(db.GormDB()),
+	).WithLiveUpdates(liveUpdatesHub).WithMetricQueue(metricQueueProvider)
 	mlflowAPI.NewRouter(
 		mlflowController.NewController(
-			mlflowRunService.NewService(
-				mlflowRepositories.NewTagRepository(db.GormDB()),
+			runService,
+			mlflowModelService.NewService(
 				mlflowRepositories.NewRunRepository(db.GormDB()),
-				mlflowRepositories.NewParamRepository(db.GormDB()),
-				mlflowRepositories.NewMetricRepository(db.GormDB()),
-				mlflowRepositories.NewExperimentRepository(db.GormDB()),
-				mlflowRepositories.NewLogRepository(db.GormDB(), config.RunLogOutputMax),
-				mlflowRepositories.NewArtifactRepository(db.GormDB()),
+				mlflowRepositories.NewModelVersionRepository(db.GormDB()),
+				mlflowRepositories.NewRegisteredModelRepository(db.GormDB()),
 			),
-			mlflowModelService.NewService(),
 			mlflowMetricService.NewService(
 				mlflowRepositories.NewRunRepository(db.GormDB()),
-				mlflowRepositories.NewMetricRepository(db.GormDB()),
+				mlflowRepositories.NewMetricRepository(db.GormDB()).WithMetricStore(metricStore),
 			),
 			artifactService.NewService(
 				mlflowRepositories.NewRunRepository(db.GormDB()),
@@ -334,10 +461,14 @@ func createApp(
 			mlflowExperimentService.NewService(
 				config,
 				mlflowRepositories.NewTagRepository(db.GormDB()),
-				mlflowRepositories.NewExperimentRepository(db.GormDB()),
+				mlflowRepositories.NewExperimentRepository(db.GormDB()).WithMetricStore(metricStore),
+				mlflowRepositories.NewInputRepository(db.GormDB()),
+			),
+			mlflowExportService.NewService(
+				ctx, config, runService, mlflowRepositories.NewExportRepository(db.GormDB()), artifactStorageFactory,
 			),
 		),
-	).Init(app)
+	).EnableArtifactsProxy(config.ServeArtifacts).Init(app)
 
 	// run a log cleaner background job.
 	mlflowRunService.NewLogCleaner(
@@ -346,6 +477,17 @@ func createApp(
 		mlflowRepositories.NewLogRepository(db.GormDB(), config.RunLogOutputMax),
 	).Run()
 
+	// run an audit events cleaner background job.
+	adminUIAuditService.NewCleaner(ctx, config, auditEventRepository).Run()
+
+	// run a metrics compactor background job.
+	metricRetentionPolicyRepository := mlflowRepositories.NewMetricRetentionPolicyRepository(db.GormDB())
+	adminUIRetentionService.NewCompactor(ctx, config, metricRetentionPolicyRepository).Run()
+
+	// run a deleted runs purger background job.
+	runPurgeRepository := mlflowRepositories.NewRunPurgeRepository(db.GormDB()).WithMetricStore(metricStore)
+	adminUIPurgeService.NewPurger(ctx, config, runPurgeRepository, artifactStorageFactory).Run()
+
 	mlflowUI.AddRoutes(app)
 	aimUI.AddRoutes(app)
 
@@ -357,6 +499,15 @@ func createApp(
 				namespaceCachedRepository,
 				mlflowRepositories.NewExperimentRepository(db.GormDB()),
 			),
+			adminUITokenService.NewService(apiTokenRepository),
+			adminUIAuditService.NewService(auditEventRepository),
+			adminUIRetentionService.NewService(
+				config,
+				namespaceCachedRepository,
+				mlflowRepositories.NewExperimentRepository(db.GormDB()),
+				metricRetentionPolicyRepository,
+			),
+			adminUIPurgeService.NewService(runPurgeRepository),
 		),
 	).Init(app); err != nil {
 		return nil, eris.Wrap(err, "error initializing admin routes")
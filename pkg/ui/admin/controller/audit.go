@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/response"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/service/audit"
+	"github.com/G-Research/fasttrackml/pkg/ui/common"
+)
+
+// GetAuditEvents renders the list view of the latest audit events matching the filters.
+func (c Controller) GetAuditEvents(ctx *fiber.Ctx) error {
+	var req request.AuditEvents
+	if err := ctx.QueryParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "unable to parse query parameters")
+	}
+	events, err := c.auditService.ListEvents(ctx.Context(), &req)
+	if err != nil {
+		return ctx.Render("audit/index", fiber.Map{
+			"Filter":  req,
+			"Query":   string(ctx.Request().URI().QueryString()),
+			"Status":  StatusError,
+			"Message": common.ErrorMessageForUI("filter", err.Error()),
+		})
+	}
+	return ctx.Render("audit/index", fiber.Map{
+		"Filter": req,
+		"Query":  string(ctx.Request().URI().QueryString()),
+		"Events": response.NewAuditEventsResponse(events),
+	})
+}
+
+// ExportAuditEvents streams all audit events matching the filters as JSON lines.
+func (c Controller) ExportAuditEvents(ctx *fiber.Ctx) error {
+	var req request.AuditEvents
+	if err := ctx.QueryParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "unable to parse query parameters")
+	}
+	if err := audit.ValidateAuditEventsRequest(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	// request context and its buffers are released once the handler returns,
+	// so the stream has its own context and copies of the request values.
+	method, path := strings.Clone(ctx.Method()), strings.Clone(ctx.Path())
+	req = request.AuditEvents{
+		Actor:     strings.Clone(req.Actor),
+		Namespace: strings.Clone(req.Namespace),
+		Action:    strings.Clone(req.Action),
+		Target:    strings.Clone(req.Target),
+		From:      strings.Clone(req.From),
+		To:        strings.Clone(req.To),
+	}
+	ctx.Set(fiber.HeaderContentType, "application/x-ndjson")
+	ctx.Set(fiber.HeaderContentDisposition, "attachment; filename=audit.jsonl")
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		encoder := json.NewEncoder(w)
+		if err := c.auditService.ExportEvents(context.Background(), &req, func(events []models.AuditEvent) error {
+			for _, event := range events {
+				if err := encoder.Encode(response.NewAuditEventResponse(event)); err != nil {
+					return eris.Wrap(err, "error encoding audit event")
+				}
+			}
+			return eris.Wrap(w.Flush(), "error flushing output stream")
+		}); err != nil {
+			log.Errorf("error encountered in %s %s: error exporting audit events: %s", method, path, err)
+		}
+	})
+	return nil
+}
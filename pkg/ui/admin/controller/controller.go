@@ -1,15 +1,35 @@
 package controller
 
-import "github.com/G-Research/fasttrackml/pkg/ui/admin/service/namespace"
+import (
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/service/audit"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/service/namespace"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/service/purge"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/service/retention"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/service/token"
+)
 
 // Controller contains all the request handler functions for the admin ui.
 type Controller struct {
 	namespaceService *namespace.Service
+	tokenService     *token.Service
+	auditService     *audit.Service
+	retentionService *retention.Service
+	purgeService     *purge.Service
 }
 
 // NewController creates new Controller instance.
-func NewController(namespaceService *namespace.Service) *Controller {
+func NewController(
+	namespaceService *namespace.Service,
+	tokenService *token.Service,
+	auditService *audit.Service,
+	retentionService *retention.Service,
+	purgeService *purge.Service,
+) *Controller {
 	return &Controller{
 		namespaceService: namespaceService,
+		tokenService:     tokenService,
+		auditService:     auditService,
+		retentionService: retentionService,
+		purgeService:     purgeService,
 	}
 }
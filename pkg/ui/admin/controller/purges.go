@@ -0,0 +1,22 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/response"
+	"github.com/G-Research/fasttrackml/pkg/ui/common"
+)
+
+// GetRunPurges renders the list view of the deleted runs waiting to be purged.
+func (c Controller) GetRunPurges(ctx *fiber.Ctx) error {
+	purges, err := c.purgeService.ListPurges(ctx.Context())
+	if err != nil {
+		return ctx.Render("purges/index", fiber.Map{
+			"Status":  StatusError,
+			"Message": common.ErrorMessageForUI("run purge", err.Error()),
+		})
+	}
+	return ctx.Render("purges/index", fiber.Map{
+		"Purges": response.NewRunPurgesResponse(purges),
+	})
+}
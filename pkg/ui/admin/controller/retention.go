@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/response"
+	"github.com/G-Research/fasttrackml/pkg/ui/common"
+)
+
+// GetRetentionPolicies renders the list view of metric retention policies with no message.
+func (c Controller) GetRetentionPolicies(ctx *fiber.Ctx) error {
+	return c.renderRetentionIndex(ctx, "")
+}
+
+// GetRetentionPolicy renders the update view for a metric retention policy.
+func (c Controller) GetRetentionPolicy(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, "unable to parse id")
+	}
+	policy, err := c.retentionService.GetPolicy(ctx.Context(), id)
+	if err != nil {
+		return fiber.NewError(fiber.ErrInternalServerError.Code, "unable to find metric retention policy")
+	}
+	if policy == nil {
+		return fiber.NewError(fiber.StatusNotFound, "metric retention policy not found")
+	}
+	return ctx.Render("retention/update", fiber.Map{
+		"Policy": response.NewMetricRetentionPolicyResponse(*policy),
+	})
+}
+
+// NewRetentionPolicy renders the create view for a metric retention policy.
+func (c Controller) NewRetentionPolicy(ctx *fiber.Ctx) error {
+	return ctx.Render("retention/create", fiber.Map{
+		"Policy": response.MetricRetentionPolicy{FullResolutionDays: 30, KeepEvery: 10, KeepMinMax: true},
+	})
+}
+
+// CreateRetentionPolicy creates a new metric retention policy record.
+func (c Controller) CreateRetentionPolicy(ctx *fiber.Ctx) error {
+	var req request.MetricRetentionPolicy
+	if err := ctx.BodyParser(&req); err != nil {
+		return fiber.NewError(400, "unable to parse request body")
+	}
+	if _, err := c.retentionService.CreatePolicy(ctx.Context(), &req); err != nil {
+		return ctx.Render("retention/create", fiber.Map{
+			"Policy":  req,
+			"Status":  StatusError,
+			"Message": common.ErrorMessageForUI("metric retention policy", err.Error()),
+		})
+	}
+	return c.renderRetentionIndex(ctx, "Successfully added new metric retention policy")
+}
+
+// UpdateRetentionPolicy updates an existing metric retention policy record.
+func (c Controller) UpdateRetentionPolicy(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, "unable to parse id")
+	}
+	var req request.MetricRetentionPolicy
+	if err := ctx.BodyParser(&req); err != nil {
+		return fiber.NewError(400, "unable to parse request body")
+	}
+	if _, err := c.retentionService.UpdatePolicy(ctx.Context(), id, &req); err != nil {
+		return ctx.JSON(fiber.Map{
+			"status":  StatusError,
+			"message": common.ErrorMessageForUI("metric retention policy", err.Error()),
+		})
+	}
+	return ctx.JSON(fiber.Map{
+		"status":  StatusSuccess,
+		"message": "Successfully updated metric retention policy.",
+	})
+}
+
+// DeleteRetentionPolicy deletes a metric retention policy record.
+func (c Controller) DeleteRetentionPolicy(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, "unable to parse id")
+	}
+	if err := c.retentionService.DeletePolicy(ctx.Context(), id); err != nil {
+		return ctx.JSON(fiber.Map{
+			"status":  StatusError,
+			"message": common.ErrorMessageForUI("metric retention policy", err.Error()),
+		})
+	}
+	return ctx.JSON(fiber.Map{
+		"status":  StatusSuccess,
+		"message": "Successfully deleted metric retention policy.",
+	})
+}
+
+// PreviewRetentionPolicy returns the number of metrics the metric retention policy would remove if applied now.
+func (c Controller) PreviewRetentionPolicy(ctx *fiber.Ctx) error {
+	var req request.MetricRetentionPolicy
+	if err := ctx.BodyParser(&req); err != nil {
+		return fiber.NewError(400, "unable to parse request body")
+	}
+	preview, err := c.retentionService.PreviewPolicy(ctx.Context(), &req)
+	if err != nil {
+		return ctx.JSON(fiber.Map{
+			"status":  StatusError,
+			"message": common.ErrorMessageForUI("metric retention policy", err.Error()),
+		})
+	}
+	return ctx.JSON(fiber.Map{
+		"status":  StatusSuccess,
+		"preview": response.NewMetricRetentionPreviewResponse(preview),
+	})
+}
+
+// renderRetentionIndex renders the metric retention policies index page with the given message.
+func (c Controller) renderRetentionIndex(ctx *fiber.Ctx, msg string) error {
+	policies, err := c.retentionService.ListPolicies(ctx.Context())
+	if err != nil {
+		return ctx.Render("retention/index", fiber.Map{
+			"Status":  StatusError,
+			"Message": common.ErrorMessageForUI("metric retention policy", err.Error()),
+		})
+	}
+	return ctx.Render("retention/index", fiber.Map{
+		"Policies": response.NewMetricRetentionPoliciesResponse(policies),
+		"Status":   StatusSuccess,
+		"Message":  msg,
+	})
+}
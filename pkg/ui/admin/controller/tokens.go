@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/response"
+	"github.com/G-Research/fasttrackml/pkg/ui/common"
+)
+
+// GetTokens renders the list view of API tokens with no message.
+func (c Controller) GetTokens(ctx *fiber.Ctx) error {
+	return c.renderTokensIndex(ctx, "", "")
+}
+
+// NewToken renders the create view for an API token.
+func (c Controller) NewToken(ctx *fiber.Ctx) error {
+	return ctx.Render("tokens/create", fiber.Map{
+		"Token": request.Token{},
+	})
+}
+
+// CreateToken creates a new API token record and shows the token value once.
+func (c Controller) CreateToken(ctx *fiber.Ctx) error {
+	var req request.Token
+	if err := ctx.BodyParser(&req); err != nil {
+		return fiber.NewError(400, "unable to parse request body")
+	}
+	_, value, err := c.tokenService.CreateToken(ctx.Context(), req.Name, req.GetRoles(), req.ExpiresInDays)
+	if err != nil {
+		return ctx.Render("tokens/create", fiber.Map{
+			"Token":   req,
+			"Status":  StatusError,
+			"Message": common.ErrorMessageForUI("token", err.Error()),
+		})
+	}
+	return c.renderTokensIndex(
+		ctx, "Successfully added new token. Copy it now, it will not be shown again.", value,
+	)
+}
+
+// RevokeToken revokes an API token record.
+func (c Controller) RevokeToken(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, "unable to parse id")
+	}
+	if err := c.tokenService.RevokeToken(ctx.Context(), id); err != nil {
+		return ctx.JSON(fiber.Map{
+			"status":  StatusError,
+			"message": common.ErrorMessageForUI("token", err.Error()),
+		})
+	}
+	return ctx.JSON(fiber.Map{
+		"status":  StatusSuccess,
+		"message": "Successfully revoked token.",
+	})
+}
+
+// renderTokensIndex renders the tokens index page with the given message and newly created token value.
+func (c Controller) renderTokensIndex(ctx *fiber.Ctx, msg, value string) error {
+	tokens, err := c.tokenService.ListTokens(ctx.Context())
+	if err != nil {
+		return ctx.Render("tokens/index", fiber.Map{
+			"Status":  StatusError,
+			"Message": common.ErrorMessageForUI("token", err.Error()),
+		})
+	}
+	return ctx.Render("tokens/index", fiber.Map{
+		"Tokens":   response.NewTokensResponse(tokens, time.Now().UTC()),
+		"NewToken": value,
+		"Status":   StatusSuccess,
+		"Message":  msg,
+	})
+}
@@ -0,0 +1,77 @@
+function createRetentionPolicy() {
+  redirectTo('/admin/retention/new');
+}
+
+function editRetentionPolicy(id) {
+  redirectTo(`/admin/retention/${id}`);
+}
+
+function retentionIndex() {
+  redirectTo('/admin/retention/');
+}
+
+function getRetentionPolicyFormData() {
+  return {
+    namespace_code: $('#namespace_code').val(),
+    experiment_id: $('#experiment_id').val(),
+    full_resolution_days: parseInt($('#full_resolution_days').val(), 10) || 0,
+    keep_every: parseInt($('#keep_every').val(), 10) || 0,
+    keep_min_max: $('#keep_min_max').is(':checked'),
+  };
+}
+
+function handleUpdateRetentionPolicy() {
+  $("#retentionForm").on("submit", function(event) {
+    event.preventDefault(); // Prevent the default form submission
+
+    // Perform a PUT request using jQuery's $.ajax
+    $.ajax({
+      url: `/admin/retention/${$('#id').val()}`,
+      type: "PUT",
+      contentType: "application/json",
+      data: JSON.stringify(getRetentionPolicyFormData()),
+    }).done(handleRetentionResponse);
+  });
+}
+
+function previewRetentionPolicy() {
+  $.ajax({
+    url: '/admin/retention/preview',
+    type: "POST",
+    contentType: "application/json",
+    data: JSON.stringify(getRetentionPolicyFormData()),
+  }).done(function(data) {
+    if (data['status'] != 'success') {
+      showErrorMessage(data['message']);
+      return;
+    }
+    const preview = data['preview'];
+    $('#retention-preview').text(
+      `The policy would remove ${preview['removed']} of ${preview['total']} metric values`
+      + ` (${preview['percent'].toFixed(1)}%) if applied now.`
+    );
+  });
+}
+
+function deleteRetentionPolicy(id) {
+  if (confirm("Are you sure?") != true ){
+    return
+  }
+  // Perform a DELETE request using jQuery's $.ajax
+  $.ajax({
+    url: `/admin/retention/${id}`,
+    type: "DELETE",
+    contentType: "application/json",
+  }).done(handleRetentionResponse);
+}
+
+function handleRetentionResponse(data, jqxhr, status) {
+  if (data['status'] == 'success'){
+    redirectTo('/admin/retention/'
+        + `?message=${encodeURIComponent(data["message"])}`
+        + `&status=success`);
+  }
+  else {
+    showErrorMessage(data['message']);
+  }
+}
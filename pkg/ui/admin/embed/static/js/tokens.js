@@ -0,0 +1,30 @@
+function createToken() {
+  redirectTo('/admin/tokens/new');
+}
+
+function tokenIndex() {
+  redirectTo('/admin/tokens/');
+}
+
+function revokeToken(id) {
+  if (confirm("Are you sure?") != true ){
+    return
+  }
+  // Perform a DELETE request using jQuery's $.ajax
+  $.ajax({
+    url: `/admin/tokens/${id}`,
+    type: "DELETE",
+    contentType: "application/json",
+  }).done(handleTokenResponse);
+}
+
+function handleTokenResponse(data, jqxhr, status) {
+  if (data['status'] == 'success'){
+    redirectTo('/admin/tokens/'
+        + `?message=${encodeURIComponent(data["message"])}`
+        + `&status=success`);
+  }
+  else {
+    showErrorMessage(data['message']);
+  }
+}
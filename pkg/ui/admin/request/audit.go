@@ -0,0 +1,12 @@
+package request
+
+// AuditEvents represents the filters to search audit events.
+type AuditEvents struct {
+	Actor     string `query:"actor"`
+	Namespace string `query:"namespace"`
+	Action    string `query:"action"`
+	Target    string `query:"target"`
+	From      string `query:"from"`
+	To        string `query:"to"`
+	Limit     int    `query:"limit"`
+}
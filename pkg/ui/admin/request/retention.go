@@ -0,0 +1,11 @@
+package request
+
+// MetricRetentionPolicy represents the data to create, update or preview a metric retention policy.
+// Empty ExperimentID means that policy is applied to the whole namespace.
+type MetricRetentionPolicy struct {
+	NamespaceCode      string `json:"namespace_code" form:"namespace_code"`
+	ExperimentID       string `json:"experiment_id" form:"experiment_id"`
+	FullResolutionDays int    `json:"full_resolution_days" form:"full_resolution_days"`
+	KeepEvery          int    `json:"keep_every" form:"keep_every"`
+	KeepMinMax         bool   `json:"keep_min_max" form:"keep_min_max"`
+}
@@ -0,0 +1,20 @@
+package request
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Token represents the data to create an API token.
+type Token struct {
+	Name          string `json:"name" form:"name"`
+	Roles         string `json:"roles" form:"roles"`
+	ExpiresInDays int    `json:"expires_in_days" form:"expires_in_days"`
+}
+
+// GetRoles returns the list of roles separated by comma or whitespaces.
+func (t Token) GetRoles() []string {
+	return strings.FieldsFunc(t.Roles, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+}
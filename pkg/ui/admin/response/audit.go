@@ -0,0 +1,54 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
+)
+
+// AuditEvent represents the data for viewing and exporting an audit event.
+type AuditEvent struct {
+	ID            uuid.UUID `json:"id"`
+	CreatedAt     time.Time `json:"created_at"`
+	Actor         string    `json:"actor"`
+	NamespaceCode string    `json:"namespace,omitempty"`
+	Action        string    `json:"action"`
+	Method        string    `json:"method"`
+	Path          string    `json:"path"`
+	TargetIDs     []string  `json:"target_ids"`
+	Request       string    `json:"request,omitempty"`
+	StatusCode    int       `json:"status_code"`
+	RemoteAddress string    `json:"remote_address"`
+}
+
+// NewAuditEventResponse creates new response object for the audit event.
+func NewAuditEventResponse(event models.AuditEvent) AuditEvent {
+	targetIDs := event.GetTargetIDs()
+	if targetIDs == nil {
+		targetIDs = []string{}
+	}
+	return AuditEvent{
+		ID:            event.ID,
+		CreatedAt:     event.CreatedAt,
+		Actor:         event.Actor,
+		NamespaceCode: event.NamespaceCode,
+		Action:        event.Action,
+		Method:        event.Method,
+		Path:          event.Path,
+		TargetIDs:     targetIDs,
+		Request:       event.Request,
+		StatusCode:    event.StatusCode,
+		RemoteAddress: event.RemoteAddress,
+	}
+}
+
+// NewAuditEventsResponse creates new response object for the list of audit events.
+func NewAuditEventsResponse(events []models.AuditEvent) []AuditEvent {
+	resp := make([]AuditEvent, len(events))
+	for i, event := range events {
+		resp[i] = NewAuditEventResponse(event)
+	}
+	return resp
+}
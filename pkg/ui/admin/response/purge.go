@@ -0,0 +1,42 @@
+package response
+
+import (
+	"time"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+)
+
+// RunPurge represents the data for viewing the progress of a run purge.
+type RunPurge struct {
+	RunID          string
+	NamespaceCode  string
+	Status         string
+	DeletedMetrics int64
+	Attempts       int
+	Error          string
+	RequestedAt    time.Time
+	UpdatedAt      time.Time
+}
+
+// NewRunPurgeResponse creates new response object for the run purge.
+func NewRunPurgeResponse(purge models.RunPurge) RunPurge {
+	return RunPurge{
+		RunID:          purge.RunID,
+		NamespaceCode:  purge.Namespace.Code,
+		Status:         string(purge.Status),
+		DeletedMetrics: purge.DeletedMetrics,
+		Attempts:       purge.Attempts,
+		Error:          purge.Error,
+		RequestedAt:    purge.RequestedAt,
+		UpdatedAt:      purge.UpdatedAt,
+	}
+}
+
+// NewRunPurgesResponse creates new response object for the list of run purges.
+func NewRunPurgesResponse(purges []models.RunPurge) []RunPurge {
+	resp := make([]RunPurge, len(purges))
+	for i, purge := range purges {
+		resp[i] = NewRunPurgeResponse(purge)
+	}
+	return resp
+}
@@ -0,0 +1,72 @@
+package response
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+)
+
+// MetricRetentionPolicy represents the data for viewing and updating a metric retention policy.
+type MetricRetentionPolicy struct {
+	ID                 uuid.UUID
+	NamespaceCode      string
+	ExperimentID       string
+	ExperimentName     string
+	FullResolutionDays int
+	KeepEvery          int
+	KeepMinMax         bool
+	LastAppliedAt      *time.Time
+	RemovedMetrics     int64
+}
+
+// NewMetricRetentionPolicyResponse creates new response object for the metric retention policy.
+func NewMetricRetentionPolicyResponse(policy models.MetricRetentionPolicy) MetricRetentionPolicy {
+	resp := MetricRetentionPolicy{
+		ID:                 policy.ID,
+		NamespaceCode:      policy.Namespace.Code,
+		FullResolutionDays: policy.FullResolutionDays,
+		KeepEvery:          policy.KeepEvery,
+		KeepMinMax:         policy.KeepMinMax,
+		LastAppliedAt:      policy.LastAppliedAt,
+		RemovedMetrics:     policy.RemovedMetrics,
+	}
+	if policy.ExperimentID != nil {
+		resp.ExperimentID = fmt.Sprint(*policy.ExperimentID)
+	}
+	if policy.Experiment != nil {
+		resp.ExperimentName = policy.Experiment.Name
+	}
+	return resp
+}
+
+// NewMetricRetentionPoliciesResponse creates new response object for the list of metric retention policies.
+func NewMetricRetentionPoliciesResponse(policies []models.MetricRetentionPolicy) []MetricRetentionPolicy {
+	resp := make([]MetricRetentionPolicy, len(policies))
+	for i, policy := range policies {
+		resp[i] = NewMetricRetentionPolicyResponse(policy)
+	}
+	return resp
+}
+
+// MetricRetentionPreview represents the number of metrics removed by a metric retention policy.
+type MetricRetentionPreview struct {
+	Total   int64   `json:"total"`
+	Removed int64   `json:"removed"`
+	Percent float64 `json:"percent"`
+}
+
+// NewMetricRetentionPreviewResponse creates new response object for the metric retention policy preview.
+func NewMetricRetentionPreviewResponse(preview *repositories.MetricRetentionPreview) MetricRetentionPreview {
+	resp := MetricRetentionPreview{
+		Total:   preview.Total,
+		Removed: preview.Removed,
+	}
+	if preview.Total > 0 {
+		resp.Percent = float64(preview.Removed) * 100 / float64(preview.Total)
+	}
+	return resp
+}
@@ -0,0 +1,54 @@
+package response
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
+)
+
+// Supported list of API token statuses.
+const (
+	TokenStatusActive  = "active"
+	TokenStatusExpired = "expired"
+	TokenStatusRevoked = "revoked"
+)
+
+// Token represents the data for viewing an API token.
+type Token struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Roles      string     `json:"roles"`
+	Status     string     `json:"status"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+}
+
+// NewTokensResponse creates new response object for the list of API tokens.
+func NewTokensResponse(tokens []models.APIToken, now time.Time) []Token {
+	resp := make([]Token, len(tokens))
+	for i, token := range tokens {
+		status := TokenStatusActive
+		switch {
+		case token.IsRevoked():
+			status = TokenStatusRevoked
+		case token.IsExpired(now):
+			status = TokenStatusExpired
+		}
+		resp[i] = Token{
+			ID:         token.ID,
+			Name:       token.Name,
+			Prefix:     token.Prefix,
+			Roles:      strings.Join(token.GetRoles(), ", "),
+			Status:     status,
+			CreatedAt:  token.CreatedAt,
+			ExpiresAt:  token.ExpiresAt,
+			LastUsedAt: token.LastUsedAt,
+		}
+	}
+	return resp
+}
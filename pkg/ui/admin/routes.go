@@ -59,6 +59,44 @@ func (r *Router) Init(router fiber.Router) error {
 	namespaces.Put("/:id<int>/", r.controller.UpdateNamespace)
 	namespaces.Delete("/:id<int>/", r.controller.DeleteNamespace)
 
+	tokens := app.Group("tokens")
+	// apply global middlewares.
+	for _, globalMiddleware := range r.globalMiddlewares {
+		tokens.Use(globalMiddleware)
+	}
+	tokens.Get("/", r.controller.GetTokens)
+	tokens.Post("/", r.controller.CreateToken)
+	tokens.Get("/new", r.controller.NewToken)
+	tokens.Delete("/:id<guid>/", r.controller.RevokeToken)
+
+	audit := app.Group("audit")
+	// apply global middlewares.
+	for _, globalMiddleware := range r.globalMiddlewares {
+		audit.Use(globalMiddleware)
+	}
+	audit.Get("/", r.controller.GetAuditEvents)
+	audit.Get("/export", r.controller.ExportAuditEvents)
+
+	retention := app.Group("retention")
+	// apply global middlewares.
+	for _, globalMiddleware := range r.globalMiddlewares {
+		retention.Use(globalMiddleware)
+	}
+	retention.Get("/", r.controller.GetRetentionPolicies)
+	retention.Post("/", r.controller.CreateRetentionPolicy)
+	retention.Get("/new", r.controller.NewRetentionPolicy)
+	retention.Post("/preview", r.controller.PreviewRetentionPolicy)
+	retention.Get("/:id<guid>/", r.controller.GetRetentionPolicy)
+	retention.Put("/:id<guid>/", r.controller.UpdateRetentionPolicy)
+	retention.Delete("/:id<guid>/", r.controller.DeleteRetentionPolicy)
+
+	purges := app.Group("purges")
+	// apply global middlewares.
+	for _, globalMiddleware := range r.globalMiddlewares {
+		purges.Use(globalMiddleware)
+	}
+	purges.Get("/", r.controller.GetRunPurges)
+
 	// default route
 	app.Use("/", etag.New(), filesystem.New(filesystem.Config{
 		Root: http.FS(sub),
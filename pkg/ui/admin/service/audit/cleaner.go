@@ -0,0 +1,56 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// Cleaner represents audit events cleaner, which deletes events older than the retention period.
+type Cleaner struct {
+	ctx                  context.Context
+	config               *config.Config
+	auditEventRepository repositories.AuditEventRepositoryProvider
+}
+
+// NewCleaner creates a new instance of Cleaner.
+func NewCleaner(
+	ctx context.Context,
+	config *config.Config,
+	auditEventRepository repositories.AuditEventRepositoryProvider,
+) *Cleaner {
+	return &Cleaner{
+		ctx:                  ctx,
+		config:               config,
+		auditEventRepository: auditEventRepository,
+	}
+}
+
+// Run runs audit events cleaner background job. Zero retention period means that events are kept forever.
+func (m Cleaner) Run() {
+	if m.config.AuditLogRetain == 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-m.ctx.Done():
+				log.Debug("audit events cleaner finished. exiting.")
+				return
+			case <-ticker.C:
+				numberOfDeleted, err := m.auditEventRepository.CleanExpired(m.ctx, m.config.AuditLogRetain)
+				if err != nil {
+					log.Errorf("error cleaning expired audit events: %+v", err)
+				} else {
+					log.Debugf("%d expired audit events were successfully cleaned", numberOfDeleted)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,76 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+)
+
+// defaultLimit is the number of events listed when no limit is requested.
+const defaultLimit = 100
+
+// Service provides service layer to work with `audit event` business logic.
+type Service struct {
+	auditEventRepository repositories.AuditEventRepositoryProvider
+}
+
+// NewService creates new Service instance.
+func NewService(auditEventRepository repositories.AuditEventRepositoryProvider) *Service {
+	return &Service{
+		auditEventRepository: auditEventRepository,
+	}
+}
+
+// ListEvents returns the latest events matching the filters.
+func (s Service) ListEvents(ctx context.Context, req *request.AuditEvents) ([]models.AuditEvent, error) {
+	if err := ValidateAuditEventsRequest(req); err != nil {
+		return nil, eris.Wrap(err, "error validating audit events request")
+	}
+	filter := convertAuditEventsRequestToFilter(req)
+	if filter.Limit == 0 {
+		filter.Limit = defaultLimit
+	}
+	events, err := s.auditEventRepository.List(ctx, filter)
+	if err != nil {
+		return nil, eris.Wrap(err, "error listing audit events")
+	}
+	return events, nil
+}
+
+// ExportEvents calls the function for the batches of all events matching the filters, from the oldest ones.
+func (s Service) ExportEvents(
+	ctx context.Context, req *request.AuditEvents, fn func(events []models.AuditEvent) error,
+) error {
+	if err := ValidateAuditEventsRequest(req); err != nil {
+		return eris.Wrap(err, "error validating audit events request")
+	}
+	if err := s.auditEventRepository.Iterate(ctx, convertAuditEventsRequestToFilter(req), fn); err != nil {
+		return eris.Wrap(err, "error exporting audit events")
+	}
+	return nil
+}
+
+// convertAuditEventsRequestToFilter converts validated request into the repository filter.
+// `to` date is inclusive, so the filter ends at the beginning of the next day.
+func convertAuditEventsRequestToFilter(req *request.AuditEvents) repositories.AuditEventFilter {
+	filter := repositories.AuditEventFilter{
+		Actor:         req.Actor,
+		NamespaceCode: req.Namespace,
+		Action:        req.Action,
+		TargetID:      req.Target,
+		Limit:         req.Limit,
+	}
+	if from, err := time.Parse(DateLayout, req.From); err == nil {
+		filter.From = &from
+	}
+	if to, err := time.Parse(DateLayout, req.To); err == nil {
+		to = to.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+	return filter
+}
@@ -0,0 +1,32 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+)
+
+const (
+	// DateLayout is the layout of `from` and `to` filters.
+	DateLayout             = "2006-01-02"
+	maxLimit               = 1000
+	dateValidationMessage  = "audit event date filter is invalid -- must be in YYYY-MM-DD format"
+	limitValidationMessage = "audit event limit is invalid -- must be between 0 and 1000"
+)
+
+// ValidateAuditEventsRequest validates the filters of audit events.
+func ValidateAuditEventsRequest(req *request.AuditEvents) error {
+	for _, date := range []string{req.From, req.To} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse(DateLayout, date); err != nil {
+			return api.NewInvalidParameterValueError(dateValidationMessage)
+		}
+	}
+	if req.Limit < 0 || req.Limit > maxLimit {
+		return api.NewInvalidParameterValueError(limitValidationMessage)
+	}
+	return nil
+}
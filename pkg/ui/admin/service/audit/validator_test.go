@@ -0,0 +1,57 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+)
+
+func TestValidateAuditEventsRequest_Ok(t *testing.T) {
+	err := ValidateAuditEventsRequest(&request.AuditEvents{
+		Actor: "user1",
+		From:  "2024-01-01",
+		To:    "2024-01-31",
+		Limit: 1000,
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateAuditEventsRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.AuditEvents
+	}{
+		{
+			name:    "InvalidFrom",
+			error:   api.NewInvalidParameterValueError(dateValidationMessage),
+			request: &request.AuditEvents{From: "yesterday"},
+		},
+		{
+			name:    "InvalidTo",
+			error:   api.NewInvalidParameterValueError(dateValidationMessage),
+			request: &request.AuditEvents{To: "2024-01-01T00:00:00Z"},
+		},
+		{
+			name:    "NegativeLimit",
+			error:   api.NewInvalidParameterValueError(limitValidationMessage),
+			request: &request.AuditEvents{Limit: -1},
+		},
+		{
+			name:    "TooBigLimit",
+			error:   api.NewInvalidParameterValueError(limitValidationMessage),
+			request: &request.AuditEvents{Limit: 1001},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAuditEventsRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
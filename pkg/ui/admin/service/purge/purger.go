@@ -0,0 +1,150 @@
+package purge
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+)
+
+// Purger represents deleted runs purger, which periodically removes the metrics, the artifacts
+// and the rest of the data of the deleted runs.
+type Purger struct {
+	ctx                    context.Context
+	config                 *config.Config
+	purgeRepository        repositories.RunPurgeRepositoryProvider
+	artifactStorageFactory storage.ArtifactStorageFactoryProvider
+}
+
+// NewPurger creates a new instance of Purger.
+func NewPurger(
+	ctx context.Context,
+	config *config.Config,
+	purgeRepository repositories.RunPurgeRepositoryProvider,
+	artifactStorageFactory storage.ArtifactStorageFactoryProvider,
+) *Purger {
+	return &Purger{
+		ctx:                    ctx,
+		config:                 config,
+		purgeRepository:        purgeRepository,
+		artifactStorageFactory: artifactStorageFactory,
+	}
+}
+
+// Run runs deleted runs purger background job. Zero interval means that deleted runs are purged by `fml gc` only.
+func (p Purger) Run() {
+	if p.config.RunPurgeInterval == 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(p.config.RunPurgeInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.ctx.Done():
+				log.Debug("run purger finished. exiting.")
+				return
+			case <-ticker.C:
+				if _, err := p.PurgeAll(); err != nil {
+					log.Errorf("error purging deleted runs: %+v", err)
+				}
+			}
+		}
+	}()
+}
+
+// PurgeAll purges all the deleted runs one by one and returns the number of purged runs. Failed purges
+// are kept with their errors and retried by the next call, the last error is returned.
+func (p Purger) PurgeAll() (int, error) {
+	purges, err := p.purgeRepository.List(p.ctx)
+	if err != nil {
+		return 0, eris.Wrap(err, "error listing run purges")
+	}
+
+	var purged int
+	var lastErr error
+	for i := range purges {
+		if p.ctx.Err() != nil {
+			return purged, p.ctx.Err()
+		}
+		if err := p.purge(&purges[i]); err != nil {
+			lastErr = eris.Wrapf(err, "error purging run with id: %s", purges[i].RunID)
+			log.Errorf("%+v", lastErr)
+			if err := p.purgeRepository.Fail(p.ctx, &purges[i], err); err != nil {
+				log.Errorf("error keeping failure of run purge: %+v", err)
+			}
+			continue
+		}
+		log.Debugf("run %s was purged, %d metrics were removed", purges[i].RunID, purges[i].DeletedMetrics)
+		purged++
+	}
+	return purged, lastErr
+}
+
+// purge removes the metrics and the artifacts of the deleted run, and then the run itself.
+func (p Purger) purge(purge *models.RunPurge) error {
+	run, err := p.purgeRepository.GetRun(p.ctx, purge)
+	if err != nil {
+		return err
+	}
+
+	if err := p.purgeRepository.DeleteMetrics(p.ctx, purge, p.config.RunPurgeChunkSize); err != nil {
+		return err
+	}
+
+	// artifacts are unknown when the run has been already removed together with its experiment.
+	if run != nil && run.ArtifactURI != "" {
+		if err := p.deleteArtifacts(purge, run); err != nil {
+			return err
+		}
+	}
+
+	return p.purgeRepository.Complete(p.ctx, purge)
+}
+
+// deleteArtifacts removes the artifacts of the deleted run. Artifacts are removed recursively, so they are kept
+// when the run points to a location shared with the other runs or to an arbitrary location.
+func (p Purger) deleteArtifacts(purge *models.RunPurge, run *models.Run) error {
+	if !p.isRunArtifactURI(run) {
+		log.Warnf("artifacts of run %s are kept, as %s is not the own artifact location of the run", run.ID, run.ArtifactURI)
+		return nil
+	}
+	ctx := storage.WithNamespace(p.ctx, purge.Namespace.Code)
+	artifactStorage, err := p.artifactStorageFactory.GetStorage(ctx, run.ArtifactURI)
+	if err != nil {
+		return eris.Wrap(err, "error getting artifact storage")
+	}
+	if err := artifactStorage.Delete(ctx, run.ArtifactURI, ""); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return eris.Wrap(err, "error deleting artifacts")
+	}
+	return nil
+}
+
+// isRunArtifactURI makes check that the artifact uri is the own `<run id>/artifacts` location of the run under
+// the artifact location of its experiment or the configured artifact roots.
+func (p Purger) isRunArtifactURI(run *models.Run) bool {
+	uri := strings.TrimRight(run.ArtifactURI, "/")
+	parent, ok := strings.CutSuffix(uri, "/"+run.ID+"/artifacts")
+	if !ok || slices.Contains(strings.Split(uri, "/"), "..") {
+		return false
+	}
+	for _, root := range []string{
+		run.Experiment.ArtifactLocation, p.config.DefaultArtifactRoot, p.config.ArtifactsDestination,
+	} {
+		root = strings.TrimRight(root, "/")
+		if root != "" && (parent == root || strings.HasPrefix(parent, root+"/")) {
+			return true
+		}
+	}
+	return false
+}
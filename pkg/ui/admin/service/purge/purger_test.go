@@ -0,0 +1,71 @@
+package purge
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+func TestPurger_isRunArtifactURI(t *testing.T) {
+	testData := []struct {
+		name        string
+		artifactURI string
+		expected    bool
+	}{
+		{
+			name:        "ExperimentArtifactLocation",
+			artifactURI: "s3://bucket/experiment/run1/artifacts",
+			expected:    true,
+		},
+		{
+			name:        "ExperimentArtifactLocationWithTrailingSlash",
+			artifactURI: "s3://bucket/experiment/run1/artifacts/",
+			expected:    true,
+		},
+		{
+			name:        "DefaultArtifactRoot",
+			artifactURI: "s3://root/1/run1/artifacts",
+			expected:    true,
+		},
+		{
+			name:        "ArtifactsOfAnotherRun",
+			artifactURI: "s3://bucket/experiment/run2/artifacts",
+			expected:    false,
+		},
+		{
+			name:        "ArtifactLocationOfExperiment",
+			artifactURI: "s3://bucket/experiment",
+			expected:    false,
+		},
+		{
+			name:        "OutsideOfArtifactRoots",
+			artifactURI: "s3://other/run1/artifacts",
+			expected:    false,
+		},
+		{
+			name:        "SiblingOfArtifactRoot",
+			artifactURI: "s3://root-other/run1/artifacts",
+			expected:    false,
+		},
+		{
+			name:        "ParentDirectoryReference",
+			artifactURI: "s3://bucket/experiment/../../other/run1/artifacts",
+			expected:    false,
+		},
+	}
+
+	purger := NewPurger(context.Background(), &config.Config{DefaultArtifactRoot: "s3://root"}, nil, nil)
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, purger.isRunArtifactURI(&models.Run{
+				ID:          "run1",
+				ArtifactURI: tt.artifactURI,
+				Experiment:  models.Experiment{ArtifactLocation: "s3://bucket/experiment/"},
+			}))
+		})
+	}
+}
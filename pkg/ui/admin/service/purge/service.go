@@ -0,0 +1,31 @@
+package purge
+
+import (
+	"context"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+)
+
+// Service provides service layer to work with `run purge` business logic.
+type Service struct {
+	purgeRepository repositories.RunPurgeRepositoryProvider
+}
+
+// NewService creates new Service instance.
+func NewService(purgeRepository repositories.RunPurgeRepositoryProvider) *Service {
+	return &Service{
+		purgeRepository: purgeRepository,
+	}
+}
+
+// ListPurges returns the purges of the deleted runs which are not completed yet.
+func (s Service) ListPurges(ctx context.Context) ([]models.RunPurge, error) {
+	purges, err := s.purgeRepository.List(ctx)
+	if err != nil {
+		return nil, eris.Wrap(err, "error listing run purges")
+	}
+	return purges, nil
+}
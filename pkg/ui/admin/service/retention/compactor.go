@@ -0,0 +1,74 @@
+package retention
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/metricstore"
+)
+
+// Compactor represents metrics compactor, which periodically applies metric retention policies.
+type Compactor struct {
+	ctx                 context.Context
+	config              *config.Config
+	retentionRepository repositories.MetricRetentionPolicyRepositoryProvider
+}
+
+// NewCompactor creates a new instance of Compactor.
+func NewCompactor(
+	ctx context.Context,
+	config *config.Config,
+	retentionRepository repositories.MetricRetentionPolicyRepositoryProvider,
+) *Compactor {
+	return &Compactor{
+		ctx:                 ctx,
+		config:              config,
+		retentionRepository: retentionRepository,
+	}
+}
+
+// Run runs metrics compactor background job. Zero interval means that policies are not applied.
+// Policies are not applied by `parquet` metric store either, as they work with the metrics table only.
+func (m Compactor) Run() {
+	if m.config.MetricRetentionInterval == 0 {
+		return
+	}
+	if m.config.MetricStore == metricstore.TypeParquet {
+		log.Warn("metric retention policies are not supported by 'parquet' metric store and are not applied")
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(m.config.MetricRetentionInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-m.ctx.Done():
+				log.Debug("metrics compactor finished. exiting.")
+				return
+			case <-ticker.C:
+				m.applyPolicies()
+			}
+		}
+	}()
+}
+
+// applyPolicies applies all the metric retention policies one by one.
+func (m Compactor) applyPolicies() {
+	policies, err := m.retentionRepository.List(m.ctx)
+	if err != nil {
+		log.Errorf("error listing metric retention policies: %+v", err)
+		return
+	}
+	for i := range policies {
+		numberOfRemoved, err := m.retentionRepository.Apply(m.ctx, &policies[i], time.Now().UTC())
+		if err != nil {
+			log.Errorf("error applying metric retention policy %s: %+v", policies[i].ID, err)
+			continue
+		}
+		log.Debugf("%d metrics were removed by metric retention policy %s", numberOfRemoved, policies[i].ID)
+	}
+}
@@ -0,0 +1,189 @@
+package retention
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/metricstore"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+)
+
+// Service provides service layer to work with `metric retention policy` business logic.
+type Service struct {
+	config               *config.Config
+	namespaceRepository  repositories.NamespaceRepositoryProvider
+	experimentRepository repositories.ExperimentRepositoryProvider
+	retentionRepository  repositories.MetricRetentionPolicyRepositoryProvider
+}
+
+// NewService creates new Service instance.
+func NewService(
+	config *config.Config,
+	namespaceRepository repositories.NamespaceRepositoryProvider,
+	experimentRepository repositories.ExperimentRepositoryProvider,
+	retentionRepository repositories.MetricRetentionPolicyRepositoryProvider,
+) *Service {
+	return &Service{
+		config:               config,
+		namespaceRepository:  namespaceRepository,
+		experimentRepository: experimentRepository,
+		retentionRepository:  retentionRepository,
+	}
+}
+
+// ListPolicies returns all policies.
+func (s Service) ListPolicies(ctx context.Context) ([]models.MetricRetentionPolicy, error) {
+	policies, err := s.retentionRepository.List(ctx)
+	if err != nil {
+		return nil, eris.Wrap(err, "error listing metric retention policies")
+	}
+	return policies, nil
+}
+
+// GetPolicy returns the policy by its ID.
+func (s Service) GetPolicy(ctx context.Context, id uuid.UUID) (*models.MetricRetentionPolicy, error) {
+	policy, err := s.retentionRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error finding metric retention policy by id: %s", id)
+	}
+	return policy, nil
+}
+
+// CreatePolicy creates a new policy. There can be only one policy of each namespace and experiment.
+func (s Service) CreatePolicy(
+	ctx context.Context, req *request.MetricRetentionPolicy,
+) (*models.MetricRetentionPolicy, error) {
+	policy, err := s.convertRequestToPolicy(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkPolicyIsUnique(ctx, policy); err != nil {
+		return nil, err
+	}
+	policy.ID = uuid.New()
+	if err := s.retentionRepository.Create(ctx, policy); err != nil {
+		return nil, eris.Wrap(err, "error creating metric retention policy")
+	}
+	return policy, nil
+}
+
+// UpdatePolicy updates the scope, full resolution period and downsampling of the existing policy.
+func (s Service) UpdatePolicy(
+	ctx context.Context, id uuid.UUID, req *request.MetricRetentionPolicy,
+) (*models.MetricRetentionPolicy, error) {
+	policy, err := s.GetPolicy(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return nil, eris.Errorf("metric retention policy not found by id: %s", id)
+	}
+	updated, err := s.convertRequestToPolicy(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	updated.ID = policy.ID
+	if err := s.checkPolicyIsUnique(ctx, updated); err != nil {
+		return nil, err
+	}
+
+	policy.NamespaceID, policy.Namespace = updated.NamespaceID, updated.Namespace
+	policy.ExperimentID, policy.Experiment = updated.ExperimentID, updated.Experiment
+	policy.FullResolutionDays, policy.KeepEvery, policy.KeepMinMax = updated.FullResolutionDays,
+		updated.KeepEvery, updated.KeepMinMax
+	if err := s.retentionRepository.Update(ctx, policy); err != nil {
+		return nil, eris.Wrap(err, "error updating metric retention policy")
+	}
+	return policy, nil
+}
+
+// DeletePolicy deletes the policy. Metrics which have been already removed by the policy can't be restored.
+func (s Service) DeletePolicy(ctx context.Context, id uuid.UUID) error {
+	policy, err := s.GetPolicy(ctx, id)
+	if err != nil {
+		return err
+	}
+	if policy == nil {
+		return eris.Errorf("metric retention policy not found by id: %s", id)
+	}
+	if err := s.retentionRepository.Delete(ctx, policy); err != nil {
+		return eris.Wrap(err, "error deleting metric retention policy")
+	}
+	return nil
+}
+
+// PreviewPolicy returns the number of metrics in the scope of the policy and the number of metrics it would remove
+// if it was applied now. The policy doesn't have to be saved.
+func (s Service) PreviewPolicy(
+	ctx context.Context, req *request.MetricRetentionPolicy,
+) (*repositories.MetricRetentionPreview, error) {
+	policy, err := s.convertRequestToPolicy(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	preview, err := s.retentionRepository.Preview(ctx, policy, time.Now().UTC())
+	if err != nil {
+		return nil, eris.Wrap(err, "error previewing metric retention policy")
+	}
+	return preview, nil
+}
+
+// convertRequestToPolicy validates the request and resolves namespace and experiment of the policy.
+func (s Service) convertRequestToPolicy(
+	ctx context.Context, req *request.MetricRetentionPolicy,
+) (*models.MetricRetentionPolicy, error) {
+	// policies are applied to the metrics table only, so the metrics kept as Parquet segments would stay intact.
+	if s.config.MetricStore == metricstore.TypeParquet {
+		return nil, eris.New("metric retention policies are not supported by 'parquet' metric store")
+	}
+	if err := ValidateMetricRetentionPolicy(req); err != nil {
+		return nil, eris.Wrap(err, "error validating metric retention policy")
+	}
+
+	namespace, err := s.namespaceRepository.GetByCode(ctx, req.NamespaceCode)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error finding namespace by code: %s", req.NamespaceCode)
+	}
+	if namespace == nil {
+		return nil, eris.Errorf("namespace not found by code: %s", req.NamespaceCode)
+	}
+
+	policy := models.MetricRetentionPolicy{
+		NamespaceID:        namespace.ID,
+		Namespace:          *namespace,
+		FullResolutionDays: req.FullResolutionDays,
+		KeepEvery:          req.KeepEvery,
+		KeepMinMax:         req.KeepMinMax,
+	}
+	if req.ExperimentID != "" {
+		// the request has been already validated, so experiment id is a correct number.
+		id, _ := strconv.ParseInt(req.ExperimentID, 10, 32)
+		experiment, err := s.experimentRepository.GetByNamespaceIDAndExperimentID(ctx, namespace.ID, int32(id))
+		if err != nil {
+			return nil, eris.Wrapf(
+				err, "error finding experiment with id: %s in namespace: %s", req.ExperimentID, namespace.Code,
+			)
+		}
+		policy.ExperimentID, policy.Experiment = experiment.ID, experiment
+	}
+	return &policy, nil
+}
+
+// checkPolicyIsUnique makes check that there is no other policy of the same namespace and experiment.
+func (s Service) checkPolicyIsUnique(ctx context.Context, policy *models.MetricRetentionPolicy) error {
+	existing, err := s.retentionRepository.GetByScope(ctx, policy.NamespaceID, policy.ExperimentID)
+	if err != nil {
+		return eris.Wrap(err, "error finding existing metric retention policy")
+	}
+	if existing != nil && existing.ID != policy.ID {
+		return eris.New("metric retention policy of the namespace or experiment already exists")
+	}
+	return nil
+}
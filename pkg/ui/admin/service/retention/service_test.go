@@ -0,0 +1,27 @@
+package retention
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/metricstore"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+)
+
+func TestService_ParquetMetricStore_Error(t *testing.T) {
+	service := NewService(&config.Config{MetricStore: metricstore.TypeParquet}, nil, nil, nil)
+	req := request.MetricRetentionPolicy{
+		NamespaceCode:      "default",
+		FullResolutionDays: 30,
+		KeepEvery:          10,
+	}
+
+	_, err := service.CreatePolicy(context.Background(), &req)
+	assert.ErrorContains(t, err, "metric retention policies are not supported by 'parquet' metric store")
+
+	_, err = service.PreviewPolicy(context.Background(), &req)
+	assert.ErrorContains(t, err, "metric retention policies are not supported by 'parquet' metric store")
+}
@@ -0,0 +1,37 @@
+package retention
+
+import (
+	"strconv"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+)
+
+const (
+	namespaceValidationMessage      = "metric retention policy namespace is invalid -- must not be empty"
+	experimentValidationMessage     = "metric retention policy experiment is invalid -- must be empty or experiment id"
+	fullResolutionValidationMessage = "metric retention policy full resolution period is invalid -- " +
+		"must be 0 or positive number of days"
+	keepEveryValidationMessage = "metric retention policy downsampling is invalid -- " +
+		"must keep every 2nd to every 1000000th iteration"
+	maxKeepEvery = 1000000
+)
+
+// ValidateMetricRetentionPolicy validates scope, full resolution period and downsampling of the policy.
+func ValidateMetricRetentionPolicy(req *request.MetricRetentionPolicy) error {
+	if req.NamespaceCode == "" {
+		return api.NewInvalidParameterValueError(namespaceValidationMessage)
+	}
+	if req.ExperimentID != "" {
+		if _, err := strconv.ParseInt(req.ExperimentID, 10, 32); err != nil {
+			return api.NewInvalidParameterValueError(experimentValidationMessage)
+		}
+	}
+	if req.FullResolutionDays < 0 {
+		return api.NewInvalidParameterValueError(fullResolutionValidationMessage)
+	}
+	if req.KeepEvery < 2 || req.KeepEvery > maxKeepEvery {
+		return api.NewInvalidParameterValueError(keepEveryValidationMessage)
+	}
+	return nil
+}
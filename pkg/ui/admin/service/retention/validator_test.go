@@ -0,0 +1,77 @@
+package retention
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+)
+
+func TestValidateMetricRetentionPolicy_Ok(t *testing.T) {
+	err := ValidateMetricRetentionPolicy(&request.MetricRetentionPolicy{
+		NamespaceCode:      "default",
+		ExperimentID:       "1",
+		FullResolutionDays: 30,
+		KeepEvery:          10,
+		KeepMinMax:         true,
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateMetricRetentionPolicy_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.MetricRetentionPolicy
+	}{
+		{
+			name:    "EmptyNamespace",
+			error:   api.NewInvalidParameterValueError(namespaceValidationMessage),
+			request: &request.MetricRetentionPolicy{KeepEvery: 10},
+		},
+		{
+			name:  "IncorrectExperimentID",
+			error: api.NewInvalidParameterValueError(experimentValidationMessage),
+			request: &request.MetricRetentionPolicy{
+				NamespaceCode: "default",
+				ExperimentID:  "experiment",
+				KeepEvery:     10,
+			},
+		},
+		{
+			name:  "NegativeFullResolutionDays",
+			error: api.NewInvalidParameterValueError(fullResolutionValidationMessage),
+			request: &request.MetricRetentionPolicy{
+				NamespaceCode:      "default",
+				FullResolutionDays: -1,
+				KeepEvery:          10,
+			},
+		},
+		{
+			name:  "KeepEveryTooSmall",
+			error: api.NewInvalidParameterValueError(keepEveryValidationMessage),
+			request: &request.MetricRetentionPolicy{
+				NamespaceCode: "default",
+				KeepEvery:     1,
+			},
+		},
+		{
+			name:  "KeepEveryTooLarge",
+			error: api.NewInvalidParameterValueError(keepEveryValidationMessage),
+			request: &request.MetricRetentionPolicy{
+				NamespaceCode: "default",
+				KeepEvery:     maxKeepEvery + 1,
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMetricRetentionPolicy(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
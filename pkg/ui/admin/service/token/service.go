@@ -0,0 +1,75 @@
+package token
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// Service provides service layer to work with `api token` business logic.
+type Service struct {
+	tokenRepository repositories.APITokenRepositoryProvider
+}
+
+// NewService creates new Service instance.
+func NewService(tokenRepository repositories.APITokenRepositoryProvider) *Service {
+	return &Service{
+		tokenRepository: tokenRepository,
+	}
+}
+
+// ListTokens returns all tokens.
+func (s Service) ListTokens(ctx context.Context) ([]models.APIToken, error) {
+	tokens, err := s.tokenRepository.List(ctx)
+	if err != nil {
+		return nil, eris.Wrap(err, "error listing api tokens")
+	}
+	return tokens, nil
+}
+
+// CreateToken creates a new token and returns it along with its plain value, which is not stored.
+// Zero expiresInDays means that token never expires.
+func (s Service) CreateToken(
+	ctx context.Context, name string, roles []string, expiresInDays int,
+) (*models.APIToken, string, error) {
+	if err := ValidateToken(name, roles, expiresInDays); err != nil {
+		return nil, "", eris.Wrap(err, "error validating api token")
+	}
+
+	var expiresAt *time.Time
+	if expiresInDays > 0 {
+		expiration := time.Now().UTC().AddDate(0, 0, expiresInDays)
+		expiresAt = &expiration
+	}
+	token, value, err := models.NewAPIToken(name, roles, expiresAt)
+	if err != nil {
+		return nil, "", eris.Wrap(err, "error generating api token")
+	}
+	if err := s.tokenRepository.Create(ctx, token); err != nil {
+		return nil, "", eris.Wrap(err, "error creating api token")
+	}
+	return token, value, nil
+}
+
+// RevokeToken revokes the token, so it can't be used anymore.
+func (s Service) RevokeToken(ctx context.Context, id uuid.UUID) error {
+	token, err := s.tokenRepository.GetByID(ctx, id)
+	if err != nil {
+		return eris.Wrapf(err, "error finding api token by id: %s", id)
+	}
+	if token == nil {
+		return eris.Errorf("api token not found by id: %s", id)
+	}
+	if token.IsRevoked() {
+		return eris.Errorf("api token with id: %s is already revoked", id)
+	}
+	if err := s.tokenRepository.Revoke(ctx, token); err != nil {
+		return eris.Wrap(err, "error revoking api token")
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package token
+
+import (
+	"regexp"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+const (
+	maxNameLength          = 64
+	nameValidationMessage  = "token name is invalid -- must be 1-64 characters"
+	rolesValidationMessage = "token roles are invalid -- must be 'admin', " +
+		"'ns:<code>' or 'ns:<code>:<read|write|owner>'"
+	expirationValidationMessage = "token expiration is invalid -- must be 0 or positive number of days"
+)
+
+// validation rule for token role.
+var validRole = regexp.MustCompile(`^admin$|^ns:[\w\d-_]{2,12}(:(read|write|owner))?$`)
+
+// ValidateToken validates token name, roles and expiration.
+func ValidateToken(name string, roles []string, expiresInDays int) error {
+	if name == "" || len(name) > maxNameLength {
+		return api.NewInvalidParameterValueError(nameValidationMessage)
+	}
+	if len(roles) == 0 {
+		return api.NewInvalidParameterValueError(rolesValidationMessage)
+	}
+	for _, role := range roles {
+		if !validRole.MatchString(role) {
+			return api.NewInvalidParameterValueError(rolesValidationMessage)
+		}
+	}
+	if expiresInDays < 0 {
+		return api.NewInvalidParameterValueError(expirationValidationMessage)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+func TestValidateToken_Ok(t *testing.T) {
+	err := ValidateToken("training", []string{"admin", "ns:default", "ns:legit-123_ns:read"}, 30)
+	require.Nil(t, err)
+}
+
+func TestValidateToken_Error(t *testing.T) {
+	testData := []struct {
+		name          string
+		error         *api.ErrorResponse
+		tokenName     string
+		roles         []string
+		expiresInDays int
+	}{
+		{
+			name:      "EmptyName",
+			error:     api.NewInvalidParameterValueError(nameValidationMessage),
+			tokenName: "",
+			roles:     []string{"admin"},
+		},
+		{
+			name:      "NoRoles",
+			error:     api.NewInvalidParameterValueError(rolesValidationMessage),
+			tokenName: "training",
+		},
+		{
+			name:      "UnknownRole",
+			error:     api.NewInvalidParameterValueError(rolesValidationMessage),
+			tokenName: "training",
+			roles:     []string{"ns:default", "superuser"},
+		},
+		{
+			name:      "UnknownPermission",
+			error:     api.NewInvalidParameterValueError(rolesValidationMessage),
+			tokenName: "training",
+			roles:     []string{"ns:default:delete"},
+		},
+		{
+			name:          "NegativeExpiration",
+			error:         api.NewInvalidParameterValueError(expirationValidationMessage),
+			tokenName:     "training",
+			roles:         []string{"admin"},
+			expiresInDays: -1,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateToken(tt.tokenName, tt.roles, tt.expiresInDays)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
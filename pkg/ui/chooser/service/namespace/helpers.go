@@ -1,9 +1,8 @@
 package namespace
 
 import (
-	"fmt"
-
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	commonModels "github.com/G-Research/fasttrackml/pkg/common/dao/models"
 )
 
 // FilterNamespacesByAuthTokenUserRoles filter namespaces by provided roles from Auth token.
@@ -13,7 +12,7 @@ func FilterNamespacesByAuthTokenUserRoles(
 ) []models.Namespace {
 	var filteredPermissions []models.Namespace
 	for _, namespace := range namespaces {
-		if _, ok := roles[fmt.Sprintf("ns:%s", namespace.Code)]; ok {
+		if _, ok := commonModels.GetNamespacePermission(roles, namespace.Code); ok {
 			filteredPermissions = append(filteredPermissions, namespace)
 		}
 	}
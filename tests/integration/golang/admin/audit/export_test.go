@@ -0,0 +1,103 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/response"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type ExportAuditEventsTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestExportAuditEventsTestSuite(t *testing.T) {
+	suite.Run(t, new(ExportAuditEventsTestSuite))
+}
+
+func (s *ExportAuditEventsTestSuite) Test_Ok() {
+	now := time.Now().UTC().Truncate(time.Second)
+	events := make([]*models.AuditEvent, 3)
+	for i := range events {
+		event, err := s.AuditEventFixtures.CreateAuditEvent(context.Background(), &models.AuditEvent{
+			ID:            uuid.New(),
+			CreatedAt:     now.Add(time.Duration(i) * time.Minute),
+			Actor:         "user1",
+			NamespaceCode: "default",
+			Action:        "mlflow.runs.delete-tag",
+			Method:        http.MethodPost,
+			Path:          "/api/2.0/mlflow/runs/delete-tag",
+			TargetIDs:     "run1,run2",
+			Request:       `{"run_id":"run1","key":"tag"}`,
+			StatusCode:    http.StatusOK,
+			RemoteAddress: "0.0.0.0",
+		})
+		s.Require().Nil(err)
+		events[i] = event
+	}
+	_, err := s.AuditEventFixtures.CreateAuditEvent(context.Background(), &models.AuditEvent{
+		ID:         uuid.New(),
+		CreatedAt:  now,
+		Actor:      "user2",
+		Action:     "admin.tokens.create",
+		Method:     http.MethodPost,
+		Path:       "/admin/tokens/",
+		StatusCode: http.StatusOK,
+	})
+	s.Require().Nil(err)
+
+	resp := new(bytes.Buffer)
+	client := s.AdminClient().WithQuery(
+		request.AuditEvents{Actor: "user1"},
+	).WithResponseType(
+		helpers.ResponseTypeBuffer,
+	).WithResponse(
+		resp,
+	)
+	s.Require().Nil(client.DoRequest("/audit/export"))
+	s.Equal(http.StatusOK, client.GetStatusCode())
+
+	// events are exported as JSON lines from the oldest ones.
+	var exported []response.AuditEvent
+	scanner := bufio.NewScanner(resp)
+	for scanner.Scan() {
+		var event response.AuditEvent
+		s.Require().Nil(json.Unmarshal(scanner.Bytes(), &event))
+		exported = append(exported, event)
+	}
+	s.Require().Len(exported, len(events))
+	for i, event := range events {
+		s.Equal(event.ID, exported[i].ID)
+		s.True(event.CreatedAt.Equal(exported[i].CreatedAt))
+		s.Equal("user1", exported[i].Actor)
+		s.Equal("default", exported[i].NamespaceCode)
+		s.Equal("mlflow.runs.delete-tag", exported[i].Action)
+		s.Equal([]string{"run1", "run2"}, exported[i].TargetIDs)
+		s.Equal(`{"run_id":"run1","key":"tag"}`, exported[i].Request)
+		s.Equal(http.StatusOK, exported[i].StatusCode)
+	}
+}
+
+func (s *ExportAuditEventsTestSuite) Test_Error() {
+	resp := new(bytes.Buffer)
+	client := s.AdminClient().WithQuery(
+		request.AuditEvents{To: "tomorrow"},
+	).WithResponseType(
+		helpers.ResponseTypeBuffer,
+	).WithResponse(
+		resp,
+	)
+	s.Require().Nil(client.DoRequest("/audit/export"))
+	s.Equal(http.StatusBadRequest, client.GetStatusCode())
+}
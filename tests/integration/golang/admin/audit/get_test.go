@@ -0,0 +1,136 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type GetAuditEventsTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestGetAuditEventsTestSuite(t *testing.T) {
+	suite.Run(t, new(GetAuditEventsTestSuite))
+}
+
+func (s *GetAuditEventsTestSuite) Test_Ok() {
+	now := time.Now().UTC()
+	for _, event := range []*models.AuditEvent{
+		{
+			ID:            uuid.New(),
+			CreatedAt:     now.AddDate(0, 0, -2),
+			Actor:         "user1",
+			NamespaceCode: "default",
+			Action:        "mlflow.runs.delete",
+			Method:        http.MethodPost,
+			Path:          "/api/2.0/mlflow/runs/delete",
+			TargetIDs:     "run1",
+			StatusCode:    http.StatusOK,
+		},
+		{
+			ID:            uuid.New(),
+			CreatedAt:     now.Add(-time.Hour),
+			Actor:         "user2",
+			NamespaceCode: "default",
+			Action:        "aim.runs.update",
+			Method:        http.MethodPut,
+			Path:          "/aim/api/runs/run2",
+			TargetIDs:     "run2",
+			StatusCode:    http.StatusOK,
+		},
+		{
+			ID:         uuid.New(),
+			CreatedAt:  now,
+			Actor:      "user1",
+			Action:     "admin.namespaces.delete",
+			Method:     http.MethodDelete,
+			Path:       "/admin/namespaces/2/",
+			TargetIDs:  "2",
+			StatusCode: http.StatusOK,
+		},
+	} {
+		_, err := s.AuditEventFixtures.CreateAuditEvent(context.Background(), event)
+		s.Require().Nil(err)
+	}
+
+	tests := []struct {
+		name    string
+		request request.AuditEvents
+		actions []string
+	}{
+		{
+			name:    "NoFilters",
+			request: request.AuditEvents{},
+			actions: []string{"admin.namespaces.delete", "aim.runs.update", "mlflow.runs.delete"},
+		},
+		{
+			name:    "FilterByActor",
+			request: request.AuditEvents{Actor: "user1"},
+			actions: []string{"admin.namespaces.delete", "mlflow.runs.delete"},
+		},
+		{
+			name:    "FilterByNamespaceAndActionPrefix",
+			request: request.AuditEvents{Namespace: "default", Action: "aim."},
+			actions: []string{"aim.runs.update"},
+		},
+		{
+			name:    "FilterByTarget",
+			request: request.AuditEvents{Target: "run1"},
+			actions: []string{"mlflow.runs.delete"},
+		},
+		{
+			name:    "FilterByDates",
+			request: request.AuditEvents{To: now.AddDate(0, 0, -1).Format("2006-01-02")},
+			actions: []string{"mlflow.runs.delete"},
+		},
+		{
+			name:    "Limit",
+			request: request.AuditEvents{Limit: 1},
+			actions: []string{"admin.namespaces.delete"},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp goquery.Document
+			s.Require().Nil(
+				s.AdminClient().WithQuery(
+					tt.request,
+				).WithResponseType(
+					helpers.ResponseTypeHTML,
+				).WithResponse(
+					&resp,
+				).DoRequest("/audit/"),
+			)
+			var actions []string
+			resp.Find("#audit-events tbody tr td:nth-child(4)").Each(func(_ int, selection *goquery.Selection) {
+				actions = append(actions, selection.Text())
+			})
+			s.Equal(tt.actions, actions)
+		})
+	}
+}
+
+func (s *GetAuditEventsTestSuite) Test_Error() {
+	var resp goquery.Document
+	s.Require().Nil(
+		s.AdminClient().WithQuery(
+			request.AuditEvents{From: "yesterday"},
+		).WithResponseType(
+			helpers.ResponseTypeHTML,
+		).WithResponse(
+			&resp,
+		).DoRequest("/audit/"),
+	)
+	s.Equal("The filter is invalid.", resp.Find(".error-message").Text())
+	s.Equal(0, resp.Find("#audit-events tbody tr").Length())
+}
@@ -0,0 +1,89 @@
+package purge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type GCTestSuite struct {
+	helpers.BaseTestSuite
+	runs []*models.Run
+}
+
+func TestGCTestSuite(t *testing.T) {
+	suite.Run(t, new(GCTestSuite))
+}
+
+func (s *GCTestSuite) SetupTest() {
+	s.BaseTestSuite.SetupTest()
+
+	var err error
+	s.runs, err = s.RunFixtures.CreateExampleRuns(context.Background(), s.DefaultExperiment, 3)
+	s.Require().Nil(err)
+	s.Require().Nil(s.RunFixtures.ArchiveRun(
+		context.Background(), s.DefaultNamespace.ID, []string{s.runs[0].ID, s.runs[1].ID},
+	))
+}
+
+func (s *GCTestSuite) Test_Ok() {
+	tests := []struct {
+		name           string
+		olderThan      time.Time
+		runIDs         []string
+		expectedQueued int64
+		expectedRuns   int
+	}{
+		{
+			name:           "RunsDeletedLaterAreKept",
+			olderThan:      time.Now().Add(-time.Hour),
+			expectedQueued: 0,
+			expectedRuns:   3,
+		},
+		{
+			name:           "RequestedRunIsPurged",
+			runIDs:         []string{s.runs[1].ID},
+			expectedQueued: 1,
+			expectedRuns:   2,
+		},
+		{
+			name:           "AllDeletedRunsArePurged",
+			olderThan:      time.Now().Add(time.Minute),
+			expectedQueued: 1,
+			expectedRuns:   1,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			queued, err := s.RunPurgeFixtures.QueueDeletedRuns(context.Background(), tt.olderThan, tt.runIDs)
+			s.Require().Nil(err)
+			s.Equal(tt.expectedQueued, queued)
+
+			purged, err := s.RunPurgeFixtures.PurgeDeletedRuns(context.Background())
+			s.Require().Nil(err)
+			s.Equal(int(tt.expectedQueued), purged)
+
+			runs, err := s.RunFixtures.GetRuns(context.Background(), *s.DefaultExperiment.ID)
+			s.Require().Nil(err)
+			s.Equal(tt.expectedRuns, len(runs))
+		})
+	}
+}
+
+func (s *GCTestSuite) Test_Error() {
+	// active runs are not deleted by gc.
+	_, err := s.RunPurgeFixtures.QueueDeletedRuns(
+		context.Background(), time.Time{}, []string{s.runs[0].ID, s.runs[2].ID},
+	)
+	s.Require().NotNil(err)
+	s.Contains(err.Error(), "run not in deleted lifecycle stage")
+
+	purges, err := s.RunPurgeFixtures.GetRunPurges(context.Background())
+	s.Require().Nil(err)
+	s.Empty(purges)
+}
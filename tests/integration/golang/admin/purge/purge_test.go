@@ -0,0 +1,249 @@
+package purge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type PurgeRunTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestPurgeRunTestSuite(t *testing.T) {
+	suite.Run(t, new(PurgeRunTestSuite))
+}
+
+func (s *PurgeRunTestSuite) Test_Ok() {
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:             "Test Experiment",
+		NamespaceID:      s.DefaultNamespace.ID,
+		LifecycleStage:   models.LifecycleStageActive,
+		ArtifactLocation: s.T().TempDir(),
+	})
+	s.Require().Nil(err)
+	runID := strings.ReplaceAll(uuid.New().String(), "-", "")
+	artifactURI := filepath.Join(experiment.ArtifactLocation, runID, "artifacts")
+	s.Require().Nil(os.MkdirAll(artifactURI, 0o755))
+	s.Require().Nil(os.WriteFile(filepath.Join(artifactURI, "model.txt"), []byte("model"), 0o600))
+
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             runID,
+		ExperimentID:   *experiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+		ArtifactURI:    artifactURI,
+	})
+	s.Require().Nil(err)
+	for _, key := range []string{"loss", "accuracy"} {
+		for iter := int64(1); iter <= 5; iter++ {
+			_, err := s.MetricFixtures.CreateMetricWithLatestMetric(context.Background(), &models.Metric{
+				Key:       key,
+				Value:     float64(iter),
+				Timestamp: 1234567890 + iter,
+				RunID:     run.ID,
+				Step:      iter,
+				Iter:      iter,
+			})
+			s.Require().Nil(err)
+		}
+	}
+	_, err = s.ParamFixtures.CreateParam(context.Background(), &models.Param{
+		Key:      "param1",
+		ValueStr: common.GetPointer("value1"),
+		RunID:    run.ID,
+	})
+	s.Require().Nil(err)
+	dataset, err := s.InputFixtures.CreateDataset(context.Background(), &models.Dataset{
+		ID:           "dataset",
+		ExperimentID: *experiment.ID,
+		Name:         "dataset",
+		Digest:       "digest",
+		SourceType:   "local",
+		Source:       "source",
+	})
+	s.Require().Nil(err)
+	_, err = s.InputFixtures.CreateInput(context.Background(), &models.Input{
+		ID:              "input",
+		SourceType:      models.InputSourceTypeDataset,
+		SourceID:        dataset.ID,
+		DestinationType: models.InputDestinationTypeRun,
+		DestinationID:   run.ID,
+		Tags: []models.InputTag{
+			{
+				InputID: "input",
+				Key:     models.InputTagDatasetContext,
+				Value:   "training",
+			},
+		},
+	})
+	s.Require().Nil(err)
+
+	// deleted run is hidden at once and queued to be purged.
+	var deleteResp fiber.Map
+	s.Require().Nil(
+		s.AIMClient().WithMethod(
+			http.MethodDelete,
+		).WithResponse(
+			&deleteResp,
+		).DoRequest(
+			"/runs/%s", run.ID,
+		),
+	)
+
+	var getResp api.ErrorResponse
+	s.Require().Nil(
+		s.MlflowClient().WithQuery(
+			request.GetRunRequest{RunID: run.ID},
+		).WithResponse(
+			&getResp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsGetRoute,
+		),
+	)
+	s.Equal(api.ErrorCodeResourceDoesNotExist, string(getResp.ErrorCode))
+
+	var page goquery.Document
+	s.Require().Nil(
+		s.AdminClient().WithResponseType(
+			helpers.ResponseTypeHTML,
+		).WithResponse(
+			&page,
+		).DoRequest("/purges/"),
+	)
+	rows := page.Find("#run-purges tbody tr")
+	s.Equal(1, rows.Length())
+	s.Equal(run.ID, rows.Find("td:nth-child(1)").Text())
+	s.Equal(s.DefaultNamespace.Code, rows.Find("td:nth-child(2)").Text())
+	s.Equal(string(models.RunPurgeStatusPending), rows.Find("td:nth-child(4)").Text())
+
+	// metrics, inputs, artifacts and the run itself are removed by the purge.
+	purged, err := s.RunPurgeFixtures.PurgeDeletedRuns(context.Background())
+	s.Require().Nil(err)
+	s.Equal(1, purged)
+
+	purges, err := s.RunPurgeFixtures.GetRunPurges(context.Background())
+	s.Require().Nil(err)
+	s.Empty(purges)
+	metrics, err := s.MetricFixtures.GetMetricsByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Empty(metrics)
+	params, err := s.ParamFixtures.GetParamsByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Empty(params)
+	inputs, err := s.InputFixtures.GetByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Empty(inputs)
+	_, err = os.Stat(artifactURI)
+	s.True(os.IsNotExist(err))
+}
+
+func (s *PurgeRunTestSuite) Test_ForeignArtifacts_Ok() {
+	// artifacts of the run point to the location, which doesn't belong to the run.
+	artifactURI := s.T().TempDir()
+	s.Require().Nil(os.WriteFile(filepath.Join(artifactURI, "model.txt"), []byte("model"), 0o600))
+
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+		ArtifactURI:    artifactURI,
+	})
+	s.Require().Nil(err)
+
+	var deleteResp fiber.Map
+	s.Require().Nil(
+		s.AIMClient().WithMethod(
+			http.MethodDelete,
+		).WithResponse(
+			&deleteResp,
+		).DoRequest(
+			"/runs/%s", run.ID,
+		),
+	)
+
+	// the run is purged, but the artifacts are kept.
+	purged, err := s.RunPurgeFixtures.PurgeDeletedRuns(context.Background())
+	s.Require().Nil(err)
+	s.Equal(1, purged)
+
+	purges, err := s.RunPurgeFixtures.GetRunPurges(context.Background())
+	s.Require().Nil(err)
+	s.Empty(purges)
+	_, err = os.Stat(filepath.Join(artifactURI, "model.txt"))
+	s.Nil(err)
+}
+
+func (s *PurgeRunTestSuite) Test_Error() {
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:             "Test Experiment",
+		NamespaceID:      s.DefaultNamespace.ID,
+		LifecycleStage:   models.LifecycleStageActive,
+		ArtifactLocation: "unsupported://bucket",
+	})
+	s.Require().Nil(err)
+	runID := strings.ReplaceAll(uuid.New().String(), "-", "")
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             runID,
+		ExperimentID:   *experiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+		ArtifactURI:    "unsupported://bucket/" + runID + "/artifacts",
+	})
+	s.Require().Nil(err)
+
+	var deleteResp fiber.Map
+	s.Require().Nil(
+		s.AIMClient().WithMethod(
+			http.MethodDelete,
+		).WithResponse(
+			&deleteResp,
+		).DoRequest(
+			"/runs/%s", run.ID,
+		),
+	)
+
+	// failed purge keeps its error and is retried later.
+	_, err = s.RunPurgeFixtures.PurgeDeletedRuns(context.Background())
+	s.Require().NotNil(err)
+
+	purges, err := s.RunPurgeFixtures.GetRunPurges(context.Background())
+	s.Require().Nil(err)
+	s.Require().Len(purges, 1)
+	s.Equal(run.ID, purges[0].RunID)
+	s.Equal(models.RunPurgeStatusFailed, purges[0].Status)
+	s.Equal(1, purges[0].Attempts)
+	s.Contains(purges[0].Error, "error getting artifact storage")
+
+	var page goquery.Document
+	s.Require().Nil(
+		s.AdminClient().WithResponseType(
+			helpers.ResponseTypeHTML,
+		).WithResponse(
+			&page,
+		).DoRequest("/purges/"),
+	)
+	s.Equal(
+		fmt.Sprint(purges[0].Attempts), page.Find("#run-purges tbody tr td:nth-child(6)").Text(),
+	)
+}
@@ -0,0 +1,128 @@
+package retention
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type CreateRetentionPolicyTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestCreateRetentionPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateRetentionPolicyTestSuite))
+}
+
+func (s *CreateRetentionPolicyTestSuite) Test_Ok() {
+	var resp goquery.Document
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.MetricRetentionPolicy{
+				NamespaceCode:      "default",
+				ExperimentID:       "0",
+				FullResolutionDays: 30,
+				KeepEvery:          10,
+				KeepMinMax:         true,
+			},
+		).WithResponseType(
+			helpers.ResponseTypeHTML,
+		).WithResponse(
+			&resp,
+		).DoRequest("/retention"),
+	)
+	s.Equal("Successfully added new metric retention policy", resp.Find(".success-message").Text())
+	s.Equal(1, resp.Find("#retention-policies tbody tr").Length())
+
+	policies, err := s.MetricRetentionFixtures.GetMetricRetentionPolicies(context.Background())
+	s.Require().Nil(err)
+	s.Require().Len(policies, 1)
+	s.Equal(s.DefaultNamespace.ID, policies[0].NamespaceID)
+	s.Equal(s.DefaultExperiment.ID, policies[0].ExperimentID)
+	s.Equal(30, policies[0].FullResolutionDays)
+	s.Equal(10, policies[0].KeepEvery)
+	s.True(policies[0].KeepMinMax)
+	s.Nil(policies[0].LastAppliedAt)
+}
+
+func (s *CreateRetentionPolicyTestSuite) Test_Error() {
+	_, err := s.MetricRetentionFixtures.CreateMetricRetentionPolicy(
+		context.Background(), &models.MetricRetentionPolicy{
+			ID:                 uuid.New(),
+			NamespaceID:        s.DefaultNamespace.ID,
+			FullResolutionDays: 30,
+			KeepEvery:          10,
+		},
+	)
+	s.Require().Nil(err)
+
+	testData := []struct {
+		name    string
+		request *request.MetricRetentionPolicy
+		error   string
+	}{
+		{
+			name: "EmptyNamespace",
+			request: &request.MetricRetentionPolicy{
+				KeepEvery: 10,
+			},
+			error: "The metric retention policy is invalid.",
+		},
+		{
+			name: "InvalidKeepEvery",
+			request: &request.MetricRetentionPolicy{
+				NamespaceCode: "default",
+				KeepEvery:     1,
+			},
+			error: "The metric retention policy is invalid.",
+		},
+		{
+			name: "NotFoundNamespace",
+			request: &request.MetricRetentionPolicy{
+				NamespaceCode: "unknown",
+				KeepEvery:     10,
+			},
+			error: "An unexpected error was encountered: namespace not found by code: unknown",
+		},
+		{
+			name: "DuplicatePolicy",
+			request: &request.MetricRetentionPolicy{
+				NamespaceCode: "default",
+				KeepEvery:     5,
+			},
+			error: "An unexpected error was encountered: " +
+				"metric retention policy of the namespace or experiment already exists",
+		},
+	}
+	for _, tt := range testData {
+		s.Run(tt.name, func() {
+			var resp goquery.Document
+			s.Require().Nil(
+				s.AdminClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponseType(
+					helpers.ResponseTypeHTML,
+				).WithResponse(
+					&resp,
+				).DoRequest("/retention"),
+			)
+			s.Equal(tt.error, resp.Find(".error-message").Text())
+
+			policies, err := s.MetricRetentionFixtures.GetMetricRetentionPolicies(context.Background())
+			s.Require().Nil(err)
+			s.Len(policies, 1)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package retention
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type DeleteRetentionPolicyTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestDeleteRetentionPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(DeleteRetentionPolicyTestSuite))
+}
+
+func (s *DeleteRetentionPolicyTestSuite) Test_Ok() {
+	policy, err := s.MetricRetentionFixtures.CreateMetricRetentionPolicy(
+		context.Background(), &models.MetricRetentionPolicy{
+			ID:                 uuid.New(),
+			NamespaceID:        s.DefaultNamespace.ID,
+			FullResolutionDays: 30,
+			KeepEvery:          10,
+		},
+	)
+	s.Require().Nil(err)
+
+	var resp map[string]any
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodDelete,
+		).WithResponse(
+			&resp,
+		).DoRequest("/retention/%s", policy.ID),
+	)
+	s.Equal(map[string]any{
+		"message": "Successfully deleted metric retention policy.",
+		"status":  "success",
+	}, resp)
+
+	policies, err := s.MetricRetentionFixtures.GetMetricRetentionPolicies(context.Background())
+	s.Require().Nil(err)
+	s.Empty(policies)
+}
+
+func (s *DeleteRetentionPolicyTestSuite) Test_Error() {
+	id := uuid.New()
+	var resp map[string]any
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodDelete,
+		).WithResponse(
+			&resp,
+		).DoRequest("/retention/%s", id),
+	)
+	s.Equal(map[string]any{
+		"message": "An unexpected error was encountered: metric retention policy not found by id: " + id.String(),
+		"status":  "error",
+	}, resp)
+}
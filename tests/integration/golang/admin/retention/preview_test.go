@@ -0,0 +1,241 @@
+package retention
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type PreviewRetentionPolicyTestSuite struct {
+	helpers.BaseTestSuite
+	run *models.Run
+}
+
+func TestPreviewRetentionPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(PreviewRetentionPolicyTestSuite))
+}
+
+// SetupTest creates `loss` metric with 100 iterations, first 90 of them are older than the full resolution period,
+// and `acc` metric with 5 old iterations.
+func (s *PreviewRetentionPolicyTestSuite) SetupTest() {
+	s.BaseTestSuite.SetupTest()
+
+	var err error
+	s.run, err = s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+
+	old, recent := time.Now().AddDate(0, 0, -60).UnixMilli(), time.Now().UnixMilli()
+	for iter := int64(1); iter <= 100; iter++ {
+		metric := models.Metric{
+			Key:       "loss",
+			Value:     float64(iter),
+			Timestamp: old,
+			RunID:     s.run.ID,
+			Step:      iter,
+			Iter:      iter,
+		}
+		if iter > 90 {
+			metric.Timestamp = recent
+		}
+		// minimum of 10..19 and maximum of 20..29 iterations.
+		switch iter {
+		case 15:
+			metric.Value = -5
+		case 27:
+			metric.Value = 1000
+		}
+		_, err := s.MetricFixtures.CreateMetric(context.Background(), &metric)
+		s.Require().Nil(err)
+	}
+	_, err = s.MetricFixtures.CreateLatestMetric(context.Background(), &models.LatestMetric{
+		Key:       "loss",
+		Value:     100,
+		Timestamp: recent,
+		Step:      100,
+		RunID:     s.run.ID,
+		LastIter:  100,
+	})
+	s.Require().Nil(err)
+
+	for iter := int64(1); iter <= 5; iter++ {
+		_, err := s.MetricFixtures.CreateMetric(context.Background(), &models.Metric{
+			Key:       "acc",
+			Value:     float64(iter),
+			Timestamp: old,
+			RunID:     s.run.ID,
+			Step:      iter,
+			Iter:      iter,
+		})
+		s.Require().Nil(err)
+	}
+	_, err = s.MetricFixtures.CreateLatestMetric(context.Background(), &models.LatestMetric{
+		Key:       "acc",
+		Value:     5,
+		Timestamp: old,
+		Step:      5,
+		RunID:     s.run.ID,
+		LastIter:  5,
+	})
+	s.Require().Nil(err)
+}
+
+func (s *PreviewRetentionPolicyTestSuite) Test_Ok() {
+	tests := []struct {
+		name     string
+		request  *request.MetricRetentionPolicy
+		response map[string]any
+	}{
+		{
+			// `loss`: 81 old iterations are not multiple of 10, 11 of them are bucket minimums or maximums.
+			// `acc`: the last iteration is kept, as well as the first one, which is bucket minimum.
+			name: "KeepMinMax",
+			request: &request.MetricRetentionPolicy{
+				NamespaceCode:      "default",
+				FullResolutionDays: 30,
+				KeepEvery:          10,
+				KeepMinMax:         true,
+			},
+			response: map[string]any{
+				"status": "success",
+				"preview": map[string]any{
+					"total":   float64(105),
+					"removed": float64(73),
+					"percent": float64(73) * 100 / 105,
+				},
+			},
+		},
+		{
+			name: "KeepEveryOnly",
+			request: &request.MetricRetentionPolicy{
+				NamespaceCode:      "default",
+				ExperimentID:       "0",
+				FullResolutionDays: 30,
+				KeepEvery:          10,
+			},
+			response: map[string]any{
+				"status": "success",
+				"preview": map[string]any{
+					"total":   float64(105),
+					"removed": float64(85),
+					"percent": float64(85) * 100 / 105,
+				},
+			},
+		},
+		{
+			name: "FullResolutionPeriodNotPassed",
+			request: &request.MetricRetentionPolicy{
+				NamespaceCode:      "default",
+				FullResolutionDays: 90,
+				KeepEvery:          10,
+			},
+			response: map[string]any{
+				"status": "success",
+				"preview": map[string]any{
+					"total":   float64(105),
+					"removed": float64(0),
+					"percent": float64(0),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp map[string]any
+			s.Require().Nil(
+				s.AdminClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest("/retention/preview"),
+			)
+			s.Equal(tt.response, resp)
+		})
+	}
+}
+
+func (s *PreviewRetentionPolicyTestSuite) Test_Apply() {
+	policy, err := s.MetricRetentionFixtures.CreateMetricRetentionPolicy(
+		context.Background(), &models.MetricRetentionPolicy{
+			ID:                 uuid.New(),
+			NamespaceID:        s.DefaultNamespace.ID,
+			FullResolutionDays: 30,
+			KeepEvery:          10,
+			KeepMinMax:         true,
+		},
+	)
+	s.Require().Nil(err)
+
+	now := time.Now().UTC()
+	removed, err := s.MetricRetentionFixtures.ApplyMetricRetentionPolicy(context.Background(), policy, now)
+	s.Require().Nil(err)
+	s.Equal(int64(73), removed)
+
+	metrics, err := s.MetricFixtures.GetMetricsByRunID(context.Background(), s.run.ID)
+	s.Require().Nil(err)
+	var lossIters, accIters []int64
+	for _, metric := range metrics {
+		switch metric.Key {
+		case "loss":
+			lossIters = append(lossIters, metric.Iter)
+		case "acc":
+			accIters = append(accIters, metric.Iter)
+		}
+	}
+	slices.Sort(lossIters)
+	slices.Sort(accIters)
+	expectedLossIters := []int64{1, 9, 10, 15, 19, 20, 27, 30, 39, 40, 49, 50, 59, 60, 69, 70, 79, 80, 89, 90}
+	for iter := int64(91); iter <= 100; iter++ {
+		expectedLossIters = append(expectedLossIters, iter)
+	}
+	s.Equal(expectedLossIters, lossIters)
+	s.Equal([]int64{1, 5}, accIters)
+
+	// the policy is idempotent.
+	removed, err = s.MetricRetentionFixtures.ApplyMetricRetentionPolicy(context.Background(), policy, now)
+	s.Require().Nil(err)
+	s.Equal(int64(0), removed)
+
+	policies, err := s.MetricRetentionFixtures.GetMetricRetentionPolicies(context.Background())
+	s.Require().Nil(err)
+	s.Require().Len(policies, 1)
+	s.Equal(int64(73), policies[0].RemovedMetrics)
+	s.Require().NotNil(policies[0].LastAppliedAt)
+}
+
+func (s *PreviewRetentionPolicyTestSuite) Test_Error() {
+	var resp map[string]any
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.MetricRetentionPolicy{
+				NamespaceCode: "default",
+				KeepEvery:     1,
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest("/retention/preview"),
+	)
+	s.Equal(map[string]any{
+		"message": "The metric retention policy is invalid.",
+		"status":  "error",
+	}, resp)
+}
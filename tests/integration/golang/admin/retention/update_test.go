@@ -0,0 +1,145 @@
+package retention
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type UpdateRetentionPolicyTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestUpdateRetentionPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(UpdateRetentionPolicyTestSuite))
+}
+
+func (s *UpdateRetentionPolicyTestSuite) Test_Ok() {
+	policy, err := s.MetricRetentionFixtures.CreateMetricRetentionPolicy(
+		context.Background(), &models.MetricRetentionPolicy{
+			ID:                 uuid.New(),
+			NamespaceID:        s.DefaultNamespace.ID,
+			FullResolutionDays: 30,
+			KeepEvery:          10,
+		},
+	)
+	s.Require().Nil(err)
+
+	var resp map[string]any
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodPut,
+		).WithRequest(
+			request.MetricRetentionPolicy{
+				NamespaceCode:      "default",
+				ExperimentID:       "0",
+				FullResolutionDays: 7,
+				KeepEvery:          100,
+				KeepMinMax:         true,
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest("/retention/%s", policy.ID),
+	)
+	s.Equal(map[string]any{
+		"message": "Successfully updated metric retention policy.",
+		"status":  "success",
+	}, resp)
+
+	policies, err := s.MetricRetentionFixtures.GetMetricRetentionPolicies(context.Background())
+	s.Require().Nil(err)
+	s.Require().Len(policies, 1)
+	s.Equal(policy.ID, policies[0].ID)
+	s.Equal(s.DefaultExperiment.ID, policies[0].ExperimentID)
+	s.Equal(7, policies[0].FullResolutionDays)
+	s.Equal(100, policies[0].KeepEvery)
+	s.True(policies[0].KeepMinMax)
+}
+
+func (s *UpdateRetentionPolicyTestSuite) Test_Error() {
+	policy, err := s.MetricRetentionFixtures.CreateMetricRetentionPolicy(
+		context.Background(), &models.MetricRetentionPolicy{
+			ID:                 uuid.New(),
+			NamespaceID:        s.DefaultNamespace.ID,
+			FullResolutionDays: 30,
+			KeepEvery:          10,
+		},
+	)
+	s.Require().Nil(err)
+
+	id := uuid.New()
+	testData := []struct {
+		name     string
+		ID       uuid.UUID
+		request  *request.MetricRetentionPolicy
+		response map[string]any
+	}{
+		{
+			name: "UpdatePolicyWithNotFoundID",
+			ID:   id,
+			request: &request.MetricRetentionPolicy{
+				NamespaceCode: "default",
+				KeepEvery:     10,
+			},
+			response: map[string]any{
+				"message": "An unexpected error was encountered: metric retention policy not found by id: " +
+					id.String(),
+				"status": "error",
+			},
+		},
+		{
+			name: "UpdatePolicyWithNotFoundExperiment",
+			ID:   policy.ID,
+			request: &request.MetricRetentionPolicy{
+				NamespaceCode: "default",
+				ExperimentID:  "10",
+				KeepEvery:     10,
+			},
+			response: map[string]any{
+				"message": "An unexpected error was encountered: " +
+					"error finding experiment with id: 10 in namespace: default: " +
+					"error getting experiment by id: 10: record not found",
+				"status": "error",
+			},
+		},
+		{
+			name: "UpdatePolicyWithInvalidKeepEvery",
+			ID:   policy.ID,
+			request: &request.MetricRetentionPolicy{
+				NamespaceCode: "default",
+				KeepEvery:     0,
+			},
+			response: map[string]any{
+				"message": "The metric retention policy is invalid.",
+				"status":  "error",
+			},
+		},
+	}
+	for _, tt := range testData {
+		s.Run(tt.name, func() {
+			var resp map[string]any
+			s.Require().Nil(
+				s.AdminClient().WithMethod(
+					http.MethodPut,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest("/retention/%s", tt.ID),
+			)
+			s.Equal(tt.response, resp)
+
+			policies, err := s.MetricRetentionFixtures.GetMetricRetentionPolicies(context.Background())
+			s.Require().Nil(err)
+			s.Require().Len(policies, 1)
+			s.Equal(10, policies[0].KeepEvery)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package token
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type CreateTokenTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestCreateTokenTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateTokenTestSuite))
+}
+
+func (s *CreateTokenTestSuite) Test_Ok() {
+	var resp goquery.Document
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.Token{
+				Name:          "training",
+				Roles:         "ns:default:write, ns:other:read",
+				ExpiresInDays: 30,
+			},
+		).WithResponseType(
+			helpers.ResponseTypeHTML,
+		).WithResponse(
+			&resp,
+		).DoRequest("/tokens"),
+	)
+	value := resp.Find("#new-token").Text()
+	s.True(strings.HasPrefix(value, models.APITokenPrefix))
+
+	tokens, err := s.APITokenFixtures.GetAPITokens(context.Background())
+	s.Require().Nil(err)
+	s.Require().Len(tokens, 1)
+	s.Equal("training", tokens[0].Name)
+	s.Equal([]string{"ns:default:write", "ns:other:read"}, tokens[0].GetRoles())
+	// token is stored as a hash only.
+	s.Equal(models.HashAPIToken(value), tokens[0].TokenHash)
+	s.NotContains(tokens[0].TokenHash, value)
+	s.True(strings.HasPrefix(value, tokens[0].Prefix))
+	s.Require().NotNil(tokens[0].ExpiresAt)
+	s.WithinDuration(time.Now().AddDate(0, 0, 30), *tokens[0].ExpiresAt, time.Minute)
+	s.Nil(tokens[0].LastUsedAt)
+
+	// the list shows the token prefix only.
+	s.Require().Nil(
+		s.AdminClient().WithResponseType(
+			helpers.ResponseTypeHTML,
+		).WithResponse(
+			&resp,
+		).DoRequest("/tokens/"),
+	)
+	s.Empty(resp.Find("#new-token").Text())
+	s.Equal(1, resp.Find("#tokens tbody tr").Length())
+	s.Contains(resp.Find("#tokens tbody tr").Text(), tokens[0].Prefix)
+	s.NotContains(resp.Find("#tokens tbody tr").Text(), value)
+}
+
+func (s *CreateTokenTestSuite) Test_Error() {
+	testData := []struct {
+		name    string
+		request *request.Token
+		error   string
+	}{
+		{
+			name: "EmptyName",
+			request: &request.Token{
+				Roles: "ns:default",
+			},
+			error: "The token is invalid.",
+		},
+		{
+			name: "EmptyRoles",
+			request: &request.Token{
+				Name: "training",
+			},
+			error: "The token is invalid.",
+		},
+		{
+			name: "InvalidRole",
+			request: &request.Token{
+				Name:  "training",
+				Roles: "ns:default:delete",
+			},
+			error: "The token is invalid.",
+		},
+		{
+			name: "NegativeExpiration",
+			request: &request.Token{
+				Name:          "training",
+				Roles:         "ns:default",
+				ExpiresInDays: -1,
+			},
+			error: "The token is invalid.",
+		},
+	}
+	for _, tt := range testData {
+		s.Run(tt.name, func() {
+			var resp goquery.Document
+			s.Require().Nil(
+				s.AdminClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponseType(
+					helpers.ResponseTypeHTML,
+				).WithResponse(
+					&resp,
+				).DoRequest("/tokens"),
+			)
+			s.Equal(tt.error, resp.Find(".error-message").Text())
+
+			tokens, err := s.APITokenFixtures.GetAPITokens(context.Background())
+			s.Require().Nil(err)
+			s.Empty(tokens)
+		})
+	}
+}
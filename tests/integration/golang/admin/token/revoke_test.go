@@ -0,0 +1,90 @@
+package token
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type RevokeTokenTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestRevokeTokenTestSuite(t *testing.T) {
+	suite.Run(t, new(RevokeTokenTestSuite))
+}
+
+func (s *RevokeTokenTestSuite) Test_Ok() {
+	token, _, err := s.APITokenFixtures.CreateAPIToken(context.Background(), "training", []string{"ns:default"}, nil)
+	s.Require().Nil(err)
+
+	var resp map[string]any
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodDelete,
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"/tokens/%s", token.ID,
+		),
+	)
+	s.Equal(map[string]any{
+		"message": "Successfully revoked token.",
+		"status":  "success",
+	}, resp)
+
+	token, err = s.APITokenFixtures.GetAPIToken(context.Background(), token)
+	s.Require().Nil(err)
+	s.True(token.IsRevoked())
+}
+
+func (s *RevokeTokenTestSuite) Test_Error() {
+	token, _, err := s.APITokenFixtures.CreateAPIToken(context.Background(), "training", []string{"ns:default"}, nil)
+	s.Require().Nil(err)
+	s.Require().Nil(s.APITokenFixtures.RevokeAPIToken(context.Background(), token))
+
+	id := uuid.New()
+	testData := []struct {
+		name     string
+		ID       string
+		response map[string]any
+	}{
+		{
+			name: "RevokeTokenWithNotFoundID",
+			ID:   id.String(),
+			response: map[string]any{
+				"message": "An unexpected error was encountered: api token not found by id: " + id.String(),
+				"status":  "error",
+			},
+		},
+		{
+			name: "RevokeAlreadyRevokedToken",
+			ID:   token.ID.String(),
+			response: map[string]any{
+				"message": "An unexpected error was encountered: api token with id: " +
+					token.ID.String() + " is already revoked",
+				"status": "error",
+			},
+		},
+	}
+	for _, tt := range testData {
+		s.Run(tt.name, func() {
+			var resp map[string]any
+			s.Require().Nil(
+				s.AdminClient().WithMethod(
+					http.MethodDelete,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"/tokens/%s", tt.ID,
+				),
+			)
+			s.Equal(tt.response, resp)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/aim/encoding"
+	"github.com/G-Research/fasttrackml/pkg/api/aim/sampling"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type SearchMetricsSamplingTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSearchMetricsSamplingTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchMetricsSamplingTestSuite))
+}
+
+func (s *SearchMetricsSamplingTestSuite) Test_Ok() {
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           uuid.New().String(),
+		LifecycleStage: models.LifecycleStageActive,
+		NamespaceID:    s.DefaultNamespace.ID,
+	})
+	s.Require().Nil(err)
+
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:         "id1",
+		Name:       "TestRun1",
+		UserID:     "1",
+		Status:     models.StatusRunning,
+		SourceType: "JOB",
+		StartTime: sql.NullInt64{
+			Int64: 123456789,
+			Valid: true,
+		},
+		ExperimentID:   *experiment.ID,
+		ArtifactURI:    "artifact_uri1",
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	// long flat curve with one spike and one NaN value, which are skipped by the default sampling.
+	const (
+		count     = 200
+		spikeIter = 78
+		nanIter   = 134
+	)
+	for i := 1; i <= count; i++ {
+		metric := models.Metric{
+			Key:       "TestMetric",
+			Value:     1,
+			Timestamp: int64(i),
+			Step:      int64(i - 1),
+			RunID:     run.ID,
+			Iter:      int64(i),
+		}
+		switch i {
+		case spikeIter:
+			metric.Value = 1000
+		case nanIter:
+			metric.Value, metric.IsNan = 0, true
+		}
+		_, err = s.MetricFixtures.CreateMetric(context.Background(), &metric)
+		s.Require().Nil(err)
+	}
+	_, err = s.MetricFixtures.CreateLatestMetric(context.Background(), &models.LatestMetric{
+		Key:      "TestMetric",
+		Value:    1,
+		Step:     count - 1,
+		RunID:    run.ID,
+		LastIter: count,
+	})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name         string
+		sampling     string
+		keepsOutlier bool
+	}{
+		{
+			name:     "Default",
+			sampling: "",
+		},
+		{
+			name:     "Modulo",
+			sampling: sampling.MethodModulo,
+		},
+		{
+			name:         "LTTB",
+			sampling:     sampling.MethodLTTB,
+			keepsOutlier: true,
+		},
+		{
+			name:         "MinMax",
+			sampling:     sampling.MethodMinMax,
+			keepsOutlier: true,
+		},
+		{
+			name:         "Mean",
+			sampling:     sampling.MethodMean,
+			keepsOutlier: true,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := new(bytes.Buffer)
+			s.Require().Nil(
+				s.AIMClient().WithMethod(
+					http.MethodPost,
+				).WithQuery(map[any]any{
+					"p": 20,
+				}).WithRequest(
+					request.SearchMetricsRequest{
+						Metrics: []request.MetricTuple{
+							{
+								Key:     "TestMetric",
+								Context: fiber.Map{},
+							},
+						},
+						Steps:    20,
+						Sampling: tt.sampling,
+					},
+				).WithResponseType(
+					helpers.ResponseTypeBuffer,
+				).WithResponse(
+					resp,
+				).DoRequest("/runs/search/metric"),
+			)
+
+			decodedData, err := encoding.NewDecoder(resp).Decode()
+			s.Require().Nil(err)
+
+			prefix := fmt.Sprintf("%v.traces.0", run.ID)
+			s.Equal("TestMetric", decodedData[prefix+".name"])
+			values := decodedData[prefix+".values.blob"].([]float64)
+			iters := decodedData[prefix+".iters.blob"].([]float64)
+			s.LessOrEqual(len(values), 20)
+			s.Equal(len(values), len(iters))
+			s.True(slices.IsSorted(iters))
+
+			hasNaN := slices.ContainsFunc(values, math.IsNaN)
+			s.Equal(tt.keepsOutlier, hasNaN)
+			if tt.sampling != sampling.MethodMean {
+				s.Equal(tt.keepsOutlier, slices.Contains(values, 1000.0))
+			}
+		})
+	}
+}
+
+func (s *SearchMetricsSamplingTestSuite) Test_Error() {
+	var resp api.ErrorResponse
+	s.Require().Nil(
+		s.AIMClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.SearchMetricsRequest{
+				Metrics: []request.MetricTuple{
+					{
+						Key:     "TestMetric",
+						Context: fiber.Map{},
+					},
+				},
+				Sampling: "median",
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest("/runs/search/metric"),
+	)
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	s.Equal(`"median" is not a valid sampling method, supported methods are: [modulo lttb minmax mean]`, resp.Message)
+}
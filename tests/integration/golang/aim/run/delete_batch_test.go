@@ -70,6 +70,14 @@ func (s *DeleteBatchTestSuite) Test_Ok() {
 			s.Require().Nil(err)
 			s.Equal(tt.expectedRunCount, len(runs))
 
+			// deleted runs are queued to be purged, row numbers are recalculated by the purge.
+			purges, err := s.RunPurgeFixtures.GetRunPurges(context.Background())
+			s.Require().Nil(err)
+			s.Equal(len(tt.runIDs), len(purges))
+			purged, err := s.RunPurgeFixtures.PurgeDeletedRuns(context.Background())
+			s.Require().Nil(err)
+			s.Equal(len(tt.runIDs), purged)
+
 			newMinRowNum, newMaxRowNum, err := s.RunFixtures.FindMinMaxRowNums(
 				context.Background(), s.runs[0].ExperimentID,
 			)
@@ -0,0 +1,32 @@
+package run
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type GetRunsLiveTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestGetRunsLiveTestSuite(t *testing.T) {
+	suite.Run(t, new(GetRunsLiveTestSuite))
+}
+
+func (s *GetRunsLiveTestSuite) Test_Error() {
+	var resp api.ErrorResponse
+	s.Require().Nil(
+		s.AIMClient().WithQuery(map[any]any{
+			"run_id": "id",
+		}).WithResponse(
+			&resp,
+		).DoRequest("/runs/live/"),
+	)
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	s.Equal("live updates are disabled", resp.Message)
+}
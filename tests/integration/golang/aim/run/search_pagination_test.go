@@ -0,0 +1,107 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/aim/encoding"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type SearchPaginationTestSuite struct {
+	helpers.BaseTestSuite
+	experiment *models.Experiment
+}
+
+func TestSearchPaginationTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchPaginationTestSuite))
+}
+
+func (s *SearchPaginationTestSuite) SetupTest() {
+	s.BaseTestSuite.SetupTest()
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           uuid.New().String(),
+		LifecycleStage: models.LifecycleStageActive,
+		NamespaceID:    s.DefaultNamespace.ID,
+	})
+	s.Require().Nil(err)
+	s.experiment = experiment
+
+	// id2 and id3 have the same row number, so they are ordered by id.
+	for n, rowNum := range []models.RowNum{1, 2, 2} {
+		_, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             fmt.Sprintf("id%d", n+1),
+			Name:           fmt.Sprintf("TestRun%d", n+1),
+			Status:         models.StatusRunning,
+			RowNum:         rowNum,
+			SourceType:     "JOB",
+			ExperimentID:   *experiment.ID,
+			LifecycleStage: models.LifecycleStageActive,
+		})
+		s.Require().Nil(err)
+	}
+}
+
+func (s *SearchPaginationTestSuite) Test_Ok() {
+	var runIDs []string
+	offset := ""
+	for {
+		resp := new(bytes.Buffer)
+		s.Require().Nil(
+			s.AIMClient().WithResponseType(
+				helpers.ResponseTypeBuffer,
+			).WithQuery(
+				request.SearchRunsRequest{
+					Limit:           1,
+					Offset:          offset,
+					ExperimentNames: []string{s.experiment.Name},
+				},
+			).WithResponse(
+				resp,
+			).DoRequest("/runs/search/run"),
+		)
+
+		decodedData, err := encoding.NewDecoder(resp).Decode()
+		s.Require().Nil(err)
+
+		var pageRunIDs []string
+		for key := range decodedData {
+			if runID, found := strings.CutSuffix(key, ".props.name"); found {
+				pageRunIDs = append(pageRunIDs, runID)
+			}
+		}
+		if len(pageRunIDs) == 0 {
+			break
+		}
+		s.Require().Len(pageRunIDs, 1)
+		runIDs = append(runIDs, pageRunIDs[0])
+		offset = pageRunIDs[0]
+	}
+	s.Equal([]string{"id3", "id2", "id1"}, runIDs)
+}
+
+func (s *SearchPaginationTestSuite) Test_Error() {
+	var resp api.ErrorResponse
+	s.Require().Nil(
+		s.AIMClient().WithQuery(
+			request.SearchRunsRequest{
+				Offset:          "not-found-id",
+				ExperimentNames: []string{s.experiment.Name},
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest("/runs/search/run"),
+	)
+	s.Equal("run 'not-found-id' not found", resp.Message)
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+}
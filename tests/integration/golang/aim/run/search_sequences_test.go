@@ -0,0 +1,175 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/aim/encoding"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type SearchSequencesTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSearchSequencesTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchSequencesTestSuite))
+}
+
+func (s *SearchSequencesTestSuite) createArtifacts(runID string) {
+	for _, artifact := range []models.Artifact{
+		{Name: "prompts", Step: 0, Type: models.ArtifactTypeTexts, Data: []byte(`{"text": "first"}`)},
+		{Name: "prompts", Step: 1, Type: models.ArtifactTypeTexts, Data: []byte(`{"text": "second"}`)},
+		{Name: "prompts", Step: 1, Index: 1, Type: models.ArtifactTypeTexts, Data: []byte(`{"text": "third"}`)},
+		// image with the same name should not be returned for texts.
+		{Name: "prompts", Step: 0, Type: models.ArtifactTypeImages, BlobURI: "path/filename.png"},
+		{
+			Name: "weights",
+			Type: models.ArtifactTypeDistributions,
+			Data: []byte(`{"weights": [1, 5, 2], "bin_range": [-1.5, 1.5]}`),
+		},
+		{
+			Name: "chart",
+			Type: models.ArtifactTypeFigures,
+			Data: []byte(`{"data": [{"type": "bar"}], "layout": {"title": "my chart"}}`),
+		},
+	} {
+		artifact.ID = uuid.New()
+		artifact.RunID = runID
+		_, err := s.ArtifactFixtures.CreateArtifact(context.Background(), &artifact)
+		s.Require().Nil(err)
+	}
+}
+
+func (s *SearchSequencesTestSuite) Test_Ok() {
+	run, err := s.RunFixtures.CreateExampleRun(context.Background(), s.DefaultExperiment)
+	s.Require().Nil(err)
+	s.createArtifacts(run.ID)
+
+	tests := []struct {
+		name     string
+		sequence string
+		request  request.SearchArtifactsRequest
+		expected map[string]any
+	}{
+		{
+			name:     "SearchTexts",
+			sequence: "texts",
+			request: request.SearchArtifactsRequest{
+				Query: `texts.name == "prompts"`,
+			},
+			expected: map[string]any{
+				"params.texts_per_step":    int64(1),
+				"traces.0.name":            "prompts",
+				"traces.0.values.0.0.data": "first",
+				"traces.0.values.1.0.data": "second",
+				"traces.0.values.1.1.data": "third",
+			},
+		},
+		{
+			name:     "SearchDistributions",
+			sequence: "distributions",
+			request: request.SearchArtifactsRequest{
+				Query: `distributions.name == "weights"`,
+			},
+			expected: map[string]any{
+				"traces.0.name":                 "weights",
+				"traces.0.values.0.0.data.blob": []float64{1, 5, 2},
+				"traces.0.values.0.0.bin_count": int64(3),
+				"traces.0.values.0.0.range.0":   -1.5,
+				"traces.0.values.0.0.range.1":   1.5,
+			},
+		},
+		{
+			name:     "SearchFigures",
+			sequence: "figures",
+			request: request.SearchArtifactsRequest{
+				Query: `figures.name == "chart"`,
+			},
+			expected: map[string]any{
+				"traces.0.name":                         "chart",
+				"traces.0.values.0.0.data.layout.title": "my chart",
+				"traces.0.values.0.0.data.data.0.type":  "bar",
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := new(bytes.Buffer)
+			s.Require().Nil(
+				s.AIMClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponseType(
+					helpers.ResponseTypeBuffer,
+				).WithResponse(
+					resp,
+				).DoRequest("/runs/search/%s", tt.sequence),
+			)
+
+			decodedData, err := encoding.NewDecoder(resp).Decode()
+			s.Require().Nil(err)
+			for key, value := range tt.expected {
+				s.Equal(value, decodedData[fmt.Sprintf("%s.%s", run.ID, key)], key)
+			}
+			s.NotContains(decodedData, fmt.Sprintf("%s.traces.1.name", run.ID))
+		})
+	}
+}
+
+func (s *SearchSequencesTestSuite) Test_Error() {
+	var resp api.ErrorResponse
+	s.Require().Nil(
+		s.AIMClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.SearchArtifactsRequest{},
+		).WithResponse(
+			&resp,
+		).DoRequest("/runs/search/videos"),
+	)
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	s.Equal(
+		`"videos" is not a valid Sequence, supported sequences are: [images texts audios distributions figures]`,
+		resp.Message,
+	)
+}
+
+func (s *SearchSequencesTestSuite) TestGetRunSequences_Ok() {
+	run, err := s.RunFixtures.CreateExampleRun(context.Background(), s.DefaultExperiment)
+	s.Require().Nil(err)
+	s.createArtifacts(run.ID)
+
+	resp := new(bytes.Buffer)
+	s.Require().Nil(
+		s.AIMClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			[]map[string]any{{"name": "prompts", "context": map[string]any{}}},
+		).WithResponseType(
+			helpers.ResponseTypeBuffer,
+		).WithResponse(
+			resp,
+		).DoRequest("/runs/%s/texts/get-batch", run.ID),
+	)
+
+	decodedData, err := encoding.NewDecoder(resp).Decode()
+	s.Require().Nil(err)
+	s.Equal("prompts", decodedData["name"])
+	s.Equal("first", decodedData["values.0.0.data"])
+	s.Equal("second", decodedData["values.1.0.data"])
+	s.Equal("third", decodedData["values.1.1.data"])
+	s.Equal(int64(1), decodedData["iters.1"])
+	s.Equal(int64(2), decodedData["record_range.1"])
+	s.Equal(int64(2), decodedData["index_range.1"])
+}
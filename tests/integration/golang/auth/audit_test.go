@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/zeebo/assert"
+	"gopkg.in/yaml.v3"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/config/auth"
+	commonModels "github.com/G-Research/fasttrackml/pkg/common/dao/models"
+	adminRequest "github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type AuditTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestAuditTestSuite(t *testing.T) {
+	// create users configuration firstly.
+	data, err := yaml.Marshal(auth.YamlConfig{
+		Users: []auth.YamlUserConfig{
+			{
+				Name: "user1",
+				Roles: []string{
+					"ns:namespace1",
+				},
+				Password: "user1password",
+			},
+			{
+				Name: "admin",
+				Roles: []string{
+					"admin",
+				},
+				Password: "adminpassword",
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	configPath := fmt.Sprintf("%s/users-config.yaml", t.TempDir())
+	assert.Nil(t, os.WriteFile(configPath, data, 0o600))
+
+	// run test suite with newly created configuration.
+	testSuite := new(AuditTestSuite)
+	testSuite.Config = config.Config{
+		Auth: auth.Config{
+			AuthUsersConfig: configPath,
+		},
+		AuditLogEnabled: true,
+	}
+	assert.Nil(t, testSuite.Config.Validate())
+	suite.Run(t, testSuite)
+}
+
+func (s *AuditTestSuite) Test_Ok() {
+	namespace1, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  2,
+		Code:                "namespace1",
+		Description:         "Test namespace 1",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+
+	_, writeTokenValue, err := s.APITokenFixtures.CreateAPIToken(
+		context.Background(), "write", []string{"ns:namespace1:write"}, nil,
+	)
+	s.Require().Nil(err)
+
+	user1Authorization := fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte("user1:user1password")))
+	adminAuthorization := fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte("admin:adminpassword")))
+
+	// user creates experiment, reads are not recorded.
+	for _, route := range []string{mlflow.ExperimentsCreateRoute, mlflow.ExperimentsSearchRoute} {
+		client := s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.CreateExperimentRequest{Name: "user-experiment"},
+		).WithNamespace(
+			namespace1.Code,
+		).WithHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": user1Authorization,
+		})
+		s.Require().Nil(client.DoRequest("%s%s", mlflow.ExperimentsRoutePrefix, route))
+		s.Equal(http.StatusOK, client.GetStatusCode())
+	}
+
+	// token creates experiment with the name which is already in use.
+	client := s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.CreateExperimentRequest{Name: "user-experiment"},
+	).WithNamespace(
+		namespace1.Code,
+	).WithHeaders(map[string]string{
+		"Content-Type":  "application/json",
+		"Authorization": "Bearer " + writeTokenValue,
+	})
+	s.Require().Nil(client.DoRequest("%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsCreateRoute))
+	s.Equal(http.StatusBadRequest, client.GetStatusCode())
+
+	// admin creates API token.
+	client = s.AdminClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		adminRequest.Token{Name: "training", Roles: "ns:namespace1"},
+	).WithHeaders(map[string]string{
+		"Content-Type":  "application/json",
+		"Authorization": adminAuthorization,
+	})
+	s.Require().Nil(client.DoRequest("/tokens"))
+	s.Equal(http.StatusOK, client.GetStatusCode())
+
+	// events are written in background.
+	var events []commonModels.AuditEvent
+	s.Require().Eventually(func() bool {
+		events, err = s.AuditEventFixtures.GetAuditEvents(context.Background())
+		s.Require().Nil(err)
+		return len(events) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// events are listed from the latest ones.
+	s.Equal("admin", events[0].Actor)
+	s.Equal("admin.tokens.create", events[0].Action)
+	s.Equal("", events[0].NamespaceCode)
+	s.Equal([]string{"training"}, events[0].GetTargetIDs())
+	s.Equal(http.StatusOK, events[0].StatusCode)
+
+	s.Equal("token:write", events[1].Actor)
+	s.Equal("mlflow.experiments.create", events[1].Action)
+	s.Equal(namespace1.Code, events[1].NamespaceCode)
+	s.Equal(http.StatusBadRequest, events[1].StatusCode)
+
+	s.Equal("user1", events[2].Actor)
+	s.Equal("mlflow.experiments.create", events[2].Action)
+	s.Equal(namespace1.Code, events[2].NamespaceCode)
+	s.Equal(http.MethodPost, events[2].Method)
+	s.Equal("/api/2.0/mlflow/experiments/create", events[2].Path)
+	s.Equal([]string{"user-experiment"}, events[2].GetTargetIDs())
+	s.Contains(events[2].Request, `"name":"user-experiment"`)
+	s.Equal(http.StatusOK, events[2].StatusCode)
+}
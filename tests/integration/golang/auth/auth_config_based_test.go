@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"net/http"
 	"os"
 	"testing"
 
@@ -13,6 +14,7 @@ import (
 
 	aimResponse "github.com/G-Research/fasttrackml/pkg/api/aim/api/response"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
 	mlflowResponse "github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/common"
@@ -53,6 +55,14 @@ func TestUserAuthFromConfigTestSuite(t *testing.T) {
 				},
 				Password: "user3password",
 			},
+			{
+				Name: "user4",
+				Roles: []string{
+					"ns:namespace1:read",
+					"ns:namespace2:write",
+				},
+				Password: "user4password",
+			},
 		},
 	})
 	assert.Nil(t, err)
@@ -430,3 +440,131 @@ func (s *ConfigAuthTestSuite) TestMlflowAuth_Ok() {
 		})
 	}
 }
+
+func (s *ConfigAuthTestSuite) TestNamespacePermissions_Ok() {
+	// create test namespaces.
+	namespace1, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  2,
+		Code:                "namespace1",
+		Description:         "Test namespace 1",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+	namespace2, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  3,
+		Code:                "namespace2",
+		Description:         "Test namespace 2",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+
+	user4Headers := map[string]string{
+		"Content-Type": "application/json",
+		"Authorization": fmt.Sprintf(
+			"Basic %s", base64.StdEncoding.EncodeToString([]byte("user4:user4password")),
+		),
+	}
+
+	// check that user4 can browse namespace1 with `read` permission, search requests included.
+	searchResponse := mlflowResponse.SearchExperimentsResponse{}
+	client := s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.SearchExperimentsRequest{},
+	).WithResponse(
+		&searchResponse,
+	).WithNamespace(
+		namespace1.Code,
+	).WithHeaders(user4Headers)
+	s.Require().Nil(client.DoRequest("%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsSearchRoute))
+	s.Equal(http.StatusOK, client.GetStatusCode())
+
+	projectResponse := aimResponse.GetProjectResponse{}
+	client = s.AIMClient().WithResponse(
+		&projectResponse,
+	).WithNamespace(
+		namespace1.Code,
+	).WithHeaders(user4Headers)
+	s.Require().Nil(client.DoRequest("/projects"))
+	s.Equal(http.StatusOK, client.GetStatusCode())
+
+	// check that user4 can't create experiment in namespace1.
+	errorResponse := api.ErrorResponse{}
+	client = s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.CreateExperimentRequest{Name: "experiment"},
+	).WithResponse(
+		&errorResponse,
+	).WithNamespace(
+		namespace1.Code,
+	).WithHeaders(user4Headers)
+	s.Require().Nil(client.DoRequest("%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsCreateRoute))
+	s.Equal(http.StatusForbidden, client.GetStatusCode())
+	s.Equal(
+		"PERMISSION_DENIED: 'write' permission to namespace with code: namespace1 is required", errorResponse.Error(),
+	)
+
+	// check that user4 can create experiment in namespace2 with `write` permission, but can't delete it.
+	createResponse := mlflowResponse.CreateExperimentResponse{}
+	client = s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.CreateExperimentRequest{Name: "experiment"},
+	).WithResponse(
+		&createResponse,
+	).WithNamespace(
+		namespace2.Code,
+	).WithHeaders(user4Headers)
+	s.Require().Nil(client.DoRequest("%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsCreateRoute))
+	s.Equal(http.StatusOK, client.GetStatusCode())
+	s.NotEmpty(createResponse.ID)
+
+	errorResponse = api.ErrorResponse{}
+	client = s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.DeleteExperimentRequest{ID: createResponse.ID},
+	).WithResponse(
+		&errorResponse,
+	).WithNamespace(
+		namespace2.Code,
+	).WithHeaders(user4Headers)
+	s.Require().Nil(client.DoRequest("%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsDeleteRoute))
+	s.Equal(http.StatusForbidden, client.GetStatusCode())
+	s.Equal(
+		"PERMISSION_DENIED: 'owner' permission to namespace with code: namespace2 is required", errorResponse.Error(),
+	)
+
+	errorResponse = api.ErrorResponse{}
+	client = s.AIMClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		[]string{"id"},
+	).WithResponse(
+		&errorResponse,
+	).WithNamespace(
+		namespace2.Code,
+	).WithHeaders(user4Headers)
+	s.Require().Nil(client.DoRequest("/runs/delete-batch/"))
+	s.Equal(http.StatusForbidden, client.GetStatusCode())
+	s.Equal(
+		"PERMISSION_DENIED: 'owner' permission to namespace with code: namespace2 is required", errorResponse.Error(),
+	)
+
+	// check that user2 with `ns:namespace2` role is the owner of namespace2 and can delete experiment.
+	client = s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.DeleteExperimentRequest{ID: createResponse.ID},
+	).WithNamespace(
+		namespace2.Code,
+	).WithHeaders(map[string]string{
+		"Content-Type": "application/json",
+		"Authorization": fmt.Sprintf(
+			"Basic %s", base64.StdEncoding.EncodeToString([]byte("user2:user2password")),
+		),
+	})
+	s.Require().Nil(client.DoRequest("%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsDeleteRoute))
+	s.Equal(http.StatusOK, client.GetStatusCode())
+}
@@ -13,12 +13,14 @@ import (
 
 	aimResponse "github.com/G-Research/fasttrackml/pkg/api/aim/api/response"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
 	mlflowResponse "github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/common"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/pkg/common/config"
 	"github.com/G-Research/fasttrackml/pkg/common/config/auth"
+	commonModels "github.com/G-Research/fasttrackml/pkg/common/dao/models"
 	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
 	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers/oidc"
 )
@@ -612,3 +614,99 @@ func (s *OIDCAuthTestSuite) TestChooserAuth_Ok() {
 		})
 	}
 }
+
+func (s *OIDCAuthTestSuite) TestNamespacePermissions_Ok() {
+	s.SetupTestSuite()
+
+	// group3 has `read` permission to namespace1 and `write` permission to namespace2.
+	group3Role := models.Role{Name: "group3"}
+	s.Nil(s.RolesFixtures.CreateRole(context.Background(), &group3Role))
+	s.Nil(s.RolesFixtures.AttachNamespaceToRoleWithPermission(
+		context.Background(), &group3Role, s.namespace1, commonModels.PermissionRead,
+	))
+	s.Nil(s.RolesFixtures.AttachNamespaceToRoleWithPermission(
+		context.Background(), &group3Role, s.namespace2, commonModels.PermissionWrite,
+	))
+	user5Token, err := s.oidcMockServer.Login(
+		context.Background(),
+		&mockoidc.MockUser{
+			Email:  "test.user@example.com",
+			Groups: []string{"group3"},
+		}, []string{"openid", "groups"},
+	)
+	s.Require().Nil(err)
+
+	// check that user5 can browse namespace1, but can't create experiment there.
+	successResponse := aimResponse.GetProjectResponse{}
+	s.Require().Nil(
+		s.AIMClient().WithResponse(
+			&successResponse,
+		).WithNamespace(
+			s.namespace1.Code,
+		).WithCookie(
+			"access_token", user5Token,
+		).DoRequest("/projects"),
+	)
+	s.Equal("FastTrackML", successResponse.Name)
+
+	errorResponse := api.ErrorResponse{}
+	client := s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.CreateExperimentRequest{Name: "experiment"},
+	).WithResponse(
+		&errorResponse,
+	).WithNamespace(
+		s.namespace1.Code,
+	).WithCookie(
+		"access_token", user5Token,
+	)
+	s.Require().Nil(client.DoRequest("%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsCreateRoute))
+	s.Equal(http.StatusForbidden, client.GetStatusCode())
+	s.Equal(
+		"PERMISSION_DENIED: 'write' permission to namespace with code: namespace1 is required", errorResponse.Error(),
+	)
+
+	// check that user5 can create experiment in namespace2, but can't delete it.
+	createResponse := mlflowResponse.CreateExperimentResponse{}
+	client = s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.CreateExperimentRequest{Name: "experiment"},
+	).WithResponse(
+		&createResponse,
+	).WithNamespace(
+		s.namespace2.Code,
+	).WithCookie(
+		"access_token", user5Token,
+	)
+	s.Require().Nil(client.DoRequest("%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsCreateRoute))
+	s.Equal(http.StatusOK, client.GetStatusCode())
+
+	errorResponse = api.ErrorResponse{}
+	client = s.AIMClient().WithMethod(
+		http.MethodDelete,
+	).WithResponse(
+		&errorResponse,
+	).WithNamespace(
+		s.namespace2.Code,
+	).WithCookie(
+		"access_token", user5Token,
+	)
+	s.Require().Nil(client.DoRequest("/experiments/%s/", createResponse.ID))
+	s.Equal(http.StatusForbidden, client.GetStatusCode())
+	s.Equal(
+		"PERMISSION_DENIED: 'owner' permission to namespace with code: namespace2 is required", errorResponse.Error(),
+	)
+
+	// check that user2 with default `owner` permission can delete experiment in namespace2.
+	client = s.AIMClient().WithMethod(
+		http.MethodDelete,
+	).WithNamespace(
+		s.namespace2.Code,
+	).WithCookie(
+		"access_token", s.user2Token,
+	)
+	s.Require().Nil(client.DoRequest("/experiments/%s/", createResponse.ID))
+	s.Equal(http.StatusOK, client.GetStatusCode())
+}
@@ -0,0 +1,238 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/zeebo/assert"
+	"gopkg.in/yaml.v3"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	mlflowResponse "github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/config/auth"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type TokenAuthTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestTokenAuthTestSuite(t *testing.T) {
+	// create users configuration firstly.
+	data, err := yaml.Marshal(auth.YamlConfig{
+		Users: []auth.YamlUserConfig{
+			{
+				Name: "user1",
+				Roles: []string{
+					"ns:namespace1",
+				},
+				Password: "user1password",
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	configPath := fmt.Sprintf("%s/users-config.yaml", t.TempDir())
+	assert.Nil(t, os.WriteFile(configPath, data, 0o600))
+
+	// run test suite with newly created configuration.
+	testSuite := new(TokenAuthTestSuite)
+	testSuite.Config = config.Config{
+		Auth: auth.Config{
+			AuthUsersConfig: configPath,
+		},
+	}
+	assert.Nil(t, testSuite.Config.Validate())
+	suite.Run(t, testSuite)
+}
+
+func (s *TokenAuthTestSuite) TestTokenAuth_Ok() {
+	namespace1, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  2,
+		Code:                "namespace1",
+		Description:         "Test namespace 1",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+	namespace2, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  3,
+		Code:                "namespace2",
+		Description:         "Test namespace 2",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+
+	readToken, readTokenValue, err := s.APITokenFixtures.CreateAPIToken(
+		context.Background(), "read", []string{"ns:namespace1:read"}, nil,
+	)
+	s.Require().Nil(err)
+	_, writeTokenValue, err := s.APITokenFixtures.CreateAPIToken(
+		context.Background(), "write", []string{"ns:namespace1:write"}, nil,
+	)
+	s.Require().Nil(err)
+	_, adminTokenValue, err := s.APITokenFixtures.CreateAPIToken(
+		context.Background(), "admin", []string{"admin"}, nil,
+	)
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name      string
+		token     string
+		namespace string
+		route     string
+		request   any
+		status    int
+		error     string
+	}{
+		{
+			name:      "ReadTokenSearchesExperiments",
+			token:     readTokenValue,
+			namespace: namespace1.Code,
+			route:     mlflow.ExperimentsSearchRoute,
+			request:   request.SearchExperimentsRequest{},
+			status:    http.StatusOK,
+		},
+		{
+			name:      "ReadTokenCreatesExperiment",
+			token:     readTokenValue,
+			namespace: namespace1.Code,
+			route:     mlflow.ExperimentsCreateRoute,
+			request:   request.CreateExperimentRequest{Name: "read-experiment"},
+			status:    http.StatusForbidden,
+			error:     "PERMISSION_DENIED: 'write' permission to namespace with code: namespace1 is required",
+		},
+		{
+			name:      "WriteTokenCreatesExperiment",
+			token:     writeTokenValue,
+			namespace: namespace1.Code,
+			route:     mlflow.ExperimentsCreateRoute,
+			request:   request.CreateExperimentRequest{Name: "write-experiment"},
+			status:    http.StatusOK,
+		},
+		{
+			name:      "WriteTokenSearchesOtherNamespace",
+			token:     writeTokenValue,
+			namespace: namespace2.Code,
+			route:     mlflow.ExperimentsSearchRoute,
+			request:   request.SearchExperimentsRequest{},
+			status:    http.StatusNotFound,
+			error:     "RESOURCE_DOES_NOT_EXIST: unable to find namespace with code: namespace2",
+		},
+		{
+			name:      "AdminTokenCreatesExperimentInAnyNamespace",
+			token:     adminTokenValue,
+			namespace: namespace2.Code,
+			route:     mlflow.ExperimentsCreateRoute,
+			request:   request.CreateExperimentRequest{Name: "admin-experiment"},
+			status:    http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp api.ErrorResponse
+			client := s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				tt.request,
+			).WithResponse(
+				&resp,
+			).WithNamespace(
+				tt.namespace,
+			).WithHeaders(map[string]string{
+				"Content-Type":  "application/json",
+				"Authorization": "Bearer " + tt.token,
+			})
+			s.Require().Nil(client.DoRequest("%s%s", mlflow.ExperimentsRoutePrefix, tt.route))
+			s.Equal(tt.status, client.GetStatusCode())
+			if tt.error != "" {
+				s.Equal(tt.error, resp.Error())
+			}
+		})
+	}
+
+	// usage of the token is recorded.
+	readToken, err = s.APITokenFixtures.GetAPIToken(context.Background(), readToken)
+	s.Require().Nil(err)
+	s.Require().NotNil(readToken.LastUsedAt)
+	s.WithinDuration(time.Now(), *readToken.LastUsedAt, time.Minute)
+
+	// basic auth keeps working along with tokens.
+	searchResponse := mlflowResponse.SearchExperimentsResponse{}
+	client := s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.SearchExperimentsRequest{},
+	).WithResponse(
+		&searchResponse,
+	).WithNamespace(
+		namespace1.Code,
+	).WithHeaders(map[string]string{
+		"Content-Type": "application/json",
+		"Authorization": fmt.Sprintf(
+			"Basic %s", base64.StdEncoding.EncodeToString([]byte("user1:user1password")),
+		),
+	})
+	s.Require().Nil(client.DoRequest("%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsSearchRoute))
+	s.Equal(http.StatusOK, client.GetStatusCode())
+	s.Len(searchResponse.Experiments, 1)
+}
+
+func (s *TokenAuthTestSuite) TestTokenAuth_Error() {
+	expiresAt := time.Now().Add(-time.Hour)
+	_, expiredTokenValue, err := s.APITokenFixtures.CreateAPIToken(
+		context.Background(), "expired", []string{"admin"}, &expiresAt,
+	)
+	s.Require().Nil(err)
+	revokedToken, revokedTokenValue, err := s.APITokenFixtures.CreateAPIToken(
+		context.Background(), "revoked", []string{"admin"}, nil,
+	)
+	s.Require().Nil(err)
+	s.Require().Nil(s.APITokenFixtures.RevokeAPIToken(context.Background(), revokedToken))
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{
+			name:  "UnknownToken",
+			token: "fml_unknown",
+		},
+		{
+			name:  "ExpiredToken",
+			token: expiredTokenValue,
+		},
+		{
+			name:  "RevokedToken",
+			token: revokedTokenValue,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp api.ErrorResponse
+			client := s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				request.SearchExperimentsRequest{},
+			).WithResponse(
+				&resp,
+			).WithHeaders(map[string]string{
+				"Content-Type":  "application/json",
+				"Authorization": "Bearer " + tt.token,
+			})
+			s.Require().Nil(client.DoRequest("%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsSearchRoute))
+			s.Equal(http.StatusUnauthorized, client.GetStatusCode())
+			s.Equal("UNAUTHENTICATED: api token is invalid, expired or revoked", resp.Error())
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package database
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/database"
+)
+
+// aimRepoFixture is a small Aim repository with two runs. Run containers contain only write-ahead logs,
+// like the containers of Aim runs which were not compacted yet.
+const aimRepoFixture = "testdata/aim-repo"
+
+func (s *ImportTestSuite) TestAimRepoImport_Ok() {
+	for _, outputBackend := range []string{"sqlite", "sqlcipher", "postgres"} {
+		s.inputBackend = "sqlite"
+		s.outputBackend = outputBackend
+		s.Run(outputBackend, func() {
+			artifactStorageFactory, err := storage.NewArtifactStorageFactory(&config.Config{})
+			s.Require().Nil(err)
+			artifactRoot := s.T().TempDir()
+
+			importer := database.NewAimRepoImporter(
+				s.outputDB, aimRepoFixture, artifactRoot, database.WithAimRepoArtifacts(artifactStorageFactory),
+			)
+			s.Require().Nil(importer.Import(context.Background()))
+
+			expectedCounts := rowCounts{
+				namespaces:               1,
+				experiments:              2,
+				runs:                     2,
+				distinctRunExperimentIDs: 2,
+				metrics:                  7,
+				latestMetrics:            3,
+				tags:                     1,
+				params:                   6,
+				sharedTags:               1,
+				runSharedTags:            1,
+			}
+			s.validateRowCounts(s.outputDB, expectedCounts)
+
+			// finished run of the regular experiment.
+			var run database.Run
+			s.Require().Nil(s.outputDB.Where("run_uuid = ?", "1a2b3c4d5e6f7a8b9c0d1e2f").First(&run).Error)
+			s.Equal("aim-run-1", run.Name)
+			s.Equal(database.StatusFinished, run.Status)
+			s.Equal(database.LifecycleStageActive, run.LifecycleStage)
+			s.Equal(int64(1700000000000), run.StartTime.Int64)
+			s.Equal(int64(1700000100500), run.EndTime.Int64)
+			var experiment database.Experiment
+			s.Require().Nil(s.outputDB.First(&experiment, run.ExperimentID).Error)
+			s.Equal("my-experiment", experiment.Name)
+
+			var params []database.Param
+			s.Require().Nil(s.outputDB.Where(
+				"run_uuid = ?", run.ID,
+			).Order("key").Find(&params).Error)
+			s.Require().Len(params, 5)
+			s.Equal("hparams.augment", params[0].Key)
+			s.Equal("true", *params[0].ValueStr)
+			s.Equal("hparams.batch_size", params[1].Key)
+			s.Equal(int64(32), *params[1].ValueInt)
+			s.Equal("hparams.layers", params[2].Key)
+			s.Equal("[64,32]", *params[2].ValueStr)
+			// earlier value was removed by the range deletion.
+			s.Equal("hparams.lr", params[3].Key)
+			s.Equal(0.01, *params[3].ValueFloat)
+			s.Equal("hparams.optimizer", params[4].Key)
+			s.Equal("adam", *params[4].ValueStr)
+
+			var tag database.Tag
+			s.Require().Nil(s.outputDB.Where("run_uuid = ?", run.ID).First(&tag).Error)
+			s.Equal("mlflow.note.content", tag.Key)
+			s.Equal("first Aim run", tag.Value)
+
+			var sharedTag database.SharedTag
+			s.Require().Nil(s.outputDB.Where("name = ?", "best").First(&sharedTag).Error)
+			s.Equal("#ff0000", sharedTag.Color)
+			s.Equal("best run", sharedTag.Description)
+
+			// metrics keep their steps, timestamps and contexts.
+			var latestMetric database.LatestMetric
+			s.Require().Nil(s.outputDB.Preload("Context").Where(
+				"run_uuid = ? AND key = ?", run.ID, "loss",
+			).First(&latestMetric).Error)
+			s.True(latestMetric.IsNan)
+			s.Equal(int64(2), latestMetric.Step)
+			s.Equal(int64(1700000002000), latestMetric.Timestamp)
+			s.Equal(int64(3), latestMetric.LastIter)
+			s.JSONEq(`{"subset":"train"}`, string(latestMetric.Context.Json))
+
+			var metrics []database.Metric
+			s.Require().Nil(s.outputDB.Where(
+				"run_uuid = ? AND key = ?", run.ID, "accuracy",
+			).Order("iter").Find(&metrics).Error)
+			s.Require().Len(metrics, 2)
+			s.Equal(75.0, metrics[1].Value)
+			s.Equal(int64(10), metrics[1].Step)
+
+			// images are copied into the run artifact storage.
+			var artifacts []database.Artifact
+			s.Require().Nil(s.outputDB.Where("run_uuid = ?", run.ID).Order(`step, "index"`).Find(&artifacts).Error)
+			s.Require().Len(artifacts, 3)
+			s.Equal("images/0_0.png", artifacts[0].BlobURI)
+			s.Equal("red", artifacts[0].Caption)
+			s.Equal(int64(2), artifacts[0].Width)
+			s.Equal("images/1_1.png", artifacts[2].BlobURI)
+			s.Equal("blue", artifacts[2].Caption)
+			s.Equal(int64(1), artifacts[2].Step)
+			s.Equal(int64(1), artifacts[2].Index)
+			artifactPath, err := url.Parse(run.ArtifactURI)
+			s.Require().Nil(err)
+			data, err := os.ReadFile(filepath.Join(artifactPath.Path, "images", "1_1.png"))
+			s.Require().Nil(err)
+			s.Equal([]byte("\x89PNG"), data[:4])
+
+			// archived run of Aim default experiment is imported into the default experiment.
+			var archivedRun database.Run
+			s.Require().Nil(s.outputDB.Where("run_uuid = ?", "aabbccddeeff001122334455").First(&archivedRun).Error)
+			s.Equal(int32(models.DefaultExperimentID), archivedRun.ExperimentID)
+			s.Equal(database.StatusRunning, archivedRun.Status)
+			s.Equal(database.LifecycleStageDeleted, archivedRun.LifecycleStage)
+			s.Equal(int64(1700000400000), archivedRun.DeletedTime.Int64)
+
+			// import the repository a 2nd time, already imported runs should be skipped.
+			s.Require().Nil(database.NewAimRepoImporter(
+				s.outputDB, filepath.Join(aimRepoFixture, ".aim"), artifactRoot,
+			).Import(context.Background()))
+			s.validateRowCounts(s.outputDB, expectedCounts)
+		})
+	}
+}
+
+func (s *ImportTestSuite) TestAimRepoImport_Error() {
+	s.inputBackend = "sqlite"
+	s.outputBackend = "sqlite"
+	s.Run("EmptyDirectory", func() {
+		root := s.T().TempDir()
+		err := database.NewAimRepoImporter(s.outputDB, root, s.T().TempDir()).Import(context.Background())
+		s.ErrorContains(err, "no Aim repository found in "+root)
+	})
+}
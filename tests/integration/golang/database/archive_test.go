@@ -0,0 +1,241 @@
+package database
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/database"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/fixtures"
+)
+
+func (s *ImportTestSuite) TestArchiveImport_Ok() {
+	backends := []string{"sqlite", "sqlcipher", "postgres"}
+	for _, inputBackend := range backends {
+		for _, outputBackend := range backends {
+			s.inputBackend = inputBackend
+			s.outputBackend = outputBackend
+			s.Run(inputBackend+"->"+outputBackend, func() {
+				// store an artifact of one of the source namespace runs.
+				artifactDir := s.T().TempDir()
+				s.Require().Nil(os.MkdirAll(filepath.Join(artifactDir, "model"), os.ModePerm))
+				s.Require().Nil(os.WriteFile(filepath.Join(artifactDir, "model", "artifact.txt"), []byte("content"), 0o600))
+				s.Require().Nil(s.inputDB.Exec(
+					"UPDATE runs SET artifact_uri = ? WHERE run_uuid = ?", artifactDir, s.runs[0].ID,
+				).Error)
+
+				artifactStorageFactory, err := storage.NewArtifactStorageFactory(&config.Config{})
+				s.Require().Nil(err)
+
+				// export the source namespace.
+				archive := bytes.Buffer{}
+				s.Require().Nil(database.NewExporter(
+					s.inputDB, "source-namespace", database.WithExportArtifacts(artifactStorageFactory),
+				).Export(context.Background(), &archive))
+
+				// import the archive. destination namespace doesn't exist, so it has to be created.
+				artifactRoot := s.T().TempDir()
+				importer := database.NewArchiveImporter(
+					s.outputDB, artifactRoot, database.WithArchiveArtifacts(artifactStorageFactory),
+				)
+				s.Require().Nil(importer.Import(context.Background(), bytes.NewReader(archive.Bytes())))
+
+				expectedCounts := rowCounts{
+					namespaces:               2,
+					experiments:              3,
+					runs:                     5,
+					distinctRunExperimentIDs: 1,
+					metrics:                  20,
+					latestMetrics:            10,
+					tags:                     5,
+					params:                   10,
+					dashboards:               1,
+					apps:                     1,
+					sharedTags:               1,
+					runSharedTags:            1,
+				}
+				s.validateRowCounts(s.outputDB, expectedCounts)
+
+				// imported runs should point to the restored artifacts.
+				var run database.Run
+				s.Require().Nil(s.outputDB.Where("run_uuid = ?", s.runs[0].ID).First(&run).Error)
+				s.True(strings.HasPrefix(run.ArtifactURI, artifactRoot))
+				content, err := os.ReadFile(filepath.Join(run.ArtifactURI, "model", "artifact.txt"))
+				s.Require().Nil(err)
+				s.Equal("content", string(content))
+
+				// import the archive a 2nd time, dest DB should still only have the expected (idempotent).
+				s.Require().Nil(database.NewArchiveImporter(
+					s.outputDB, artifactRoot, database.WithArchiveArtifacts(artifactStorageFactory),
+				).Import(context.Background(), bytes.NewReader(archive.Bytes())))
+				s.validateRowCounts(s.outputDB, expectedCounts)
+
+				// confirm row-for-row equality of the data which keeps the original keys.
+				for _, table := range []string{
+					"tags",
+					"params",
+					"metrics",
+					"latest_metrics",
+				} {
+					s.validateTable(
+						database.EntityLimitedByNamespace(
+							table,
+							s.inputDB.Table(table).Select(table+".*"),
+							&database.Namespace{ID: 2},
+						),
+						s.outputDB,
+						table,
+					)
+				}
+			})
+		}
+	}
+}
+
+func (s *ImportTestSuite) TestArchiveImportIntoExistingNamespace_Ok() {
+	backends := []string{"sqlite", "sqlcipher", "postgres"}
+	for _, inputBackend := range backends {
+		for _, outputBackend := range backends {
+			s.inputBackend = inputBackend
+			s.outputBackend = outputBackend
+			s.Run(inputBackend+"->"+outputBackend, func() {
+				namespaceFixtures, err := fixtures.NewNamespaceFixtures(s.outputDB)
+				s.Require().Nil(err)
+
+				namespace, err := namespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+					Code:                "destination-namespace",
+					DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+				})
+				s.Require().Nil(err)
+
+				// export the default namespace without artifacts.
+				archive := bytes.Buffer{}
+				s.Require().Nil(
+					database.NewExporter(s.inputDB, "default").Export(context.Background(), &archive),
+				)
+
+				// import the archive into existing namespace.
+				s.Require().Nil(database.NewArchiveImporter(
+					s.outputDB, s.T().TempDir(), database.WithArchiveNamespace(namespace.Code),
+				).Import(context.Background(), &archive))
+
+				// the default experiment is merged into the existing one. the shared tag of the default
+				// namespace is associated with the run of another namespace, so the association is skipped.
+				s.validateRowCounts(s.outputDB, rowCounts{
+					namespaces:               2,
+					experiments:              3,
+					runs:                     10,
+					distinctRunExperimentIDs: 2,
+					metrics:                  40,
+					latestMetrics:            20,
+					tags:                     10,
+					params:                   20,
+					dashboards:               2,
+					apps:                     2,
+					sharedTags:               1,
+					runSharedTags:            0,
+				})
+
+				// runs keep the original artifact uri, because artifacts were not exported.
+				var count int64
+				s.Require().Nil(s.outputDB.Model(
+					&database.Run{},
+				).Where(
+					"artifact_uri != ?", "artifact_uri",
+				).Count(&count).Error)
+				s.Equal(int64(0), count)
+			})
+		}
+	}
+}
+
+func (s *ImportTestSuite) TestArchiveImport_Error() {
+	s.inputBackend = "sqlite"
+	s.outputBackend = "sqlite"
+	s.Run("NamespaceNotFound", func() {
+		s.EqualError(
+			database.NewExporter(s.inputDB, "not-existing-namespace").Export(context.Background(), &bytes.Buffer{}),
+			"namespace not-existing-namespace not found: record not found",
+		)
+	})
+	s.Run("NotAnArchive", func() {
+		s.Error(database.NewArchiveImporter(s.outputDB, s.T().TempDir()).Import(
+			context.Background(), strings.NewReader("not an archive"),
+		))
+	})
+	s.Run("ArtifactPathOutsideRun", func() {
+		artifactDir := s.T().TempDir()
+		s.Require().Nil(os.WriteFile(filepath.Join(artifactDir, "artifact.txt"), []byte("content"), 0o600))
+		s.Require().Nil(s.inputDB.Exec(
+			"UPDATE runs SET artifact_uri = ? WHERE run_uuid = ?", artifactDir, s.runs[0].ID,
+		).Error)
+
+		artifactStorageFactory, err := storage.NewArtifactStorageFactory(&config.Config{})
+		s.Require().Nil(err)
+		archive := bytes.Buffer{}
+		s.Require().Nil(database.NewExporter(
+			s.inputDB, "source-namespace", database.WithExportArtifacts(artifactStorageFactory),
+		).Export(context.Background(), &archive))
+
+		// the malicious entry tries to escape the run artifacts of the destination namespace.
+		name := fmt.Sprintf("artifacts/%s/../../../escape.txt", s.runs[0].ID)
+		artifactRoot := filepath.Join(s.T().TempDir(), "root")
+		s.EqualError(
+			database.NewArchiveImporter(
+				s.outputDB, artifactRoot, database.WithArchiveArtifacts(artifactStorageFactory),
+			).Import(context.Background(), bytes.NewReader(s.appendArchiveEntry(archive.Bytes(), name, "escaped"))),
+			fmt.Sprintf(
+				"error importing artifact %s: artifact path ../../../escape.txt is outside of the run artifacts", name,
+			),
+		)
+		_, err = os.Stat(filepath.Join(artifactRoot, "escape.txt"))
+		s.True(os.IsNotExist(err))
+		_, err = os.Stat(filepath.Join(filepath.Dir(artifactRoot), "escape.txt"))
+		s.True(os.IsNotExist(err))
+	})
+}
+
+// appendArchiveEntry returns the copy of the namespace archive with one more entry at the end.
+func (s *ImportTestSuite) appendArchiveEntry(archive []byte, name, content string) []byte {
+	decompressor, err := zstd.NewReader(bytes.NewReader(archive))
+	s.Require().Nil(err)
+	defer decompressor.Close()
+
+	result := bytes.Buffer{}
+	compressor, err := zstd.NewWriter(&result)
+	s.Require().Nil(err)
+	reader, writer := tar.NewReader(decompressor), tar.NewWriter(compressor)
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		s.Require().Nil(err)
+		s.Require().Nil(writer.WriteHeader(header))
+		_, err = io.Copy(writer, reader)
+		s.Require().Nil(err)
+	}
+	s.Require().Nil(writer.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     int64(len(content)),
+		Mode:     0o644,
+	}))
+	_, err = writer.Write([]byte(content))
+	s.Require().Nil(err)
+	s.Require().Nil(writer.Close())
+	s.Require().Nil(compressor.Close())
+	return result.Bytes()
+}
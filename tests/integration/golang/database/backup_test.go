@@ -0,0 +1,160 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/database"
+)
+
+func (s *ImportTestSuite) TestBackupRestore_Ok() {
+	for _, backend := range []string{"sqlite", "sqlcipher", "postgres"} {
+		s.inputBackend = backend
+		s.outputBackend = backend
+		s.Run(backend, func() {
+			// store an artifact of one of the runs.
+			artifactDir := filepath.Join(s.T().TempDir(), "artifacts")
+			s.Require().Nil(os.MkdirAll(filepath.Join(artifactDir, "model"), os.ModePerm))
+			s.Require().Nil(os.WriteFile(filepath.Join(artifactDir, "model", "artifact.txt"), []byte("content"), 0o600))
+			s.Require().Nil(s.inputDB.Exec(
+				"UPDATE runs SET artifact_uri = ? WHERE run_uuid = ?", artifactDir, s.runs[0].ID,
+			).Error)
+
+			artifactStorage, err := storage.NewLocal(&config.Config{})
+			s.Require().Nil(err)
+
+			// backup the whole source database.
+			backup := bytes.Buffer{}
+			s.Require().Nil(database.NewBackuper(
+				s.inputDBProvider, database.WithBackupArtifacts(artifactStorage),
+			).Backup(context.Background(), &backup))
+			s.Require().Nil(os.RemoveAll(artifactDir))
+
+			// destination database isn't empty, so it has to be overwritten explicitly.
+			s.EqualError(
+				database.NewRestorer(s.outputDBProvider).Restore(context.Background(), bytes.NewReader(backup.Bytes())),
+				"database is not empty, it has to be overwritten explicitly",
+			)
+			s.Require().Nil(database.NewRestorer(
+				s.outputDBProvider, database.WithRestoreOverwrite(), database.WithRestoreArtifacts(artifactStorage),
+			).Restore(context.Background(), bytes.NewReader(backup.Bytes())))
+
+			s.validateRowCounts(s.outputDB, rowCounts{
+				namespaces:               2,
+				experiments:              4,
+				runs:                     15,
+				distinctRunExperimentIDs: 3,
+				metrics:                  60,
+				latestMetrics:            30,
+				tags:                     15,
+				params:                   30,
+				dashboards:               3,
+				apps:                     3,
+				sharedTags:               2,
+				runSharedTags:            2,
+			})
+			for _, table := range []string{
+				"namespaces",
+				"experiments",
+				"apps",
+				"dashboards",
+				"experiment_tags",
+				"runs",
+				"tags",
+				"params",
+				"contexts",
+				"metrics",
+				"latest_metrics",
+				"shared_tags",
+				"run_shared_tags",
+			} {
+				s.validateTable(s.inputDB, s.outputDB, table)
+			}
+
+			// artifacts are restored into the original location.
+			content, err := os.ReadFile(filepath.Join(artifactDir, "model", "artifact.txt"))
+			s.Require().Nil(err)
+			s.Equal("content", string(content))
+
+			// restored database is usable, new rows don't conflict with the restored ones.
+			_, err = s.outputRunFixtures.CreateExampleRuns(context.Background(), &s.runs[0].Experiment, 1)
+			s.Require().Nil(err)
+		})
+	}
+}
+
+func (s *ImportTestSuite) TestBackupRestore_Error() {
+	s.inputBackend = "sqlite"
+	s.outputBackend = "sqlcipher"
+	s.Run("EncryptedDatabase", func() {
+		backup := bytes.Buffer{}
+		s.Require().Nil(database.NewBackuper(s.inputDBProvider).Backup(context.Background(), &backup))
+		s.EqualError(
+			database.NewRestorer(
+				s.outputDBProvider, database.WithRestoreOverwrite(),
+			).Restore(context.Background(), bytes.NewReader(backup.Bytes())),
+			"error restoring database: encrypted backup has to be restored into encrypted database "+
+				"with the same key and vice versa",
+		)
+	})
+
+	s.outputBackend = "sqlite"
+	s.Run("ArtifactPathOutsideRun", func() {
+		artifactDir := filepath.Join(s.T().TempDir(), "artifacts")
+		s.Require().Nil(os.MkdirAll(artifactDir, os.ModePerm))
+		s.Require().Nil(os.WriteFile(filepath.Join(artifactDir, "artifact.txt"), []byte("content"), 0o600))
+		s.Require().Nil(s.inputDB.Exec(
+			"UPDATE runs SET artifact_uri = ? WHERE run_uuid = ?", artifactDir, s.runs[0].ID,
+		).Error)
+
+		artifactStorage, err := storage.NewLocal(&config.Config{})
+		s.Require().Nil(err)
+		backup := bytes.Buffer{}
+		s.Require().Nil(database.NewBackuper(
+			s.inputDBProvider, database.WithBackupArtifacts(artifactStorage),
+		).Backup(context.Background(), &backup))
+
+		// the malicious entry tries to escape the run artifacts.
+		name := fmt.Sprintf("artifacts/%s/../escape.txt", s.runs[0].ID)
+		s.EqualError(
+			database.NewRestorer(
+				s.outputDBProvider, database.WithRestoreOverwrite(), database.WithRestoreArtifacts(artifactStorage),
+			).Restore(context.Background(), bytes.NewReader(s.appendArchiveEntry(backup.Bytes(), name, "escaped"))),
+			fmt.Sprintf("error restoring artifact %s: artifact path ../escape.txt is outside of the run artifacts", name),
+		)
+		_, err = os.Stat(filepath.Join(filepath.Dir(artifactDir), "escape.txt"))
+		s.True(os.IsNotExist(err))
+	})
+
+	s.Run("InvalidBackup", func() {
+		s.Require().NotNil(
+			database.NewRestorer(s.outputDBProvider).Restore(context.Background(), bytes.NewReader([]byte("backup"))),
+		)
+	})
+
+	s.inputBackend = "postgres"
+	s.outputBackend = "postgres"
+	s.Run("TruncatedBackupKeepsDatabase", func() {
+		backup := bytes.Buffer{}
+		s.Require().Nil(database.NewBackuper(s.inputDBProvider).Backup(context.Background(), &backup))
+
+		// the tables of the truncated backup can't be copied, so the existing database has to stay unchanged.
+		err := database.NewRestorer(
+			s.outputDBProvider, database.WithRestoreOverwrite(),
+		).Restore(context.Background(), bytes.NewReader(backup.Bytes()[:backup.Len()/2]))
+		s.Require().NotNil(err)
+		s.Contains(err.Error(), "error restoring database")
+
+		s.Require().Nil(database.CheckAndMigrateDB(false, s.outputDB))
+		var namespaces, experiments int64
+		s.Require().Nil(s.outputDB.Model(&database.Namespace{}).Count(&namespaces).Error)
+		s.Require().Nil(s.outputDB.Model(&database.Experiment{}).Count(&experiments).Error)
+		s.Equal(int64(1), namespaces)
+		s.Equal(int64(1), experiments)
+	})
+}
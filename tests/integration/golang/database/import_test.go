@@ -40,6 +40,8 @@ type ImportTestSuite struct {
 	outputBackend     string
 	inputDB           *gorm.DB
 	outputDB          *gorm.DB
+	inputDBProvider   database.DBProvider
+	outputDBProvider  database.DBProvider
 }
 
 func TestImportTestSuite(t *testing.T) {
@@ -60,6 +62,7 @@ func (s *ImportTestSuite) SetupSubTest() {
 	s.Require().Nil(database.CreateDefaultNamespace(db.GormDB()))
 	s.Require().Nil(database.CreateDefaultExperiment(db.GormDB(), "s3://fasttrackml"))
 	s.inputDB = db.GormDB()
+	s.inputDBProvider = db
 
 	inputRunFixtures, err := fixtures.NewRunFixtures(db.GormDB())
 	s.Require().Nil(err)
@@ -79,6 +82,7 @@ func (s *ImportTestSuite) SetupSubTest() {
 	s.Require().Nil(database.CreateDefaultNamespace(db.GormDB()))
 	s.Require().Nil(database.CreateDefaultExperiment(db.GormDB(), "s3://fasttrackml"))
 	s.outputDB = db.GormDB()
+	s.outputDBProvider = db
 
 	outputRunFixtures, err := fixtures.NewRunFixtures(db.GormDB())
 	s.Require().Nil(err)
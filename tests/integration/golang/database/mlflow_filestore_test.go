@@ -0,0 +1,129 @@
+package database
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/database"
+)
+
+func (s *ImportTestSuite) TestMlflowFileStoreImport_Ok() {
+	for _, outputBackend := range []string{"sqlite", "sqlcipher", "postgres"} {
+		s.inputBackend = "sqlite"
+		s.outputBackend = outputBackend
+		s.Run(outputBackend, func() {
+			root := s.T().TempDir()
+			s.writeMlflowFileStore(root)
+
+			importer := database.NewMlflowFileStoreImporter(s.outputDB, root, s.T().TempDir())
+			s.Require().Nil(importer.Import(context.Background()))
+
+			expectedCounts := rowCounts{
+				namespaces:               1,
+				experiments:              3,
+				runs:                     3,
+				distinctRunExperimentIDs: 3,
+				metrics:                  6,
+				latestMetrics:            3,
+				tags:                     3,
+				params:                   2,
+			}
+			s.validateRowCounts(s.outputDB, expectedCounts)
+
+			// runs of the default experiment are imported into the default experiment.
+			var run database.Run
+			s.Require().Nil(s.outputDB.Where("run_uuid = ?", "run-1").First(&run).Error)
+			s.Equal(int32(models.DefaultExperimentID), run.ExperimentID)
+			s.Equal("first-run", run.Name)
+			s.Equal(database.StatusFinished, run.Status)
+			s.Equal("LOCAL", run.SourceType)
+			s.Equal("file://"+filepath.ToSlash(filepath.Join(root, "0", "run-1", "artifacts")), run.ArtifactURI)
+
+			var param database.Param
+			s.Require().Nil(s.outputDB.Where("run_uuid = ? AND key = ?", "run-1", "nested/lr").First(&param).Error)
+			s.Equal("0.01", *param.ValueStr)
+
+			var latestMetric database.LatestMetric
+			s.Require().Nil(s.outputDB.Where("run_uuid = ? AND key = ?", "run-1", "loss").First(&latestMetric).Error)
+			s.True(latestMetric.IsNan)
+			s.Equal(int64(2), latestMetric.Step)
+			s.Equal(int64(3), latestMetric.LastIter)
+
+			// deleted run keeps its lifecycle stage and remote artifact location.
+			var deletedRun database.Run
+			s.Require().Nil(s.outputDB.Where("run_uuid = ?", "run-2").First(&deletedRun).Error)
+			s.Equal(database.LifecycleStageDeleted, deletedRun.LifecycleStage)
+			s.Equal(int64(1700000003000), deletedRun.DeletedTime.Int64)
+			s.Equal("s3://bucket/1/run-2/artifacts", deletedRun.ArtifactURI)
+
+			// experiment from `.trash` directory is imported as deleted one.
+			var deletedExperiment database.Experiment
+			s.Require().Nil(s.outputDB.Where("name = ?", "deleted-experiment").First(&deletedExperiment).Error)
+			s.Equal(database.LifecycleStageDeleted, deletedExperiment.LifecycleStage)
+			var experiment database.Experiment
+			s.Require().Nil(s.outputDB.Preload("Tags").Where("name = ?", "experiment-1").First(&experiment).Error)
+			s.Equal([]database.ExperimentTag{
+				{Key: "team", Value: "ml", ExperimentID: *experiment.ID},
+			}, experiment.Tags)
+
+			// import the file store a 2nd time, already imported runs should be skipped.
+			s.Require().Nil(database.NewMlflowFileStoreImporter(
+				s.outputDB, root, s.T().TempDir(),
+			).Import(context.Background()))
+			s.validateRowCounts(s.outputDB, expectedCounts)
+		})
+	}
+}
+
+func (s *ImportTestSuite) TestMlflowFileStoreImport_Error() {
+	s.inputBackend = "sqlite"
+	s.outputBackend = "sqlite"
+	s.Run("EmptyDirectory", func() {
+		root := s.T().TempDir()
+		err := database.NewMlflowFileStoreImporter(s.outputDB, root, s.T().TempDir()).Import(context.Background())
+		s.EqualError(err, "no experiments found in "+root)
+	})
+}
+
+// writeMlflowFileStore creates MLflow FileStore with default, regular and deleted experiment.
+func (s *ImportTestSuite) writeMlflowFileStore(root string) {
+	files := map[string]string{
+		// default experiment with finished run.
+		"0/meta.yaml": "artifact_location: file://" + filepath.Join(root, "0") + "\n" +
+			"experiment_id: '0'\nlifecycle_stage: active\nname: Default\n",
+		"0/run-1/meta.yaml": "artifact_uri: file://" + filepath.Join(root, "0", "run-1", "artifacts") + "\n" +
+			"end_time: 1700000002000\nentry_point_name: ''\nexperiment_id: '0'\nlifecycle_stage: active\n" +
+			"run_id: run-1\nrun_name: first-run\nrun_uuid: run-1\nsource_name: ''\nsource_type: 4\n" +
+			"source_version: ''\nstart_time: 1700000000000\nstatus: 3\ntags: []\nuser_id: user\n",
+		"0/run-1/params/batch_size":   "32",
+		"0/run-1/params/nested/lr":    "0.01",
+		"0/run-1/tags/mlflow.runName": "first-run",
+		"0/run-1/tags/mlflow.user":    "user",
+		"0/run-1/metrics/loss":        "1700000000000 0.5 0\n1700000001000 0.25 1\n1700000002000 NaN 2\n",
+		"0/run-1/artifacts/model.txt": "content",
+		"1/meta.yaml": "artifact_location: s3://bucket/1\nexperiment_id: '1'\n" +
+			"lifecycle_stage: active\nname: experiment-1\ncreation_time: 1700000000000\n",
+		"1/tags/team": "ml",
+		// deleted run with remote artifacts.
+		"1/run-2/meta.yaml": "artifact_uri: s3://bucket/1/run-2/artifacts\nend_time: 1700000002000\n" +
+			"experiment_id: '1'\nlifecycle_stage: deleted\nrun_id: run-2\nrun_uuid: run-2\n" +
+			"source_type: 4\nstart_time: 1700000000000\nstatus: 4\nuser_id: user\n" +
+			"deleted_time: 1700000003000\n",
+		"1/run-2/tags/mlflow.runName": "second-run",
+		"1/run-2/metrics/accuracy":    "1700000000000 0.5 0\n1700000001000 Infinity 1\n",
+		// deleted experiment.
+		".trash/2/meta.yaml": "artifact_location: s3://bucket/2\nexperiment_id: '2'\n" +
+			"lifecycle_stage: deleted\nname: deleted-experiment\n",
+		".trash/2/run-3/meta.yaml": "artifact_uri: s3://bucket/2/run-3/artifacts\nexperiment_id: '2'\n" +
+			"lifecycle_stage: deleted\nrun_id: run-3\nrun_uuid: run-3\nsource_type: 1000\n" +
+			"start_time: 1700000000000\nstatus: 5\nuser_id: user\n",
+		".trash/2/run-3/metrics/loss": "1700000000000 1\n",
+	}
+	for path, content := range files {
+		path = filepath.Join(root, path)
+		s.Require().Nil(os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		s.Require().Nil(os.WriteFile(path, []byte(content), 0o600))
+	}
+}
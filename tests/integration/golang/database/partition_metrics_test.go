@@ -0,0 +1,120 @@
+package database
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/database"
+	"github.com/G-Research/fasttrackml/pkg/database/migrations"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/fixtures"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type PartitionMetricsTestSuite struct {
+	suite.Suite
+	db          *gorm.DB
+	runs        []*models.Run
+	runFixtures *fixtures.RunFixtures
+}
+
+func TestPartitionMetricsTestSuite(t *testing.T) {
+	// partitioning is supported only by Postgres.
+	if helpers.GetDatabaseBackend() != (postgres.Dialector{}).Name() {
+		t.Skip("partitioning of metrics table is supported only by postgres")
+	}
+	suite.Run(t, new(PartitionMetricsTestSuite))
+}
+
+func (s *PartitionMetricsTestSuite) SetupTest() {
+	dsn, err := helpers.GenerateDatabaseURI(s.T(), postgres.Dialector{}.Name())
+	s.Require().Nil(err)
+	db, err := database.NewDBProvider(
+		dsn,
+		1*time.Second,
+		20,
+	)
+	s.Require().Nil(err)
+	s.Require().Nil(database.CheckAndMigrateDB(true, db.GormDB()))
+	s.Require().Nil(database.CreateDefaultNamespace(db.GormDB()))
+	s.Require().Nil(database.CreateDefaultExperiment(db.GormDB(), "s3://fasttrackml"))
+	s.db = db.GormDB()
+
+	s.runFixtures, err = fixtures.NewRunFixtures(s.db)
+	s.Require().Nil(err)
+	s.runs, err = s.runFixtures.CreateExampleRuns(context.Background(), &models.Experiment{
+		ID: common.GetPointer(models.DefaultExperimentID),
+	}, 10)
+	s.Require().Nil(err)
+}
+
+func (s *PartitionMetricsTestSuite) Test_Ok() {
+	var expectedMetrics int64
+	s.Require().Nil(s.db.Model(&models.Metric{}).Count(&expectedMetrics).Error)
+
+	s.Require().Nil(migrations.PartitionMetricsTable(s.db, 4))
+
+	// check that the table has been partitioned and all the metrics have been copied.
+	var partitions int
+	s.Require().Nil(s.db.Raw(
+		"SELECT COUNT(*) FROM pg_inherits WHERE inhparent = 'metrics'::regclass",
+	).Scan(&partitions).Error)
+	s.Equal(4, partitions)
+
+	var actualMetrics int64
+	s.Require().Nil(s.db.Model(&models.Metric{}).Count(&actualMetrics).Error)
+	s.Equal(expectedMetrics, actualMetrics)
+
+	// check that constraints and indexes have been recreated.
+	var constraints []string
+	s.Require().Nil(s.db.Raw(
+		"SELECT conname FROM pg_constraint WHERE conrelid = 'metrics'::regclass AND contype <> 'n' ORDER BY conname",
+	).Scan(&constraints).Error)
+	s.Equal([]string{"fk_metrics_context", "fk_runs_metrics", "metrics_pkey"}, constraints)
+	for _, index := range []string{"RunID", "Iter"} {
+		s.True(s.db.Migrator().HasIndex(&database.Metric{}, index))
+	}
+
+	// check that queries by run are pruned to one partition.
+	var plan []string
+	s.Require().Nil(s.db.Raw(
+		"EXPLAIN SELECT * FROM metrics WHERE run_uuid = ?", s.runs[0].ID,
+	).Scan(&plan).Error)
+	// plan refers to the partition and its indexes, e.g. `metrics_p1` and `metrics_p1_pkey`.
+	scannedPartitions := map[string]struct{}{}
+	for _, partition := range regexp.MustCompile(`metrics_p\d+`).FindAllString(strings.Join(plan, "\n"), -1) {
+		scannedPartitions[partition] = struct{}{}
+	}
+	s.Len(scannedPartitions, 1)
+
+	// check that metrics are still logged and removed together with the run.
+	_, err := s.runFixtures.CreateExampleRuns(context.Background(), &models.Experiment{
+		ID: common.GetPointer(models.DefaultExperimentID),
+	}, 1)
+	s.Require().Nil(err)
+	s.Require().Nil(s.db.Where("run_uuid = ?", s.runs[0].ID).Delete(&models.Run{}).Error)
+	s.Require().Nil(s.db.Model(&models.Metric{}).Count(&actualMetrics).Error)
+	s.Equal(expectedMetrics, actualMetrics)
+
+	// check that the migration could be repeated with the same number of partitions only.
+	s.Nil(migrations.PartitionMetricsTable(s.db, 4))
+	s.EqualError(
+		migrations.PartitionMetricsTable(s.db, 8),
+		"table metrics is already partitioned into 4 partitions",
+	)
+}
+
+func (s *PartitionMetricsTestSuite) Test_Error() {
+	s.EqualError(
+		migrations.PartitionMetricsTable(s.db, 1),
+		"number of partitions should be at least 2, got 1",
+	)
+}
@@ -0,0 +1,66 @@
+package fixtures
+
+import (
+	"context"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// APITokenFixtures represents data fixtures object.
+type APITokenFixtures struct {
+	baseFixtures
+	tokenRepository repositories.APITokenRepositoryProvider
+}
+
+// NewAPITokenFixtures creates new instance of APITokenFixtures.
+func NewAPITokenFixtures(db *gorm.DB) (*APITokenFixtures, error) {
+	return &APITokenFixtures{
+		baseFixtures:    baseFixtures{db: db},
+		tokenRepository: repositories.NewAPITokenRepository(db),
+	}, nil
+}
+
+// CreateAPIToken creates a new test APIToken and returns it along with its plain value.
+func (f APITokenFixtures) CreateAPIToken(
+	ctx context.Context, name string, roles []string, expiresAt *time.Time,
+) (*models.APIToken, string, error) {
+	token, value, err := models.NewAPIToken(name, roles, expiresAt)
+	if err != nil {
+		return nil, "", eris.Wrap(err, "error generating test api token")
+	}
+	if err := f.tokenRepository.Create(ctx, token); err != nil {
+		return nil, "", eris.Wrap(err, "error creating test api token")
+	}
+	return token, value, nil
+}
+
+// RevokeAPIToken revokes the test APIToken.
+func (f APITokenFixtures) RevokeAPIToken(ctx context.Context, token *models.APIToken) error {
+	if err := f.tokenRepository.Revoke(ctx, token); err != nil {
+		return eris.Wrap(err, "error revoking test api token")
+	}
+	return nil
+}
+
+// GetAPIToken returns the test APIToken by its ID.
+func (f APITokenFixtures) GetAPIToken(ctx context.Context, token *models.APIToken) (*models.APIToken, error) {
+	token, err := f.tokenRepository.GetByID(ctx, token.ID)
+	if err != nil {
+		return nil, eris.Wrap(err, "error getting test api token")
+	}
+	return token, nil
+}
+
+// GetAPITokens returns all the test APITokens.
+func (f APITokenFixtures) GetAPITokens(ctx context.Context) ([]models.APIToken, error) {
+	tokens, err := f.tokenRepository.List(ctx)
+	if err != nil {
+		return nil, eris.Wrap(err, "error getting test api tokens")
+	}
+	return tokens, nil
+}
@@ -39,3 +39,16 @@ func (f ArtifactFixtures) CreateArtifact(ctx context.Context, artifact *models.A
 	}
 	return artifact, nil
 }
+
+// GetArtifactsByRunIDAndType returns Run artifacts of the type by requested Run ID.
+func (f ArtifactFixtures) GetArtifactsByRunIDAndType(
+	ctx context.Context, runID, artifactType string,
+) ([]models.Artifact, error) {
+	var artifacts []models.Artifact
+	if err := f.db.WithContext(ctx).Where(
+		"run_uuid = ? AND type = ?", runID, artifactType,
+	).Order("step").Find(&artifacts).Error; err != nil {
+		return nil, eris.Wrapf(err, "error getting run %s by run id: %s", artifactType, runID)
+	}
+	return artifacts, nil
+}
@@ -0,0 +1,44 @@
+package fixtures
+
+import (
+	"context"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// AuditEventFixtures represents data fixtures object.
+type AuditEventFixtures struct {
+	baseFixtures
+	auditEventRepository repositories.AuditEventRepositoryProvider
+}
+
+// NewAuditEventFixtures creates new instance of AuditEventFixtures.
+func NewAuditEventFixtures(db *gorm.DB) (*AuditEventFixtures, error) {
+	return &AuditEventFixtures{
+		baseFixtures:         baseFixtures{db: db},
+		auditEventRepository: repositories.NewAuditEventRepository(db),
+	}, nil
+}
+
+// CreateAuditEvent creates a new test AuditEvent.
+func (f AuditEventFixtures) CreateAuditEvent(
+	ctx context.Context, event *models.AuditEvent,
+) (*models.AuditEvent, error) {
+	if err := f.auditEventRepository.Create(ctx, event); err != nil {
+		return nil, eris.Wrap(err, "error creating test audit event")
+	}
+	return event, nil
+}
+
+// GetAuditEvents returns all the test AuditEvents, from the latest ones.
+func (f AuditEventFixtures) GetAuditEvents(ctx context.Context) ([]models.AuditEvent, error) {
+	events, err := f.auditEventRepository.List(ctx, repositories.AuditEventFilter{})
+	if err != nil {
+		return nil, eris.Wrap(err, "error getting test audit events")
+	}
+	return events, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	aimModels "github.com/G-Research/fasttrackml/pkg/api/aim/dao/models"
 	mlflowModels "github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	commonModels "github.com/G-Research/fasttrackml/pkg/common/dao/models"
 )
 
 // baseFixtures represents base fixtures object.
@@ -25,18 +26,31 @@ func (f baseFixtures) TruncateTables() error {
 		aimModels.App{},
 		aimModels.SharedTag{},
 		mlflowModels.Artifact{},
+		mlflowModels.ModelVersionTag{},
+		mlflowModels.ModelVersion{},
+		mlflowModels.RegisteredModelAlias{},
+		mlflowModels.RegisteredModelTag{},
+		mlflowModels.RegisteredModel{},
+		mlflowModels.MetricRetentionPolicy{},
 		mlflowModels.Tag{},
 		mlflowModels.Param{},
 		mlflowModels.LatestMetric{},
 		mlflowModels.Metric{},
 		mlflowModels.Context{},
 		mlflowModels.Log{},
+		mlflowModels.InputTag{},
+		mlflowModels.Input{},
+		mlflowModels.Dataset{},
+		mlflowModels.RunPurge{},
+		mlflowModels.Export{},
 		mlflowModels.Run{},
 		mlflowModels.ExperimentTag{},
 		mlflowModels.Experiment{},
 		mlflowModels.Namespace{},
 		mlflowModels.RoleNamespace{},
 		mlflowModels.Role{},
+		commonModels.APIToken{},
+		commonModels.AuditEvent{},
 	} {
 		if err := f.db.Session(
 			&gorm.Session{AllowGlobalUpdate: true},
@@ -0,0 +1,42 @@
+package fixtures
+
+import (
+	"context"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+)
+
+// ExportFixtures represents data fixtures object.
+type ExportFixtures struct {
+	baseFixtures
+	exportRepository repositories.ExportRepositoryProvider
+}
+
+// NewExportFixtures creates new instance of ExportFixtures.
+func NewExportFixtures(db *gorm.DB) (*ExportFixtures, error) {
+	return &ExportFixtures{
+		baseFixtures:     baseFixtures{db: db},
+		exportRepository: repositories.NewExportRepository(db),
+	}, nil
+}
+
+// CreateExport creates new test Export.
+func (f ExportFixtures) CreateExport(ctx context.Context, export *models.Export) (*models.Export, error) {
+	if err := f.exportRepository.Create(ctx, export); err != nil {
+		return nil, eris.Wrap(err, "error creating test export")
+	}
+	return export, nil
+}
+
+// GetExport returns the test Export by Namespace ID and its ID.
+func (f ExportFixtures) GetExport(ctx context.Context, namespaceID uint, id string) (*models.Export, error) {
+	export, err := f.exportRepository.GetByNamespaceIDAndID(ctx, namespaceID, id)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error getting test export by id: %s", id)
+	}
+	return export, nil
+}
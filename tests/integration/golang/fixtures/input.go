@@ -0,0 +1,70 @@
+package fixtures
+
+import (
+	"context"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+)
+
+// InputFixtures represents data fixtures object.
+type InputFixtures struct {
+	baseFixtures
+}
+
+// NewInputFixtures creates new instance of InputFixtures.
+func NewInputFixtures(db *gorm.DB) (*InputFixtures, error) {
+	return &InputFixtures{
+		baseFixtures: baseFixtures{db: db},
+	}, nil
+}
+
+// CreateDataset creates new Dataset.
+func (f InputFixtures) CreateDataset(ctx context.Context, dataset *models.Dataset) (*models.Dataset, error) {
+	if err := f.db.WithContext(ctx).Create(dataset).Error; err != nil {
+		return nil, eris.Wrap(err, "error creating test dataset")
+	}
+	return dataset, nil
+}
+
+// CreateInput creates new Input together with its Tags.
+func (f InputFixtures) CreateInput(ctx context.Context, input *models.Input) (*models.Input, error) {
+	if err := f.db.WithContext(ctx).Omit(clause.Associations).Create(input).Error; err != nil {
+		return nil, eris.Wrap(err, "error creating test input")
+	}
+	if len(input.Tags) > 0 {
+		if err := f.db.WithContext(ctx).Create(&input.Tags).Error; err != nil {
+			return nil, eris.Wrap(err, "error creating test input tags")
+		}
+	}
+	return input, nil
+}
+
+// GetByRunID returns inputs of the run together with their datasets and tags.
+func (f InputFixtures) GetByRunID(ctx context.Context, runID string) ([]models.Input, error) {
+	var inputs []models.Input
+	if err := f.db.WithContext(ctx).Preload(
+		"Tags", func(db *gorm.DB) *gorm.DB { return db.Order("name") },
+	).Preload(
+		"Dataset",
+	).Where(
+		"destination_type = ? AND destination_id = ?", models.InputDestinationTypeRun, runID,
+	).Find(&inputs).Error; err != nil {
+		return nil, eris.Wrapf(err, "error getting inputs by run id: %s", runID)
+	}
+	return inputs, nil
+}
+
+// GetDatasetsByExperimentID returns datasets of the experiment.
+func (f InputFixtures) GetDatasetsByExperimentID(ctx context.Context, experimentID int32) ([]models.Dataset, error) {
+	var datasets []models.Dataset
+	if err := f.db.WithContext(ctx).Where(
+		"experiment_id = ?", experimentID,
+	).Order("name").Find(&datasets).Error; err != nil {
+		return nil, eris.Wrapf(err, "error getting datasets by experiment id: %d", experimentID)
+	}
+	return datasets, nil
+}
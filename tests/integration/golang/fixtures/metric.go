@@ -5,6 +5,7 @@ import (
 
 	"github.com/rotisserie/eris"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
@@ -53,6 +54,32 @@ func (f MetricFixtures) CreateMetric(ctx context.Context, metric *models.Metric)
 	return metric, nil
 }
 
+// CreateMetricWithLatestMetric creates new test Metric together with the Latest Metric of its series, as it
+// happens when the metric is logged. Metric history is read only for the series known by latest metrics.
+func (f MetricFixtures) CreateMetricWithLatestMetric(
+	ctx context.Context, metric *models.Metric,
+) (*models.Metric, error) {
+	metric, err := f.CreateMetric(ctx, metric)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.baseFixtures.db.WithContext(ctx).Clauses(
+		clause.OnConflict{UpdateAll: true},
+	).Create(&models.LatestMetric{
+		Key:       metric.Key,
+		Value:     metric.Value,
+		Timestamp: metric.Timestamp,
+		Step:      metric.Step,
+		IsNan:     metric.IsNan,
+		RunID:     metric.RunID,
+		LastIter:  metric.Iter,
+		ContextID: metric.ContextID,
+	}).Error; err != nil {
+		return nil, eris.Wrap(err, "error creating latest metric")
+	}
+	return metric, nil
+}
+
 // GetMetricsByRunID returns the metrics by Run ID.
 func (f MetricFixtures) GetMetricsByRunID(ctx context.Context, runID string) ([]*models.Metric, error) {
 	var metrics []*models.Metric
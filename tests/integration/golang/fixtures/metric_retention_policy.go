@@ -0,0 +1,58 @@
+package fixtures
+
+import (
+	"context"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+)
+
+// MetricRetentionPolicyFixtures represents data fixtures object.
+type MetricRetentionPolicyFixtures struct {
+	baseFixtures
+	retentionRepository repositories.MetricRetentionPolicyRepositoryProvider
+}
+
+// NewMetricRetentionPolicyFixtures creates new instance of MetricRetentionPolicyFixtures.
+func NewMetricRetentionPolicyFixtures(db *gorm.DB) (*MetricRetentionPolicyFixtures, error) {
+	return &MetricRetentionPolicyFixtures{
+		baseFixtures:        baseFixtures{db: db},
+		retentionRepository: repositories.NewMetricRetentionPolicyRepository(db),
+	}, nil
+}
+
+// CreateMetricRetentionPolicy creates a new test MetricRetentionPolicy.
+func (f MetricRetentionPolicyFixtures) CreateMetricRetentionPolicy(
+	ctx context.Context, policy *models.MetricRetentionPolicy,
+) (*models.MetricRetentionPolicy, error) {
+	if err := f.retentionRepository.Create(ctx, policy); err != nil {
+		return nil, eris.Wrap(err, "error creating test metric retention policy")
+	}
+	return policy, nil
+}
+
+// GetMetricRetentionPolicies returns all the test MetricRetentionPolicies.
+func (f MetricRetentionPolicyFixtures) GetMetricRetentionPolicies(
+	ctx context.Context,
+) ([]models.MetricRetentionPolicy, error) {
+	policies, err := f.retentionRepository.List(ctx)
+	if err != nil {
+		return nil, eris.Wrap(err, "error getting test metric retention policies")
+	}
+	return policies, nil
+}
+
+// ApplyMetricRetentionPolicy applies the test MetricRetentionPolicy the same way the compactor does.
+func (f MetricRetentionPolicyFixtures) ApplyMetricRetentionPolicy(
+	ctx context.Context, policy *models.MetricRetentionPolicy, now time.Time,
+) (int64, error) {
+	removed, err := f.retentionRepository.Apply(ctx, policy, now)
+	if err != nil {
+		return 0, eris.Wrap(err, "error applying test metric retention policy")
+	}
+	return removed, nil
+}
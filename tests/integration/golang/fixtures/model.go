@@ -0,0 +1,91 @@
+package fixtures
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+)
+
+// ModelFixtures represents data fixtures object.
+type ModelFixtures struct {
+	baseFixtures
+}
+
+// NewModelFixtures creates new instance of ModelFixtures.
+func NewModelFixtures(db *gorm.DB) (*ModelFixtures, error) {
+	return &ModelFixtures{
+		baseFixtures: baseFixtures{db: db},
+	}, nil
+}
+
+// CreateRegisteredModel creates new test RegisteredModel.
+func (f ModelFixtures) CreateRegisteredModel(
+	ctx context.Context, registeredModel *models.RegisteredModel,
+) (*models.RegisteredModel, error) {
+	if err := f.db.WithContext(ctx).Create(registeredModel).Error; err != nil {
+		return nil, eris.Wrap(err, "error creating test registered model")
+	}
+	return registeredModel, nil
+}
+
+// CreateModelVersion creates new test ModelVersion.
+func (f ModelFixtures) CreateModelVersion(
+	ctx context.Context, modelVersion *models.ModelVersion,
+) (*models.ModelVersion, error) {
+	if err := f.db.WithContext(ctx).Omit("RegisteredModel").Create(modelVersion).Error; err != nil {
+		return nil, eris.Wrap(err, "error creating test model version")
+	}
+	return modelVersion, nil
+}
+
+// CreateRegisteredModelAlias creates new test RegisteredModelAlias.
+func (f ModelFixtures) CreateRegisteredModelAlias(
+	ctx context.Context, alias *models.RegisteredModelAlias,
+) (*models.RegisteredModelAlias, error) {
+	if err := f.db.WithContext(ctx).Create(alias).Error; err != nil {
+		return nil, eris.Wrap(err, "error creating test registered model alias")
+	}
+	return alias, nil
+}
+
+// GetRegisteredModelByName returns registered model by Namespace ID and its name.
+func (f ModelFixtures) GetRegisteredModelByName(
+	ctx context.Context, namespaceID uint, name string,
+) (*models.RegisteredModel, error) {
+	var registeredModel models.RegisteredModel
+	if err := f.db.WithContext(ctx).Preload(
+		"Tags",
+	).Preload(
+		"Aliases",
+	).Preload(
+		"Versions.Tags",
+	).Where(
+		"namespace_id = ?", namespaceID,
+	).Where(
+		"name = ?", name,
+	).First(&registeredModel).Error; err != nil {
+		return nil, eris.Wrapf(err, "error getting registered model by name: %s", name)
+	}
+	return &registeredModel, nil
+}
+
+// GetModelVersions returns the list of model versions by Registered Model ID.
+func (f ModelFixtures) GetModelVersions(
+	ctx context.Context, registeredModelID uuid.UUID,
+) ([]models.ModelVersion, error) {
+	var modelVersions []models.ModelVersion
+	if err := f.db.WithContext(ctx).Preload(
+		"Tags",
+	).Where(
+		"registered_model_id = ?", registeredModelID,
+	).Order(
+		"version",
+	).Find(&modelVersions).Error; err != nil {
+		return nil, eris.Wrapf(err, "error getting model versions by registered model id: %s", registeredModelID)
+	}
+	return modelVersions, nil
+}
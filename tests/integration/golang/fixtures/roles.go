@@ -7,6 +7,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	commonModels "github.com/G-Research/fasttrackml/pkg/common/dao/models"
 )
 
 // RoleFixtures represents data fixtures object.
@@ -41,3 +42,17 @@ func (f RoleFixtures) AttachNamespaceToRole(
 	}
 	return nil
 }
+
+// AttachNamespaceToRoleWithPermission attaches a Role to provided Namespace with provided permission.
+func (f RoleFixtures) AttachNamespaceToRoleWithPermission(
+	ctx context.Context, role *models.Role, namespace *models.Namespace, permission commonModels.Permission,
+) error {
+	if err := f.db.WithContext(ctx).Create(&models.RoleNamespace{
+		RoleID:      role.ID,
+		NamespaceID: namespace.ID,
+		Permission:  permission,
+	}).Error; err != nil {
+		return eris.Wrap(err, "error attaching namespace to role")
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package fixtures
+
+import (
+	"context"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/service/purge"
+)
+
+// RunPurgeFixtures represents data fixtures object.
+type RunPurgeFixtures struct {
+	baseFixtures
+	purgeRepository repositories.RunPurgeRepositoryProvider
+}
+
+// NewRunPurgeFixtures creates new instance of RunPurgeFixtures.
+func NewRunPurgeFixtures(db *gorm.DB) (*RunPurgeFixtures, error) {
+	return &RunPurgeFixtures{
+		baseFixtures:    baseFixtures{db: db},
+		purgeRepository: repositories.NewRunPurgeRepository(db),
+	}, nil
+}
+
+// GetRunPurges returns all the purges of the deleted runs.
+func (f RunPurgeFixtures) GetRunPurges(ctx context.Context) ([]models.RunPurge, error) {
+	purges, err := f.purgeRepository.List(ctx)
+	if err != nil {
+		return nil, eris.Wrap(err, "error getting test run purges")
+	}
+	return purges, nil
+}
+
+// QueueDeletedRuns queues the runs in `deleted` lifecycle stage to be purged the same way `fml gc` does.
+func (f RunPurgeFixtures) QueueDeletedRuns(ctx context.Context, olderThan time.Time, runIDs []string) (int64, error) {
+	queued, err := f.purgeRepository.CreateForDeletedRuns(ctx, olderThan, runIDs)
+	if err != nil {
+		return 0, eris.Wrap(err, "error queueing test deleted runs")
+	}
+	return queued, nil
+}
+
+// PurgeDeletedRuns purges the deleted runs the same way the purge worker does, with small chunks of metrics.
+func (f RunPurgeFixtures) PurgeDeletedRuns(ctx context.Context) (int, error) {
+	cfg := &config.Config{RunPurgeChunkSize: 2}
+	artifactStorageFactory, err := storage.NewArtifactStorageFactory(cfg)
+	if err != nil {
+		return 0, eris.Wrap(err, "error creating artifact storage factory")
+	}
+	purged, err := purge.NewPurger(ctx, cfg, f.purgeRepository, artifactStorageFactory).PurgeAll()
+	if err != nil {
+		return 0, eris.Wrap(err, "error purging test deleted runs")
+	}
+	return purged, nil
+}
@@ -2,10 +2,14 @@ package helpers
 
 import (
 	"bytes"
+	"context"
 
+	"github.com/apache/arrow/go/v14/arrow"
 	"github.com/apache/arrow/go/v14/arrow/array"
 	"github.com/apache/arrow/go/v14/arrow/ipc"
 	"github.com/apache/arrow/go/v14/arrow/memory"
+	"github.com/apache/arrow/go/v14/parquet"
+	"github.com/apache/arrow/go/v14/parquet/pqarrow"
 	"github.com/rotisserie/eris"
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
@@ -48,3 +52,86 @@ func DecodeArrowMetrics(buf *bytes.Buffer) ([]models.Metric, error) {
 
 	return metrics, nil
 }
+
+// DecodeArrowTable decodes Arrow stream into the names of the columns and the rows, where each row maps
+// the column name to its string, int64 or float64 value. Null values are decoded as nil.
+func DecodeArrowTable(buf *bytes.Buffer) ([]string, []map[string]any, error) {
+	reader, err := ipc.NewReader(buf, ipc.WithAllocator(memory.NewGoAllocator()))
+	if err != nil {
+		return nil, nil, eris.Wrap(err, "error creating reader for arrow decode")
+	}
+	defer reader.Release()
+
+	var rows []map[string]any
+	for reader.Next() {
+		if rows, err = appendRecordRows(rows, reader.Record()); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if reader.Err() != nil {
+		return nil, nil, eris.Wrap(reader.Err(), "error processing reader in arrow decode")
+	}
+
+	return schemaColumns(reader.Schema()), rows, nil
+}
+
+// DecodeParquetTable decodes Parquet file the same way as DecodeArrowTable does.
+func DecodeParquetTable(data []byte) ([]string, []map[string]any, error) {
+	table, err := pqarrow.ReadTable(
+		context.Background(),
+		bytes.NewReader(data),
+		parquet.NewReaderProperties(memory.DefaultAllocator),
+		pqarrow.ArrowReadProperties{},
+		memory.DefaultAllocator,
+	)
+	if err != nil {
+		return nil, nil, eris.Wrap(err, "error reading parquet table")
+	}
+	defer table.Release()
+
+	reader := array.NewTableReader(table, -1)
+	defer reader.Release()
+
+	var rows []map[string]any
+	for reader.Next() {
+		if rows, err = appendRecordRows(rows, reader.Record()); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return schemaColumns(table.Schema()), rows, nil
+}
+
+// schemaColumns returns the names of the columns of the schema.
+func schemaColumns(schema *arrow.Schema) []string {
+	var columns []string
+	for _, field := range schema.Fields() {
+		columns = append(columns, field.Name)
+	}
+	return columns
+}
+
+// appendRecordRows appends the rows of the record to provided rows.
+func appendRecordRows(rows []map[string]any, rec arrow.Record) ([]map[string]any, error) {
+	columns := schemaColumns(rec.Schema())
+	for i := 0; i < int(rec.NumRows()); i++ {
+		row := make(map[string]any, len(columns))
+		for j, column := range rec.Columns() {
+			switch {
+			case column.IsNull(i):
+				row[columns[j]] = nil
+			case column.DataType().ID() == arrow.STRING:
+				row[columns[j]] = column.(*array.String).Value(i)
+			case column.DataType().ID() == arrow.INT64:
+				row[columns[j]] = column.(*array.Int64).Value(i)
+			case column.DataType().ID() == arrow.FLOAT64:
+				row[columns[j]] = column.(*array.Float64).Value(i)
+			default:
+				return nil, eris.Errorf("unsupported type of column '%s': %s", columns[j], column.DataType())
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
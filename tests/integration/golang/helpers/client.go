@@ -63,6 +63,11 @@ func NewMlflowApiClient(server server.Server) *HttpClient {
 	return NewClient(server, "/api/2.0/mlflow")
 }
 
+// NewMlflowArtifactsApiClient creates a new HTTP client for the mlflow-artifacts api
+func NewMlflowArtifactsApiClient(server server.Server) *HttpClient {
+	return NewClient(server, "/api/2.0/mlflow-artifacts")
+}
+
 // NewAimApiClient creates a new HTTP client for the aim api
 func NewAimApiClient(server server.Server) *HttpClient {
 	return NewClient(server, "/aim/api")
@@ -135,13 +140,18 @@ func (c *HttpClient) GetStatusCode() int {
 // nolint:gocyclo
 func (c *HttpClient) DoRequest(uri string, values ...any) error {
 	// 1. check if request object were provided. if provided then marshal it.
+	// io.Reader request objects are sent as is.
 	var requestBody io.Reader
 	if c.request != nil {
-		data, err := json.Marshal(c.request)
-		if err != nil {
-			return eris.Wrap(err, "error marshaling request object")
+		if reader, ok := c.request.(io.Reader); ok {
+			requestBody = reader
+		} else {
+			data, err := json.Marshal(c.request)
+			if err != nil {
+				return eris.Wrap(err, "error marshaling request object")
+			}
+			requestBody = bytes.NewBuffer(data)
 		}
-		requestBody = bytes.NewBuffer(data)
 	}
 
 	// 2. build path with namespace.
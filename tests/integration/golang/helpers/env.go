@@ -51,6 +51,17 @@ func GetS3EndpointUri() string {
 	return "http://localhost:9000"
 }
 
+func GetAzureStorageConnectionString() string {
+	connectionString, ok := os.LookupEnv("FML_AZURE_STORAGE_CONNECTION_STRING")
+	if ok {
+		return connectionString
+	}
+	// default Azurite account credentials.
+	return "DefaultEndpointsProtocol=http;AccountName=devstoreaccount1;" +
+		"AccountKey=Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw==;" +
+		"BlobEndpoint=http://127.0.0.1:10000/devstoreaccount1;"
+}
+
 func GetSlowTestsEnabledFlag() bool {
 	flag, ok := os.LookupEnv("FML_SLOW_TESTS_ENABLED")
 	if ok && flag == "true" {
@@ -27,21 +27,29 @@ type BaseTestSuite struct {
 	tearDownHooks               []func()
 	AIMClient                   func() *HttpClient
 	MlflowClient                func() *HttpClient
+	MlflowArtifactsClient       func() *HttpClient
 	AdminClient                 func() *HttpClient
 	ChooserClient               func() *HttpClient
 	AppFixtures                 *fixtures.AppFixtures
+	APITokenFixtures            *fixtures.APITokenFixtures
+	AuditEventFixtures          *fixtures.AuditEventFixtures
 	RunFixtures                 *fixtures.RunFixtures
+	RunPurgeFixtures            *fixtures.RunPurgeFixtures
 	LogFixtures                 *fixtures.LogFixtures
+	InputFixtures               *fixtures.InputFixtures
 	TagFixtures                 *fixtures.TagFixtures
 	ArtifactFixtures            *fixtures.ArtifactFixtures
 	SharedTagFixtures           *fixtures.SharedTagFixtures
 	RolesFixtures               *fixtures.RoleFixtures
 	MetricFixtures              *fixtures.MetricFixtures
+	MetricRetentionFixtures     *fixtures.MetricRetentionPolicyFixtures
+	ModelFixtures               *fixtures.ModelFixtures
 	ContextFixtures             *fixtures.ContextFixtures
 	ParamFixtures               *fixtures.ParamFixtures
 	ProjectFixtures             *fixtures.ProjectFixtures
 	DashboardFixtures           *fixtures.DashboardFixtures
 	ExperimentFixtures          *fixtures.ExperimentFixtures
+	ExportFixtures              *fixtures.ExportFixtures
 	DefaultExperiment           *models.Experiment
 	NamespaceFixtures           *fixtures.NamespaceFixtures
 	DefaultNamespace            *models.Namespace
@@ -88,6 +96,14 @@ func (s *BaseTestSuite) initFixtures() {
 	s.Require().Nil(err)
 	s.AppFixtures = appFixtures
 
+	apiTokenFixtures, err := fixtures.NewAPITokenFixtures(db)
+	s.Require().Nil(err)
+	s.APITokenFixtures = apiTokenFixtures
+
+	auditEventFixtures, err := fixtures.NewAuditEventFixtures(db)
+	s.Require().Nil(err)
+	s.AuditEventFixtures = auditEventFixtures
+
 	dashboardFixtures, err := fixtures.NewDashboardFixtures(db)
 	s.Require().Nil(err)
 	s.DashboardFixtures = dashboardFixtures
@@ -96,10 +112,22 @@ func (s *BaseTestSuite) initFixtures() {
 	s.Require().Nil(err)
 	s.ExperimentFixtures = experimentFixtures
 
+	exportFixtures, err := fixtures.NewExportFixtures(db)
+	s.Require().Nil(err)
+	s.ExportFixtures = exportFixtures
+
 	metricFixtures, err := fixtures.NewMetricFixtures(db)
 	s.Require().Nil(err)
 	s.MetricFixtures = metricFixtures
 
+	metricRetentionFixtures, err := fixtures.NewMetricRetentionPolicyFixtures(db)
+	s.Require().Nil(err)
+	s.MetricRetentionFixtures = metricRetentionFixtures
+
+	modelFixtures, err := fixtures.NewModelFixtures(db)
+	s.Require().Nil(err)
+	s.ModelFixtures = modelFixtures
+
 	rolesFixtures, err := fixtures.NewRoleFixtures(db)
 	s.Require().Nil(err)
 	s.RolesFixtures = rolesFixtures
@@ -124,6 +152,10 @@ func (s *BaseTestSuite) initFixtures() {
 	s.Require().Nil(err)
 	s.RunFixtures = runFixtures
 
+	runPurgeFixtures, err := fixtures.NewRunPurgeFixtures(db)
+	s.Require().Nil(err)
+	s.RunPurgeFixtures = runPurgeFixtures
+
 	tagFixtures, err := fixtures.NewTagFixtures(db)
 	s.Require().Nil(err)
 	s.TagFixtures = tagFixtures
@@ -139,6 +171,10 @@ func (s *BaseTestSuite) initFixtures() {
 	logFixtures, err := fixtures.NewLogFixtures(db)
 	s.Require().Nil(err)
 	s.LogFixtures = logFixtures
+
+	inputFixtures, err := fixtures.NewInputFixtures(db)
+	s.Require().Nil(err)
+	s.InputFixtures = inputFixtures
 }
 
 func (s *BaseTestSuite) closeDB() {
@@ -147,14 +183,15 @@ func (s *BaseTestSuite) closeDB() {
 
 func (s *BaseTestSuite) startServer() {
 	cfg := config.Config{
-		DatabaseURI:           s.db.Dsn(),
-		DatabasePoolMax:       10,
-		DatabaseSlowThreshold: 1 * time.Second,
-		DatabaseMigrate:       true,
-		DefaultArtifactRoot:   s.T().TempDir(),
-		S3EndpointURI:         GetS3EndpointUri(),
-		GSEndpointURI:         GetGSEndpointUri(),
-		RunLogOutputMax:       MaxLogRows,
+		DatabaseURI:                  s.db.Dsn(),
+		DatabasePoolMax:              10,
+		DatabaseSlowThreshold:        1 * time.Second,
+		DatabaseMigrate:              true,
+		DefaultArtifactRoot:          s.T().TempDir(),
+		S3EndpointURI:                GetS3EndpointUri(),
+		GSEndpointURI:                GetGSEndpointUri(),
+		RunLogOutputMax:              MaxLogRows,
+		AzureStorageConnectionString: GetAzureStorageConnectionString(),
 	}
 	s.Require().Nil(mergo.Merge(&cfg, s.Config))
 
@@ -168,6 +205,9 @@ func (s *BaseTestSuite) startServer() {
 	s.MlflowClient = func() *HttpClient {
 		return NewMlflowApiClient(s.server)
 	}
+	s.MlflowArtifactsClient = func() *HttpClient {
+		return NewMlflowArtifactsApiClient(s.server)
+	}
 	s.AdminClient = func() *HttpClient {
 		return NewAdminApiClient(s.server)
 	}
@@ -0,0 +1,91 @@
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/api/request"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type GetArtifactAzureTestSuite struct {
+	helpers.AzureTestSuite
+}
+
+func TestGetArtifactAzureTestSuite(t *testing.T) {
+	suite.Run(t, &GetArtifactAzureTestSuite{
+		helpers.NewAzureTestSuite("container1"),
+	})
+}
+
+func (s *GetArtifactAzureTestSuite) Test_Ok() {
+	// create test experiment
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:             "Test Experiment In Container container1",
+		NamespaceID:      s.DefaultNamespace.ID,
+		LifecycleStage:   models.LifecycleStageActive,
+		ArtifactLocation: "abfss://container1@devstoreaccount1.dfs.core.windows.net/1",
+	})
+	s.Require().Nil(err)
+
+	// create test run
+	runID := strings.ReplaceAll(uuid.New().String(), "-", "")
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             runID,
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		ExperimentID:   *experiment.ID,
+		ArtifactURI:    fmt.Sprintf("%s/%s/artifacts", experiment.ArtifactLocation, runID),
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	// upload artifact subdir object to Azure
+	_, err = s.Client.UploadBuffer(
+		context.Background(),
+		"container1",
+		fmt.Sprintf("1/%s/artifacts/artifact/artifact.txt", runID),
+		[]byte("subdir-object-content"),
+		nil,
+	)
+	s.Require().Nil(err)
+
+	// make API call for subdir object
+	resp := new(bytes.Buffer)
+	s.Require().Nil(s.MlflowClient().WithQuery(
+		request.GetArtifactRequest{
+			RunID: run.ID,
+			Path:  "artifact/artifact.txt",
+		},
+	).WithResponseType(
+		helpers.ResponseTypeBuffer,
+	).WithResponse(
+		resp,
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsGetRoute,
+	))
+	s.Equal("subdir-object-content", resp.String())
+
+	// make API call for non-existing object
+	errorResp := api.ErrorResponse{}
+	s.Require().Nil(s.MlflowClient().WithQuery(
+		request.GetArtifactRequest{
+			RunID: run.ID,
+			Path:  "non-existent-file",
+		},
+	).WithResponse(
+		&errorResp,
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsGetRoute,
+	))
+	s.Equal(api.ErrorCodeResourceDoesNotExist, errorResp.ErrorCode)
+}
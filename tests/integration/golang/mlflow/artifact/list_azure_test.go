@@ -0,0 +1,162 @@
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api/request"
+	"github.com/G-Research/fasttrackml/pkg/common/api/response"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type ListArtifactAzureTestSuite struct {
+	helpers.AzureTestSuite
+}
+
+func TestListArtifactAzureTestSuite(t *testing.T) {
+	suite.Run(t, &ListArtifactAzureTestSuite{
+		helpers.NewAzureTestSuite("container1", "container2"),
+	})
+}
+
+func (s *ListArtifactAzureTestSuite) Test_Ok() {
+	tests := []struct {
+		name      string
+		container string
+	}{
+		{
+			name:      "TestWithContainer1",
+			container: "container1",
+		},
+		{
+			name:      "TestWithContainer2",
+			container: "container2",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// 1. create test experiment.
+			experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+				Name:             fmt.Sprintf("Test Experiment In Container %s", tt.container),
+				NamespaceID:      s.DefaultNamespace.ID,
+				LifecycleStage:   models.LifecycleStageActive,
+				ArtifactLocation: fmt.Sprintf("wasbs://%s@devstoreaccount1.blob.core.windows.net/1", tt.container),
+			})
+			s.Require().Nil(err)
+
+			// 2. create test run.
+			runID := strings.ReplaceAll(uuid.New().String(), "-", "")
+			run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+				ID:             runID,
+				Status:         models.StatusRunning,
+				SourceType:     "JOB",
+				ExperimentID:   *experiment.ID,
+				ArtifactURI:    fmt.Sprintf("%s/%s/artifacts", experiment.ArtifactLocation, runID),
+				LifecycleStage: models.LifecycleStageActive,
+			})
+			s.Require().Nil(err)
+
+			// 3. upload artifact objects to Azure.
+			_, err = s.Client.UploadBuffer(
+				context.Background(),
+				tt.container,
+				fmt.Sprintf("1/%s/artifacts/artifact.txt", runID),
+				[]byte("contentX"),
+				nil,
+			)
+			s.Require().Nil(err)
+			_, err = s.Client.UploadBuffer(
+				context.Background(),
+				tt.container,
+				fmt.Sprintf("1/%s/artifacts/artifact/artifact.txt", runID),
+				[]byte("contentXX"),
+				nil,
+			)
+			s.Require().Nil(err)
+
+			// 4. make actual API call for root dir.
+			rootDirQuery := request.ListArtifactsRequest{
+				RunID: run.ID,
+			}
+
+			rootDirResp := response.ListArtifactsResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithQuery(
+					rootDirQuery,
+				).WithResponse(
+					&rootDirResp,
+				).DoRequest(
+					"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsListRoute,
+				),
+			)
+
+			s.Equal(run.ArtifactURI, rootDirResp.RootURI)
+			s.Equal([]response.FilePartialResponse{
+				{
+					Path:     "artifact",
+					IsDir:    true,
+					FileSize: 0,
+				},
+				{
+					Path:     "artifact.txt",
+					IsDir:    false,
+					FileSize: 8,
+				},
+			}, rootDirResp.Files)
+
+			// 5. make actual API call for sub dir.
+			subDirQuery := request.ListArtifactsRequest{
+				RunID: run.ID,
+				Path:  "artifact",
+			}
+
+			subDirResp := response.ListArtifactsResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithQuery(
+					subDirQuery,
+				).WithResponse(
+					&subDirResp,
+				).DoRequest(
+					"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsListRoute,
+				),
+			)
+
+			s.Equal(run.ArtifactURI, subDirResp.RootURI)
+			s.Equal([]response.FilePartialResponse{
+				{
+					Path:     "artifact/artifact.txt",
+					IsDir:    false,
+					FileSize: 9,
+				},
+			}, subDirResp.Files)
+
+			// 6. make actual API call for non-existing dir.
+			nonExistingDirQuery := request.ListArtifactsRequest{
+				RunID: run.ID,
+				Path:  "non-existing-dir",
+			}
+
+			nonExistingDirResp := response.ListArtifactsResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithQuery(
+					nonExistingDirQuery,
+				).WithResponse(
+					&nonExistingDirResp,
+				).DoRequest(
+					"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsListRoute,
+				),
+			)
+
+			s.Equal(run.ArtifactURI, nonExistingDirResp.RootURI)
+			s.Equal(0, len(nonExistingDirResp.Files))
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package experiment
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type SearchDatasetsTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSearchDatasetsTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchDatasetsTestSuite))
+}
+
+func (s *SearchDatasetsTestSuite) Test_Ok() {
+	// 1. prepare database with test data.
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           "Test Experiment",
+		NamespaceID:    s.DefaultNamespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	for _, data := range []struct {
+		experimentID int32
+		runID        string
+		dataset      string
+		contexts     []string
+	}{
+		{experimentID: *s.DefaultExperiment.ID, runID: "id1", dataset: "dataset1", contexts: []string{"training"}},
+		{experimentID: *s.DefaultExperiment.ID, runID: "id2", dataset: "dataset1", contexts: []string{"eval"}},
+		{experimentID: *s.DefaultExperiment.ID, runID: "id3", dataset: "dataset2", contexts: nil},
+		{experimentID: *experiment.ID, runID: "id4", dataset: "dataset3", contexts: []string{"training"}},
+	} {
+		run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             data.runID,
+			ExperimentID:   data.experimentID,
+			SourceType:     "JOB",
+			LifecycleStage: models.LifecycleStageActive,
+			Status:         models.StatusRunning,
+		})
+		s.Require().Nil(err)
+
+		datasetID := fmt.Sprintf("%d-%s", data.experimentID, data.dataset)
+		datasets, err := s.InputFixtures.GetDatasetsByExperimentID(context.Background(), data.experimentID)
+		s.Require().Nil(err)
+		exists := false
+		for _, dataset := range datasets {
+			exists = exists || dataset.ID == datasetID
+		}
+		if !exists {
+			_, err = s.InputFixtures.CreateDataset(context.Background(), &models.Dataset{
+				ID:           datasetID,
+				ExperimentID: data.experimentID,
+				Name:         data.dataset,
+				Digest:       fmt.Sprintf("%s-digest", data.dataset),
+				SourceType:   "local",
+				Source:       "source",
+			})
+			s.Require().Nil(err)
+		}
+
+		input := models.Input{
+			ID:              run.ID,
+			SourceType:      models.InputSourceTypeDataset,
+			SourceID:        datasetID,
+			DestinationType: models.InputDestinationTypeRun,
+			DestinationID:   run.ID,
+		}
+		for _, datasetContext := range data.contexts {
+			input.Tags = append(input.Tags, models.InputTag{
+				InputID: input.ID,
+				Key:     models.InputTagDatasetContext,
+				Value:   datasetContext,
+			})
+		}
+		_, err = s.InputFixtures.CreateInput(context.Background(), &input)
+		s.Require().Nil(err)
+	}
+
+	tests := []struct {
+		name     string
+		request  request.SearchDatasetsRequest
+		response *response.SearchDatasetsResponse
+	}{
+		{
+			name: "SearchDefaultExperiment",
+			request: request.SearchDatasetsRequest{
+				ExperimentIDs: []string{fmt.Sprintf("%d", *s.DefaultExperiment.ID)},
+			},
+			response: &response.SearchDatasetsResponse{
+				DatasetSummaries: []response.DatasetSummaryPartialResponse{
+					{
+						ExperimentID: fmt.Sprintf("%d", *s.DefaultExperiment.ID),
+						Name:         "dataset1",
+						Digest:       "dataset1-digest",
+						Context:      "eval",
+					},
+					{
+						ExperimentID: fmt.Sprintf("%d", *s.DefaultExperiment.ID),
+						Name:         "dataset1",
+						Digest:       "dataset1-digest",
+						Context:      "training",
+					},
+					{
+						ExperimentID: fmt.Sprintf("%d", *s.DefaultExperiment.ID),
+						Name:         "dataset2",
+						Digest:       "dataset2-digest",
+					},
+				},
+			},
+		},
+		{
+			name: "SearchSeveralExperiments",
+			request: request.SearchDatasetsRequest{
+				ExperimentIDs: []string{"0", fmt.Sprintf("%d", *experiment.ID)},
+			},
+			response: &response.SearchDatasetsResponse{
+				DatasetSummaries: []response.DatasetSummaryPartialResponse{
+					{
+						ExperimentID: fmt.Sprintf("%d", *s.DefaultExperiment.ID),
+						Name:         "dataset1",
+						Digest:       "dataset1-digest",
+						Context:      "eval",
+					},
+					{
+						ExperimentID: fmt.Sprintf("%d", *s.DefaultExperiment.ID),
+						Name:         "dataset1",
+						Digest:       "dataset1-digest",
+						Context:      "training",
+					},
+					{
+						ExperimentID: fmt.Sprintf("%d", *s.DefaultExperiment.ID),
+						Name:         "dataset2",
+						Digest:       "dataset2-digest",
+					},
+					{
+						ExperimentID: fmt.Sprintf("%d", *experiment.ID),
+						Name:         "dataset3",
+						Digest:       "dataset3-digest",
+						Context:      "training",
+					},
+				},
+			},
+		},
+		{
+			name: "SearchExperimentWithoutDatasets",
+			request: request.SearchDatasetsRequest{
+				ExperimentIDs: []string{"123456"},
+			},
+			response: &response.SearchDatasetsResponse{
+				DatasetSummaries: []response.DatasetSummaryPartialResponse{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := response.SearchDatasetsResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsSearchDatasetsRoute,
+				),
+			)
+			s.Equal(tt.response, &resp)
+		})
+	}
+}
+
+func (s *SearchDatasetsTestSuite) Test_Error() {
+	tests := []struct {
+		name    string
+		request request.SearchDatasetsRequest
+		error   *api.ErrorResponse
+	}{
+		{
+			name:    "MissingExperimentIDs",
+			request: request.SearchDatasetsRequest{},
+			error: api.NewInvalidParameterValueError(
+				"Missing value for required parameter 'experiment_ids'",
+			),
+		},
+		{
+			name: "InvalidExperimentID",
+			request: request.SearchDatasetsRequest{
+				ExperimentIDs: []string{"invalid"},
+			},
+			error: api.NewBadRequestError(
+				`unable to parse experiment id 'invalid': strconv.ParseInt: parsing "invalid": invalid syntax`,
+			),
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsSearchDatasetsRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
@@ -2,6 +2,8 @@ package experiment
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -133,6 +135,92 @@ func (s *SearchExperimentsTestSuite) Test_Ok() {
 	}
 }
 
+func (s *SearchExperimentsTestSuite) Test_Pagination_Ok() {
+	// 1. prepare database with test data. Experiments 2 and 3 have the same creation time,
+	// experiments 1 and 4 don't have the last update time.
+	for n, creationTime := range []int64{1, 2, 2, 3, 4} {
+		lastUpdateTime := sql.NullInt64{Int64: int64(10 - n), Valid: n != 0 && n != 3}
+		_, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+			Name:           fmt.Sprintf("Test Experiment %d", n+1),
+			NamespaceID:    s.DefaultNamespace.ID,
+			LifecycleStage: models.LifecycleStageActive,
+			CreationTime:   sql.NullInt64{Int64: creationTime, Valid: true},
+			LastUpdateTime: lastUpdateTime,
+		})
+		s.Require().Nil(err)
+	}
+
+	tests := []struct {
+		name     string
+		orderBy  []string
+		expected []string
+	}{
+		{
+			name: "DefaultOrder",
+			expected: []string{
+				"Test Experiment 5",
+				"Test Experiment 4",
+				"Test Experiment 2",
+				"Test Experiment 3",
+				"Test Experiment 1",
+			},
+		},
+		{
+			name:    "OrderByNameDesc",
+			orderBy: []string{"name DESC"},
+			expected: []string{
+				"Test Experiment 5",
+				"Test Experiment 4",
+				"Test Experiment 3",
+				"Test Experiment 2",
+				"Test Experiment 1",
+			},
+		},
+		{
+			name:    "OrderByLastUpdateTimeWithMissingValues",
+			orderBy: []string{"last_update_time ASC"},
+			expected: []string{
+				"Test Experiment 5",
+				"Test Experiment 3",
+				"Test Experiment 2",
+				"Test Experiment 1",
+				"Test Experiment 4",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var names []string
+			pageToken := ""
+			for {
+				resp := response.SearchExperimentsResponse{}
+				s.Require().Nil(
+					s.MlflowClient().WithQuery(
+						request.SearchExperimentsRequest{
+							OrderBy:    tt.orderBy,
+							MaxResults: 2,
+							PageToken:  pageToken,
+						},
+					).WithResponse(
+						&resp,
+					).DoRequest(
+						"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsSearchRoute,
+					),
+				)
+				for _, exp := range resp.Experiments {
+					names = append(names, exp.Name)
+				}
+				if resp.NextPageToken == "" {
+					break
+				}
+				pageToken = resp.NextPageToken
+			}
+			s.Equal(tt.expected, names)
+		})
+	}
+}
+
 func (s *SearchExperimentsTestSuite) Test_Error() {
 	testData := []struct {
 		name    string
@@ -161,8 +249,10 @@ func (s *SearchExperimentsTestSuite) Test_Error() {
 			},
 		},
 		{
-			name:  "MalformedFilter",
-			error: api.NewInvalidParameterValueError("malformed filter 'invalid_filter'"),
+			name: "MalformedFilter",
+			error: api.NewInvalidParameterValueError(
+				"malformed filter 'invalid_filter': expected comparison operator but found end of filter at position 15",
+			),
 			request: request.SearchExperimentsRequest{
 				Filter: "invalid_filter",
 			},
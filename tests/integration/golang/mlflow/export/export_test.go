@@ -0,0 +1,428 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type ExportTestSuite struct {
+	helpers.BaseTestSuite
+	experimentID string
+}
+
+func TestExportTestSuite(t *testing.T) {
+	suite.Run(t, new(ExportTestSuite))
+}
+
+func (s *ExportTestSuite) SetupTest() {
+	s.BaseTestSuite.SetupTest()
+	s.experimentID = fmt.Sprintf("%d", *s.DefaultExperiment.ID)
+
+	for n := 1; n <= 2; n++ {
+		_, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             fmt.Sprintf("id%d", n),
+			Name:           fmt.Sprintf("TestRun%d", n),
+			UserID:         "1",
+			Status:         models.StatusRunning,
+			SourceType:     "JOB",
+			ExperimentID:   *s.DefaultExperiment.ID,
+			LifecycleStage: models.LifecycleStageActive,
+			ArtifactURI:    "artifact_uri",
+			StartTime:      sql.NullInt64{Int64: int64(n), Valid: true},
+		})
+		s.Require().Nil(err)
+
+		for step := int64(1); step <= 2; step++ {
+			_, err := s.MetricFixtures.CreateMetricWithLatestMetric(context.Background(), &models.Metric{
+				Key:       "metric1",
+				Value:     float64(n) + float64(step)/10,
+				Timestamp: step,
+				Step:      step,
+				RunID:     fmt.Sprintf("id%d", n),
+			})
+			s.Require().Nil(err)
+		}
+	}
+}
+
+func (s *ExportTestSuite) Test_Ok() {
+	tests := []struct {
+		name    string
+		request request.CreateExportRequest
+		format  string
+		rows    int
+		check   func(data []byte)
+	}{
+		{
+			name: "ExportRunsToDestination",
+			request: request.CreateExportRequest{
+				ExportRunsRequest: request.ExportRunsRequest{
+					ExperimentIDs: []string{s.experimentID},
+					Columns:       []string{"run_id", "run_name"},
+					Format:        request.ExportFormatCSV,
+				},
+				Table:       request.ExportTableRuns,
+				Destination: "daily/runs",
+			},
+			format: "csv",
+			rows:   2,
+			check: func(data []byte) {
+				records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+				s.Require().Nil(err)
+				s.Equal([][]string{{"run_id", "run_name"}, {"id2", "TestRun2"}, {"id1", "TestRun1"}}, records)
+			},
+		},
+		{
+			name: "ExportLatestMetrics",
+			request: request.CreateExportRequest{
+				ExportRunsRequest: request.ExportRunsRequest{
+					Query:  "run.name == 'TestRun1'",
+					Format: request.ExportFormatParquet,
+				},
+				Table: request.ExportTableLatestMetrics,
+			},
+			format: "parquet",
+			rows:   1,
+			check: func(data []byte) {
+				columns, rows, err := helpers.DecodeParquetTable(data)
+				s.Require().Nil(err)
+				s.Equal([]string{"run_id", "metrics.metric1"}, columns)
+				s.Equal([]map[string]any{{"run_id": "id1", "metrics.metric1": 1.2}}, rows)
+			},
+		},
+		{
+			name: "ExportMetrics",
+			request: request.CreateExportRequest{
+				ExportRunsRequest: request.ExportRunsRequest{
+					ExperimentIDs: []string{s.experimentID},
+					Filter:        "attributes.run_id = 'id2'",
+					Columns:       []string{"run_id", "step", "value"},
+				},
+				Table: request.ExportTableMetrics,
+			},
+			format: "arrow",
+			rows:   2,
+			check: func(data []byte) {
+				columns, rows, err := helpers.DecodeArrowTable(bytes.NewBuffer(data))
+				s.Require().Nil(err)
+				s.Equal([]string{"run_id", "step", "value"}, columns)
+				s.Equal([]map[string]any{
+					{"run_id": "id2", "step": int64(1), "value": 2.1},
+					{"run_id": "id2", "step": int64(2), "value": 2.2},
+				}, rows)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := response.CreateExportResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.ExportsRoutePrefix, mlflow.ExportsCreateRoute,
+				),
+			)
+			s.NotEmpty(resp.Export.ID)
+			s.Equal(string(tt.request.Table), resp.Export.Table)
+			s.Equal(tt.format, resp.Export.Format)
+			name := fmt.Sprintf("%s.%s", resp.Export.ID, tt.format)
+			// the file is written to the exports directory of the namespace in the default artifact root.
+			if tt.request.Destination != "" {
+				s.True(strings.HasSuffix(resp.Export.URI, "/exports/default/"+tt.request.Destination+"/"+name))
+			} else {
+				s.True(strings.HasSuffix(resp.Export.URI, "/exports/default/"+name))
+			}
+			s.NotZero(resp.Export.CreationTime)
+
+			export := s.waitForExport(resp.Export.ID)
+			s.Equal(string(models.ExportStatusCompleted), export.Status)
+			s.Empty(export.ErrorMessage)
+			s.Equal(tt.rows, export.Rows)
+			s.NotZero(export.FinishTime)
+
+			// the result of the export is kept by the database.
+			stored, err := s.ExportFixtures.GetExport(context.Background(), s.DefaultNamespace.ID, export.ID)
+			s.Require().Nil(err)
+			s.Require().NotNil(stored)
+			s.Equal(models.ExportStatusCompleted, stored.Status)
+			s.Equal(tt.rows, stored.Rows)
+			s.Equal(export.URI, stored.URI)
+
+			data, err := os.ReadFile(strings.TrimPrefix(export.URI, "file://"))
+			s.Require().Nil(err)
+			tt.check(data)
+		})
+	}
+}
+
+func (s *ExportTestSuite) Test_CustomNamespace_Ok() {
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		Code:                "custom",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+
+	resp := response.CreateExportResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.CreateExportRequest{
+				ExportRunsRequest: request.ExportRunsRequest{ExperimentIDs: []string{s.experimentID}},
+				Table:             request.ExportTableRuns,
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.ExportsRoutePrefix, mlflow.ExportsCreateRoute,
+		),
+	)
+
+	// the export is not visible in the other namespace.
+	errResp := api.ErrorResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithNamespace(
+			namespace.Code,
+		).WithQuery(
+			request.GetExportRequest{ID: resp.Export.ID},
+		).WithResponse(
+			&errResp,
+		).DoRequest(
+			"%s%s", mlflow.ExportsRoutePrefix, mlflow.ExportsGetRoute,
+		),
+	)
+	s.Equal(
+		api.NewResourceDoesNotExistError("unable to find export '%s'", resp.Export.ID).Error(),
+		errResp.Error(),
+	)
+
+	// the runs of the experiment from the other namespace are not exported.
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithNamespace(
+			namespace.Code,
+		).WithRequest(
+			request.CreateExportRequest{
+				ExportRunsRequest: request.ExportRunsRequest{ExperimentIDs: []string{s.experimentID}},
+				Table:             request.ExportTableRuns,
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.ExportsRoutePrefix, mlflow.ExportsCreateRoute,
+		),
+	)
+	export := s.waitForExport(resp.Export.ID, namespace.Code)
+	s.Equal(string(models.ExportStatusCompleted), export.Status)
+	s.Zero(export.Rows)
+}
+
+func (s *ExportTestSuite) Test_Error() {
+	tests := []struct {
+		name    string
+		request request.CreateExportRequest
+		error   *api.ErrorResponse
+	}{
+		{
+			name: "MissingTable",
+			request: request.CreateExportRequest{
+				ExportRunsRequest: request.ExportRunsRequest{ExperimentIDs: []string{s.experimentID}},
+			},
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'table'"),
+		},
+		{
+			name: "IncorrectTable",
+			request: request.CreateExportRequest{
+				ExportRunsRequest: request.ExportRunsRequest{ExperimentIDs: []string{s.experimentID}},
+				Table:             "params",
+			},
+			error: api.NewInvalidParameterValueError("Invalid table 'params'"),
+		},
+		{
+			name: "IncorrectFormat",
+			request: request.CreateExportRequest{
+				ExportRunsRequest: request.ExportRunsRequest{
+					ExperimentIDs: []string{s.experimentID},
+					Format:        "xlsx",
+				},
+				Table: request.ExportTableMetrics,
+			},
+			error: api.NewInvalidParameterValueError("Invalid format 'xlsx'"),
+		},
+		{
+			name: "MissingExperimentIDs",
+			request: request.CreateExportRequest{
+				Table: request.ExportTableRuns,
+			},
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'experiment_ids'"),
+		},
+		{
+			name: "IncorrectColumn",
+			request: request.CreateExportRequest{
+				ExportRunsRequest: request.ExportRunsRequest{
+					ExperimentIDs: []string{s.experimentID},
+					Columns:       []string{"iter"},
+				},
+				Table: request.ExportTableMetrics,
+			},
+			error: api.NewInvalidParameterValueError(
+				"invalid column 'iter'. Valid values are ['run_id', 'key', 'step', 'timestamp', 'value', 'context']",
+			),
+		},
+		{
+			name: "DestinationURI",
+			request: request.CreateExportRequest{
+				ExportRunsRequest: request.ExportRunsRequest{ExperimentIDs: []string{s.experimentID}},
+				Table:             request.ExportTableRuns,
+				Destination:       "ftp://bucket/exports",
+			},
+			error: api.NewInvalidParameterValueError(
+				"Invalid destination 'ftp://bucket/exports'. It has to be a relative path in the exports directory",
+			),
+		},
+		{
+			name: "DestinationOutsideNamespace",
+			request: request.CreateExportRequest{
+				ExportRunsRequest: request.ExportRunsRequest{ExperimentIDs: []string{s.experimentID}},
+				Table:             request.ExportTableRuns,
+				Destination:       "../custom",
+			},
+			error: api.NewInvalidParameterValueError(
+				"Invalid destination '../custom'. It has to be a relative path in the exports directory",
+			),
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.ExportsRoutePrefix, mlflow.ExportsCreateRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
+
+func (s *ExportTestSuite) Test_Get_Ok() {
+	// the export was created by another instance, which keeps it in the same database.
+	export, err := s.ExportFixtures.CreateExport(context.Background(), &models.Export{
+		ID:           "8a0c3b0f4f9b4cbf9d1f6b3b4d0c2a11",
+		NamespaceID:  s.DefaultNamespace.ID,
+		Table:        string(request.ExportTableRuns),
+		Format:       string(request.ExportFormatCSV),
+		URI:          "s3://bucket/exports/default/8a0c3b0f4f9b4cbf9d1f6b3b4d0c2a11.csv",
+		Status:       models.ExportStatusFailed,
+		Error:        "error uploading export",
+		CreationTime: 1,
+		FinishTime:   2,
+	})
+	s.Require().Nil(err)
+
+	resp := response.GetExportResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithQuery(
+			request.GetExportRequest{ID: export.ID},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.ExportsRoutePrefix, mlflow.ExportsGetRoute,
+		),
+	)
+	s.Equal(export.ID, resp.Export.ID)
+	s.Equal(export.Table, resp.Export.Table)
+	s.Equal(export.Format, resp.Export.Format)
+	s.Equal(export.URI, resp.Export.URI)
+	s.Equal(string(models.ExportStatusFailed), resp.Export.Status)
+	s.Equal(export.Error, resp.Export.ErrorMessage)
+	s.Equal(export.CreationTime, resp.Export.CreationTime)
+	s.Equal(export.FinishTime, resp.Export.FinishTime)
+}
+
+func (s *ExportTestSuite) Test_Get_Error() {
+	tests := []struct {
+		name    string
+		request request.GetExportRequest
+		error   *api.ErrorResponse
+	}{
+		{
+			name:    "MissingExportID",
+			request: request.GetExportRequest{},
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'export_id'"),
+		},
+		{
+			name:    "NotFoundExport",
+			request: request.GetExportRequest{ID: "not_found"},
+			error:   api.NewResourceDoesNotExistError("unable to find export 'not_found'"),
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithQuery(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.ExportsRoutePrefix, mlflow.ExportsGetRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
+
+// waitForExport waits until the export is finished and returns it.
+func (s *ExportTestSuite) waitForExport(id string, namespace ...string) response.ExportPartialResponse {
+	client := s.MlflowClient()
+	if len(namespace) > 0 {
+		client = client.WithNamespace(namespace[0])
+	}
+
+	resp := response.GetExportResponse{}
+	s.Eventually(func() bool {
+		s.Require().Nil(
+			client.WithQuery(
+				request.GetExportRequest{ID: id},
+			).WithResponse(
+				&resp,
+			).DoRequest(
+				"%s%s", mlflow.ExportsRoutePrefix, mlflow.ExportsGetRoute,
+			),
+		)
+		return resp.Export.Status != string(models.ExportStatusRunning)
+	}, 5*time.Second, 50*time.Millisecond)
+	return resp.Export
+}
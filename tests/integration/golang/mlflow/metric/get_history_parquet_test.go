@@ -0,0 +1,187 @@
+package metric
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type GetHistoryParquetTestSuite struct {
+	helpers.BaseTestSuite
+	metricStoreDir string
+}
+
+func TestGetHistoryParquetTestSuite(t *testing.T) {
+	testSuite := new(GetHistoryParquetTestSuite)
+	testSuite.metricStoreDir = t.TempDir()
+	testSuite.Config = config.Config{
+		MetricStore:       "parquet",
+		MetricStoreURI:    "file://" + testSuite.metricStoreDir,
+		RunPurgeInterval:  100 * time.Millisecond,
+		RunPurgeChunkSize: 10,
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *GetHistoryParquetTestSuite) Test_Ok() {
+	run := s.createRun(*s.DefaultExperiment.ID)
+	s.logMetrics("default", run.ID)
+
+	// metric history is kept by the store, only the latest metric is kept by the database.
+	metrics, err := s.MetricFixtures.GetMetricsByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Empty(metrics)
+	latestMetric, err := s.MetricFixtures.GetLatestMetricByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Equal(int64(3), latestMetric.LastIter)
+	segments, err := os.ReadDir(filepath.Join(s.metricStoreDir, run.ID))
+	s.Require().Nil(err)
+	s.Len(segments, 3)
+
+	resp := response.GetMetricHistoryResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithQuery(
+			request.GetMetricHistoryRequest{
+				RunID:     run.ID,
+				MetricKey: "key1",
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.MetricsRoutePrefix, mlflow.MetricsGetHistoryRoute,
+		),
+	)
+	s.Require().Len(resp.Metrics, 3)
+	for i, metric := range resp.Metrics {
+		s.Equal("key1", metric.Key)
+		s.Equal(int64(i+1), metric.Step)
+		s.Equal(float64(i+1)+0.1, metric.Value)
+	}
+
+	// metrics are removed from the store once the deleted run is purged.
+	var deleteResp fiber.Map
+	s.Require().Nil(
+		s.AIMClient().WithMethod(
+			http.MethodDelete,
+		).WithResponse(
+			&deleteResp,
+		).DoRequest(
+			"/runs/%s", run.ID,
+		),
+	)
+	s.Eventually(func() bool {
+		_, err := os.Stat(filepath.Join(s.metricStoreDir, run.ID))
+		return os.IsNotExist(err)
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func (s *GetHistoryParquetTestSuite) Test_DeleteExperiment_Ok() {
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           "Test Experiment",
+		NamespaceID:    s.DefaultNamespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+	run := s.createRun(*experiment.ID)
+	s.logMetrics("default", run.ID)
+
+	// metrics are removed from the store together with the experiment.
+	var resp fiber.Map
+	s.Require().Nil(
+		s.AIMClient().WithMethod(
+			http.MethodDelete,
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"/experiments/%d", *experiment.ID,
+		),
+	)
+	_, err = os.Stat(filepath.Join(s.metricStoreDir, run.ID))
+	s.True(os.IsNotExist(err))
+}
+
+func (s *GetHistoryParquetTestSuite) Test_DeleteNamespace_Ok() {
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		Code:                "parquet",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           "Test Experiment",
+		NamespaceID:    namespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+	run := s.createRun(*experiment.ID)
+	s.logMetrics(namespace.Code, run.ID)
+
+	// metrics are removed from the store together with the namespace.
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodDelete,
+		).DoRequest(
+			"/namespaces/%d", namespace.ID,
+		),
+	)
+	_, err = os.Stat(filepath.Join(s.metricStoreDir, run.ID))
+	s.True(os.IsNotExist(err))
+}
+
+// createRun creates a new active run of the experiment.
+func (s *GetHistoryParquetTestSuite) createRun(experimentID int32) *models.Run {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   experimentID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+	return run
+}
+
+// logMetrics logs the metrics of the run by several requests, so the run has several segments.
+func (s *GetHistoryParquetTestSuite) logMetrics(namespace, runID string) {
+	for step := int64(1); step <= 3; step++ {
+		resp := map[string]any{}
+		s.Require().Nil(
+			s.MlflowClient().WithNamespace(
+				namespace,
+			).WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				&request.LogBatchRequest{
+					RunID: runID,
+					Metrics: []request.MetricPartialRequest{
+						{
+							Key:       "key1",
+							Value:     float64(step) + 0.1,
+							Timestamp: 1687325991,
+							Step:      step,
+						},
+					},
+				},
+			).WithResponse(
+				&resp,
+			).DoRequest(
+				"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute,
+			),
+		)
+	}
+}
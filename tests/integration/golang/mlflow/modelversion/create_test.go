@@ -0,0 +1,124 @@
+package modelversion
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type CreateModelVersionTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestCreateModelVersionTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateModelVersionTestSuite))
+}
+
+func (s *CreateModelVersionTestSuite) Test_Ok() {
+	registeredModel, err := s.ModelFixtures.CreateRegisteredModel(context.Background(), &models.RegisteredModel{
+		Name:        "TestModel",
+		NamespaceID: s.DefaultNamespace.ID,
+	})
+	s.Require().Nil(err)
+	run, err := s.RunFixtures.CreateExampleRun(context.Background(), s.DefaultExperiment)
+	s.Require().Nil(err)
+
+	// create two versions, so that the version number is incremented.
+	for _, expectedVersion := range []string{"1", "2"} {
+		resp := response.ModelVersionResponse{}
+		s.Require().Nil(
+			s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				request.CreateModelVersionRequest{
+					Name:        "TestModel",
+					Source:      "s3://bucket/model",
+					RunID:       run.ID,
+					Description: "description",
+					Tags: []request.ModelVersionTagPartialRequest{
+						{Key: "key", Value: "value"},
+					},
+				},
+			).WithResponse(
+				&resp,
+			).DoRequest(
+				"%s%s", mlflow.ModelVersionsRoutePrefix, mlflow.ModelVersionsCreateRoute,
+			),
+		)
+		s.Equal("TestModel", resp.ModelVersion.Name)
+		s.Equal(expectedVersion, resp.ModelVersion.Version)
+		s.Equal("s3://bucket/model", resp.ModelVersion.Source)
+		s.Equal(run.ID, resp.ModelVersion.RunID)
+		s.Equal("description", resp.ModelVersion.Description)
+		s.Equal(string(models.ModelVersionStageNone), resp.ModelVersion.CurrentStage)
+		s.Equal(string(models.ModelVersionStatusReady), resp.ModelVersion.Status)
+		s.Equal([]response.ModelVersionTagPartialResponse{{Key: "key", Value: "value"}}, resp.ModelVersion.Tags)
+	}
+
+	versions, err := s.ModelFixtures.GetModelVersions(context.Background(), registeredModel.ID)
+	s.Require().Nil(err)
+	s.Require().Len(versions, 2)
+	s.Equal(int64(1), versions[0].Version)
+	s.Equal(int64(2), versions[1].Version)
+}
+
+func (s *CreateModelVersionTestSuite) Test_Error() {
+	_, err := s.ModelFixtures.CreateRegisteredModel(context.Background(), &models.RegisteredModel{
+		Name:        "TestModel",
+		NamespaceID: s.DefaultNamespace.ID,
+	})
+	s.Require().Nil(err)
+
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request request.CreateModelVersionRequest
+	}{
+		{
+			name:    "EmptySource",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'source'"),
+			request: request.CreateModelVersionRequest{Name: "TestModel"},
+		},
+		{
+			name:    "RegisteredModelNotFound",
+			error:   api.NewResourceDoesNotExistError("Registered Model with name=NotFound not found"),
+			request: request.CreateModelVersionRequest{Name: "NotFound", Source: "source"},
+		},
+		{
+			name:  "RunNotFound",
+			error: api.NewResourceDoesNotExistError("Run with id=unknown not found"),
+			request: request.CreateModelVersionRequest{
+				Name:   "TestModel",
+				Source: "source",
+				RunID:  "unknown",
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.ModelVersionsRoutePrefix, mlflow.ModelVersionsCreateRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
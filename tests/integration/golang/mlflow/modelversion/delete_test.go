@@ -0,0 +1,94 @@
+package modelversion
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type DeleteModelVersionTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestDeleteModelVersionTestSuite(t *testing.T) {
+	suite.Run(t, new(DeleteModelVersionTestSuite))
+}
+
+func (s *DeleteModelVersionTestSuite) Test_Ok() {
+	registeredModel, err := s.ModelFixtures.CreateRegisteredModel(context.Background(), &models.RegisteredModel{
+		Name:        "TestModel",
+		NamespaceID: s.DefaultNamespace.ID,
+	})
+	s.Require().Nil(err)
+	for _, version := range []int64{1, 2} {
+		_, err = s.ModelFixtures.CreateModelVersion(context.Background(), &models.ModelVersion{
+			Version:           version,
+			RegisteredModelID: registeredModel.ID,
+			CurrentStage:      models.ModelVersionStageNone,
+			Status:            models.ModelVersionStatusReady,
+			Tags:              []models.ModelVersionTag{{Key: "key", Value: "value"}},
+		})
+		s.Require().Nil(err)
+	}
+	_, err = s.ModelFixtures.CreateRegisteredModelAlias(context.Background(), &models.RegisteredModelAlias{
+		Alias:             "champion",
+		Version:           1,
+		RegisteredModelID: registeredModel.ID,
+	})
+	s.Require().Nil(err)
+
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodDelete,
+		).WithRequest(
+			request.DeleteModelVersionRequest{Name: "TestModel", Version: "1"},
+		).WithResponse(
+			&map[string]any{},
+		).DoRequest(
+			"%s%s", mlflow.ModelVersionsRoutePrefix, mlflow.ModelVersionsDeleteRoute,
+		),
+	)
+
+	versions, err := s.ModelFixtures.GetModelVersions(context.Background(), registeredModel.ID)
+	s.Require().Nil(err)
+	s.Require().Len(versions, 1)
+	s.Equal(int64(2), versions[0].Version)
+	registeredModel, err = s.ModelFixtures.GetRegisteredModelByName(
+		context.Background(), s.DefaultNamespace.ID, "TestModel",
+	)
+	s.Require().Nil(err)
+	s.Empty(registeredModel.Aliases)
+}
+
+func (s *DeleteModelVersionTestSuite) Test_Error() {
+	_, err := s.ModelFixtures.CreateRegisteredModel(context.Background(), &models.RegisteredModel{
+		Name:        "TestModel",
+		NamespaceID: s.DefaultNamespace.ID,
+	})
+	s.Require().Nil(err)
+
+	resp := api.ErrorResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodDelete,
+		).WithRequest(
+			request.DeleteModelVersionRequest{Name: "TestModel", Version: "1"},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.ModelVersionsRoutePrefix, mlflow.ModelVersionsDeleteRoute,
+		),
+	)
+	s.Equal(
+		api.NewResourceDoesNotExistError("Model Version (name=TestModel, version=1) not found").Error(),
+		resp.Error(),
+	)
+}
@@ -0,0 +1,117 @@
+package modelversion
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type GetModelVersionTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestGetModelVersionTestSuite(t *testing.T) {
+	suite.Run(t, new(GetModelVersionTestSuite))
+}
+
+func (s *GetModelVersionTestSuite) Test_Ok() {
+	registeredModel, err := s.ModelFixtures.CreateRegisteredModel(context.Background(), &models.RegisteredModel{
+		Name:        "TestModel",
+		NamespaceID: s.DefaultNamespace.ID,
+	})
+	s.Require().Nil(err)
+	modelVersion, err := s.ModelFixtures.CreateModelVersion(context.Background(), &models.ModelVersion{
+		Version:           1,
+		RegisteredModelID: registeredModel.ID,
+		CurrentStage:      models.ModelVersionStageStaging,
+		Status:            models.ModelVersionStatusReady,
+		Source:            "s3://bucket/model",
+		Description:       "description",
+		Tags:              []models.ModelVersionTag{{Key: "key", Value: "value"}},
+	})
+	s.Require().Nil(err)
+
+	resp := response.ModelVersionResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithQuery(
+			request.GetModelVersionRequest{Name: "TestModel", Version: "1"},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.ModelVersionsRoutePrefix, mlflow.ModelVersionsGetRoute,
+		),
+	)
+	s.Equal("TestModel", resp.ModelVersion.Name)
+	s.Equal("1", resp.ModelVersion.Version)
+	s.Equal(modelVersion.CreatedAt.UnixMilli(), resp.ModelVersion.CreationTimestamp)
+	s.Equal(string(models.ModelVersionStageStaging), resp.ModelVersion.CurrentStage)
+	s.Equal("s3://bucket/model", resp.ModelVersion.Source)
+	s.Equal("description", resp.ModelVersion.Description)
+	s.Equal([]response.ModelVersionTagPartialResponse{{Key: "key", Value: "value"}}, resp.ModelVersion.Tags)
+
+	uriResp := response.GetModelVersionDownloadURIResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithQuery(
+			request.GetModelVersionDownloadURIRequest{Name: "TestModel", Version: "1"},
+		).WithResponse(
+			&uriResp,
+		).DoRequest(
+			"%s%s", mlflow.ModelVersionsRoutePrefix, mlflow.ModelVersionsGetDownloadURIRoute,
+		),
+	)
+	s.Equal("s3://bucket/model", uriResp.ArtifactURI)
+}
+
+func (s *GetModelVersionTestSuite) Test_Error() {
+	_, err := s.ModelFixtures.CreateRegisteredModel(context.Background(), &models.RegisteredModel{
+		Name:        "TestModel",
+		NamespaceID: s.DefaultNamespace.ID,
+	})
+	s.Require().Nil(err)
+
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request request.GetModelVersionRequest
+	}{
+		{
+			name:    "EmptyVersion",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'version'"),
+			request: request.GetModelVersionRequest{Name: "TestModel"},
+		},
+		{
+			name:    "RegisteredModelNotFound",
+			error:   api.NewResourceDoesNotExistError("Registered Model with name=NotFound not found"),
+			request: request.GetModelVersionRequest{Name: "NotFound", Version: "1"},
+		},
+		{
+			name:    "ModelVersionNotFound",
+			error:   api.NewResourceDoesNotExistError("Model Version (name=TestModel, version=2) not found"),
+			request: request.GetModelVersionRequest{Name: "TestModel", Version: "2"},
+		},
+	}
+
+	for _, tt := range testData {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithQuery(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.ModelVersionsRoutePrefix, mlflow.ModelVersionsGetRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package modelversion
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type SearchModelVersionsTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSearchModelVersionsTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchModelVersionsTestSuite))
+}
+
+func (s *SearchModelVersionsTestSuite) Test_Ok() {
+	for _, name := range []string{"ModelA", "ModelB"} {
+		registeredModel, err := s.ModelFixtures.CreateRegisteredModel(context.Background(), &models.RegisteredModel{
+			Name:        name,
+			NamespaceID: s.DefaultNamespace.ID,
+		})
+		s.Require().Nil(err)
+		for _, version := range []int64{1, 2} {
+			_, err = s.ModelFixtures.CreateModelVersion(context.Background(), &models.ModelVersion{
+				Version:           version,
+				RegisteredModelID: registeredModel.ID,
+				CurrentStage:      models.ModelVersionStageNone,
+				Status:            models.ModelVersionStatusReady,
+				RunID:             fmt.Sprintf("run%d", version),
+				Source:            fmt.Sprintf("s3://bucket/%s/%d", name, version),
+				Tags:              []models.ModelVersionTag{{Key: "version", Value: fmt.Sprint(version)}},
+			})
+			s.Require().Nil(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		request  request.SearchModelVersionsRequest
+		expected []string
+	}{
+		{
+			name:     "NoFilter",
+			request:  request.SearchModelVersionsRequest{},
+			expected: []string{"ModelA/2", "ModelA/1", "ModelB/2", "ModelB/1"},
+		},
+		{
+			name:     "FilterByName",
+			request:  request.SearchModelVersionsRequest{Filter: "name = 'ModelB'"},
+			expected: []string{"ModelB/2", "ModelB/1"},
+		},
+		{
+			name:     "FilterByRunIDs",
+			request:  request.SearchModelVersionsRequest{Filter: "run_id IN ('run1')"},
+			expected: []string{"ModelA/1", "ModelB/1"},
+		},
+		{
+			name:     "FilterBySourcePath",
+			request:  request.SearchModelVersionsRequest{Filter: "source_path LIKE '%ModelA%'"},
+			expected: []string{"ModelA/2", "ModelA/1"},
+		},
+		{
+			name:     "FilterByTag",
+			request:  request.SearchModelVersionsRequest{Filter: "tags.version = '2' AND name = 'ModelA'"},
+			expected: []string{"ModelA/2"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := response.SearchModelVersionsResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithQuery(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.ModelVersionsRoutePrefix, mlflow.ModelVersionsSearchRoute,
+				),
+			)
+			actual := make([]string, len(resp.ModelVersions))
+			for n, modelVersion := range resp.ModelVersions {
+				actual[n] = fmt.Sprintf("%s/%s", modelVersion.Name, modelVersion.Version)
+			}
+			s.Equal(tt.expected, actual)
+		})
+	}
+}
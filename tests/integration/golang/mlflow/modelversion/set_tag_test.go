@@ -0,0 +1,72 @@
+package modelversion
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type ModelVersionTagTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestModelVersionTagTestSuite(t *testing.T) {
+	suite.Run(t, new(ModelVersionTagTestSuite))
+}
+
+func (s *ModelVersionTagTestSuite) Test_Ok() {
+	registeredModel, err := s.ModelFixtures.CreateRegisteredModel(context.Background(), &models.RegisteredModel{
+		Name:        "TestModel",
+		NamespaceID: s.DefaultNamespace.ID,
+	})
+	s.Require().Nil(err)
+	modelVersion, err := s.ModelFixtures.CreateModelVersion(context.Background(), &models.ModelVersion{
+		Version:           1,
+		RegisteredModelID: registeredModel.ID,
+		CurrentStage:      models.ModelVersionStageNone,
+		Status:            models.ModelVersionStatusReady,
+	})
+	s.Require().Nil(err)
+
+	// 1. set the tag.
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.SetModelVersionTagRequest{Name: "TestModel", Version: "1", Key: "key", Value: "value"},
+		).WithResponse(
+			&map[string]any{},
+		).DoRequest(
+			"%s%s", mlflow.ModelVersionsRoutePrefix, mlflow.ModelVersionsSetTagRoute,
+		),
+	)
+	versions, err := s.ModelFixtures.GetModelVersions(context.Background(), registeredModel.ID)
+	s.Require().Nil(err)
+	s.Require().Len(versions, 1)
+	s.Equal([]models.ModelVersionTag{
+		{Key: "key", Value: "value", ModelVersionID: modelVersion.ID},
+	}, versions[0].Tags)
+
+	// 2. delete the tag.
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodDelete,
+		).WithRequest(
+			request.DeleteModelVersionTagRequest{Name: "TestModel", Version: "1", Key: "key"},
+		).WithResponse(
+			&map[string]any{},
+		).DoRequest(
+			"%s%s", mlflow.ModelVersionsRoutePrefix, mlflow.ModelVersionsDeleteTagRoute,
+		),
+	)
+	versions, err = s.ModelFixtures.GetModelVersions(context.Background(), registeredModel.ID)
+	s.Require().Nil(err)
+	s.Empty(versions[0].Tags)
+}
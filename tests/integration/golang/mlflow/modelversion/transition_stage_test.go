@@ -0,0 +1,111 @@
+package modelversion
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type TransitionModelVersionStageTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestTransitionModelVersionStageTestSuite(t *testing.T) {
+	suite.Run(t, new(TransitionModelVersionStageTestSuite))
+}
+
+func (s *TransitionModelVersionStageTestSuite) Test_Ok() {
+	registeredModel, err := s.ModelFixtures.CreateRegisteredModel(context.Background(), &models.RegisteredModel{
+		Name:        "TestModel",
+		NamespaceID: s.DefaultNamespace.ID,
+	})
+	s.Require().Nil(err)
+	for _, version := range []int64{1, 2} {
+		_, err = s.ModelFixtures.CreateModelVersion(context.Background(), &models.ModelVersion{
+			Version:           version,
+			RegisteredModelID: registeredModel.ID,
+			CurrentStage:      models.ModelVersionStageProduction,
+			Status:            models.ModelVersionStatusReady,
+		})
+		s.Require().Nil(err)
+	}
+	_, err = s.ModelFixtures.CreateModelVersion(context.Background(), &models.ModelVersion{
+		Version:           3,
+		RegisteredModelID: registeredModel.ID,
+		CurrentStage:      models.ModelVersionStageNone,
+		Status:            models.ModelVersionStatusReady,
+	})
+	s.Require().Nil(err)
+
+	resp := response.ModelVersionResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.TransitionModelVersionStageRequest{
+				Name:                    "TestModel",
+				Version:                 "3",
+				Stage:                   "production",
+				ArchiveExistingVersions: true,
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.ModelVersionsRoutePrefix, mlflow.ModelVersionsTransitionStageRoute,
+		),
+	)
+	s.Equal("3", resp.ModelVersion.Version)
+	s.Equal(string(models.ModelVersionStageProduction), resp.ModelVersion.CurrentStage)
+
+	versions, err := s.ModelFixtures.GetModelVersions(context.Background(), registeredModel.ID)
+	s.Require().Nil(err)
+	s.Require().Len(versions, 3)
+	s.Equal(models.ModelVersionStageArchived, versions[0].CurrentStage)
+	s.Equal(models.ModelVersionStageArchived, versions[1].CurrentStage)
+	s.Equal(models.ModelVersionStageProduction, versions[2].CurrentStage)
+
+	latest := response.GetLatestVersionsResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.GetLatestVersionsRequest{Name: "TestModel", Stages: []string{"Production"}},
+		).WithResponse(
+			&latest,
+		).DoRequest(
+			"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsGetLatestVersionsRoute,
+		),
+	)
+	s.Require().Len(latest.ModelVersions, 1)
+	s.Equal("3", latest.ModelVersions[0].Version)
+}
+
+func (s *TransitionModelVersionStageTestSuite) Test_Error() {
+	resp := api.ErrorResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.TransitionModelVersionStageRequest{Name: "TestModel", Version: "1", Stage: "unknown"},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.ModelVersionsRoutePrefix, mlflow.ModelVersionsTransitionStageRoute,
+		),
+	)
+	s.Equal(
+		api.NewInvalidParameterValueError(
+			"Invalid Model Version stage: unknown. Value must be one of [None Staging Production Archived].",
+		).Error(),
+		resp.Error(),
+	)
+}
@@ -0,0 +1,57 @@
+package modelversion
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type UpdateModelVersionTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestUpdateModelVersionTestSuite(t *testing.T) {
+	suite.Run(t, new(UpdateModelVersionTestSuite))
+}
+
+func (s *UpdateModelVersionTestSuite) Test_Ok() {
+	registeredModel, err := s.ModelFixtures.CreateRegisteredModel(context.Background(), &models.RegisteredModel{
+		Name:        "TestModel",
+		NamespaceID: s.DefaultNamespace.ID,
+	})
+	s.Require().Nil(err)
+	_, err = s.ModelFixtures.CreateModelVersion(context.Background(), &models.ModelVersion{
+		Version:           1,
+		RegisteredModelID: registeredModel.ID,
+		CurrentStage:      models.ModelVersionStageNone,
+		Status:            models.ModelVersionStatusReady,
+	})
+	s.Require().Nil(err)
+
+	resp := response.ModelVersionResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPatch,
+		).WithRequest(
+			request.UpdateModelVersionRequest{Name: "TestModel", Version: "1", Description: "new description"},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.ModelVersionsRoutePrefix, mlflow.ModelVersionsUpdateRoute,
+		),
+	)
+	s.Equal("new description", resp.ModelVersion.Description)
+
+	versions, err := s.ModelFixtures.GetModelVersions(context.Background(), registeredModel.ID)
+	s.Require().Nil(err)
+	s.Require().Len(versions, 1)
+	s.Equal("new description", versions[0].Description)
+}
@@ -0,0 +1,126 @@
+package registeredmodel
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type RegisteredModelAliasTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestRegisteredModelAliasTestSuite(t *testing.T) {
+	suite.Run(t, new(RegisteredModelAliasTestSuite))
+}
+
+func (s *RegisteredModelAliasTestSuite) Test_Ok() {
+	registeredModel, err := s.ModelFixtures.CreateRegisteredModel(context.Background(), &models.RegisteredModel{
+		Name:        "TestModel",
+		NamespaceID: s.DefaultNamespace.ID,
+	})
+	s.Require().Nil(err)
+	_, err = s.ModelFixtures.CreateModelVersion(context.Background(), &models.ModelVersion{
+		Version:           1,
+		RegisteredModelID: registeredModel.ID,
+		CurrentStage:      models.ModelVersionStageNone,
+		Status:            models.ModelVersionStatusReady,
+		Source:            "s3://bucket/model",
+	})
+	s.Require().Nil(err)
+
+	// 1. set alias.
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.SetRegisteredModelAliasRequest{Name: "TestModel", Alias: "champion", Version: "1"},
+		).WithResponse(
+			&map[string]any{},
+		).DoRequest(
+			"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsAliasRoute,
+		),
+	)
+
+	// 2. get model version by alias.
+	resp := response.ModelVersionResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithQuery(
+			request.GetModelVersionByAliasRequest{Name: "TestModel", Alias: "champion"},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsAliasRoute,
+		),
+	)
+	s.Equal("TestModel", resp.ModelVersion.Name)
+	s.Equal("1", resp.ModelVersion.Version)
+	s.Equal([]string{"champion"}, resp.ModelVersion.Aliases)
+
+	// 3. delete alias.
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodDelete,
+		).WithRequest(
+			request.DeleteRegisteredModelAliasRequest{Name: "TestModel", Alias: "champion"},
+		).WithResponse(
+			&map[string]any{},
+		).DoRequest(
+			"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsAliasRoute,
+		),
+	)
+	registeredModel, err = s.ModelFixtures.GetRegisteredModelByName(
+		context.Background(), s.DefaultNamespace.ID, "TestModel",
+	)
+	s.Require().Nil(err)
+	s.Empty(registeredModel.Aliases)
+}
+
+func (s *RegisteredModelAliasTestSuite) Test_Error() {
+	_, err := s.ModelFixtures.CreateRegisteredModel(context.Background(), &models.RegisteredModel{
+		Name:        "TestModel",
+		NamespaceID: s.DefaultNamespace.ID,
+	})
+	s.Require().Nil(err)
+
+	resp := api.ErrorResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.SetRegisteredModelAliasRequest{Name: "TestModel", Alias: "champion", Version: "1"},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsAliasRoute,
+		),
+	)
+	s.Equal(
+		api.NewResourceDoesNotExistError("Model Version (name=TestModel, version=1) not found").Error(),
+		resp.Error(),
+	)
+
+	resp = api.ErrorResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithQuery(
+			request.GetModelVersionByAliasRequest{Name: "TestModel", Alias: "unknown"},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsAliasRoute,
+		),
+	)
+	s.Equal(
+		api.NewResourceDoesNotExistError("Registered model alias unknown not found for model TestModel.").Error(),
+		resp.Error(),
+	)
+}
@@ -0,0 +1,106 @@
+package registeredmodel
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type CreateRegisteredModelTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestCreateRegisteredModelTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateRegisteredModelTestSuite))
+}
+
+func (s *CreateRegisteredModelTestSuite) Test_Ok() {
+	req := request.CreateRegisteredModelRequest{
+		Name: "TestModel",
+		Tags: []request.RegisteredModelTagPartialRequest{
+			{
+				Key:   "key1",
+				Value: "value1",
+			},
+		},
+		Description: "description",
+	}
+	resp := response.RegisteredModelResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			req,
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsCreateRoute,
+		),
+	)
+	s.Equal("TestModel", resp.RegisteredModel.Name)
+	s.Equal("description", resp.RegisteredModel.Description)
+	s.NotEmpty(resp.RegisteredModel.CreationTimestamp)
+	s.NotEmpty(resp.RegisteredModel.LastUpdatedTimestamp)
+	s.Equal([]response.RegisteredModelTagPartialResponse{{Key: "key1", Value: "value1"}}, resp.RegisteredModel.Tags)
+
+	registeredModel, err := s.ModelFixtures.GetRegisteredModelByName(
+		context.Background(), s.DefaultNamespace.ID, "TestModel",
+	)
+	s.Require().Nil(err)
+	s.Equal("description", registeredModel.Description)
+	s.Equal([]models.RegisteredModelTag{
+		{Key: "key1", Value: "value1", RegisteredModelID: registeredModel.ID},
+	}, registeredModel.Tags)
+}
+
+func (s *CreateRegisteredModelTestSuite) Test_Error() {
+	_, err := s.ModelFixtures.CreateRegisteredModel(context.Background(), &models.RegisteredModel{
+		Name:        "ExistingModel",
+		NamespaceID: s.DefaultNamespace.ID,
+	})
+	s.Require().Nil(err)
+
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request request.CreateRegisteredModelRequest
+	}{
+		{
+			name:    "EmptyName",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'name'"),
+			request: request.CreateRegisteredModelRequest{},
+		},
+		{
+			name:    "AlreadyExists",
+			error:   api.NewResourceAlreadyExistsError("Registered Model (name=ExistingModel) already exists."),
+			request: request.CreateRegisteredModelRequest{Name: "ExistingModel"},
+		},
+	}
+
+	for _, tt := range testData {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsCreateRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
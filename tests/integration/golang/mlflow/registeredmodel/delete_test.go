@@ -0,0 +1,76 @@
+package registeredmodel
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type DeleteRegisteredModelTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestDeleteRegisteredModelTestSuite(t *testing.T) {
+	suite.Run(t, new(DeleteRegisteredModelTestSuite))
+}
+
+func (s *DeleteRegisteredModelTestSuite) Test_Ok() {
+	registeredModel, err := s.ModelFixtures.CreateRegisteredModel(context.Background(), &models.RegisteredModel{
+		Name:        "TestModel",
+		NamespaceID: s.DefaultNamespace.ID,
+		Tags:        []models.RegisteredModelTag{{Key: "key", Value: "value"}},
+	})
+	s.Require().Nil(err)
+	_, err = s.ModelFixtures.CreateModelVersion(context.Background(), &models.ModelVersion{
+		Version:           1,
+		RegisteredModelID: registeredModel.ID,
+		CurrentStage:      models.ModelVersionStageNone,
+		Status:            models.ModelVersionStatusReady,
+		Tags:              []models.ModelVersionTag{{Key: "key", Value: "value"}},
+	})
+	s.Require().Nil(err)
+
+	resp := map[string]any{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodDelete,
+		).WithRequest(
+			request.DeleteRegisteredModelRequest{Name: "TestModel"},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsDeleteRoute,
+		),
+	)
+	s.Empty(resp)
+
+	_, err = s.ModelFixtures.GetRegisteredModelByName(context.Background(), s.DefaultNamespace.ID, "TestModel")
+	s.Require().NotNil(err)
+	versions, err := s.ModelFixtures.GetModelVersions(context.Background(), registeredModel.ID)
+	s.Require().Nil(err)
+	s.Empty(versions)
+}
+
+func (s *DeleteRegisteredModelTestSuite) Test_Error() {
+	resp := api.ErrorResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodDelete,
+		).WithRequest(
+			request.DeleteRegisteredModelRequest{Name: "NotFound"},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsDeleteRoute,
+		),
+	)
+	s.Equal(api.NewResourceDoesNotExistError("Registered Model with name=NotFound not found").Error(), resp.Error())
+}
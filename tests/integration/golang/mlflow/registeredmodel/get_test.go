@@ -0,0 +1,111 @@
+package registeredmodel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type GetRegisteredModelTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestGetRegisteredModelTestSuite(t *testing.T) {
+	suite.Run(t, new(GetRegisteredModelTestSuite))
+}
+
+func (s *GetRegisteredModelTestSuite) Test_Ok() {
+	registeredModel, err := s.ModelFixtures.CreateRegisteredModel(context.Background(), &models.RegisteredModel{
+		Name:        "TestModel",
+		Description: "description",
+		NamespaceID: s.DefaultNamespace.ID,
+	})
+	s.Require().Nil(err)
+	for _, stage := range []models.ModelVersionStage{
+		models.ModelVersionStageProduction,
+		models.ModelVersionStageProduction,
+		models.ModelVersionStageStaging,
+	} {
+		versions, err := s.ModelFixtures.GetModelVersions(context.Background(), registeredModel.ID)
+		s.Require().Nil(err)
+		_, err = s.ModelFixtures.CreateModelVersion(context.Background(), &models.ModelVersion{
+			Version:           int64(len(versions) + 1),
+			RegisteredModelID: registeredModel.ID,
+			CurrentStage:      stage,
+			Status:            models.ModelVersionStatusReady,
+			Source:            "s3://bucket/model",
+		})
+		s.Require().Nil(err)
+	}
+	_, err = s.ModelFixtures.CreateRegisteredModelAlias(context.Background(), &models.RegisteredModelAlias{
+		Alias:             "champion",
+		Version:           2,
+		RegisteredModelID: registeredModel.ID,
+	})
+	s.Require().Nil(err)
+
+	resp := response.RegisteredModelResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithQuery(
+			request.GetRegisteredModelRequest{Name: "TestModel"},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsGetRoute,
+		),
+	)
+	s.Equal("TestModel", resp.RegisteredModel.Name)
+	s.Equal("description", resp.RegisteredModel.Description)
+	s.Equal([]response.RegisteredModelAliasPartialResponse{
+		{Alias: "champion", Version: "2"},
+	}, resp.RegisteredModel.Aliases)
+	s.Require().Len(resp.RegisteredModel.LatestVersions, 2)
+	s.Equal("3", resp.RegisteredModel.LatestVersions[0].Version)
+	s.Equal(string(models.ModelVersionStageStaging), resp.RegisteredModel.LatestVersions[0].CurrentStage)
+	s.Equal("2", resp.RegisteredModel.LatestVersions[1].Version)
+	s.Equal(string(models.ModelVersionStageProduction), resp.RegisteredModel.LatestVersions[1].CurrentStage)
+	s.Equal([]string{"champion"}, resp.RegisteredModel.LatestVersions[1].Aliases)
+}
+
+func (s *GetRegisteredModelTestSuite) Test_Error() {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request request.GetRegisteredModelRequest
+	}{
+		{
+			name:    "EmptyName",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'name'"),
+			request: request.GetRegisteredModelRequest{},
+		},
+		{
+			name:    "NotFound",
+			error:   api.NewResourceDoesNotExistError("Registered Model with name=NotFound not found"),
+			request: request.GetRegisteredModelRequest{Name: "NotFound"},
+		},
+	}
+
+	for _, tt := range testData {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithQuery(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsGetRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package registeredmodel
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type SearchRegisteredModelsTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSearchRegisteredModelsTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchRegisteredModelsTestSuite))
+}
+
+func (s *SearchRegisteredModelsTestSuite) Test_Ok() {
+	for i := 1; i <= 3; i++ {
+		_, err := s.ModelFixtures.CreateRegisteredModel(context.Background(), &models.RegisteredModel{
+			Name:        fmt.Sprintf("TestModel%d", i),
+			NamespaceID: s.DefaultNamespace.ID,
+			Tags: []models.RegisteredModelTag{
+				{Key: "group", Value: fmt.Sprintf("group%d", i%2)},
+			},
+		})
+		s.Require().Nil(err)
+	}
+
+	tests := []struct {
+		name          string
+		request       request.SearchRegisteredModelsRequest
+		expectedNames []string
+		hasNextPage   bool
+	}{
+		{
+			name:          "NoFilter",
+			request:       request.SearchRegisteredModelsRequest{},
+			expectedNames: []string{"TestModel1", "TestModel2", "TestModel3"},
+		},
+		{
+			name:          "FilterByName",
+			request:       request.SearchRegisteredModelsRequest{Filter: "name = 'TestModel2'"},
+			expectedNames: []string{"TestModel2"},
+		},
+		{
+			name:          "FilterByNameILike",
+			request:       request.SearchRegisteredModelsRequest{Filter: "name ILIKE 'testmodel%'"},
+			expectedNames: []string{"TestModel1", "TestModel2", "TestModel3"},
+		},
+		{
+			name:          "FilterByTag",
+			request:       request.SearchRegisteredModelsRequest{Filter: "tags.group = 'group1'"},
+			expectedNames: []string{"TestModel1", "TestModel3"},
+		},
+		{
+			name:          "MaxResults",
+			request:       request.SearchRegisteredModelsRequest{MaxResults: 2},
+			expectedNames: []string{"TestModel1", "TestModel2"},
+			hasNextPage:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := response.SearchRegisteredModelsResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithQuery(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsSearchRoute,
+				),
+			)
+			names := make([]string, len(resp.RegisteredModels))
+			for n, registeredModel := range resp.RegisteredModels {
+				names[n] = registeredModel.Name
+			}
+			s.Equal(tt.expectedNames, names)
+			s.Equal(tt.hasNextPage, resp.NextPageToken != "")
+		})
+	}
+}
+
+func (s *SearchRegisteredModelsTestSuite) Test_Error() {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request request.SearchRegisteredModelsRequest
+	}{
+		{
+			name: "MalformedFilter",
+			error: api.NewInvalidParameterValueError(
+				"malformed filter 'name is model': expected NULL but found 'model' at position 9",
+			),
+			request: request.SearchRegisteredModelsRequest{Filter: "name is model"},
+		},
+		{
+			name: "UnsupportedAttribute",
+			error: api.NewInvalidParameterValueError(
+				"invalid attribute 'unknown'. Valid values are ['name']",
+			),
+			request: request.SearchRegisteredModelsRequest{Filter: "unknown = 'value'"},
+		},
+	}
+
+	for _, tt := range testData {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithQuery(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsSearchRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
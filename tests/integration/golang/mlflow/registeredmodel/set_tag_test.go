@@ -0,0 +1,70 @@
+package registeredmodel
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type RegisteredModelTagTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestRegisteredModelTagTestSuite(t *testing.T) {
+	suite.Run(t, new(RegisteredModelTagTestSuite))
+}
+
+func (s *RegisteredModelTagTestSuite) Test_Ok() {
+	registeredModel, err := s.ModelFixtures.CreateRegisteredModel(context.Background(), &models.RegisteredModel{
+		Name:        "TestModel",
+		NamespaceID: s.DefaultNamespace.ID,
+	})
+	s.Require().Nil(err)
+
+	// 1. set and then overwrite the tag.
+	for _, value := range []string{"value1", "value2"} {
+		s.Require().Nil(
+			s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				request.SetRegisteredModelTagRequest{Name: "TestModel", Key: "key", Value: value},
+			).WithResponse(
+				&map[string]any{},
+			).DoRequest(
+				"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsSetTagRoute,
+			),
+		)
+	}
+	registeredModel, err = s.ModelFixtures.GetRegisteredModelByName(
+		context.Background(), s.DefaultNamespace.ID, "TestModel",
+	)
+	s.Require().Nil(err)
+	s.Equal([]models.RegisteredModelTag{
+		{Key: "key", Value: "value2", RegisteredModelID: registeredModel.ID},
+	}, registeredModel.Tags)
+
+	// 2. delete the tag.
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodDelete,
+		).WithRequest(
+			request.DeleteRegisteredModelTagRequest{Name: "TestModel", Key: "key"},
+		).WithResponse(
+			&map[string]any{},
+		).DoRequest(
+			"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsDeleteTagRoute,
+		),
+	)
+	registeredModel, err = s.ModelFixtures.GetRegisteredModelByName(
+		context.Background(), s.DefaultNamespace.ID, "TestModel",
+	)
+	s.Require().Nil(err)
+	s.Empty(registeredModel.Tags)
+}
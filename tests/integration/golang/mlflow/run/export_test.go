@@ -0,0 +1,473 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type ExportTestSuite struct {
+	helpers.BaseTestSuite
+	experimentID string
+}
+
+func TestExportTestSuite(t *testing.T) {
+	suite.Run(t, new(ExportTestSuite))
+}
+
+func (s *ExportTestSuite) SetupTest() {
+	s.BaseTestSuite.SetupTest()
+	s.experimentID = fmt.Sprintf("%d", *s.DefaultExperiment.ID)
+
+	for n, lifecycleStage := range []models.LifecycleStage{
+		models.LifecycleStageActive, models.LifecycleStageActive, models.LifecycleStageDeleted,
+	} {
+		_, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             fmt.Sprintf("id%d", n+1),
+			Name:           fmt.Sprintf("TestRun%d", n+1),
+			UserID:         "1",
+			Status:         models.StatusRunning,
+			SourceType:     "JOB",
+			ExperimentID:   *s.DefaultExperiment.ID,
+			LifecycleStage: lifecycleStage,
+			ArtifactURI:    "artifact_uri",
+			StartTime:      sql.NullInt64{Int64: int64(n + 1), Valid: true},
+		})
+		s.Require().Nil(err)
+	}
+
+	value := "value1"
+	_, err := s.ParamFixtures.CreateParam(context.Background(), &models.Param{
+		Key:      "param1",
+		ValueStr: &value,
+		RunID:    "id1",
+	})
+	s.Require().Nil(err)
+	_, err = s.TagFixtures.CreateTag(context.Background(), &models.Tag{
+		Key:   "tag1",
+		Value: "value1",
+		RunID: "id2",
+	})
+	s.Require().Nil(err)
+
+	// metric1 of id1 is logged with two contexts, the latest step is exported.
+	for _, metric := range []models.Metric{
+		{
+			Key:       "metric1",
+			Value:     1.1,
+			Timestamp: 1,
+			Step:      2,
+			RunID:     "id1",
+			Context:   models.Context{Json: types.JSONB(`{"subset": "train"}`)},
+		},
+		{
+			Key:       "metric1",
+			Value:     2.2,
+			Timestamp: 2,
+			Step:      1,
+			RunID:     "id1",
+			Context:   models.Context{Json: types.JSONB(`{"subset": "test"}`)},
+		},
+		{
+			Key:       "metric1",
+			Timestamp: 1,
+			Step:      1,
+			IsNan:     true,
+			RunID:     "id2",
+		},
+		{
+			Key:       "metric2",
+			Value:     3.3,
+			Timestamp: 1,
+			Step:      1,
+			RunID:     "id2",
+		},
+	} {
+		metric := metric
+		_, err := s.MetricFixtures.CreateMetricWithLatestMetric(context.Background(), &metric)
+		s.Require().Nil(err)
+	}
+}
+
+func (s *ExportTestSuite) Test_Ok() {
+	tests := []struct {
+		name    string
+		request request.ExportRunsRequest
+		columns []string
+		rows    []map[string]any
+	}{
+		{
+			name:    "ExportAllColumns",
+			request: request.ExportRunsRequest{ExperimentIDs: []string{s.experimentID}},
+			columns: []string{
+				"run_id",
+				"experiment_id",
+				"run_name",
+				"status",
+				"user_id",
+				"start_time",
+				"end_time",
+				"lifecycle_stage",
+				"artifact_uri",
+				"metrics.metric1",
+				"metrics.metric2",
+				"params.param1",
+				"tags.tag1",
+			},
+			rows: []map[string]any{
+				{
+					"run_id":          "id2",
+					"experiment_id":   s.experimentID,
+					"run_name":        "TestRun2",
+					"status":          "RUNNING",
+					"user_id":         "1",
+					"start_time":      int64(2),
+					"end_time":        nil,
+					"lifecycle_stage": "active",
+					"artifact_uri":    "artifact_uri",
+					"metrics.metric1": nil,
+					"metrics.metric2": 3.3,
+					"params.param1":   nil,
+					"tags.tag1":       "value1",
+				},
+				{
+					"run_id":          "id1",
+					"experiment_id":   s.experimentID,
+					"run_name":        "TestRun1",
+					"status":          "RUNNING",
+					"user_id":         "1",
+					"start_time":      int64(1),
+					"end_time":        nil,
+					"lifecycle_stage": "active",
+					"artifact_uri":    "artifact_uri",
+					"metrics.metric1": 1.1,
+					"metrics.metric2": nil,
+					"params.param1":   "value1",
+					"tags.tag1":       nil,
+				},
+			},
+		},
+		{
+			name: "ExportRequestedColumnsOfAllRuns",
+			request: request.ExportRunsRequest{
+				ExperimentIDs: []string{"0"},
+				ViewType:      request.ViewTypeAll,
+				Columns:       []string{"run_id", "metrics.metric2", "params.*"},
+			},
+			columns: []string{"run_id", "metrics.metric2", "params.param1"},
+			rows: []map[string]any{
+				{"run_id": "id3", "metrics.metric2": nil, "params.param1": nil},
+				{"run_id": "id2", "metrics.metric2": 3.3, "params.param1": nil},
+				{"run_id": "id1", "metrics.metric2": nil, "params.param1": "value1"},
+			},
+		},
+		{
+			name: "ExportFilteredRuns",
+			request: request.ExportRunsRequest{
+				ExperimentIDs: []string{s.experimentID},
+				Filter:        "tags.tag1 = 'value1'",
+				Columns:       []string{"run_id", "tags.tag1"},
+			},
+			columns: []string{"run_id", "tags.tag1"},
+			rows: []map[string]any{
+				{"run_id": "id2", "tags.tag1": "value1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			columns, rows := s.export(mlflow.RunsExportRoute, tt.request)
+			s.Equal(tt.columns, columns)
+			s.Equal(tt.rows, rows)
+		})
+	}
+}
+
+func (s *ExportTestSuite) Test_Metrics_Ok() {
+	tests := []struct {
+		name    string
+		request request.ExportRunsRequest
+		columns []string
+		rows    []map[string]any
+	}{
+		{
+			name: "ExportAllColumns",
+			request: request.ExportRunsRequest{
+				ExperimentIDs: []string{s.experimentID},
+				Filter:        "attributes.run_id = 'id2'",
+			},
+			columns: []string{"run_id", "key", "step", "timestamp", "value", "context"},
+			rows: []map[string]any{
+				{"run_id": "id2", "key": "metric1", "step": int64(1), "timestamp": int64(1), "value": nil, "context": "{}"},
+				{"run_id": "id2", "key": "metric2", "step": int64(1), "timestamp": int64(1), "value": 3.3, "context": "{}"},
+			},
+		},
+		{
+			name: "ExportRequestedColumnsAndMetricKeys",
+			request: request.ExportRunsRequest{
+				ExperimentIDs: []string{s.experimentID},
+				Columns:       []string{"run_id", "value"},
+				MetricKeys:    []string{"metric2"},
+			},
+			columns: []string{"run_id", "value"},
+			rows: []map[string]any{
+				{"run_id": "id2", "value": 3.3},
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			columns, rows := s.export(mlflow.RunsExportMetricsRoute, tt.request)
+			s.Equal(tt.columns, columns)
+			s.Equal(tt.rows, rows)
+		})
+	}
+}
+
+func (s *ExportTestSuite) Test_Query_Ok() {
+	tests := []struct {
+		name  string
+		query string
+		rows  []map[string]any
+	}{
+		{
+			name:  "SelectRunsByName",
+			query: "run.name == 'TestRun1'",
+			rows:  []map[string]any{{"run_id": "id1"}},
+		},
+		{
+			name:  "SelectRunsByMetric",
+			query: "run.metrics['metric2'].last > 3",
+			rows:  []map[string]any{{"run_id": "id2"}},
+		},
+		{
+			name:  "SelectArchivedRuns",
+			query: "run.archived",
+			rows:  []map[string]any{{"run_id": "id3"}},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			columns, rows := s.export(mlflow.RunsExportRoute, request.ExportRunsRequest{
+				Query:   tt.query,
+				Columns: []string{"run_id"},
+			})
+			s.Equal([]string{"run_id"}, columns)
+			s.Equal(tt.rows, rows)
+		})
+	}
+}
+
+func (s *ExportTestSuite) Test_Formats_Ok() {
+	req := request.ExportRunsRequest{
+		ExperimentIDs: []string{s.experimentID},
+		Columns:       []string{"run_id", "start_time", "metrics.metric1", "tags.tag1"},
+	}
+
+	s.Run("ExportCSV", func() {
+		req.Format = request.ExportFormatCSV
+		records, err := csv.NewReader(s.download(mlflow.RunsExportRoute, req)).ReadAll()
+		s.Require().Nil(err)
+		s.Equal([][]string{
+			{"run_id", "start_time", "metrics.metric1", "tags.tag1"},
+			{"id2", "2", "", "value1"},
+			{"id1", "1", "1.1", ""},
+		}, records)
+	})
+
+	s.Run("ExportParquet", func() {
+		req.Format = request.ExportFormatParquet
+		columns, rows, err := helpers.DecodeParquetTable(s.download(mlflow.RunsExportRoute, req).Bytes())
+		s.Require().Nil(err)
+		s.Equal(req.Columns, columns)
+		s.Equal([]map[string]any{
+			{"run_id": "id2", "start_time": int64(2), "metrics.metric1": nil, "tags.tag1": "value1"},
+			{"run_id": "id1", "start_time": int64(1), "metrics.metric1": 1.1, "tags.tag1": nil},
+		}, rows)
+	})
+
+	s.Run("ExportMetricsParquet", func() {
+		columns, rows, err := helpers.DecodeParquetTable(s.download(mlflow.RunsExportMetricsRoute, request.ExportRunsRequest{
+			ExperimentIDs: []string{s.experimentID},
+			Columns:       []string{"run_id", "key", "value"},
+			MetricKeys:    []string{"metric2"},
+			Format:        request.ExportFormatParquet,
+		}).Bytes())
+		s.Require().Nil(err)
+		s.Equal([]string{"run_id", "key", "value"}, columns)
+		s.Equal([]map[string]any{{"run_id": "id2", "key": "metric2", "value": 3.3}}, rows)
+	})
+}
+
+func (s *ExportTestSuite) Test_CustomNamespace_Ok() {
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		Code:                "custom",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+
+	// the runs of the experiment from the other namespace are not exported.
+	for _, route := range []string{mlflow.RunsExportRoute, mlflow.RunsExportMetricsRoute} {
+		resp := new(bytes.Buffer)
+		s.Require().Nil(
+			s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithNamespace(
+				namespace.Code,
+			).WithRequest(
+				request.ExportRunsRequest{ExperimentIDs: []string{s.experimentID}},
+			).WithResponseType(
+				helpers.ResponseTypeBuffer,
+			).WithResponse(
+				resp,
+			).DoRequest(
+				"%s%s", mlflow.RunsRoutePrefix, route,
+			),
+		)
+		_, rows, err := helpers.DecodeArrowTable(resp)
+		s.Require().Nil(err)
+		s.Empty(rows)
+	}
+}
+
+func (s *ExportTestSuite) Test_Error() {
+	tests := []struct {
+		name    string
+		route   string
+		request request.ExportRunsRequest
+		error   *api.ErrorResponse
+	}{
+		{
+			name:    "MissingExperimentIDs",
+			route:   mlflow.RunsExportRoute,
+			request: request.ExportRunsRequest{},
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'experiment_ids'"),
+		},
+		{
+			name:  "FilterAndQuery",
+			route: mlflow.RunsExportRoute,
+			request: request.ExportRunsRequest{
+				Filter: "tags.tag1 = 'value1'",
+				Query:  "run.archived",
+			},
+			error: api.NewInvalidParameterValueError("filter and query cannot both be specified at the same time"),
+		},
+		{
+			name:  "QueryAndViewType",
+			route: mlflow.RunsExportMetricsRoute,
+			request: request.ExportRunsRequest{
+				Query:    "run.archived",
+				ViewType: request.ViewTypeAll,
+			},
+			error: api.NewInvalidParameterValueError(
+				"run_view_type and query cannot both be specified at the same time",
+			),
+		},
+		{
+			name:    "IncorrectQuery",
+			route:   mlflow.RunsExportRoute,
+			request: request.ExportRunsRequest{Query: "run.name =="},
+			error: api.NewInvalidParameterValueError(
+				`invalid query 'run.name ==': syntax error at (1, 13) in "(run.name ==) and (not run.archived)": ` +
+					"invalid syntax",
+			),
+		},
+		{
+			name:  "IncorrectFormat",
+			route: mlflow.RunsExportRoute,
+			request: request.ExportRunsRequest{
+				ExperimentIDs: []string{s.experimentID},
+				Format:        "xlsx",
+			},
+			error: api.NewInvalidParameterValueError("Invalid format 'xlsx'"),
+		},
+		{
+			name:  "IncorrectViewType",
+			route: mlflow.RunsExportMetricsRoute,
+			request: request.ExportRunsRequest{
+				ExperimentIDs: []string{s.experimentID},
+				ViewType:      "unsupported_view_type",
+			},
+			error: api.NewInvalidParameterValueError("Invalid run_view_type 'unsupported_view_type'"),
+		},
+		{
+			name:  "IncorrectRunColumn",
+			route: mlflow.RunsExportRoute,
+			request: request.ExportRunsRequest{
+				ExperimentIDs: []string{s.experimentID},
+				Columns:       []string{"metrics."},
+			},
+			error: api.NewInvalidParameterValueError(
+				"invalid column 'metrics.'. Valid values are ['run_id', 'experiment_id', 'run_name', 'status', " +
+					"'user_id', 'start_time', 'end_time', 'lifecycle_stage', 'artifact_uri'] or 'metrics.<key>', " +
+					"'params.<key>', 'tags.<key>' where key could be '*' to export all the keys",
+			),
+		},
+		{
+			name:  "IncorrectMetricColumn",
+			route: mlflow.RunsExportMetricsRoute,
+			request: request.ExportRunsRequest{
+				ExperimentIDs: []string{s.experimentID},
+				Columns:       []string{"iter"},
+			},
+			error: api.NewInvalidParameterValueError(
+				"invalid column 'iter'. Valid values are ['run_id', 'key', 'step', 'timestamp', 'value', 'context']",
+			),
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, tt.route,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
+
+func (s *ExportTestSuite) export(route string, req request.ExportRunsRequest) ([]string, []map[string]any) {
+	columns, rows, err := helpers.DecodeArrowTable(s.download(route, req))
+	s.Require().Nil(err)
+	return columns, rows
+}
+
+func (s *ExportTestSuite) download(route string, req request.ExportRunsRequest) *bytes.Buffer {
+	resp := new(bytes.Buffer)
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			req,
+		).WithResponseType(
+			helpers.ResponseTypeBuffer,
+		).WithResponse(
+			resp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, route,
+		),
+	)
+	return resp
+}
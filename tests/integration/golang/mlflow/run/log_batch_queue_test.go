@@ -0,0 +1,129 @@
+package run
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type LogBatchQueueTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestLogBatchQueueTestSuite(t *testing.T) {
+	testSuite := new(LogBatchQueueTestSuite)
+	testSuite.Config = config.Config{
+		MetricQueueEnabled:       true,
+		MetricQueueSize:          5,
+		MetricQueueFlushSize:     5,
+		MetricQueueFlushInterval: 100 * time.Millisecond,
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *LogBatchQueueTestSuite) Test_Ok() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+
+	// metrics of several requests are coalesced and written together.
+	for step := int64(1); step <= 3; step++ {
+		resp := map[string]any{}
+		s.Require().Nil(
+			s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				&request.LogBatchRequest{
+					RunID: run.ID,
+					Metrics: []request.MetricPartialRequest{
+						{
+							Key:       "key1",
+							Value:     float64(step),
+							Timestamp: 1687325991,
+							Step:      step,
+						},
+					},
+				},
+			).WithResponse(
+				&resp,
+			).DoRequest(
+				"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute,
+			),
+		)
+		s.Empty(resp)
+	}
+
+	s.Eventually(func() bool {
+		metrics, err := s.MetricFixtures.GetMetricsByRunID(context.Background(), run.ID)
+		s.Require().Nil(err)
+		return len(metrics) == 3
+	}, 5*time.Second, 50*time.Millisecond)
+
+	latestMetric, err := s.MetricFixtures.GetLatestMetricByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Equal("key1", latestMetric.Key)
+	s.Equal(int64(3), latestMetric.Step)
+	s.Equal(3.0, latestMetric.Value)
+	s.Equal(int64(3), latestMetric.LastIter)
+}
+
+func (s *LogBatchQueueTestSuite) Test_Error() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+
+	metrics := make([]request.MetricPartialRequest, 6)
+	for i := range metrics {
+		metrics[i] = request.MetricPartialRequest{
+			Key:       "key1",
+			Value:     float64(i),
+			Timestamp: 1687325991,
+			Step:      int64(i),
+		}
+	}
+
+	resp := api.ErrorResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			&request.LogBatchRequest{
+				RunID:   run.ID,
+				Metrics: metrics,
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute,
+		),
+	)
+	s.Equal(api.ErrorCodeResourceExhausted, string(resp.ErrorCode))
+	s.Contains(resp.Error(), "metric ingestion queue is full")
+
+	// rejected metrics are not written.
+	storedMetrics, err := s.MetricFixtures.GetMetricsByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Empty(storedMetrics)
+}
@@ -387,6 +387,30 @@ func (s *LogBatchTestSuite) TestMetrics_Ok() {
 				return metrics
 			}(),
 		},
+		{
+			name: "LogManyDuplicateSameContext",
+			request: &request.LogBatchRequest{
+				RunID: run.ID,
+				Metrics: func() []request.MetricPartialRequest {
+					metrics := make([]request.MetricPartialRequest, 200)
+					for i := range metrics {
+						metrics[i] = request.MetricPartialRequest{
+							Key:       "manyduplicate",
+							Value:     1.0,
+							Timestamp: 1687325991,
+							Step:      1,
+						}
+					}
+					return metrics
+				}(),
+			},
+			latestMetricIteration: map[string]int64{
+				"manyduplicate": 200,
+			},
+			latestMetricKeyCount: map[string]int{
+				"manyduplicate": 1,
+			},
+		},
 		{
 			name: "LogNaNValue",
 			request: &request.LogBatchRequest{
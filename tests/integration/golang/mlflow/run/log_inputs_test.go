@@ -0,0 +1,278 @@
+package run
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type LogInputsTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestLogInputsTestSuite(t *testing.T) {
+	suite.Run(t, new(LogInputsTestSuite))
+}
+
+func (s *LogInputsTestSuite) Test_Ok() {
+	run1, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+	run2, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+
+	trainingDataset := request.DatasetInputPartialRequest{
+		Tags: []request.InputTagPartialRequest{
+			{
+				Key:   models.InputTagDatasetContext,
+				Value: "training",
+			},
+		},
+		Dataset: request.DatasetPartialRequest{
+			Name:       "dataset1",
+			Digest:     "digest1",
+			SourceType: "local",
+			Source:     `{"uri": "/data/dataset1.csv"}`,
+			Schema:     `{"mlflow_colspec": [{"type": "double", "name": "x"}]}`,
+			Profile:    `{"num_rows": 10}`,
+		},
+	}
+	evalDataset := request.DatasetInputPartialRequest{
+		Dataset: request.DatasetPartialRequest{
+			Name:       "dataset2",
+			Digest:     "digest2",
+			SourceType: "http",
+			Source:     `{"url": "https://example.com/dataset2.csv"}`,
+		},
+	}
+
+	// log the datasets into the first run, logging them twice keeps the same inputs.
+	for i := 0; i < 2; i++ {
+		resp := map[string]any{}
+		s.Require().Nil(
+			s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				request.LogInputsRequest{
+					RunID:    run1.ID,
+					Datasets: []request.DatasetInputPartialRequest{trainingDataset, evalDataset},
+				},
+			).WithResponse(
+				&resp,
+			).DoRequest(
+				"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogInputsRoute,
+			),
+		)
+		s.Empty(resp)
+	}
+
+	// log the same dataset into the second run, the dataset is reused.
+	resp := map[string]any{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.LogInputsRequest{
+				RunID:    run2.ID,
+				Datasets: []request.DatasetInputPartialRequest{trainingDataset},
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogInputsRoute,
+		),
+	)
+	s.Empty(resp)
+
+	datasets, err := s.InputFixtures.GetDatasetsByExperimentID(context.Background(), *s.DefaultExperiment.ID)
+	s.Require().Nil(err)
+	s.Require().Equal(2, len(datasets))
+	s.Equal("dataset1", datasets[0].Name)
+	s.Equal("digest1", datasets[0].Digest)
+	s.Equal("local", datasets[0].SourceType)
+	s.Equal(`{"uri": "/data/dataset1.csv"}`, datasets[0].Source)
+	s.Equal(`{"num_rows": 10}`, datasets[0].Profile)
+	s.Equal("dataset2", datasets[1].Name)
+	s.Equal("", datasets[1].Schema)
+
+	inputs, err := s.InputFixtures.GetByRunID(context.Background(), run1.ID)
+	s.Require().Nil(err)
+	s.Equal(2, len(inputs))
+	inputs, err = s.InputFixtures.GetByRunID(context.Background(), run2.ID)
+	s.Require().Nil(err)
+	s.Require().Equal(1, len(inputs))
+	s.Equal(datasets[0].ID, inputs[0].SourceID)
+	s.Equal([]models.InputTag{
+		{
+			InputID: inputs[0].ID,
+			Key:     models.InputTagDatasetContext,
+			Value:   "training",
+		},
+	}, inputs[0].Tags)
+
+	// the inputs are returned together with the run.
+	getResp := response.GetRunResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithQuery(
+			request.GetRunRequest{RunID: run2.ID},
+		).WithResponse(
+			&getResp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsGetRoute,
+		),
+	)
+	s.Equal(response.RunInputsPartialResponse{
+		DatasetInputs: []response.DatasetInputPartialResponse{
+			{
+				Tags: []response.InputTagPartialResponse{
+					{
+						Key:   models.InputTagDatasetContext,
+						Value: "training",
+					},
+				},
+				Dataset: response.DatasetPartialResponse{
+					Name:       "dataset1",
+					Digest:     "digest1",
+					SourceType: "local",
+					Source:     `{"uri": "/data/dataset1.csv"}`,
+					Schema:     `{"mlflow_colspec": [{"type": "double", "name": "x"}]}`,
+					Profile:    `{"num_rows": 10}`,
+				},
+			},
+		},
+	}, getResp.Run.Inputs)
+}
+
+func (s *LogInputsTestSuite) Test_Error() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name    string
+		request request.LogInputsRequest
+		error   *api.ErrorResponse
+	}{
+		{
+			name:    "MissingRunID",
+			request: request.LogInputsRequest{},
+			error: api.NewInvalidParameterValueError(
+				"Missing value for required parameter 'run_id'",
+			),
+		},
+		{
+			name: "MissingDatasetDigest",
+			request: request.LogInputsRequest{
+				RunID: run.ID,
+				Datasets: []request.DatasetInputPartialRequest{
+					{
+						Dataset: request.DatasetPartialRequest{
+							Name:       "dataset",
+							SourceType: "local",
+							Source:     "source",
+						},
+					},
+				},
+			},
+			error: api.NewInvalidParameterValueError(
+				"Invalid value for parameter 'datasets' supplied",
+			),
+		},
+		{
+			name: "TooLongDatasetDigest",
+			request: request.LogInputsRequest{
+				RunID: run.ID,
+				Datasets: []request.DatasetInputPartialRequest{
+					{
+						Dataset: request.DatasetPartialRequest{
+							Name:       "dataset",
+							Digest:     strings.Repeat("d", 37),
+							SourceType: "local",
+							Source:     "source",
+						},
+					},
+				},
+			},
+			error: api.NewInvalidParameterValueError(
+				"Invalid value for parameter 'datasets' supplied",
+			),
+		},
+		{
+			name: "MissingTagKey",
+			request: request.LogInputsRequest{
+				RunID: run.ID,
+				Datasets: []request.DatasetInputPartialRequest{
+					{
+						Tags: []request.InputTagPartialRequest{
+							{
+								Value: "training",
+							},
+						},
+						Dataset: request.DatasetPartialRequest{
+							Name:       "dataset",
+							Digest:     "digest",
+							SourceType: "local",
+							Source:     "source",
+						},
+					},
+				},
+			},
+			error: api.NewInvalidParameterValueError(
+				"Invalid value for parameter 'tags' supplied",
+			),
+		},
+		{
+			name: "NotFoundRun",
+			request: request.LogInputsRequest{
+				RunID: "not-found",
+			},
+			error: api.NewResourceDoesNotExistError(
+				"Run 'not-found' not found",
+			),
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogInputsRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type LogSequenceTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestLogSequenceTestSuite(t *testing.T) {
+	suite.Run(t, new(LogSequenceTestSuite))
+}
+
+func (s *LogSequenceTestSuite) Test_Ok() {
+	run, err := s.RunFixtures.CreateExampleRun(context.Background(), s.DefaultExperiment)
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name         string
+		route        string
+		artifactType string
+		requestBody  any
+		check        func(artifact models.Artifact)
+	}{
+		{
+			name:         "LogText",
+			route:        mlflow.RunsLogTextRoute,
+			artifactType: models.ArtifactTypeTexts,
+			requestBody: request.LogTextRequest{
+				RunID: run.ID,
+				Name:  "prompts",
+				Step:  2,
+				Index: 1,
+				Text:  "some text",
+			},
+			check: func(artifact models.Artifact) {
+				s.Equal(int64(2), artifact.Step)
+				s.Equal(int64(1), artifact.Index)
+				s.JSONEq(`{"text": "some text"}`, string(artifact.Data))
+			},
+		},
+		{
+			name:         "LogAudio",
+			route:        mlflow.RunsLogAudioRoute,
+			artifactType: models.ArtifactTypeAudios,
+			requestBody: request.LogAudioRequest{
+				RunID:   run.ID,
+				Name:    "samples",
+				Step:    1,
+				Caption: "caption",
+				Format:  "wav",
+				BlobURI: "audios/sample.wav",
+			},
+			check: func(artifact models.Artifact) {
+				s.Equal(int64(1), artifact.Step)
+				s.Equal("caption", artifact.Caption)
+				s.Equal("wav", artifact.Format)
+				s.Equal("audios/sample.wav", artifact.BlobURI)
+			},
+		},
+		{
+			name:         "LogDistribution",
+			route:        mlflow.RunsLogDistributionRoute,
+			artifactType: models.ArtifactTypeDistributions,
+			requestBody: request.LogDistributionRequest{
+				RunID:    run.ID,
+				Name:     "weights",
+				Step:     3,
+				Weights:  []float64{1, 5, 2},
+				BinRange: [2]float64{-1.5, 1.5},
+			},
+			check: func(artifact models.Artifact) {
+				s.Equal(int64(3), artifact.Step)
+				s.JSONEq(`{"weights": [1, 5, 2], "bin_range": [-1.5, 1.5]}`, string(artifact.Data))
+			},
+		},
+		{
+			name:         "LogFigure",
+			route:        mlflow.RunsLogFigureRoute,
+			artifactType: models.ArtifactTypeFigures,
+			requestBody: request.LogFigureRequest{
+				RunID:  run.ID,
+				Name:   "chart",
+				Figure: json.RawMessage(`{"data": [{"type": "bar", "y": [1, 2]}], "layout": {}}`),
+			},
+			check: func(artifact models.Artifact) {
+				s.Equal(int64(0), artifact.Step)
+				s.JSONEq(`{"data": [{"type": "bar", "y": [1, 2]}], "layout": {}}`, string(artifact.Data))
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.requestBody,
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, tt.route,
+				),
+			)
+
+			artifacts, err := s.ArtifactFixtures.GetArtifactsByRunIDAndType(
+				context.Background(), run.ID, tt.artifactType,
+			)
+			s.Require().Nil(err)
+			s.Require().Len(artifacts, 1)
+			s.Equal(int64(1), artifacts[0].Iter)
+			tt.check(artifacts[0])
+		})
+	}
+}
+
+func (s *LogSequenceTestSuite) Test_Error() {
+	run, err := s.RunFixtures.CreateExampleRun(context.Background(), s.DefaultExperiment)
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name        string
+		route       string
+		requestBody any
+		error       *api.ErrorResponse
+	}{
+		{
+			name:        "LogTextWithEmptyName",
+			route:       mlflow.RunsLogTextRoute,
+			requestBody: request.LogTextRequest{RunID: run.ID},
+			error:       api.NewInvalidParameterValueError("Missing value for required parameter 'name'"),
+		},
+		{
+			name:        "LogAudioWithNotFoundRun",
+			route:       mlflow.RunsLogAudioRoute,
+			requestBody: request.LogAudioRequest{RunID: "not-found", Name: "samples", BlobURI: "sample.wav"},
+			error:       api.NewResourceDoesNotExistError("unable to find run 'not-found'"),
+		},
+		{
+			name:  "LogDistributionWithIncorrectBinRange",
+			route: mlflow.RunsLogDistributionRoute,
+			requestBody: request.LogDistributionRequest{
+				RunID: run.ID, Name: "weights", Weights: []float64{1}, BinRange: [2]float64{1, 0},
+			},
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'bin_range' supplied"),
+		},
+		{
+			name:  "LogFigureWithIncorrectFigure",
+			route: mlflow.RunsLogFigureRoute,
+			requestBody: request.LogFigureRequest{
+				RunID: run.ID, Name: "chart", Figure: json.RawMessage(`"figure"`),
+			},
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'figure' supplied"),
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.requestBody,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, tt.route,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
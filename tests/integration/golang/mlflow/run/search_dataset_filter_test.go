@@ -0,0 +1,197 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type SearchDatasetFilterTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSearchDatasetFilterTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchDatasetFilterTestSuite))
+}
+
+func (s *SearchDatasetFilterTestSuite) Test_Ok() {
+	// create 3 runs, the first one uses both datasets, the second one only the evaluation dataset.
+	runs := make([]*models.Run, 3)
+	for n := range runs {
+		run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             fmt.Sprintf("id%d", n+1),
+			Name:           fmt.Sprintf("TestRun%d", n+1),
+			ExperimentID:   *s.DefaultExperiment.ID,
+			SourceType:     "JOB",
+			LifecycleStage: models.LifecycleStageActive,
+			Status:         models.StatusRunning,
+		})
+		s.Require().Nil(err)
+		runs[n] = run
+	}
+
+	trainingDataset, err := s.InputFixtures.CreateDataset(context.Background(), &models.Dataset{
+		ID:           "training",
+		ExperimentID: *s.DefaultExperiment.ID,
+		Name:         "Training",
+		Digest:       "digest1",
+		SourceType:   "local",
+		Source:       "source1",
+	})
+	s.Require().Nil(err)
+	evalDataset, err := s.InputFixtures.CreateDataset(context.Background(), &models.Dataset{
+		ID:           "eval",
+		ExperimentID: *s.DefaultExperiment.ID,
+		Name:         "Eval",
+		Digest:       "digest2",
+		SourceType:   "local",
+		Source:       "source2",
+	})
+	s.Require().Nil(err)
+
+	for _, input := range []struct {
+		run     *models.Run
+		dataset *models.Dataset
+		context string
+	}{
+		{run: runs[0], dataset: trainingDataset, context: "training"},
+		{run: runs[0], dataset: evalDataset, context: "eval"},
+		{run: runs[1], dataset: evalDataset, context: "eval"},
+	} {
+		id := fmt.Sprintf("%s-%s", input.run.ID, input.dataset.ID)
+		_, err := s.InputFixtures.CreateInput(context.Background(), &models.Input{
+			ID:              id,
+			SourceType:      models.InputSourceTypeDataset,
+			SourceID:        input.dataset.ID,
+			DestinationType: models.InputDestinationTypeRun,
+			DestinationID:   input.run.ID,
+			Tags: []models.InputTag{
+				{
+					InputID: id,
+					Key:     models.InputTagDatasetContext,
+					Value:   input.context,
+				},
+			},
+		})
+		s.Require().Nil(err)
+	}
+
+	tests := []struct {
+		name   string
+		filter string
+		runs   []string
+	}{
+		{
+			name:   "DatasetNameEqual",
+			filter: `dataset.name = 'Training'`,
+			runs:   []string{"id1"},
+		},
+		{
+			name:   "DatasetNameNotEqual",
+			filter: `dataset.name != 'Training'`,
+			runs:   []string{"id1", "id2"},
+		},
+		{
+			name:   "DatasetNameILike",
+			filter: `datasets.name ILIKE 'ev%'`,
+			runs:   []string{"id1", "id2"},
+		},
+		{
+			name:   "DatasetDigestIn",
+			filter: `dataset.digest IN ('digest1', 'digest3')`,
+			runs:   []string{"id1"},
+		},
+		{
+			name:   "DatasetContextEqual",
+			filter: `dataset.context = 'eval'`,
+			runs:   []string{"id1", "id2"},
+		},
+		{
+			name:   "DatasetNameAndContext",
+			filter: `dataset.name = 'Eval' AND dataset.context = 'training'`,
+			runs:   []string{"id1"},
+		},
+		{
+			name:   "DatasetNameWithoutMatches",
+			filter: `dataset.name = 'Unknown'`,
+			runs:   nil,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := response.SearchRunsResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					request.SearchRunsRequest{
+						ExperimentIDs: []string{fmt.Sprintf("%d", *s.DefaultExperiment.ID)},
+						Filter:        tt.filter,
+					},
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSearchRoute,
+				),
+			)
+			var runIDs []string
+			for _, run := range resp.Runs {
+				runIDs = append(runIDs, run.Info.ID)
+			}
+			s.Equal(tt.runs, runIDs)
+		})
+	}
+}
+
+func (s *SearchDatasetFilterTestSuite) Test_Error() {
+	tests := []struct {
+		name   string
+		filter string
+		error  *api.ErrorResponse
+	}{
+		{
+			name:   "InvalidDatasetAttribute",
+			filter: `dataset.source = 'source'`,
+			error: api.NewInvalidParameterValueError(
+				"invalid dataset attribute 'source'. Valid values are ['name', 'digest', 'context']",
+			),
+		},
+		{
+			name:   "InvalidDatasetComparisonOperator",
+			filter: `dataset.name > 'Training'`,
+			error: api.NewInvalidParameterValueError(
+				"invalid dataset comparison operator '>'",
+			),
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					request.SearchRunsRequest{
+						ExperimentIDs: []string{fmt.Sprintf("%d", *s.DefaultExperiment.ID)},
+						Filter:        tt.filter,
+					},
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSearchRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type SearchFilterSyntaxTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSearchFilterSyntaxTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchFilterSyntaxTestSuite))
+}
+
+func (s *SearchFilterSyntaxTestSuite) Test_Ok() {
+	// create 3 runs with tags and params, which values and keys were problematic for the regexp based filter.
+	for n, note := range []string{"this and that", "it's here", "a,b"} {
+		run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             fmt.Sprintf("id%d", n+1),
+			Name:           fmt.Sprintf("TestRun%d", n+1),
+			ExperimentID:   *s.DefaultExperiment.ID,
+			SourceType:     "JOB",
+			LifecycleStage: models.LifecycleStageActive,
+			Status:         models.StatusRunning,
+		})
+		s.Require().Nil(err)
+
+		for _, tag := range []models.Tag{
+			{Key: "note", Value: note},
+			{Key: "mlflow.runName", Value: run.Name},
+		} {
+			tag.RunID = run.ID
+			_, err = s.TagFixtures.CreateTag(context.Background(), &tag)
+			s.Require().Nil(err)
+		}
+
+		value := fmt.Sprintf("value%d", n+1)
+		_, err = s.ParamFixtures.CreateParam(context.Background(), &models.Param{
+			Key:      "my param",
+			ValueStr: &value,
+			RunID:    run.ID,
+		})
+		s.Require().Nil(err)
+	}
+
+	tests := []struct {
+		name   string
+		filter string
+		runs   []string
+	}{
+		{
+			name:   "ValueContainingAnd",
+			filter: `tags.note = 'this and that'`,
+			runs:   []string{"id1"},
+		},
+		{
+			name:   "ValueContainingEscapedQuote",
+			filter: `tags.note = 'it''s here'`,
+			runs:   []string{"id2"},
+		},
+		{
+			name:   "ValueContainingComma",
+			filter: `tags.note LIKE '%,%' AND attributes.run_id IN ('id1', 'id3')`,
+			runs:   []string{"id3"},
+		},
+		{
+			name:   "BacktickEscapedTagKey",
+			filter: "tags.`mlflow.runName` = 'TestRun2'",
+			runs:   []string{"id2"},
+		},
+		{
+			name:   "QuotedParamKeyWithSpaces",
+			filter: `params."my param" != 'value2' AND run_id NOT IN ('id3')`,
+			runs:   []string{"id1"},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := response.SearchRunsResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					request.SearchRunsRequest{
+						ExperimentIDs: []string{fmt.Sprintf("%d", *s.DefaultExperiment.ID)},
+						Filter:        tt.filter,
+					},
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSearchRoute,
+				),
+			)
+			var runIDs []string
+			for _, run := range resp.Runs {
+				runIDs = append(runIDs, run.Info.ID)
+			}
+			s.Equal(tt.runs, runIDs)
+		})
+	}
+}
+
+func (s *SearchFilterSyntaxTestSuite) Test_Error() {
+	tests := []struct {
+		name   string
+		filter string
+		error  *api.ErrorResponse
+	}{
+		{
+			name:   "UnescapedQuote",
+			filter: `tags.note = 'it's here'`,
+			error: api.NewInvalidParameterValueError(
+				"malformed filter 'tags.note = 'it's here'': " +
+					"expected AND, OR or end of filter but found 's' at position 17",
+			),
+		},
+		{
+			name:   "OrIsNotSupported",
+			filter: `tags.note = 'a' OR tags.note = 'b'`,
+			error: api.NewInvalidParameterValueError(
+				"malformed filter 'tags.note = 'a' OR tags.note = 'b'': " +
+					"OR operator is not supported at position 17",
+			),
+		},
+		{
+			name:   "IsNullIsNotSupported",
+			filter: `tags.note IS NULL`,
+			error:  api.NewInvalidParameterValueError("invalid tag comparison operator 'IS NULL'"),
+		},
+		{
+			name:   "InvalidListDefinition",
+			filter: `attributes.run_id IN 'id1'`,
+			error:  api.NewInvalidParameterValueError("invalid list definition ''id1''"),
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					request.SearchRunsRequest{
+						ExperimentIDs: []string{fmt.Sprintf("%d", *s.DefaultExperiment.ID)},
+						Filter:        tt.filter,
+					},
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSearchRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
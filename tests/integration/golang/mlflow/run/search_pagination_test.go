@@ -0,0 +1,212 @@
+package run
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type SearchPaginationTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSearchPaginationTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchPaginationTestSuite))
+}
+
+func (s *SearchPaginationTestSuite) Test_Ok() {
+	// create 5 runs. id2 and id3 have the same start time, so they are ordered by id.
+	// id1 and id4 don't have the tag, so they go after the other runs ordering by it.
+	for n, startTime := range []int64{1, 2, 2, 3, 4} {
+		run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             fmt.Sprintf("id%d", n+1),
+			Name:           fmt.Sprintf("TestRun%d", n+1),
+			ExperimentID:   *s.DefaultExperiment.ID,
+			SourceType:     "JOB",
+			LifecycleStage: models.LifecycleStageActive,
+			Status:         models.StatusRunning,
+			StartTime:      sql.NullInt64{Int64: startTime, Valid: true},
+		})
+		s.Require().Nil(err)
+		if n != 0 && n != 3 {
+			_, err = s.TagFixtures.CreateTag(context.Background(), &models.Tag{
+				Key:   "order",
+				Value: fmt.Sprintf("%d", 10-n),
+				RunID: run.ID,
+			})
+			s.Require().Nil(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		orderBy []string
+		runs    []string
+	}{
+		{
+			name: "DefaultOrder",
+			runs: []string{"id5", "id4", "id2", "id3", "id1"},
+		},
+		{
+			name:    "OrderByStartTimeAsc",
+			orderBy: []string{"attribute.start_time ASC"},
+			runs:    []string{"id1", "id2", "id3", "id4", "id5"},
+		},
+		{
+			name:    "OrderByTagWithMissingValues",
+			orderBy: []string{"tag.order ASC"},
+			runs:    []string{"id5", "id3", "id2", "id4", "id1"},
+		},
+		{
+			name:    "OrderByTagWithMissingValuesDesc",
+			orderBy: []string{"tag.order DESC"},
+			runs:    []string{"id2", "id3", "id5", "id4", "id1"},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var runIDs []string
+			pageToken := ""
+			for {
+				resp := s.searchRuns(request.SearchRunsRequest{
+					OrderBy:    tt.orderBy,
+					MaxResults: 2,
+					PageToken:  pageToken,
+				})
+				for _, run := range resp.Runs {
+					runIDs = append(runIDs, run.Info.ID)
+				}
+				if resp.NextPageToken == "" {
+					break
+				}
+				pageToken = resp.NextPageToken
+			}
+			s.Equal(tt.runs, runIDs)
+		})
+	}
+}
+
+func (s *SearchPaginationTestSuite) Test_ConcurrentInsert_Ok() {
+	for n := 0; n < 4; n++ {
+		_, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             fmt.Sprintf("id%d", n+1),
+			Name:           fmt.Sprintf("TestRun%d", n+1),
+			ExperimentID:   *s.DefaultExperiment.ID,
+			SourceType:     "JOB",
+			LifecycleStage: models.LifecycleStageActive,
+			Status:         models.StatusRunning,
+			StartTime:      sql.NullInt64{Int64: int64(n + 1), Valid: true},
+		})
+		s.Require().Nil(err)
+	}
+
+	resp := s.searchRuns(request.SearchRunsRequest{MaxResults: 2})
+	s.Require().Len(resp.Runs, 2)
+	s.Equal("id4", resp.Runs[0].Info.ID)
+	s.Equal("id3", resp.Runs[1].Info.ID)
+	s.Require().NotEmpty(resp.NextPageToken)
+
+	// the run created between the requests of the pages doesn't shift the next page.
+	_, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             "id5",
+		Name:           "TestRun5",
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+		StartTime:      sql.NullInt64{Int64: 5, Valid: true},
+	})
+	s.Require().Nil(err)
+
+	resp = s.searchRuns(request.SearchRunsRequest{MaxResults: 2, PageToken: resp.NextPageToken})
+	s.Require().Len(resp.Runs, 2)
+	s.Equal("id2", resp.Runs[0].Info.ID)
+	s.Equal("id1", resp.Runs[1].Info.ID)
+	s.Empty(resp.NextPageToken)
+}
+
+func (s *SearchPaginationTestSuite) Test_OffsetPageToken_Ok() {
+	for n := 0; n < 3; n++ {
+		_, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             fmt.Sprintf("id%d", n+1),
+			Name:           fmt.Sprintf("TestRun%d", n+1),
+			ExperimentID:   *s.DefaultExperiment.ID,
+			SourceType:     "JOB",
+			LifecycleStage: models.LifecycleStageActive,
+			Status:         models.StatusRunning,
+			StartTime:      sql.NullInt64{Int64: int64(n + 1), Valid: true},
+		})
+		s.Require().Nil(err)
+	}
+
+	// page tokens issued by the previous versions contain the offset.
+	var pageToken strings.Builder
+	encoder := base64.NewEncoder(base64.StdEncoding, &pageToken)
+	s.Require().Nil(json.NewEncoder(encoder).Encode(request.PageToken{Offset: 1}))
+	s.Require().Nil(encoder.Close())
+	resp := s.searchRuns(request.SearchRunsRequest{PageToken: pageToken.String()})
+	s.Require().Len(resp.Runs, 2)
+	s.Equal("id2", resp.Runs[0].Info.ID)
+	s.Equal("id1", resp.Runs[1].Info.ID)
+	s.Empty(resp.NextPageToken)
+}
+
+func (s *SearchPaginationTestSuite) Test_Error() {
+	var pageToken strings.Builder
+	encoder := base64.NewEncoder(base64.StdEncoding, &pageToken)
+	s.Require().Nil(json.NewEncoder(encoder).Encode(request.PageToken{Keys: []request.PageTokenKey{{}}}))
+	s.Require().Nil(encoder.Close())
+
+	resp := api.ErrorResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.SearchRunsRequest{
+				ExperimentIDs: []string{fmt.Sprintf("%d", *s.DefaultExperiment.ID)},
+				PageToken:     pageToken.String(),
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSearchRoute,
+		),
+	)
+	s.Equal(
+		api.NewInvalidParameterValueError(
+			"invalid page_token '%s': expected 2 keys, got 1", pageToken.String(),
+		).Error(),
+		resp.Error(),
+	)
+}
+
+func (s *SearchPaginationTestSuite) searchRuns(req request.SearchRunsRequest) *response.SearchRunsResponse {
+	req.ExperimentIDs = []string{fmt.Sprintf("%d", *s.DefaultExperiment.ID)}
+	resp := response.SearchRunsResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			req,
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSearchRoute,
+		),
+	)
+	return &resp
+}
@@ -0,0 +1,310 @@
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/api/request"
+	"github.com/G-Research/fasttrackml/pkg/common/api/response"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type ProxyLocalTestSuite struct {
+	helpers.BaseTestSuite
+	destination string
+}
+
+func TestProxyLocalTestSuite(t *testing.T) {
+	testSuite := new(ProxyLocalTestSuite)
+	testSuite.destination = t.TempDir()
+	testSuite.Config = config.Config{
+		ServeArtifacts:       true,
+		ArtifactsDestination: "file://" + testSuite.destination,
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *ProxyLocalTestSuite) Test_Ok() {
+	// 1. upload artifacts through the proxy.
+	resp := map[string]any{}
+	s.Require().Nil(
+		s.MlflowArtifactsClient().WithMethod(
+			http.MethodPut,
+		).WithRequest(
+			strings.NewReader("contentX"),
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s/1/run/artifacts/artifact.file1", mlflow.MlflowArtifactsArtifactsRoutePrefix,
+		),
+	)
+	s.Equal(map[string]any{}, resp)
+	s.Require().Nil(
+		s.MlflowArtifactsClient().WithMethod(
+			http.MethodPut,
+		).WithRequest(
+			strings.NewReader("contentXX"),
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s/1/run/artifacts/artifact.dir/artifact.file2", mlflow.MlflowArtifactsArtifactsRoutePrefix,
+		),
+	)
+
+	// #nosec G304
+	content, err := os.ReadFile(filepath.Join(s.destination, "default", "1", "run", "artifacts", "artifact.file1"))
+	s.Require().Nil(err)
+	s.Equal("contentX", string(content))
+
+	// 2. list artifacts through the proxy.
+	listResp := response.ListProxiedArtifactsResponse{}
+	s.Require().Nil(
+		s.MlflowArtifactsClient().WithQuery(
+			request.ListProxiedArtifactsRequest{
+				Path: "1/run/artifacts",
+			},
+		).WithResponse(
+			&listResp,
+		).DoRequest(
+			"%s", mlflow.MlflowArtifactsArtifactsRoutePrefix,
+		),
+	)
+	s.Equal([]response.FilePartialResponse{
+		{
+			Path:  "artifact.dir",
+			IsDir: true,
+		},
+		{
+			Path:     "artifact.file1",
+			FileSize: 8,
+		},
+	}, listResp.Files)
+
+	// 3. download artifact through the proxy.
+	buffer := bytes.Buffer{}
+	s.Require().Nil(
+		s.MlflowArtifactsClient().WithResponseType(
+			helpers.ResponseTypeBuffer,
+		).WithResponse(
+			&buffer,
+		).DoRequest(
+			"%s/1/run/artifacts/artifact.dir/artifact.file2", mlflow.MlflowArtifactsArtifactsRoutePrefix,
+		),
+	)
+	s.Equal("contentXX", buffer.String())
+
+	// 4. delete artifact directory through the proxy.
+	s.Require().Nil(
+		s.MlflowArtifactsClient().WithMethod(
+			http.MethodDelete,
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s/1/run/artifacts/artifact.dir", mlflow.MlflowArtifactsArtifactsRoutePrefix,
+		),
+	)
+	_, err = os.Stat(filepath.Join(s.destination, "default", "1", "run", "artifacts", "artifact.dir"))
+	s.True(os.IsNotExist(err))
+}
+
+func (s *ProxyLocalTestSuite) Test_Namespaces_Ok() {
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		Code:                "custom",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+
+	// 1. upload the artifacts with the same path into both namespaces.
+	resp := map[string]any{}
+	s.Require().Nil(
+		s.MlflowArtifactsClient().WithMethod(
+			http.MethodPut,
+		).WithRequest(
+			strings.NewReader("default"),
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s/2/run/artifacts/artifact.file", mlflow.MlflowArtifactsArtifactsRoutePrefix,
+		),
+	)
+	s.Require().Nil(
+		s.MlflowArtifactsClient().WithNamespace(
+			namespace.Code,
+		).WithMethod(
+			http.MethodPut,
+		).WithRequest(
+			strings.NewReader("custom"),
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s/2/run/artifacts/artifact.file", mlflow.MlflowArtifactsArtifactsRoutePrefix,
+		),
+	)
+	s.Require().Nil(
+		s.MlflowArtifactsClient().WithMethod(
+			http.MethodPut,
+		).WithRequest(
+			strings.NewReader("default only"),
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s/2/run/artifacts/default.file", mlflow.MlflowArtifactsArtifactsRoutePrefix,
+		),
+	)
+
+	// #nosec G304
+	content, err := os.ReadFile(filepath.Join(s.destination, "custom", "2", "run", "artifacts", "artifact.file"))
+	s.Require().Nil(err)
+	s.Equal("custom", string(content))
+
+	// 2. the namespace lists and downloads only its own artifacts.
+	listResp := response.ListProxiedArtifactsResponse{}
+	s.Require().Nil(
+		s.MlflowArtifactsClient().WithNamespace(
+			namespace.Code,
+		).WithQuery(
+			request.ListProxiedArtifactsRequest{
+				Path: "2/run/artifacts",
+			},
+		).WithResponse(
+			&listResp,
+		).DoRequest(
+			"%s", mlflow.MlflowArtifactsArtifactsRoutePrefix,
+		),
+	)
+	s.Equal([]response.FilePartialResponse{
+		{
+			Path:     "artifact.file",
+			FileSize: 6,
+		},
+	}, listResp.Files)
+
+	buffer := bytes.Buffer{}
+	s.Require().Nil(
+		s.MlflowArtifactsClient().WithNamespace(
+			namespace.Code,
+		).WithResponseType(
+			helpers.ResponseTypeBuffer,
+		).WithResponse(
+			&buffer,
+		).DoRequest(
+			"%s/2/run/artifacts/artifact.file", mlflow.MlflowArtifactsArtifactsRoutePrefix,
+		),
+	)
+	s.Equal("custom", buffer.String())
+
+	errResp := api.ErrorResponse{}
+	s.Require().Nil(
+		s.MlflowArtifactsClient().WithNamespace(
+			namespace.Code,
+		).WithResponse(
+			&errResp,
+		).DoRequest(
+			"%s/2/run/artifacts/default.file", mlflow.MlflowArtifactsArtifactsRoutePrefix,
+		),
+	)
+	s.Equal(http.StatusNotFound, errResp.StatusCode)
+
+	// 3. deleting the artifacts of the namespace keeps the artifacts of the other namespace.
+	s.Require().Nil(
+		s.MlflowArtifactsClient().WithNamespace(
+			namespace.Code,
+		).WithMethod(
+			http.MethodDelete,
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s/2/run/artifacts", mlflow.MlflowArtifactsArtifactsRoutePrefix,
+		),
+	)
+	_, err = os.Stat(filepath.Join(s.destination, "custom", "2", "run", "artifacts"))
+	s.True(os.IsNotExist(err))
+
+	buffer.Reset()
+	s.Require().Nil(
+		s.MlflowArtifactsClient().WithResponseType(
+			helpers.ResponseTypeBuffer,
+		).WithResponse(
+			&buffer,
+		).DoRequest(
+			"%s/2/run/artifacts/artifact.file", mlflow.MlflowArtifactsArtifactsRoutePrefix,
+		),
+	)
+	s.Equal("default", buffer.String())
+}
+
+func (s *ProxyLocalTestSuite) Test_Error() {
+	tests := []struct {
+		name       string
+		method     string
+		route      string
+		request    any
+		error      *api.ErrorResponse
+		statusCode int
+	}{
+		{
+			name:   "DownloadNotExistingArtifact",
+			method: http.MethodGet,
+			route:  mlflow.MlflowArtifactsArtifactsRoutePrefix + "/1/run/artifacts/not-existing",
+			error: api.NewResourceDoesNotExistError(
+				"error getting artifact object '1/run/artifacts/not-existing'",
+			),
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:   "DeleteNotExistingArtifact",
+			method: http.MethodDelete,
+			route:  mlflow.MlflowArtifactsArtifactsRoutePrefix + "/1/run/artifacts/not-existing",
+			error: api.NewResourceDoesNotExistError(
+				"error deleting artifact object '1/run/artifacts/not-existing'",
+			),
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "ListWithIncorrectPath",
+			method:     http.MethodGet,
+			route:      mlflow.MlflowArtifactsArtifactsRoutePrefix + "?path=../",
+			error:      api.NewInvalidParameterValueError("Invalid path"),
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "CreateMultipartUploadIsNotSupported",
+			method: http.MethodPost,
+			route:  mlflow.MlflowArtifactsMpuRoutePrefix + "/create/1/run/artifacts",
+			request: request.CreateMultipartUploadRequest{
+				Path:     "/local/artifact.file",
+				NumParts: 2,
+			},
+			error: api.NewNotImplementedError(
+				"error creating multipart upload for '1/run/artifacts/artifact.file': " +
+					"multipart upload is not supported by the artifact storage",
+			),
+			statusCode: http.StatusNotImplemented,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			client := s.MlflowArtifactsClient().WithMethod(tt.method).WithResponse(&resp)
+			if tt.request != nil {
+				client = client.WithRequest(tt.request)
+			}
+			s.Require().Nil(client.DoRequest("%s", tt.route))
+			s.Equal(tt.error.Error(), resp.Error())
+			s.Equal(tt.statusCode, resp.StatusCode)
+		})
+	}
+}